@@ -39,8 +39,8 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/util/configz"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
-	"github.com/kubernetes-sigs/service-catalog/pkg/metrics/osbclientproxy"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/server/healthz"
@@ -50,9 +50,11 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/cmd/controller-manager/app/options"
 	servicecatalogv1beta1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	settingsv1alpha1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
 	servicecataloginformers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	"github.com/kubernetes-sigs/service-catalog/pkg/probe"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
 	"context"
@@ -82,8 +84,18 @@ the core control loops shipped with the service catalog.`,
 const controllerManagerAgentName = "service-catalog-controller-manager"
 const controllerDiscoveryAgentName = "service-catalog-controller-discovery"
 
+// The names of the clients in the client_rate_limiter_wait_count metric.
+const (
+	kubernetesClientName     = "kubernetes"
+	serviceCatalogClientName = "service-catalog"
+)
+
 // Run runs the service-catalog controller-manager; should never exit.
 func Run(controllerManagerOptions *options.ControllerManagerServer) error {
+	if err := controllerManagerOptions.Validate(); err != nil {
+		return err
+	}
+
 	// TODO: what does this do
 
 	// if c, err := configz.New("componentconfig"); err == nil {
@@ -118,7 +130,7 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 	k8sKubeconfig.QPS = controllerManagerOptions.KubeAPIQPS
 	k8sKubeconfig.Burst = int(controllerManagerOptions.KubeAPIBurst)
 	k8sKubeClient, err := kubernetes.NewForConfig(
-		rest.AddUserAgent(k8sKubeconfig, controllerManagerAgentName),
+		rateLimitedConfig(rest.AddUserAgent(k8sKubeconfig, controllerManagerAgentName), kubernetesClientName),
 	)
 	if err != nil {
 		return fmt.Errorf("invalid Kubernetes API configuration: %v", err)
@@ -144,6 +156,9 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 		return fmt.Errorf("failed to get Service Catalog client configuration: %v", err)
 	}
 	serviceCatalogKubeconfig.Insecure = controllerManagerOptions.ServiceCatalogInsecureSkipVerify
+	// Override kubeconfig qps/burst settings from flags
+	serviceCatalogKubeconfig.QPS = controllerManagerOptions.ServiceCatalogAPIQPS
+	serviceCatalogKubeconfig.Burst = int(controllerManagerOptions.ServiceCatalogAPIBurst)
 
 	// Initialize SSL/TLS configuration.  Ensures we have a certificate and key to use.
 	// This is the same code as what is done in the API Server.  By default, Helm created
@@ -214,7 +229,7 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 	// 'run' is the logic to run the controllers for the controller manager
 	run := func(ctx context.Context) {
 		serviceCatalogClientBuilder := controller.SimpleClientBuilder{
-			ClientConfig: serviceCatalogKubeconfig,
+			ClientConfig: rateLimitedConfig(serviceCatalogKubeconfig, serviceCatalogClientName),
 		}
 
 		// TODO: understand service account story for this controller-manager
@@ -283,6 +298,15 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 	panic("unreachable")
 }
 
+// rateLimitedConfig returns a copy of the config whose clients share a
+// client-side rate limiter with the QPS and burst of the config, which counts
+// the requests it delays.
+func rateLimitedConfig(config *rest.Config, clientName string) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.RateLimiter = metrics.NewClientRateLimiter(clientName, config.QPS, config.Burst)
+	return config
+}
+
 // StartControllers starts all the controllers in the service-catalog
 // controller manager.
 func StartControllers(s *options.ControllerManagerServer,
@@ -298,7 +322,7 @@ func StartControllers(s *options.ControllerManagerServer,
 	}
 
 	// Launch service-catalog controller
-	coreKubeconfig = rest.AddUserAgent(coreKubeconfig, controllerManagerAgentName)
+	coreKubeconfig = rateLimitedConfig(rest.AddUserAgent(coreKubeconfig, controllerManagerAgentName), kubernetesClientName)
 	coreClient, err := kubernetes.NewForConfig(coreKubeconfig)
 	if err != nil {
 		klog.Fatal(err)
@@ -308,6 +332,16 @@ func StartControllers(s *options.ControllerManagerServer,
 	coreInformerFactory := informers.NewSharedInformerFactory(coreClient, s.ResyncInterval)
 	coreInformers := coreInformerFactory.Core()
 
+	// Only the config maps labeled for binding parameters are watched, so
+	// that the controller does not cache every config map of the cluster.
+	configMapInformerFactory := informers.NewSharedInformerFactoryWithOptions(
+		coreClient,
+		s.ResyncInterval,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = servicecatalogv1beta1.ServiceBindingParametersConfigMapLabel + "=true"
+		}),
+	)
+
 	// Build the informer factory for service-catalog resources
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(
 		serviceCatalogClientBuilder.ClientOrDie("shared-informers"),
@@ -316,10 +350,22 @@ func StartControllers(s *options.ControllerManagerServer,
 	// All shared informers are v1beta1 API level
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	brokerURLPolicy, err := brokerurl.NewPolicy(s.BrokerURLAllowedCIDRs, s.BrokerURLDeniedCIDRs, s.BrokerURLAllowedHosts, s.BrokerURLDeniedHosts)
+	if err != nil {
+		return fmt.Errorf("invalid broker URL policy: %v", err)
+	}
+
+	tracer, err := tracing.NewTracer(controllerManagerAgentName, s.TracingEndpoint, s.TracingSamplingRatio)
+	if err != nil {
+		return err
+	}
+
 	klog.V(5).Infof("Creating controller; broker relist interval: %v", s.ServiceBrokerRelistInterval)
 	serviceCatalogController, err := controller.NewController(
 		coreClient,
 		coreInformers.V1().Secrets(),
+		configMapInformerFactory.Core().V1().ConfigMaps(),
+		coreInformers.V1().Namespaces(),
 		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -329,15 +375,43 @@ func StartControllers(s *options.ControllerManagerServer,
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
-		osbclientproxy.NewClient,
+		controller.NewBrokerURLPolicyCreateFunc(brokerURLPolicy, controller.NewBrokerProtocolCreateFunc(map[servicecatalogv1beta1.ServiceBrokerProtocol]controller.BrokerClientCreateFunc{
+			servicecatalogv1beta1.ServiceBrokerProtocolHTTP: controller.NewHTTPBrokerClient,
+			servicecatalogv1beta1.ServiceBrokerProtocolGRPC: controller.NewGRPCBrokerClient,
+		})),
 		s.ServiceBrokerRelistInterval,
 		s.OSBAPIPreferredVersion,
 		recorder,
 		s.ReconciliationRetryDuration,
 		s.OperationPollingMaximumBackoffDuration,
+		s.OperationRetryMaximumBackoffDuration,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
 		s.OSBAPITimeOut,
+		s.OSBAPIUserAgentSuffix,
+		controller.BindingSecretRetentionPolicy(s.BindingSecretRetentionPolicy),
+		s.CatalogStaleRelistMultiple,
+		s.BindingInstanceWaitTimeout,
+		s.BrokerTLSMinVersion,
+		s.BrokerTLSCipherSuites,
+		s.NamespaceDeletionDeprovisionTimeout,
+		s.BrokerMaxConcurrentRequests,
+		s.OSBAPIUpdateContext,
+		s.OSBAPIAcceptsIncomplete,
+		s.AsyncOperationTimeout,
+		s.OSBAPIContextPlatform,
+		s.ClusterID,
+		s.DeprovisionTimeout,
+		controller.ClassWithoutPlansPolicy(s.ClassWithoutPlansPolicy),
+		controller.EmptyBindingCredentialsPolicy(s.EmptyBindingCredentialsPolicy),
+		s.BrokerRelistTimeout,
+		s.ParametersResyncInterval,
+		s.OSBAPIContext,
+		s.BrokerHealthCheckInterval,
+		controller.NewBrokerURLPolicyHealthProbe(brokerURLPolicy, controller.ProbeBrokerHealth),
+		tracer,
+		s.OrphanMitigationGracePeriod,
+		s.OrphanMitigationCheckInstance,
 	)
 	if err != nil {
 		return err
@@ -346,12 +420,15 @@ func StartControllers(s *options.ControllerManagerServer,
 	klog.V(1).Info("Starting shared informers")
 	informerFactory.Start(stop)
 	coreInformerFactory.Start(stop)
+	configMapInformerFactory.Start(stop)
 
 	klog.V(5).Info("Waiting for caches to sync")
 	informerFactory.WaitForCacheSync(stop)
 	coreInformerFactory.WaitForCacheSync(stop)
+	configMapInformerFactory.WaitForCacheSync(stop)
 
 	klog.V(5).Info("Running controller")
+	go tracer.Run(stop)
 	go serviceCatalogController.Run(s.ConcurrentSyncs, stop)
 
 	select {}
@@ -21,17 +21,22 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/componentconfig"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	k8scomponentconfig "github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/apis/componentconfig"
 	"github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/client/leaderelectionconfig"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	cliflag "k8s.io/component-base/cli/flag"
 )
 
 const (
@@ -60,7 +65,15 @@ const (
 	defaultLeaderElectionNamespace                = "kube-system"
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
+	defaultOperationRetryMaximumBackoffDuration   = 20 * time.Minute
 	defaultOSBAPITimeOut                          = 60 * time.Second
+	defaultCatalogStaleRelistMultiple             = 3
+	defaultBrokerRelistTimeout                    = 10 * time.Minute
+	defaultKubeAPIQPS                             = 20
+	defaultKubeAPIBurst                           = 30
+	defaultServiceCatalogAPIQPS                   = 20
+	defaultServiceCatalogAPIBurst                 = 30
+	defaultTracingSamplingRatio                   = 1
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -73,6 +86,10 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			Address:                                defaultBindAddress,
 			Port:                                   0,
 			ContentType:                            defaultContentType,
+			KubeAPIQPS:                             defaultKubeAPIQPS,
+			KubeAPIBurst:                           defaultKubeAPIBurst,
+			ServiceCatalogAPIQPS:                   defaultServiceCatalogAPIQPS,
+			ServiceCatalogAPIBurst:                 defaultServiceCatalogAPIBurst,
 			K8sKubeconfigPath:                      defaultK8sKubeconfigPath,
 			ServiceCatalogKubeconfigPath:           defaultServiceCatalogKubeconfigPath,
 			ResyncInterval:                         defaultResyncInterval,
@@ -80,6 +97,15 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			OSBAPIContextProfile:                   defaultOSBAPIContextProfile,
 			OSBAPIPreferredVersion:                 defaultOSBAPIPreferredVersion,
 			OSBAPITimeOut:                          defaultOSBAPITimeOut,
+			OSBAPIUpdateContext:                    true,
+			OSBAPIAcceptsIncomplete:                true,
+			OSBAPIContextPlatform:                  controller.ContextProfilePlatformKubernetes,
+			BindingSecretRetentionPolicy:           string(controller.BindingSecretRetentionPolicyDelete),
+			ClassWithoutPlansPolicy:                string(controller.ClassWithoutPlansPolicyReject),
+			EmptyBindingCredentialsPolicy:          string(controller.EmptyBindingCredentialsPolicyAllow),
+			CatalogStaleRelistMultiple:             defaultCatalogStaleRelistMultiple,
+			BrokerRelistTimeout:                    defaultBrokerRelistTimeout,
+			BrokerURLDeniedCIDRs:                   brokerurl.DefaultDeniedCIDRs,
 			ConcurrentSyncs:                        defaultConcurrentSyncs,
 			LeaderElection:                         leaderelectionconfig.DefaultLeaderElectionConfiguration(),
 			LeaderElectionNamespace:                defaultLeaderElectionNamespace,
@@ -87,6 +113,8 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			EnableContentionProfiling:              false,
 			ReconciliationRetryDuration:            defaultReconciliationRetryDuration,
 			OperationPollingMaximumBackoffDuration: defaultOperationPollingMaximumBackoffDuration,
+			OperationRetryMaximumBackoffDuration:   defaultOperationRetryMaximumBackoffDuration,
+			TracingSamplingRatio:                   defaultTracingSamplingRatio,
 			SecureServingOptions:                   genericoptions.NewSecureServingOptions(),
 		},
 	}
@@ -105,6 +133,10 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.ConcurrentSyncs, "concurrent-syncs", defaultConcurrentSyncs, "Number of concurrent syncs")
 	fs.MarkDeprecated("port", "see --secure-port instead")
 	fs.StringVar(&s.ContentType, "api-content-type", s.ContentType, "Content type of requests sent to API servers")
+	fs.Float32Var(&s.KubeAPIQPS, "kube-api-qps", s.KubeAPIQPS, "The QPS of the client-side rate limit of the requests to the k8s API server")
+	fs.Int32Var(&s.KubeAPIBurst, "kube-api-burst", s.KubeAPIBurst, "The burst of the client-side rate limit of the requests to the k8s API server")
+	fs.Float32Var(&s.ServiceCatalogAPIQPS, "service-catalog-api-qps", s.ServiceCatalogAPIQPS, "The QPS of the client-side rate limit of the requests to the service-catalog API server")
+	fs.Int32Var(&s.ServiceCatalogAPIBurst, "service-catalog-api-burst", s.ServiceCatalogAPIBurst, "The burst of the client-side rate limit of the requests to the service-catalog API server")
 	fs.StringVar(&s.K8sAPIServerURL, "k8s-api-server-url", "", "The URL for the k8s API server")
 	fs.StringVar(&s.K8sKubeconfigPath, "k8s-kubeconfig", "", "Path to k8s core kubeconfig")
 	fs.StringVar(&s.ServiceCatalogAPIServerURL, "service-catalog-api-server-url", "", "The URL for the service-catalog API server")
@@ -121,9 +153,51 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.LeaderElectionNamespace, "leader-election-namespace", s.LeaderElectionNamespace, "Namespace to use for leader election lock")
 	fs.DurationVar(&s.ReconciliationRetryDuration, "reconciliation-retry-duration", s.ReconciliationRetryDuration, "The maximum amount of time to retry reconciliations on a resource before failing")
 	fs.DurationVar(&s.OperationPollingMaximumBackoffDuration, "operation-polling-maximum-backoff-duration", s.OperationPollingMaximumBackoffDuration, "The maximum amount of time to back-off while polling an OSB API operation")
+	fs.DurationVar(&s.OperationRetryMaximumBackoffDuration, "operation-retry-maximum-backoff-duration", s.OperationRetryMaximumBackoffDuration, "The maximum amount of time to back-off before retrying a failed provision or update of a ServiceInstance, independent of the back-off used while polling")
 	fs.DurationVar(&s.OSBAPITimeOut, "osb-api-request-timeout", s.OSBAPITimeOut, "The maximum amount of timeout to any request to the broker.")
+	fs.StringVar(&s.OSBAPIUserAgentSuffix, "osb-api-user-agent-suffix", s.OSBAPIUserAgentSuffix, "Appended to the User-Agent \"service-catalog/<version>\" of the requests to the brokers, e.g. to identify the cluster. The userAgentSuffix of a broker overrides it.")
+	fs.BoolVar(&s.OSBAPIUpdateContext, "osb-api-update-context", s.OSBAPIUpdateContext, "Send the OSB context in update requests, and update ServiceInstances when their context changes, e.g. when the labels of their namespace change. Disable it for brokers that reject the context in update requests.")
+	fs.BoolVar(&s.OSBAPIAcceptsIncomplete, "osb-api-accepts-incomplete", s.OSBAPIAcceptsIncomplete, "Send accepts_incomplete=true in the first request of an operation. When disabled, operations are requested synchronously and only sent again with accepts_incomplete=true when the broker responds with 422 AsyncRequired.")
+	fs.StringVar(&s.BindingSecretRetentionPolicy, "binding-secret-retention-policy", s.BindingSecretRetentionPolicy, "What to do with the Secret of a ServiceBinding when it is unbound: Delete removes the Secret, Retain removes the credentials but keeps the Secret.")
+	fs.StringVar(&s.ClassWithoutPlansPolicy, "class-without-plans-policy", s.ClassWithoutPlansPolicy, "What to do when the catalog of a broker contains a service without plans: Reject fails the sync of the whole catalog, Skip syncs the catalog without creating a class for the service and records a warning event on the broker.")
+	fs.StringVar(&s.EmptyBindingCredentialsPolicy, "empty-binding-credentials-policy", s.EmptyBindingCredentialsPolicy, "What to do when a broker returns no credentials for a ServiceBinding: Allow writes a Secret without keys, Warn also sets the NoCredentials condition on the binding and records a warning event. The servicecatalog.k8s.io/empty-credentials-policy annotation of a broker overrides it.")
+	fs.DurationVar(&s.BindingInstanceWaitTimeout, "binding-instance-wait-timeout", s.BindingInstanceWaitTimeout, "How long a ServiceBinding waits for its ServiceInstance to become ready before the binding fails; 0 disables waiting and retries the binding as an error until the instance is ready.")
+	fs.DurationVar(&s.AsyncOperationTimeout, "async-operation-timeout", s.AsyncOperationTimeout, "How long an asynchronous operation of a ServiceInstance is polled before it fails with the OperationTimedOut reason; 0 polls until the reconciliation retry duration is exceeded. The servicecatalog.k8s.io/async-operation-timeout annotation of an instance overrides it.")
+	fs.DurationVar(&s.DeprovisionTimeout, "deprovision-timeout", s.DeprovisionTimeout, "How long the deprovisioning of a deleted ServiceInstance may keep failing before a DeprovisionTimedOut warning event is emitted; the finalizer of an instance is then removed only if it has the servicecatalog.k8s.io/force-orphan annotation set to \"true\". 0 disables the timeout. The servicecatalog.k8s.io/deprovision-timeout annotation of an instance overrides it.")
+	fs.DurationVar(&s.NamespaceDeletionDeprovisionTimeout, "namespace-deletion-deprovision-timeout", s.NamespaceDeletionDeprovisionTimeout, "How long the deprovisioning of a ServiceInstance is retried once the deletion of its namespace started, before the deprovisioning fails; 0 retries until the reconciliation retry duration is exceeded.")
+	fs.IntVar(&s.BrokerMaxConcurrentRequests, "broker-max-concurrent-requests", s.BrokerMaxConcurrentRequests, "The maximum number of requests sent to a single broker at the same time; reconciliations which would exceed it are retried later. 0 disables the limit.")
+	fs.Float64Var(&s.CatalogStaleRelistMultiple, "broker-catalog-stale-relist-multiple", s.CatalogStaleRelistMultiple, "The number of relist intervals after which a broker whose catalog can not be retrieved gets the CatalogStale condition; 0 disables the condition.")
+	fs.DurationVar(&s.BrokerHealthCheckInterval, "broker-health-check-interval", s.BrokerHealthCheckInterval, "How often the brokers that set spec.healthCheck are probed between relists, without fetching their catalog, to set their Reachable condition; 0 disables the probes.")
+	fs.DurationVar(&s.BrokerRelistTimeout, "broker-relist-timeout", s.BrokerRelistTimeout, "How long the relist of a broker may be in progress before the broker gets the RelistStuck condition. While a relist is in progress, status.currentOperation of the broker is Relist and status.operationStartTime is its start time; 0 disables recording the relist.")
+	fs.DurationVar(&s.ParametersResyncInterval, "parameters-resync-interval", s.ParametersResyncInterval, "How often the parametersFrom and secretParameterRefs Secrets of ready ServiceInstances are read again, so that an update is requested when they changed, even if the Secret event was missed; 0 disables the periodic read. The servicecatalog.k8s.io/parameters-resync-interval annotation of an instance overrides it.")
+	fs.StringVar(&s.BrokerTLSMinVersion, "broker-tls-min-version", s.BrokerTLSMinVersion, "Minimum TLS version of the connections to the brokers. Possible values: "+strings.Join(cliflag.TLSPossibleVersions(), ", ")+". If omitted, the default of Go is used.")
+	fs.StringSliceVar(&s.BrokerTLSCipherSuites, "broker-tls-cipher-suites", s.BrokerTLSCipherSuites, "Comma-separated list of cipher suites allowed for the connections to the brokers. Possible values: "+strings.Join(cliflag.TLSCipherPossibleValues(), ", ")+". If omitted, the defaults of Go are used.")
+	fs.StringSliceVar(&s.BrokerURLDeniedCIDRs, "broker-url-denied-cidrs", s.BrokerURLDeniedCIDRs, "Comma-separated list of address ranges the broker URLs may not resolve to. Defaults to the unspecified, loopback and link-local ranges, which include the metadata services of cloud providers; set it to \"\" to allow them.")
+	fs.StringSliceVar(&s.BrokerURLAllowedCIDRs, "broker-url-allowed-cidrs", s.BrokerURLAllowedCIDRs, "Comma-separated list of address ranges the broker URLs may resolve to even when they are part of --broker-url-denied-cidrs.")
+	fs.StringSliceVar(&s.BrokerURLDeniedHosts, "broker-url-denied-hosts", s.BrokerURLDeniedHosts, "Comma-separated list of host names the broker URLs may not point at; a name starting with \"*.\" matches all of its subdomains.")
+	fs.StringSliceVar(&s.BrokerURLAllowedHosts, "broker-url-allowed-hosts", s.BrokerURLAllowedHosts, "Comma-separated list of host names the broker URLs may point at regardless of the addresses they resolve to; a name starting with \"*.\" matches all of its subdomains.")
+	fs.StringVar(&s.TracingEndpoint, "tracing-otlp-endpoint", s.TracingEndpoint, "The OTLP/HTTP endpoint of an OpenTelemetry collector, e.g. http://otel-collector:4318, the spans of the reconciles and of the requests to the brokers are exported to as JSON. If omitted, tracing is disabled.")
+	fs.Float64Var(&s.TracingSamplingRatio, "tracing-sampling-ratio", s.TracingSamplingRatio, "The ratio of the reconciles that are traced when --tracing-otlp-endpoint is set, from 0 to 1.")
+	fs.DurationVar(&s.OrphanMitigationGracePeriod, "orphan-mitigation-grace-period", s.OrphanMitigationGracePeriod, "How long the orphan mitigation of a ServiceInstance whose provision failed with an ambiguous error waits before deprovisioning it, for brokers that may still be finishing the provision; 0 deprovisions it right away.")
+	fs.BoolVar(&s.OrphanMitigationCheckInstance, "orphan-mitigation-check-instance", s.OrphanMitigationCheckInstance, "Whether to fetch a ServiceInstance of an instancesRetrievable class from its broker during --orphan-mitigation-grace-period, and to cancel its orphan mitigation when the broker has it.")
 	s.SecureServingOptions.AddFlags(fs)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
 	fs.StringVar(&s.ClusterIDConfigMapNamespace, "cluster-id-configmap-namespace", controller.DefaultClusterIDConfigMapNamespace, "k8s namespace for clusterid configmap")
+	fs.StringVar(&s.ClusterID, "cluster-id", s.ClusterID, "The cluster ID sent as clusterid in the OSB context. If omitted, the ID in the clusterid configmap is used; when the configmap does not exist, it is created with the UID of the kube-system namespace.")
+	fs.StringVar(&s.OSBAPIContextPlatform, "osb-api-context-platform", s.OSBAPIContextPlatform, "The platform sent in the OSB context.")
+	fs.StringVar(&s.OSBAPIContext, "osb-api-context", s.OSBAPIContext, "A JSON object holding additional keys sent in the OSB context of all requests to the brokers, e.g. '{\"encrypt\":true}'. The servicecatalog.k8s.io/context annotation of a broker takes precedence; the keys set by the controller, such as platform and namespace, can not be set.")
+}
+
+// Validate checks that the flags have been set to values the controller can
+// run with.
+func (s *ControllerManagerServer) Validate() error {
+	var errors []error
+	if s.OperationPollingMaximumBackoffDuration <= 0 {
+		errors = append(errors, fmt.Errorf("validation error: --operation-polling-maximum-backoff-duration must be positive"))
+	}
+	if s.OperationRetryMaximumBackoffDuration <= 0 {
+		errors = append(errors, fmt.Errorf("validation error: --operation-retry-maximum-backoff-duration must be positive"))
+	}
+	return utilerrors.NewAggregate(errors)
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package all
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// GetCmd contains the info needed to list the instances of a namespace
+// together with their bindings
+type GetCmd struct {
+	*command.Namespaced
+	*command.Formatted
+}
+
+// NewGetCmd builds a "svcat get all" command
+func NewGetCmd(cxt *command.Context) *cobra.Command {
+	getCmd := &GetCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "List instances together with their bindings, optionally filtered by namespace",
+		Example: command.NormalizeExamples(`
+  svcat get all
+  svcat get all -n ci
+  svcat get all --all-namespaces
+  svcat get all -o json
+`),
+		PreRunE: command.PreRunE(getCmd),
+		RunE:    command.RunE(getCmd),
+	}
+
+	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	getCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+// Validate checks that no arguments have been provided and that the output
+// format can represent the aggregate view
+func (c *GetCmd) Validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("get all does not accept arguments")
+	}
+	if c.OutputFormat == output.FormatCustomColumns || c.OutputFormat == output.FormatJSONPath {
+		return fmt.Errorf("get all does not support the %s output format", c.OutputFormat)
+	}
+	return nil
+}
+
+// Run runs the command
+func (c *GetCmd) Run() error {
+	return c.GetAll()
+}
+
+// GetAll lists the instances and the bindings of the namespace, and prints
+// each instance with the bindings that reference it. When the user is not
+// allowed to list one of the two resources, the other one is still printed
+// along with a warning.
+func (c *GetCmd) GetAll() error {
+	all := &output.InstancesWithBindings{
+		Items: []output.InstanceWithBindings{},
+	}
+
+	instances, instancesErr := c.App.RetrieveInstances(c.Namespace, "", "", "")
+	if instancesErr != nil {
+		if !apierrors.IsForbidden(errors.Cause(instancesErr)) {
+			return instancesErr
+		}
+		all.Warnings = append(all.Warnings, instancesErr.Error())
+	}
+	bindings, bindingsErr := c.App.RetrieveBindings(c.Namespace, "")
+	if bindingsErr != nil {
+		if !apierrors.IsForbidden(errors.Cause(bindingsErr)) || instancesErr != nil {
+			return bindingsErr
+		}
+		all.Warnings = append(all.Warnings, bindingsErr.Error())
+	}
+
+	index := map[string]int{}
+	if instances != nil {
+		for i, instance := range instances.Items {
+			index[instance.Namespace+"/"+instance.Name] = len(all.Items)
+			all.Items = append(all.Items, output.InstanceWithBindings{
+				Instance: instances.Items[i],
+				Bindings: []v1beta1.ServiceBinding{},
+			})
+		}
+	}
+	if bindings != nil {
+		for _, binding := range bindings.Items {
+			i, ok := index[binding.Namespace+"/"+binding.Spec.InstanceRef.Name]
+			if !ok {
+				all.UnassociatedBindings = append(all.UnassociatedBindings, binding)
+				continue
+			}
+			all.Items[i].Bindings = append(all.Items[i].Bindings, binding)
+		}
+	}
+
+	output.WriteInstancesWithBindings(c.Output, c.OutputFormat, all)
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package all_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/all"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Get All Command", func() {
+	var (
+		outputBuffer *bytes.Buffer
+		fakeSDK      *servicecatalogfakes.FakeSvcatClient
+		cmd          *GetCmd
+		instances    *v1beta1.ServiceInstanceList
+		bindings     *v1beta1.ServiceBindingList
+	)
+
+	forbidden := func(resource string) error {
+		err := apierrors.NewForbidden(schema.GroupResource{Group: "servicecatalog.k8s.io", Resource: resource}, "", errors.New("access denied"))
+		return pkgerrors.Wrapf(err, "unable to list %s in default", resource)
+	}
+
+	BeforeEach(func() {
+		outputBuffer = &bytes.Buffer{}
+		fakeApp, _ := svcat.NewApp(nil, nil, "default")
+		fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+		fakeApp.SvcatClient = fakeSDK
+		cxt := svcattest.NewContext(outputBuffer, fakeApp)
+		cmd = &GetCmd{
+			Namespaced: command.NewNamespaced(cxt),
+			Formatted:  command.NewFormatted(),
+		}
+		cmd.Namespace = "default"
+
+		instances = &v1beta1.ServiceInstanceList{
+			Items: []v1beta1.ServiceInstance{
+				{ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "default"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"}},
+			},
+		}
+		bindings = &v1beta1.ServiceBindingList{
+			Items: []v1beta1.ServiceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "mysql-binding", Namespace: "default"},
+					Spec:       v1beta1.ServiceBindingSpec{InstanceRef: v1beta1.LocalObjectReference{Name: "mysql"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "orphan-binding", Namespace: "default"},
+					Spec:       v1beta1.ServiceBindingSpec{InstanceRef: v1beta1.LocalObjectReference{Name: "deleted"}},
+				},
+			},
+		}
+	})
+
+	Describe("NewGetCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewGetCmd(cxt)
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("all"))
+			Expect(cmd.Example).To(ContainSubstring("svcat get all --all-namespaces"))
+			Expect(cmd.Flags().Lookup("all-namespaces")).NotTo(BeNil())
+			Expect(cmd.Flags().Lookup("output")).NotTo(BeNil())
+		})
+	})
+	Describe("Validate", func() {
+		It("errors if arguments are provided", func() {
+			err := cmd.Validate([]string{"foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not accept arguments"))
+		})
+		It("errors for the jsonpath output format", func() {
+			cmd.OutputFormat = output.FormatJSONPath
+			err := cmd.Validate(nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not support the jsonpath output format"))
+		})
+	})
+	Describe("GetAll", func() {
+		It("Groups the bindings under their instance", func() {
+			fakeSDK.RetrieveInstancesReturns(instances, nil)
+			fakeSDK.RetrieveBindingsReturns(bindings, nil)
+			cmd.OutputFormat = output.FormatJSON
+
+			err := cmd.GetAll()
+
+			Expect(err).NotTo(HaveOccurred())
+			ns, _, _, _ := fakeSDK.RetrieveInstancesArgsForCall(0)
+			Expect(ns).To(Equal("default"))
+			ns, _ = fakeSDK.RetrieveBindingsArgsForCall(0)
+			Expect(ns).To(Equal("default"))
+
+			var all output.InstancesWithBindings
+			Expect(json.Unmarshal(outputBuffer.Bytes(), &all)).To(Succeed())
+			Expect(all.Items).To(HaveLen(2))
+			Expect(all.Items[0].Instance.Name).To(Equal("mysql"))
+			Expect(all.Items[0].Bindings).To(HaveLen(1))
+			Expect(all.Items[0].Bindings[0].Name).To(Equal("mysql-binding"))
+			Expect(all.Items[1].Instance.Name).To(Equal("redis"))
+			Expect(all.Items[1].Bindings).To(BeEmpty())
+			Expect(all.UnassociatedBindings).To(HaveLen(1))
+			Expect(all.UnassociatedBindings[0].Name).To(Equal("orphan-binding"))
+			Expect(all.Warnings).To(BeEmpty())
+		})
+		It("Prints the instances with a warning when the bindings are forbidden", func() {
+			fakeSDK.RetrieveInstancesReturns(instances, nil)
+			fakeSDK.RetrieveBindingsReturns(nil, forbidden("servicebindings"))
+
+			err := cmd.GetAll()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputBuffer.String()).To(ContainSubstring("Warning: unable to list servicebindings in default"))
+			Expect(outputBuffer.String()).To(ContainSubstring("mysql"))
+			Expect(outputBuffer.String()).To(ContainSubstring("redis"))
+		})
+		It("Prints the bindings with a warning when the instances are forbidden", func() {
+			fakeSDK.RetrieveInstancesReturns(nil, forbidden("serviceinstances"))
+			fakeSDK.RetrieveBindingsReturns(bindings, nil)
+			cmd.OutputFormat = output.FormatJSON
+
+			err := cmd.GetAll()
+
+			Expect(err).NotTo(HaveOccurred())
+			var all output.InstancesWithBindings
+			Expect(json.Unmarshal(outputBuffer.Bytes(), &all)).To(Succeed())
+			Expect(all.Items).To(BeEmpty())
+			Expect(all.UnassociatedBindings).To(HaveLen(2))
+			Expect(all.Warnings).To(ConsistOf(ContainSubstring("unable to list serviceinstances in default")))
+		})
+		It("Errors when both resources are forbidden", func() {
+			fakeSDK.RetrieveInstancesReturns(nil, forbidden("serviceinstances"))
+			fakeSDK.RetrieveBindingsReturns(nil, forbidden("servicebindings"))
+
+			err := cmd.GetAll()
+
+			Expect(err).To(HaveOccurred())
+			Expect(outputBuffer.String()).To(BeEmpty())
+		})
+		It("Bubbles up other errors", func() {
+			fakeSDK.RetrieveInstancesReturns(nil, errors.New("unable to list instances"))
+
+			err := cmd.GetAll()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to list instances"))
+			Expect(fakeSDK.RetrieveBindingsCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(BeEmpty())
+		})
+	})
+})
@@ -17,7 +17,10 @@ limitations under the License.
 package binding
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
@@ -26,27 +29,60 @@ import (
 
 type describeCmd struct {
 	*command.Namespaced
+	*command.Described
 	name        string
 	showSecrets bool
+	showSecret  bool
+	reveal      bool
+	skipPrompt  bool
 }
 
 // NewDescribeCmd builds a "svcat describe binding" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Namespaced: command.NewNamespaced(cxt)}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Described:  command.NewDescribed(),
+	}
 	cmd := &cobra.Command{
 		Use:     "binding NAME",
 		Aliases: []string{"bindings", "bnd"},
 		Short:   "Show details of a specific binding",
-		Example: command.NormalizeExamples(`svcat describe binding wordpress-mysql-binding`),
+		Example: command.NormalizeExamples(`
+  svcat describe binding wordpress-mysql-binding
+  svcat describe binding wordpress-mysql-binding --show-secret
+  svcat describe binding wordpress-mysql-binding --show-secret --reveal
+  svcat describe binding wordpress-mysql-binding --output yaml
+`),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	cmd.Flags().BoolVar(
 		&describeCmd.showSecrets,
 		"show-secrets",
 		false,
-		"Output the decoded secret values. By default only the length of the secret is displayed",
+		"Deprecated alias of --show-secret, which lists the keys of the bound secret and the length of their values",
+	)
+	cmd.Flags().MarkDeprecated("show-secrets", "use --show-secret to list the lengths of the values, and --reveal to print the values")
+	cmd.Flags().BoolVar(
+		&describeCmd.showSecret,
+		"show-secret",
+		false,
+		"List the keys of the bound secret and the length of their values. Fails when the secret can not be read, for example when you are not allowed to get it",
+	)
+	cmd.Flags().BoolVar(
+		&describeCmd.reveal,
+		"reveal",
+		false,
+		"Output the decoded values of the secret listed by --show-secret, after a confirmation",
+	)
+	cmd.Flags().BoolVarP(
+		&describeCmd.skipPrompt,
+		"yes",
+		"y",
+		false,
+		`Automatic yes to prompts. Assume "yes" as answer to all prompts and run non-interactively.`,
 	)
 	return cmd
 }
@@ -58,6 +94,18 @@ func (c *describeCmd) Validate(args []string) error {
 	}
 	c.name = args[0]
 
+	// --show-secrets used to print the values without a confirmation; it
+	// now only lists their lengths, and --reveal must be asked for.
+	if c.showSecrets {
+		c.showSecret = true
+	}
+	if c.reveal && !c.showSecret {
+		return fmt.Errorf("--reveal requires --show-secret")
+	}
+	if c.reveal && c.IsStructured() && !c.skipPrompt {
+		return fmt.Errorf("--reveal with --output %s requires --yes", c.OutputFormat)
+	}
+
 	return nil
 }
 
@@ -72,10 +120,36 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
-	output.WriteBindingDetails(c.Output, binding)
-
 	secret, err := c.App.RetrieveSecretByBinding(binding)
-	output.WriteAssociatedSecret(c.Output, secret, err, c.showSecrets)
+	if c.showSecret {
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			return fmt.Errorf("the secret %s/%s of the binding has not been created yet", binding.GetSecretNamespace(), binding.Spec.SecretName)
+		}
+	}
+
+	// The warning is not printed with a structured output, which must stay
+	// parseable; Validate requires --yes in that case.
+	if c.reveal && !c.IsStructured() {
+		fmt.Fprintf(c.Output, "The values of the secret %s/%s will be printed in clear text.\n", binding.GetSecretNamespace(), binding.Spec.SecretName)
+		if !c.skipPrompt {
+			fmt.Fprintln(c.Output, "Are you sure? [y|n]: ")
+			s := bufio.NewScanner(os.Stdin)
+			s.Scan()
+
+			if err := s.Err(); err != nil {
+				return err
+			}
+
+			if strings.ToLower(s.Text()) != "y" {
+				return fmt.Errorf("aborted revealing the secret values")
+			}
+		}
+	}
+
+	output.WriteBindingDescription(c.Output, c.OutputFormat, binding, secret, err, c.reveal)
 
 	return nil
 }
@@ -39,6 +39,7 @@ func TestDescribeCommand(t *testing.T) {
 		name          string
 		fakeBindings  []string
 		bindingName   string
+		showSecret    bool
 		expectedError string
 		wantError     bool
 	}{
@@ -55,6 +56,14 @@ func TestDescribeCommand(t *testing.T) {
 			bindingName:  "mybinding",
 			wantError:    false,
 		},
+		{
+			name:          "show the secret of a binding without secret",
+			fakeBindings:  []string{"mybinding"},
+			bindingName:   "mybinding",
+			showSecret:    true,
+			expectedError: "the secret " + namespace + "/mybinding of the binding has not been created yet",
+			wantError:     true,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -69,7 +78,7 @@ func TestDescribeCommand(t *testing.T) {
 						Namespace: namespace,
 						Name:      name,
 					},
-					Spec: v1beta1.ServiceBindingSpec{},
+					Spec: v1beta1.ServiceBindingSpec{SecretName: name},
 				})
 			}
 
@@ -81,9 +90,11 @@ func TestDescribeCommand(t *testing.T) {
 			// Initialize the command arguments
 			cmd := &describeCmd{
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
+			cmd.showSecret = tc.showSecret
 
 			err := cmd.Run()
 
@@ -17,6 +17,8 @@ limitations under the License.
 package binding
 
 import (
+	"fmt"
+
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/spf13/cobra"
@@ -25,6 +27,8 @@ import (
 type getCmd struct {
 	*command.Namespaced
 	*command.Formatted
+	*command.Selected
+	*command.Sorted
 	name string
 }
 
@@ -33,6 +37,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd := &getCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Formatted:  command.NewFormatted(),
+		Selected:   command.NewSelected(),
+		Sorted:     command.NewSorted(),
 	}
 	cmd := &cobra.Command{
 		Use:     "bindings [NAME]",
@@ -41,6 +47,9 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat get bindings
   svcat get bindings --all-namespaces
+  svcat get bindings -l team=payments
+  svcat get bindings --sort-by status
+  svcat get bindings -o wide
   svcat get binding wordpress-mysql-binding
   svcat get binding -n ci concourse-postgres-binding
 `),
@@ -50,6 +59,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddOutputFlags(cmd.Flags())
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddSortFlag(cmd)
 	return cmd
 }
 
@@ -57,6 +68,14 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
 		c.name = args[0]
+
+		if c.LabelSelector != "" {
+			return fmt.Errorf("selector is not supported when specifiying binding name")
+		}
+
+		if c.SortBy != nil {
+			return fmt.Errorf("sort-by is not supported when specifiying binding name")
+		}
 	}
 
 	return nil
@@ -73,11 +92,20 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	bindings, err := c.App.RetrieveBindings(c.Namespace)
+	bindings, err := c.App.RetrieveBindings(c.Namespace, c.LabelSelector)
 	if err != nil {
 		return err
 	}
+	if err := output.SortList(bindings.Items, c.SortBy); err != nil {
+		return err
+	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, bindings.Items)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPathList(c.Output, c.JSONPath, bindings.Items)
+	}
 	output.WriteBindingList(c.Output, c.OutputFormat, bindings)
 	return nil
 }
@@ -88,6 +116,12 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, binding)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPath(c.Output, c.JSONPath, binding)
+	}
 	output.WriteBinding(c.Output, c.OutputFormat, *binding)
 	return nil
 }
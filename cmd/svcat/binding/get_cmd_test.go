@@ -134,6 +134,8 @@ func TestGetCommand(t *testing.T) {
 			cmd := &getCmd{
 				Namespaced: command.NewNamespaced(cxt),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Sorted:     command.NewSorted(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
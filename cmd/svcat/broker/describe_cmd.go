@@ -32,7 +32,9 @@ type DescribeCmd struct {
 	*command.Namespaced
 	*command.Scoped
 
-	Name string
+	Name           string
+	Metrics        bool
+	MetricsService string
 }
 
 // NewDescribeCmd builds a "svcat describe broker" command
@@ -48,10 +50,16 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Short:   "Show details of a specific broker",
 		Example: command.NormalizeExamples(`
   svcat describe broker asb
+  svcat describe broker asb --metrics
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
+	cmd.Flags().BoolVar(&describeCmd.Metrics, "metrics", false,
+		"Show the number of succeeded and failed requests the controller sent to the broker, read from the metrics of the controller manager. When they can not be read, the ready and failed instances and bindings of the broker are counted instead.")
+	cmd.Flags().StringVar(&describeCmd.MetricsService, "metrics-service",
+		servicecatalog.DefaultMetricsNamespace+"/"+servicecatalog.DefaultMetricsService,
+		"The Service of the controller manager whose metrics are read with --metrics, as NAMESPACE/NAME")
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
 	describeCmd.AddScopedFlags(cmd.Flags(), true)
 	return cmd
@@ -64,9 +72,25 @@ func (c *DescribeCmd) Validate(args []string) error {
 	}
 	c.Name = args[0]
 
+	if c.Metrics {
+		if _, _, err := c.metricsOptions(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// metricsOptions splits --metrics-service into the namespace and name of the
+// Service of the controller manager.
+func (c *DescribeCmd) metricsOptions() (string, string, error) {
+	parts := strings.Split(c.MetricsService, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid --metrics-service value %q, it must be NAMESPACE/NAME", c.MetricsService)
+	}
+	return parts[0], parts[1], nil
+}
+
 // Run retrieves the broker(s) with the requested name, interprets
 // possible errors if we need to ask the user for more info, and displays
 // the found broker to the user
@@ -86,5 +110,20 @@ func (c *DescribeCmd) Run() error {
 		return err
 	}
 	output.WriteBrokerDetails(c.Output, broker)
+
+	if c.Metrics {
+		namespace, service, err := c.metricsOptions()
+		if err != nil {
+			return err
+		}
+		metrics, err := c.App.RetrieveBrokerMetrics(broker, servicecatalog.MetricsOptions{
+			Namespace: namespace,
+			Service:   service,
+		})
+		if err != nil {
+			return err
+		}
+		output.WriteBrokerMetrics(c.Output, metrics)
+	}
 	return nil
 }
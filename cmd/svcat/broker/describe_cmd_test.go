@@ -58,6 +58,12 @@ var _ = Describe("Describe Command", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("a broker name is required"))
 		})
+		It("errors if the metrics service is not a namespace and a name", func() {
+			cmd := DescribeCmd{Metrics: true, MetricsService: "catalog-controller-manager"}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("it must be NAMESPACE/NAME"))
+		})
 	})
 	Describe("Run", func() {
 		var (
@@ -129,6 +135,79 @@ var _ = Describe("Describe Command", func() {
 			Expect(output).To(ContainSubstring(brokerURL))
 			Expect(output).To(ContainSubstring("Scope:    cluster"))
 		})
+		It("prints the metrics of the broker when --metrics is set", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(brokerToReturn, nil)
+			fakeSDK.RetrieveBrokerMetricsReturns(&servicecatalog.BrokerMetrics{
+				Source: servicecatalog.BrokerMetricsSourceController,
+				Operations: []servicecatalog.BrokerOperationCount{
+					{Operation: "Provision", Succeeded: 12, Failed: 3},
+				},
+			}, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := DescribeCmd{
+				Context:        cxt,
+				Namespaced:     command.NewNamespaced(cxt),
+				Name:           brokerName,
+				Scoped:         command.NewScoped(),
+				Metrics:        true,
+				MetricsService: "svc-cat/catalog-controller-manager",
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Scope = servicecatalog.AllScope
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveBrokerMetricsCallCount()).To(Equal(1))
+			returnedBroker, returnedOpts := fakeSDK.RetrieveBrokerMetricsArgsForCall(0)
+			Expect(returnedBroker).To(Equal(brokerToReturn))
+			Expect(returnedOpts).To(Equal(servicecatalog.MetricsOptions{
+				Namespace: "svc-cat",
+				Service:   "catalog-controller-manager",
+			}))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("since the controller manager started"))
+			Expect(output).To(MatchRegexp(`Provision\s+12\s+3`))
+		})
+		It("prints why the metrics could not be read when it falls back to the status", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(brokerToReturn, nil)
+			fakeSDK.RetrieveBrokerMetricsReturns(&servicecatalog.BrokerMetrics{
+				Source:       servicecatalog.BrokerMetricsSourceStatus,
+				MetricsError: fmt.Errorf("services is forbidden"),
+				Operations: []servicecatalog.BrokerOperationCount{
+					{Operation: "Provision", Succeeded: 2},
+					{Operation: "Bind", Failed: 1},
+				},
+			}, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := DescribeCmd{
+				Context:        cxt,
+				Namespaced:     command.NewNamespaced(cxt),
+				Name:           brokerName,
+				Scoped:         command.NewScoped(),
+				Metrics:        true,
+				MetricsService: "catalog/catalog-catalog-controller-manager",
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Scope = servicecatalog.AllScope
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("services is forbidden"))
+			Expect(output).To(ContainSubstring("ready and failed instances and bindings"))
+			Expect(output).To(MatchRegexp(`Bind\s+0\s+1`))
+		})
 		It("prints out a namespaced broker when it only finds a namespace broker", func() {
 			outputBuffer := &bytes.Buffer{}
 
@@ -22,17 +22,29 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
+const (
+	// StatusReady matches the brokers whose Ready condition is true.
+	StatusReady = "Ready"
+	// StatusNotReady matches the brokers whose Ready condition is not true,
+	// including the failed brokers.
+	StatusNotReady = "NotReady"
+	// StatusFailed matches the brokers whose Failed condition is true.
+	StatusFailed = "Failed"
+)
+
 // GetCmd contains the information needed to get a broker or list of brokers
 type GetCmd struct {
 	*command.Namespaced
 	*command.Formatted
 	*command.Scoped
 
-	Name string
+	Name   string
+	Status string
 }
 
 // NewGetCmd builds a "svcat get brokers" command
@@ -51,6 +63,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get brokers --scope=cluster
   svcat get brokers --scope=all
   svcat get broker minibroker
+  svcat get brokers --status NotReady -o wide
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -58,6 +71,12 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddScopedFlags(cmd.Flags(), true)
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	cmd.Flags().StringVar(
+		&getCmd.Status,
+		"status",
+		"",
+		"If present, only list the brokers with this status. Valid options are Ready, NotReady and Failed",
+	)
 	return cmd
 }
 
@@ -67,6 +86,22 @@ func (c *GetCmd) Validate(args []string) error {
 		c.Name = args[0]
 	}
 
+	if c.Status != "" {
+		if c.Name != "" {
+			return fmt.Errorf("--status can not be used when getting a broker by name")
+		}
+		switch {
+		case strings.EqualFold(c.Status, StatusReady):
+			c.Status = StatusReady
+		case strings.EqualFold(c.Status, StatusNotReady):
+			c.Status = StatusNotReady
+		case strings.EqualFold(c.Status, StatusFailed):
+			c.Status = StatusFailed
+		default:
+			return fmt.Errorf("invalid --status %q, allowed values are: %s, %s and %s", c.Status, StatusReady, StatusNotReady, StatusFailed)
+		}
+	}
+
 	return nil
 }
 
@@ -89,7 +124,16 @@ func (c *GetCmd) getAll() error {
 	if err != nil {
 		return err
 	}
+	if c.Status != "" {
+		brokers = filterBrokersByStatus(brokers, c.Status)
+	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, brokers)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPathList(c.Output, c.JSONPath, brokers)
+	}
 	output.WriteBrokerList(c.Output, c.OutputFormat, brokers...)
 	return nil
 }
@@ -106,6 +150,37 @@ func (c *GetCmd) get() error {
 		}
 		return err
 	}
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, broker)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPath(c.Output, c.JSONPath, broker)
+	}
 	output.WriteBroker(c.Output, c.OutputFormat, broker)
 	return nil
 }
+
+// filterBrokersByStatus returns the brokers that match the status, computed
+// from their Ready and Failed conditions.
+func filterBrokersByStatus(brokers []servicecatalog.Broker, status string) []servicecatalog.Broker {
+	filtered := make([]servicecatalog.Broker, 0, len(brokers))
+	for _, broker := range brokers {
+		ready := brokerHasCondition(broker, v1beta1.ServiceBrokerConditionReady)
+		failed := brokerHasCondition(broker, v1beta1.ServiceBrokerConditionFailed)
+		if (status == StatusReady && ready) ||
+			(status == StatusNotReady && !ready) ||
+			(status == StatusFailed && failed) {
+			filtered = append(filtered, broker)
+		}
+	}
+	return filtered
+}
+
+func brokerHasCondition(broker servicecatalog.Broker, conditionType v1beta1.ServiceBrokerConditionType) bool {
+	for _, cond := range broker.GetStatus().Conditions {
+		if cond.Type == conditionType && cond.Status == v1beta1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,7 @@ import (
 
 	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/broker"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
@@ -59,6 +60,23 @@ var _ = Describe("Get Broker Command", func() {
 			Expect(err).To(BeNil())
 			Expect(cmd.Name).To(Equal("minibroker"))
 		})
+		It("normalizes the status filter", func() {
+			cmd := &GetCmd{Status: "notready"}
+			err := cmd.Validate([]string{})
+			Expect(err).To(BeNil())
+			Expect(cmd.Status).To(Equal(StatusNotReady))
+		})
+		It("rejects an unknown status filter", func() {
+			cmd := &GetCmd{Status: "Pending"}
+			err := cmd.Validate([]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid --status"))
+		})
+		It("rejects the status filter with a broker name", func() {
+			cmd := &GetCmd{Status: StatusReady}
+			err := cmd.Validate([]string{"minibroker"})
+			Expect(err).To(HaveOccurred())
+		})
 	})
 	Describe("Run", func() {
 		It("Calls the pkg/svcat libs RetrieveBrokers with namespace scope and current namespace", func() {
@@ -90,6 +108,52 @@ var _ = Describe("Get Broker Command", func() {
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring("minibroker"))
 		})
+		It("Lists only the brokers with the requested status", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokersReturns(
+				[]servicecatalog.Broker{
+					&v1beta1.ClusterServiceBroker{
+						ObjectMeta: v1.ObjectMeta{Name: "ready-broker"},
+						Status: v1beta1.ClusterServiceBrokerStatus{CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+							Conditions: []v1beta1.ServiceBrokerCondition{
+								{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue},
+							},
+						}},
+					},
+					&v1beta1.ClusterServiceBroker{
+						ObjectMeta: v1.ObjectMeta{Name: "failing-broker"},
+						Status: v1beta1.ClusterServiceBrokerStatus{CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+							Conditions: []v1beta1.ServiceBrokerCondition{
+								{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionFalse, Reason: "ErrorFetchingCatalog", Message: "connection refused"},
+							},
+						}},
+					},
+					&v1beta1.ClusterServiceBroker{ObjectMeta: v1.ObjectMeta{Name: "new-broker"}},
+				},
+				nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := GetCmd{
+				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
+				Status:     StatusNotReady,
+			}
+			cmd.OutputFormat = output.FormatWide
+			cmd.Scope = servicecatalog.ClusterScope
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			output := outputBuffer.String()
+			Expect(output).NotTo(ContainSubstring("ready-broker"))
+			Expect(output).To(ContainSubstring("failing-broker"))
+			Expect(output).To(ContainSubstring("ErrorFetchingCatalog"))
+			Expect(output).To(ContainSubstring("connection refused"))
+			Expect(output).To(ContainSubstring("new-broker"))
+		})
 		It("Calls the pkg/svcat libs RetrieveBrokers with namespace scope and all namespaces", func() {
 			outputBuffer := &bytes.Buffer{}
 
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// LogsCmd contains the info needed to print the events of a broker
+type LogsCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	*command.Logged
+
+	Name string
+}
+
+// NewLogsCmd builds a "svcat logs broker" command
+func NewLogsCmd(cxt *command.Context) *cobra.Command {
+	logsCmd := &LogsCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+		Logged:     command.NewLogged(),
+	}
+	cmd := &cobra.Command{
+		Use:     "broker NAME",
+		Aliases: []string{"brokers", "brk"},
+		Short:   "Show recent events for a specific broker",
+		Example: command.NormalizeExamples(`
+  svcat logs broker asb
+  svcat logs broker asb --since 1h --follow
+`),
+		PreRunE: command.PreRunE(logsCmd),
+		RunE:    command.RunE(logsCmd),
+	}
+	logsCmd.AddNamespaceFlags(cmd.Flags(), false)
+	logsCmd.AddScopedFlags(cmd.Flags(), true)
+	logsCmd.AddLogFlags(cmd)
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided
+func (c *LogsCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.Name = args[0]
+
+	return nil
+}
+
+// Run prints the events of the broker with the requested name.
+func (c *LogsCmd) Run() error {
+	scopeOpts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	broker, err := c.App.RetrieveBrokerByID(c.Name, scopeOpts)
+	if err != nil {
+		if strings.Contains(err.Error(), servicecatalog.MultipleBrokersFoundError) {
+			return fmt.Errorf("%s, please specify a scope with --scope", err)
+		}
+		return err
+	}
+
+	kind := "ServiceBroker"
+	if broker.GetNamespace() == "" {
+		kind = "ClusterServiceBroker"
+	}
+	return c.WriteEvents(c.Context, broker.GetNamespace(), kind, broker.GetName())
+}
@@ -46,6 +46,7 @@ type RegisterCmd struct {
 	RelistBehavior    string
 	RelistDuration    time.Duration
 	URL               string
+	ValidateOnly      bool
 }
 
 // NewRegisterCmd builds a "svcat register" command
@@ -60,6 +61,9 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 		Short: "Registers a new broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat register mysqlbroker --url http://mysqlbroker.com
+		svcat register mysqlbroker --url http://mysqlbroker.com --basic-secret mysqlbroker-auth --validate-only
+		svcat register mysqlbroker --url http://mysqlbroker.com --relist-duration 1h
+		svcat register mysqlbroker --url http://mysqlbroker.com --relist-behavior manual --scope namespace --namespace dev
 		`),
 		PreRunE: command.PreRunE(registerCmd),
 		RunE:    command.RunE(registerCmd),
@@ -80,9 +84,11 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringVar(&registerCmd.RelistBehavior, "relist-behavior", "",
 		"Behavior for relisting the broker's catalog. Valid options are manual or duration. Defaults to duration with an interval of 15m.")
 	cmd.Flags().DurationVar(&registerCmd.RelistDuration, "relist-duration", 0*time.Second,
-		"Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h")
+		"Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h. Implies --relist-behavior duration, and cannot be used with --relist-behavior manual.")
 	cmd.Flags().BoolVar(&registerCmd.SkipTLS, "skip-tls", false,
 		"Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead.")
+	cmd.Flags().BoolVar(&registerCmd.ValidateOnly, "validate-only", false,
+		"Retrieves the catalog of the broker to check the URL and credentials, without registering the broker")
 	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
 	registerCmd.AddScopedFlags(cmd.Flags(), false)
 	registerCmd.AddWaitFlags(cmd)
@@ -110,9 +116,21 @@ func (c *RegisterCmd) Validate(args []string) error {
 	if c.RelistBehavior != "" {
 		c.RelistBehavior = strings.ToLower(c.RelistBehavior)
 		if c.RelistBehavior != "duration" && c.RelistBehavior != "manual" {
-			return fmt.Errorf("invalid --relist-duration value, allowed values are: duration, manual")
+			return fmt.Errorf("invalid --relist-behavior value, allowed values are: duration, manual")
 		}
 	}
+	if c.RelistDuration < 0 {
+		return fmt.Errorf("invalid --relist-duration value, it must be greater than 0")
+	}
+	if c.RelistDuration > 0 {
+		if c.RelistBehavior == "manual" {
+			return fmt.Errorf("cannot use --relist-duration with --relist-behavior manual")
+		}
+		c.RelistBehavior = "duration"
+	}
+	if c.ValidateOnly && c.Wait {
+		return fmt.Errorf("cannot use --wait with --validate-only")
+	}
 	return nil
 }
 
@@ -133,11 +151,22 @@ func (c *RegisterCmd) Run() error {
 	}
 	if c.RelistBehavior == "duration" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorDuration
-		opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+		if c.RelistDuration > 0 {
+			opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+		}
 	} else if c.RelistBehavior == "manual" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorManual
 	}
 
+	if c.ValidateOnly {
+		summary, err := c.Context.App.ValidateBroker(c.BrokerName, c.URL, opts)
+		if err != nil {
+			return err
+		}
+		output.WriteBrokerCatalogSummary(c.Output, c.URL, summary)
+		return nil
+	}
+
 	broker, err := c.Context.App.Register(c.BrokerName, c.URL, opts, scopeOpts)
 	if err != nil {
 		return err
@@ -147,7 +176,7 @@ func (c *RegisterCmd) Run() error {
 		fmt.Fprintln(c.Output, "Waiting for the broker to be registered...")
 		finalBroker, err := c.Context.App.WaitForBroker(c.BrokerName, scopeOpts, c.Interval, c.Timeout)
 		if err == nil {
-			broker = finalBroker.(*v1beta1.ClusterServiceBroker)
+			broker = finalBroker
 		}
 
 		output.WriteBrokerDetails(c.Output, broker)
@@ -81,6 +81,10 @@ var _ = Describe("Register Command", func() {
 			Expect(skipTLSFlag).NotTo(BeNil())
 			Expect(skipTLSFlag.Usage).To(ContainSubstring("Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead."))
 
+			validateOnlyFlag := cmd.Flags().Lookup("validate-only")
+			Expect(validateOnlyFlag).NotTo(BeNil())
+			Expect(validateOnlyFlag.Usage).To(ContainSubstring("without registering the broker"))
+
 			waitFlag := cmd.Flags().Lookup("wait")
 			Expect(waitFlag).NotTo(BeNil())
 			timeoutFlag := cmd.Flags().Lookup("timeout")
@@ -127,7 +131,7 @@ var _ = Describe("Register Command", func() {
 			}
 			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value, allowed values are: duration, manual"))
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-behavior value, allowed values are: duration, manual"))
 
 			cmd = RegisterCmd{
 				RelistBehavior: "Duration",
@@ -141,6 +145,41 @@ var _ = Describe("Register Command", func() {
 			err = cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).NotTo(HaveOccurred())
 		})
+		It("implies the duration relist behavior when a relist duration is provided", func() {
+			cmd := RegisterCmd{
+				RelistDuration: time.Hour,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.RelistBehavior).To(Equal("duration"))
+		})
+		It("errors if a relist duration is provided with the manual relist behavior", func() {
+			cmd := RegisterCmd{
+				RelistBehavior: "Manual",
+				RelistDuration: time.Hour,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use --relist-duration with --relist-behavior manual"))
+		})
+		It("errors if the relist duration is negative", func() {
+			cmd := RegisterCmd{
+				RelistDuration: -time.Minute,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value, it must be greater than 0"))
+		})
+		It("errors if both --validate-only and --wait are provided", func() {
+			cmd := RegisterCmd{
+				ValidateOnly: true,
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Wait = true
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use --wait with --validate-only"))
+		})
 	})
 	Describe("Run", func() {
 		var (
@@ -293,6 +332,89 @@ var _ = Describe("Register Command", func() {
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Registers a namespaced broker with the manual relist behavior and waits for it", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			namespacedBroker := &v1beta1.ServiceBroker{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      brokerName,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
+						RelistBehavior: v1beta1.ServiceBrokerRelistBehaviorManual,
+						URL:            brokerURL,
+					},
+				},
+			}
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterReturns(namespacedBroker, nil)
+			fakeSDK.WaitForBrokerReturns(namespacedBroker, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BrokerName:     brokerName,
+				Namespaced:     command.NewNamespaced(cxt),
+				RelistBehavior: "manual",
+				Scoped:         command.NewScoped(),
+				URL:            brokerURL,
+				Waitable:       command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Scope = servicecatalog.NamespaceScope
+			cmd.Waitable.ApplyWaitFlags()
+			cmd.Wait = true
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(1))
+			_, _, returnedOpts, returnedScopeOpts := fakeSDK.RegisterArgsForCall(0)
+			Expect(returnedOpts.RelistBehavior).To(Equal(v1beta1.ServiceBrokerRelistBehaviorManual))
+			Expect(returnedOpts.RelistDuration).To(BeNil())
+			Expect(returnedScopeOpts.Scope.Matches(servicecatalog.NamespaceScope)).To(BeTrue())
+			Expect(fakeSDK.WaitForBrokerCallCount()).To(Equal(1))
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(brokerName))
+			Expect(output).To(ContainSubstring(namespace))
+		})
+		It("Only validates the broker when ValidateOnly==true", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.ValidateBrokerReturns(&servicecatalog.BrokerCatalogSummary{APIVersion: "2.13", Classes: 2, Plans: 3}, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BasicSecret:  basicSecret,
+				BrokerName:   brokerName,
+				Namespaced:   command.NewNamespaced(cxt),
+				Scoped:       command.NewScoped(),
+				Waitable:     command.NewWaitable(),
+				URL:          brokerURL,
+				ValidateOnly: true,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(0))
+			Expect(fakeSDK.ValidateBrokerCallCount()).To(Equal(1))
+			returnedName, returnedURL, returnedOpts := fakeSDK.ValidateBrokerArgsForCall(0)
+			Expect(returnedName).To(Equal(brokerName))
+			Expect(returnedURL).To(Equal(brokerURL))
+			Expect(*returnedOpts).To(Equal(servicecatalog.RegisterOptions{
+				BasicSecret: basicSecret,
+				Namespace:   namespace,
+			}))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(brokerURL))
+			Expect(output).To(ContainSubstring("2.13"))
+			Expect(output).To(ContainSubstring("it was not registered"))
+		})
 		It("Calls the SDK's WaitForBroker method with the passed in interval and timeout when Wait==true", func() {
 			interval := 1 * time.Second
 			timeout := 1 * time.Minute
@@ -66,12 +66,12 @@ func (c *MarketplaceCmd) Run() error {
 		Namespace: c.Namespace,
 		Scope:     servicecatalog.AllScope,
 	}
-	classes, err := c.App.RetrieveClasses(opts)
+	classes, err := c.App.RetrieveClasses(opts, "")
 	if err != nil {
 		return err
 	}
 	plans := make([][]servicecatalog.Plan, len(classes))
-	classPlans, err := c.App.RetrievePlans("", opts)
+	classPlans, err := c.App.RetrievePlans("", opts, "")
 	if err != nil {
 		return err
 	}
@@ -149,14 +149,14 @@ var _ = Describe("Register Command", func() {
 			err := cmd.Run()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-			scopeOpts := fakeSDK.RetrieveClassesArgsForCall(0)
+			scopeOpts, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 			Expect(scopeOpts).To(Equal(servicecatalog.ScopeOptions{
 				Scope:     servicecatalog.AllScope,
 				Namespace: namespace,
 			}))
 
 			Expect(fakeSDK.RetrievePlansCallCount()).To(Equal(1))
-			class, scopeOpts := fakeSDK.RetrievePlansArgsForCall(0)
+			class, scopeOpts, _ := fakeSDK.RetrievePlansArgsForCall(0)
 			Expect(class).To(Equal(""))
 			Expect(scopeOpts).To(Equal(servicecatalog.ScopeOptions{
 				Scope:     servicecatalog.AllScope,
@@ -31,6 +31,7 @@ type DescribeCmd struct {
 	*command.Context
 	*command.Namespaced
 	*command.Scoped
+	*command.Described
 
 	LookupByKubeName bool
 	KubeName         string
@@ -43,6 +44,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Context:    cxt,
 		Namespaced: command.NewNamespaced(cxt),
 		Scoped:     command.NewScoped(),
+		Described:  command.NewDescribed(),
 	}
 	cmd := &cobra.Command{
 		Use:     "class NAME",
@@ -51,6 +53,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat describe class mysqldb
   svcat describe class --kube-name 997b8372-8dac-40ac-ae65-758b4a5075a5
+  svcat describe class mysqldb --output yaml
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -64,6 +67,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 	)
 	describeCmd.AddNamespaceFlags(cmd.Flags(), true)
 	describeCmd.AddScopedFlags(cmd.Flags(), true)
+	describeCmd.AddOutputFlags(cmd.Flags())
 
 	return cmd
 }
@@ -110,14 +114,12 @@ func (c *DescribeCmd) Run() error {
 		return err
 	}
 
-	output.WriteClassDetails(c.Output, class)
-
 	opts := servicecatalog.ScopeOptions{Scope: servicecatalog.AllScope}
-	plans, err := c.App.RetrievePlans(class.GetName(), opts)
+	plans, err := c.App.RetrievePlans(class.GetName(), opts, "")
 	if err != nil {
 		return err
 	}
-	output.WriteAssociatedPlans(c.Output, plans)
+	output.WriteClassDescription(c.Output, c.OutputFormat, class, plans)
 
 	return nil
 }
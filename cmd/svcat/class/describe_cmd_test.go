@@ -151,6 +151,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:    cxt,
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 				Name:       className,
 				Scoped:     command.NewScoped(),
 			}
@@ -169,7 +170,7 @@ var _ = Describe("Describe Command", func() {
 			Expect(returnedScopeOpts).To(Equal(scopeOpts))
 
 			Expect(fakeSDK.RetrievePlansCallCount()).To(Equal(1))
-			returnedName, returnedScopeOpts = fakeSDK.RetrievePlansArgsForCall(0)
+			returnedName, returnedScopeOpts, _ = fakeSDK.RetrievePlansArgsForCall(0)
 			Expect(returnedName).To(Equal(classKubeName))
 			scopeOpts = servicecatalog.ScopeOptions{
 				Scope: servicecatalog.AllScope,
@@ -194,6 +195,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:    cxt,
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 				Name:       namespacedClassName,
 				Scoped:     command.NewScoped(),
 			}
@@ -227,6 +229,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:          cxt,
 				Namespaced:       command.NewNamespaced(cxt),
+				Described:        command.NewDescribed(),
 				KubeName:         classKubeName,
 				LookupByKubeName: true,
 				Scoped:           command.NewScoped(),
@@ -262,6 +265,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:    cxt,
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 				Name:       className,
 				Scoped:     command.NewScoped(),
 			}
@@ -284,6 +288,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:    cxt,
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 				Name:       className,
 				Scoped:     command.NewScoped(),
 			}
@@ -307,6 +312,7 @@ var _ = Describe("Describe Command", func() {
 			cmd := DescribeCmd{
 				Context:    cxt,
 				Namespaced: command.NewNamespaced(cxt),
+				Described:  command.NewDescribed(),
 				Name:       className,
 				Scoped:     command.NewScoped(),
 			}
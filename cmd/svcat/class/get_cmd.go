@@ -31,8 +31,11 @@ type GetCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	*command.Formatted
+	*command.Selected
+	*command.Sorted
 
 	LookupByKubeName bool
+	ShowSchemas      bool
 	KubeName         string
 	Name             string
 }
@@ -43,6 +46,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Namespaced: command.NewNamespaced(cxt),
 		Scoped:     command.NewScoped(),
 		Formatted:  command.NewFormatted(),
+		Selected:   command.NewSelected(),
+		Sorted:     command.NewSorted(),
 	}
 	cmd := &cobra.Command{
 		Use:     "classes [NAME]",
@@ -52,6 +57,9 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get classes
   svcat get classes --scope cluster
   svcat get classes --scope namespace --namespace dev
+  svcat get classes -l team=payments
+  svcat get classes --sort-by '{.spec.clusterServiceBrokerName}'
+  svcat get classes -o json --show-schemas
   svcat get class mysqldb
   svcat get class --kube-name 997b8372-8dac-40ac-ae65-758b4a5075a5
 `),
@@ -65,9 +73,17 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by its Kubernetes name (the default is by external name)",
 	)
+	cmd.Flags().BoolVar(
+		&getCmd.ShowSchemas,
+		"show-schemas",
+		false,
+		"Whether or not to include the parameter schemas of the plans in the json and yaml output",
+	)
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddScopedFlags(cmd.Flags(), true)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddSortFlag(cmd)
 	return cmd
 }
 
@@ -79,6 +95,14 @@ func (c *GetCmd) Validate(args []string) error {
 		} else {
 			c.Name = args[0]
 		}
+
+		if c.LabelSelector != "" {
+			return fmt.Errorf("selector is not supported when specifiying class name")
+		}
+
+		if c.SortBy != nil {
+			return fmt.Errorf("sort-by is not supported when specifiying class name")
+		}
 	}
 
 	return nil
@@ -99,11 +123,24 @@ func (c *GetCmd) getAll() error {
 		Namespace: c.Namespace,
 		Scope:     c.Scope,
 	}
-	classes, err := c.App.RetrieveClasses(opts)
+	classes, err := c.App.RetrieveClasses(opts, c.LabelSelector)
 	if err != nil {
 		return err
 	}
-	output.WriteClassList(c.Output, c.OutputFormat, classes...)
+	if err := output.SortList(classes, c.SortBy); err != nil {
+		return err
+	}
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, classes)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPathList(c.Output, c.JSONPath, classes)
+	}
+	plans, err := c.retrievePlans(opts)
+	if err != nil {
+		return err
+	}
+	output.WriteClassList(c.Output, c.OutputFormat, plans, c.ShowSchemas, classes...)
 	return nil
 }
 
@@ -126,6 +163,32 @@ func (c *GetCmd) get() error {
 		return err
 	}
 
-	output.WriteClass(c.Output, c.OutputFormat, class)
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, class)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPath(c.Output, c.JSONPath, class)
+	}
+	planOpts := servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope}
+	if !class.IsClusterServiceClass() {
+		planOpts = servicecatalog.ScopeOptions{
+			Namespace: class.GetNamespace(),
+			Scope:     servicecatalog.NamespaceScope,
+		}
+	}
+	plans, err := c.retrievePlans(planOpts)
+	if err != nil {
+		return err
+	}
+	output.WriteClass(c.Output, c.OutputFormat, plans, c.ShowSchemas, class)
 	return nil
 }
+
+// retrievePlans lists the plans whose schemas are summarized in the json and
+// yaml output. The other output formats do not need them.
+func (c *GetCmd) retrievePlans(opts servicecatalog.ScopeOptions) ([]servicecatalog.Plan, error) {
+	if c.OutputFormat != output.FormatJSON && c.OutputFormat != output.FormatYAML {
+		return nil, nil
+	}
+	return c.App.RetrievePlans("", opts, "")
+}
@@ -58,12 +58,12 @@ var _ = Describe("Get Class Command", func() {
 	})
 	Describe("Validate", func() {
 		It("allows class name arg to be empty", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			err := cmd.Validate([]string{})
 			Expect(err).To(BeNil())
 		})
 		It("optionally parses the class name argument", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			err := cmd.Validate([]string{"foobarclass"})
 			Expect(err).To(BeNil())
 			Expect(cmd.Name).To(Equal("foobarclass"))
@@ -73,6 +73,12 @@ var _ = Describe("Get Class Command", func() {
 			Expect(err).To(BeNil())
 			Expect(cmd.KubeName).To(Equal("foobarclass"))
 		})
+		It("errors when a selector is combined with the class name argument", func() {
+			cmd := &GetCmd{Selected: &command.Selected{LabelSelector: "team=payments"}}
+			err := cmd.Validate([]string{"foobarclass"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("selector is not supported"))
+		})
 	})
 	Describe("Run", func() {
 		var (
@@ -132,6 +138,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -141,7 +149,7 @@ var _ = Describe("Get Class Command", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-				returnedScopeOpts := fakeSDK.RetrieveClassesArgsForCall(0)
+				returnedScopeOpts, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 				scopeOpts := servicecatalog.ScopeOptions{
 					Scope:     servicecatalog.AllScope,
 					Namespace: namespace,
@@ -155,6 +163,31 @@ var _ = Describe("Get Class Command", func() {
 				Expect(output).To(ContainSubstring(namespace))
 				Expect(output).To(ContainSubstring(namespacedClassToReturn.Spec.Description))
 			})
+			It("Marks the classes removed from the broker catalog", func() {
+				outputBuffer := &bytes.Buffer{}
+
+				classToReturn.Status.RemovedFromBrokerCatalog = true
+				fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+				fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+				fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{classToReturn, namespacedClassToReturn}, nil)
+				fakeApp.SvcatClient = fakeSDK
+				cxt := svcattest.NewContext(outputBuffer, fakeApp)
+				cmd := GetCmd{
+					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
+					Namespaced: command.NewNamespaced(cxt),
+					Scoped:     command.NewScoped(),
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Scope = servicecatalog.AllScope
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				output := outputBuffer.String()
+				Expect(output).To(ContainSubstring(className + " (REMOVED)"))
+				Expect(output).NotTo(ContainSubstring(namespacedClassName + " (REMOVED)"))
+			})
 			It("Calls the pkg/svcat libs RetrieveClasses  with namespace scope and current namespace", func() {
 				outputBuffer := &bytes.Buffer{}
 
@@ -165,6 +198,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -175,7 +210,7 @@ var _ = Describe("Get Class Command", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-				returnedScopeOpts := fakeSDK.RetrieveClassesArgsForCall(0)
+				returnedScopeOpts, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 				scopeOpts := servicecatalog.ScopeOptions{
 					Scope:     servicecatalog.NamespaceScope,
 					Namespace: namespace,
@@ -199,6 +234,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -208,7 +245,7 @@ var _ = Describe("Get Class Command", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-				returnedScopeOpts := fakeSDK.RetrieveClassesArgsForCall(0)
+				returnedScopeOpts, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 				scopeOpts := servicecatalog.ScopeOptions{
 					Scope:     servicecatalog.ClusterScope,
 					Namespace: namespace,
@@ -222,6 +259,30 @@ var _ = Describe("Get Class Command", func() {
 				Expect(output).NotTo(ContainSubstring(namespace))
 				Expect(output).NotTo(ContainSubstring(namespacedClassToReturn.Spec.Description))
 			})
+			It("Passes the label selector to the pkg/svcat libs RetrieveClasses", func() {
+				outputBuffer := &bytes.Buffer{}
+
+				fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+				fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+				fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{classToReturn}, nil)
+				fakeApp.SvcatClient = fakeSDK
+				cxt := svcattest.NewContext(outputBuffer, fakeApp)
+				cmd := GetCmd{
+					Formatted:  command.NewFormatted(),
+					Selected:   &command.Selected{LabelSelector: "team=payments"},
+					Sorted:     command.NewSorted(),
+					Namespaced: command.NewNamespaced(cxt),
+					Scoped:     command.NewScoped(),
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Scope = servicecatalog.AllScope
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
+				_, returnedSelector := fakeSDK.RetrieveClassesArgsForCall(0)
+				Expect(returnedSelector).To(Equal("team=payments"))
+			})
 		})
 		Context("getting a single class", func() {
 			It("Calls the pkg/svcat libs RetrieveClassByName when getting a single class", func() {
@@ -234,6 +295,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -267,6 +330,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -303,6 +368,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -336,6 +403,8 @@ var _ = Describe("Get Class Command", func() {
 				cxt := svcattest.NewContext(outputBuffer, fakeApp)
 				cmd := GetCmd{
 					Formatted:  command.NewFormatted(),
+					Selected:   command.NewSelected(),
+					Sorted:     command.NewSorted(),
 					Namespaced: command.NewNamespaced(cxt),
 					Scoped:     command.NewScoped(),
 				}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// CleanupCmd contains the info needed to delete the classes and plans that
+// were removed from the catalog of their broker
+type CleanupCmd struct {
+	*command.Namespaced
+	*command.Scoped
+
+	DryRun bool
+}
+
+// NewCleanupCmd builds a "svcat cleanup" command
+func NewCleanupCmd(cxt *command.Context) *cobra.Command {
+	cleanupCmd := &CleanupCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Deletes the classes and plans that were removed from their broker's catalog and are not used by any instance",
+		Example: command.NormalizeExamples(`
+  svcat cleanup --dry-run
+  svcat cleanup
+  svcat cleanup --scope cluster
+  svcat cleanup --scope namespace --namespace dev
+`),
+		PreRunE: command.PreRunE(cleanupCmd),
+		RunE:    command.RunE(cleanupCmd),
+	}
+	cmd.Flags().BoolVar(
+		&cleanupCmd.DryRun,
+		"dry-run",
+		false,
+		"Print the classes and plans that would be deleted, without deleting them",
+	)
+	cleanupCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cleanupCmd.AddScopedFlags(cmd.Flags(), true)
+	return cmd
+}
+
+// Validate checks that no arguments have been provided
+func (c *CleanupCmd) Validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("cleanup does not accept arguments")
+	}
+	return nil
+}
+
+// Run runs the command
+func (c *CleanupCmd) Run() error {
+	return c.Cleanup()
+}
+
+// Cleanup calls out to the pkg lib to find the classes and plans that can be
+// deleted, and deletes them unless this is a dry run. Plans are deleted
+// before their classes.
+func (c *CleanupCmd) Cleanup() error {
+	opts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     c.Scope,
+	}
+	removed, err := c.App.RetrieveRemovedCatalog(opts)
+	if err != nil {
+		return err
+	}
+
+	if c.DryRun {
+		output.WriteRemovedCatalog(c.Output, removed)
+		return nil
+	}
+
+	if len(removed.Plans) == 0 && len(removed.Classes) == 0 {
+		fmt.Fprintln(c.Output, "No classes or plans to clean up")
+		return nil
+	}
+	for _, plan := range removed.Plans {
+		if err := c.App.DeletePlan(plan); err != nil {
+			return err
+		}
+		output.WriteDeletedResourceName(c.Output, output.FormatCatalogObject(plan))
+	}
+	for _, class := range removed.Classes {
+		if err := c.App.DeleteClass(class); err != nil {
+			return err
+		}
+		output.WriteDeletedResourceName(c.Output, output.FormatCatalogObject(class))
+	}
+	return nil
+}
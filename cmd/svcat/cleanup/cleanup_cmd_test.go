@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup_test
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/cleanup"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Cleanup Command", func() {
+	var (
+		outputBuffer *bytes.Buffer
+		fakeSDK      *servicecatalogfakes.FakeSvcatClient
+		cmd          *CleanupCmd
+		removed      *servicecatalog.RemovedCatalog
+	)
+
+	BeforeEach(func() {
+		outputBuffer = &bytes.Buffer{}
+		fakeApp, _ := svcat.NewApp(nil, nil, "default")
+		fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+		fakeApp.SvcatClient = fakeSDK
+		cxt := svcattest.NewContext(outputBuffer, fakeApp)
+		cmd = &CleanupCmd{
+			Namespaced: command.NewNamespaced(cxt),
+			Scoped:     command.NewScoped(),
+		}
+		cmd.Namespace = "default"
+		cmd.Scope = servicecatalog.AllScope
+
+		removed = &servicecatalog.RemovedCatalog{
+			Classes: []servicecatalog.Class{
+				&v1beta1.ClusterServiceClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "removed-class"},
+					Spec: v1beta1.ClusterServiceClassSpec{
+						CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "oldclass"},
+					},
+				},
+			},
+			Plans: []servicecatalog.Plan{
+				&v1beta1.ClusterServicePlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "removed-plan"},
+					Spec: v1beta1.ClusterServicePlanSpec{
+						CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "oldplan"},
+					},
+				},
+				&v1beta1.ServicePlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "removed-ns-plan", Namespace: "default"},
+					Spec: v1beta1.ServicePlanSpec{
+						CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "oldnsplan"},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("NewCleanupCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewCleanupCmd(cxt)
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("cleanup"))
+			Expect(cmd.Short).To(ContainSubstring("removed from their broker's catalog"))
+			Expect(cmd.Example).To(ContainSubstring("svcat cleanup --dry-run"))
+
+			dryRunFlag := cmd.Flags().Lookup("dry-run")
+			Expect(dryRunFlag).NotTo(BeNil())
+			Expect(dryRunFlag.DefValue).To(Equal("false"))
+			scopeFlag := cmd.Flags().Lookup("scope")
+			Expect(scopeFlag).NotTo(BeNil())
+			Expect(scopeFlag.DefValue).To(Equal(servicecatalog.AllScope))
+		})
+	})
+	Describe("Validate", func() {
+		It("errors if arguments are provided", func() {
+			err := cmd.Validate([]string{"foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not accept arguments"))
+		})
+	})
+	Describe("Cleanup", func() {
+		It("Prints what would be deleted without deleting it in a dry run", func() {
+			fakeSDK.RetrieveRemovedCatalogReturns(removed, nil)
+			cmd.DryRun = true
+
+			err := cmd.Cleanup()
+
+			Expect(err).NotTo(HaveOccurred())
+			opts := fakeSDK.RetrieveRemovedCatalogArgsForCall(0)
+			Expect(opts.Namespace).To(Equal("default"))
+			Expect(opts.Scope.Matches(servicecatalog.AllScope)).To(BeTrue())
+			Expect(fakeSDK.DeletePlanCallCount()).To(Equal(0))
+			Expect(fakeSDK.DeleteClassCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(Equal(
+				"would delete clusterserviceplan removed-plan (oldplan)\n" +
+					"would delete serviceplan default/removed-ns-plan (oldnsplan)\n" +
+					"would delete clusterserviceclass removed-class (oldclass)\n"))
+		})
+		It("Deletes the plans before the classes", func() {
+			fakeSDK.RetrieveRemovedCatalogReturns(removed, nil)
+
+			err := cmd.Cleanup()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.DeletePlanCallCount()).To(Equal(2))
+			Expect(fakeSDK.DeletePlanArgsForCall(0)).To(Equal(removed.Plans[0]))
+			Expect(fakeSDK.DeletePlanArgsForCall(1)).To(Equal(removed.Plans[1]))
+			Expect(fakeSDK.DeleteClassCallCount()).To(Equal(1))
+			Expect(fakeSDK.DeleteClassArgsForCall(0)).To(Equal(removed.Classes[0]))
+			Expect(outputBuffer.String()).To(Equal(
+				"deleted clusterserviceplan removed-plan (oldplan)\n" +
+					"deleted serviceplan default/removed-ns-plan (oldnsplan)\n" +
+					"deleted clusterserviceclass removed-class (oldclass)\n"))
+		})
+		It("Does not delete the classes when a plan can not be deleted", func() {
+			fakeSDK.RetrieveRemovedCatalogReturns(removed, nil)
+			fakeSDK.DeletePlanReturnsOnCall(1, errors.New("unable to delete plan removed-ns-plan"))
+
+			err := cmd.Cleanup()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to delete plan removed-ns-plan"))
+			Expect(fakeSDK.DeleteClassCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(Equal("deleted clusterserviceplan removed-plan (oldplan)\n"))
+		})
+		It("Reports when there is nothing to clean up", func() {
+			fakeSDK.RetrieveRemovedCatalogReturns(&servicecatalog.RemovedCatalog{}, nil)
+
+			err := cmd.Cleanup()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputBuffer.String()).To(Equal("No classes or plans to clean up\n"))
+		})
+		It("Bubbles up errors", func() {
+			fakeSDK.RetrieveRemovedCatalogReturns(nil, errors.New("unable to list instances"))
+
+			err := cmd.Cleanup()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to list instances"))
+			Expect(outputBuffer.String()).To(BeEmpty())
+		})
+	})
+})
@@ -64,12 +64,30 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 				return err
 			}
 		}
+		if selectedCmd, ok := cmd.(HasSelectorFlag); ok {
+			err := selectedCmd.ApplySelectorFlag(c)
+			if err != nil {
+				return err
+			}
+		}
+		if sortedCmd, ok := cmd.(HasSortFlag); ok {
+			err := sortedCmd.ApplySortFlag(c)
+			if err != nil {
+				return err
+			}
+		}
 		if waitCmd, ok := cmd.(HasWaitFlags); ok {
 			err := waitCmd.ApplyWaitFlags()
 			if err != nil {
 				return err
 			}
 		}
+		if logCmd, ok := cmd.(HasLogFlags); ok {
+			err := logCmd.ApplyLogFlags()
+			if err != nil {
+				return err
+			}
+		}
 		// validate the args and print help info if needed.
 		err := cmd.Validate(args)
 		if err != nil {
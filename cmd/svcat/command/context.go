@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Context is ambient data necessary to run any svcat command.
@@ -33,4 +34,10 @@ type Context struct {
 
 	// Viper configuration
 	Viper *viper.Viper
+
+	// ClientConfig is the kubeconfig used to connect to the API server, resolved from the flags
+	ClientConfig clientcmd.ClientConfig
+
+	// KubeContext is the name of the kubeconfig context selected with --context, if any
+	KubeContext string
 }
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/spf13/pflag"
+)
+
+// Described is the base command of the describe commands, whose details can
+// be printed as JSON or YAML instead of the human view.
+type Described struct {
+	OutputFormat string
+}
+
+// NewDescribed initializes a new described command.
+func NewDescribed() *Described {
+	return &Described{
+		OutputFormat: output.FormatTable,
+	}
+}
+
+// AddOutputFlags adds the output flag of a describe command.
+//   --output
+func (c *Described) AddOutputFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&c.OutputFormat, "output", "o", output.FormatTable,
+		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table",
+	)
+}
+
+// ApplyFormatFlags validates and persists the output flag.
+//   --output
+func (c *Described) ApplyFormatFlags(flags *pflag.FlagSet) error {
+	c.OutputFormat = strings.ToLower(c.OutputFormat)
+
+	switch c.OutputFormat {
+	case output.FormatTable, output.FormatJSON, output.FormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output format %q, allowed values are: table, json and yaml", c.OutputFormat)
+	}
+}
+
+// IsStructured returns whether the details are printed as JSON or YAML.
+func (c *Described) IsStructured() bool {
+	return c.OutputFormat == output.FormatJSON || c.OutputFormat == output.FormatYAML
+}
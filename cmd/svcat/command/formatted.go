@@ -28,12 +28,27 @@ import (
 type HasFormatFlags interface {
 	// ApplyFormatFlags persists the format-related flags:
 	// * --output
+	// * --no-headers
+	// * --show-kind
 	ApplyFormatFlags(lags *pflag.FlagSet) error
 }
 
 // Formatted is the base command of all svcat commands that support customizable output formats.
 type Formatted struct {
 	OutputFormat string
+
+	// Columns are the columns to print with the custom-columns output format.
+	Columns []output.Column
+
+	// NoHeaders omits the column headers of the custom-columns output format.
+	NoHeaders bool
+
+	// ShowKind prefixes the names of the name output format with the type
+	// of their resource.
+	ShowKind bool
+
+	// JSONPath is the template to print with the jsonpath output format.
+	JSONPath *output.JSONPath
 }
 
 // NewFormatted command.
@@ -46,19 +61,59 @@ func NewFormatted() *Formatted {
 // AddOutputFlags adds common output flags to a command that can have variable output formats.
 func (c *Formatted) AddOutputFlags(flags *pflag.FlagSet) {
 	flags.StringVarP(&c.OutputFormat, "output", "o", output.FormatTable,
-		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table",
+		"The output format to use. Valid options are table, wide, json, yaml, name, custom-columns=HEADER:JSONPATH,... or jsonpath=TEMPLATE. If not present, defaults to table",
+	)
+	flags.BoolVar(&c.NoHeaders, "no-headers", false,
+		"When using the custom-columns output format, don't print the column headers",
+	)
+	flags.BoolVar(&c.ShowKind, "show-kind", false,
+		"When using the name output format, prefix each name with the type of its resource and print the Kubernetes name, e.g. clusterserviceclass/<uuid>, for use with kubectl",
 	)
 }
 
 // ApplyFormatFlags persists the format-related flags:
 // * --output
+// * --no-headers
+// * --show-kind
 func (c *Formatted) ApplyFormatFlags(flags *pflag.FlagSet) error {
+	// The columns of the custom-columns format are case sensitive
+	format := strings.SplitN(c.OutputFormat, "=", 2)
+	if strings.ToLower(format[0]) == output.FormatCustomColumns {
+		if len(format) != 2 {
+			return fmt.Errorf("invalid --output format %q, expected custom-columns=HEADER:JSONPATH,...", c.OutputFormat)
+		}
+		columns, err := output.ParseCustomColumns(format[1])
+		if err != nil {
+			return fmt.Errorf("invalid --output format %q: %v", c.OutputFormat, err)
+		}
+		c.OutputFormat = output.FormatCustomColumns
+		c.Columns = columns
+		return nil
+	}
+	if strings.ToLower(format[0]) == output.FormatJSONPath {
+		if len(format) != 2 {
+			return fmt.Errorf("invalid --output format %q, expected jsonpath=TEMPLATE", c.OutputFormat)
+		}
+		template, err := output.ParseJSONPath(format[1])
+		if err != nil {
+			return fmt.Errorf("invalid --output format %q: %v", c.OutputFormat, err)
+		}
+		c.OutputFormat = output.FormatJSONPath
+		c.JSONPath = template
+		return nil
+	}
+
 	c.OutputFormat = strings.ToLower(c.OutputFormat)
 
 	switch c.OutputFormat {
-	case output.FormatTable, output.FormatJSON, output.FormatYAML:
+	case output.FormatName:
+		if c.ShowKind {
+			c.OutputFormat = output.FormatNameWithKind
+		}
+		return nil
+	case output.FormatTable, output.FormatWide, output.FormatJSON, output.FormatYAML:
 		return nil
 	default:
-		return fmt.Errorf("invalid --output format %q, allowed values are: table, json and yaml", c.OutputFormat)
+		return fmt.Errorf("invalid --output format %q, allowed values are: table, wide, json, yaml, name, custom-columns=HEADER:JSONPATH,... and jsonpath=TEMPLATE", c.OutputFormat)
 	}
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// HasLogFlags represents a command that supports printing the events of an object.
+type HasLogFlags interface {
+	// ApplyLogFlags validates and persists the log related flags.
+	//   --since
+	//   --follow
+	ApplyLogFlags() error
+}
+
+// Logged adds support to a command for printing the events of an object.
+type Logged struct {
+	Follow   bool
+	rawSince string
+	Since    *time.Duration
+}
+
+// NewLogged initializes a new logged command.
+func NewLogged() *Logged {
+	return &Logged{}
+}
+
+// AddLogFlags adds the log related flags.
+//   --since
+//   --follow
+func (c *Logged) AddLogFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.rawSince, "since", "",
+		"Only show events newer than a relative duration, specified in human readable format: 30s, 1m, 1h. Defaults to all events.")
+	cmd.Flags().BoolVarP(&c.Follow, "follow", "f", false,
+		"Watch for new events after printing the existing ones.")
+}
+
+// ApplyLogFlags validates and persists the log related flags.
+//   --since
+//   --follow
+func (c *Logged) ApplyLogFlags() error {
+	if c.rawSince == "" {
+		return nil
+	}
+
+	since, err := time.ParseDuration(c.rawSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since value (%s)", err)
+	}
+	c.Since = &since
+
+	return nil
+}
+
+// WriteEvents prints the events involving the specified object,
+// and then prints new events as they occur when --follow is set.
+func (c *Logged) WriteEvents(cxt *Context, ns, kind, name string) error {
+	events, err := cxt.App.RetrieveEvents(ns, kind, name)
+	if err != nil {
+		return err
+	}
+
+	if c.Since != nil {
+		cutoff := time.Now().Add(-*c.Since)
+		recent := events.Items[:0]
+		for _, e := range events.Items {
+			if !servicecatalog.GetEventTime(e).Before(cutoff) {
+				recent = append(recent, e)
+			}
+		}
+		events.Items = recent
+	}
+
+	output.WriteEventList(cxt.Output, events)
+
+	if !c.Follow {
+		return nil
+	}
+
+	w, err := cxt.App.WatchEvents(ns, kind, name, events.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for e := range w.ResultChan() {
+		switch e.Type {
+		case watch.Added, watch.Modified:
+			if event, ok := e.Object.(*corev1.Event); ok {
+				output.WriteEvent(cxt.Output, *event)
+			}
+		case watch.Error:
+			return fmt.Errorf("error watching events for %s %q", kind, name)
+		}
+	}
+
+	return nil
+}
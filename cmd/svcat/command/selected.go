@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// HasSelectorFlag represents a command that supports --selector.
+type HasSelectorFlag interface {
+	// ApplySelectorFlag validates and persists the selector related flag.
+	//   --selector
+	ApplySelectorFlag(*cobra.Command) error
+}
+
+// Selected adds support to a command for the --selector flag.
+type Selected struct {
+	LabelSelector string
+}
+
+// NewSelected initializes a new label selected command.
+func NewSelected() *Selected {
+	return &Selected{}
+}
+
+// AddSelectorFlag adds the selector related flag.
+//   --selector
+func (c *Selected) AddSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(
+		"selector",
+		"l",
+		"",
+		"Selector (label query) to filter on, supports '=', '==', '!=', 'in' and 'notin' (e.g. -l team=payments)",
+	)
+}
+
+// ApplySelectorFlag validates and persists the selector related flag.
+//   --selector
+func (c *Selected) ApplySelectorFlag(cmd *cobra.Command) error {
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return err
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid --selector %q: %v", selector, err)
+	}
+	c.LabelSelector = selector
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+// HasSortFlag represents a command that supports --sort-by.
+type HasSortFlag interface {
+	// ApplySortFlag validates and persists the sort related flag.
+	//   --sort-by
+	ApplySortFlag(*cobra.Command) error
+}
+
+// Sorted adds support to a command for the --sort-by flag.
+type Sorted struct {
+	// SortBy is the key the listed items are sorted by, nil to keep the
+	// default order.
+	SortBy *output.SortBy
+}
+
+// NewSorted initializes a new sorted command.
+func NewSorted() *Sorted {
+	return &Sorted{}
+}
+
+// AddSortFlag adds the sort related flag.
+//   --sort-by
+func (c *Sorted) AddSortFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		"sort-by",
+		"",
+		"Sort the list by name, age, status, or a JSONPath expression (e.g. --sort-by '{.metadata.namespace}')",
+	)
+}
+
+// ApplySortFlag validates and persists the sort related flag.
+//   --sort-by
+func (c *Sorted) ApplySortFlag(cmd *cobra.Command) error {
+	key, err := cmd.Flags().GetString("sort-by")
+	if err != nil {
+		return err
+	}
+	c.SortBy, err = output.ParseSortBy(key)
+	return err
+}
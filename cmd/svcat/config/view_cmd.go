@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+)
+
+// ViewCmd contains the info needed to show the configuration used by svcat
+type ViewCmd struct {
+	*command.Context
+
+	Raw bool
+}
+
+// NewViewCmd builds a "svcat config view" command
+func NewViewCmd(cxt *command.Context) *cobra.Command {
+	viewCmd := &ViewCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show the kubeconfig context, API server and namespace used by svcat, and whether the Service Catalog API is reachable",
+		Example: command.NormalizeExamples(`
+  svcat config view
+  svcat config view --raw
+  svcat config view --context staging
+`),
+		PreRunE: command.PreRunE(viewCmd),
+		RunE:    command.RunE(viewCmd),
+	}
+	cmd.Flags().BoolVar(
+		&viewCmd.Raw,
+		"raw",
+		false,
+		"Also show the kubeconfig files, the cluster and user of the context, and the resources served by the Service Catalog API",
+	)
+	return cmd
+}
+
+// Validate checks that no arguments have been provided
+func (c *ViewCmd) Validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("view does not accept arguments")
+	}
+	return nil
+}
+
+// Run runs the command
+func (c *ViewCmd) Run() error {
+	return c.View()
+}
+
+// View resolves the configuration used to connect to the API server in the
+// same way as the other commands, and checks that the Service Catalog API can
+// be reached with it.
+func (c *ViewCmd) View() error {
+	if c.ClientConfig == nil {
+		return fmt.Errorf("the kubeconfig used by svcat is not available")
+	}
+	rawConfig, err := c.ClientConfig.RawConfig()
+	if err != nil {
+		return fmt.Errorf("could not read the kubeconfig: %v", err)
+	}
+	restConfig, err := c.ClientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("could not get the Kubernetes config: %v", err)
+	}
+
+	config := output.ClientConfig{
+		Context:   c.KubeContext,
+		Server:    restConfig.Host,
+		Namespace: c.App.CurrentNamespace,
+		Auth:      authMethod(restConfig),
+		Raw:       c.Raw,
+	}
+	if config.Context == "" {
+		config.Context = rawConfig.CurrentContext
+	}
+	if kubeContext, ok := rawConfig.Contexts[config.Context]; ok {
+		config.Cluster = kubeContext.Cluster
+		config.User = kubeContext.AuthInfo
+	}
+	if access := c.ClientConfig.ConfigAccess(); access != nil {
+		if access.IsExplicitFile() {
+			config.Kubeconfigs = []string{access.GetExplicitFile()}
+		} else {
+			config.Kubeconfigs = access.GetLoadingPrecedence()
+		}
+	}
+
+	resources, err := c.App.ServerResources()
+	if err != nil {
+		config.APIError = err
+	} else {
+		for _, resource := range resources.APIResources {
+			config.Resources = append(config.Resources, resource.Name)
+		}
+	}
+
+	output.WriteClientConfig(c.Output, config)
+	return nil
+}
+
+// authMethod describes how the client authenticates to the API server.
+func authMethod(config *rest.Config) string {
+	switch {
+	case config.AuthProvider != nil:
+		return fmt.Sprintf("Auth provider (%s)", config.AuthProvider.Name)
+	case config.ExecProvider != nil:
+		return fmt.Sprintf("Exec plugin (%s)", config.ExecProvider.Command)
+	case config.BearerToken != "" || config.BearerTokenFile != "":
+		return "Bearer token"
+	case len(config.CertData) > 0 || config.CertFile != "":
+		return "Client certificate"
+	case config.Username != "":
+		return "Basic auth"
+	default:
+		return "None"
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/config"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var _ = Describe("View Command", func() {
+	var (
+		outputBuffer *bytes.Buffer
+		fakeSDK      *servicecatalogfakes.FakeSvcatClient
+		cmd          *ViewCmd
+	)
+
+	BeforeEach(func() {
+		outputBuffer = &bytes.Buffer{}
+		fakeApp, _ := svcat.NewApp(nil, nil, "dev")
+		fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+		fakeApp.SvcatClient = fakeSDK
+		cxt := svcattest.NewContext(outputBuffer, fakeApp)
+
+		kubeconfig := clientcmdapi.NewConfig()
+		kubeconfig.CurrentContext = "prod"
+		kubeconfig.Clusters["prod-cluster"] = &clientcmdapi.Cluster{Server: "https://prod.example.com"}
+		kubeconfig.Clusters["staging-cluster"] = &clientcmdapi.Cluster{Server: "https://staging.example.com"}
+		kubeconfig.AuthInfos["admin"] = &clientcmdapi.AuthInfo{Token: "secret-token"}
+		kubeconfig.AuthInfos["deployer"] = &clientcmdapi.AuthInfo{ClientCertificateData: []byte("cert"), ClientKeyData: []byte("key")}
+		kubeconfig.Contexts["prod"] = &clientcmdapi.Context{Cluster: "prod-cluster", AuthInfo: "admin", Namespace: "dev"}
+		kubeconfig.Contexts["staging"] = &clientcmdapi.Context{Cluster: "staging-cluster", AuthInfo: "deployer"}
+		cxt.ClientConfig = clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{})
+
+		cmd = &ViewCmd{Context: cxt}
+
+		fakeSDK.ServerResourcesReturns(&metav1.APIResourceList{
+			GroupVersion: "servicecatalog.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "clusterservicebrokers"},
+				{Name: "serviceinstances"},
+			},
+		}, nil)
+	})
+
+	Describe("NewViewCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewViewCmd(cxt)
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("view"))
+			Expect(cmd.Short).To(ContainSubstring("whether the Service Catalog API is reachable"))
+			Expect(cmd.Example).To(ContainSubstring("svcat config view --raw"))
+
+			rawFlag := cmd.Flags().Lookup("raw")
+			Expect(rawFlag).NotTo(BeNil())
+			Expect(rawFlag.DefValue).To(Equal("false"))
+		})
+	})
+	Describe("Validate", func() {
+		It("errors if arguments are provided", func() {
+			err := cmd.Validate([]string{"foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not accept arguments"))
+		})
+	})
+	Describe("View", func() {
+		It("shows the context, server, namespace and auth method of the current context", func() {
+			err := cmd.View()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.ServerResourcesCallCount()).To(Equal(1))
+
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`Context:\s+prod`))
+			Expect(output).To(MatchRegexp(`Server:\s+https://prod.example.com`))
+			Expect(output).To(MatchRegexp(`Namespace:\s+dev`))
+			Expect(output).To(MatchRegexp(`Auth:\s+Bearer token`))
+			Expect(output).To(MatchRegexp(`Service Catalog API:\s+Reachable`))
+			Expect(output).NotTo(ContainSubstring("secret-token"))
+			Expect(output).NotTo(ContainSubstring("Cluster:"))
+			Expect(output).NotTo(ContainSubstring("serviceinstances"))
+		})
+		It("shows the context selected with --context", func() {
+			cmd.KubeContext = "staging"
+			cmd.ClientConfig = clientcmd.NewDefaultClientConfig(mustRawConfig(cmd.ClientConfig), &clientcmd.ConfigOverrides{CurrentContext: "staging"})
+
+			err := cmd.View()
+			Expect(err).NotTo(HaveOccurred())
+
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`Context:\s+staging`))
+			Expect(output).To(MatchRegexp(`Server:\s+https://staging.example.com`))
+			Expect(output).To(MatchRegexp(`Auth:\s+Client certificate`))
+		})
+		It("shows more detail with --raw", func() {
+			cmd.Raw = true
+
+			err := cmd.View()
+			Expect(err).NotTo(HaveOccurred())
+
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`Cluster:\s+prod-cluster`))
+			Expect(output).To(MatchRegexp(`User:\s+admin`))
+			Expect(output).To(MatchRegexp(`Resources:\s+clusterservicebrokers, serviceinstances`))
+			Expect(output).NotTo(ContainSubstring("secret-token"))
+		})
+		It("reports that the Service Catalog API is unreachable", func() {
+			fakeSDK.ServerResourcesReturns(nil, errors.New("the server could not find the requested resource"))
+
+			err := cmd.View()
+			Expect(err).NotTo(HaveOccurred())
+
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`Service Catalog API:\s+Unreachable - the server could not find the requested resource`))
+			Expect(output).To(MatchRegexp(`Server:\s+https://prod.example.com`))
+		})
+	})
+})
+
+func mustRawConfig(config clientcmd.ClientConfig) clientcmdapi.Config {
+	rawConfig, err := config.RawConfig()
+	Expect(err).NotTo(HaveOccurred())
+	return rawConfig
+}
@@ -26,23 +26,37 @@ import (
 
 type describeCmd struct {
 	*command.Namespaced
-	name string
+	*command.Described
+	name       string
+	showParams bool
 }
 
 // NewDescribeCmd builds a "svcat describe instance" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Namespaced: command.NewNamespaced(cxt)}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Described:  command.NewDescribed(),
+	}
 	cmd := &cobra.Command{
 		Use:     "instance NAME",
 		Aliases: []string{"instances", "inst"},
 		Short:   "Show details of a specific instance",
 		Example: command.NormalizeExamples(`
   svcat describe instance wordpress-mysql-instance
+  svcat describe instance wordpress-mysql-instance --show-params
+  svcat describe instance wordpress-mysql-instance --output yaml
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
+	cmd.Flags().BoolVar(
+		&describeCmd.showParams,
+		"show-params",
+		false,
+		"Show the secret parameter refs and the parameters last sent to the broker. Values read from secrets are not shown.",
+	)
 	return cmd
 }
 
@@ -65,13 +79,11 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
-	output.WriteInstanceDetails(c.Output, instance)
-
 	bindings, err := c.App.RetrieveBindingsByInstance(instance)
 	if err != nil {
 		return err
 	}
-	output.WriteAssociatedBindings(c.Output, bindings)
+	output.WriteInstanceDescription(c.Output, c.OutputFormat, instance, bindings, c.showParams)
 
 	return nil
 }
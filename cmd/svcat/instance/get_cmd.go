@@ -29,6 +29,8 @@ type getCmd struct {
 	*command.Formatted
 	*command.PlanFiltered
 	*command.ClassFiltered
+	*command.Selected
+	*command.Sorted
 	name string
 }
 
@@ -39,6 +41,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Formatted:     command.NewFormatted(),
 		ClassFiltered: command.NewClassFiltered(),
 		PlanFiltered:  command.NewPlanFiltered(),
+		Selected:      command.NewSelected(),
+		Sorted:        command.NewSorted(),
 	}
 	cmd := &cobra.Command{
 		Use:     "instances [NAME]",
@@ -49,6 +53,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get instances --class redis
   svcat get instances --plan default
   svcat get instances --all-namespaces
+  svcat get instances -l team=payments
+  svcat get instances --sort-by age
   svcat get instance wordpress-mysql-instance
   svcat get instance -n ci concourse-postgres-instance
 `),
@@ -59,6 +65,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddClassFlag(cmd)
 	getCmd.AddPlanFlag(cmd)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddSortFlag(cmd)
 
 	return cmd
 }
@@ -74,6 +82,14 @@ func (c *getCmd) Validate(args []string) error {
 		if c.PlanFilter != "" {
 			return fmt.Errorf("plan filter is not supported when specifiying instance name")
 		}
+
+		if c.LabelSelector != "" {
+			return fmt.Errorf("selector is not supported when specifiying instance name")
+		}
+
+		if c.SortBy != nil {
+			return fmt.Errorf("sort-by is not supported when specifiying instance name")
+		}
 	}
 
 	return nil
@@ -88,11 +104,20 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	instances, err := c.App.RetrieveInstances(c.Namespace, c.ClassFilter, c.PlanFilter)
+	instances, err := c.App.RetrieveInstances(c.Namespace, c.ClassFilter, c.PlanFilter, c.LabelSelector)
 	if err != nil {
 		return err
 	}
+	if err := output.SortList(instances.Items, c.SortBy); err != nil {
+		return err
+	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, instances.Items)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPathList(c.Output, c.JSONPath, instances.Items)
+	}
 	output.WriteInstanceList(c.Output, c.OutputFormat, instances)
 	return nil
 }
@@ -103,6 +128,12 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, instance)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPath(c.Output, c.JSONPath, instance)
+	}
 	output.WriteInstance(c.Output, c.OutputFormat, *instance)
 
 	return nil
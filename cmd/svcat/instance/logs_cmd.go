@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type logsCmd struct {
+	*command.Namespaced
+	*command.Logged
+	name string
+}
+
+// NewLogsCmd builds a "svcat logs instance" command
+func NewLogsCmd(cxt *command.Context) *cobra.Command {
+	logsCmd := &logsCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Logged:     command.NewLogged(),
+	}
+	cmd := &cobra.Command{
+		Use:     "instance NAME",
+		Aliases: []string{"instances", "inst"},
+		Short:   "Show recent events for a specific instance",
+		Example: command.NormalizeExamples(`
+  svcat logs instance wordpress-mysql-instance
+  svcat logs instance wordpress-mysql-instance --since 10m
+  svcat logs instance -n ci concourse-postgres-instance --follow
+`),
+		PreRunE: command.PreRunE(logsCmd),
+		RunE:    command.RunE(logsCmd),
+	}
+	logsCmd.AddNamespaceFlags(cmd.Flags(), false)
+	logsCmd.AddLogFlags(cmd)
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided
+func (c *logsCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+// Run prints the events of the instance with the requested name.
+func (c *logsCmd) Run() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	return c.WriteEvents(c.Context, instance.Namespace, "ServiceInstance", instance.Name)
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package instance
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
@@ -32,19 +35,25 @@ type ProvisionCmd struct {
 	*command.Namespaced
 	*command.Waitable
 
+	ClassID                  string
 	ClassKubeName            string
 	ClassName                string
 	ExternalID               string
+	FromInstance             string
 	InstanceName             string
 	JSONParams               string
 	LookupByKubeName         bool
 	Params                   interface{}
+	PlanID                   string
 	PlanKubeName             string
 	PlanName                 string
 	ProvisionClusterInstance bool
 	RawParams                []string
 	RawSecrets               []string
 	Secrets                  map[string]string
+
+	parametersFrom      []v1beta1.ParametersFromSource
+	secretParameterRefs []v1beta1.SecretParameterReference
 }
 
 // NewProvisionCmd builds a "svcat provision" command
@@ -59,7 +68,9 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
+  svcat provision wordpress-mysql-instance --class-id 997b8372-8dac-40ac-ae65-758b4a5075a5 --plan-id 2a44ed0e-2c09-4be6-8a81-761ddba2f733
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision wordpress-mysql-copy --from-instance wordpress-mysql-instance -p location=westus
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -74,10 +85,11 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		PreRunE: command.PreRunE(provisionCmd),
 		RunE:    command.RunE(provisionCmd),
 	}
-	cmd.Flags().StringVar(&provisionCmd.ClassName, "class", "", "The class name (Required)")
-	cmd.MarkFlagRequired("class")
-	cmd.Flags().StringVar(&provisionCmd.PlanName, "plan", "", "The plan name (Required)")
-	cmd.MarkFlagRequired("plan")
+	cmd.Flags().StringVar(&provisionCmd.ClassName, "class", "", "The class name (Required unless --from-instance is specified)")
+	cmd.Flags().StringVar(&provisionCmd.PlanName, "plan", "", "The plan name (Required unless --from-instance is specified)")
+	cmd.Flags().StringVar(&provisionCmd.ClassID, "class-id", "", "The external ID of the class, which the broker knows it by. The instance refers to its class and plan by ID, which does not change when they are renamed. Cannot be combined with --class")
+	cmd.Flags().StringVar(&provisionCmd.PlanID, "plan-id", "", "The external ID of the plan, required with --class-id. Cannot be combined with --plan")
+	cmd.Flags().StringVar(&provisionCmd.FromInstance, "from-instance", "", "An existing instance, format: [NAMESPACE/]NAME, whose class, plan and parameters are copied to the new instance. Parameters specified with --param, --params-json or --secret are added on top")
 	cmd.Flags().StringVar(&provisionCmd.ExternalID, "external-id", "", "The ID of the instance for use with the OSB SB API (Optional)")
 	cmd.Flags().BoolVarP(&provisionCmd.LookupByKubeName, "kube-name", "k", false, "Whether or not to interpret the Class/Plan names as Kubernetes names (the default is by external name)")
 	cmd.Flags().StringSliceVarP(&provisionCmd.RawParams, "param", "p", nil, "Additional parameter to use when provisioning the service, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
@@ -97,6 +109,21 @@ func (c *ProvisionCmd) Validate(args []string) error {
 	}
 	c.InstanceName = args[0]
 
+	if c.FromInstance != "" {
+		if c.ClassName != "" || c.PlanName != "" {
+			return fmt.Errorf("--from-instance cannot be used with --class or --plan")
+		}
+		if c.ClassID != "" || c.PlanID != "" {
+			return fmt.Errorf("--from-instance cannot be used with --class-id or --plan-id")
+		}
+	} else if c.ClassID != "" || c.PlanID != "" {
+		if err := c.validateIDs(); err != nil {
+			return err
+		}
+	} else if c.ClassName == "" || c.PlanName == "" {
+		return fmt.Errorf("--class and --plan are required unless --from-instance is specified")
+	}
+
 	var err error
 
 	if c.JSONParams != "" && len(c.RawParams) > 0 {
@@ -123,15 +150,131 @@ func (c *ProvisionCmd) Validate(args []string) error {
 	return nil
 }
 
+// validateIDs checks that the class and plan are referred to by ID the way
+// the API accepts: both by ID, and not by name as well.
+func (c *ProvisionCmd) validateIDs() error {
+	if c.ClassName != "" && c.ClassID != "" {
+		return fmt.Errorf("--class cannot be used with --class-id")
+	}
+	if c.PlanName != "" && c.PlanID != "" {
+		return fmt.Errorf("--plan cannot be used with --plan-id")
+	}
+	if c.ClassID == "" {
+		return fmt.Errorf("--plan-id requires --class-id")
+	}
+	if c.PlanID == "" {
+		return fmt.Errorf("--class-id requires --plan-id")
+	}
+	if c.LookupByKubeName {
+		return fmt.Errorf("--kube-name cannot be used with --class-id or --plan-id")
+	}
+	return nil
+}
+
 // Run calls the Provision method
 func (c *ProvisionCmd) Run() error {
-	err := c.findKubeNames()
+	var err error
+	switch {
+	case c.FromInstance != "":
+		err = c.copyFromInstance()
+	case c.ClassID != "":
+		err = c.findClassScope()
+	default:
+		err = c.findKubeNames()
+	}
 	if err != nil {
 		return err
 	}
 	return c.provision()
 }
 
+// copyFromInstance sets the Kubernetes names of the Class/Plan and the
+// parameters of the new instance from the instance specified with
+// --from-instance. Parameters specified on the command line override the
+// copied parameters with the same name.
+func (c *ProvisionCmd) copyFromInstance() error {
+	namespace, name := c.Namespace, c.FromInstance
+	if i := strings.Index(c.FromInstance, "/"); i >= 0 {
+		namespace, name = c.FromInstance[:i], c.FromInstance[i+1:]
+	}
+	source, err := c.App.RetrieveInstance(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	c.ProvisionClusterInstance = source.Spec.ClusterServiceClassSpecified()
+	if c.ProvisionClusterInstance {
+		if source.Spec.ClusterServiceClassRef == nil || source.Spec.ClusterServicePlanRef == nil {
+			return fmt.Errorf("the class and plan of instance %s/%s have not been resolved yet", namespace, name)
+		}
+		c.ClassKubeName = source.Spec.ClusterServiceClassRef.Name
+		c.PlanKubeName = source.Spec.ClusterServicePlanRef.Name
+	} else {
+		if source.Spec.ServiceClassRef == nil || source.Spec.ServicePlanRef == nil {
+			return fmt.Errorf("the class and plan of instance %s/%s have not been resolved yet", namespace, name)
+		}
+		c.ClassKubeName = source.Spec.ServiceClassRef.Name
+		c.PlanKubeName = source.Spec.ServicePlanRef.Name
+	}
+
+	params := make(map[string]interface{})
+	if source.Spec.Parameters != nil && len(source.Spec.Parameters.Raw) > 0 {
+		if err := json.Unmarshal(source.Spec.Parameters.Raw, &params); err != nil {
+			return fmt.Errorf("unable to read the parameters of instance %s/%s (%s)", namespace, name, err)
+		}
+	}
+	if overrides, ok := c.Params.(map[string]interface{}); ok {
+		for k, v := range overrides {
+			params[k] = v
+		}
+	}
+	c.Params = params
+
+	c.parametersFrom = source.Spec.ParametersFrom
+	c.secretParameterRefs = source.Spec.SecretParameterRefs
+	if secrets := referencedSecrets(source); len(secrets) > 0 {
+		fmt.Fprintf(c.Output, "Warning: the parametersFrom and secretParameterRefs of instance %s/%s were copied, the namespace %s must contain the secrets: %s\n",
+			namespace, name, c.Namespace, strings.Join(secrets, ", "))
+	}
+	return nil
+}
+
+// referencedSecrets returns the sorted names of the secrets that the
+// parameters of the instance are read from.
+func referencedSecrets(instance *v1beta1.ServiceInstance) []string {
+	names := map[string]bool{}
+	for _, p := range instance.Spec.ParametersFrom {
+		if p.SecretKeyRef != nil {
+			names[p.SecretKeyRef.Name] = true
+		}
+	}
+	for _, ref := range instance.Spec.SecretParameterRefs {
+		names[ref.SecretKeyRef.Name] = true
+	}
+	secrets := make([]string, 0, len(names))
+	for name := range names {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+	return secrets
+}
+
+// findClassScope sets whether we are provisioning a ClusterServiceClass or
+// ServiceClass instance, from the class with the external ID specified with
+// --class-id.
+func (c *ProvisionCmd) findClassScope() error {
+	scopeOpts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     servicecatalog.AllScope,
+	}
+	class, err := c.App.RetrieveClassByExternalID(c.ClassID, scopeOpts)
+	if err != nil {
+		return err
+	}
+	c.ProvisionClusterInstance = class.IsClusterServiceClass()
+	return nil
+}
+
 // FindKubeNames determines if we need to find the Kubernetes
 // metadata names of the Class/Plan, and finds them if we do.
 // It also sets whether we are provisioning a ClusterServiceClass
@@ -179,10 +322,14 @@ func (c *ProvisionCmd) findKubeNames() error {
 // to the user
 func (c *ProvisionCmd) provision() error {
 	opts := &servicecatalog.ProvisionOptions{
-		ExternalID: c.ExternalID,
-		Namespace:  c.Namespace,
-		Params:     c.Params,
-		Secrets:    c.Secrets,
+		ClassExternalID:     c.ClassID,
+		PlanExternalID:      c.PlanID,
+		ExternalID:          c.ExternalID,
+		Namespace:           c.Namespace,
+		Params:              c.Params,
+		ParametersFrom:      c.parametersFrom,
+		SecretParameterRefs: c.secretParameterRefs,
+		Secrets:             c.Secrets,
 	}
 	instance, err := c.App.Provision(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, opts)
 	if err != nil {
@@ -191,7 +338,7 @@ func (c *ProvisionCmd) provision() error {
 
 	if c.Wait {
 		fmt.Fprintln(c.Output, "Waiting for the instance to be provisioned...")
-		finalInstance, err := c.App.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
+		finalInstance, err := c.App.WaitForInstanceWithProgress(instance.Namespace, instance.Name, c.Interval, c.Timeout, output.InstanceProgressWriter(c.Output))
 		if err == nil {
 			instance = finalInstance
 		}
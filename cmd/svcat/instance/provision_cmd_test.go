@@ -50,11 +50,15 @@ var _ = Describe("Provision Command", func() {
 
 			flag := cmd.Flags().Lookup("plan")
 			Expect(flag).NotTo(BeNil())
-			Expect(flag.Usage).To(ContainSubstring("The plan name (Required)"))
+			Expect(flag.Usage).To(ContainSubstring("The plan name (Required unless --from-instance is specified)"))
 
 			flag = cmd.Flags().Lookup("class")
 			Expect(flag).NotTo(BeNil())
-			Expect(flag.Usage).To(ContainSubstring("The class name (Required)"))
+			Expect(flag.Usage).To(ContainSubstring("The class name (Required unless --from-instance is specified)"))
+
+			flag = cmd.Flags().Lookup("from-instance")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.Usage).To(ContainSubstring("An existing instance, format: [NAMESPACE/]NAME, whose class, plan and parameters are copied to the new instance"))
 
 			flag = cmd.Flags().Lookup("external-id")
 			Expect(flag).NotTo(BeNil())
@@ -80,7 +84,10 @@ var _ = Describe("Provision Command", func() {
 	})
 	Describe("Validate", func() {
 		It("succeeds if an instance name is provided", func() {
-			cmd := ProvisionCmd{}
+			cmd := ProvisionCmd{
+				ClassName: "mysqldb",
+				PlanName:  "free",
+			}
 			err := cmd.Validate([]string{"bananainstance"})
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -92,6 +99,8 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("errors if both json params and raw params are provided", func() {
 			cmd := ProvisionCmd{
+				ClassName:  "mysqldb",
+				PlanName:   "free",
 				JSONParams: "{\"foo\":\"bar\"}",
 				RawParams:  []string{"a=b"},
 			}
@@ -101,6 +110,8 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("succeeds only if the provided json params are parseable json", func() {
 			cmd := ProvisionCmd{
+				ClassName:  "mysqldb",
+				PlanName:   "free",
 				JSONParams: "{\"foo\":\"bar\"}",
 			}
 			err := cmd.Validate([]string{"bananainstance"})
@@ -111,6 +122,8 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("successfully parses raw params into the params map", func() {
 			cmd := ProvisionCmd{
+				ClassName: "mysqldb",
+				PlanName:  "free",
 				RawParams: []string{"a=b"},
 			}
 			err := cmd.Validate([]string{"bananainstance"})
@@ -121,6 +134,8 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("errors if the provided json params are not parseable", func() {
 			cmd := ProvisionCmd{
+				ClassName:  "mysqldb",
+				PlanName:   "free",
 				JSONParams: "foo=bar",
 			}
 			err := cmd.Validate([]string{"bananainstance"})
@@ -129,6 +144,8 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("parses secrets into the secrets map", func() {
 			cmd := ProvisionCmd{
+				ClassName:  "mysqldb",
+				PlanName:   "free",
 				RawSecrets: []string{"foo[bar]"},
 			}
 			err := cmd.Validate([]string{"bananainstance"})
@@ -139,12 +156,73 @@ var _ = Describe("Provision Command", func() {
 		})
 		It("errors if secrets aren't parseable", func() {
 			cmd := ProvisionCmd{
+				ClassName:  "mysqldb",
+				PlanName:   "free",
 				RawSecrets: []string{"foo=bar"},
 			}
 			err := cmd.Validate([]string{"bananainstance"})
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("invalid --secret value (invalid parameter (foo=bar), must be in MAP[KEY] format)"))
 		})
+		It("errors if the class or plan is missing", func() {
+			cmd := ProvisionCmd{
+				ClassName: "mysqldb",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--class and --plan are required unless --from-instance is specified"))
+		})
+		It("succeeds without a class and plan if an instance to copy from is provided", func() {
+			cmd := ProvisionCmd{
+				FromInstance: "existinginstance",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("errors if an instance to copy from is provided together with a class or plan", func() {
+			cmd := ProvisionCmd{
+				FromInstance: "existinginstance",
+				PlanName:     "free",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--from-instance cannot be used with --class or --plan"))
+		})
+		It("succeeds with a class and plan external ID", func() {
+			cmd := ProvisionCmd{
+				ClassID: "classid",
+				PlanID:  "planid",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("errors if a class external ID is provided without a plan external ID", func() {
+			cmd := ProvisionCmd{
+				ClassID: "classid",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--class-id requires --plan-id"))
+		})
+		It("errors if a plan external ID is provided together with a plan name", func() {
+			cmd := ProvisionCmd{
+				ClassID:  "classid",
+				PlanID:   "planid",
+				PlanName: "free",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--plan cannot be used with --plan-id"))
+		})
+		It("errors if an instance to copy from is provided together with an external ID", func() {
+			cmd := ProvisionCmd{
+				FromInstance: "existinginstance",
+				ClassID:      "classid",
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--from-instance cannot be used with --class-id or --plan-id"))
+		})
 	})
 	Describe("Run", func() {
 		var (
@@ -278,10 +356,43 @@ var _ = Describe("Provision Command", func() {
 			Expect(output).To(ContainSubstring(namespace))
 			Expect(output).To(ContainSubstring(className))
 		})
-		It("Calls the SDK's WaitForInstance method with the passed in interval and timeout when Wait==true", func() {
+		It("finds the scope of the class by its external ID and provisions by the class and plan external IDs", func() {
+			fakeSDK.RetrieveClassByExternalIDReturns(classToReturn, nil)
+			cmd := ProvisionCmd{
+				ClassID:      "classid",
+				ExternalID:   externalID,
+				InstanceName: instanceName,
+				PlanID:       "planid",
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveClassByNameCallCount()).To(Equal(0))
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(0))
+
+			Expect(fakeSDK.RetrieveClassByExternalIDCallCount()).To(Equal(1))
+			returnedClassID, returnedScopeOpts := fakeSDK.RetrieveClassByExternalIDArgsForCall(0)
+			Expect(returnedClassID).To(Equal("classid"))
+			Expect(returnedScopeOpts).To(Equal(servicecatalog.ScopeOptions{
+				Namespace: namespace,
+				Scope:     servicecatalog.AllScope,
+			}))
+
+			Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+			_, _, _, returnedProvisionClusterInstance, returnedOpts := fakeSDK.ProvisionArgsForCall(0)
+			Expect(returnedProvisionClusterInstance).To(BeTrue())
+			Expect(returnedOpts.ClassExternalID).To(Equal("classid"))
+			Expect(returnedOpts.PlanExternalID).To(Equal("planid"))
+		})
+		It("Calls the SDK's WaitForInstanceWithProgress method with the passed in interval and timeout when Wait==true", func() {
 			interval := 1 * time.Second
 			timeout := 1 * time.Minute
-			fakeSDK.WaitForInstanceReturns(instanceToReturn, nil)
+			fakeSDK.WaitForInstanceWithProgressReturns(instanceToReturn, nil)
 			cmd := ProvisionCmd{
 				ClassName:    className,
 				ExternalID:   externalID,
@@ -315,12 +426,13 @@ var _ = Describe("Provision Command", func() {
 			}
 			Expect(*returnedOpts).To(Equal(opts))
 
-			Expect(fakeSDK.WaitForInstanceCallCount()).To(Equal(1))
-			waitNamespace, waitName, waitInterval, waitTimeout := fakeSDK.WaitForInstanceArgsForCall(0)
+			Expect(fakeSDK.WaitForInstanceWithProgressCallCount()).To(Equal(1))
+			waitNamespace, waitName, waitInterval, waitTimeout, waitProgress := fakeSDK.WaitForInstanceWithProgressArgsForCall(0)
 			Expect(waitNamespace).To(Equal(namespace))
 			Expect(waitName).To(Equal(instanceName))
 			Expect(waitInterval).To(Equal(interval))
 			Expect(*waitTimeout).To(Equal(timeout))
+			Expect(waitProgress).NotTo(BeNil())
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring("Waiting for the instance"))
@@ -423,5 +535,77 @@ var _ = Describe("Provision Command", func() {
 			_, _, _, returnedProvisionClusterInstance, _ := fakeSDK.ProvisionArgsForCall(0)
 			Expect(returnedProvisionClusterInstance).To(BeFalse())
 		})
+		It("copies the class, plan and parameters of the instance specified with FromInstance, and applies the given parameters on top", func() {
+			sourceParams, err := json.Marshal(map[string]interface{}{"foo": "old", "size": "10"})
+			Expect(err).NotTo(HaveOccurred())
+			parametersFrom := []v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "dbsecret", Key: "params"}},
+			}
+			source := instanceToReturn.DeepCopy()
+			source.Name = "existinginstance"
+			source.Namespace = "othernamespace"
+			source.Spec.Parameters = &runtime.RawExtension{Raw: sourceParams}
+			source.Spec.ParametersFrom = parametersFrom
+			fakeSDK.RetrieveInstanceReturns(source, nil)
+
+			cmd := ProvisionCmd{
+				FromInstance: "othernamespace/existinginstance",
+				InstanceName: instanceName,
+				Params:       params,
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+
+			err = cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveClassByNameCallCount()).To(Equal(0))
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(0))
+
+			Expect(fakeSDK.RetrieveInstanceCallCount()).To(Equal(1))
+			returnedNamespace, returnedName := fakeSDK.RetrieveInstanceArgsForCall(0)
+			Expect(returnedNamespace).To(Equal("othernamespace"))
+			Expect(returnedName).To(Equal("existinginstance"))
+
+			Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+			returnedInstanceName, returnedClassKubeName, returnedPlanKubeName, returnedProvisionClusterInstance, returnedOpts := fakeSDK.ProvisionArgsForCall(0)
+			Expect(returnedInstanceName).To(Equal(instanceName))
+			Expect(returnedClassKubeName).To(Equal(classKubeName))
+			Expect(returnedPlanKubeName).To(Equal(planKubeName))
+			Expect(returnedProvisionClusterInstance).To(BeTrue())
+			Expect(*returnedOpts).To(Equal(servicecatalog.ProvisionOptions{
+				Namespace:      namespace,
+				Params:         map[string]interface{}{"foo": "bar", "size": "10"},
+				ParametersFrom: parametersFrom,
+			}))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("Warning: the parametersFrom and secretParameterRefs of instance othernamespace/existinginstance were copied, the namespace foobarnamespace must contain the secrets: dbsecret"))
+		})
+		It("errors if the class and plan of the instance specified with FromInstance are not resolved", func() {
+			source := instanceToReturn.DeepCopy()
+			source.Spec.ClusterServicePlanRef = nil
+			fakeSDK.RetrieveInstanceReturns(source, nil)
+
+			cmd := ProvisionCmd{
+				FromInstance: "existinginstance",
+				InstanceName: instanceName,
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the class and plan of instance foobarnamespace/existinginstance have not been resolved yet"))
+			namespace, name := fakeSDK.RetrieveInstanceArgsForCall(0)
+			Expect(namespace).To(Equal("foobarnamespace"))
+			Expect(name).To(Equal("existinginstance"))
+			Expect(fakeSDK.ProvisionCallCount()).To(Equal(0))
+		})
 	})
 })
@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeCmd contains the information needed to change the plan of an instance
+type UpgradeCmd struct {
+	*command.Namespaced
+	*command.Waitable
+
+	InstanceName string
+	PlanName     string
+}
+
+// NewUpgradeCmd builds a "svcat upgrade instance" command
+func NewUpgradeCmd(cxt *command.Context) *cobra.Command {
+	upgradeCmd := &UpgradeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME --to-plan PLAN",
+		Short: "Change the plan of an instance",
+		Long: `Change the plan of an instance to another plan of its class.
+The class of the instance must allow changing plans; changing the class of an
+instance is not supported.`,
+		Example: command.NormalizeExamples(`
+  svcat upgrade instance wordpress-mysql-instance --to-plan 100mb
+  svcat upgrade instance wordpress-mysql-instance --to-plan 100mb --wait
+`),
+		PreRunE: command.PreRunE(upgradeCmd),
+		RunE:    command.RunE(upgradeCmd),
+	}
+	upgradeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(
+		&upgradeCmd.PlanName,
+		"to-plan",
+		"",
+		"The external name of the plan to change the instance to (Required)",
+	)
+	upgradeCmd.AddWaitFlags(cmd)
+
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided
+func (c *UpgradeCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.InstanceName = args[0]
+
+	if c.PlanName == "" {
+		return fmt.Errorf("a plan must be specified with --to-plan")
+	}
+
+	return nil
+}
+
+// Run changes the plan of the instance, waits if necessary, and displays
+// the instance to the user
+func (c *UpgradeCmd) Run() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.InstanceName)
+	if err != nil {
+		return err
+	}
+
+	plan, err := c.findPlan(instance)
+	if err != nil {
+		return err
+	}
+
+	const retries = 3
+	instance, err = c.App.UpdateInstancePlan(c.Namespace, c.InstanceName, plan, retries)
+	if err != nil {
+		return err
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the instance to be updated...")
+		finalInstance, err := c.App.WaitForInstanceWithProgress(instance.Namespace, instance.Name, c.Interval, c.Timeout, output.InstanceProgressWriter(c.Output))
+		if err == nil {
+			instance = finalInstance
+		}
+
+		// Always print the instance because the plan was changed, and just
+		// print any errors that occurred while polling
+		output.WriteInstanceDetails(c.Output, instance)
+		return err
+	}
+
+	output.WriteInstanceDetails(c.Output, instance)
+	return nil
+}
+
+// findPlan returns the plan to change the instance to. The plan must be a
+// plan of the class of the instance, and the class must allow changing plans.
+func (c *UpgradeCmd) findPlan(instance *v1beta1.ServiceInstance) (servicecatalog.Plan, error) {
+	var classKubeName, planKubeName string
+	scopeOpts := servicecatalog.ScopeOptions{Namespace: c.Namespace}
+	if instance.Spec.ClusterServiceClassSpecified() {
+		scopeOpts.Scope = servicecatalog.ClusterScope
+		if instance.Spec.ClusterServiceClassRef != nil {
+			classKubeName = instance.Spec.ClusterServiceClassRef.Name
+		}
+		if instance.Spec.ClusterServicePlanRef != nil {
+			planKubeName = instance.Spec.ClusterServicePlanRef.Name
+		}
+	} else {
+		scopeOpts.Scope = servicecatalog.NamespaceScope
+		if instance.Spec.ServiceClassRef != nil {
+			classKubeName = instance.Spec.ServiceClassRef.Name
+		}
+		if instance.Spec.ServicePlanRef != nil {
+			planKubeName = instance.Spec.ServicePlanRef.Name
+		}
+	}
+	if classKubeName == "" {
+		return nil, fmt.Errorf("the class of instance '%s' is not resolved yet, try again once the instance is provisioned", c.InstanceName)
+	}
+
+	class, err := c.App.RetrieveClassByID(classKubeName, scopeOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := c.App.RetrievePlanByClassIDAndName(classKubeName, c.PlanName, scopeOpts)
+	if err != nil {
+		// Explain why a plan of another class can not be used
+		if otherPlan, otherErr := c.App.RetrievePlanByName(c.PlanName, scopeOpts); otherErr == nil {
+			otherClassName := otherPlan.GetClassID()
+			if otherClass, classErr := c.App.RetrieveClassByPlan(otherPlan); classErr == nil {
+				otherClassName = otherClass.GetExternalName()
+			}
+			return nil, fmt.Errorf("plan '%s' belongs to class '%s', not to class '%s' of instance '%s': the class of an instance can not be changed, provision a new instance instead",
+				c.PlanName, otherClassName, class.GetExternalName(), c.InstanceName)
+		}
+		return nil, fmt.Errorf("Unable to find plan '%s' of class '%s': %s", c.PlanName, class.GetExternalName(), err.Error())
+	}
+
+	if plan.GetName() == planKubeName {
+		return nil, fmt.Errorf("instance '%s' already uses plan '%s'", c.InstanceName, c.PlanName)
+	}
+	if !class.GetSpec().PlanUpdatable {
+		return nil, fmt.Errorf("class '%s' does not allow changing the plan of its instances", class.GetExternalName())
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/instance"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Upgrade Command", func() {
+	Describe("NewUpgradeCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewUpgradeCmd(cxt)
+
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("instance NAME --to-plan PLAN"))
+			Expect(cmd.Short).To(ContainSubstring("Change the plan of an instance"))
+			Expect(cmd.Example).To(ContainSubstring("svcat upgrade instance wordpress-mysql-instance --to-plan 100mb"))
+
+			flag := cmd.Flags().Lookup("to-plan")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.Usage).To(ContainSubstring("The external name of the plan to change the instance to (Required)"))
+
+			flag = cmd.Flags().Lookup("wait")
+			Expect(flag).NotTo(BeNil())
+			flag = cmd.Flags().Lookup("namespace")
+			Expect(flag).NotTo(BeNil())
+		})
+	})
+	Describe("Validate", func() {
+		It("succeeds if an instance name and a plan are provided", func() {
+			cmd := UpgradeCmd{PlanName: "100mb"}
+			err := cmd.Validate([]string{"myinstance"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.InstanceName).To(Equal("myinstance"))
+		})
+		It("errors if no instance name is provided", func() {
+			cmd := UpgradeCmd{PlanName: "100mb"}
+			err := cmd.Validate([]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("an instance name is required"))
+		})
+		It("errors if no plan is provided", func() {
+			cmd := UpgradeCmd{}
+			err := cmd.Validate([]string{"myinstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a plan must be specified with --to-plan"))
+		})
+	})
+	Describe("Run", func() {
+		var (
+			classToReturn    *v1beta1.ClusterServiceClass
+			cxt              *command.Context
+			fakeApp          *svcat.App
+			fakeSDK          *servicecatalogfakes.FakeSvcatClient
+			instanceToReturn *v1beta1.ServiceInstance
+			namespace        string
+			outputBuffer     *bytes.Buffer
+			planToReturn     *v1beta1.ClusterServicePlan
+		)
+		BeforeEach(func() {
+			namespace = "foobarnamespace"
+			instanceToReturn = &v1beta1.ServiceInstance{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "myinstance",
+					Namespace: namespace,
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalName: "mysqldb",
+						ClusterServicePlanExternalName:  "10mb",
+					},
+					ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "mysqlclass1234"},
+					ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "10mbplan1234"},
+				},
+			}
+			classToReturn = &v1beta1.ClusterServiceClass{
+				ObjectMeta: v1.ObjectMeta{Name: "mysqlclass1234"},
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+						ExternalName:  "mysqldb",
+						PlanUpdatable: true,
+					},
+				},
+			}
+			planToReturn = &v1beta1.ClusterServicePlan{
+				ObjectMeta: v1.ObjectMeta{Name: "100mbplan1234"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName: "100mb",
+					},
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "mysqlclass1234"},
+				},
+			}
+
+			fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveInstanceReturns(instanceToReturn, nil)
+			fakeSDK.RetrieveClassByIDReturns(classToReturn, nil)
+			fakeSDK.RetrievePlanByClassIDAndNameReturns(planToReturn, nil)
+			fakeSDK.UpdateInstancePlanReturns(instanceToReturn, nil)
+			fakeApp, _ = svcat.NewApp(nil, nil, namespace)
+			fakeApp.SvcatClient = fakeSDK
+			outputBuffer = &bytes.Buffer{}
+			cxt = svcattest.NewContext(outputBuffer, fakeApp)
+		})
+
+		newCmd := func(planName string, wait bool) *UpgradeCmd {
+			cmd := &UpgradeCmd{
+				InstanceName: "myinstance",
+				PlanName:     planName,
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Wait = wait
+			cmd.Waitable.ApplyWaitFlags()
+			return cmd
+		}
+
+		It("Finds the plan of the class of the instance and changes the plan of the instance", func() {
+			err := newCmd("100mb", false).Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSDK.RetrieveClassByIDCallCount()).To(Equal(1))
+			classKubeName, scopeOpts := fakeSDK.RetrieveClassByIDArgsForCall(0)
+			Expect(classKubeName).To(Equal("mysqlclass1234"))
+			Expect(scopeOpts).To(Equal(servicecatalog.ScopeOptions{
+				Namespace: namespace,
+				Scope:     servicecatalog.ClusterScope,
+			}))
+
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(1))
+			classKubeName, planName, _ := fakeSDK.RetrievePlanByClassIDAndNameArgsForCall(0)
+			Expect(classKubeName).To(Equal("mysqlclass1234"))
+			Expect(planName).To(Equal("100mb"))
+
+			Expect(fakeSDK.UpdateInstancePlanCallCount()).To(Equal(1))
+			ns, name, plan, _ := fakeSDK.UpdateInstancePlanArgsForCall(0)
+			Expect(ns).To(Equal(namespace))
+			Expect(name).To(Equal("myinstance"))
+			Expect(plan).To(Equal(planToReturn))
+
+			Expect(fakeSDK.WaitForInstanceWithProgressCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(ContainSubstring("myinstance"))
+		})
+		It("Waits for the update to complete with --wait", func() {
+			fakeSDK.WaitForInstanceWithProgressReturns(instanceToReturn, nil)
+
+			err := newCmd("100mb", true).Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSDK.WaitForInstanceWithProgressCallCount()).To(Equal(1))
+			Expect(outputBuffer.String()).To(ContainSubstring("Waiting for the instance to be updated..."))
+		})
+		It("Prints the progress reported by the broker with --wait", func() {
+			fakeSDK.WaitForInstanceWithProgressStub = func(ns, name string, interval time.Duration, timeout *time.Duration, progress func(*v1beta1.ServiceInstance)) (*v1beta1.ServiceInstance, error) {
+				for _, description := range []string{"", "resizing disk, 40%", "resizing disk, 40%", "resizing disk, 80%"} {
+					inProgress := instanceToReturn.DeepCopy()
+					inProgress.Status.LastOperationDescription = description
+					progress(inProgress)
+				}
+				return instanceToReturn, nil
+			}
+
+			err := newCmd("100mb", true).Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("  resizing disk, 40%\n  resizing disk, 80%\n"))
+			Expect(strings.Count(output, "resizing disk, 40%")).To(Equal(1))
+		})
+		It("Rejects a plan of another class", func() {
+			otherPlan := planToReturn.DeepCopy()
+			otherPlan.Name = "otherplan1234"
+			otherPlan.Spec.ClusterServiceClassRef.Name = "otherclass1234"
+			otherClass := &v1beta1.ClusterServiceClass{
+				ObjectMeta: v1.ObjectMeta{Name: "otherclass1234"},
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "postgresdb"},
+				},
+			}
+			fakeSDK.RetrievePlanByClassIDAndNameReturns(nil, errors.New("plan not found"))
+			fakeSDK.RetrievePlanByNameReturns(otherPlan, nil)
+			fakeSDK.RetrieveClassByPlanReturns(otherClass, nil)
+
+			err := newCmd("100mb", false).Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("plan '100mb' belongs to class 'postgresdb', not to class 'mysqldb' of instance 'myinstance'"))
+			Expect(fakeSDK.UpdateInstancePlanCallCount()).To(Equal(0))
+		})
+		It("Errors if the plan does not exist", func() {
+			fakeSDK.RetrievePlanByClassIDAndNameReturns(nil, errors.New("plan not found"))
+			fakeSDK.RetrievePlanByNameReturns(nil, errors.New("plan not found"))
+
+			err := newCmd("100mb", false).Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unable to find plan '100mb' of class 'mysqldb'"))
+			Expect(fakeSDK.UpdateInstancePlanCallCount()).To(Equal(0))
+		})
+		It("Rejects a class that is not plan updatable", func() {
+			classToReturn.Spec.PlanUpdatable = false
+
+			err := newCmd("100mb", false).Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("class 'mysqldb' does not allow changing the plan of its instances"))
+			Expect(fakeSDK.UpdateInstancePlanCallCount()).To(Equal(0))
+		})
+		It("Errors if the instance already uses the plan", func() {
+			instanceToReturn.Spec.ClusterServicePlanRef.Name = "100mbplan1234"
+
+			err := newCmd("100mb", false).Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("instance 'myinstance' already uses plan '100mb'"))
+			Expect(fakeSDK.UpdateInstancePlanCallCount()).To(Equal(0))
+		})
+	})
+})
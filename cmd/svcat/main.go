@@ -26,12 +26,15 @@ import (
 	"k8s.io/klog"
 	"k8s.io/kubectl/pkg/pluginutils"
 
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/all"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/binding"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/broker"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/browsing"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/class"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/cleanup"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/completion"
+	svcatconfig "github.com/kubernetes-sigs/service-catalog/cmd/svcat/config"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/instance"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/plan"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/plugin"
@@ -74,6 +77,8 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	var opts struct {
 		KubeConfig  string
 		KubeContext string
+		Server      string
+		Token       string
 	}
 
 	cmd := &cobra.Command{
@@ -93,7 +98,11 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 
 			// Initialize the context if not already configured (by tests)
 			if cxt.App == nil {
-				k8sClient, svcatClient, namespace, err := getClients(opts.KubeConfig, opts.KubeContext)
+				config, restConfig, err := getConfig(opts.KubeConfig, opts.KubeContext, opts.Server, opts.Token)
+				if err != nil {
+					return err
+				}
+				k8sClient, svcatClient, namespace, err := getClients(config, restConfig)
 				if err != nil {
 					return err
 				}
@@ -104,6 +113,8 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 				}
 
 				cxt.App = app
+				cxt.ClientConfig = config
+				cxt.KubeContext = opts.KubeContext
 			}
 
 			return nil
@@ -116,6 +127,8 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&opts.KubeContext, "context", "", "name of the kubeconfig context to use.")
 	cmd.PersistentFlags().StringVar(&opts.KubeConfig, "kubeconfig", "", "path to kubeconfig file. Overrides $KUBECONFIG")
+	cmd.PersistentFlags().StringVar(&opts.Server, "server", "", "address of the Kubernetes API server. Overrides the server of the kubeconfig context")
+	cmd.PersistentFlags().StringVar(&opts.Token, "token", "", "bearer token for authentication to the API server. Overrides the credentials of the kubeconfig context")
 
 	cmd.AddCommand(newCreateCmd(cxt))
 	cmd.AddCommand(newGetCmd(cxt))
@@ -127,12 +140,16 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(binding.NewBindCmd(cxt))
 	cmd.AddCommand(binding.NewUnbindCmd(cxt))
 	cmd.AddCommand(browsing.NewMarketplaceCmd(cxt))
+	cmd.AddCommand(cleanup.NewCleanupCmd(cxt))
 	cmd.AddCommand(newSyncCmd(cxt))
 	if !plugin.IsPlugin() {
 		cmd.AddCommand(newInstallCmd(cxt))
 	}
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(newUpgradeCmd(cxt))
+	cmd.AddCommand(newLogsCmd(cxt))
 	cmd.AddCommand(versions.NewVersionCmd(cxt))
+	cmd.AddCommand(newConfigCmd(cxt))
 	cmd.AddCommand(newCompletionCmd(cxt))
 
 	return cmd
@@ -164,6 +181,7 @@ func newGetCmd(cxt *command.Context) *cobra.Command {
 		Use:   "get",
 		Short: "List a resource, optionally filtered by name",
 	}
+	cmd.AddCommand(all.NewGetCmd(cxt))
 	cmd.AddCommand(binding.NewGetCmd(cxt))
 	cmd.AddCommand(broker.NewGetCmd(cxt))
 	cmd.AddCommand(class.NewGetCmd(cxt))
@@ -206,33 +224,73 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newUpgradeCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Change the plan of a resource",
+	}
+	cmd.AddCommand(instance.NewUpgradeCmd(cxt))
+	return cmd
+}
+
+func newLogsCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show recent events for a specific resource",
+	}
+	cmd.AddCommand(binding.NewLogsCmd(cxt))
+	cmd.AddCommand(broker.NewLogsCmd(cxt))
+	cmd.AddCommand(instance.NewLogsCmd(cxt))
+	return cmd
+}
+
+func newConfigCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show the configuration used by svcat",
+	}
+	cmd.AddCommand(svcatconfig.NewViewCmd(cxt))
+	return cmd
+}
+
 func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
 
-// getClients loads api clients based on the plugin context if present, otherwise the specified kube config.
-func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, err error) {
-	var restConfig *rest.Config
-	var config clientcmd.ClientConfig
-
+// getConfig loads the kube config based on the plugin context if present, otherwise the specified kube config.
+// The flags take precedence over the kube config in the same way as in kubectl: --kubeconfig over $KUBECONFIG
+// over ~/.kube/config, --context over the current context, and --server and --token over the cluster and user
+// of the context.
+func getConfig(kubeConfig, kubeContext, server, token string) (config clientcmd.ClientConfig, restConfig *rest.Config, err error) {
 	if plugin.IsPlugin() {
 		restConfig, config, err = pluginutils.InitClientAndConfig()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
-		}
-	} else {
-		config = kube.GetConfig(kubeContext, kubeConfig)
-		restConfig, err = config.ClientConfig()
-		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
+			return nil, nil, fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
 		}
+		return config, restConfig, nil
 	}
 
+	config = kube.GetConfigWithOverrides(kubeContext, kubeConfig, server, token)
+	restConfig, err = config.ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
+	}
+	return config, restConfig, nil
+}
+
+// getClients loads api clients from the kube config returned by getConfig.
+func getClients(config clientcmd.ClientConfig, restConfig *rest.Config) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, err error) {
 	namespace, _, err := config.Namespace()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("could not get the namespace of the Kubernetes config: %s", err)
+	}
 	k8sClient, err = k8sclient.NewForConfig(restConfig)
 	if err != nil {
 		return nil, nil, "", err
 	}
 	svcatClient, err = svcatclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, "", err
+	}
 	return k8sClient, svcatClient, namespace, nil
 }
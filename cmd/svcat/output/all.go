@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// InstanceWithBindings is an instance together with the bindings that
+// reference it.
+type InstanceWithBindings struct {
+	Instance v1beta1.ServiceInstance  `json:"instance"`
+	Bindings []v1beta1.ServiceBinding `json:"bindings"`
+}
+
+// InstancesWithBindings is the aggregate view of the instances and bindings
+// of a namespace printed by "svcat get all".
+type InstancesWithBindings struct {
+	Items []InstanceWithBindings `json:"items"`
+
+	// UnassociatedBindings are the bindings whose instance was not listed,
+	// because it does not exist or because it could not be retrieved.
+	UnassociatedBindings []v1beta1.ServiceBinding `json:"unassociatedBindings,omitempty"`
+
+	// Warnings describe the resources that could not be listed, e.g. because
+	// the user is not allowed to list them.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func writeInstancesWithBindingsTable(w io.Writer, all *InstancesWithBindings) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Namespace",
+		"Class",
+		"Plan",
+		"Status",
+	})
+
+	appendBindings := func(bindings []v1beta1.ServiceBinding) {
+		for _, binding := range bindings {
+			t.Append([]string{
+				"└─ " + binding.Name,
+				binding.Namespace,
+				"",
+				"",
+				getBindingStatusShort(binding.Status),
+			})
+		}
+	}
+	for _, item := range all.Items {
+		t.Append([]string{
+			item.Instance.Name,
+			item.Instance.Namespace,
+			item.Instance.Spec.GetSpecifiedClusterServiceClass(),
+			item.Instance.Spec.GetSpecifiedClusterServicePlan(),
+			getInstanceStatusShort(item.Instance.Status),
+		})
+		appendBindings(item.Bindings)
+	}
+	if len(all.UnassociatedBindings) > 0 {
+		t.Append([]string{"<unknown instance>", "", "", "", ""})
+		appendBindings(all.UnassociatedBindings)
+	}
+	t.Render()
+}
+
+// WriteInstancesWithBindings prints the instances of a namespace grouped with
+// their bindings in the specified output format. The warnings are printed
+// before the table, and are embedded in the json and yaml output.
+func WriteInstancesWithBindings(w io.Writer, outputFormat string, all *InstancesWithBindings) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, all)
+	case FormatYAML:
+		writeYAML(w, all, 0)
+	case FormatName, FormatNameWithKind:
+		names := []string{}
+		for _, item := range all.Items {
+			names = append(names, resourceName(outputFormat, "serviceinstance", item.Instance.Name, item.Instance.Name))
+			for _, binding := range item.Bindings {
+				names = append(names, resourceName(outputFormat, "servicebinding", binding.Name, binding.Name))
+			}
+		}
+		for _, binding := range all.UnassociatedBindings {
+			names = append(names, resourceName(outputFormat, "servicebinding", binding.Name, binding.Name))
+		}
+		writeNames(w, names...)
+	case FormatTable, FormatWide:
+		for _, warning := range all.Warnings {
+			fmt.Fprintf(w, "Warning: %s\n", warning)
+		}
+		writeInstancesWithBindingsTable(w, all)
+	}
+}
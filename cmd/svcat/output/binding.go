@@ -24,6 +24,8 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcatsdk "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func getBindingStatusShort(status v1beta1.ServiceBindingStatus) string {
@@ -56,6 +58,43 @@ func writeBindingListTable(w io.Writer, bindingList *v1beta1.ServiceBindingList)
 	t.Render()
 }
 
+// writeBindingListWideTable prints the bindings with the name of their
+// secret and the reason of their Ready condition.
+func writeBindingListWideTable(w io.Writer, bindingList *v1beta1.ServiceBindingList) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Namespace",
+		"Instance",
+		"Status",
+		"Secret",
+		"Reason",
+	})
+
+	for _, binding := range bindingList.Items {
+		t.Append([]string{
+			binding.Name,
+			binding.Namespace,
+			binding.Spec.InstanceRef.Name,
+			getBindingStatusShort(binding.Status),
+			binding.Spec.SecretName,
+			getBindingReadyReason(binding.Status),
+		})
+	}
+	t.Render()
+}
+
+// getBindingReadyReason returns the reason of the Ready condition of a
+// binding, or an empty string if it has none.
+func getBindingReadyReason(status v1beta1.ServiceBindingStatus) string {
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionReady {
+			return cond.Reason
+		}
+	}
+	return ""
+}
+
 // WriteBindingList prints a list of bindings in the specified output format.
 func WriteBindingList(w io.Writer, outputFormat string, bindingList *v1beta1.ServiceBindingList) {
 	switch outputFormat {
@@ -63,8 +102,16 @@ func WriteBindingList(w io.Writer, outputFormat string, bindingList *v1beta1.Ser
 		writeJSON(w, bindingList)
 	case FormatYAML:
 		writeYAML(w, bindingList, 0)
+	case FormatName, FormatNameWithKind:
+		names := make([]string, 0, len(bindingList.Items))
+		for _, binding := range bindingList.Items {
+			names = append(names, resourceName(outputFormat, "servicebinding", binding.Name, binding.Name))
+		}
+		writeNames(w, names...)
 	case FormatTable:
 		writeBindingListTable(w, bindingList)
+	case FormatWide:
+		writeBindingListWideTable(w, bindingList)
 	}
 }
 
@@ -75,11 +122,18 @@ func WriteBinding(w io.Writer, outputFormat string, binding v1beta1.ServiceBindi
 		writeJSON(w, binding)
 	case FormatYAML:
 		writeYAML(w, binding, 0)
+	case FormatName, FormatNameWithKind:
+		writeNames(w, resourceName(outputFormat, "servicebinding", binding.Name, binding.Name))
 	case FormatTable:
 		l := v1beta1.ServiceBindingList{
 			Items: []v1beta1.ServiceBinding{binding},
 		}
 		writeBindingListTable(w, &l)
+	case FormatWide:
+		l := v1beta1.ServiceBindingList{
+			Items: []v1beta1.ServiceBinding{binding},
+		}
+		writeBindingListWideTable(w, &l)
 	}
 }
 
@@ -91,8 +145,11 @@ func WriteBindingDetails(w io.Writer, binding *v1beta1.ServiceBinding) {
 		{"Namespace:", binding.Namespace},
 		{"Status:", getBindingStatusFull(binding.Status)},
 		{"Secret:", binding.Spec.SecretName},
-		{"Instance:", binding.Spec.InstanceRef.Name},
 	})
+	if secretNamespace := binding.GetSecretNamespace(); secretNamespace != binding.Namespace {
+		t.Append([]string{"Secret Namespace:", secretNamespace})
+	}
+	t.Append([]string{"Instance:", binding.Spec.InstanceRef.Name})
 	t.Render()
 
 	writeParameters(w, binding.Spec.Parameters)
@@ -154,6 +211,95 @@ func WriteAssociatedSecret(w io.Writer, secret *v1.Secret, err error, showSecret
 	t.Render()
 }
 
+// associatedBinding is the JSON and YAML representation of a binding listed
+// in the description of its instance.
+type associatedBinding struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func newAssociatedBindings(bindings []v1beta1.ServiceBinding) []associatedBinding {
+	out := make([]associatedBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		out = append(out, associatedBinding{
+			Name:   binding.Name,
+			Status: getBindingStatusShort(binding.Status),
+		})
+	}
+	return out
+}
+
+// bindingDescription is the JSON and YAML representation of the description
+// of a binding. The secret data lists the keys of the bound secret and the
+// length of their values, the values being only included when they are
+// revealed.
+type bindingDescription struct {
+	Name               string                         `json:"name"`
+	Namespace          string                         `json:"namespace"`
+	Status             string                         `json:"status"`
+	StatusMessage      string                         `json:"statusMessage,omitempty"`
+	LastTransitionTime *metav1.Time                   `json:"lastTransitionTime,omitempty"`
+	Secret             string                         `json:"secret"`
+	SecretNamespace    string                         `json:"secretNamespace"`
+	Instance           string                         `json:"instance"`
+	Parameters         *runtime.RawExtension          `json:"parameters,omitempty"`
+	ParametersFrom     []v1beta1.ParametersFromSource `json:"parametersFrom,omitempty"`
+	SecretData         []secretDataEntry              `json:"secretData,omitempty"`
+	SecretError        string                         `json:"secretError,omitempty"`
+}
+
+// secretDataEntry is a key of the secret of a described binding.
+type secretDataEntry struct {
+	Key    string `json:"key"`
+	Length int    `json:"length"`
+	Value  string `json:"value,omitempty"`
+}
+
+// WriteBindingDescription prints the description of a binding and of the data
+// of its secret, whose values are only printed when showSecrets is set. The
+// error of the retrieval of the secret is printed instead of its data.
+func WriteBindingDescription(w io.Writer, outputFormat string, binding *v1beta1.ServiceBinding, secret *v1.Secret, err error, showSecrets bool) {
+	if outputFormat != FormatJSON && outputFormat != FormatYAML {
+		WriteBindingDetails(w, binding)
+		WriteAssociatedSecret(w, secret, err, showSecrets)
+		return
+	}
+
+	lastCond := svcatsdk.GetBindingStatusCondition(binding.Status)
+	out := bindingDescription{
+		Name:            binding.Name,
+		Namespace:       binding.Namespace,
+		Status:          getBindingStatusShort(binding.Status),
+		StatusMessage:   lastCond.Message,
+		Secret:          binding.Spec.SecretName,
+		SecretNamespace: binding.GetSecretNamespace(),
+		Instance:        binding.Spec.InstanceRef.Name,
+		Parameters:      binding.Spec.Parameters,
+		ParametersFrom:  binding.Spec.ParametersFrom,
+	}
+	if !lastCond.LastTransitionTime.IsZero() {
+		out.LastTransitionTime = &lastCond.LastTransitionTime
+	}
+	switch {
+	case err != nil:
+		out.SecretError = err.Error()
+	case secret != nil:
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			entry := secretDataEntry{Key: key, Length: len(secret.Data[key])}
+			if showSecrets {
+				entry.Value = string(secret.Data[key])
+			}
+			out.SecretData = append(out.SecretData, entry)
+		}
+	}
+	writeStructured(w, outputFormat, out)
+}
+
 // WriteDeletedBindingNames prints the names of a list of bindings
 func WriteDeletedBindingNames(w io.Writer, bindings []v1beta1.ServiceBinding) {
 	for _, binding := range bindings {
@@ -17,12 +17,118 @@ limitations under the License.
 package output
 
 import (
+	"fmt"
 	"io"
+	"strconv"
+	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
+// brokerExtras holds the fields that the JSON and YAML representation of a
+// broker adds to the broker: the type of its authentication and its last
+// error, so that automation gets the health of a broker without reading its
+// conditions. The broker only references the secrets holding its
+// credentials, so the credentials themselves are never included.
+type brokerExtras struct {
+	AuthType  string `json:"authType"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// clusterServiceBrokerOutput is the JSON and YAML representation of a
+// ClusterServiceBroker.
+type clusterServiceBrokerOutput struct {
+	*v1beta1.ClusterServiceBroker `json:",inline"`
+	brokerExtras                  `json:",inline"`
+}
+
+// serviceBrokerOutput is the JSON and YAML representation of a
+// ServiceBroker.
+type serviceBrokerOutput struct {
+	*v1beta1.ServiceBroker `json:",inline"`
+	brokerExtras           `json:",inline"`
+}
+
+// Authentication types of brokers.
+const (
+	brokerAuthTypeNone                = "none"
+	brokerAuthTypeBasic               = "basic"
+	brokerAuthTypeBearer              = "bearer"
+	brokerAuthTypeServiceAccountToken = "serviceAccountToken"
+)
+
+// newBrokerOutput returns a copy of the broker with its kind and API version
+// set, so that cluster-scoped and namespaced brokers can be told apart, and
+// with its authentication type and last error alongside its metadata, spec
+// and status.
+func newBrokerOutput(broker servicecatalog.Broker) interface{} {
+	extras := brokerExtras{
+		AuthType:  getBrokerAuthType(broker),
+		LastError: getBrokerLastError(broker.GetStatus()),
+	}
+	switch b := broker.(type) {
+	case *v1beta1.ClusterServiceBroker:
+		out := b.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ClusterServiceBroker"
+		return clusterServiceBrokerOutput{ClusterServiceBroker: out, brokerExtras: extras}
+	case *v1beta1.ServiceBroker:
+		out := b.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ServiceBroker"
+		return serviceBrokerOutput{ServiceBroker: out, brokerExtras: extras}
+	}
+	return broker
+}
+
+// getBrokerAuthType returns the type of the authentication of the broker to
+// its OSB API, from the variant of its authInfo that is set.
+func getBrokerAuthType(broker servicecatalog.Broker) string {
+	switch b := broker.(type) {
+	case *v1beta1.ClusterServiceBroker:
+		if authInfo := b.Spec.AuthInfo; authInfo != nil {
+			switch {
+			case authInfo.Basic != nil:
+				return brokerAuthTypeBasic
+			case authInfo.Bearer != nil:
+				return brokerAuthTypeBearer
+			case authInfo.ServiceAccountToken != nil:
+				return brokerAuthTypeServiceAccountToken
+			}
+		}
+	case *v1beta1.ServiceBroker:
+		if authInfo := b.Spec.AuthInfo; authInfo != nil {
+			switch {
+			case authInfo.Basic != nil:
+				return brokerAuthTypeBasic
+			case authInfo.Bearer != nil:
+				return brokerAuthTypeBearer
+			case authInfo.ServiceAccountToken != nil:
+				return brokerAuthTypeServiceAccountToken
+			}
+		}
+	}
+	return brokerAuthTypeNone
+}
+
+// getBrokerLastError returns the message of the condition that reports the
+// current error of a broker: its Failed condition, or else its Ready
+// condition when the broker is not ready.
+func getBrokerLastError(status v1beta1.CommonServiceBrokerStatus) string {
+	message := ""
+	for _, cond := range status.Conditions {
+		switch {
+		case cond.Type == v1beta1.ServiceBrokerConditionFailed && cond.Status == v1beta1.ConditionTrue:
+			return cond.Message
+		case cond.Type == v1beta1.ServiceBrokerConditionReady && cond.Status != v1beta1.ConditionTrue:
+			message = cond.Message
+		}
+	}
+	return message
+}
+
 func getBrokerScope(broker servicecatalog.Broker) string {
 	if broker.GetNamespace() != "" {
 		return servicecatalog.NamespaceScope
@@ -47,6 +153,24 @@ func getBrokerStatusFull(status v1beta1.CommonServiceBrokerStatus) string {
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
 
+// getBrokerCurrentOperation describes the operation that the controller has
+// recorded as in progress for a broker, such as "relisting (started 2m ago)".
+func getBrokerCurrentOperation(status v1beta1.CommonServiceBrokerStatus, now time.Time) string {
+	var operation string
+	switch status.CurrentOperation {
+	case "":
+		return ""
+	case v1beta1.ServiceBrokerOperationRelist:
+		operation = "relisting"
+	default:
+		operation = string(status.CurrentOperation)
+	}
+	if status.OperationStartTime == nil {
+		return operation
+	}
+	return fmt.Sprintf("%s (started %s ago)", operation, duration.HumanDuration(now.Sub(status.OperationStartTime.Time)))
+}
+
 func writeBrokerListTable(w io.Writer, brokers []servicecatalog.Broker) {
 	t := NewListTable(w)
 	t.SetHeader([]string{
@@ -66,15 +190,52 @@ func writeBrokerListTable(w io.Writer, brokers []servicecatalog.Broker) {
 	t.Render()
 }
 
+// writeBrokerListWideTable prints the brokers with the message of their last
+// condition, which explains why a broker is not ready.
+func writeBrokerListWideTable(w io.Writer, brokers []servicecatalog.Broker) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Namespace",
+		"URL",
+		"Status",
+		"Message",
+	})
+	for _, broker := range brokers {
+		t.Append([]string{
+			broker.GetName(),
+			broker.GetNamespace(),
+			broker.GetURL(),
+			getBrokerStatusShort(broker.GetStatus()),
+			getBrokerStatusCondition(broker.GetStatus()).Message,
+		})
+	}
+	t.Render()
+}
+
 // WriteBrokerList prints a list of brokers in the specified output format.
 func WriteBrokerList(w io.Writer, outputFormat string, brokers ...servicecatalog.Broker) {
 	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, brokers)
-	case FormatYAML:
-		writeYAML(w, brokers, 0)
+	case FormatJSON, FormatYAML:
+		out := make([]interface{}, 0, len(brokers))
+		for _, broker := range brokers {
+			out = append(out, newBrokerOutput(broker))
+		}
+		if outputFormat == FormatJSON {
+			writeJSON(w, out)
+		} else {
+			writeYAML(w, out, 0)
+		}
+	case FormatName, FormatNameWithKind:
+		names := make([]string, 0, len(brokers))
+		for _, broker := range brokers {
+			names = append(names, brokerResourceName(outputFormat, broker))
+		}
+		writeNames(w, names...)
 	case FormatTable:
 		writeBrokerListTable(w, brokers)
+	case FormatWide:
+		writeBrokerListWideTable(w, brokers)
 	}
 }
 
@@ -82,11 +243,15 @@ func WriteBrokerList(w io.Writer, outputFormat string, brokers ...servicecatalog
 func WriteBroker(w io.Writer, outputFormat string, broker servicecatalog.Broker) {
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, broker)
+		writeJSON(w, newBrokerOutput(broker))
 	case FormatYAML:
-		writeYAML(w, broker, 0)
+		writeYAML(w, newBrokerOutput(broker), 0)
+	case FormatName, FormatNameWithKind:
+		writeNames(w, brokerResourceName(outputFormat, broker))
 	case FormatTable:
 		writeBrokerListTable(w, []servicecatalog.Broker{broker})
+	case FormatWide:
+		writeBrokerListWideTable(w, []servicecatalog.Broker{broker})
 	}
 }
 
@@ -101,6 +266,56 @@ func WriteBrokerDetails(w io.Writer, broker servicecatalog.Broker) {
 	}
 	table = append(table, []string{"URL:", broker.GetURL()})
 	table = append(table, []string{"Status:", getBrokerStatusFull(broker.GetStatus())})
+	if operation := getBrokerCurrentOperation(broker.GetStatus(), time.Now()); operation != "" {
+		table = append(table, []string{"Current Operation:", operation})
+	}
 	t.AppendBulk(table)
 	t.Render()
 }
+
+// WriteBrokerMetrics prints the counts of the recent operations of a broker.
+func WriteBrokerMetrics(w io.Writer, metrics *servicecatalog.BrokerMetrics) {
+	fmt.Fprintln(w, "\nMetrics:")
+	if metrics.Source == servicecatalog.BrokerMetricsSourceStatus {
+		fmt.Fprintf(w, "  %s\n", metrics.MetricsError)
+		fmt.Fprintln(w, "  Showing the ready and failed instances and bindings of the broker instead.")
+	} else {
+		fmt.Fprintln(w, "  Requests sent to the broker since the controller manager started.")
+	}
+
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Operation",
+		"Succeeded",
+		"Failed",
+	})
+	for _, operation := range metrics.Operations {
+		t.Append([]string{
+			operation.Operation,
+			strconv.Itoa(operation.Succeeded),
+			strconv.Itoa(operation.Failed),
+		})
+	}
+	t.Render()
+}
+
+// WriteBrokerCatalogSummary prints the summary of the catalog retrieved from
+// a broker that was validated.
+func WriteBrokerCatalogSummary(w io.Writer, url string, summary *servicecatalog.BrokerCatalogSummary) {
+	t := NewDetailsTable(w)
+	t.AppendBulk([][]string{
+		{"URL:", url},
+		{"API Version:", summary.APIVersion},
+		{"Classes:", strconv.Itoa(summary.Classes)},
+		{"Plans:", strconv.Itoa(summary.Plans)},
+	})
+	t.Render()
+	fmt.Fprintln(w, "The broker returned a valid catalog; it was not registered.")
+}
+
+// brokerResourceName returns the name of the broker printed by the name output
+// formats.
+func brokerResourceName(outputFormat string, broker servicecatalog.Broker) string {
+	resource := scopedResource("clusterservicebroker", "servicebroker", broker.GetNamespace())
+	return resourceName(outputFormat, resource, broker.GetName(), broker.GetName())
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetBrokerCurrentOperation(t *testing.T) {
+	now := time.Now()
+	startTime := metav1.NewTime(now.Add(-2 * time.Minute))
+	tests := []struct {
+		name     string
+		status   v1beta1.CommonServiceBrokerStatus
+		expected string
+	}{
+		{"noOperation", v1beta1.CommonServiceBrokerStatus{}, ""},
+		{"retriedRelist", v1beta1.CommonServiceBrokerStatus{OperationStartTime: &startTime}, ""},
+		{"relist", v1beta1.CommonServiceBrokerStatus{
+			CurrentOperation:   v1beta1.ServiceBrokerOperationRelist,
+			OperationStartTime: &startTime,
+		}, "relisting (started 2m ago)"},
+		{"relistWithoutStartTime", v1beta1.CommonServiceBrokerStatus{
+			CurrentOperation: v1beta1.ServiceBrokerOperationRelist,
+		}, "relisting"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getBrokerCurrentOperation(tt.status, now)
+			if actual != tt.expected {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetBrokerAuthType(t *testing.T) {
+	tests := []struct {
+		name     string
+		broker   servicecatalog.Broker
+		expected string
+	}{
+		{"none", &v1beta1.ClusterServiceBroker{}, "none"},
+		{"emptyAuthInfo", &v1beta1.ClusterServiceBroker{Spec: v1beta1.ClusterServiceBrokerSpec{
+			AuthInfo: &v1beta1.ClusterServiceBrokerAuthInfo{},
+		}}, "none"},
+		{"clusterBasic", &v1beta1.ClusterServiceBroker{Spec: v1beta1.ClusterServiceBrokerSpec{
+			AuthInfo: &v1beta1.ClusterServiceBrokerAuthInfo{Basic: &v1beta1.ClusterBasicAuthConfig{}},
+		}}, "basic"},
+		{"clusterServiceAccountToken", &v1beta1.ClusterServiceBroker{Spec: v1beta1.ClusterServiceBrokerSpec{
+			AuthInfo: &v1beta1.ClusterServiceBrokerAuthInfo{ServiceAccountToken: &v1beta1.ClusterServiceAccountTokenAuthConfig{}},
+		}}, "serviceAccountToken"},
+		{"namespacedBearer", &v1beta1.ServiceBroker{Spec: v1beta1.ServiceBrokerSpec{
+			AuthInfo: &v1beta1.ServiceBrokerAuthInfo{Bearer: &v1beta1.BearerTokenAuthConfig{}},
+		}}, "bearer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getBrokerAuthType(tt.broker)
+			if actual != tt.expected {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetBrokerLastError(t *testing.T) {
+	ready := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue, Message: "fetched"}
+	notReady := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionFalse, Message: "connection refused"}
+	failed := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionFailed, Status: v1beta1.ConditionTrue, Message: "retries stopped"}
+	tests := []struct {
+		name       string
+		conditions []v1beta1.ServiceBrokerCondition
+		expected   string
+	}{
+		{"noConditions", nil, ""},
+		{"ready", []v1beta1.ServiceBrokerCondition{ready}, ""},
+		{"notReady", []v1beta1.ServiceBrokerCondition{notReady}, "connection refused"},
+		{"failed", []v1beta1.ServiceBrokerCondition{notReady, failed}, "retries stopped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getBrokerLastError(v1beta1.CommonServiceBrokerStatus{Conditions: tt.conditions})
+			if actual != tt.expected {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expected, actual)
+			}
+		})
+	}
+}
@@ -20,9 +20,92 @@ import (
 	"io"
 	"strings"
 
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// classExtras holds the fields that the JSON and YAML representation of a
+// class adds to the class: whether its plans have parameter schemas, and the
+// schemas themselves when they are requested.
+type classExtras struct {
+	HasSchemas classSchemasPresent `json:"hasSchemas"`
+	Schemas    []planSchemas       `json:"schemas,omitempty"`
+}
+
+// clusterServiceClassOutput is the JSON and YAML representation of a
+// ClusterServiceClass.
+type clusterServiceClassOutput struct {
+	*v1beta1.ClusterServiceClass `json:",inline"`
+	classExtras                  `json:",inline"`
+}
+
+// serviceClassOutput is the JSON and YAML representation of a ServiceClass.
+type serviceClassOutput struct {
+	*v1beta1.ServiceClass `json:",inline"`
+	classExtras           `json:",inline"`
+}
+
+// classSchemasPresent indicates which parameter schemas are defined by at
+// least one plan of a class.
+type classSchemasPresent struct {
+	InstanceCreate bool `json:"instanceCreate"`
+	InstanceUpdate bool `json:"instanceUpdate"`
+	BindingCreate  bool `json:"bindingCreate"`
+}
+
+// planSchemas holds the parameter schemas of a plan of a class.
+type planSchemas struct {
+	Plan           string                `json:"plan"`
+	InstanceCreate *runtime.RawExtension `json:"instanceCreate,omitempty"`
+	InstanceUpdate *runtime.RawExtension `json:"instanceUpdate,omitempty"`
+	BindingCreate  *runtime.RawExtension `json:"bindingCreate,omitempty"`
+}
+
+// newClassExtras summarizes the schemas of the plans of a class, from plans
+// which may include the plans of other classes. The schemas of the plans are
+// only included when showSchemas is set.
+func newClassExtras(class servicecatalog.Class, plans []servicecatalog.Plan, showSchemas bool) classExtras {
+	var extras classExtras
+	for _, plan := range plans {
+		if plan.GetClassID() != class.GetName() || plan.GetNamespace() != class.GetNamespace() {
+			continue
+		}
+		schemas := planSchemas{
+			Plan:           plan.GetExternalName(),
+			InstanceCreate: plan.GetInstanceCreateSchema(),
+			InstanceUpdate: plan.GetInstanceUpdateSchema(),
+			BindingCreate:  plan.GetBindingCreateSchema(),
+		}
+		extras.HasSchemas.InstanceCreate = extras.HasSchemas.InstanceCreate || schemas.InstanceCreate != nil
+		extras.HasSchemas.InstanceUpdate = extras.HasSchemas.InstanceUpdate || schemas.InstanceUpdate != nil
+		extras.HasSchemas.BindingCreate = extras.HasSchemas.BindingCreate || schemas.BindingCreate != nil
+		if showSchemas {
+			extras.Schemas = append(extras.Schemas, schemas)
+		}
+	}
+	return extras
+}
+
+// newClassOutput returns a copy of the class with its kind and API version
+// set, so that cluster-scoped and namespaced classes can be told apart, and
+// with the extras alongside its metadata, spec and status.
+func newClassOutput(class servicecatalog.Class, extras classExtras) interface{} {
+	switch c := class.(type) {
+	case *v1beta1.ClusterServiceClass:
+		out := c.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ClusterServiceClass"
+		return clusterServiceClassOutput{ClusterServiceClass: out, classExtras: extras}
+	case *v1beta1.ServiceClass:
+		out := c.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ServiceClass"
+		return serviceClassOutput{ServiceClass: out, classExtras: extras}
+	}
+	return class
+}
+
 func getScope(class servicecatalog.Class) string {
 	if class.GetNamespace() != "" {
 		return servicecatalog.NamespaceScope
@@ -35,14 +118,16 @@ func writeClassListTable(w io.Writer, classes []servicecatalog.Class) {
 
 	t.SetHeader([]string{
 		"Name",
+		"Scope",
 		"Namespace",
 		"Description",
 	})
-	t.SetVariableColumn(3)
+	t.SetVariableColumn(4)
 
 	for _, class := range classes {
 		t.Append([]string{
-			class.GetExternalName(),
+			listedName(class.GetExternalName(), class.GetStatusText()),
+			getScope(class),
 			class.GetNamespace(),
 			class.GetDescription(),
 		})
@@ -51,26 +136,42 @@ func writeClassListTable(w io.Writer, classes []servicecatalog.Class) {
 	t.Render()
 }
 
-// WriteClassList prints a list of classes in the specified output format.
-func WriteClassList(w io.Writer, outputFormat string, classes ...servicecatalog.Class) {
+// WriteClassList prints a list of classes in the specified output format. The
+// JSON and YAML output indicate which schemas the plans of each class have,
+// and include the schemas when showSchemas is set.
+func WriteClassList(w io.Writer, outputFormat string, plans []servicecatalog.Plan, showSchemas bool, classes ...servicecatalog.Class) {
 	switch outputFormat {
-	case FormatJSON:
-		writeJSON(w, classes)
-	case FormatYAML:
-		writeYAML(w, classes, 0)
-	case FormatTable:
+	case FormatJSON, FormatYAML:
+		out := make([]interface{}, 0, len(classes))
+		for _, class := range classes {
+			out = append(out, newClassOutput(class, newClassExtras(class, plans, showSchemas)))
+		}
+		if outputFormat == FormatJSON {
+			writeJSON(w, out)
+		} else {
+			writeYAML(w, out, 0)
+		}
+	case FormatName, FormatNameWithKind:
+		names := make([]string, 0, len(classes))
+		for _, class := range classes {
+			names = append(names, classResourceName(outputFormat, class))
+		}
+		writeNames(w, names...)
+	case FormatTable, FormatWide:
 		writeClassListTable(w, classes)
 	}
 }
 
 // WriteClass prints a single class in the specified output format.
-func WriteClass(w io.Writer, outputFormat string, class servicecatalog.Class) {
+func WriteClass(w io.Writer, outputFormat string, plans []servicecatalog.Plan, showSchemas bool, class servicecatalog.Class) {
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, class)
+		writeJSON(w, newClassOutput(class, newClassExtras(class, plans, showSchemas)))
 	case FormatYAML:
-		writeYAML(w, class, 0)
-	case FormatTable:
+		writeYAML(w, newClassOutput(class, newClassExtras(class, plans, showSchemas)), 0)
+	case FormatName, FormatNameWithKind:
+		writeNames(w, classResourceName(outputFormat, class))
+	case FormatTable, FormatWide:
 		writeClassListTable(w, []servicecatalog.Class{class})
 	}
 }
@@ -95,6 +196,77 @@ func WriteClassDetails(w io.Writer, class servicecatalog.Class) {
 	t.Render()
 }
 
+// classDescription is the JSON and YAML representation of the description
+// of a class. It holds the fields of the class listed by the human view, and
+// the plans of the class.
+type classDescription struct {
+	Kind                     string              `json:"kind"`
+	Scope                    string              `json:"scope"`
+	Name                     string              `json:"name"`
+	Namespace                string              `json:"namespace,omitempty"`
+	ExternalName             string              `json:"externalName"`
+	ExternalID               string              `json:"externalID"`
+	Description              string              `json:"description"`
+	ClusterServiceBrokerName string              `json:"clusterServiceBrokerName,omitempty"`
+	ServiceBrokerName        string              `json:"serviceBrokerName,omitempty"`
+	Tags                     []string            `json:"tags,omitempty"`
+	Bindable                 bool                `json:"bindable"`
+	Status                   string              `json:"status"`
+	HasSchemas               classSchemasPresent `json:"hasSchemas"`
+	Plans                    []associatedPlan    `json:"plans"`
+}
+
+// associatedPlan is the JSON and YAML representation of a plan listed in the
+// description of its class.
+type associatedPlan struct {
+	Name         string `json:"name"`
+	ExternalName string `json:"externalName"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+}
+
+// WriteClassDescription prints the description of a class and of its plans:
+// the details and the list of the plans in the table format, or a stable
+// representation of both in the JSON and YAML formats.
+func WriteClassDescription(w io.Writer, outputFormat string, class servicecatalog.Class, plans []servicecatalog.Plan) {
+	if outputFormat != FormatJSON && outputFormat != FormatYAML {
+		WriteClassDetails(w, class)
+		WriteAssociatedPlans(w, plans)
+		return
+	}
+
+	spec := class.GetSpec()
+	out := classDescription{
+		Kind:         "ServiceClass",
+		Scope:        getScope(class),
+		Name:         class.GetName(),
+		Namespace:    class.GetNamespace(),
+		ExternalName: spec.ExternalName,
+		ExternalID:   spec.ExternalID,
+		Description:  spec.Description,
+		Tags:         spec.Tags,
+		Bindable:     spec.Bindable,
+		Status:       class.GetStatusText(),
+		HasSchemas:   newClassExtras(class, plans, false).HasSchemas,
+		Plans:        make([]associatedPlan, 0, len(plans)),
+	}
+	if class.IsClusterServiceClass() {
+		out.Kind = "ClusterServiceClass"
+		out.ClusterServiceBrokerName = class.GetServiceBrokerName()
+	} else {
+		out.ServiceBrokerName = class.GetServiceBrokerName()
+	}
+	for _, plan := range plans {
+		out.Plans = append(out.Plans, associatedPlan{
+			Name:         plan.GetName(),
+			ExternalName: plan.GetExternalName(),
+			Description:  plan.GetDescription(),
+			Status:       plan.GetShortStatus(),
+		})
+	}
+	writeStructured(w, outputFormat, out)
+}
+
 // WriteClassAndPlanDetails prints details for multiple classes and plans
 func WriteClassAndPlanDetails(w io.Writer, classes []servicecatalog.Class, plans [][]servicecatalog.Plan) {
 	t := NewListTable(w)
@@ -107,14 +279,14 @@ func WriteClassAndPlanDetails(w io.Writer, classes []servicecatalog.Class, plans
 		for i, plan := range plans[i] {
 			if i == 0 {
 				t.Append([]string{
-					class.GetExternalName(),
-					plan.GetExternalName(),
+					listedName(class.GetExternalName(), class.GetStatusText()),
+					listedName(plan.GetExternalName(), plan.GetShortStatus()),
 					class.GetSpec().Description,
 				})
 			} else {
 				t.Append([]string{
 					"",
-					plan.GetExternalName(),
+					listedName(plan.GetExternalName(), plan.GetShortStatus()),
 					"",
 				})
 			}
@@ -124,3 +296,10 @@ func WriteClassAndPlanDetails(w io.Writer, classes []servicecatalog.Class, plans
 	t.SetVariableColumn(3)
 	t.Render()
 }
+
+// classResourceName returns the name of the class printed by the name output
+// formats.
+func classResourceName(outputFormat string, class servicecatalog.Class) string {
+	resource := scopedResource("clusterserviceclass", "serviceclass", class.GetNamespace())
+	return resourceName(outputFormat, resource, class.GetExternalName(), class.GetName())
+}
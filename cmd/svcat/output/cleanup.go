@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+)
+
+// FormatCatalogObject describes a class or plan by its kind, namespace,
+// Kubernetes name and external name, e.g.
+// "clusterserviceclass 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468 (mysqldb)".
+func FormatCatalogObject(obj interface {
+	GetName() string
+	GetNamespace() string
+	GetExternalName() string
+}) string {
+	var kind string
+	switch obj.(type) {
+	case servicecatalog.Class:
+		kind = "serviceclass"
+	case servicecatalog.Plan:
+		kind = "serviceplan"
+	}
+	name := obj.GetName()
+	if obj.GetNamespace() == "" {
+		kind = "cluster" + kind
+	} else {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return fmt.Sprintf("%s %s (%s)", kind, name, obj.GetExternalName())
+}
+
+// WriteRemovedCatalog prints the classes and plans that a cleanup would delete
+func WriteRemovedCatalog(w io.Writer, removed *servicecatalog.RemovedCatalog) {
+	if len(removed.Plans) == 0 && len(removed.Classes) == 0 {
+		fmt.Fprintln(w, "No classes or plans to clean up")
+		return
+	}
+	for _, plan := range removed.Plans {
+		fmt.Fprintf(w, "would delete %s\n", FormatCatalogObject(plan))
+	}
+	for _, class := range removed.Classes {
+		fmt.Fprintf(w, "would delete %s\n", FormatCatalogObject(class))
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ClientConfig is the configuration that svcat uses to connect to the API server.
+type ClientConfig struct {
+	Context   string
+	Server    string
+	Namespace string
+	Auth      string
+
+	// APIError is the error returned when contacting the Service Catalog API,
+	// it is nil when the API is reachable.
+	APIError error
+
+	// The remaining fields are only printed with --raw.
+	Raw         bool
+	Kubeconfigs []string
+	Cluster     string
+	User        string
+	Resources   []string
+}
+
+// WriteClientConfig prints the configuration that svcat uses to connect to
+// the API server, and whether the Service Catalog API is reachable.
+func WriteClientConfig(w io.Writer, config ClientConfig) {
+	t := NewDetailsTable(w)
+	table := [][]string{}
+	if config.Raw {
+		table = append(table, []string{"Kubeconfig:", strings.Join(config.Kubeconfigs, ", ")})
+	}
+	table = append(table, []string{"Context:", config.Context})
+	if config.Raw {
+		table = append(table, []string{"Cluster:", config.Cluster})
+		table = append(table, []string{"User:", config.User})
+	}
+	table = append(table, []string{"Server:", config.Server})
+	table = append(table, []string{"Namespace:", config.Namespace})
+	table = append(table, []string{"Auth:", config.Auth})
+	if config.APIError != nil {
+		table = append(table, []string{"Service Catalog API:", fmt.Sprintf("Unreachable - %v", config.APIError)})
+	} else {
+		table = append(table, []string{"Service Catalog API:", "Reachable"})
+		if config.Raw {
+			table = append(table, []string{"Resources:", strings.Join(config.Resources, ", ")})
+		}
+	}
+	t.AppendBulk(table)
+	t.Render()
+}
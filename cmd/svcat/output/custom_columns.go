@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// missingValue is printed in custom-columns output for fields that are not set.
+const missingValue = "<none>"
+
+// Column is a column of custom-columns output, with its header and the JSONPath
+// expression that selects its value in each object.
+type Column struct {
+	Header string
+	Path   string
+
+	parser *jsonpath.JSONPath
+}
+
+// ParseCustomColumns parses the spec of the custom-columns output format, a
+// comma separated list of HEADER:JSONPATH pairs such as
+// NAME:.spec.externalName,FREE:.spec.free. As with kubectl, the JSONPath
+// expressions may be given with or without the enclosing braces.
+func ParseCustomColumns(spec string) ([]Column, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no columns given")
+	}
+
+	parts := strings.Split(spec, ",")
+	columns := make([]Column, 0, len(parts))
+	for _, part := range parts {
+		colSpec := strings.SplitN(part, ":", 2)
+		if len(colSpec) != 2 || colSpec[0] == "" || colSpec[1] == "" {
+			return nil, fmt.Errorf("unexpected custom-columns spec %q, expected HEADER:JSONPATH", part)
+		}
+
+		path := colSpec[1]
+		if !strings.HasPrefix(path, "{") {
+			path = "{" + path + "}"
+		}
+		parser := jsonpath.New(colSpec[0]).AllowMissingKeys(true)
+		if err := parser.Parse(path); err != nil {
+			return nil, fmt.Errorf("invalid JSONPath %q for column %s: %v", colSpec[1], colSpec[0], err)
+		}
+
+		columns = append(columns, Column{
+			Header: colSpec[0],
+			Path:   colSpec[1],
+			parser: parser,
+		})
+	}
+	return columns, nil
+}
+
+// WriteCustomColumns prints the given object, or slice of objects, with one
+// row per object and one column per custom column. The headers are omitted
+// when noHeaders is true.
+func WriteCustomColumns(w io.Writer, columns []Column, noHeaders bool, objects interface{}) error {
+	items := listItems(objects)
+
+	tw := tabwriter.NewWriter(w, 5, 8, 3, ' ', 0)
+	if !noHeaders {
+		headers := make([]string, 0, len(columns))
+		for _, col := range columns {
+			headers = append(headers, col.Header)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, item := range items {
+		// The objects are evaluated in their JSON form so that the paths
+		// use the same field names as the json and yaml output formats
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		var obj interface{}
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return err
+		}
+
+		cells := make([]string, 0, len(columns))
+		for _, col := range columns {
+			buf := &bytes.Buffer{}
+			if err := col.parser.Execute(buf, obj); err != nil {
+				return fmt.Errorf("unable to evaluate the JSONPath %q of column %s: %v", col.Path, col.Header, err)
+			}
+			cell := buf.String()
+			if cell == "" {
+				cell = missingValue
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// listItems returns the elements of the given slice of objects, or the given
+// object alone when it is not a slice.
+func listItems(objects interface{}) []interface{} {
+	items := []interface{}{}
+	v := reflect.ValueOf(objects)
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i).Interface())
+		}
+	} else {
+		items = append(items, objects)
+	}
+	return items
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	testcases := []struct {
+		name    string // Test name
+		spec    string // Spec tested
+		headers []string
+		err     string // Expected error, if any
+	}{
+		{"Single column", "NAME:.metadata.name", []string{"NAME"}, ""},
+		{"Several columns", "NAME:.metadata.name,FREE:{.spec.free}", []string{"NAME", "FREE"}, ""},
+		{"No columns", "", nil, "no columns given"},
+		{"Missing path", "NAME", nil, `unexpected custom-columns spec "NAME"`},
+		{"Empty header", ":.metadata.name", nil, `unexpected custom-columns spec ":.metadata.name"`},
+		{"Invalid path", "NAME:{.metadata.name", nil, `invalid JSONPath "{.metadata.name" for column NAME`},
+	}
+
+	for _, tc := range testcases {
+		columns, err := ParseCustomColumns(tc.spec)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%v: expected an error containing %q, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		var headers []string
+		for _, col := range columns {
+			headers = append(headers, col.Header)
+		}
+		if strings.Join(headers, ",") != strings.Join(tc.headers, ",") {
+			t.Errorf("%v: headers mismatch: expected %v, actual %v", tc.name, tc.headers, headers)
+		}
+	}
+}
+
+func TestWriteCustomColumns(t *testing.T) {
+	free := &v1beta1.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-1"},
+		Spec: v1beta1.ClusterServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "default", Free: true},
+		},
+	}
+	paid := &v1beta1.ServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-2", Namespace: "test-ns"},
+		Spec: v1beta1.ServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "premium"},
+		},
+	}
+	columns, err := ParseCustomColumns("NAME:.spec.externalName,NAMESPACE:.metadata.namespace,FREE:.spec.free")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testcases := []struct {
+		name      string      // Test name
+		objects   interface{} // Objects tested
+		noHeaders bool
+		output    string // Expected output
+	}{
+		{"List", []interface{}{free, paid}, false, "" +
+			"NAME      NAMESPACE   FREE\n" +
+			"default   <none>      true\n" +
+			"premium   test-ns     false\n"},
+		{"Single object", free, false, "" +
+			"NAME      NAMESPACE   FREE\n" +
+			"default   <none>      true\n"},
+		{"No headers", []interface{}{free}, true, "default   <none>   true\n"},
+		{"Empty list", []interface{}{}, false, "NAME   NAMESPACE   FREE\n"},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		if err := WriteCustomColumns(output, columns, tc.noHeaders, tc.objects); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	svcatsdk "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/api/core/v1"
+)
+
+func getEventRow(event v1.Event) []string {
+	return []string{
+		svcatsdk.GetEventTime(event).UTC().String(),
+		event.Type,
+		event.Reason,
+		strings.TrimSpace(event.Message),
+	}
+}
+
+// WriteEventList prints a list of events.
+func WriteEventList(w io.Writer, eventList *v1.EventList) {
+	if len(eventList.Items) == 0 {
+		fmt.Fprintln(w, "No events found.")
+		return
+	}
+
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Last Seen",
+		"Type",
+		"Reason",
+		"Message",
+	})
+	t.SetVariableColumn(4)
+
+	for _, event := range eventList.Items {
+		t.Append(getEventRow(event))
+	}
+
+	t.Render()
+}
+
+// WriteEvent prints a single event on one line, used when following events.
+func WriteEvent(w io.Writer, event v1.Event) {
+	fmt.Fprintln(w, strings.Join(getEventRow(event), "  "))
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/olekukonko/tablewriter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func getInstanceStatusCondition(status v1beta1.ServiceInstanceStatus) v1beta1.ServiceInstanceCondition {
@@ -50,6 +52,57 @@ func appendInstanceDashboardURL(status v1beta1.ServiceInstanceStatus, table *tab
 	}
 }
 
+func appendInstanceLastOperationDescription(status v1beta1.ServiceInstanceStatus, table *tablewriter.Table) {
+	if status.LastOperationDescription != "" {
+		table.AppendBulk([][]string{
+			{"Last Operation:", status.LastOperationDescription},
+		})
+	}
+}
+
+// InstanceProgressWriter returns a function that prints the description of
+// the progress of the ongoing operation of an instance, as reported by the
+// broker, each time it changes.
+func InstanceProgressWriter(w io.Writer) func(*v1beta1.ServiceInstance) {
+	var last string
+	return func(instance *v1beta1.ServiceInstance) {
+		description := instance.Status.LastOperationDescription
+		if description != "" && description != last {
+			fmt.Fprintf(w, "  %s\n", description)
+		}
+		last = description
+	}
+}
+
+func getPropertiesPlan(props *v1beta1.ServiceInstancePropertiesState) string {
+	if props == nil {
+		return ""
+	}
+	if props.ClusterServicePlanExternalName != "" {
+		return props.ClusterServicePlanExternalName
+	}
+	return props.ServicePlanExternalName
+}
+
+// getInstanceAppliedPlan returns the plan that the broker last provisioned or
+// updated the instance with, which differs from the plan of the spec while an
+// update to another plan is in progress or after it failed.
+func getInstanceAppliedPlan(status v1beta1.ServiceInstanceStatus) string {
+	return getPropertiesPlan(status.ExternalProperties)
+}
+
+func appendInstanceAppliedProperties(status v1beta1.ServiceInstanceStatus, table *tablewriter.Table) {
+	if status.ExternalProperties != nil {
+		table.Append([]string{"Applied Plan:", getInstanceAppliedPlan(status)})
+		if plan := getPropertiesPlan(status.InProgressProperties); plan != "" && plan != getInstanceAppliedPlan(status) {
+			table.Append([]string{"Plan In Progress:", plan})
+		}
+		if checksum := status.ExternalProperties.ParameterChecksum; checksum != "" {
+			table.Append([]string{"Parameters Checksum:", checksum})
+		}
+	}
+}
+
 func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceList) {
 	t := NewListTable(w)
 	t.SetHeader([]string{
@@ -57,6 +110,7 @@ func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceLi
 		"Namespace",
 		"Class",
 		"Plan",
+		"Applied Plan",
 		"Status",
 	})
 
@@ -66,6 +120,7 @@ func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceLi
 			instance.Namespace,
 			instance.Spec.GetSpecifiedClusterServiceClass(),
 			instance.Spec.GetSpecifiedClusterServicePlan(),
+			getInstanceAppliedPlan(instance.Status),
 			getInstanceStatusShort(instance.Status),
 		})
 	}
@@ -80,7 +135,13 @@ func WriteInstanceList(w io.Writer, outputFormat string, instanceList *v1beta1.S
 		writeJSON(w, instanceList)
 	case FormatYAML:
 		writeYAML(w, instanceList, 0)
-	case FormatTable:
+	case FormatName, FormatNameWithKind:
+		names := make([]string, 0, len(instanceList.Items))
+		for _, instance := range instanceList.Items {
+			names = append(names, resourceName(outputFormat, "serviceinstance", instance.Name, instance.Name))
+		}
+		writeNames(w, names...)
+	case FormatTable, FormatWide:
 		writeInstanceListTable(w, instanceList)
 	}
 }
@@ -92,7 +153,9 @@ func WriteInstance(w io.Writer, outputFormat string, instance v1beta1.ServiceIns
 		writeJSON(w, instance)
 	case FormatYAML:
 		writeYAML(w, instance, 0)
-	case FormatTable:
+	case FormatName, FormatNameWithKind:
+		writeNames(w, resourceName(outputFormat, "serviceinstance", instance.Name, instance.Name))
+	case FormatTable, FormatWide:
 		p := v1beta1.ServiceInstanceList{
 			Items: []v1beta1.ServiceInstance{instance},
 		}
@@ -112,6 +175,10 @@ func WriteParentInstance(w io.Writer, instance *v1beta1.ServiceInstance) {
 	t.Render()
 }
 
+// associatedInstancesSummaryThreshold is the number of instances associated
+// with a plan above which the list is followed by a count summary.
+const associatedInstancesSummaryThreshold = 10
+
 // WriteAssociatedInstances prints a list of instances associated with a plan.
 func WriteAssociatedInstances(w io.Writer, instances []v1beta1.ServiceInstance) {
 	fmt.Fprintln(w, "\nInstances:")
@@ -134,6 +201,14 @@ func WriteAssociatedInstances(w io.Writer, instances []v1beta1.ServiceInstance)
 		})
 	}
 	t.Render()
+
+	if len(instances) > associatedInstancesSummaryThreshold {
+		namespaces := map[string]bool{}
+		for _, instance := range instances {
+			namespaces[instance.Namespace] = true
+		}
+		fmt.Fprintf(w, "%d instances in %d namespaces\n", len(instances), len(namespaces))
+	}
 }
 
 // WriteInstanceDetails prints an instance.
@@ -144,13 +219,161 @@ func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 		{"Namespace:", instance.Namespace},
 		{"Status:", getInstanceStatusFull(instance.Status)},
 	})
+	appendInstanceLastOperationDescription(instance.Status, t)
 	appendInstanceDashboardURL(instance.Status, t)
 	t.AppendBulk([][]string{
 		{"Class:", instance.Spec.GetSpecifiedClusterServiceClass()},
 		{"Plan:", instance.Spec.GetSpecifiedClusterServicePlan()},
 	})
+	appendInstanceAppliedProperties(instance.Status, t)
 	t.Render()
 
 	writeParameters(w, instance.Spec.Parameters)
 	writeParametersFrom(w, instance.Spec.ParametersFrom)
+	writeProvisionMetadata(w, instance.Status.ProvisionMetadata)
+}
+
+// writeProvisionMetadata prints the labels and the attributes that the broker
+// returned when provisioning an instance, if any.
+func writeProvisionMetadata(w io.Writer, metadata *v1beta1.ServiceInstanceProvisionMetadata) {
+	if metadata == nil {
+		return
+	}
+	fmt.Fprintln(w, "\nProvision Metadata:")
+	writeYAML(w, metadata, 2)
+}
+
+// associatedInstance is the JSON and YAML representation of an instance
+// listed in the description of its plan.
+type associatedInstance struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+}
+
+func newAssociatedInstances(instances []v1beta1.ServiceInstance) []associatedInstance {
+	out := make([]associatedInstance, 0, len(instances))
+	for _, instance := range instances {
+		out = append(out, associatedInstance{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Status:    getInstanceStatusShort(instance.Status),
+		})
+	}
+	return out
+}
+
+// instanceDescription is the JSON and YAML representation of the description
+// of an instance. It holds the fields shown by the human view, the status
+// being split into the status, the message and the time of the last
+// condition of the instance. The secret parameter refs and the parameters
+// sent to the broker are only included when they are requested.
+type instanceDescription struct {
+	Name                 string                                    `json:"name"`
+	Namespace            string                                    `json:"namespace"`
+	Status               string                                    `json:"status"`
+	StatusMessage        string                                    `json:"statusMessage,omitempty"`
+	LastTransitionTime   *metav1.Time                              `json:"lastTransitionTime,omitempty"`
+	LastOperation        string                                    `json:"lastOperation,omitempty"`
+	DashboardURL         string                                    `json:"dashboardURL,omitempty"`
+	ProvisionMetadata    *v1beta1.ServiceInstanceProvisionMetadata `json:"provisionMetadata,omitempty"`
+	Class                string                                    `json:"class"`
+	Plan                 string                                    `json:"plan"`
+	AppliedPlan          string                                    `json:"appliedPlan,omitempty"`
+	PlanInProgress       string                                    `json:"planInProgress,omitempty"`
+	ParametersChecksum   string                                    `json:"parametersChecksum,omitempty"`
+	Parameters           *runtime.RawExtension                     `json:"parameters,omitempty"`
+	ParametersFrom       []v1beta1.ParametersFromSource            `json:"parametersFrom,omitempty"`
+	SecretParameterRefs  []v1beta1.SecretParameterReference        `json:"secretParameterRefs,omitempty"`
+	AppliedParameters    *runtime.RawExtension                     `json:"appliedParameters,omitempty"`
+	ParametersInProgress *runtime.RawExtension                     `json:"parametersInProgress,omitempty"`
+	Bindings             []associatedBinding                       `json:"bindings"`
+}
+
+// WriteInstanceDescription prints the description of an instance and of its
+// bindings. The secret parameter refs and the parameters last sent to the
+// broker are only printed when showParams is set.
+func WriteInstanceDescription(w io.Writer, outputFormat string, instance *v1beta1.ServiceInstance, bindings []v1beta1.ServiceBinding, showParams bool) {
+	if outputFormat != FormatJSON && outputFormat != FormatYAML {
+		WriteInstanceDetails(w, instance)
+		if showParams {
+			WriteInstanceParameters(w, instance)
+		}
+		WriteAssociatedBindings(w, bindings)
+		return
+	}
+
+	lastCond := getInstanceStatusCondition(instance.Status)
+	out := instanceDescription{
+		Name:           instance.Name,
+		Namespace:      instance.Namespace,
+		Status:         getInstanceStatusShort(instance.Status),
+		StatusMessage:  lastCond.Message,
+		LastOperation:  instance.Status.LastOperationDescription,
+		Class:          instance.Spec.GetSpecifiedClusterServiceClass(),
+		Plan:           instance.Spec.GetSpecifiedClusterServicePlan(),
+		AppliedPlan:    getInstanceAppliedPlan(instance.Status),
+		Parameters:     instance.Spec.Parameters,
+		ParametersFrom: instance.Spec.ParametersFrom,
+		Bindings:       newAssociatedBindings(bindings),
+	}
+	if out.Class == "" {
+		out.Class = instance.Spec.GetSpecifiedServiceClass()
+		out.Plan = instance.Spec.GetSpecifiedServicePlan()
+	}
+	if !lastCond.LastTransitionTime.IsZero() {
+		out.LastTransitionTime = &lastCond.LastTransitionTime
+	}
+	if instance.Status.DashboardURL != nil {
+		out.DashboardURL = *instance.Status.DashboardURL
+	}
+	out.ProvisionMetadata = instance.Status.ProvisionMetadata
+	if props := instance.Status.ExternalProperties; props != nil {
+		if plan := getPropertiesPlan(instance.Status.InProgressProperties); plan != out.AppliedPlan {
+			out.PlanInProgress = plan
+		}
+		out.ParametersChecksum = props.ParameterChecksum
+	}
+	if showParams {
+		out.SecretParameterRefs = instance.Spec.SecretParameterRefs
+		if props := instance.Status.ExternalProperties; props != nil {
+			out.AppliedParameters = props.Parameters
+		}
+		if props := instance.Status.InProgressProperties; props != nil {
+			out.ParametersInProgress = props.Parameters
+		}
+	}
+	writeStructured(w, outputFormat, out)
+}
+
+// WriteInstanceParameters prints the sources of the parameters of an instance
+// that are not inline, and the parameters last sent to the broker. The values
+// read from secrets are never printed, the controller records them as
+// "<redacted>".
+func WriteInstanceParameters(w io.Writer, instance *v1beta1.ServiceInstance) {
+	writeSecretParameterRefs(w, instance.Spec.SecretParameterRefs)
+
+	var applied *runtime.RawExtension
+	if instance.Status.ExternalProperties != nil {
+		applied = instance.Status.ExternalProperties.Parameters
+	}
+	writeParametersSection(w, "Applied Parameters:", "No parameters applied", applied)
+	if instance.Status.InProgressProperties != nil {
+		writeParametersSection(w, "Parameters In Progress:", "No parameters defined", instance.Status.InProgressProperties.Parameters)
+	}
+}
+
+func writeSecretParameterRefs(w io.Writer, refs []v1beta1.SecretParameterReference) {
+	if len(refs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nSecret Parameter Refs:")
+	for _, ref := range refs {
+		line := fmt.Sprintf("  Secret: %s.%s", ref.SecretKeyRef.Name, ref.SecretKeyRef.Key)
+		if ref.PropagateToBindings {
+			line += " (propagated to bindings)"
+		}
+		fmt.Fprintln(w, line)
+	}
 }
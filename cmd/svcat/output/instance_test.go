@@ -22,6 +22,7 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func Test_appendInstanceDashboardURL(t *testing.T) {
@@ -53,3 +54,124 @@ func Test_appendInstanceDashboardURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_appendInstanceLastOperationDescription(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         v1beta1.ServiceInstanceStatus
+		expectedString string
+	}{
+		{"inProgress", v1beta1.ServiceInstanceStatus{
+			LastOperationDescription: "creating database, 40%",
+		}, "Last Operation:   creating database, 40%"},
+		{"noDescription", v1beta1.ServiceInstanceStatus{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			table := NewDetailsTable(&stringBuilder)
+			appendInstanceLastOperationDescription(tt.status, table)
+			table.Render()
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %v; got %v", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
+
+func Test_appendInstanceAppliedProperties(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         v1beta1.ServiceInstanceStatus
+		expectedString string
+	}{
+		{"clusterPlan", v1beta1.ServiceInstanceStatus{
+			ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "default",
+				ParameterChecksum:              "abc123",
+			},
+		}, "Applied Plan:          default  \n  Parameters Checksum:   abc123"},
+		{"namespacedPlan", v1beta1.ServiceInstanceStatus{
+			ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+				ServicePlanExternalName: "premium",
+			},
+		}, "Applied Plan:   premium"},
+		{"planUpdateInProgress", v1beta1.ServiceInstanceStatus{
+			ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "default",
+			},
+			InProgressProperties: &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "premium",
+			},
+		}, "Applied Plan:       default  \n  Plan In Progress:   premium"},
+		{"parametersUpdateInProgress", v1beta1.ServiceInstanceStatus{
+			ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "default",
+			},
+			InProgressProperties: &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "default",
+			},
+		}, "Applied Plan:   default"},
+		{"notApplied", v1beta1.ServiceInstanceStatus{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			table := NewDetailsTable(&stringBuilder)
+			appendInstanceAppliedProperties(tt.status, table)
+			table.Render()
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
+
+func TestWriteInstanceParameters(t *testing.T) {
+	tests := []struct {
+		name           string
+		instance       v1beta1.ServiceInstance
+		expectedString string
+	}{
+		{"notApplied", v1beta1.ServiceInstance{}, "Applied Parameters:\n  No parameters applied"},
+		{"secretParameterRefs", v1beta1.ServiceInstance{
+			Spec: v1beta1.ServiceInstanceSpec{
+				SecretParameterRefs: []v1beta1.SecretParameterReference{
+					{SecretKeyRef: v1beta1.SecretKeyReference{Name: "creds", Key: "admin"}},
+					{SecretKeyRef: v1beta1.SecretKeyReference{Name: "creds", Key: "shared"}, PropagateToBindings: true},
+				},
+			},
+			Status: v1beta1.ServiceInstanceStatus{
+				ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"password":"<redacted>","size":2}`)},
+				},
+			},
+		}, "Secret Parameter Refs:\n  Secret: creds.admin\n  Secret: creds.shared (propagated to bindings)\n\n" +
+			"Applied Parameters:\n  password: <redacted>\n  size: 2"},
+		{"parametersInProgress", v1beta1.ServiceInstance{
+			Status: v1beta1.ServiceInstanceStatus{
+				ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"size":2}`)},
+				},
+				InProgressProperties: &v1beta1.ServiceInstancePropertiesState{
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"size":3}`)},
+				},
+			},
+		}, "Applied Parameters:\n  size: 2\n\nParameters In Progress:\n  size: 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			WriteInstanceParameters(&stringBuilder, &tt.instance)
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPath is the template of the jsonpath output format, such as
+// {.items[*].metadata.name}.
+type JSONPath struct {
+	Template string
+
+	parser *jsonpath.JSONPath
+}
+
+// ParseJSONPath parses the template of the jsonpath output format. As with
+// kubectl, fields missing from an object are printed as empty.
+func ParseJSONPath(template string) (*JSONPath, error) {
+	if template == "" {
+		return nil, fmt.Errorf("jsonpath format specified but no template given")
+	}
+
+	parser := jsonpath.New("jsonpath").AllowMissingKeys(true)
+	if err := parser.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %v", template, err)
+	}
+	return &JSONPath{Template: template, parser: parser}, nil
+}
+
+// WriteJSONPath prints the given object evaluated with the template.
+func WriteJSONPath(w io.Writer, template *JSONPath, obj interface{}) error {
+	return template.execute(w, obj)
+}
+
+// WriteJSONPathList prints the given slice of objects evaluated with the
+// template. As with kubectl, the objects are the items of a List, so that
+// {.items[*].metadata.name} prints the names of all of them.
+func WriteJSONPathList(w io.Writer, template *JSONPath, objects interface{}) error {
+	list := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      listItems(objects),
+	}
+	return template.execute(w, list)
+}
+
+func (p *JSONPath) execute(w io.Writer, obj interface{}) error {
+	// The objects are evaluated in their JSON form so that the paths use the
+	// same field names as the json and yaml output formats
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	if err := p.parser.Execute(w, data); err != nil {
+		return fmt.Errorf("unable to evaluate the JSONPath %q: %v", p.Template, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	testcases := []struct {
+		name     string // Test name
+		template string // Template tested
+		err      string // Expected error, if any
+	}{
+		{"Field", "{.metadata.name}", ""},
+		{"Range over items", "{range .items[*]}{.metadata.name}{\"\\n\"}{end}", ""},
+		{"No template", "", "no template given"},
+		{"Invalid template", "{.metadata.name", `invalid JSONPath "{.metadata.name"`},
+	}
+
+	for _, tc := range testcases {
+		_, err := ParseJSONPath(tc.template)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%v: expected an error containing %q, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+	}
+}
+
+func TestWriteJSONPath(t *testing.T) {
+	free := &v1beta1.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-1"},
+		Spec: v1beta1.ClusterServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "default", Free: true},
+		},
+	}
+	paid := &v1beta1.ServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-2", Namespace: "test-ns"},
+		Spec: v1beta1.ServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "premium"},
+		},
+	}
+
+	testcases := []struct {
+		name     string      // Test name
+		template string      // Template tested
+		objects  interface{} // Objects tested
+		list     bool        // Whether the objects are printed as a list
+		output   string      // Expected output
+	}{
+		{"List", "{.items[*].spec.externalName}", []interface{}{free, paid}, true, "default premium"},
+		{"List with missing fields", "{.items[*].metadata.namespace}", []interface{}{free, paid}, true, "test-ns"},
+		{"Empty list", "{.items[*].spec.externalName}", []interface{}{}, true, ""},
+		{"Nil list", "{.items[*].spec.externalName}", []*v1beta1.ServicePlan(nil), true, ""},
+		{"Single object", "{.spec.externalName}", free, false, "default"},
+		{"Missing field of a single object", "{.metadata.namespace}", free, false, ""},
+	}
+
+	for _, tc := range testcases {
+		template, err := ParseJSONPath(tc.template)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		output := &bytes.Buffer{}
+		if tc.list {
+			err = WriteJSONPathList(output, template, tc.objects)
+		} else {
+			err = WriteJSONPath(output, template, tc.objects)
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeNames prints one name per line, so that the output can be piped into
+// other commands.
+func writeNames(w io.Writer, names ...string) {
+	for _, name := range names {
+		fmt.Fprintln(w, name)
+	}
+}
+
+// resourceName returns the name of a resource printed by the name output
+// formats. By default it is the bare name that the svcat commands take, which
+// is the external name of a class or a plan. With the kind shown, it is the
+// type of the resource followed by its metadata.name, e.g.
+// "clusterserviceclass/<uuid>", as kubectl prints and accepts it.
+func resourceName(outputFormat, resource, name, kubeName string) string {
+	if outputFormat == FormatNameWithKind {
+		return resource + "/" + kubeName
+	}
+	return name
+}
+
+// scopedResource returns the type of a resource which is either cluster-wide,
+// as clusterResource, or namespaced, as namespacedResource.
+func scopedResource(clusterResource, namespacedResource, namespace string) string {
+	if namespace == "" {
+		return clusterResource
+	}
+	return namespacedResource
+}
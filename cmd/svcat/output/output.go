@@ -28,15 +28,48 @@ import (
 const (
 	statusActive     = "Active"
 	statusDeprecated = "Deprecated"
+
+	// removedIndicator marks the classes and plans in listings which the
+	// broker removed from its catalog.
+	removedIndicator = " (REMOVED)"
 )
 
+// listedName returns the name of a class or plan for a listing, marked when
+// the broker removed it from its catalog.
+func listedName(name, status string) string {
+	if status == statusDeprecated {
+		return name + removedIndicator
+	}
+	return name
+}
+
 const (
+	// FormatCustomColumns is the --output flag value for printing the columns
+	// given as custom-columns=HEADER:JSONPATH,...
+	FormatCustomColumns = "custom-columns"
+
 	// FormatJSON is the --output flag value for json output.
 	FormatJSON = "json"
 
+	// FormatJSONPath is the --output flag value for printing the fields
+	// selected by the template given as jsonpath=TEMPLATE
+	FormatJSONPath = "jsonpath"
+
+	// FormatName is the --output flag value for printing resource names only.
+	FormatName = "name"
+
+	// FormatNameWithKind is the output format of --output name combined
+	// with --show-kind, printing the names prefixed with the type of their
+	// resource.
+	FormatNameWithKind = "name-with-kind"
+
 	// FormatTable is the --output flag value for tablular output.
 	FormatTable = "table"
 
+	// FormatWide is the --output flag value for tabular output with
+	// additional columns, where a resource has them.
+	FormatWide = "wide"
+
 	// FormatYAML is the --output flag value for yaml output.
 	FormatYAML = "yaml"
 )
@@ -58,6 +91,15 @@ func formatStatusFull(condition string, conditionStatus v1beta1.ConditionStatus,
 	return fmt.Sprintf("%s - %s @ %s", status, message, timestamp.UTC())
 }
 
+// writeStructured writes obj in the JSON or YAML output format.
+func writeStructured(w io.Writer, outputFormat string, obj interface{}) {
+	if outputFormat == FormatJSON {
+		writeJSON(w, obj)
+	} else {
+		writeYAML(w, obj, 0)
+	}
+}
+
 // WriteDeletedResourceName prints the name of a deleted resource
 func WriteDeletedResourceName(w io.Writer, resourceName string) {
 	fmt.Fprintf(w, "deleted %s\n", resourceName)
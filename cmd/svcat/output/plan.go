@@ -21,9 +21,11 @@ import (
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func getPlanStatusShort(status v1beta1.ClusterServicePlanStatus) string {
@@ -47,43 +49,89 @@ func (a byClass) Less(i, j int) bool {
 	return a[i].GetClassID() < a[j].GetClassID()
 }
 
-func writePlanListTable(w io.Writer, plans []servicecatalog.Plan, classNames map[string]string) {
+func writePlanListTable(w io.Writer, plans []servicecatalog.Plan, classNames map[string]string, groupByClass bool) {
 
-	sort.Sort(byClass(plans))
+	if groupByClass {
+		sort.Sort(byClass(plans))
+	}
 
 	t := NewListTable(w)
 	t.SetHeader([]string{
 		"Name",
+		"Scope",
 		"Namespace",
 		"Class",
 		"Description",
 	})
 	for _, plan := range plans {
 		t.Append([]string{
-			plan.GetExternalName(),
+			listedName(plan.GetExternalName(), plan.GetShortStatus()),
+			getPlanScope(plan),
 			plan.GetNamespace(),
 			classNames[plan.GetClassID()],
 			plan.GetDescription(),
 		})
 	}
-	t.SetVariableColumn(4)
+	t.SetVariableColumn(5)
 
 	t.Render()
 }
 
-// WritePlanList prints a list of plans in the specified output format.
-func WritePlanList(w io.Writer, outputFormat string, plans []servicecatalog.Plan, classes []servicecatalog.Class) {
+func getPlanScope(plan servicecatalog.Plan) string {
+	if plan.GetNamespace() != "" {
+		return servicecatalog.NamespaceScope
+	}
+	return servicecatalog.ClusterScope
+}
+
+// withPlanKind returns a copy of the plan with its kind and API version set,
+// so that cluster-scoped and namespaced plans can be told apart in the JSON
+// and YAML output. Listed objects do not carry them.
+func withPlanKind(plan servicecatalog.Plan) interface{} {
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		out := p.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ClusterServicePlan"
+		return out
+	case *v1beta1.ServicePlan:
+		out := p.DeepCopy()
+		out.APIVersion = v1beta1.SchemeGroupVersion.String()
+		out.Kind = "ServicePlan"
+		return out
+	}
+	return plan
+}
+
+func withPlanKinds(plans []servicecatalog.Plan) []interface{} {
+	out := make([]interface{}, 0, len(plans))
+	for _, plan := range plans {
+		out = append(out, withPlanKind(plan))
+	}
+	return out
+}
+
+// WritePlanList prints a list of plans in the specified output format. The
+// tables group the plans by class if groupByClass is set, otherwise they keep
+// the order of the list.
+func WritePlanList(w io.Writer, outputFormat string, plans []servicecatalog.Plan, classes []servicecatalog.Class, groupByClass bool) {
 	classNames := map[string]string{}
 	for _, class := range classes {
 		classNames[class.GetName()] = class.GetExternalName()
 	}
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, plans)
+		writeJSON(w, withPlanKinds(plans))
 	case FormatYAML:
-		writeYAML(w, plans, 0)
-	case FormatTable:
-		writePlanListTable(w, plans, classNames)
+		writeYAML(w, withPlanKinds(plans), 0)
+	case FormatName, FormatNameWithKind:
+		names := make([]string, 0, len(plans))
+		for _, plan := range plans {
+			names = append(names, planResourceName(outputFormat, plan))
+		}
+		writeNames(w, names...)
+	case FormatTable, FormatWide:
+		writePlanListTable(w, plans, classNames, groupByClass)
 	}
 }
 
@@ -92,13 +140,15 @@ func WritePlan(w io.Writer, outputFormat string, plan servicecatalog.Plan, class
 
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, plan)
+		writeJSON(w, withPlanKind(plan))
 	case FormatYAML:
-		writeYAML(w, plan, 0)
-	case FormatTable:
+		writeYAML(w, withPlanKind(plan), 0)
+	case FormatName, FormatNameWithKind:
+		writeNames(w, planResourceName(outputFormat, plan))
+	case FormatTable, FormatWide:
 		classNames := map[string]string{}
 		classNames[class.GetName()] = class.GetExternalName()
-		writePlanListTable(w, []servicecatalog.Plan{plan}, classNames)
+		writePlanListTable(w, []servicecatalog.Plan{plan}, classNames, false)
 	}
 }
 
@@ -117,7 +167,7 @@ func WriteAssociatedPlans(w io.Writer, plans []servicecatalog.Plan) {
 	})
 	for _, plan := range plans {
 		t.Append([]string{
-			plan.GetExternalName(),
+			listedName(plan.GetExternalName(), plan.GetShortStatus()),
 			plan.GetDescription(),
 		})
 	}
@@ -152,6 +202,37 @@ func WritePlanDetails(w io.Writer, plan servicecatalog.Plan, class servicecatalo
 	t.Render()
 }
 
+// WritePlanCosts prints the costs of a single plan, one line per currency.
+func WritePlanCosts(w io.Writer, plan servicecatalog.Plan) {
+	costs := plan.GetCosts()
+	if len(costs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nCosts:")
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Amount",
+		"Currency",
+		"Unit",
+	})
+	for _, cost := range costs {
+		currencies := make([]string, 0, len(cost.Amount))
+		for currency := range cost.Amount {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			t.Append([]string{
+				cost.Amount[currency],
+				strings.ToUpper(currency),
+				cost.Unit,
+			})
+		}
+	}
+	t.Render()
+}
+
 // WriteDefaultProvisionParameters prints the default provision parameters for a single plan.
 func WriteDefaultProvisionParameters(w io.Writer, plan servicecatalog.Plan) {
 	defaultProvisionParameters := plan.GetDefaultProvisionParameters()
@@ -183,3 +264,88 @@ func WritePlanSchemas(w io.Writer, plan servicecatalog.Plan) {
 		writeYAML(w, bindingCreateSchema, 2)
 	}
 }
+
+// planDescription is the JSON and YAML representation of the description of
+// a plan. It holds the fields shown by the human view: the class of the plan,
+// its costs and default provision parameters, and, when they are requested,
+// its instances and parameter schemas.
+type planDescription struct {
+	Kind                       string                    `json:"kind"`
+	Scope                      string                    `json:"scope"`
+	Name                       string                    `json:"name"`
+	Namespace                  string                    `json:"namespace,omitempty"`
+	ExternalName               string                    `json:"externalName"`
+	Description                string                    `json:"description"`
+	Status                     string                    `json:"status"`
+	Free                       bool                      `json:"free"`
+	ClassName                  string                    `json:"className"`
+	ClassExternalName          string                    `json:"classExternalName"`
+	Costs                      []v1beta1.ServicePlanCost `json:"costs,omitempty"`
+	DefaultProvisionParameters *runtime.RawExtension     `json:"defaultProvisionParameters,omitempty"`
+	Instances                  []associatedInstance      `json:"instances,omitempty"`
+	Schemas                    *describedPlanSchemas     `json:"schemas,omitempty"`
+}
+
+// describedPlanSchemas holds the parameter schemas of a described plan.
+type describedPlanSchemas struct {
+	InstanceCreate *runtime.RawExtension `json:"instanceCreate,omitempty"`
+	InstanceUpdate *runtime.RawExtension `json:"instanceUpdate,omitempty"`
+	BindingCreate  *runtime.RawExtension `json:"bindingCreate,omitempty"`
+}
+
+// WritePlanDescription prints the description of a plan of the given class.
+// The instances of the plan are only printed when showInstances is set, and
+// its parameter schemas when showSchemas is set.
+func WritePlanDescription(w io.Writer, outputFormat string, plan servicecatalog.Plan, class servicecatalog.Class, instances []v1beta1.ServiceInstance, showInstances, showSchemas bool) {
+	if outputFormat != FormatJSON && outputFormat != FormatYAML {
+		WritePlanDetails(w, plan, class)
+		WritePlanCosts(w, plan)
+		WriteDefaultProvisionParameters(w, plan)
+		if showInstances {
+			WriteAssociatedInstances(w, instances)
+		}
+		if showSchemas {
+			WritePlanSchemas(w, plan)
+		}
+		return
+	}
+
+	out := planDescription{
+		Kind:                       "ServicePlan",
+		Scope:                      getPlanScope(plan),
+		Name:                       plan.GetName(),
+		Namespace:                  plan.GetNamespace(),
+		ExternalName:               plan.GetExternalName(),
+		Description:                plan.GetDescription(),
+		Status:                     plan.GetShortStatus(),
+		Free:                       plan.GetFree(),
+		ClassName:                  class.GetName(),
+		ClassExternalName:          class.GetExternalName(),
+		Costs:                      plan.GetCosts(),
+		DefaultProvisionParameters: plan.GetDefaultProvisionParameters(),
+	}
+	if _, ok := plan.(*v1beta1.ClusterServicePlan); ok {
+		out.Kind = "ClusterServicePlan"
+	}
+	if showInstances {
+		out.Instances = newAssociatedInstances(instances)
+	}
+	if showSchemas {
+		schemas := &describedPlanSchemas{
+			InstanceCreate: plan.GetInstanceCreateSchema(),
+			InstanceUpdate: plan.GetInstanceUpdateSchema(),
+			BindingCreate:  plan.GetBindingCreateSchema(),
+		}
+		if schemas.InstanceCreate != nil || schemas.InstanceUpdate != nil || schemas.BindingCreate != nil {
+			out.Schemas = schemas
+		}
+	}
+	writeStructured(w, outputFormat, out)
+}
+
+// planResourceName returns the name of the plan printed by the name output
+// formats.
+func planResourceName(outputFormat string, plan servicecatalog.Plan) string {
+	resource := scopedResource("clusterserviceplan", "serviceplan", plan.GetNamespace())
+	return resourceName(outputFormat, resource, plan.GetExternalName(), plan.GetName())
+}
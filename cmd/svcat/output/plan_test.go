@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestWritePlanCosts(t *testing.T) {
+	tests := []struct {
+		name           string
+		costs          []v1beta1.ServicePlanCost
+		expectedString string
+	}{
+		{"costs", []v1beta1.ServicePlanCost{
+			{Amount: map[string]string{"usd": "99.95", "eur": "89"}, Unit: "MONTHLY"},
+			{Amount: map[string]string{"usd": "0.5"}, Unit: "1GB of messages"},
+		}, "Costs:\n  AMOUNT   CURRENCY        UNIT        \n+--------+----------+-----------------+\n      89   EUR        MONTHLY          \n   99.95   USD        MONTHLY          \n     0.5   USD        1GB of messages"},
+		{"noCosts", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			plan := &v1beta1.ClusterServicePlan{}
+			plan.Spec.Costs = tt.costs
+			WritePlanCosts(&stringBuilder, plan)
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %q; got %q", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
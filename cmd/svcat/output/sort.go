@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// The named keys of the --sort-by flag.
+const (
+	// SortByName sorts by the name shown in the table, which is the
+	// external name of classes and plans.
+	SortByName = "name"
+
+	// SortByAge sorts from the oldest to the newest object.
+	SortByAge = "age"
+
+	// SortByStatus sorts by the status shown in the table.
+	SortByStatus = "status"
+)
+
+// SortBy is the key by which the items of a list are sorted: one of the
+// named keys, or a JSONPath expression such as {.spec.clusterServicePlanExternalName}.
+type SortBy struct {
+	Key string
+
+	parser *jsonpath.JSONPath
+}
+
+// ParseSortBy parses the value of the --sort-by flag. As with kubectl, the
+// braces of a JSONPath expression are optional. An empty value returns nil,
+// which leaves lists in their default order.
+func ParseSortBy(key string) (*SortBy, error) {
+	switch key {
+	case "":
+		return nil, nil
+	case SortByName, SortByAge, SortByStatus:
+		return &SortBy{Key: key}, nil
+	}
+
+	template := key
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+	parser := jsonpath.New("sort-by").AllowMissingKeys(true)
+	if err := parser.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid --sort-by %q, must be %s, %s, %s or a JSONPath expression: %v", key, SortByName, SortByAge, SortByStatus, err)
+	}
+	return &SortBy{Key: key, parser: parser}, nil
+}
+
+// SortList sorts the items of the given slice in place by the key. Items
+// with the same key are sorted by namespace and name, so that the order is
+// deterministic. A nil key leaves the slice unchanged.
+func SortList(list interface{}, sortBy *SortBy) error {
+	if sortBy == nil {
+		return nil
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("unable to sort a %T", list)
+	}
+
+	type sortItem struct {
+		value     reflect.Value
+		key       string
+		namespace string
+		name      string
+	}
+	items := make([]sortItem, v.Len())
+	for i := range items {
+		obj := v.Index(i)
+		if obj.Kind() != reflect.Ptr && obj.Kind() != reflect.Interface {
+			obj = obj.Addr()
+		}
+		key, err := sortBy.key(obj.Interface())
+		if err != nil {
+			return err
+		}
+		items[i] = sortItem{value: reflect.ValueOf(v.Index(i).Interface()), key: key}
+		if accessor, err := meta.Accessor(obj.Interface()); err == nil {
+			items[i].namespace = accessor.GetNamespace()
+			items[i].name = accessor.GetName()
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].key != items[j].key {
+			return lessSortKey(items[i].key, items[j].key)
+		}
+		if items[i].namespace != items[j].namespace {
+			return items[i].namespace < items[j].namespace
+		}
+		return items[i].name < items[j].name
+	})
+	for i, item := range items {
+		v.Index(i).Set(item.value)
+	}
+	return nil
+}
+
+// lessSortKey compares the keys as numbers when both of them are numbers,
+// so that a JSONPath to a count does not sort 10 before 9.
+func lessSortKey(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+	return a < b
+}
+
+// key returns the key of the given object, a pointer to an object or one of
+// the class and plan interfaces.
+func (s *SortBy) key(obj interface{}) (string, error) {
+	switch s.Key {
+	case SortByName:
+		if named, ok := obj.(interface{ GetExternalName() string }); ok {
+			return named.GetExternalName(), nil
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return "", err
+		}
+		return accessor.GetName(), nil
+	case SortByAge:
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return "", err
+		}
+		return accessor.GetCreationTimestamp().UTC().Format(metav1.RFC3339Micro), nil
+	case SortByStatus:
+		return sortStatus(obj), nil
+	}
+
+	// The objects are evaluated in their JSON form so that the paths use the
+	// same field names as the json and yaml output formats
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return "", err
+	}
+	var key strings.Builder
+	if err := s.parser.Execute(&key, data); err != nil {
+		return "", fmt.Errorf("unable to evaluate the --sort-by %q: %v", s.Key, err)
+	}
+	return key.String(), nil
+}
+
+// sortStatus returns the status of the object that is shown in its table.
+func sortStatus(obj interface{}) string {
+	switch o := obj.(type) {
+	case *v1beta1.ServiceInstance:
+		return getInstanceStatusShort(o.Status)
+	case *v1beta1.ServiceBinding:
+		return getBindingStatusShort(o.Status)
+	case interface{ GetStatusText() string }:
+		return o.GetStatusText()
+	case interface{ GetShortStatus() string }:
+		return o.GetShortStatus()
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortList(t *testing.T) {
+	now := time.Now()
+	instance := func(name string, age time.Duration, reason string, generation int64) v1beta1.ServiceInstance {
+		return v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "test-ns",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Generation:        generation,
+			},
+			Status: v1beta1.ServiceInstanceStatus{
+				Conditions: []v1beta1.ServiceInstanceCondition{
+					{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse, Reason: reason},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sortBy   string
+		expected []string
+	}{
+		{"default", "", []string{"b", "a", "c"}},
+		{"name", "name", []string{"a", "b", "c"}},
+		{"age", "age", []string{"a", "c", "b"}},
+		{"status", "status", []string{"b", "a", "c"}},
+		{"jsonpath", ".metadata.generation", []string{"a", "c", "b"}},
+		{"jsonpath with braces", "{.metadata.generation}", []string{"a", "c", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances := []v1beta1.ServiceInstance{
+				instance("b", time.Minute, "Deprovisioning", 10),
+				instance("a", time.Hour, "ProvisionCallFailed", 2),
+				instance("c", 30*time.Minute, "Provisioning", 9),
+			}
+			sortBy, err := ParseSortBy(tt.sortBy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := SortList(instances, sortBy); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, name := range tt.expected {
+				if instances[i].Name != name {
+					t.Fatalf("%v failed; unexpected order at %d; expected %q, got %q", tt.name, i, name, instances[i].Name)
+				}
+			}
+		})
+	}
+}
@@ -46,9 +46,13 @@ func writeYAML(w io.Writer, obj interface{}, n int) {
 }
 
 func writeParameters(w io.Writer, parameters *runtime.RawExtension) {
-	fmt.Fprintln(w, "\nParameters:")
+	writeParametersSection(w, "Parameters:", "No parameters defined", parameters)
+}
+
+func writeParametersSection(w io.Writer, header, empty string, parameters *runtime.RawExtension) {
+	fmt.Fprintln(w, "\n"+header)
 	if parameters == nil || string(parameters.Raw) == "" || string(parameters.Raw) == "{}" {
-		fmt.Fprintln(w, "  No parameters defined")
+		fmt.Fprintln(w, "  "+empty)
 		return
 	}
 	var params map[string]interface{}
@@ -68,12 +72,18 @@ func writeParametersFrom(w io.Writer, parametersFrom []v1beta1.ParametersFromSou
 
 	headerPrinted := false
 	for _, p := range parametersFrom {
+		if p.SecretKeyRef == nil && p.ConfigMapKeyRef == nil {
+			continue
+		}
+		if !headerPrinted {
+			fmt.Fprintln(w, "\nParameters From:")
+			headerPrinted = true
+		}
 		if p.SecretKeyRef != nil {
-			if !headerPrinted {
-				fmt.Fprintln(w, "\nParameters From:")
-				headerPrinted = true
-			}
 			fmt.Fprintf(w, "  Secret: %s.%s\n", p.SecretKeyRef.Name, p.SecretKeyRef.Key)
 		}
+		if p.ConfigMapKeyRef != nil {
+			fmt.Fprintf(w, "  ConfigMap: %s.%s\n", p.ConfigMapKeyRef.Name, p.ConfigMapKeyRef.Key)
+		}
 	}
 }
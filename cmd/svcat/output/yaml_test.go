@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	_ "github.com/kubernetes-sigs/service-catalog/internal/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -45,3 +46,30 @@ func TestWriteParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteParametersFrom(t *testing.T) {
+	testcases := []struct {
+		name           string                         // Test name
+		parametersFrom []v1beta1.ParametersFromSource // Sources tested
+		output         string                         // Expected output
+	}{
+		{"No source", nil, ""},
+		{"Empty source", []v1beta1.ParametersFromSource{{}}, ""},
+		{
+			"Secret and config map sources",
+			[]v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "mysecret", Key: "secret-parameter"}},
+				{ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{Name: "myconfigmap", Key: "bind-parameters"}},
+			},
+			"\nParameters From:\n  Secret: mysecret.secret-parameter\n  ConfigMap: myconfigmap.bind-parameters\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		writeParametersFrom(output, tc.parametersFrom)
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected \"%v\", actual \"%v\"", tc.name, tc.output, output.String())
+		}
+	}
+}
@@ -22,8 +22,11 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // DescribeCmd contains the needed info to fetch detailed info about a specific
@@ -31,8 +34,10 @@ import (
 type DescribeCmd struct {
 	*command.Namespaced
 	*command.Scoped
+	*command.Described
 	LookupByKubeName bool
 	ShowSchemas      bool
+	ShowInstances    bool
 	KubeName         string
 	Name             string
 }
@@ -42,6 +47,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 	describeCmd := &DescribeCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Scoped:     command.NewScoped(),
+		Described:  command.NewDescribed(),
 	}
 	cmd := &cobra.Command{
 		Use:     "plan NAME",
@@ -52,6 +58,8 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
   svcat describe plan --kube-name 08e4b43a-36bc-447e-a81f-8202b13e339c
   svcat describe plan PLAN_NAME --scope cluster
   svcat describe plan PLAN_NAME --scope namespace --namespace NAMESPACE_NAME
+  svcat describe plan PLAN_NAME --instances=false
+  svcat describe plan PLAN_NAME --output json
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -70,8 +78,16 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		true,
 		"Whether or not to show instance and binding parameter schemas",
 	)
+	cmd.Flags().BoolVarP(
+		&describeCmd.ShowInstances,
+		"instances",
+		"",
+		true,
+		"Whether or not to list the instances of the plan, across all namespaces for a cluster-scoped plan. Requires permission to list instances in those namespaces",
+	)
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
 	describeCmd.AddScopedFlags(cmd.Flags(), false)
+	describeCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -123,19 +139,18 @@ func (c *DescribeCmd) Run() error {
 		return err
 	}
 
-	output.WritePlanDetails(c.Output, plan, class)
-
-	output.WriteDefaultProvisionParameters(c.Output, plan)
-
-	instances, err := c.App.RetrieveInstancesByPlan(plan)
-	if err != nil {
-		return err
+	var instances []v1beta1.ServiceInstance
+	if c.ShowInstances {
+		instances, err = c.App.RetrieveInstancesByPlan(plan)
+		if err != nil {
+			if apierrors.IsForbidden(errors.Cause(err)) {
+				return fmt.Errorf("%v; use --instances=false to describe the plan without its instances", err)
+			}
+			return err
+		}
 	}
-	output.WriteAssociatedInstances(c.Output, instances)
 
-	if c.ShowSchemas {
-		output.WritePlanSchemas(c.Output, plan)
-	}
+	output.WritePlanDescription(c.Output, c.OutputFormat, plan, class, instances, c.ShowInstances, c.ShowSchemas)
 
 	return nil
 }
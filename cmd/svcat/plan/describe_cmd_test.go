@@ -18,6 +18,7 @@ package plan_test
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
@@ -29,7 +30,10 @@ import (
 	servicecatalogfakes "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Describe Command", func() {
@@ -54,6 +58,10 @@ var _ = Describe("Describe Command", func() {
 			Expect(showSchemaFlag).NotTo(BeNil())
 			Expect(showSchemaFlag.Usage).To(ContainSubstring("Whether or not to show instance and binding parameter schemas"))
 
+			instancesFlag := cmd.Flags().Lookup("instances")
+			Expect(instancesFlag).NotTo(BeNil())
+			Expect(instancesFlag.DefValue).To(Equal("true"))
+
 			scopeFlag := cmd.Flags().Lookup("scope")
 			Expect(scopeFlag).NotTo(BeNil())
 			Expect(scopeFlag.Usage).To(ContainSubstring("Limit the command to a particular scope: cluster or namespace"))
@@ -92,6 +100,7 @@ var _ = Describe("Describe Command", func() {
 
 			cmd = &DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 
@@ -164,11 +173,66 @@ var _ = Describe("Describe Command", func() {
 			Expect(output).To(ContainSubstring(clusterServicePlan.Spec.ExternalName))
 			Expect(output).To(ContainSubstring(clusterServiceClass.Spec.ExternalName))
 		})
+		It("Lists the instances of the plan with a summary when the list is long", func() {
+			fakeSDK.RetrievePlanByNameReturns(clusterServicePlan, nil)
+			fakeSDK.RetrieveClassByPlanReturns(clusterServiceClass, nil)
+			var instances []v1beta1.ServiceInstance
+			for i := 0; i < 12; i++ {
+				instances = append(instances, v1beta1.ServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("instance-%d", i),
+						Namespace: fmt.Sprintf("ns-%d", i%2),
+					},
+				})
+			}
+			fakeSDK.RetrieveInstancesByPlanReturns(instances, nil)
+
+			cmd.Scope = servicecatalog.ClusterScope
+			cmd.Name = clusterServicePlan.Spec.ExternalName
+			cmd.ShowInstances = true
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveInstancesByPlanCallCount()).To(Equal(1))
+			Expect(fakeSDK.RetrieveInstancesByPlanArgsForCall(0)).To(Equal(clusterServicePlan))
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("instance-11"))
+			Expect(output).To(ContainSubstring("12 instances in 2 namespaces"))
+		})
+		It("Does not list the instances of the plan without --instances", func() {
+			fakeSDK.RetrievePlanByNameReturns(clusterServicePlan, nil)
+			fakeSDK.RetrieveClassByPlanReturns(clusterServiceClass, nil)
+
+			cmd.Scope = servicecatalog.ClusterScope
+			cmd.Name = clusterServicePlan.Spec.ExternalName
+			cmd.ShowInstances = false
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveInstancesByPlanCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).NotTo(ContainSubstring("Instances:"))
+		})
+		It("Suggests --instances=false when listing the instances is forbidden", func() {
+			fakeSDK.RetrievePlanByNameReturns(clusterServicePlan, nil)
+			fakeSDK.RetrieveClassByPlanReturns(clusterServiceClass, nil)
+			forbidden := apierrors.NewForbidden(schema.GroupResource{Group: v1beta1.GroupName, Resource: "serviceinstances"}, "", errors.New("no access"))
+			fakeSDK.RetrieveInstancesByPlanReturns(nil, errors.Wrap(forbidden, "unable to list instances"))
+
+			cmd.Scope = servicecatalog.ClusterScope
+			cmd.Name = clusterServicePlan.Spec.ExternalName
+			cmd.ShowInstances = true
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to list instances"))
+			Expect(err.Error()).To(ContainSubstring("use --instances=false"))
+		})
 		It("Calls the pkg/svcat libs RetrievePlanByName with namespace scope options", func() {
 			fakeSDK.RetrievePlanByNameReturns(defaultServicePlan, nil)
 			fakeSDK.RetrieveClassByPlanReturns(defaultServiceClass, nil)
 			cmd := DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
@@ -194,6 +258,7 @@ var _ = Describe("Describe Command", func() {
 			fakeSDK.RetrieveClassByPlanReturns(clusterServiceClass, nil)
 			cmd := DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 			cmd.Scope = servicecatalog.ClusterScope
@@ -247,6 +312,7 @@ var _ = Describe("Describe Command", func() {
 			fakeApp.SvcatClient = fakeSDK
 			cmd := DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
@@ -299,6 +365,7 @@ var _ = Describe("Describe Command", func() {
 			fakeApp.SvcatClient = fakeSDK
 			cmd := DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 			cmd.Scope = servicecatalog.ClusterScope
@@ -351,6 +418,7 @@ var _ = Describe("Describe Command", func() {
 			fakeApp.SvcatClient = fakeSDK
 			cmd := DescribeCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Described:  command.NewDescribed(),
 				Scoped:     command.NewScoped(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
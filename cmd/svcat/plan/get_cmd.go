@@ -31,6 +31,8 @@ type GetCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	*command.Formatted
+	*command.Selected
+	*command.Sorted
 	LookupByKubeName bool
 	KubeName         string
 	Name             string
@@ -46,6 +48,8 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
 		Namespaced: command.NewNamespaced(ctx),
 		Scoped:     command.NewScoped(),
 		Formatted:  command.NewFormatted(),
+		Selected:   command.NewSelected(),
+		Sorted:     command.NewSorted(),
 	}
 	cmd := &cobra.Command{
 		Use:     "plans [NAME]",
@@ -55,6 +59,8 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
   svcat get plans
   svcat get plans --scope cluster
   svcat get plans --scope namespace --namespace dev
+  svcat get plans -l team=payments
+  svcat get plans --sort-by name
   svcat get plan PLAN_NAME
   svcat get plan CLASS_NAME/PLAN_NAME
   svcat get plan --kube-name PLAN_KUBE_NAME
@@ -83,6 +89,8 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddScopedFlags(cmd.Flags(), true)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddSortFlag(cmd)
 	return cmd
 }
 
@@ -110,6 +118,14 @@ func (c *GetCmd) Validate(args []string) error {
 		} else {
 			c.Name = args[0]
 		}
+
+		if c.LabelSelector != "" {
+			return fmt.Errorf("selector is not supported when specifiying plan name")
+		}
+
+		if c.SortBy != nil {
+			return fmt.Errorf("sort-by is not supported when specifiying plan name")
+		}
 	}
 	if c.ClassFilter != "" {
 		if c.LookupByKubeName {
@@ -138,7 +154,7 @@ func (c *GetCmd) getAll() error {
 		Namespace: c.Namespace,
 		Scope:     c.Scope,
 	}
-	classes, err := c.App.RetrieveClasses(classOpts)
+	classes, err := c.App.RetrieveClasses(classOpts, "")
 	if err != nil {
 		return fmt.Errorf("unable to list classes (%s)", err)
 	}
@@ -161,11 +177,20 @@ func (c *GetCmd) getAll() error {
 		classID = c.ClassKubeName
 	}
 
-	plans, err := c.App.RetrievePlans(classID, opts)
+	plans, err := c.App.RetrievePlans(classID, opts, c.LabelSelector)
 	if err != nil {
 		return fmt.Errorf("unable to list plans (%s)", err)
 	}
-	output.WritePlanList(c.Output, c.OutputFormat, plans, classes)
+	if err := output.SortList(plans, c.SortBy); err != nil {
+		return err
+	}
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, plans)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPathList(c.Output, c.JSONPath, plans)
+	}
+	output.WritePlanList(c.Output, c.OutputFormat, plans, classes, c.SortBy == nil)
 	return nil
 }
 
@@ -198,6 +223,12 @@ func (c *GetCmd) get() error {
 		return err
 	}
 
+	if c.OutputFormat == output.FormatCustomColumns {
+		return output.WriteCustomColumns(c.Output, c.Columns, c.NoHeaders, plan)
+	}
+	if c.OutputFormat == output.FormatJSONPath {
+		return output.WriteJSONPath(c.Output, c.JSONPath, plan)
+	}
 	output.WritePlan(c.Output, c.OutputFormat, plan, class)
 
 	return nil
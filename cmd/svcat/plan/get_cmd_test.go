@@ -67,18 +67,20 @@ var _ = Describe("Get Plans Command", func() {
 	})
 	Describe("Validate", func() {
 		It("allows plan name arg to be empty", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			err := cmd.Validate([]string{})
 			Expect(err).To(BeNil())
 		})
 		It("optionally parses the plan name argument", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			err := cmd.Validate([]string{"myplan"})
 			Expect(err).To(BeNil())
 			Expect(cmd.Name).To(Equal("myplan"))
 		})
 		It("populates kubeName and classKubeName when lookupByKubeName is set", func() {
 			cmd := &GetCmd{
+				Selected:         command.NewSelected(),
+				Sorted:           command.NewSorted(),
 				LookupByKubeName: true,
 				ClassFilter:      "myclass",
 			}
@@ -89,6 +91,8 @@ var _ = Describe("Get Plans Command", func() {
 		})
 		It("parses a combined class/plan k8s name argument when --kube-name is set", func() {
 			cmd := &GetCmd{
+				Selected:         command.NewSelected(),
+				Sorted:           command.NewSorted(),
 				LookupByKubeName: true,
 			}
 			err := cmd.Validate([]string{"myclass/myplan", "--kube-name"})
@@ -98,6 +102,8 @@ var _ = Describe("Get Plans Command", func() {
 		})
 		It("errors when passed an unparseable combined class/plan k8s name argument when --kube-name is set", func() {
 			cmd := &GetCmd{
+				Selected:         command.NewSelected(),
+				Sorted:           command.NewSorted(),
 				LookupByKubeName: true,
 			}
 			combinationArg := "myclass/myplan/myotherthing"
@@ -108,6 +114,8 @@ var _ = Describe("Get Plans Command", func() {
 		})
 		It("populates className when provided a class filter and --kube-name is not set", func() {
 			cmd := &GetCmd{
+				Selected:    command.NewSelected(),
+				Sorted:      command.NewSorted(),
 				ClassFilter: "myclass",
 			}
 			err := cmd.Validate([]string{"myplan", "--class", "foo"})
@@ -116,14 +124,14 @@ var _ = Describe("Get Plans Command", func() {
 			Expect(cmd.ClassName).To(Equal("myclass"))
 		})
 		It("parses a combined class/plan name argument", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			err := cmd.Validate([]string{"myclass/myplan"})
 			Expect(err).To(BeNil())
 			Expect(cmd.Name).To(Equal("myplan"))
 			Expect(cmd.ClassName).To(Equal("myclass"))
 		})
 		It("errors when passed an unparseable combination arg", func() {
-			cmd := &GetCmd{}
+			cmd := &GetCmd{Selected: command.NewSelected(), Sorted: command.NewSorted()}
 			combinationArg := "myclass/myplan/myotherthing"
 			err := cmd.Validate([]string{combinationArg})
 			Expect(err).NotTo(BeNil())
@@ -164,6 +172,8 @@ var _ = Describe("Get Plans Command", func() {
 					Scope: servicecatalog.AllScope,
 				},
 				Formatted: command.NewFormatted(),
+				Selected:  command.NewSelected(),
+				Sorted:    command.NewSorted(),
 			}
 
 			clusterServiceClass = &v1beta1.ClusterServiceClass{
@@ -248,13 +258,13 @@ var _ = Describe("Get Plans Command", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-				scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+				scopeArg, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 				Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 					Scope:     servicecatalog.AllScope,
 					Namespace: defaultNamespace,
 				}))
 				Expect(fakeSDK.RetrievePlansCallCount()).To(Equal(1))
-				classID, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+				classID, scopeArg, _ := fakeSDK.RetrievePlansArgsForCall(0)
 				Expect(classID).To(Equal(""))
 				Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 					Scope:     servicecatalog.AllScope,
@@ -267,6 +277,17 @@ var _ = Describe("Get Plans Command", func() {
 				Expect(output).To(ContainSubstring(defaultServiceClass.Spec.ExternalName))
 				Expect(output).To(ContainSubstring(defaultServicePlan.Spec.ExternalName))
 			})
+			It("Marks the plans removed from the broker catalog", func() {
+				clusterServicePlan.Status.RemovedFromBrokerCatalog = true
+				fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{clusterServiceClass, defaultServiceClass}, nil)
+				fakeSDK.RetrievePlansReturns([]servicecatalog.Plan{clusterServicePlan, defaultServicePlan}, nil)
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				output := outputBuffer.String()
+				Expect(output).To(ContainSubstring(clusterServicePlan.Spec.ExternalName + " (REMOVED)"))
+				Expect(output).NotTo(ContainSubstring(defaultServicePlan.Spec.ExternalName + " (REMOVED)"))
+			})
 			It("Bubbles up errors from RetrieveClasses", func() {
 				errMsg := "error: burnt toast"
 				fakeSDK.RetrieveClassesReturns(nil, errors.New(errMsg))
@@ -300,13 +321,13 @@ var _ = Describe("Get Plans Command", func() {
 
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-					scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+					scopeArg, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 					Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 						Scope:     servicecatalog.NamespaceScope,
 						Namespace: defaultNamespace,
 					}))
 					Expect(fakeSDK.RetrievePlansCallCount()).To(Equal(1))
-					classID, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+					classID, scopeArg, _ := fakeSDK.RetrievePlansArgsForCall(0)
 					Expect(classID).To(Equal(""))
 					Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 						Scope:     servicecatalog.NamespaceScope,
@@ -333,12 +354,12 @@ var _ = Describe("Get Plans Command", func() {
 
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeSDK.RetrieveClassesCallCount()).To(Equal(1))
-					scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+					scopeArg, _ := fakeSDK.RetrieveClassesArgsForCall(0)
 					Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 						Scope:     servicecatalog.AllScope,
 						Namespace: "",
 					}))
-					classID, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+					classID, scopeArg, _ := fakeSDK.RetrievePlansArgsForCall(0)
 					Expect(classID).To(Equal(""))
 					Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
 						Scope:     servicecatalog.AllScope,
@@ -54,7 +54,7 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-var catalogRequestRegex = regexp.MustCompile("/apis/servicecatalog.k8s.io/v1beta1/(.*)")
+var catalogRequestRegex = regexp.MustCompile("/apis/servicecatalog.k8s.io/v1beta1/?(.*)")
 var coreRequestRegex = regexp.MustCompile("/api/v1/(.*)")
 
 func TestMain(m *testing.M) {
@@ -160,10 +160,16 @@ func TestCommandValidation(t *testing.T) {
 		{"describe plan requires name", "describe plan", "a plan name or Kubernetes name is required"},
 		{"describe instance requires name", "describe instance", "an instance name is required"},
 		{"describe binding requires name", "describe binding", "a binding name is required"},
+		{"describe binding reveal requires show-secret", "describe binding NAME --reveal", "--reveal requires --show-secret"},
+		{"describe binding reveal as yaml requires yes", "describe binding NAME --show-secret --reveal -o yaml", "--reveal with --output yaml requires --yes"},
+		{"describe instance invalid output", "describe instance NAME -o wide", "invalid --output format \"wide\", allowed values are: table, json and yaml"},
 		{"bind requires arg", "bind", "an instance name is required"},
 		{"unbind requires arg", "unbind", "an instance or binding name is required"},
 		{"sync requires names", "sync broker", "a broker name is required"},
 		{"deprovision requires name", "deprovision", "an instance name is required"},
+		{"provision requires class and plan", "provision name --class class", "--class and --plan are required unless --from-instance is specified"},
+		{"provision does not accept --from-instance and --plan", "provision name --from-instance other --plan plan", "--from-instance cannot be used with --class or --plan"},
+		{"provision requires --plan-id with --class-id", "provision name --class-id classid", "--class-id requires --plan-id"},
 		{"provision does not accept --param and --params-json",
 			`provision name --class class --plan plan --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
@@ -194,27 +200,42 @@ func TestCommandOutput(t *testing.T) {
 		{name: "list all brokers", cmd: "get brokers", golden: "output/get-brokers.txt"},
 		{name: "list all brokers (json)", cmd: "get brokers -o json", golden: "output/get-brokers.json"},
 		{name: "list all brokers (yaml)", cmd: "get brokers -o yaml", golden: "output/get-brokers.yaml"},
+		{name: "list all brokers (name)", cmd: "get brokers -o name", golden: "output/get-brokers-name.txt"},
+		{name: "list all brokers (name with kind)", cmd: "get brokers -o name --show-kind", golden: "output/get-brokers-name-with-kind.txt"},
+		{name: "list all brokers (wide)", cmd: "get brokers -o wide", golden: "output/get-brokers-wide.txt"},
+		{name: "list ready brokers", cmd: "get brokers --status ready", golden: "output/get-brokers.txt"},
+		{name: "list not ready brokers", cmd: "get brokers --status NotReady", golden: "output/get-brokers-not-ready.txt"},
 		{name: "get cluster scoped broker", cmd: "get broker ups-broker --scope cluster", golden: "output/get-broker.txt"},
 		{name: "get cluster scoped broker (json)", cmd: "get broker ups-broker --scope cluster -o json", golden: "output/get-broker.json"},
 		{name: "get cluster scoped broker (yaml)", cmd: "get broker ups-broker --scope cluster -o yaml", golden: "output/get-broker.yaml"},
 		{name: "describe cluster broker", cmd: "describe broker ups-broker --scope cluster", golden: "output/describe-broker.txt"},
 		{name: "register broker", cmd: "register ups-broker --url http://upsbroker.com", golden: "output/register-broker.txt"},
 		{name: "deregister broker", cmd: "deregister ups-broker", golden: "output/deregister-broker.txt"},
+		{name: "cleanup removed catalog (dry-run)", cmd: "cleanup --dry-run", golden: "output/cleanup-dry-run.txt"},
 
 		{name: "sync broker", cmd: "sync broker ups-broker", golden: "output/sync-broker.txt"},
 		{name: "sync broker in namespace", cmd: "sync broker ups-broker-ns -n test-ns", golden: "output/sync-broker-ns.txt"},
 		{name: "list all classes", cmd: "get classes", golden: "output/get-classes.txt"},
 		{name: "list all classes (json)", cmd: "get classes -o json", golden: "output/get-classes.json"},
 		{name: "list all classes (yaml)", cmd: "get classes -o yaml", golden: "output/get-classes.yaml"},
+		{name: "list all classes (name)", cmd: "get classes -o name", golden: "output/get-classes-name.txt"},
+		{name: "list all classes (name with kind)", cmd: "get classes -o name --show-kind", golden: "output/get-classes-name-with-kind.txt"},
+		{name: "list cluster classes", cmd: "get classes --scope cluster", golden: "output/get-cluster-classes.txt"},
+		{name: "list all classes sorted by name (name)", cmd: "get classes --sort-by name -o name", golden: "output/get-classes-sorted-by-name.txt"},
+		{name: "list namespaced classes", cmd: "get classes --scope namespace", golden: "output/get-namespaced-classes.txt"},
+		{name: "list namespaced classes (json)", cmd: "get classes --scope namespace -o json", golden: "output/get-namespaced-classes.json"},
 		{name: "get class by name", cmd: "get class user-provided-service", golden: "output/get-class.txt"},
 		{name: "get class not found（cluster scope）", cmd: "get class foo --scope cluster", golden: "output/get-class-not-found-cluster.txt", continueOnError: true},
 		{name: "get class not found（default namespace）", cmd: "get class foo --scope namespace", golden: "output/get-class-not-found-default-namespace.txt", continueOnError: true},
 		{name: "get class not found（all namespaces）", cmd: "get class foo --scope namespace --all-namespaces", golden: "output/get-class-not-found-all-namespaces.txt", continueOnError: true},
 		{name: "get class by name (json)", cmd: "get class user-provided-service -o json", golden: "output/get-class.json"},
+		{name: "get class by name with schemas (json)", cmd: "get class user-provided-service -o json --show-schemas", golden: "output/get-class-with-schemas.json"},
 		{name: "get class by name (yaml)", cmd: "get class user-provided-service -o yaml", golden: "output/get-class.yaml"},
 		{name: "get class by Kubernetes name", cmd: "get class --kube-name 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468 --scope cluster", golden: "output/get-class.txt"},
 		{name: "describe class by name", cmd: "describe class user-provided-service", golden: "output/describe-class.txt"},
 		{name: "describe class by Kubernetes name", cmd: "describe class --kube-name 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468 --scope cluster", golden: "output/describe-class.txt"},
+		{name: "describe class as yaml", cmd: "describe class user-provided-service -o yaml", golden: "output/describe-class.yaml"},
+		{name: "describe class as json", cmd: "describe class user-provided-service -o json", golden: "output/describe-class.json"},
 		{name: "create cluster class", cmd: "create class new-class --from user-provided-service --scope cluster", golden: "output/create-cluster-class.txt"},
 		{name: "create cluster class not found", cmd: "create class new-class --from foo --scope cluster", golden: "output/create-cluster-class-not-found.txt", continueOnError: true},
 		{name: "create namespace class", cmd: "create class new-class --from user-provided-namespaced-service --scope namespace --namespace default", golden: "output/create-namespace-class.txt"},
@@ -223,6 +244,12 @@ func TestCommandOutput(t *testing.T) {
 		{name: "list all plans", cmd: "get plans", golden: "output/get-plans.txt"},
 		{name: "list all plans (json)", cmd: "get plans -o json", golden: "output/get-plans.json"},
 		{name: "list all plans (yaml)", cmd: "get plans -o yaml", golden: "output/get-plans.yaml"},
+		{name: "list all plans (name)", cmd: "get plans -o name", golden: "output/get-plans-name.txt"},
+		{name: "list all plans (name with kind)", cmd: "get plans -o name --show-kind", golden: "output/get-plans-name-with-kind.txt"},
+		{name: "list all plans sorted by name", cmd: "get plans --sort-by name", golden: "output/get-plans-sorted-by-name.txt"},
+		{name: "list all plans (custom-columns)", cmd: "get plans -o custom-columns=NAME:.spec.externalName,ID:.spec.externalID,FREE:.spec.free,CLASS:.spec.clusterServiceClassRef.name", golden: "output/get-plans-custom-columns.txt"},
+		{name: "list all plans (custom-columns without headers)", cmd: "get plans -o custom-columns=NAME:.spec.externalName --no-headers", golden: "output/get-plans-custom-columns-no-headers.txt"},
+		{name: "list all plans (invalid custom-columns)", cmd: "get plans -o custom-columns=NAME:{.spec.externalName", golden: "output/get-plans-custom-columns-invalid.txt", continueOnError: true},
 		{name: "list all namespaced plans", cmd: "get plans --scope namespace", golden: "output/get-namespaced-plans.txt"},
 		{name: "list all namespaced plans (json)", cmd: "get plans --scope namespace -o json", golden: "output/get-namespaced-plans.json"},
 		{name: "list all namespaced plans (yaml)", cmd: "get plans --scope namespace -o yaml", golden: "output/get-namespaced-plans.yaml"},
@@ -243,19 +270,36 @@ func TestCommandOutput(t *testing.T) {
 		{name: "describe namespace plan by class/plan name combo", cmd: "describe plan user-provided-namespaced-service/namespacedplan", golden: "output/describe-namespace-plan.txt"},
 		{name: "describe plan with schemas", cmd: "describe plan --scope cluster premium", golden: "output/describe-plan-with-schemas.txt"},
 		{name: "describe plan without schemas", cmd: "describe plan --scope cluster premium --show-schemas=false", golden: "output/describe-plan-without-schemas.txt"},
+		{name: "describe plan with schemas as yaml", cmd: "describe plan --scope cluster premium -o yaml", golden: "output/describe-plan-with-schemas.yaml"},
+		{name: "describe namespace plan as json", cmd: "describe plan namespacedplan -o json", golden: "output/describe-namespace-plan.json"},
 
 		{name: "list all instances in a namespace", cmd: "get instances -n test-ns", golden: "output/get-instances.txt"},
 		{name: "list all instances in a namespace (json)", cmd: "get instances -n test-ns -o json", golden: "output/get-instances.json"},
 		{name: "list all instances in a namespace (yaml)", cmd: "get instances -n test-ns -o yaml", golden: "output/get-instances.yaml"},
+		{name: "list all instances in a namespace (name)", cmd: "get instances -n test-ns -o name", golden: "output/get-instances-name.txt"},
+		{name: "list all instances in a namespace (name with kind)", cmd: "get instances -n test-ns -o name --show-kind", golden: "output/get-instances-name-with-kind.txt"},
+		{name: "list all instances in a namespace (custom-columns)", cmd: "get instances -n test-ns -o custom-columns=NAME:.metadata.name,PLAN:.spec.clusterServicePlanExternalName,DASHBOARD:.status.dashboardURL", golden: "output/get-instances-custom-columns.txt"},
+		{name: "list all instances in a namespace (jsonpath)", cmd: "get instances -n test-ns -o jsonpath={.items[*].metadata.name}", golden: "output/get-instances-jsonpath.txt"},
+		{name: "list all instances filtered by not existing plan (jsonpath)", cmd: "get instances --all-namespaces --plan wrong -o jsonpath={.items[*].metadata.name}", golden: "output/get-instances-jsonpath-empty.txt"},
+		{name: "list all instances in a namespace (invalid jsonpath)", cmd: "get instances -n test-ns -o jsonpath={.items[*].metadata.name", golden: "output/get-instances-jsonpath-invalid.txt", continueOnError: true},
 		{name: "list all instances filtered by existing plan", cmd: "get instances --all-namespaces --plan default", golden: "output/get-instances-all-namespaces-by-plan.txt"},
 		{name: "list all instances filtered by not existing plan", cmd: "get instances --all-namespaces --plan wrong", golden: "output/get-instances-all-namespaces-by-wrong-plan.txt"},
 		{name: "list all instances filtered by existing class", cmd: "get instances --all-namespaces --class user-provided-service", golden: "output/get-instances-all-namespaces-by-class.txt"},
 		{name: "list all instances filtered by not existing class", cmd: "get instances --all-namespaces --class wrong", golden: "output/get-instances-all-namespaces-by-wrong-class.txt"},
 		{name: "list all instances", cmd: "get instances --all-namespaces", golden: "output/get-instances-all-namespaces.txt"},
+		{name: "list all instances sorted by namespace", cmd: "get instances --all-namespaces --sort-by .metadata.namespace", golden: "output/get-instances-all-namespaces-sorted.txt"},
+		{name: "list all instances sorted by an invalid key", cmd: "get instances --all-namespaces --sort-by {.metadata", golden: "output/get-instances-invalid-sort-by.txt", continueOnError: true},
+		{name: "list all instances filtered by label selector", cmd: "get instances -n test-ns -l team=payments", golden: "output/get-instances-by-selector.txt"},
+		{name: "list all instances filtered by invalid label selector", cmd: "get instances -n test-ns -l team=pay=ments", golden: "output/get-instances-invalid-selector.txt", continueOnError: true},
 		{name: "get instance", cmd: "get instance ups-instance -n test-ns", golden: "output/get-instance.txt"},
 		{name: "get instance (json)", cmd: "get instance ups-instance -n test-ns -o json", golden: "output/get-instance.json"},
 		{name: "get instance (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml", golden: "output/get-instance.yaml"},
+		{name: "get instance (jsonpath)", cmd: "get instance ups-instance -n test-ns -o jsonpath={.spec.clusterServicePlanExternalName}", golden: "output/get-instance-jsonpath.txt"},
 		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance.txt"},
+		{name: "describe instance with parameters", cmd: "describe instance ups-instance -n test-ns --show-params", golden: "output/describe-instance-show-params.txt"},
+		{name: "describe instance as yaml", cmd: "describe instance ups-instance -n test-ns -o yaml", golden: "output/describe-instance.yaml"},
+		{name: "describe instance with parameters as json", cmd: "describe instance ups-instance -n test-ns --show-params -o json", golden: "output/describe-instance-show-params.json"},
+		{name: "logs instance", cmd: "logs instance ups-instance -n test-ns", golden: "output/logs-instance.txt"},
 		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt"},
 		{name: "bind instance and wait", cmd: "bind ups-instance --name ups-binding -n test-ns --wait", golden: "output/bind-instance-and-wait.txt"},
 		{name: "unbind instance", cmd: "unbind ups-instance -n test-ns", golden: "output/unbind-instance.txt"},
@@ -263,15 +307,26 @@ func TestCommandOutput(t *testing.T) {
 		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
 		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
 		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
+		{name: "list all instances and bindings in a namespace", cmd: "get all -n test-ns", golden: "output/get-all.txt"},
+		{name: "list all instances and bindings in a namespace (json)", cmd: "get all -n test-ns -o json", golden: "output/get-all.json"},
+		{name: "list all instances and bindings in all namespaces", cmd: "get all --all-namespaces", golden: "output/get-all-all-namespaces.txt"},
 		{name: "list all bindings in a namespace", cmd: "get bindings -n test-ns", golden: "output/get-bindings.txt"},
 		{name: "list all bindings in a namespace (json)", cmd: "get bindings -n test-ns -o json", golden: "output/get-bindings.json"},
 		{name: "list all bindings in a namespace (yaml)", cmd: "get bindings -n test-ns -o yaml", golden: "output/get-bindings.yaml"},
+		{name: "list all bindings in a namespace (name)", cmd: "get bindings -n test-ns -o name", golden: "output/get-bindings-name.txt"},
+		{name: "list all bindings in a namespace (name with kind)", cmd: "get bindings -n test-ns -o name --show-kind", golden: "output/get-bindings-name-with-kind.txt"},
+		{name: "list all bindings in a namespace (wide)", cmd: "get bindings -n test-ns -o wide", golden: "output/get-bindings-wide.txt"},
 		{name: "list all bindings", cmd: "get bindings --all-namespaces", golden: "output/get-bindings-all-namespaces.txt"},
+		{name: "list all bindings filtered by label selector", cmd: "get bindings -n test-ns -l team=payments", golden: "output/get-bindings-by-selector.txt"},
 		{name: "get binding", cmd: "get binding ups-binding -n test-ns", golden: "output/get-binding.txt"},
 		{name: "get binding (json)", cmd: "get binding ups-binding -n test-ns -o json", golden: "output/get-binding.json"},
 		{name: "get binding (yaml)", cmd: "get binding ups-binding -n test-ns -o yaml", golden: "output/get-binding.yaml"},
 		{name: "describe binding", cmd: "describe binding ups-binding -n test-ns", golden: "output/describe-binding.txt"},
-		{name: "describe binding and decode secret", cmd: "describe binding ups-binding -n test-ns --show-secrets", golden: "output/describe-binding-show-secrets.txt"},
+		{name: "describe binding with the deprecated show-secrets flag", cmd: "describe binding ups-binding -n test-ns --show-secrets", golden: "output/describe-binding-show-secrets.txt"},
+		{name: "describe binding and list secret keys", cmd: "describe binding ups-binding -n test-ns --show-secret", golden: "output/describe-binding-show-secret.txt"},
+		{name: "describe binding and reveal secret", cmd: "describe binding ups-binding -n test-ns --show-secret --reveal --yes", golden: "output/describe-binding-show-secret-reveal.txt"},
+		{name: "describe binding as yaml", cmd: "describe binding ups-binding -n test-ns -o yaml", golden: "output/describe-binding.yaml"},
+		{name: "describe binding and reveal secret as json", cmd: "describe binding ups-binding -n test-ns --show-secret --reveal --yes -o json", golden: "output/describe-binding-show-secret-reveal.json"},
 		{name: "delete binding", cmd: "unbind --name ups-binding -n test-ns", golden: "output/delete-binding.txt"},
 		{name: "delete binding and wait", cmd: "unbind --name ups-binding -n test-ns --wait", golden: "output/delete-binding-and-wait.txt"},
 
@@ -513,6 +568,71 @@ func TestPluginFlags(t *testing.T) {
 	}
 }
 
+// TestServerOverride verifies that --server takes precedence over the server
+// of the kubeconfig context.
+func TestServerOverride(t *testing.T) {
+	apisvr := newAPIServer()
+	defer apisvr.Close()
+
+	// The kubeconfig points at a server that does not exist
+	kubeconfig, err := writeTestKubeconfig("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.Remove(kubeconfig)
+
+	svcat, _, err := buildCommand("get brokers --scope cluster --server "+apisvr.URL, newContext(), kubeconfig)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	output := &bytes.Buffer{}
+	svcat.SetOutput(output)
+
+	if err := svcat.Execute(); err != nil {
+		t.Fatalf("expected the command to use the server of --server: %+v\n%s", err, output.String())
+	}
+	if !strings.Contains(output.String(), "ups-broker") {
+		t.Fatalf("unexpected output:\n%s", output.String())
+	}
+}
+
+// TestConfigView verifies that config view shows the configuration resolved
+// from the kubeconfig and reaches the Service Catalog API with it.
+func TestConfigView(t *testing.T) {
+	apisvr := newAPIServer()
+	defer apisvr.Close()
+
+	kubeconfig, err := writeTestKubeconfig(apisvr.URL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.Remove(kubeconfig)
+
+	svcat, _, err := buildCommand("config view --raw", newContext(), kubeconfig)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	output := &bytes.Buffer{}
+	svcat.SetOutput(output)
+
+	if err := svcat.Execute(); err != nil {
+		t.Fatalf("%+v\n%s", err, output.String())
+	}
+	for _, want := range []string{
+		`Kubeconfig:\s+` + regexp.QuoteMeta(kubeconfig),
+		`Context:\s+fakek8s`,
+		`Server:\s+` + regexp.QuoteMeta(apisvr.URL),
+		`Namespace:\s+default`,
+		`Auth:\s+None`,
+		`Service Catalog API:\s+Reachable`,
+		`Resources:\s+clusterservicebrokers, serviceinstances`,
+	} {
+		if !regexp.MustCompile(want).MatchString(output.String()) {
+			t.Errorf("expected the output to match %q:\n%s", want, output.String())
+		}
+	}
+}
+
 // executeCommand runs a svcat command against a fake k8s api,
 // returning the cli output.
 func executeCommand(t *testing.T, cmd string, continueOnErr bool) string {
@@ -19,6 +19,8 @@ package server
 import (
 	"fmt"
 
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
 	"github.com/spf13/pflag"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	genericserveroptions "k8s.io/apiserver/pkg/server/options"
@@ -36,6 +38,27 @@ type WebhookServerOptions struct {
 	SecureServingOptions  *genericserveroptions.SecureServingOptions
 	ReleaseName           string
 	HealthzServerBindPort int
+	// MaxParametersSize is the maximum size in bytes of the parameters of
+	// ServiceInstances and ServiceBindings, 0 disables the limit
+	MaxParametersSize int
+	// ParametersFromSecretPolicy is what to do when a Secret referenced by
+	// the parameters of a ServiceInstance or ServiceBinding does not exist
+	ParametersFromSecretPolicy string
+	// ServiceInstanceNamePattern and ServiceBindingNamePattern are the
+	// regular expressions that the names of new ServiceInstances and
+	// ServiceBindings must match, "" disables the check
+	ServiceInstanceNamePattern string
+	ServiceBindingNamePattern  string
+	// ExternalIDCollisionPolicy is what to do when a new ServiceInstance has
+	// the external ID of an existing ServiceInstance
+	ExternalIDCollisionPolicy string
+	// BrokerURLAllowedCIDRs, BrokerURLDeniedCIDRs, BrokerURLAllowedHosts and
+	// BrokerURLDeniedHosts decide which URLs ClusterServiceBrokers and
+	// ServiceBrokers may point at
+	BrokerURLAllowedCIDRs []string
+	BrokerURLDeniedCIDRs  []string
+	BrokerURLAllowedHosts []string
+	BrokerURLDeniedHosts  []string
 }
 
 // NewWebhookServerOptions creates a new WebhookServerOptions with a default settings.
@@ -54,6 +77,15 @@ func NewWebhookServerOptions() *WebhookServerOptions {
 // AddFlags adds flags for a WebhookServerOptions to the specified FlagSet.
 func (s *WebhookServerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.HealthzServerBindPort, "healthz-server-bind-port", defaultHealthzServerPort, "The port on which to serve HTTP  /healthz endpoint")
+	fs.IntVar(&s.MaxParametersSize, "max-parameters-size", webhookutil.DefaultMaxParametersSize, "The maximum size in bytes of the parameters of ServiceInstances and ServiceBindings. Larger parameters are rejected, 0 disables the limit.")
+	fs.StringVar(&s.ParametersFromSecretPolicy, "parameters-from-secret-policy", string(webhookutil.DefaultParametersFromSecretPolicy), "What to do when a Secret referenced by the parametersFrom of a ServiceInstance or ServiceBinding does not exist: Warn logs a warning and admits the object, Deny rejects it, Off does not check the Secrets.")
+	fs.StringVar(&s.ServiceInstanceNamePattern, "service-instance-name-pattern", "", "A regular expression that the whole name of new ServiceInstances must match, for example \"(payments|search)-.+\". Empty disables the check.")
+	fs.StringVar(&s.ServiceBindingNamePattern, "service-binding-name-pattern", "", "A regular expression that the whole name of new ServiceBindings must match. Empty disables the check.")
+	fs.StringVar(&s.ExternalIDCollisionPolicy, "external-id-collision-policy", string(webhookutil.DefaultExternalIDCollisionPolicy), "What to do when a new ServiceInstance has the external ID of an existing ServiceInstance, for example after a restore from a backup: Deny rejects it, Warn logs a warning and admits it, Off does not look for collisions.")
+	fs.StringSliceVar(&s.BrokerURLDeniedCIDRs, "broker-url-denied-cidrs", brokerurl.DefaultDeniedCIDRs, "Comma-separated list of address ranges the URLs of ClusterServiceBrokers and ServiceBrokers may not resolve to. Defaults to the unspecified, loopback and link-local ranges, which include the metadata services of cloud providers; set it to \"\" to allow them.")
+	fs.StringSliceVar(&s.BrokerURLAllowedCIDRs, "broker-url-allowed-cidrs", nil, "Comma-separated list of address ranges the broker URLs may resolve to even when they are part of --broker-url-denied-cidrs.")
+	fs.StringSliceVar(&s.BrokerURLDeniedHosts, "broker-url-denied-hosts", nil, "Comma-separated list of host names the broker URLs may not point at; a name starting with \"*.\" matches all of its subdomains.")
+	fs.StringSliceVar(&s.BrokerURLAllowedHosts, "broker-url-allowed-hosts", nil, "Comma-separated list of host names the broker URLs may point at regardless of the addresses they resolve to; a name starting with \"*.\" matches all of its subdomains.")
 
 	s.SecureServingOptions.AddFlags(fs)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
@@ -68,6 +100,30 @@ func (s *WebhookServerOptions) Validate() error {
 	if s.SecureServingOptions.BindPort == s.HealthzServerBindPort {
 		errors = append(errors, fmt.Errorf("validation erorr: --secure-port and --healthz-server-bind-port MUST have different values"))
 	}
+	if s.MaxParametersSize < 0 {
+		errors = append(errors, fmt.Errorf("validation error: --max-parameters-size must not be negative"))
+	}
+	if err := webhookutil.ValidateParametersFromSecretPolicy(webhookutil.ParametersFromSecretPolicy(s.ParametersFromSecretPolicy)); err != nil {
+		errors = append(errors, fmt.Errorf("validation error: --parameters-from-secret-policy: %v", err))
+	}
+	if _, err := webhookutil.NewNamePattern(s.ServiceInstanceNamePattern); err != nil {
+		errors = append(errors, fmt.Errorf("validation error: --service-instance-name-pattern: %v", err))
+	}
+	if _, err := webhookutil.NewNamePattern(s.ServiceBindingNamePattern); err != nil {
+		errors = append(errors, fmt.Errorf("validation error: --service-binding-name-pattern: %v", err))
+	}
+	if err := webhookutil.ValidateExternalIDCollisionPolicy(webhookutil.ExternalIDCollisionPolicy(s.ExternalIDCollisionPolicy)); err != nil {
+		errors = append(errors, fmt.Errorf("validation error: --external-id-collision-policy: %v", err))
+	}
+	if _, err := s.BrokerURLPolicy(); err != nil {
+		errors = append(errors, fmt.Errorf("validation error: broker URL policy: %v", err))
+	}
 
 	return utilerrors.NewAggregate(errors)
 }
+
+// BrokerURLPolicy returns the policy built from the broker URL flags, nil
+// when it denies nothing.
+func (s *WebhookServerOptions) BrokerURLPolicy() (*brokerurl.Policy, error) {
+	return brokerurl.NewPolicy(s.BrokerURLAllowedCIDRs, s.BrokerURLDeniedCIDRs, s.BrokerURLAllowedHosts, s.BrokerURLDeniedHosts)
+}
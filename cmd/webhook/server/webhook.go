@@ -40,6 +40,7 @@ import (
 	scvalidation "github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceclass/validation"
 	sivalidation "github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
 	spvalidation "github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceplan/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/probe"
 	"github.com/pkg/errors"
@@ -93,6 +94,20 @@ func run(opts *WebhookServerOptions, stopCh <-chan struct{}) error {
 		return errors.Wrap(err, "while register Service Catalog scheme into manager")
 	}
 
+	brokerURLPolicy, err := opts.BrokerURLPolicy()
+	if err != nil {
+		return errors.Wrap(err, "while building the broker URL policy")
+	}
+
+	instanceNamePattern, err := webhookutil.NewNamePattern(opts.ServiceInstanceNamePattern)
+	if err != nil {
+		return errors.Wrap(err, "while compiling the ServiceInstance name pattern")
+	}
+	bindingNamePattern, err := webhookutil.NewNamePattern(opts.ServiceBindingNamePattern)
+	if err != nil {
+		return errors.Wrap(err, "while compiling the ServiceBinding name pattern")
+	}
+
 	// setup webhook server
 	webhookSvr := &webhook.Server{
 		Port:    opts.SecureServingOptions.BindPort,
@@ -110,18 +125,18 @@ func run(opts *WebhookServerOptions, stopCh <-chan struct{}) error {
 		"/mutating-serviceplans":     &spmutation.CreateUpdateHandler{},
 		"/mutating-serviceinstances": simutation.NewCreateUpdateHandler(),
 
-		"/validating-clusterservicebrokers":        csbrvalidation.NewSpecValidationHandler(),
+		"/validating-clusterservicebrokers":        csbrvalidation.NewSpecValidationHandler(brokerURLPolicy),
 		"/validating-clusterservicebrokers/status": &csbrvalidation.StatusValidationHandler{},
 		"/validating-clusterserviceclasses":        cscvalidation.NewSpecValidationHandler(),
 		"/validating-clusterserviceplans":          cspvalidation.NewSpecValidationHandler(),
 
-		"/validating-servicebindings":        sbvalidation.NewSpecValidationHandler(),
+		"/validating-servicebindings":        sbvalidation.NewSpecValidationHandler(opts.MaxParametersSize, webhookutil.ParametersFromSecretPolicy(opts.ParametersFromSecretPolicy), bindingNamePattern),
 		"/validating-servicebindings/status": &sbvalidation.StatusValidationHandler{},
-		"/validating-servicebrokers":         sbrvalidation.NewSpecValidationHandler(),
+		"/validating-servicebrokers":         sbrvalidation.NewSpecValidationHandler(brokerURLPolicy),
 		"/validating-servicebrokers/status":  &sbrvalidation.StatusValidationHandler{},
 		"/validating-serviceclasses":         scvalidation.NewSpecValidationHandler(),
 		"/validating-serviceplans":           spvalidation.NewSpecValidationHandler(),
-		"/validating-serviceinstances":       sivalidation.NewSpecValidationHandler(),
+		"/validating-serviceinstances":       sivalidation.NewSpecValidationHandler(opts.MaxParametersSize, webhookutil.ParametersFromSecretPolicy(opts.ParametersFromSecretPolicy), instanceNamePattern, webhookutil.ExternalIDCollisionPolicy(opts.ExternalIDCollisionPolicy)),
 	}
 
 	for path, handler := range webhooks {
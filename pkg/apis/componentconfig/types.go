@@ -43,6 +43,12 @@ type ControllerManagerConfiguration struct {
 	KubeAPIQPS float32
 	// kubeAPIBurst is the burst to use while talking with kubernetes apiserver.
 	KubeAPIBurst int32
+	// ServiceCatalogAPIQPS is the QPS to use while talking with the
+	// service-catalog API server.
+	ServiceCatalogAPIQPS float32
+	// ServiceCatalogAPIBurst is the burst to use while talking with the
+	// service-catalog API server.
+	ServiceCatalogAPIBurst int32
 
 	// K8sAPIServerURL is the URL for the k8s API server.
 	K8sAPIServerURL string
@@ -80,6 +86,98 @@ type ControllerManagerConfiguration struct {
 	// OSBAPITimeOut the length of the timeout of any request to the broker.
 	OSBAPITimeOut time.Duration
 
+	// OSBAPIUserAgentSuffix is appended to the User-Agent of the requests to
+	// the brokers that do not set a userAgentSuffix of their own.
+	OSBAPIUserAgentSuffix string
+
+	// OSBAPIUpdateContext is whether the OSB context is sent in update
+	// requests, and instances are updated when their context changes.
+	OSBAPIUpdateContext bool
+
+	// OSBAPIAcceptsIncomplete is whether the first request of an operation
+	// accepts an asynchronous operation. Otherwise, the request is sent
+	// again with accepts_incomplete only when the broker requires it.
+	OSBAPIAcceptsIncomplete bool
+
+	// BindingSecretRetentionPolicy controls whether the Secret of a
+	// ServiceBinding is deleted or retained when the binding is unbound.
+	BindingSecretRetentionPolicy string
+
+	// ClassWithoutPlansPolicy controls whether a broker catalog with a
+	// service without plans is rejected, or synced without that service.
+	ClassWithoutPlansPolicy string
+
+	// EmptyBindingCredentialsPolicy controls whether a ServiceBinding for
+	// which the broker returned no credentials gets the NoCredentials
+	// condition.
+	EmptyBindingCredentialsPolicy string
+
+	// CatalogStaleRelistMultiple is the number of relist intervals after
+	// which a broker whose catalog can not be retrieved is marked with the
+	// CatalogStale condition. Zero disables the condition.
+	CatalogStaleRelistMultiple float64
+
+	// BrokerRelistTimeout is how long the relist of a broker may be in
+	// progress before the broker gets the RelistStuck condition. Zero
+	// disables recording the relist in the status of the broker.
+	BrokerRelistTimeout time.Duration
+
+	// ParametersResyncInterval is how often the parametersFrom sources of
+	// ready ServiceInstances are read again, so that parameters which changed
+	// without a Secret event are sent to the broker. Zero disables the
+	// periodic read.
+	ParametersResyncInterval time.Duration
+
+	// BindingInstanceWaitTimeout is how long a ServiceBinding waits for its
+	// ServiceInstance to become ready before the binding fails. Zero
+	// disables waiting.
+	BindingInstanceWaitTimeout time.Duration
+
+	// NamespaceDeletionDeprovisionTimeout is how long the deprovisioning of
+	// a ServiceInstance is retried once the deletion of its namespace
+	// started. Zero retries until the reconciliation retry duration is
+	// exceeded.
+	NamespaceDeletionDeprovisionTimeout time.Duration
+
+	// AsyncOperationTimeout is how long an asynchronous operation of a
+	// ServiceInstance is polled before it fails as timed out. Zero polls
+	// until the reconciliation retry duration is exceeded.
+	AsyncOperationTimeout time.Duration
+
+	// DeprovisionTimeout is how long the deprovisioning of a deleted
+	// ServiceInstance may keep failing before it is reported as timed out,
+	// and the finalizer of an instance with the force-orphan annotation is
+	// removed. Zero disables the timeout.
+	DeprovisionTimeout time.Duration
+
+	// BrokerMaxConcurrentRequests is the maximum number of requests sent to
+	// a single broker at the same time. Zero disables the limit.
+	BrokerMaxConcurrentRequests int
+
+	// BrokerTLSMinVersion is the minimum TLS version of the connections to
+	// the brokers. Empty uses the default of Go.
+	BrokerTLSMinVersion string
+
+	// BrokerTLSCipherSuites are the cipher suites allowed for the
+	// connections to the brokers. Empty uses the defaults of Go.
+	BrokerTLSCipherSuites []string
+
+	// BrokerURLAllowedCIDRs are the address ranges the brokers may be
+	// reached at even when they are part of BrokerURLDeniedCIDRs.
+	BrokerURLAllowedCIDRs []string
+
+	// BrokerURLDeniedCIDRs are the address ranges the URL of a broker may
+	// not point at.
+	BrokerURLDeniedCIDRs []string
+
+	// BrokerURLAllowedHosts are the host name patterns of the brokers which
+	// are allowed regardless of the addresses they resolve to.
+	BrokerURLAllowedHosts []string
+
+	// BrokerURLDeniedHosts are the host name patterns the URL of a broker
+	// may not point at.
+	BrokerURLDeniedHosts []string
+
 	// ConcurrentSyncs is the number of resources, per resource type,
 	// that are allowed to sync concurrently. Larger number = more responsive
 	// SC operations, but more CPU (and network) load.
@@ -106,10 +204,45 @@ type ControllerManagerConfiguration struct {
 	// backoff for polling OSB API operations will use.
 	OperationPollingMaximumBackoffDuration time.Duration
 
+	// OperationRetryMaximumBackoffDuration is the maximum duration that
+	// exponential backoff for retrying failed provision and update
+	// operations will use.
+	OperationRetryMaximumBackoffDuration time.Duration
+
 	SecureServingOptions *genericoptions.SecureServingOptions
 
 	// ClusterIDConfigMapName is the k8s name that the clusterid configmap will have
 	ClusterIDConfigMapName string
 	// ClusterIDConfigMapNamespace is the k8s namespace that the clusterid configmap will be stored in.
 	ClusterIDConfigMapNamespace string
+	// ClusterID is the ID of the cluster sent in the OSB context. If empty,
+	// the ID from the clusterid configmap is used.
+	ClusterID string
+
+	// OSBAPIContextPlatform is the platform sent in the OSB context.
+	OSBAPIContextPlatform string
+
+	// OSBAPIContext is a JSON object holding additional keys sent in the OSB
+	// context of all requests to the brokers.
+	OSBAPIContext string
+
+	// BrokerHealthCheckInterval is how often the brokers that enable their
+	// health check are probed between relists. Zero disables the probes.
+	BrokerHealthCheckInterval time.Duration
+
+	// TracingEndpoint is the OTLP/HTTP endpoint the spans of the reconciles
+	// and of the requests to the brokers are exported to. Empty disables
+	// tracing.
+	TracingEndpoint string
+	// TracingSamplingRatio is the ratio of the reconciles that are traced.
+	TracingSamplingRatio float64
+
+	// OrphanMitigationGracePeriod is how long the orphan mitigation of an
+	// instance whose provision failed waits before deprovisioning it. Zero
+	// deprovisions it right away.
+	OrphanMitigationGracePeriod time.Duration
+	// OrphanMitigationCheckInstance controls whether the broker is asked for
+	// the state of the instance during the grace period, to cancel the
+	// orphan mitigation of an instance it provisioned after all.
+	OrphanMitigationCheckInstance bool
 }
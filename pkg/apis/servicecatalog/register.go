@@ -62,6 +62,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServicePlanList{},
 		&ServiceInstance{},
 		&ServiceInstanceList{},
+		&ServiceInstanceTemplate{},
+		&ServiceInstanceTemplateList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
 	)
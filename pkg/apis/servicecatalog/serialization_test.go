@@ -38,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
 	"k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
 
@@ -213,6 +214,142 @@ func TestRoundTripTypes(t *testing.T) {
 	roundtrip.RoundTripTypesWithoutProtobuf(t, api.Scheme, api.Codecs, fuzzer, nonRoundTrippableTypes)
 }
 
+// TestConversionRoundTrip fuzzes the internal version of the core types and
+// checks that converting them to v1beta1 and back preserves every field,
+// including the parametersFrom, secretTransforms and conditions which have
+// custom fuzzers or nested types. These are the generated conversions of the
+// scheme of the aggregated API server. No conversion webhook is served: the
+// CRDs only have the v1beta1 version, so the API server never has a version
+// to convert them to.
+func TestConversionRoundTrip(t *testing.T) {
+	const iterations = 50
+	cases := []struct {
+		internal runtime.Object
+		external runtime.Object
+		// fields report whether the fuzzer set the fields whose fidelity
+		// this test is meant to verify, each of them has to be set at least
+		// once over all of the iterations.
+		fields map[string]func(runtime.Object) bool
+	}{
+		{
+			internal: &servicecatalog.ClusterServiceBroker{},
+			external: &v1beta1.ClusterServiceBroker{},
+			fields: map[string]func(runtime.Object) bool{
+				"status.conditions": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ClusterServiceBroker).Status.Conditions) > 0
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ServiceBroker{},
+			external: &v1beta1.ServiceBroker{},
+			fields: map[string]func(runtime.Object) bool{
+				"status.conditions": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceBroker).Status.Conditions) > 0
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ClusterServiceClass{},
+			external: &v1beta1.ClusterServiceClass{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.externalMetadata": func(obj runtime.Object) bool {
+					return obj.(*servicecatalog.ClusterServiceClass).Spec.ExternalMetadata != nil
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ServiceClass{},
+			external: &v1beta1.ServiceClass{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.externalMetadata": func(obj runtime.Object) bool {
+					return obj.(*servicecatalog.ServiceClass).Spec.ExternalMetadata != nil
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ClusterServicePlan{},
+			external: &v1beta1.ClusterServicePlan{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.instanceCreateParameterSchema": func(obj runtime.Object) bool {
+					return obj.(*servicecatalog.ClusterServicePlan).Spec.InstanceCreateParameterSchema != nil
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ServicePlan{},
+			external: &v1beta1.ServicePlan{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.instanceCreateParameterSchema": func(obj runtime.Object) bool {
+					return obj.(*servicecatalog.ServicePlan).Spec.InstanceCreateParameterSchema != nil
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ServiceInstance{},
+			external: &v1beta1.ServiceInstance{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.parametersFrom": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceInstance).Spec.ParametersFrom) > 0
+				},
+				"status.conditions": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceInstance).Status.Conditions) > 0
+				},
+			},
+		},
+		{
+			internal: &servicecatalog.ServiceBinding{},
+			external: &v1beta1.ServiceBinding{},
+			fields: map[string]func(runtime.Object) bool{
+				"spec.parametersFrom": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceBinding).Spec.ParametersFrom) > 0
+				},
+				"spec.secretTransforms": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceBinding).Spec.SecretTransforms) > 0
+				},
+				"status.conditions": func(obj runtime.Object) bool {
+					return len(obj.(*servicecatalog.ServiceBinding).Status.Conditions) > 0
+				},
+			},
+		},
+	}
+
+	seed := rand.Int63()
+	f := fuzzer.FuzzerFor(apitesting.FuzzerFuncs, rand.NewSource(seed), api.Codecs)
+	for _, tc := range cases {
+		name := reflect.TypeOf(tc.internal).Elem().Name()
+		t.Run(name, func(t *testing.T) {
+			fuzzed := sets.NewString()
+			for i := 0; i < iterations; i++ {
+				original := tc.internal.DeepCopyObject()
+				f.Fuzz(original)
+				for field, isSet := range tc.fields {
+					if isSet(original) {
+						fuzzed.Insert(field)
+					}
+				}
+
+				external := tc.external.DeepCopyObject()
+				if err := api.Scheme.Convert(original, external, nil); err != nil {
+					t.Fatalf("seed %d: unable to convert to %T: %v", seed, external, err)
+				}
+				roundTripped := tc.internal.DeepCopyObject()
+				if err := api.Scheme.Convert(external, roundTripped, nil); err != nil {
+					t.Fatalf("seed %d: unable to convert from %T: %v", seed, external, err)
+				}
+				if !equality.Semantic.DeepEqual(original, roundTripped) {
+					t.Fatalf("seed %d: the conversion round trip altered the object, diff: %v", seed, diff.ObjectReflectDiff(original, roundTripped))
+				}
+			}
+			for field := range tc.fields {
+				if !fuzzed.Has(field) {
+					t.Fatalf("seed %d: the fuzzer never set %s of %s", seed, field, name)
+				}
+			}
+		})
+	}
+}
+
 func TestBadJSONRejection(t *testing.T) {
 	badJSONMissingKind := []byte(`{ }`)
 	if _, err := runtime.Decode(testapi.ServiceCatalog.Codec(), badJSONMissingKind); err == nil {
@@ -113,6 +113,14 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			}
 			is.Parameters = parameters
 		},
+		func(ts *servicecatalog.ServiceInstanceTemplateSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(ts)
+			parameters, err := createParameter(c)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
+			}
+			ts.Parameters = parameters
+		},
 		func(bs *servicecatalog.ServiceBindingSpec, c fuzz.Continue) {
 			c.FuzzNoCustom(bs)
 			bs.ExternalID = string(uuid.NewUUID())
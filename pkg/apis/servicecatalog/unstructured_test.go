@@ -65,6 +65,10 @@ func doUnstructuredRoundTrip(t *testing.T, group testapi.TestGroup, kind string)
 			is.ExternalID = string(uuid.NewUUID())
 			is.Parameters = nil
 		},
+		func(ts *servicecatalog.ServiceInstanceTemplateSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(ts)
+			ts.Parameters = nil
+		},
 		func(is *servicecatalog.ServiceInstanceStatus, c fuzz.Continue) {
 			c.FuzzNoCustom(is)
 			is.DefaultProvisionParameters = nil
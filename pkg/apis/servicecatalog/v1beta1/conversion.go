@@ -108,6 +108,7 @@ func ServiceBindingFieldLabelConversionFunc(label, value string) (string, string
 	switch label {
 	case "metadata.name",
 		"metadata.namespace",
+		"spec.instanceRef.name",
 		"spec.externalID":
 		return label, value, nil
 	default:
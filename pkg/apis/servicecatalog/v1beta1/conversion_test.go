@@ -235,6 +235,14 @@ func TestServiceInstanceFieldLabelConversionFunc(t *testing.T) {
 
 func TestServiceBindingFieldLabelConversionFunc(t *testing.T) {
 	cases := []testcase{
+		{
+			name:     "spec.instanceRef.name works",
+			inLabel:  "spec.instanceRef.name",
+			inValue:  "someref",
+			outLabel: "spec.instanceRef.name",
+			outValue: "someref",
+			success:  true,
+		},
 		{
 			name:     "spec.externalID works",
 			inLabel:  "spec.externalID",
@@ -86,6 +86,16 @@ func (p *ServicePlan) GetFree() bool {
 	return p.Spec.Free
 }
 
+// GetCosts returns the costs of the plan.
+func (p *ClusterServicePlan) GetCosts() []ServicePlanCost {
+	return p.Spec.Costs
+}
+
+// GetCosts returns the costs of the plan.
+func (p *ServicePlan) GetCosts() []ServicePlanCost {
+	return p.Spec.Costs
+}
+
 // GetClassID returns the class name from plan.
 func (p *ClusterServicePlan) GetClassID() string {
 	return p.Spec.ClusterServiceClassRef.Name
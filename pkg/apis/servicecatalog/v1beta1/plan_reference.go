@@ -19,6 +19,8 @@ package v1beta1
 import (
 	"fmt"
 	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ClusterServiceClassSpecified checks that at least one clusterserviceclass
@@ -26,7 +28,8 @@ import (
 func (pr PlanReference) ClusterServiceClassSpecified() bool {
 	return pr.ClusterServiceClassExternalName != "" ||
 		pr.ClusterServiceClassExternalID != "" ||
-		pr.ClusterServiceClassName != ""
+		pr.ClusterServiceClassName != "" ||
+		pr.ClusterServiceClassSelector != nil
 }
 
 // ClusterServicePlanSpecified checks that at least one clusterserviceplan
@@ -41,7 +44,8 @@ func (pr PlanReference) ClusterServicePlanSpecified() bool {
 func (pr PlanReference) ServiceClassSpecified() bool {
 	return pr.ServiceClassExternalName != "" ||
 		pr.ServiceClassExternalID != "" ||
-		pr.ServiceClassName != ""
+		pr.ServiceClassName != "" ||
+		pr.ServiceClassSelector != nil
 }
 
 // ServicePlanSpecified checks that at least one serviceplan field is set.
@@ -55,6 +59,7 @@ func (pr PlanReference) ServicePlanSpecified() bool {
 // * ClusterServiceClassExternalName
 // * ClusterServiceClassExternalID
 // * ClusterServiceClassName
+// * ClusterServiceClassSelector, formatted as a label selector
 func (pr PlanReference) GetSpecifiedClusterServiceClass() string {
 	if pr.ClusterServiceClassExternalName != "" {
 		return pr.ClusterServiceClassExternalName
@@ -68,6 +73,10 @@ func (pr PlanReference) GetSpecifiedClusterServiceClass() string {
 		return pr.ClusterServiceClassName
 	}
 
+	if pr.ClusterServiceClassSelector != nil {
+		return metav1.FormatLabelSelector(pr.ClusterServiceClassSelector)
+	}
+
 	return ""
 }
 
@@ -75,6 +84,7 @@ func (pr PlanReference) GetSpecifiedClusterServiceClass() string {
 // * ServiceClassExternalName
 // * ServiceClassExternalID
 // * ServiceClassName
+// * ServiceClassSelector, formatted as a label selector
 func (pr PlanReference) GetSpecifiedServiceClass() string {
 	if pr.ServiceClassExternalName != "" {
 		return pr.ServiceClassExternalName
@@ -88,6 +98,10 @@ func (pr PlanReference) GetSpecifiedServiceClass() string {
 		return pr.ServiceClassName
 	}
 
+	if pr.ServiceClassSelector != nil {
+		return metav1.FormatLabelSelector(pr.ServiceClassSelector)
+	}
+
 	return ""
 }
 
@@ -206,6 +220,7 @@ func (pr PlanReference) String() string {
 //     {ClassExternalName:"foo"}
 //     {ClassExternalID:"foo123"}
 //     {ClassName:"k8s-foo123"}
+//     {ClassSelector:"database=postgres"}
 // %b - Print specified plan fields only
 //    NOTE: %p is a reserved verb so we can't use it, and go vet fails for non-standard verbs
 //    Examples:
@@ -235,6 +250,9 @@ func (pr PlanReference) Format(s fmt.State, verb rune) {
 	if pr.ClusterServiceClassName != "" {
 		classFields = append(classFields, fmt.Sprintf("ClusterServiceClassName:%q", pr.ClusterServiceClassName))
 	}
+	if pr.ClusterServiceClassSelector != nil {
+		classFields = append(classFields, fmt.Sprintf("ClusterServiceClassSelector:%q", metav1.FormatLabelSelector(pr.ClusterServiceClassSelector)))
+	}
 
 	if pr.ClusterServicePlanExternalName != "" {
 		planFields = append(planFields, fmt.Sprintf("ClusterServicePlanExternalName:%q", pr.ClusterServicePlanExternalName))
@@ -255,6 +273,9 @@ func (pr PlanReference) Format(s fmt.State, verb rune) {
 	if pr.ServiceClassName != "" {
 		classFields = append(classFields, fmt.Sprintf("ServiceClassName:%q", pr.ServiceClassName))
 	}
+	if pr.ServiceClassSelector != nil {
+		classFields = append(classFields, fmt.Sprintf("ServiceClassSelector:%q", metav1.FormatLabelSelector(pr.ServiceClassSelector)))
+	}
 
 	if pr.ServicePlanExternalName != "" {
 		planFields = append(planFields, fmt.Sprintf("ServicePlanExternalName:%q", pr.ServicePlanExternalName))
@@ -19,6 +19,8 @@ package v1beta1
 import (
 	"fmt"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPlanReference_Format(t *testing.T) {
@@ -46,6 +48,8 @@ func TestPlanReference_Format(t *testing.T) {
 			ClusterServiceClassExternalID: "foo-abc123", ClusterServicePlanExternalID: "bar-def456"}},
 		{"class: cluster-name", "%c", `{ClusterServiceClassName:"k8s-foo1232"}`, PlanReference{
 			ClusterServiceClassName: "k8s-foo1232", ClusterServicePlanName: "k8s-bar456"}},
+		{"class: selector", "%c", `{ClusterServiceClassSelector:"database=postgres"}`, PlanReference{
+			ClusterServiceClassSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"database": "postgres"}}, ClusterServicePlanExternalName: "bar"}},
 		{"plan: external-name", "%b", `{ClusterServicePlanExternalName:"bar"}`, PlanReference{
 			ClusterServiceClassExternalName: "foo", ClusterServicePlanExternalName: "bar"}},
 		{"plan: external-id", "%b", `{ClusterServicePlanExternalID:"bar-def456"}`, PlanReference{
@@ -40,6 +40,7 @@ func (in *ServiceInstance) RecalculatePrinterColumnStatusFields() {
 	}
 	in.Status.UserSpecifiedClassName = class
 	in.Status.UserSpecifiedPlanName = plan
+	in.Status.AppliedPlanName, in.Status.AppliedParameterChecksum = getServiceInstanceAppliedProperties(in.Status.ExternalProperties)
 
 	in.Status.LastConditionState = getServiceInstanceLastConditionState(&in.Status)
 }
@@ -65,6 +66,20 @@ func getServiceInstanceLastConditionState(status *ServiceInstanceStatus) string
 	return ""
 }
 
+// getServiceInstanceAppliedProperties returns the plan external name and the
+// parameter checksum of the properties last accepted by the broker. The
+// parameters and the user info are not exposed.
+func getServiceInstanceAppliedProperties(props *ServiceInstancePropertiesState) (string, string) {
+	if props == nil {
+		return "", ""
+	}
+	plan := props.ClusterServicePlanExternalName
+	if plan == "" {
+		plan = props.ServicePlanExternalName
+	}
+	return plan, props.ParameterChecksum
+}
+
 func serviceBrokerLastConditionState(status *CommonServiceBrokerStatus) string {
 	if len(status.Conditions) > 0 {
 		condition := status.Conditions[len(status.Conditions)-1]
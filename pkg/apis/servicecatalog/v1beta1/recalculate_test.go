@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestServiceInstanceRecalculateAppliedProperties(t *testing.T) {
+	cases := []struct {
+		name             string
+		props            *ServiceInstancePropertiesState
+		expectedPlan     string
+		expectedChecksum string
+	}{
+		{
+			name: "not yet applied",
+		},
+		{
+			name: "cluster plan",
+			props: &ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "cluster-plan",
+				ClusterServicePlanExternalID:   "cluster-plan-id",
+				ParameterChecksum:              "checksum",
+			},
+			expectedPlan:     "cluster-plan",
+			expectedChecksum: "checksum",
+		},
+		{
+			name: "namespaced plan",
+			props: &ServiceInstancePropertiesState{
+				ServicePlanExternalName: "plan",
+				ServicePlanExternalID:   "plan-id",
+			},
+			expectedPlan: "plan",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &ServiceInstance{
+				Spec: ServiceInstanceSpec{
+					PlanReference: PlanReference{
+						ClusterServiceClassExternalName: "cluster-class",
+						ClusterServicePlanExternalName:  "desired-plan",
+					},
+				},
+				Status: ServiceInstanceStatus{
+					ExternalProperties: tc.props,
+				},
+			}
+			instance.RecalculatePrinterColumnStatusFields()
+
+			if e, a := "desired-plan", instance.Status.UserSpecifiedPlanName; e != a {
+				t.Errorf("unexpected user specified plan: expected %q, got %q", e, a)
+			}
+			if e, a := tc.expectedPlan, instance.Status.AppliedPlanName; e != a {
+				t.Errorf("unexpected applied plan: expected %q, got %q", e, a)
+			}
+			if e, a := tc.expectedChecksum, instance.Status.AppliedParameterChecksum; e != a {
+				t.Errorf("unexpected applied parameter checksum: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
@@ -69,6 +69,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServicePlanList{},
 		&ServiceInstance{},
 		&ServiceInstanceList{},
+		&ServiceInstanceTemplate{},
+		&ServiceInstanceTemplateList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
 	)
@@ -88,6 +88,26 @@ type ServiceBrokerList struct {
 	Items []ServiceBroker `json:"items"`
 }
 
+// ServiceBrokerUpdateContextAnnotation is the annotation that, when set to
+// "true" or "false" on a ClusterServiceBroker or a ServiceBroker, overrides
+// for the instances of the broker whether the controller sends the OSB
+// context in update requests and updates the instances when their context
+// changes.
+const ServiceBrokerUpdateContextAnnotation = "servicecatalog.k8s.io/update-context"
+
+// ServiceBrokerEmptyCredentialsPolicyAnnotation is the annotation that, when
+// set to "Allow" or "Warn" on a ClusterServiceBroker or a ServiceBroker,
+// overrides for the bindings of the broker what the controller does when the
+// broker returns no credentials for a binding.
+const ServiceBrokerEmptyCredentialsPolicyAnnotation = "servicecatalog.k8s.io/empty-credentials-policy"
+
+// ServiceBrokerContextAnnotation is the annotation that holds, as a JSON
+// object on a ClusterServiceBroker or a ServiceBroker, additional keys sent in
+// the OSB context of the requests to the broker, for example
+// '{"encrypt": true}'. They take precedence over the context configured for
+// the controller, but can not set the keys reserved by the OSB API.
+const ServiceBrokerContextAnnotation = "servicecatalog.k8s.io/context"
+
 // CommonServiceBrokerSpec represents a description of a Broker.
 type CommonServiceBrokerSpec struct {
 	// URL is the address used to communicate with the ServiceBroker.
@@ -95,6 +115,8 @@ type CommonServiceBrokerSpec struct {
 
 	// InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker.
 	// This is strongly discouraged.  You should use the CABundle instead.
+	// It can not be combined with CABundle, and the Broker gets the InsecureTLS
+	// condition and a warning event while it is set.
 	// +optional
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
@@ -126,6 +148,77 @@ type CommonServiceBrokerSpec struct {
 	// and plans have resources created for them.
 	// +optional
 	CatalogRestrictions *CatalogRestrictions `json:"catalogRestrictions,omitempty"`
+
+	// UserAgentSuffix is appended to the User-Agent header of the requests
+	// to the broker, for example to tell the broker which cluster is calling
+	// it. It overrides the suffix configured in the controller manager.
+	// +optional
+	UserAgentSuffix string `json:"userAgentSuffix,omitempty"`
+
+	// RetryPolicy overrides the backoff of the controller manager between
+	// the retries of the requests to the broker that failed.
+	// +optional
+	RetryPolicy *BrokerRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Protocol is the protocol with which the controller contacts the
+	// broker: HTTP, the protocol of the Open Service Broker API, or GRPC,
+	// which maps its operations to gRPC calls. Defaults to HTTP.
+	// +optional
+	Protocol ServiceBrokerProtocol `json:"protocol,omitempty"`
+
+	// HealthCheck enables probing the broker between relists, without
+	// fetching its catalog, to set its Reachable condition. The broker is
+	// only probed when the health check interval of the controller manager
+	// is not zero.
+	// +optional
+	HealthCheck *BrokerHealthCheck `json:"healthCheck,omitempty"`
+
+	// CatalogRefreshJitterPercent is the percentage of the relist
+	// duration, from 0 to 99, by which the relists of the broker are
+	// delayed at most, so that they do not all hit the broker on the same
+	// schedule. Zero, the default, relists the broker exactly every relist
+	// duration.
+	// +optional
+	CatalogRefreshJitterPercent int64 `json:"catalogRefreshJitterPercent,omitempty"`
+}
+
+// BrokerHealthCheck configures the probe that tells whether a broker is
+// reachable.
+type BrokerHealthCheck struct {
+	// Path is the path, relative to the URL of the broker, of an endpoint
+	// that the controller sends a GET request to. When it is empty, the
+	// controller sends a HEAD request for the catalog, whose response body
+	// is not read.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// BrokerRetryPolicy is the backoff between the retries of the failed
+// requests to a broker. Fetching the catalog and operating on instances and
+// bindings back off independently, so that a broker that is flaky on one of
+// them can be tuned without affecting the other.
+type BrokerRetryPolicy struct {
+	// Catalog is the backoff between the retries of a failed catalog fetch.
+	// +optional
+	Catalog *RetryBackoff `json:"catalog,omitempty"`
+
+	// Operations is the backoff between the retries of failed provision,
+	// update and bind requests.
+	// +optional
+	Operations *RetryBackoff `json:"operations,omitempty"`
+}
+
+// RetryBackoff is an exponential backoff: the delay before the first retry
+// is InitialDelay, and it doubles with each failure up to MaxDelay. A field
+// that is not set takes the value configured in the controller manager.
+type RetryBackoff struct {
+	// InitialDelay is the delay before the first retry.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// MaxDelay is the maximum delay between two retries.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -212,6 +305,19 @@ const (
 	ServiceBrokerRelistBehaviorManual ServiceBrokerRelistBehavior = "Manual"
 )
 
+// ServiceBrokerProtocol is the protocol with which a broker is contacted.
+type ServiceBrokerProtocol string
+
+const (
+	// ServiceBrokerProtocolHTTP indicates that the broker implements the
+	// HTTP API of the Open Service Broker API.
+	ServiceBrokerProtocolHTTP ServiceBrokerProtocol = "HTTP"
+
+	// ServiceBrokerProtocolGRPC indicates that the broker exposes the
+	// operations of the Open Service Broker API as a gRPC service.
+	ServiceBrokerProtocolGRPC ServiceBrokerProtocol = "GRPC"
+)
+
 // ClusterServiceBrokerAuthInfo is a union type that contains information on
 // one of the authentication methods the service catalog and brokers may
 // support, according to the OpenServiceBroker API specification
@@ -223,6 +329,10 @@ type ClusterServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *ClusterBearerTokenAuthConfig `json:"bearer,omitempty"`
+	// ClusterServiceAccountTokenAuthConfig provides configuration to send a short-lived token of a
+	// ServiceAccount as a bearer token. The token is requested for the given
+	// audience through the TokenRequest API and renewed before it expires.
+	ServiceAccountToken *ClusterServiceAccountTokenAuthConfig `json:"serviceAccountToken,omitempty"`
 }
 
 // ClusterBasicAuthConfig provides config for the basic authentication of
@@ -248,6 +358,19 @@ type ClusterBearerTokenAuthConfig struct {
 	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 }
 
+// ClusterServiceAccountTokenAuthConfig provides config for the ServiceAccount token
+// authentication of cluster scoped brokers.
+type ClusterServiceAccountTokenAuthConfig struct {
+	// ServiceAccountRef is a reference to the ServiceAccount whose token
+	// is sent to this ServiceBroker.
+	ServiceAccountRef *ObjectReference `json:"serviceAccountRef,omitempty"`
+
+	// Audience is the intended audience of the token, which the broker
+	// should verify. It must not be empty, so that the token can not be
+	// used against the Kubernetes API server.
+	Audience string `json:"audience"`
+}
+
 // ServiceBrokerAuthInfo is a union type that contains information on
 // one of the authentication methods the service catalog and brokers may
 // support, according to the OpenServiceBroker API specification
@@ -259,6 +382,10 @@ type ServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *BearerTokenAuthConfig `json:"bearer,omitempty"`
+	// ServiceAccountTokenAuthConfig provides configuration to send a short-lived token of a
+	// ServiceAccount as a bearer token. The token is requested for the given
+	// audience through the TokenRequest API and renewed before it expires.
+	ServiceAccountToken *ServiceAccountTokenAuthConfig `json:"serviceAccountToken,omitempty"`
 }
 
 // BasicAuthConfig provides config for the basic authentication of
@@ -284,6 +411,20 @@ type BearerTokenAuthConfig struct {
 	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
 }
 
+// ServiceAccountTokenAuthConfig provides config for the ServiceAccount token
+// authentication of namespaced brokers.
+type ServiceAccountTokenAuthConfig struct {
+	// ServiceAccountRef is a reference to the ServiceAccount whose token
+	// is sent to this ServiceBroker. The ServiceAccount must be in the namespace
+	// of the broker.
+	ServiceAccountRef *LocalObjectReference `json:"serviceAccountRef,omitempty"`
+
+	// Audience is the intended audience of the token, which the broker
+	// should verify. It must not be empty, so that the token can not be
+	// used against the Kubernetes API server.
+	Audience string `json:"audience"`
+}
+
 const (
 	// BasicAuthUsernameKey is the key of the username for SecretTypeBasicAuth secrets
 	BasicAuthUsernameKey = "username"
@@ -298,11 +439,20 @@ const (
 type CommonServiceBrokerStatus struct {
 	Conditions []ServiceBrokerCondition `json:"conditions"`
 
+	// LastConditionUpdateTime is the last time any of the conditions changed
+	// their status, reason or message.
+	LastConditionUpdateTime *metav1.Time `json:"lastConditionUpdateTime,omitempty"`
+
 	// ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that
 	// was last processed by the controller. The reconciled generation is updated
 	// even if the controller failed to process the spec.
 	ReconciledGeneration int64 `json:"reconciledGeneration"`
 
+	// CurrentOperation is the operation the controller is currently
+	// performing on the broker. It is only set while a relist is tracked,
+	// see the --broker-relist-timeout flag of the controller manager.
+	CurrentOperation ServiceBrokerOperation `json:"currentOperation,omitempty"`
+
 	// OperationStartTime is the time at which the current operation began.
 	OperationStartTime *metav1.Time `json:"operationStartTime,omitempty"`
 
@@ -310,6 +460,16 @@ type CommonServiceBrokerStatus struct {
 	// the Service Broker
 	LastCatalogRetrievalTime *metav1.Time `json:"lastCatalogRetrievalTime,omitempty"`
 
+	// LastRelistRequestProcessed is the value of spec.relistRequests that
+	// was last processed by the controller. It is updated together with the
+	// reconciled generation, so a client that increments relistRequests can
+	// wait for this field to match it to know that the relist has completed.
+	LastRelistRequestProcessed int64 `json:"lastRelistRequestProcessed,omitempty"`
+
+	// LastHealthCheckTime is the time the broker was last probed, see
+	// spec.healthCheck.
+	LastHealthCheckTime *metav1.Time `json:"lastHealthCheckTime,omitempty"`
+
 	// LastConditionState aggregates state from the Conditions array
 	// It is used for printing in a kubectl output via additionalPrinterColumns
 	LastConditionState string `json:"lastConditionState"`
@@ -358,6 +518,35 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionCatalogStale represents the fact that the catalog
+	// of a broker has not been retrieved successfully for several relist
+	// intervals.
+	ServiceBrokerConditionCatalogStale ServiceBrokerConditionType = "CatalogStale"
+
+	// ServiceBrokerConditionInsecureTLS represents the fact that the TLS
+	// certificate of a broker is not verified because its spec sets
+	// insecureSkipTLSVerify.
+	ServiceBrokerConditionInsecureTLS ServiceBrokerConditionType = "InsecureTLS"
+
+	// ServiceBrokerConditionRelistStuck represents the fact that a relist of
+	// a broker has been in progress for longer than the relist timeout.
+	ServiceBrokerConditionRelistStuck ServiceBrokerConditionType = "RelistStuck"
+
+	// ServiceBrokerConditionReachable represents the fact that a broker
+	// answered the last probe of its health check. Unlike the ready
+	// condition, it does not depend on the catalog of the broker.
+	ServiceBrokerConditionReachable ServiceBrokerConditionType = "Reachable"
+)
+
+// ServiceBrokerOperation represents a type of operation the controller can be
+// performing for a broker.
+type ServiceBrokerOperation string
+
+const (
+	// ServiceBrokerOperationRelist indicates that the catalog of the broker
+	// is being fetched and synced into classes and plans.
+	ServiceBrokerOperationRelist ServiceBrokerOperation = "Relist"
 )
 
 // ConditionStatus represents a condition's status.
@@ -489,6 +678,14 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool `json:"bindingRetrievable"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstancesRetrievable indicates whether fetching a service instance via
+	// a GET on its endpoint is supported for all plans.
+	// +optional
+	InstancesRetrievable bool `json:"instancesRetrievable,omitempty"`
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being
 	// provisioned.
@@ -651,6 +848,23 @@ type CommonServicePlanSpec struct {
 	// the instance are merged with these defaults, with instance-defined
 	// parameters taking precedence over defaults.
 	DefaultProvisionParameters *runtime.RawExtension `json:"defaultProvisionParameters,omitempty"`
+
+	// Costs are the costs of this plan that the Service Broker advertises in
+	// the costs of the plan metadata. Costs that can not be parsed are left
+	// out; they remain available in ExternalMetadata.
+	Costs []ServicePlanCost `json:"costs,omitempty"`
+}
+
+// ServicePlanCost is a cost of a ServicePlan, following the OSB convention
+// for the costs in the plan metadata.
+type ServicePlanCost struct {
+	// Amount maps a lowercase currency code, such as "usd", to the amount
+	// charged in that currency for each Unit, such as "99.95".
+	Amount map[string]string `json:"amount"`
+
+	// Unit is what the amount is charged for, such as "MONTHLY" or
+	// "1GB of messages".
+	Unit string `json:"unit"`
 }
 
 // ClusterServicePlanSpec represents details about a ClusterServicePlan.
@@ -797,6 +1011,8 @@ type ServiceInstance struct {
 //  - ServiceClassExternalName and ServicePlanExternalName
 //  - ServiceClassExternalID and ServicePlanExternalID
 //  - ServiceClassName and ServicePlanName
+//  - ClusterServiceClassSelector and ClusterServicePlanExternalName
+//  - ServiceClassSelector and ServicePlanExternalName
 //
 // For any of these ways, if a ClusterServiceClass only has one plan
 // then the corresponding service plan field is optional.
@@ -833,6 +1049,17 @@ type PlanReference struct {
 	// ClusterServicePlanName is kubernetes name of the ClusterServicePlan.
 	ClusterServicePlanName string `json:"clusterServicePlanName,omitempty"`
 
+	// ClusterServiceClassSelector selects the ClusterServiceClass by its
+	// labels. The selector must match exactly one ClusterServiceClass that
+	// has not been removed from the catalog of its broker. The plan is
+	// either omitted or given by ClusterServicePlanExternalName, and is
+	// looked up among the plans of the selected class. The class is only
+	// resolved once: changing the labels of the classes afterwards does not
+	// change the class of the instance.
+	//
+	// Immutable.
+	ClusterServiceClassSelector *metav1.LabelSelector `json:"clusterServiceClassSelector,omitempty"`
+
 	// ServiceClassExternalName is the human-readable name of the
 	// service as reported by the ServiceBroker. Note that if the ServiceBroker
 	// changes the name of the ServiceClass, it will not be reflected here,
@@ -862,6 +1089,17 @@ type PlanReference struct {
 	ServiceClassName string `json:"serviceClassName,omitempty"`
 	// ServicePlanName is kubernetes name of the ServicePlan.
 	ServicePlanName string `json:"servicePlanName,omitempty"`
+
+	// ServiceClassSelector selects the ServiceClass in the namespace of the
+	// instance by its labels. The selector must match exactly one
+	// ServiceClass that has not been removed from the catalog of its broker.
+	// The plan is either omitted or given by ServicePlanExternalName, and is
+	// looked up among the plans of the selected class. The class is only
+	// resolved once: changing the labels of the classes afterwards does not
+	// change the class of the instance.
+	//
+	// Immutable.
+	ServiceClassSelector *metav1.LabelSelector `json:"serviceClassSelector,omitempty"`
 }
 
 // ServiceInstanceSpec represents the desired state of an Instance.
@@ -908,6 +1146,13 @@ type ServiceInstanceSpec struct {
 	// +optional
 	ParametersFrom []ParametersFromSource `json:"parametersFrom,omitempty"`
 
+	// SecretParameterRefs are Secret keys that populate parameters like the
+	// secretKeyRef sources of ParametersFrom. Unlike ParametersFrom, the
+	// instance is updated when the parameters read from these Secrets
+	// change, and the parameters can be propagated to new bindings.
+	// +optional
+	SecretParameterRefs []SecretParameterReference `json:"secretParameterRefs,omitempty"`
+
 	// ExternalID is the identity of this object for use with the OSB SB API.
 	//
 	// Immutable.
@@ -937,6 +1182,10 @@ type ServiceInstanceStatus struct {
 	// ServiceInstance's status.
 	Conditions []ServiceInstanceCondition `json:"conditions"`
 
+	// LastConditionUpdateTime is the last time any of the conditions changed
+	// their status, reason or message.
+	LastConditionUpdateTime *metav1.Time `json:"lastConditionUpdateTime,omitempty"`
+
 	// AsyncOpInProgress is set to true if there is an ongoing async operation
 	// against this Service Instance in progress.
 	AsyncOpInProgress bool `json:"asyncOpInProgress"`
@@ -950,10 +1199,28 @@ type ServiceInstanceStatus struct {
 	// on poll requests as a query param.
 	LastOperation *string `json:"lastOperation,omitempty"`
 
+	// LastOperationDescription is the description of the progress of the
+	// ongoing async operation that the broker returned on the last poll
+	// request, truncated if too long. It is cleared when the operation
+	// completes.
+	LastOperationDescription string `json:"lastOperationDescription,omitempty"`
+
 	// DashboardURL is the URL of a web-based management user interface for
 	// the service instance.
 	DashboardURL *string `json:"dashboardURL,omitempty"`
 
+	// ProvisionMetadata is the metadata that the broker returned for the
+	// instance in the response to its provision request, such as the
+	// identifier or the region of the resource it created. It is limited in
+	// size, and the values of the keys that look sensitive are redacted.
+	ProvisionMetadata *ServiceInstanceProvisionMetadata `json:"provisionMetadata,omitempty"`
+
+	// StateToken is the state of the instance at the broker, the ETag that
+	// the broker returned for it. It is sent back in the If-Match header of
+	// the update and deprovision requests, so that the broker refuses them
+	// when the instance changed in the meantime.
+	StateToken string `json:"stateToken,omitempty"`
+
 	// CurrentOperation is the operation the Controller is currently performing
 	// on the ServiceInstance.
 	CurrentOperation ServiceInstanceOperation `json:"currentOperation,omitempty"`
@@ -1003,6 +1270,16 @@ type ServiceInstanceStatus struct {
 	// UserSpecifiedClassName aggregates cluster or namespace ClassName
 	// It is used for printing in a kubectl output via additionalPrinterColumns
 	UserSpecifiedClassName string `json:"userSpecifiedClassName"`
+
+	// AppliedPlanName is the external name of the plan that the broker last
+	// accepted for the instance, taken from ExternalProperties. It differs
+	// from UserSpecifiedPlanName while a plan change is in progress.
+	// It is used for printing in a kubectl output via additionalPrinterColumns
+	AppliedPlanName string `json:"appliedPlanName,omitempty"`
+
+	// AppliedParameterChecksum is the checksum of the parameters that the
+	// broker last accepted for the instance, taken from ExternalProperties.
+	AppliedParameterChecksum string `json:"appliedParameterChecksum,omitempty"`
 }
 
 // ServiceInstanceCondition contains condition information about an Instance.
@@ -1041,8 +1318,50 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionPaused represents that the controller is not
+	// reconciling the instance because of the paused annotation.
+	ServiceInstanceConditionPaused ServiceInstanceConditionType = "Paused"
+
+	// ServiceInstanceConditionNamespaceDeletion represents that the instance
+	// is being deprovisioned because its namespace is being deleted.
+	ServiceInstanceConditionNamespaceDeletion ServiceInstanceConditionType = "NamespaceDeletion"
 )
 
+// ServiceInstancePausedAnnotation is the annotation that, when set to "true"
+// on a ServiceInstance, stops the controller from reconciling the instance
+// until the annotation is removed.
+const ServiceInstancePausedAnnotation = "servicecatalog.k8s.io/paused"
+
+// ServiceInstanceAsyncOperationTimeoutAnnotation is the annotation that
+// overrides, for a single ServiceInstance, how long the controller polls an
+// asynchronous operation of the instance before the operation fails as timed
+// out. Its value is a duration such as "2h"; "0" polls until the
+// reconciliation retry duration is exceeded.
+const ServiceInstanceAsyncOperationTimeoutAnnotation = "servicecatalog.k8s.io/async-operation-timeout"
+
+// ServiceInstanceDeprovisionTimeoutAnnotation is the annotation that
+// overrides, for a single ServiceInstance, how long the deprovisioning of the
+// instance may keep failing after the instance was deleted before the
+// controller reports it as timed out. Its value is a duration such as "24h";
+// "0" disables the timeout.
+const ServiceInstanceDeprovisionTimeoutAnnotation = "servicecatalog.k8s.io/deprovision-timeout"
+
+// ServiceInstanceParametersResyncIntervalAnnotation is the annotation that
+// overrides, for a single ServiceInstance, how often the controller reads the
+// Secrets of spec.parametersFrom and spec.secretParameterRefs again to detect
+// parameters that changed. Its value is a duration such as "1h"; "0" disables
+// the periodic read.
+const ServiceInstanceParametersResyncIntervalAnnotation = "servicecatalog.k8s.io/parameters-resync-interval"
+
+// ServiceInstanceForceOrphanAnnotation is the annotation that, when set to
+// "true" on a deleted ServiceInstance whose deprovisioning failed or timed
+// out, makes the controller remove the finalizer of the instance even though
+// the broker did not deprovision it, once no ServiceBinding references the
+// instance. The resources of the instance at the broker may be left orphaned
+// and have to be cleaned up manually.
+const ServiceInstanceForceOrphanAnnotation = "servicecatalog.k8s.io/force-orphan"
+
 // ServiceInstanceOperation represents a type of operation the controller can
 // be performing for a service instance in the OSB API.
 type ServiceInstanceOperation string
@@ -1059,6 +1378,19 @@ const (
 	ServiceInstanceOperationDeprovision ServiceInstanceOperation = "Deprovision"
 )
 
+// ServiceInstanceProvisionMetadata is the metadata of a ServiceInstance
+// returned by the broker in the response to its provision request. The values
+// which are not strings are encoded as JSON.
+type ServiceInstanceProvisionMetadata struct {
+	// Labels are the labels that the broker assigned to the instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Attributes are the attributes that the broker assigned to the instance.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
 // ServiceInstancePropertiesState is the state of a ServiceInstance that
 // the ClusterServiceBroker knows about.
 type ServiceInstancePropertiesState struct {
@@ -1088,6 +1420,9 @@ type ServiceInstancePropertiesState struct {
 	// ParameterChecksum is the checksum of the parameters that were sent.
 	ParameterChecksum string `json:"parameterChecksum,omitempty"`
 
+	// ContextChecksum is the checksum of the OSB context that was sent.
+	ContextChecksum string `json:"contextChecksum,omitempty"`
+
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
 }
@@ -1128,6 +1463,76 @@ const (
 	ServiceInstanceProvisionStatusNotProvisioned ServiceInstanceProvisionStatus = "NotProvisioned"
 )
 
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceTemplate captures the class, plan and base parameters shared
+// by ServiceInstances in its namespace. A ServiceInstance refers to a
+// template with the ServiceInstanceTemplateAnnotation and only specifies the
+// values that differ from the template.
+//
+// Templates are expanded once, when the ServiceInstance is created. Later
+// changes to a template are not propagated to the ServiceInstances created
+// from it.
+type ServiceInstanceTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// The name of this resource in etcd is in ObjectMeta.Name.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the values applied to the ServiceInstances created from
+	// the template.
+	// +optional
+	Spec ServiceInstanceTemplateSpec `json:"spec,omitempty"`
+}
+
+// ServiceInstanceTemplateSpec represents the values a ServiceInstanceTemplate
+// applies to the ServiceInstances created from it.
+type ServiceInstanceTemplateSpec struct {
+	// Specification of the ServiceClass/ServicePlan of the instances. The
+	// class is used only by instances that do not specify a class, the plan
+	// only by instances that do not specify a plan.
+	PlanReference `json:",inline"`
+
+	// Parameters are the base parameters of the instances. The parameters
+	// of an instance are merged on top of them, so an instance only needs to
+	// specify the parameters that differ from the template.
+	//
+	// The Parameters field is NOT secret or secured in any way and should
+	// NEVER be used to hold sensitive information.
+	//
+	// +optional
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+
+	// ParametersFrom are the sources of parameters added before the
+	// parametersFrom of the instances.
+	// +optional
+	ParametersFrom []ParametersFromSource `json:"parametersFrom,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceTemplateList is a list of ServiceInstanceTemplates.
+type ServiceInstanceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceInstanceTemplate `json:"items"`
+}
+
+// ServiceInstanceTemplateAnnotation is the annotation that names the
+// ServiceInstanceTemplate, in the namespace of the ServiceInstance, that the
+// ServiceInstance is created from.
+const ServiceInstanceTemplateAnnotation = "servicecatalog.k8s.io/instance-template"
+
+// ServiceInstanceTemplateGenerationAnnotation records the generation of the
+// ServiceInstanceTemplate that was applied when the ServiceInstance was
+// created.
+const ServiceInstanceTemplateGenerationAnnotation = "servicecatalog.k8s.io/instance-template-generation"
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ServiceBindingList is a list of ServiceBindings.
@@ -1165,7 +1570,8 @@ type ServiceBinding struct {
 // ServiceBinding.
 //
 // The spec field cannot be changed after a ServiceBinding is
-// created.  Changes submitted to the spec field will be ignored.
+// created.  Changes submitted to the spec field will be ignored, except
+// for RebindRequests.
 type ServiceBindingSpec struct {
 	// InstanceRef is the reference to the Instance this ServiceBinding is to.
 	//
@@ -1193,20 +1599,51 @@ type ServiceBindingSpec struct {
 	// +optional
 	ParametersFrom []ParametersFromSource `json:"parametersFrom,omitempty"`
 
-	// SecretName is the name of the secret to create in the ServiceBinding's
-	// namespace that will hold the credentials associated with the ServiceBinding.
+	// SecretName is the name of the secret to create in the SecretNamespace
+	// that will hold the credentials associated with the ServiceBinding.
 	SecretName string `json:"secretName,omitempty"`
 
+	// SecretNamespace is the namespace of the secret that holds the
+	// credentials, for example a central namespace that is synchronized with
+	// a secrets manager. It defaults to the ServiceBinding's namespace. The
+	// controller must be permitted to write Secrets in the namespace.
+	//
+	// Immutable.
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
 	// List of transformations that should be applied to the credentials
 	// associated with the ServiceBinding before they are inserted into the Secret.
 	SecretTransforms []SecretTransform `json:"secretTransforms,omitempty"`
 
+	// BindResource holds the data of the platform resource that the binding
+	// is for, for example the "app_guid" of an application or a "route". It
+	// is sent to the broker as the "bind_resource" of the bind request. The
+	// keys defined by the OSB API, "app_guid" and "route", must not be empty;
+	// other keys are sent as they are.
+	//
+	// Immutable.
+	// +optional
+	BindResource map[string]string `json:"bindResource,omitempty"`
+
 	// ExternalID is the identity of this object for use with the OSB API.
+	// It defaults to a new UUID. To import a binding that was created at the
+	// broker out-of-band, set it to the ID of that binding on creation and
+	// set the ServiceBindingImportAnnotation.
 	//
 	// Immutable.
 	// +optional
 	ExternalID string `json:"externalID"`
 
+	// RebindRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rebind the
+	// ServiceBinding, for example to rotate compromised credentials. The
+	// binding is then bound again at the broker under a new ID, the new
+	// credentials are written into the same Secret, and the previous binding
+	// is unbound.
+	// +optional
+	RebindRequests int64 `json:"rebindRequests,omitempty"`
+
 	// Currently, this field is ALPHA: it may change or disappear at any time
 	// and its data will not be migrated.
 	//
@@ -1217,10 +1654,41 @@ type ServiceBindingSpec struct {
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
 }
 
+const (
+	// BindResourceAppGUIDKey is the key of the GUID of the application in
+	// the BindResource of a ServiceBinding.
+	BindResourceAppGUIDKey = "app_guid"
+	// BindResourceRouteKey is the key of the address of the route in the
+	// BindResource of a ServiceBinding.
+	BindResourceRouteKey = "route"
+)
+
+// ServiceBindingImportAnnotation is the annotation that, when set to "true"
+// on a ServiceBinding, makes the controller fetch the binding with the
+// external ID of the ServiceBinding from the broker instead of creating a new
+// one, if the service class declares bindings as retrievable. The Secret is
+// then populated with the credentials of the existing binding.
+const ServiceBindingImportAnnotation = "servicecatalog.k8s.io/import"
+
+// ServiceBindingSecretOwnerAnnotation is the annotation that records the UID
+// of the ServiceBinding that owns a Secret in another namespace than the
+// binding. Owner references can not cross namespaces, so such a Secret is not
+// garbage collected and the controller deletes it on unbind.
+const ServiceBindingSecretOwnerAnnotation = "servicecatalog.k8s.io/binding-uid"
+
+// ServiceBindingSecretBindingAnnotation is the annotation that records the
+// namespace and the name of the ServiceBinding that owns a Secret in another
+// namespace than the binding, as "namespace/name".
+const ServiceBindingSecretBindingAnnotation = "servicecatalog.k8s.io/binding"
+
 // ServiceBindingStatus represents the current status of a ServiceBinding.
 type ServiceBindingStatus struct {
 	Conditions []ServiceBindingCondition `json:"conditions"`
 
+	// LastConditionUpdateTime is the last time any of the conditions changed
+	// their status, reason or message.
+	LastConditionUpdateTime *metav1.Time `json:"lastConditionUpdateTime,omitempty"`
+
 	// Currently, this field is ALPHA: it may change or disappear at any time
 	// and its data will not be migrated.
 	//
@@ -1265,6 +1733,24 @@ type ServiceBindingStatus struct {
 	// UnbindStatus describes what has been done to unbind the ServiceBinding.
 	UnbindStatus ServiceBindingUnbindStatus `json:"unbindStatus"`
 
+	// RebindCount is the value of RebindRequests that the controller last
+	// processed. A rebind is pending while RebindRequests is greater.
+	RebindCount int64 `json:"rebindCount,omitempty"`
+
+	// ExternalID is the ID of the binding at the broker once a rebind
+	// replaced the one of the spec. It is empty until then.
+	ExternalID string `json:"externalID,omitempty"`
+
+	// RebindExternalID is the ID under which a rebind in progress binds the
+	// ServiceBinding again. The binding keeps its previous ID, and the Secret
+	// its previous credentials, until the new credentials are written.
+	RebindExternalID string `json:"rebindExternalID,omitempty"`
+
+	// StaleExternalIDs are the IDs of the bindings at the broker that a
+	// rebind replaced, or that a failed rebind may have created, and which
+	// remain to be unbound.
+	StaleExternalIDs []string `json:"staleExternalIDs,omitempty"`
+
 	// LastConditionState aggregates state from the Conditions array
 	// It is used for printing in a kubectl output via additionalPrinterColumns
 	LastConditionState string `json:"lastConditionState"`
@@ -1301,6 +1787,14 @@ const (
 	// ServiceBindingConditionFailed represents a ServiceBindingCondition that has failed
 	// completely and should not be retried.
 	ServiceBindingConditionFailed ServiceBindingConditionType = "Failed"
+
+	// ServiceBindingConditionWaitingForInstance represents a binding whose
+	// bind request is held back until its ServiceInstance becomes ready.
+	ServiceBindingConditionWaitingForInstance ServiceBindingConditionType = "WaitingForInstance"
+
+	// ServiceBindingConditionNoCredentials represents a binding for which the
+	// broker returned no credentials, so that its Secret is empty.
+	ServiceBindingConditionNoCredentials ServiceBindingConditionType = "NoCredentials"
 )
 
 // ServiceBindingOperation represents a type of operation
@@ -1363,6 +1857,11 @@ type ParametersFromSource struct {
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference `json:"secretKeyRef,omitempty"`
+	// The ConfigMap key to select from, for parameters which are not
+	// sensitive. The value must be a JSON object. It is only supported in
+	// the parametersFrom of ServiceBindings.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef,omitempty"`
 }
 
 // SecretKeyReference references a key of a Secret.
@@ -1373,6 +1872,35 @@ type SecretKeyReference struct {
 	Key string `json:"key"`
 }
 
+// ConfigMapKeyReference references a key of a ConfigMap.
+type ConfigMapKeyReference struct {
+	// The name of the ConfigMap in the namespace of the resource to select
+	// from.
+	Name string `json:"name"`
+	// The key of the ConfigMap to select from.
+	Key string `json:"key"`
+}
+
+// ServiceBindingParametersConfigMapLabel is the label that, when set to "true"
+// on a ConfigMap, makes the controller watch the ConfigMap and rebind the
+// ready ServiceBindings whose parameters read from it changed. The ConfigMaps
+// referenced by ServiceBindings must have it.
+const ServiceBindingParametersConfigMapLabel = "servicecatalog.k8s.io/binding-parameters"
+
+// SecretParameterReference references a Secret key that populates
+// parameters of a ServiceInstance.
+type SecretParameterReference struct {
+	// The Secret key to select from.
+	// The value must be a JSON object.
+	SecretKeyRef SecretKeyReference `json:"secretKeyRef"`
+
+	// PropagateToBindings adds the parameters to the bind requests of the
+	// ServiceBindings of the instance. They are read when a binding is
+	// bound, existing bindings are not bound again when they change.
+	// +optional
+	PropagateToBindings bool `json:"propagateToBindings,omitempty"`
+}
+
 // ObjectReference contains enough information to let you locate the
 // referenced object.
 type ObjectReference struct {
@@ -1519,6 +2047,8 @@ func init() {
 	SchemeBuilderRuntime.Register(
 		&ServiceBinding{},
 		&ServiceInstance{},
+		&ServiceInstanceTemplate{},
+		&ServiceInstanceTemplateList{},
 		&ClusterServiceClass{},
 		&ClusterServiceClassList{},
 		&ServiceBroker{},
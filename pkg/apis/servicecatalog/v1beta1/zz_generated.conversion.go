@@ -76,6 +76,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BrokerHealthCheck)(nil), (*servicecatalog.BrokerHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BrokerHealthCheck_To_servicecatalog_BrokerHealthCheck(a.(*BrokerHealthCheck), b.(*servicecatalog.BrokerHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.BrokerHealthCheck)(nil), (*BrokerHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_BrokerHealthCheck_To_v1beta1_BrokerHealthCheck(a.(*servicecatalog.BrokerHealthCheck), b.(*BrokerHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BrokerRetryPolicy)(nil), (*servicecatalog.BrokerRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BrokerRetryPolicy_To_servicecatalog_BrokerRetryPolicy(a.(*BrokerRetryPolicy), b.(*servicecatalog.BrokerRetryPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.BrokerRetryPolicy)(nil), (*BrokerRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_BrokerRetryPolicy_To_v1beta1_BrokerRetryPolicy(a.(*servicecatalog.BrokerRetryPolicy), b.(*BrokerRetryPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CatalogRestrictions)(nil), (*servicecatalog.CatalogRestrictions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(a.(*CatalogRestrictions), b.(*servicecatalog.CatalogRestrictions), scope)
 	}); err != nil {
@@ -116,6 +136,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterServiceAccountTokenAuthConfig)(nil), (*servicecatalog.ClusterServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(a.(*ClusterServiceAccountTokenAuthConfig), b.(*servicecatalog.ClusterServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ClusterServiceAccountTokenAuthConfig)(nil), (*ClusterServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(a.(*servicecatalog.ClusterServiceAccountTokenAuthConfig), b.(*ClusterServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterServiceBroker)(nil), (*servicecatalog.ClusterServiceBroker)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker(a.(*ClusterServiceBroker), b.(*servicecatalog.ClusterServiceBroker), scope)
 	}); err != nil {
@@ -306,6 +336,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ConfigMapKeyReference)(nil), (*servicecatalog.ConfigMapKeyReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(a.(*ConfigMapKeyReference), b.(*servicecatalog.ConfigMapKeyReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ConfigMapKeyReference)(nil), (*ConfigMapKeyReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(a.(*servicecatalog.ConfigMapKeyReference), b.(*ConfigMapKeyReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LocalObjectReference)(nil), (*servicecatalog.LocalObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(a.(*LocalObjectReference), b.(*servicecatalog.LocalObjectReference), scope)
 	}); err != nil {
@@ -366,6 +406,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RetryBackoff)(nil), (*servicecatalog.RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RetryBackoff_To_servicecatalog_RetryBackoff(a.(*RetryBackoff), b.(*servicecatalog.RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.RetryBackoff)(nil), (*RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_RetryBackoff_To_v1beta1_RetryBackoff(a.(*servicecatalog.RetryBackoff), b.(*RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecretKeyReference)(nil), (*servicecatalog.SecretKeyReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference(a.(*SecretKeyReference), b.(*servicecatalog.SecretKeyReference), scope)
 	}); err != nil {
@@ -376,6 +426,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SecretParameterReference)(nil), (*servicecatalog.SecretParameterReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecretParameterReference_To_servicecatalog_SecretParameterReference(a.(*SecretParameterReference), b.(*servicecatalog.SecretParameterReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.SecretParameterReference)(nil), (*SecretParameterReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_SecretParameterReference_To_v1beta1_SecretParameterReference(a.(*servicecatalog.SecretParameterReference), b.(*SecretParameterReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecretTransform)(nil), (*servicecatalog.SecretTransform)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(a.(*SecretTransform), b.(*servicecatalog.SecretTransform), scope)
 	}); err != nil {
@@ -386,6 +446,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAccountTokenAuthConfig)(nil), (*servicecatalog.ServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(a.(*ServiceAccountTokenAuthConfig), b.(*servicecatalog.ServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceAccountTokenAuthConfig)(nil), (*ServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(a.(*servicecatalog.ServiceAccountTokenAuthConfig), b.(*ServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceBinding)(nil), (*servicecatalog.ServiceBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServiceBinding_To_servicecatalog_ServiceBinding(a.(*ServiceBinding), b.(*servicecatalog.ServiceBinding), scope)
 	}); err != nil {
@@ -586,6 +656,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceInstanceProvisionMetadata)(nil), (*servicecatalog.ServiceInstanceProvisionMetadata)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceInstanceProvisionMetadata_To_servicecatalog_ServiceInstanceProvisionMetadata(a.(*ServiceInstanceProvisionMetadata), b.(*servicecatalog.ServiceInstanceProvisionMetadata), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceInstanceProvisionMetadata)(nil), (*ServiceInstanceProvisionMetadata)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceInstanceProvisionMetadata_To_v1beta1_ServiceInstanceProvisionMetadata(a.(*servicecatalog.ServiceInstanceProvisionMetadata), b.(*ServiceInstanceProvisionMetadata), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceInstanceSpec)(nil), (*servicecatalog.ServiceInstanceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSpec(a.(*ServiceInstanceSpec), b.(*servicecatalog.ServiceInstanceSpec), scope)
 	}); err != nil {
@@ -606,6 +686,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceInstanceTemplate)(nil), (*servicecatalog.ServiceInstanceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceInstanceTemplate_To_servicecatalog_ServiceInstanceTemplate(a.(*ServiceInstanceTemplate), b.(*servicecatalog.ServiceInstanceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceInstanceTemplate)(nil), (*ServiceInstanceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceInstanceTemplate_To_v1beta1_ServiceInstanceTemplate(a.(*servicecatalog.ServiceInstanceTemplate), b.(*ServiceInstanceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceInstanceTemplateList)(nil), (*servicecatalog.ServiceInstanceTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceInstanceTemplateList_To_servicecatalog_ServiceInstanceTemplateList(a.(*ServiceInstanceTemplateList), b.(*servicecatalog.ServiceInstanceTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceInstanceTemplateList)(nil), (*ServiceInstanceTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceInstanceTemplateList_To_v1beta1_ServiceInstanceTemplateList(a.(*servicecatalog.ServiceInstanceTemplateList), b.(*ServiceInstanceTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceInstanceTemplateSpec)(nil), (*servicecatalog.ServiceInstanceTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec(a.(*ServiceInstanceTemplateSpec), b.(*servicecatalog.ServiceInstanceTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceInstanceTemplateSpec)(nil), (*ServiceInstanceTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec(a.(*servicecatalog.ServiceInstanceTemplateSpec), b.(*ServiceInstanceTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServicePlan)(nil), (*servicecatalog.ServicePlan)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServicePlan_To_servicecatalog_ServicePlan(a.(*ServicePlan), b.(*servicecatalog.ServicePlan), scope)
 	}); err != nil {
@@ -616,6 +726,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServicePlanCost)(nil), (*servicecatalog.ServicePlanCost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServicePlanCost_To_servicecatalog_ServicePlanCost(a.(*ServicePlanCost), b.(*servicecatalog.ServicePlanCost), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServicePlanCost)(nil), (*ServicePlanCost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServicePlanCost_To_v1beta1_ServicePlanCost(a.(*servicecatalog.ServicePlanCost), b.(*ServicePlanCost), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServicePlanList)(nil), (*servicecatalog.ServicePlanList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServicePlanList_To_servicecatalog_ServicePlanList(a.(*ServicePlanList), b.(*servicecatalog.ServicePlanList), scope)
 	}); err != nil {
@@ -745,6 +865,48 @@ func Convert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConf
 	return autoConvert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_BrokerHealthCheck_To_servicecatalog_BrokerHealthCheck(in *BrokerHealthCheck, out *servicecatalog.BrokerHealthCheck, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_BrokerHealthCheck_To_servicecatalog_BrokerHealthCheck is an autogenerated conversion function.
+func Convert_v1beta1_BrokerHealthCheck_To_servicecatalog_BrokerHealthCheck(in *BrokerHealthCheck, out *servicecatalog.BrokerHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_BrokerHealthCheck_To_servicecatalog_BrokerHealthCheck(in, out, s)
+}
+
+func autoConvert_servicecatalog_BrokerHealthCheck_To_v1beta1_BrokerHealthCheck(in *servicecatalog.BrokerHealthCheck, out *BrokerHealthCheck, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_servicecatalog_BrokerHealthCheck_To_v1beta1_BrokerHealthCheck is an autogenerated conversion function.
+func Convert_servicecatalog_BrokerHealthCheck_To_v1beta1_BrokerHealthCheck(in *servicecatalog.BrokerHealthCheck, out *BrokerHealthCheck, s conversion.Scope) error {
+	return autoConvert_servicecatalog_BrokerHealthCheck_To_v1beta1_BrokerHealthCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_BrokerRetryPolicy_To_servicecatalog_BrokerRetryPolicy(in *BrokerRetryPolicy, out *servicecatalog.BrokerRetryPolicy, s conversion.Scope) error {
+	out.Catalog = (*servicecatalog.RetryBackoff)(unsafe.Pointer(in.Catalog))
+	out.Operations = (*servicecatalog.RetryBackoff)(unsafe.Pointer(in.Operations))
+	return nil
+}
+
+// Convert_v1beta1_BrokerRetryPolicy_To_servicecatalog_BrokerRetryPolicy is an autogenerated conversion function.
+func Convert_v1beta1_BrokerRetryPolicy_To_servicecatalog_BrokerRetryPolicy(in *BrokerRetryPolicy, out *servicecatalog.BrokerRetryPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_BrokerRetryPolicy_To_servicecatalog_BrokerRetryPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_BrokerRetryPolicy_To_v1beta1_BrokerRetryPolicy(in *servicecatalog.BrokerRetryPolicy, out *BrokerRetryPolicy, s conversion.Scope) error {
+	out.Catalog = (*RetryBackoff)(unsafe.Pointer(in.Catalog))
+	out.Operations = (*RetryBackoff)(unsafe.Pointer(in.Operations))
+	return nil
+}
+
+// Convert_servicecatalog_BrokerRetryPolicy_To_v1beta1_BrokerRetryPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_BrokerRetryPolicy_To_v1beta1_BrokerRetryPolicy(in *servicecatalog.BrokerRetryPolicy, out *BrokerRetryPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_BrokerRetryPolicy_To_v1beta1_BrokerRetryPolicy(in, out, s)
+}
+
 func autoConvert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(in *CatalogRestrictions, out *servicecatalog.CatalogRestrictions, s conversion.Scope) error {
 	out.ServiceClass = *(*[]string)(unsafe.Pointer(&in.ServiceClass))
 	out.ServicePlan = *(*[]string)(unsafe.Pointer(&in.ServicePlan))
@@ -827,6 +989,28 @@ func Convert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectRefer
 	return autoConvert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in *ClusterServiceAccountTokenAuthConfig, out *servicecatalog.ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	return nil
+}
+
+// Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in *ClusterServiceAccountTokenAuthConfig, out *servicecatalog.ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in *servicecatalog.ClusterServiceAccountTokenAuthConfig, out *ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*ObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in *servicecatalog.ClusterServiceAccountTokenAuthConfig, out *ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker(in *ClusterServiceBroker, out *servicecatalog.ClusterServiceBroker, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ClusterServiceBrokerSpec_To_servicecatalog_ClusterServiceBrokerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -862,6 +1046,7 @@ func Convert_servicecatalog_ClusterServiceBroker_To_v1beta1_ClusterServiceBroker
 func autoConvert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServiceBrokerAuthInfo(in *ClusterServiceBrokerAuthInfo, out *servicecatalog.ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.ServiceAccountToken = (*servicecatalog.ClusterServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -873,6 +1058,7 @@ func Convert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServi
 func autoConvert_servicecatalog_ClusterServiceBrokerAuthInfo_To_v1beta1_ClusterServiceBrokerAuthInfo(in *servicecatalog.ClusterServiceBrokerAuthInfo, out *ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.ServiceAccountToken = (*ClusterServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -1175,6 +1361,11 @@ func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.RetryPolicy = (*servicecatalog.BrokerRetryPolicy)(unsafe.Pointer(in.RetryPolicy))
+	out.Protocol = servicecatalog.ServiceBrokerProtocol(in.Protocol)
+	out.HealthCheck = (*servicecatalog.BrokerHealthCheck)(unsafe.Pointer(in.HealthCheck))
+	out.CatalogRefreshJitterPercent = in.CatalogRefreshJitterPercent
 	return nil
 }
 
@@ -1191,6 +1382,11 @@ func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.RetryPolicy = (*BrokerRetryPolicy)(unsafe.Pointer(in.RetryPolicy))
+	out.Protocol = ServiceBrokerProtocol(in.Protocol)
+	out.HealthCheck = (*BrokerHealthCheck)(unsafe.Pointer(in.HealthCheck))
+	out.CatalogRefreshJitterPercent = in.CatalogRefreshJitterPercent
 	return nil
 }
 
@@ -1201,9 +1397,13 @@ func Convert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonServiceBrok
 
 func autoConvert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServiceBrokerStatus(in *CommonServiceBrokerStatus, out *servicecatalog.CommonServiceBrokerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]servicecatalog.ServiceBrokerCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.ReconciledGeneration = in.ReconciledGeneration
+	out.CurrentOperation = servicecatalog.ServiceBrokerOperation(in.CurrentOperation)
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.LastRelistRequestProcessed = in.LastRelistRequestProcessed
+	out.LastHealthCheckTime = (*v1.Time)(unsafe.Pointer(in.LastHealthCheckTime))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1215,9 +1415,13 @@ func Convert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServiceBr
 
 func autoConvert_servicecatalog_CommonServiceBrokerStatus_To_v1beta1_CommonServiceBrokerStatus(in *servicecatalog.CommonServiceBrokerStatus, out *CommonServiceBrokerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]ServiceBrokerCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.ReconciledGeneration = in.ReconciledGeneration
+	out.CurrentOperation = ServiceBrokerOperation(in.CurrentOperation)
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.LastRelistRequestProcessed = in.LastRelistRequestProcessed
+	out.LastHealthCheckTime = (*v1.Time)(unsafe.Pointer(in.LastHealthCheckTime))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1233,6 +1437,7 @@ func autoConvert_v1beta1_CommonServiceClassSpec_To_servicecatalog_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -1252,6 +1457,7 @@ func autoConvert_servicecatalog_CommonServiceClassSpec_To_v1beta1_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -1297,6 +1503,7 @@ func autoConvert_v1beta1_CommonServicePlanSpec_To_servicecatalog_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.Costs = *(*[]servicecatalog.ServicePlanCost)(unsafe.Pointer(&in.Costs))
 	return nil
 }
 
@@ -1317,6 +1524,7 @@ func autoConvert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.Costs = *(*[]ServicePlanCost)(unsafe.Pointer(&in.Costs))
 	return nil
 }
 
@@ -1345,6 +1553,28 @@ func Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlan
 	return autoConvert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in *ConfigMapKeyReference, out *servicecatalog.ConfigMapKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference is an autogenerated conversion function.
+func Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in *ConfigMapKeyReference, out *servicecatalog.ConfigMapKeyReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in *servicecatalog.ConfigMapKeyReference, out *ConfigMapKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference is an autogenerated conversion function.
+func Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in *servicecatalog.ConfigMapKeyReference, out *ConfigMapKeyReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in, out, s)
+}
+
 func autoConvert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(in *LocalObjectReference, out *servicecatalog.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -1389,6 +1619,7 @@ func Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in *servi
 
 func autoConvert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource(in *ParametersFromSource, out *servicecatalog.ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*servicecatalog.SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ConfigMapKeyRef = (*servicecatalog.ConfigMapKeyReference)(unsafe.Pointer(in.ConfigMapKeyRef))
 	return nil
 }
 
@@ -1399,6 +1630,7 @@ func Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource
 
 func autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in *servicecatalog.ParametersFromSource, out *ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ConfigMapKeyRef = (*ConfigMapKeyReference)(unsafe.Pointer(in.ConfigMapKeyRef))
 	return nil
 }
 
@@ -1414,12 +1646,14 @@ func autoConvert_v1beta1_PlanReference_To_servicecatalog_PlanReference(in *PlanR
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ClusterServiceClassName = in.ClusterServiceClassName
 	out.ClusterServicePlanName = in.ClusterServicePlanName
+	out.ClusterServiceClassSelector = (*v1.LabelSelector)(unsafe.Pointer(in.ClusterServiceClassSelector))
 	out.ServiceClassExternalName = in.ServiceClassExternalName
 	out.ServicePlanExternalName = in.ServicePlanExternalName
 	out.ServiceClassExternalID = in.ServiceClassExternalID
 	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.ServiceClassName = in.ServiceClassName
 	out.ServicePlanName = in.ServicePlanName
+	out.ServiceClassSelector = (*v1.LabelSelector)(unsafe.Pointer(in.ServiceClassSelector))
 	return nil
 }
 
@@ -1435,12 +1669,14 @@ func autoConvert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in *servi
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ClusterServiceClassName = in.ClusterServiceClassName
 	out.ClusterServicePlanName = in.ClusterServicePlanName
+	out.ClusterServiceClassSelector = (*v1.LabelSelector)(unsafe.Pointer(in.ClusterServiceClassSelector))
 	out.ServiceClassExternalName = in.ServiceClassExternalName
 	out.ServicePlanExternalName = in.ServicePlanExternalName
 	out.ServiceClassExternalID = in.ServiceClassExternalID
 	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.ServiceClassName = in.ServiceClassName
 	out.ServicePlanName = in.ServicePlanName
+	out.ServiceClassSelector = (*v1.LabelSelector)(unsafe.Pointer(in.ServiceClassSelector))
 	return nil
 }
 
@@ -1491,6 +1727,28 @@ func Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in
 	return autoConvert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_RetryBackoff_To_servicecatalog_RetryBackoff(in *RetryBackoff, out *servicecatalog.RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	return nil
+}
+
+// Convert_v1beta1_RetryBackoff_To_servicecatalog_RetryBackoff is an autogenerated conversion function.
+func Convert_v1beta1_RetryBackoff_To_servicecatalog_RetryBackoff(in *RetryBackoff, out *servicecatalog.RetryBackoff, s conversion.Scope) error {
+	return autoConvert_v1beta1_RetryBackoff_To_servicecatalog_RetryBackoff(in, out, s)
+}
+
+func autoConvert_servicecatalog_RetryBackoff_To_v1beta1_RetryBackoff(in *servicecatalog.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	return nil
+}
+
+// Convert_servicecatalog_RetryBackoff_To_v1beta1_RetryBackoff is an autogenerated conversion function.
+func Convert_servicecatalog_RetryBackoff_To_v1beta1_RetryBackoff(in *servicecatalog.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	return autoConvert_servicecatalog_RetryBackoff_To_v1beta1_RetryBackoff(in, out, s)
+}
+
 func autoConvert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference(in *SecretKeyReference, out *servicecatalog.SecretKeyReference, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Key = in.Key
@@ -1513,6 +1771,32 @@ func Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference(in
 	return autoConvert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference(in, out, s)
 }
 
+func autoConvert_v1beta1_SecretParameterReference_To_servicecatalog_SecretParameterReference(in *SecretParameterReference, out *servicecatalog.SecretParameterReference, s conversion.Scope) error {
+	if err := Convert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference(&in.SecretKeyRef, &out.SecretKeyRef, s); err != nil {
+		return err
+	}
+	out.PropagateToBindings = in.PropagateToBindings
+	return nil
+}
+
+// Convert_v1beta1_SecretParameterReference_To_servicecatalog_SecretParameterReference is an autogenerated conversion function.
+func Convert_v1beta1_SecretParameterReference_To_servicecatalog_SecretParameterReference(in *SecretParameterReference, out *servicecatalog.SecretParameterReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_SecretParameterReference_To_servicecatalog_SecretParameterReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_SecretParameterReference_To_v1beta1_SecretParameterReference(in *servicecatalog.SecretParameterReference, out *SecretParameterReference, s conversion.Scope) error {
+	if err := Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference(&in.SecretKeyRef, &out.SecretKeyRef, s); err != nil {
+		return err
+	}
+	out.PropagateToBindings = in.PropagateToBindings
+	return nil
+}
+
+// Convert_servicecatalog_SecretParameterReference_To_v1beta1_SecretParameterReference is an autogenerated conversion function.
+func Convert_servicecatalog_SecretParameterReference_To_v1beta1_SecretParameterReference(in *servicecatalog.SecretParameterReference, out *SecretParameterReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_SecretParameterReference_To_v1beta1_SecretParameterReference(in, out, s)
+}
+
 func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *SecretTransform, out *servicecatalog.SecretTransform, s conversion.Scope) error {
 	out.RenameKey = (*servicecatalog.RenameKeyTransform)(unsafe.Pointer(in.RenameKey))
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
@@ -1539,6 +1823,28 @@ func Convert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *servi
 	return autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in *ServiceAccountTokenAuthConfig, out *servicecatalog.ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	return nil
+}
+
+// Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in *ServiceAccountTokenAuthConfig, out *servicecatalog.ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in *servicecatalog.ServiceAccountTokenAuthConfig, out *ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*LocalObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	return nil
+}
+
+// Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in *servicecatalog.ServiceAccountTokenAuthConfig, out *ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBinding_To_servicecatalog_ServiceBinding(in *ServiceBinding, out *servicecatalog.ServiceBinding, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1652,8 +1958,11 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
+	out.SecretNamespace = in.SecretNamespace
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.BindResource = *(*map[string]string)(unsafe.Pointer(&in.BindResource))
 	out.ExternalID = in.ExternalID
+	out.RebindRequests = in.RebindRequests
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
 }
@@ -1670,8 +1979,11 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
+	out.SecretNamespace = in.SecretNamespace
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.BindResource = *(*map[string]string)(unsafe.Pointer(&in.BindResource))
 	out.ExternalID = in.ExternalID
+	out.RebindRequests = in.RebindRequests
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
 }
@@ -1683,6 +1995,7 @@ func Convert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec(in
 
 func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingStatus(in *ServiceBindingStatus, out *servicecatalog.ServiceBindingStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]servicecatalog.ServiceBindingCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.CurrentOperation = servicecatalog.ServiceBindingOperation(in.CurrentOperation)
@@ -1692,6 +2005,10 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RebindCount = in.RebindCount
+	out.ExternalID = in.ExternalID
+	out.RebindExternalID = in.RebindExternalID
+	out.StaleExternalIDs = *(*[]string)(unsafe.Pointer(&in.StaleExternalIDs))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1703,6 +2020,7 @@ func Convert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingStatus
 
 func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingStatus(in *servicecatalog.ServiceBindingStatus, out *ServiceBindingStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]ServiceBindingCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.CurrentOperation = ServiceBindingOperation(in.CurrentOperation)
@@ -1712,6 +2030,10 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RebindCount = in.RebindCount
+	out.ExternalID = in.ExternalID
+	out.RebindExternalID = in.RebindExternalID
+	out.StaleExternalIDs = *(*[]string)(unsafe.Pointer(&in.StaleExternalIDs))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1756,6 +2078,7 @@ func Convert_servicecatalog_ServiceBroker_To_v1beta1_ServiceBroker(in *serviceca
 func autoConvert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthInfo(in *ServiceBrokerAuthInfo, out *servicecatalog.ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.ServiceAccountToken = (*servicecatalog.ServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -1767,6 +2090,7 @@ func Convert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthIn
 func autoConvert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthInfo(in *servicecatalog.ServiceBrokerAuthInfo, out *ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.ServiceAccountToken = (*ServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -2068,6 +2392,7 @@ func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_Servic
 	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
+	out.ContextChecksum = in.ContextChecksum
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
 }
@@ -2084,6 +2409,7 @@ func autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_Servic
 	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
+	out.ContextChecksum = in.ContextChecksum
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
 }
@@ -2093,6 +2419,28 @@ func Convert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceIns
 	return autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceInstancePropertiesState(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceProvisionMetadata_To_servicecatalog_ServiceInstanceProvisionMetadata(in *ServiceInstanceProvisionMetadata, out *servicecatalog.ServiceInstanceProvisionMetadata, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Attributes = *(*map[string]string)(unsafe.Pointer(&in.Attributes))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceProvisionMetadata_To_servicecatalog_ServiceInstanceProvisionMetadata is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceProvisionMetadata_To_servicecatalog_ServiceInstanceProvisionMetadata(in *ServiceInstanceProvisionMetadata, out *servicecatalog.ServiceInstanceProvisionMetadata, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceProvisionMetadata_To_servicecatalog_ServiceInstanceProvisionMetadata(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceProvisionMetadata_To_v1beta1_ServiceInstanceProvisionMetadata(in *servicecatalog.ServiceInstanceProvisionMetadata, out *ServiceInstanceProvisionMetadata, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Attributes = *(*map[string]string)(unsafe.Pointer(&in.Attributes))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceProvisionMetadata_To_v1beta1_ServiceInstanceProvisionMetadata is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceProvisionMetadata_To_v1beta1_ServiceInstanceProvisionMetadata(in *servicecatalog.ServiceInstanceProvisionMetadata, out *ServiceInstanceProvisionMetadata, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceProvisionMetadata_To_v1beta1_ServiceInstanceProvisionMetadata(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSpec(in *ServiceInstanceSpec, out *servicecatalog.ServiceInstanceSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference(&in.PlanReference, &out.PlanReference, s); err != nil {
 		return err
@@ -2103,6 +2451,7 @@ func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSp
 	out.ServicePlanRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ServicePlanRef))
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
+	out.SecretParameterRefs = *(*[]servicecatalog.SecretParameterReference)(unsafe.Pointer(&in.SecretParameterRefs))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
@@ -2124,6 +2473,7 @@ func autoConvert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSp
 	out.ServicePlanRef = (*LocalObjectReference)(unsafe.Pointer(in.ServicePlanRef))
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
+	out.SecretParameterRefs = *(*[]SecretParameterReference)(unsafe.Pointer(&in.SecretParameterRefs))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
@@ -2137,10 +2487,14 @@ func Convert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSpec(i
 
 func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstanceStatus(in *ServiceInstanceStatus, out *servicecatalog.ServiceInstanceStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]servicecatalog.ServiceInstanceCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
+	out.LastOperationDescription = in.LastOperationDescription
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	out.ProvisionMetadata = (*servicecatalog.ServiceInstanceProvisionMetadata)(unsafe.Pointer(in.ProvisionMetadata))
+	out.StateToken = in.StateToken
 	out.CurrentOperation = servicecatalog.ServiceInstanceOperation(in.CurrentOperation)
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.ObservedGeneration = in.ObservedGeneration
@@ -2153,6 +2507,8 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.LastConditionState = in.LastConditionState
 	out.UserSpecifiedPlanName = in.UserSpecifiedPlanName
 	out.UserSpecifiedClassName = in.UserSpecifiedClassName
+	out.AppliedPlanName = in.AppliedPlanName
+	out.AppliedParameterChecksum = in.AppliedParameterChecksum
 	return nil
 }
 
@@ -2163,10 +2519,14 @@ func Convert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstanceStat
 
 func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstanceStatus(in *servicecatalog.ServiceInstanceStatus, out *ServiceInstanceStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]ServiceInstanceCondition)(unsafe.Pointer(&in.Conditions))
+	out.LastConditionUpdateTime = (*v1.Time)(unsafe.Pointer(in.LastConditionUpdateTime))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
+	out.LastOperationDescription = in.LastOperationDescription
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	out.ProvisionMetadata = (*ServiceInstanceProvisionMetadata)(unsafe.Pointer(in.ProvisionMetadata))
+	out.StateToken = in.StateToken
 	out.CurrentOperation = ServiceInstanceOperation(in.CurrentOperation)
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.ObservedGeneration = in.ObservedGeneration
@@ -2179,6 +2539,8 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.LastConditionState = in.LastConditionState
 	out.UserSpecifiedPlanName = in.UserSpecifiedPlanName
 	out.UserSpecifiedClassName = in.UserSpecifiedClassName
+	out.AppliedPlanName = in.AppliedPlanName
+	out.AppliedParameterChecksum = in.AppliedParameterChecksum
 	return nil
 }
 
@@ -2187,6 +2549,82 @@ func Convert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstanceStat
 	return autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstanceStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceTemplate_To_servicecatalog_ServiceInstanceTemplate(in *ServiceInstanceTemplate, out *servicecatalog.ServiceInstanceTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceTemplate_To_servicecatalog_ServiceInstanceTemplate is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceTemplate_To_servicecatalog_ServiceInstanceTemplate(in *ServiceInstanceTemplate, out *servicecatalog.ServiceInstanceTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceTemplate_To_servicecatalog_ServiceInstanceTemplate(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceTemplate_To_v1beta1_ServiceInstanceTemplate(in *servicecatalog.ServiceInstanceTemplate, out *ServiceInstanceTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceTemplate_To_v1beta1_ServiceInstanceTemplate is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceTemplate_To_v1beta1_ServiceInstanceTemplate(in *servicecatalog.ServiceInstanceTemplate, out *ServiceInstanceTemplate, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceTemplate_To_v1beta1_ServiceInstanceTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceTemplateList_To_servicecatalog_ServiceInstanceTemplateList(in *ServiceInstanceTemplateList, out *servicecatalog.ServiceInstanceTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ServiceInstanceTemplate)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceTemplateList_To_servicecatalog_ServiceInstanceTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceTemplateList_To_servicecatalog_ServiceInstanceTemplateList(in *ServiceInstanceTemplateList, out *servicecatalog.ServiceInstanceTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceTemplateList_To_servicecatalog_ServiceInstanceTemplateList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceTemplateList_To_v1beta1_ServiceInstanceTemplateList(in *servicecatalog.ServiceInstanceTemplateList, out *ServiceInstanceTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ServiceInstanceTemplate)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceTemplateList_To_v1beta1_ServiceInstanceTemplateList is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceTemplateList_To_v1beta1_ServiceInstanceTemplateList(in *servicecatalog.ServiceInstanceTemplateList, out *ServiceInstanceTemplateList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceTemplateList_To_v1beta1_ServiceInstanceTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec(in *ServiceInstanceTemplateSpec, out *servicecatalog.ServiceInstanceTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference(&in.PlanReference, &out.PlanReference, s); err != nil {
+		return err
+	}
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec(in *ServiceInstanceTemplateSpec, out *servicecatalog.ServiceInstanceTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceTemplateSpec_To_servicecatalog_ServiceInstanceTemplateSpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec(in *servicecatalog.ServiceInstanceTemplateSpec, out *ServiceInstanceTemplateSpec, s conversion.Scope) error {
+	if err := Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference(&in.PlanReference, &out.PlanReference, s); err != nil {
+		return err
+	}
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec(in *servicecatalog.ServiceInstanceTemplateSpec, out *ServiceInstanceTemplateSpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceTemplateSpec_To_v1beta1_ServiceInstanceTemplateSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_ServicePlan_To_servicecatalog_ServicePlan(in *ServicePlan, out *servicecatalog.ServicePlan, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ServicePlanSpec_To_servicecatalog_ServicePlanSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2219,6 +2657,28 @@ func Convert_servicecatalog_ServicePlan_To_v1beta1_ServicePlan(in *servicecatalo
 	return autoConvert_servicecatalog_ServicePlan_To_v1beta1_ServicePlan(in, out, s)
 }
 
+func autoConvert_v1beta1_ServicePlanCost_To_servicecatalog_ServicePlanCost(in *ServicePlanCost, out *servicecatalog.ServicePlanCost, s conversion.Scope) error {
+	out.Amount = *(*map[string]string)(unsafe.Pointer(&in.Amount))
+	out.Unit = in.Unit
+	return nil
+}
+
+// Convert_v1beta1_ServicePlanCost_To_servicecatalog_ServicePlanCost is an autogenerated conversion function.
+func Convert_v1beta1_ServicePlanCost_To_servicecatalog_ServicePlanCost(in *ServicePlanCost, out *servicecatalog.ServicePlanCost, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServicePlanCost_To_servicecatalog_ServicePlanCost(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServicePlanCost_To_v1beta1_ServicePlanCost(in *servicecatalog.ServicePlanCost, out *ServicePlanCost, s conversion.Scope) error {
+	out.Amount = *(*map[string]string)(unsafe.Pointer(&in.Amount))
+	out.Unit = in.Unit
+	return nil
+}
+
+// Convert_servicecatalog_ServicePlanCost_To_v1beta1_ServicePlanCost is an autogenerated conversion function.
+func Convert_servicecatalog_ServicePlanCost_To_v1beta1_ServicePlanCost(in *servicecatalog.ServicePlanCost, out *ServicePlanCost, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServicePlanCost_To_v1beta1_ServicePlanCost(in, out, s)
+}
+
 func autoConvert_v1beta1_ServicePlanList_To_servicecatalog_ServicePlanList(in *ServicePlanList, out *servicecatalog.ServicePlanList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]servicecatalog.ServicePlan)(unsafe.Pointer(&in.Items))
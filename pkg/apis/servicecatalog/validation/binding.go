@@ -91,8 +91,40 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("secretName"), spec.SecretName, msg))
 	}
 
+	if spec.SecretNamespace != "" {
+		for _, msg := range apivalidation.ValidateNamespaceName(spec.SecretNamespace, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("secretNamespace"), spec.SecretNamespace, msg))
+		}
+	}
+
 	if spec.ParametersFrom != nil {
-		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
+		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath, true)...)
+	}
+
+	allErrs = append(allErrs, validateBindResource(spec.BindResource, fldPath.Child("bindResource"))...)
+
+	if spec.RebindRequests < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("rebindRequests"), spec.RebindRequests, "rebindRequests must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateBindResource validates the keys of the bind resource defined by
+// the OSB API, which must not be empty. The other keys are passed to the
+// broker as they are, and only must not be empty themselves.
+func validateBindResource(bindResource map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for key, value := range bindResource {
+		switch key {
+		case sc.BindResourceAppGUIDKey, sc.BindResourceRouteKey:
+			if value == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Key(key), key+" must not be empty when present"))
+			}
+		case "":
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "keys must not be empty"))
+		}
 	}
 
 	return allErrs
@@ -238,6 +270,12 @@ func internalValidateServiceBindingUpdateAllowed(new *sc.ServiceBinding, old *sc
 func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.ExternalID, old.Spec.ExternalID, field.NewPath("spec").Child("externalID"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.SecretNamespace, old.Spec.SecretNamespace, field.NewPath("spec").Child("secretNamespace"))...)
+	// RebindRequests can be increasing to rebind the binding, or equal to update other fields
+	if new.Spec.RebindRequests < old.Spec.RebindRequests {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rebindRequests"), old.Spec.RebindRequests, "RebindRequests must be strictly increasing"))
+	}
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
 	return allErrs
 }
@@ -128,6 +128,24 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid secretNamespace",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretNamespace = "central-secrets"
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid secretNamespace",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretNamespace = "central.secrets"
+				return b
+			}(),
+			valid: false,
+		},
 		{
 			name: "valid parametersFrom",
 			binding: func() *servicecatalog.ServiceBinding {
@@ -171,6 +189,86 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid configMapKeyRef in parametersFrom",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ConfigMapKeyRef: &servicecatalog.ConfigMapKeyReference{Name: "test-configmap", Key: "test-key"}}}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "configMapKeyRef key is missing in parametersFrom",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ConfigMapKeyRef: &servicecatalog.ConfigMapKeyReference{Name: "test-configmap", Key: ""}}}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "secretKeyRef and configMapKeyRef in the same parametersFrom source",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{{
+						SecretKeyRef:    &servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"},
+						ConfigMapKeyRef: &servicecatalog.ConfigMapKeyReference{Name: "test-configmap", Key: "test-key"},
+					}}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid bindResource",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.BindResource = map[string]string{"app_guid": "test-app", "route": "test.example.com"}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "unknown key in bindResource",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.BindResource = map[string]string{"app_guid": "test-app", "credential_client_id": "test-client"}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "empty app_guid in bindResource",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.BindResource = map[string]string{"app_guid": ""}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty route in bindResource",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.BindResource = map[string]string{"route": ""}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty key in bindResource",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.BindResource = map[string]string{"": "value"}
+				return b
+			}(),
+			valid: false,
+		},
 
 		{
 			name:    "valid with in-progress bind",
@@ -657,3 +755,121 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateServiceBindingUpdateExternalID(t *testing.T) {
+	cases := []struct {
+		name       string
+		externalID string
+		valid      bool
+	}{
+		{
+			name:       "unchanged external ID",
+			externalID: "external-id",
+			valid:      true,
+		},
+		{
+			name:       "changed external ID",
+			externalID: "other-external-id",
+			valid:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldBinding := validServiceBinding()
+			oldBinding.Spec.ExternalID = "external-id"
+
+			newBinding := validServiceBinding()
+			newBinding.Spec.ExternalID = tc.externalID
+
+			errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
+
+func TestValidateServiceBindingUpdateSecretNamespace(t *testing.T) {
+	cases := []struct {
+		name            string
+		secretNamespace string
+		valid           bool
+	}{
+		{
+			name:            "unchanged secret namespace",
+			secretNamespace: "central-secrets",
+			valid:           true,
+		},
+		{
+			name:            "changed secret namespace",
+			secretNamespace: "other-secrets",
+			valid:           false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldBinding := validServiceBinding()
+			oldBinding.Spec.SecretNamespace = "central-secrets"
+
+			newBinding := validServiceBinding()
+			newBinding.Spec.SecretNamespace = tc.secretNamespace
+
+			errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
+
+func TestValidateServiceBindingUpdateRebindRequests(t *testing.T) {
+	cases := []struct {
+		name           string
+		rebindRequests int64
+		valid          bool
+	}{
+		{
+			name:           "unchanged rebind requests",
+			rebindRequests: 1,
+			valid:          true,
+		},
+		{
+			name:           "increased rebind requests",
+			rebindRequests: 2,
+			valid:          true,
+		},
+		{
+			name:           "decreased rebind requests",
+			rebindRequests: 0,
+			valid:          false,
+		},
+		{
+			name:           "negative rebind requests",
+			rebindRequests: -1,
+			valid:          false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldBinding := validServiceBinding()
+			oldBinding.Spec.RebindRequests = 1
+
+			newBinding := validServiceBinding()
+			newBinding.Spec.RebindRequests = tc.rebindRequests
+
+			errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +28,7 @@ import (
 
 	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/filter"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util"
 )
 
 // validateCommonServiceBrokerName is the validation function for common
@@ -81,6 +85,27 @@ func validateClusterServiceBrokerSpec(spec *sc.ClusterServiceBrokerSpec, fldPath
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.ServiceAccountToken != nil {
+			saRef := spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+			if saRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(saRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "namespace"), saRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.ValidateServiceAccountName(saRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "name"), saRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef"), "a service account is required"),
+				)
+			}
+			if spec.AuthInfo.ServiceAccountToken.Audience == "" {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "audience"), "the audience of the token is required"),
+				)
+			}
 		} else {
 			// Authentication
 			allErrs = append(
@@ -143,6 +168,24 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.ServiceAccountToken != nil {
+			saRef := spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+			if saRef != nil {
+				for _, msg := range apivalidation.ValidateServiceAccountName(saRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "name"), saRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef"), "a service account is required"),
+				)
+			}
+			if spec.AuthInfo.ServiceAccountToken.Audience == "" {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "audience"), "the audience of the token is required"),
+				)
+			}
 		} else {
 			// Authentication
 			allErrs = append(
@@ -207,6 +250,11 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
+	if spec.CatalogRefreshJitterPercent < 0 || spec.CatalogRefreshJitterPercent > 99 {
+		commonErrs = append(commonErrs,
+			field.Invalid(fldPath.Child("catalogRefreshJitterPercent"), spec.CatalogRefreshJitterPercent, "catalogRefreshJitterPercent must be between 0 and 99"))
+	}
+
 	if spec.CatalogRestrictions != nil && len(spec.CatalogRestrictions.ServiceClass) > 0 {
 		// confirm that the restrictions can turn into a predicate.
 		_, err := filter.CreatePredicate(spec.CatalogRestrictions.ServiceClass)
@@ -246,9 +294,81 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
+	if len(spec.UserAgentSuffix) > maxUserAgentSuffixLength {
+		commonErrs = append(commonErrs,
+			field.TooLong(fldPath.Child("userAgentSuffix"), spec.UserAgentSuffix, maxUserAgentSuffixLength))
+	} else if !util.IsPrintableASCII(spec.UserAgentSuffix) {
+		commonErrs = append(commonErrs,
+			field.Invalid(fldPath.Child("userAgentSuffix"), spec.UserAgentSuffix, "userAgentSuffix must only contain printable ASCII characters"))
+	}
+
+	if spec.RetryPolicy != nil {
+		commonErrs = append(commonErrs, validateRetryBackoff(spec.RetryPolicy.Catalog, fldPath.Child("retryPolicy", "catalog"))...)
+		commonErrs = append(commonErrs, validateRetryBackoff(spec.RetryPolicy.Operations, fldPath.Child("retryPolicy", "operations"))...)
+	}
+
+	if spec.HealthCheck != nil && spec.HealthCheck.Path != "" {
+		if u, err := url.Parse(spec.HealthCheck.Path); err != nil || !strings.HasPrefix(spec.HealthCheck.Path, "/") || u.IsAbs() || u.Host != "" {
+			commonErrs = append(commonErrs,
+				field.Invalid(fldPath.Child("healthCheck", "path"), spec.HealthCheck.Path, "path must be an absolute path, relative to the url of the broker"))
+		}
+	}
+
+	switch spec.Protocol {
+	case "", sc.ServiceBrokerProtocolHTTP:
+	case sc.ServiceBrokerProtocolGRPC:
+		// the gRPC adapter dials the host of the URL, with TLS unless
+		// the scheme is http
+		if u, err := url.Parse(spec.URL); spec.URL != "" && (err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "") {
+			commonErrs = append(commonErrs,
+				field.Invalid(fldPath.Child("url"), spec.URL, "the url of a GRPC broker must be an http or https url with a host"))
+		}
+	default:
+		commonErrs = append(commonErrs,
+			field.NotSupported(fldPath.Child("protocol"), spec.Protocol, []string{string(sc.ServiceBrokerProtocolHTTP), string(sc.ServiceBrokerProtocolGRPC)}))
+	}
+
 	return commonErrs
 }
 
+// maxRetryDelay is the maximum delay that a broker can configure between two
+// retries of a failed request.
+const maxRetryDelay = 24 * time.Hour
+
+func validateRetryBackoff(backoff *sc.RetryBackoff, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if backoff == nil {
+		return allErrs
+	}
+
+	for _, d := range []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"initialDelay", backoff.InitialDelay},
+		{"maxDelay", backoff.MaxDelay},
+	} {
+		if d.duration == nil {
+			continue
+		}
+		if d.duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(d.name), d.duration.Duration.String(), d.name+" must be greater than zero"))
+		} else if d.duration.Duration > maxRetryDelay {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(d.name), d.duration.Duration.String(), fmt.Sprintf("%s must be at most %v", d.name, maxRetryDelay)))
+		}
+	}
+
+	if backoff.InitialDelay != nil && backoff.MaxDelay != nil && backoff.InitialDelay.Duration > backoff.MaxDelay.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialDelay"), backoff.InitialDelay.Duration.String(), "initialDelay must not be greater than maxDelay"))
+	}
+
+	return allErrs
+}
+
+// maxUserAgentSuffixLength is the maximum length of the userAgentSuffix of a
+// broker, which is sent as part of a header with every request to the broker.
+const maxUserAgentSuffixLength = 256
+
 // ValidateClusterServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateClusterServiceBrokerUpdate(new *sc.ClusterServiceBroker, old *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
@@ -18,11 +18,14 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"sigs.k8s.io/yaml"
@@ -93,8 +96,9 @@ func validateServiceInstanceSpec(spec *sc.ServiceInstanceSpec, fldPath *field.Pa
 	allErrs = append(allErrs, validatePlanReference(&spec.PlanReference, fldPath)...)
 
 	if spec.ParametersFrom != nil {
-		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
+		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath, false)...)
 	}
+	allErrs = append(allErrs, validateSecretParameterRefs(spec.SecretParameterRefs, fldPath.Child("secretParameterRefs"))...)
 	if spec.Parameters != nil {
 		if len(spec.Parameters.Raw) == 0 {
 			allErrs = append(allErrs, field.Required(fldPath.Child("parameters"), "inline parameters must not be empty if present"))
@@ -449,52 +453,78 @@ type scopedRefHelper struct {
 	externalPlanID    string
 	k8sClass          string
 	k8sPlan           string
+	classSelector     *metav1.LabelSelector
 	classField        func(string) string
 	planField         func(string) string
 }
 
+// planReferenceField is a field of a plan reference and its value.
+type planReferenceField struct {
+	name  string
+	value string
+}
+
+// setPlanReferenceFields returns the fields that are set.
+func setPlanReferenceFields(fields ...planReferenceField) []planReferenceField {
+	set := []planReferenceField{}
+	for _, f := range fields {
+		if f.value != "" {
+			set = append(set, f)
+		}
+	}
+	return set
+}
+
+// planReferenceFieldNames joins the names of the fields for error messages.
+func planReferenceFieldNames(fields []planReferenceField) string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// labelSelectorValue returns the value of a class selector field for
+// setPlanReferenceFields: the formatted selector, or an empty string when the
+// selector is not set.
+func labelSelectorValue(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
 func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.ErrorList {
 	var errMsg string
 	allErrs := field.ErrorList{}
 
 	// Verify an instance refs either cluster *or* namespaced types, but not both.
-	cases := []struct {
-		cluster string
-		ns      string
-		field   string
-	}{
-		{p.ClusterServiceClassExternalName, p.ServiceClassExternalName, "serviceClassExternalName"},
-		{p.ClusterServiceClassExternalID, p.ServiceClassExternalID, "serviceClassExternalID"},
-		{p.ClusterServiceClassName, p.ServiceClassName, "serviceClassName"},
-		{p.ClusterServicePlanExternalName, p.ServicePlanExternalName, "servicePlanExternalName"},
-		{p.ClusterServicePlanExternalID, p.ServicePlanExternalID, "servicePlanExternalID"},
-		{p.ClusterServicePlanName, p.ServicePlanName, "servicePlanName"},
-	}
-
-	var clusterCount, nsCount uint8
-	for _, test := range cases {
-		if test.ns != "" {
-			nsCount++
-		}
-		if test.cluster != "" {
-			clusterCount++
-		}
-	}
+	clusterRefs := setPlanReferenceFields(
+		planReferenceField{"clusterServiceClassExternalName", p.ClusterServiceClassExternalName},
+		planReferenceField{"clusterServiceClassExternalID", p.ClusterServiceClassExternalID},
+		planReferenceField{"clusterServiceClassName", p.ClusterServiceClassName},
+		planReferenceField{"clusterServiceClassSelector", labelSelectorValue(p.ClusterServiceClassSelector)},
+		planReferenceField{"clusterServicePlanExternalName", p.ClusterServicePlanExternalName},
+		planReferenceField{"clusterServicePlanExternalID", p.ClusterServicePlanExternalID},
+		planReferenceField{"clusterServicePlanName", p.ClusterServicePlanName},
+	)
+	nsRefs := setPlanReferenceFields(
+		planReferenceField{"serviceClassExternalName", p.ServiceClassExternalName},
+		planReferenceField{"serviceClassExternalID", p.ServiceClassExternalID},
+		planReferenceField{"serviceClassName", p.ServiceClassName},
+		planReferenceField{"serviceClassSelector", labelSelectorValue(p.ServiceClassSelector)},
+		planReferenceField{"servicePlanExternalName", p.ServicePlanExternalName},
+		planReferenceField{"servicePlanExternalID", p.ServicePlanExternalID},
+		planReferenceField{"servicePlanName", p.ServicePlanName},
+	)
+	clusterCount, nsCount := len(clusterRefs), len(nsRefs)
 
 	if clusterCount > 0 && nsCount > 0 {
-		errMsg = "instances can only refer to a cluster or namespaced class or plan type, but not both"
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassExternalName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassExternalID"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServicePlanExternalName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServicePlanExternalID"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServicePlanName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassExternalName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassExternalID"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanExternalName"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanExternalID"), "", errMsg))
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanName"), "", errMsg))
+		conflicting := append(clusterRefs, nsRefs...)
+		errMsg = fmt.Sprintf("instances can only refer to a cluster or namespaced class or plan type, but not both; %s are set", planReferenceFieldNames(conflicting))
+		for _, f := range conflicting {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f.name), f.value, errMsg))
+		}
 		return allErrs
 	}
 
@@ -503,9 +533,11 @@ func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.Error
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassExternalName"), errMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassExternalID"), errMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassName"), errMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassSelector"), errMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassExternalName"), errMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassExternalID"), errMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassName"), errMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassSelector"), errMsg))
 		return allErrs
 	}
 
@@ -525,6 +557,7 @@ func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.Error
 		refHelper.externalPlanID = p.ClusterServicePlanExternalID
 		refHelper.k8sClass = p.ClusterServiceClassName
 		refHelper.k8sPlan = p.ClusterServicePlanName
+		refHelper.classSelector = p.ClusterServiceClassSelector
 		refHelper.classField = func(f string) string {
 			return fmt.Sprintf("clusterServiceClass%s", f)
 		}
@@ -538,6 +571,7 @@ func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.Error
 		refHelper.externalPlanID = p.ServicePlanExternalID
 		refHelper.k8sClass = p.ServiceClassName
 		refHelper.k8sPlan = p.ServicePlanName
+		refHelper.classSelector = p.ServiceClassSelector
 		refHelper.classField = func(f string) string {
 			return fmt.Sprintf("serviceClass%s", f)
 		}
@@ -552,13 +586,6 @@ func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.Error
 func validateScopedPlanRef(h scopedRefHelper, p *sc.PlanReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	// helper function to test that exactly one set of plan references are set
-	b2i := func(b bool) int8 {
-		if b {
-			return 1
-		}
-		return 0
-	}
 	// Just to make reading of the conditionals in the code easier.
 	externalClassNameSet := h.externalClassName != ""
 	externalPlanNameSet := h.externalPlanName != ""
@@ -566,26 +593,44 @@ func validateScopedPlanRef(h scopedRefHelper, p *sc.PlanReference, fldPath *fiel
 	externalPlanIDSet := h.externalPlanID != ""
 	k8sClassSet := h.k8sClass != ""
 	k8sPlanSet := h.k8sPlan != ""
-
-	// Must specify exactly one source of the class: external id, external name, k8s name.
-	if (b2i(externalClassNameSet) + b2i(externalClassIDSet) + b2i(k8sClassSet)) != 1 {
-		classSetErrMsg := fmt.Sprintf("exactly one of %s, %s, or %s required",
-			h.classField("ExternalName"), h.classField("ExternalID"), h.classField("Name"))
+	classSelectorSet := h.classSelector != nil
+
+	// Must specify exactly one source of the class: external id, external name, k8s name, selector.
+	// When several are set, only the conflicting fields are reported.
+	classSetErrMsg := fmt.Sprintf("exactly one of %s, %s, %s, or %s required",
+		h.classField("ExternalName"), h.classField("ExternalID"), h.classField("Name"), h.classField("Selector"))
+	classRefs := setPlanReferenceFields(
+		planReferenceField{h.classField("ExternalName"), h.externalClassName},
+		planReferenceField{h.classField("ExternalID"), h.externalClassID},
+		planReferenceField{h.classField("Name"), h.k8sClass},
+		planReferenceField{h.classField("Selector"), labelSelectorValue(h.classSelector)},
+	)
+	if len(classRefs) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child(h.classField("ExternalName")), classSetErrMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child(h.classField("ExternalID")), classSetErrMsg))
 		allErrs = append(allErrs, field.Required(fldPath.Child(h.classField("Name")), classSetErrMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child(h.classField("Selector")), classSetErrMsg))
+	} else if len(classRefs) > 1 {
+		errMsg := fmt.Sprintf("%s, but %s are set", classSetErrMsg, planReferenceFieldNames(classRefs))
+		for _, f := range classRefs {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f.name), f.value, errMsg))
+		}
 	}
 
 	// Must specify zero or one source of the plan: external id, external name, k8s name.
 	// If Zero, assume there is a "default plan" and the defaultserviceplan admission controller
 	// will set it up or error out
 	// Must specify exactly one source of the plan: external id, external name, k8s name.
-	if (b2i(externalPlanNameSet) + b2i(externalPlanIDSet) + b2i(k8sPlanSet)) > 1 {
-		planSetErrMsg := fmt.Sprintf("exactly one of %s, %s, or %s required",
-			h.planField("ExternalName"), h.planField("ExternalID"), h.planField("Name"))
-		allErrs = append(allErrs, field.Required(fldPath.Child(h.planField("ExternalName")), planSetErrMsg))
-		allErrs = append(allErrs, field.Required(fldPath.Child(h.planField("ExternalID")), planSetErrMsg))
-		allErrs = append(allErrs, field.Required(fldPath.Child(h.planField("Name")), planSetErrMsg))
+	if planRefs := setPlanReferenceFields(
+		planReferenceField{h.planField("ExternalName"), h.externalPlanName},
+		planReferenceField{h.planField("ExternalID"), h.externalPlanID},
+		planReferenceField{h.planField("Name"), h.k8sPlan},
+	); len(planRefs) > 1 {
+		errMsg := fmt.Sprintf("exactly one of %s, %s, or %s required, but %s are set",
+			h.planField("ExternalName"), h.planField("ExternalID"), h.planField("Name"), planReferenceFieldNames(planRefs))
+		for _, f := range planRefs {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f.name), f.value, errMsg))
+		}
 	}
 
 	var errMsg string
@@ -637,6 +682,26 @@ func validateScopedPlanRef(h scopedRefHelper, p *sc.PlanReference, fldPath *fiel
 				allErrs = append(allErrs, field.Invalid(fldPath.Child(h.planField("Name")), h.k8sPlan, msg))
 			}
 		}
+	} else if classSelectorSet {
+		selectorPath := fldPath.Child(h.classField("Selector"))
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(h.classSelector, selectorPath)...)
+		// An empty selector would select any class
+		if len(h.classSelector.MatchLabels) == 0 && len(h.classSelector.MatchExpressions) == 0 {
+			allErrs = append(allErrs, field.Invalid(selectorPath, labelSelectorValue(h.classSelector), "must not be empty"))
+		}
+
+		// If ClassSelector given, must use PlanExternalName or not specify the plan:
+		// the other plan fields are specific to the broker of the class
+		if !externalPlanNameSet {
+			if externalPlanIDSet || k8sPlanSet {
+				errMsg = fmt.Sprintf("must specify %s with %s", h.planField("ExternalName"), h.classField("Selector"))
+				allErrs = append(allErrs, field.Required(fldPath.Child(h.planField("ExternalName")), errMsg))
+			}
+		} else {
+			for _, msg := range validateCommonServicePlanName(h.externalPlanName, false /* prefix */) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(h.planField("ExternalName")), h.externalPlanName, msg))
+			}
+		}
 	}
 
 	return allErrs
@@ -649,9 +714,11 @@ func validatePlanReferenceUpdate(pOld *sc.PlanReference, pNew *sc.PlanReference,
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassExternalName, pOld.ClusterServiceClassExternalName, field.NewPath("spec").Child("clusterServiceClassExternalName"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassExternalID, pOld.ClusterServiceClassExternalID, field.NewPath("spec").Child("clusterServiceClassExternalID"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassName, pOld.ClusterServiceClassName, field.NewPath("spec").Child("clusterServiceClassName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassSelector, pOld.ClusterServiceClassSelector, field.NewPath("spec").Child("clusterServiceClassSelector"))...)
 
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassExternalName, pOld.ServiceClassExternalName, field.NewPath("spec").Child("serviceClassExternalName"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassExternalID, pOld.ServiceClassExternalID, field.NewPath("spec").Child("serviceClassExternalID"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassName, pOld.ServiceClassName, field.NewPath("spec").Child("serviceClassName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassSelector, pOld.ServiceClassSelector, field.NewPath("spec").Child("serviceClassSelector"))...)
 	return allErrs
 }
@@ -87,6 +87,24 @@ func validPlanReferenceServiceK8S() servicecatalog.PlanReference {
 	}
 }
 
+func validPlanReferenceClusterServiceSelector() servicecatalog.PlanReference {
+	return servicecatalog.PlanReference{
+		ClusterServiceClassSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"database": "postgres"},
+		},
+		ClusterServicePlanExternalName: clusterServicePlanExternalName,
+	}
+}
+
+func validPlanReferenceServiceSelector() servicecatalog.PlanReference {
+	return servicecatalog.PlanReference{
+		ServiceClassSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"database": "postgres"},
+		},
+		ServicePlanExternalName: servicePlanExternalName,
+	}
+}
+
 func validServiceInstanceForCreateClusterPlanRef() *servicecatalog.ServiceInstance {
 	return &servicecatalog.ServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -266,6 +284,17 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "configMapKeyRef in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ConfigMapKeyRef: &servicecatalog.ConfigMapKeyReference{Name: "test-configmap", Key: "test-key"}}}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing key reference in parametersFrom",
 			instance: func() *servicecatalog.ServiceInstance {
@@ -298,6 +327,52 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid secretParameterRefs",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.SecretParameterRefs = []servicecatalog.SecretParameterReference{
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"}},
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "other-key"}, PropagateToBindings: true},
+				}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "secret name is missing in secretParameterRefs",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.SecretParameterRefs = []servicecatalog.SecretParameterReference{
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "", Key: "test-key"}},
+				}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "key is missing in secretParameterRefs",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.SecretParameterRefs = []servicecatalog.SecretParameterReference{
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "test-key-name", Key: ""}},
+				}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate secretParameterRefs",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.SecretParameterRefs = []servicecatalog.SecretParameterReference{
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"}},
+					{SecretKeyRef: servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"}, PropagateToBindings: true},
+				}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid with in-progress provision",
 			instance: validServiceInstanceWithInProgressProvision(),
@@ -1731,6 +1806,78 @@ func TestValidatePlanReference(t *testing.T) {
 			ref:   validPlanReferenceServiceK8S(),
 			valid: true,
 		},
+		{
+			name:  "valid -- cluster class selector",
+			ref:   validPlanReferenceClusterServiceSelector(),
+			valid: true,
+		},
+		{
+			name:  "valid -- class selector",
+			ref:   validPlanReferenceServiceSelector(),
+			valid: true,
+		},
+		{
+			name: "valid -- cluster class selector without plan",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "database",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"postgres", "postgresql"},
+					}},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid -- cluster class selector, k8s plan",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassSelector: validPlanReferenceClusterServiceSelector().ClusterServiceClassSelector,
+				ClusterServicePlanName:      clusterServicePlanName,
+			},
+			valid:         false,
+			expectedError: "must specify clusterServicePlanExternalName with clusterServiceClassSelector",
+		},
+		{
+			name: "invalid -- class selector, external plan id",
+			ref: servicecatalog.PlanReference{
+				ServiceClassSelector:  validPlanReferenceServiceSelector().ServiceClassSelector,
+				ServicePlanExternalID: servicePlanExternalID,
+			},
+			valid:         false,
+			expectedError: "must specify servicePlanExternalName with serviceClassSelector",
+		},
+		{
+			name: "invalid -- empty cluster class selector",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassSelector:    &metav1.LabelSelector{},
+				ClusterServicePlanExternalName: clusterServicePlanExternalName,
+			},
+			valid:         false,
+			expectedError: "must not be empty",
+		},
+		{
+			name: "invalid -- malformed class selector",
+			ref: servicecatalog.PlanReference{
+				ServiceClassSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "database",
+						Operator: metav1.LabelSelectorOpIn,
+					}},
+				},
+			},
+			valid:         false,
+			expectedError: "must be specified when `operator` is 'In' or 'NotIn'",
+		},
+		{
+			name: "invalid -- cluster class selector, external class name",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassSelector:     validPlanReferenceClusterServiceSelector().ClusterServiceClassSelector,
+				ClusterServiceClassExternalName: clusterServiceClassExternalName,
+			},
+			valid:         false,
+			expectedError: "exactly one of clusterServiceClassExternalName, clusterServiceClassExternalID, clusterServiceClassName, or clusterServiceClassSelector required",
+		},
 		{
 			name: "invalid -- cluster external class name, k8s plan",
 			ref: servicecatalog.PlanReference{
@@ -1960,6 +2107,85 @@ func TestValidatePlanReference(t *testing.T) {
 	}
 }
 
+// TestValidatePlanReferenceConflictingFields tests that conflicting plan
+// references are reported on exactly the fields that are set.
+func TestValidatePlanReferenceConflictingFields(t *testing.T) {
+	cases := []struct {
+		name   string
+		ref    servicecatalog.PlanReference
+		fields []string
+	}{
+		{
+			name: "cluster external class name and k8s class name",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: clusterServiceClassExternalName,
+				ClusterServiceClassName:         clusterServiceClassName,
+			},
+			fields: []string{"spec.clusterServiceClassExternalName", "spec.clusterServiceClassName"},
+		},
+		{
+			name: "all cluster class references",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: clusterServiceClassExternalName,
+				ClusterServiceClassExternalID:   clusterServiceClassExternalID,
+				ClusterServiceClassName:         clusterServiceClassName,
+			},
+			fields: []string{"spec.clusterServiceClassExternalName", "spec.clusterServiceClassExternalID", "spec.clusterServiceClassName"},
+		},
+		{
+			name: "external plan id and k8s plan name",
+			ref: servicecatalog.PlanReference{
+				ServiceClassName:      serviceClassName,
+				ServicePlanExternalID: servicePlanExternalID,
+				ServicePlanName:       servicePlanName,
+			},
+			fields: []string{"spec.servicePlanExternalID", "spec.servicePlanName"},
+		},
+		{
+			name: "cluster class and namespaced plan",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassName: clusterServiceClassName,
+				ServicePlanName:         servicePlanName,
+			},
+			fields: []string{"spec.clusterServiceClassName", "spec.servicePlanName"},
+		},
+		{
+			name: "k8s class name and class selector",
+			ref: servicecatalog.PlanReference{
+				ServiceClassName:     serviceClassName,
+				ServiceClassSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"database": "postgres"}},
+			},
+			fields: []string{"spec.serviceClassName", "spec.serviceClassSelector"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validatePlanReference(&tc.ref, field.NewPath("spec"))
+			fields := []string{}
+			for _, e := range errs {
+				if e.Type != field.ErrorTypeInvalid {
+					t.Errorf("unexpected error: %v", e)
+				}
+				if !strings.Contains(e.Detail, strings.Join(trimSpecPrefix(tc.fields), ", ")+" are set") {
+					t.Errorf("error %q does not name the conflicting fields %v", e.Detail, tc.fields)
+				}
+				fields = append(fields, e.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.fields) {
+				t.Errorf("expected errors for the fields %v, got %v", tc.fields, fields)
+			}
+		})
+	}
+}
+
+func trimSpecPrefix(fields []string) []string {
+	trimmed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		trimmed = append(trimmed, strings.TrimPrefix(f, "spec."))
+	}
+	return trimmed
+}
+
 func TestValidatePlanReferenceUpdate(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -2037,6 +2263,27 @@ func TestValidatePlanReferenceUpdate(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "invalid -- changing cluster class selector",
+			old:  validPlanReferenceClusterServiceSelector(),
+			new: servicecatalog.PlanReference{
+				ClusterServiceClassSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"database": "mysql"},
+				},
+				ClusterServicePlanExternalName: clusterServicePlanExternalName,
+			},
+			valid:         false,
+			expectedError: "clusterServiceClassSelector",
+		},
+		{
+			name: "valid -- changing plan of class selector",
+			old:  validPlanReferenceServiceSelector(),
+			new: servicecatalog.PlanReference{
+				ServiceClassSelector:    validPlanReferenceServiceSelector().ServiceClassSelector,
+				ServicePlanExternalName: "new-plan",
+			},
+			valid: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
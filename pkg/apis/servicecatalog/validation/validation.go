@@ -28,21 +28,59 @@ func stringIsHexadecimal(s string) bool {
 	return hexademicalStringRegexp.MatchString(s)
 }
 
-func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldPath *field.Path) field.ErrorList {
+// validateParametersFromSource validates the sources of parametersFrom. Each
+// source must set exactly one reference; ConfigMap references are only
+// allowed when allowConfigMaps is true.
+func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldPath *field.Path, allowConfigMaps bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for _, paramsFrom := range parametersFrom {
-		if paramsFrom.SecretKeyRef != nil {
+		switch {
+		case paramsFrom.SecretKeyRef != nil && paramsFrom.ConfigMapKeyRef != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parametersFrom"), "", "secretKeyRef and configMapKeyRef can not both be set in the same source"))
+		case paramsFrom.SecretKeyRef != nil:
 			if paramsFrom.SecretKeyRef.Name == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.name"), "name is required"))
 			}
 			if paramsFrom.SecretKeyRef.Key == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.key"), "key is required"))
 			}
-		} else {
+		case paramsFrom.ConfigMapKeyRef != nil:
+			if !allowConfigMaps {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("parametersFrom.configMapKeyRef"), "configMapKeyRef is only supported in the parametersFrom of ServiceBindings"))
+				continue
+			}
+			if paramsFrom.ConfigMapKeyRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.configMapKeyRef.name"), "name is required"))
+			}
+			if paramsFrom.ConfigMapKeyRef.Key == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.configMapKeyRef.key"), "key is required"))
+			}
+		default:
 			allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom"), "source must not be empty if present"))
 		}
 	}
 
 	return allErrs
 }
+
+func validateSecretParameterRefs(refs []sc.SecretParameterReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := map[sc.SecretKeyReference]bool{}
+	for i, ref := range refs {
+		refPath := fldPath.Index(i).Child("secretKeyRef")
+		if ref.SecretKeyRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "name is required"))
+		}
+		if ref.SecretKeyRef.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), "key is required"))
+		}
+		if seen[ref.SecretKeyRef] {
+			allErrs = append(allErrs, field.Duplicate(refPath, ref.SecretKeyRef))
+		}
+		seen[ref.SecretKeyRef] = true
+	}
+
+	return allErrs
+}
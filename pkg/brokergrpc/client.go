@@ -0,0 +1,268 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokergrpc implements the Open Service Broker API client interface
+// for brokers that expose the operations of the API as a gRPC service, so
+// that the controller can reconcile them without an HTTP shim.
+//
+// The service is named ServiceName and has one unary method per operation of
+// the client interface, e.g. /servicecatalog.osb.v2.ServiceBroker/Bind. The
+// messages are the JSON documents of the requests and responses of the API,
+// exchanged with the "json" content subtype, and the originating identity is
+// part of the request message. The API version and the credentials of the
+// broker are sent in the X-Broker-API-Version and Authorization metadata.
+package brokergrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+// ServiceName is the name of the gRPC service that the brokers implement.
+const ServiceName = "servicecatalog.osb.v2.ServiceBroker"
+
+const (
+	apiVersionMetadata    = "x-broker-api-version"
+	authorizationMetadata = "authorization"
+)
+
+// client is an osb.Client that calls the operations of a broker over gRPC.
+type client struct {
+	conn       *grpc.ClientConn
+	apiVersion osb.APIVersion
+	authConfig *osb.AuthConfig
+	timeout    time.Duration
+}
+
+var _ osb.Client = &client{}
+
+// Options are the settings of the connection of a client that are not part
+// of the osb.ClientConfiguration.
+type Options struct {
+	// UserAgent is the user agent of the connection to the broker. If
+	// empty, the default of gRPC is sent.
+	UserAgent string
+	// URLPolicy, if not nil, refuses the connections to the addresses it
+	// denies.
+	URLPolicy *brokerurl.Policy
+}
+
+// NewClient creates the client of a broker whose protocol is GRPC. It dials the host of the URL of the configuration, with TLS unless the
+// scheme of the URL is http. The connection is established lazily, by the
+// first call to the broker.
+func NewClient(config *osb.ClientConfiguration, options Options) (osb.Client, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker URL %q: %v", config.URL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("broker URL %q has no host", config.URL)
+	}
+
+	if config.AuthConfig != nil {
+		if config.AuthConfig.BasicAuthConfig == nil && config.AuthConfig.BearerConfig == nil {
+			return nil, errors.New("Non-nil AuthConfig cannot be empty")
+		}
+		if config.AuthConfig.BasicAuthConfig != nil && config.AuthConfig.BearerConfig != nil {
+			return nil, errors.New("Only one AuthConfig implementation must be set at a time")
+		}
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(codecName)),
+	}
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, grpc.WithInsecure())
+	case "https":
+		tlsConfig, err := newTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	default:
+		return nil, fmt.Errorf("broker URL %q must have the http or https scheme", config.URL)
+	}
+	if options.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(options.UserAgent))
+	}
+	if options.URLPolicy != nil {
+		opts = append(opts, grpc.WithDialer(func(address string, timeout time.Duration) (net.Conn, error) {
+			return options.URLPolicy.DialContext(&net.Dialer{Timeout: timeout})(context.Background(), "tcp", address)
+		}))
+	}
+
+	conn, err := grpc.Dial(u.Host, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		conn:       conn,
+		apiVersion: config.APIVersion,
+		authConfig: config.AuthConfig,
+		timeout:    time.Duration(config.TimeoutSeconds) * time.Second,
+	}
+	// The controller replaces the client of a broker without closing it,
+	// like the HTTP clients whose idle connections time out, so the
+	// connection is closed once the client is no longer referenced.
+	runtime.SetFinalizer(c, func(c *client) {
+		c.conn.Close()
+	})
+	return c, nil
+}
+
+// newTLSConfig returns the TLS configuration of the connection to the
+// broker, which is built like the one of the HTTP client.
+func newTLSConfig(config *osb.ClientConfiguration) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if config.TLSConfig != nil {
+		tlsConfig = config.TLSConfig.Clone()
+	}
+	if config.Insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if len(config.CAData) != 0 {
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+		tlsConfig.RootCAs.AppendCertsFromPEM(config.CAData)
+	}
+	if tlsConfig.InsecureSkipVerify && tlsConfig.RootCAs != nil {
+		return nil, errors.New("Cannot specify root CAs and to skip TLS verification")
+	}
+	return tlsConfig, nil
+}
+
+// invoke calls the method of the broker with the request, and decodes the
+// reply of the broker into response. A NotFound error of the broker is
+// converted into an osb.HTTPStatusCodeError with notFoundStatusCode.
+func (c *client) invoke(method string, request, response interface{}, notFoundStatusCode int) error {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	ctx = metadata.NewOutgoingContext(ctx, c.metadata())
+
+	var trailer metadata.MD
+	if err := c.conn.Invoke(ctx, "/"+ServiceName+"/"+method, request, response, grpc.Trailer(&trailer)); err != nil {
+		return httpStatusCodeError(err, trailer, notFoundStatusCode)
+	}
+	return nil
+}
+
+func (c *client) metadata() metadata.MD {
+	md := metadata.Pairs(apiVersionMetadata, c.apiVersion.HeaderValue())
+	if c.authConfig != nil {
+		if basic := c.authConfig.BasicAuthConfig; basic != nil {
+			credentials := base64.StdEncoding.EncodeToString([]byte(basic.Username + ":" + basic.Password))
+			md.Set(authorizationMetadata, "Basic "+credentials)
+		} else if bearer := c.authConfig.BearerConfig; bearer != nil {
+			md.Set(authorizationMetadata, "Bearer "+bearer.Token)
+		}
+	}
+	return md
+}
+
+func (c *client) GetCatalog() (*osb.CatalogResponse, error) {
+	response := &osb.CatalogResponse{}
+	if err := c.invoke("GetCatalog", struct{}{}, response, http.StatusNotFound); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	response := &osb.ProvisionResponse{}
+	if err := c.invoke("ProvisionInstance", r, response, http.StatusNotFound); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	response := &osb.UpdateInstanceResponse{}
+	if err := c.invoke("UpdateInstance", r, response, http.StatusNotFound); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	response := &osb.DeprovisionResponse{}
+	if err := c.invoke("DeprovisionInstance", r, response, http.StatusGone); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	response := &osb.LastOperationResponse{}
+	if err := c.invoke("PollLastOperation", r, response, http.StatusGone); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	response := &osb.LastOperationResponse{}
+	if err := c.invoke("PollBindingLastOperation", r, response, http.StatusGone); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	response := &osb.BindResponse{}
+	if err := c.invoke("Bind", r, response, http.StatusNotFound); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	response := &osb.UnbindResponse{}
+	if err := c.invoke("Unbind", r, response, http.StatusGone); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *client) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	response := &osb.GetBindingResponse{}
+	if err := c.invoke("GetBinding", r, response, http.StatusNotFound); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
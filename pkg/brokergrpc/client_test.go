@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokergrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// handler handles a call to the fake broker; dec decodes the request.
+type handler func(ctx context.Context, dec func(interface{}) error) (interface{}, error)
+
+// fakeBroker is a broker that serves the gRPC service with handlers that
+// are set by each test.
+type fakeBroker struct {
+	metadata metadata.MD
+	handlers map[string]handler
+}
+
+func (b *fakeBroker) serviceDesc(methods ...string) *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*interface{})(nil),
+	}
+	for _, method := range methods {
+		method := method
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: method,
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				b.metadata, _ = metadata.FromIncomingContext(ctx)
+				return b.handlers[method](ctx, dec)
+			},
+		})
+	}
+	return desc
+}
+
+// newTestClient starts the fake broker and returns a client that calls it,
+// and the function that stops the broker.
+func newTestClient(t *testing.T, broker *fakeBroker, config *osb.ClientConfiguration, options Options) (osb.Client, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	server := grpc.NewServer()
+	methods := []string{}
+	for method := range broker.handlers {
+		methods = append(methods, method)
+	}
+	server.RegisterService(broker.serviceDesc(methods...), broker)
+	go server.Serve(listener)
+
+	config.URL = "http://" + listener.Addr().String()
+	client, err := NewClient(config, options)
+	if err != nil {
+		server.Stop()
+		t.Fatalf("error creating the client: %v", err)
+	}
+	return client, server.Stop
+}
+
+func TestGetCatalog(t *testing.T) {
+	broker := &fakeBroker{
+		handlers: map[string]handler{
+			"GetCatalog": func(_ context.Context, dec func(interface{}) error) (interface{}, error) {
+				return &osb.CatalogResponse{
+					Services: []osb.Service{{ID: "service-id", Name: "service", Plans: []osb.Plan{{ID: "plan-id", Name: "plan"}}}},
+				}, nil
+			},
+		},
+	}
+	config := osb.DefaultClientConfiguration()
+	config.AuthConfig = &osb.AuthConfig{BearerConfig: &osb.BearerConfig{Token: "token"}}
+	options := Options{UserAgent: "service-catalog/v0.3.0"}
+	client, stop := newTestClient(t, broker, config, options)
+	defer stop()
+
+	response, err := client.GetCatalog()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Services) != 1 || response.Services[0].ID != "service-id" || len(response.Services[0].Plans) != 1 {
+		t.Fatalf("unexpected catalog: %+v", response)
+	}
+
+	for key, expected := range map[string]string{
+		apiVersionMetadata:    osb.LatestAPIVersion().HeaderValue(),
+		authorizationMetadata: "Bearer token",
+	} {
+		if values := broker.metadata.Get(key); len(values) != 1 || values[0] != expected {
+			t.Errorf("unexpected %s metadata; expected %q, got %q", key, expected, values)
+		}
+	}
+	if values := broker.metadata.Get("user-agent"); len(values) != 1 || len(values[0]) < len(options.UserAgent) || values[0][:len(options.UserAgent)] != options.UserAgent {
+		t.Errorf("unexpected user-agent metadata %q", values)
+	}
+}
+
+func TestProvisionInstance(t *testing.T) {
+	var request osb.ProvisionRequest
+	operation := osb.OperationKey("operation")
+	broker := &fakeBroker{
+		handlers: map[string]handler{
+			"ProvisionInstance": func(_ context.Context, dec func(interface{}) error) (interface{}, error) {
+				if err := dec(&request); err != nil {
+					return nil, err
+				}
+				return &osb.ProvisionResponse{Async: true, OperationKey: &operation}, nil
+			},
+		},
+	}
+	client, stop := newTestClient(t, broker, osb.DefaultClientConfiguration(), Options{})
+	defer stop()
+
+	response, err := client.ProvisionInstance(&osb.ProvisionRequest{
+		InstanceID:        "instance-id",
+		AcceptsIncomplete: true,
+		ServiceID:         "service-id",
+		PlanID:            "plan-id",
+		Parameters:        map[string]interface{}{"size": "small"},
+		OriginatingIdentity: &osb.OriginatingIdentity{
+			Platform: "kubernetes",
+			Value:    `{"username":"alice"}`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !response.Async || response.OperationKey == nil || *response.OperationKey != operation {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if request.InstanceID != "instance-id" || !request.AcceptsIncomplete || request.Parameters["size"] != "small" {
+		t.Fatalf("unexpected request: %+v", request)
+	}
+	if request.OriginatingIdentity == nil || request.OriginatingIdentity.Platform != "kubernetes" {
+		t.Fatalf("unexpected originating identity: %+v", request.OriginatingIdentity)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		trailer metadata.MD
+		call    func(osb.Client) error
+		check   func(error) bool
+	}{
+		{
+			name: "deprovision of an instance that does not exist is gone",
+			err:  status.Error(codes.NotFound, "no such instance"),
+			call: func(c osb.Client) error {
+				_, err := c.DeprovisionInstance(&osb.DeprovisionRequest{InstanceID: "instance-id"})
+				return err
+			},
+			check: osb.IsGoneError,
+		},
+		{
+			name: "binding that does not exist is not found",
+			err:  status.Error(codes.NotFound, "no such binding"),
+			call: func(c osb.Client) error {
+				_, err := c.GetBinding(&osb.GetBindingRequest{InstanceID: "instance-id", BindingID: "binding-id"})
+				return err
+			},
+			check: func(err error) bool {
+				httpErr, ok := osb.IsHTTPError(err)
+				return ok && httpErr.StatusCode == http.StatusNotFound
+			},
+		},
+		{
+			name:    "async required",
+			err:     status.Error(codes.FailedPrecondition, osb.AsyncErrorDescription),
+			trailer: metadata.Pairs(ErrorTrailer, osb.AsyncErrorMessage),
+			call: func(c osb.Client) error {
+				_, err := c.ProvisionInstance(&osb.ProvisionRequest{InstanceID: "instance-id"})
+				return err
+			},
+			check: osb.IsAsyncRequiredError,
+		},
+		{
+			name: "conflict",
+			err:  status.Error(codes.AlreadyExists, "the instance exists with other attributes"),
+			call: func(c osb.Client) error {
+				_, err := c.ProvisionInstance(&osb.ProvisionRequest{InstanceID: "instance-id"})
+				return err
+			},
+			check: osb.IsConflictError,
+		},
+		{
+			name: "unavailable broker",
+			err:  status.Error(codes.Unavailable, "the broker is shutting down"),
+			call: func(c osb.Client) error {
+				_, err := c.Bind(&osb.BindRequest{InstanceID: "instance-id", BindingID: "binding-id"})
+				return err
+			},
+			check: func(err error) bool {
+				_, ok := osb.IsHTTPError(err)
+				return err != nil && !ok
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fail := func(ctx context.Context, _ func(interface{}) error) (interface{}, error) {
+				if tc.trailer != nil {
+					grpc.SetTrailer(ctx, tc.trailer)
+				}
+				return nil, tc.err
+			}
+			broker := &fakeBroker{
+				handlers: map[string]handler{
+					"ProvisionInstance":   fail,
+					"DeprovisionInstance": fail,
+					"Bind":                fail,
+					"GetBinding":          fail,
+				},
+			}
+			client, stop := newTestClient(t, broker, osb.DefaultClientConfiguration(), Options{})
+			defer stop()
+
+			if err := tc.call(client); !tc.check(err) {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		})
+	}
+}
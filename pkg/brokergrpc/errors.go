@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokergrpc
+
+import (
+	"net/http"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorTrailer is the trailer in which a broker returns the machine-readable
+// error code of the Open Service Broker API of a failed call, such as
+// AsyncRequired or ConcurrencyError. The message of the gRPC status is the
+// description of the error.
+const ErrorTrailer = "x-broker-api-error"
+
+// statusCodes maps the gRPC status codes of failed calls to the HTTP status
+// codes the brokers of the Open Service Broker API return for the same
+// errors, so that the controller handles them the same way.
+var statusCodes = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusUnprocessableEntity,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+// httpStatusCodeError converts the error of a failed call into the
+// osb.HTTPStatusCodeError the HTTP client returns for the same response of
+// the broker. NotFound is converted into notFoundStatusCode, as the Open
+// Service Broker API returns 410 Gone for some operations and 404 Not Found
+// for others. Errors that did not come from the broker, such as a broker
+// that can not be reached or a call that timed out, are returned unchanged.
+func httpStatusCodeError(err error, trailer metadata.MD, notFoundStatusCode int) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	statusCode, ok := statusCodes[s.Code()]
+	if s.Code() == codes.NotFound {
+		statusCode, ok = notFoundStatusCode, true
+	}
+	if !ok {
+		return err
+	}
+
+	httpErr := osb.HTTPStatusCodeError{StatusCode: statusCode}
+	if values := trailer.Get(ErrorTrailer); len(values) > 0 && values[0] != "" {
+		errorMessage := values[0]
+		httpErr.ErrorMessage = &errorMessage
+	}
+	if s.Message() != "" {
+		description := s.Message()
+		httpErr.Description = &description
+	}
+	return httpErr
+}
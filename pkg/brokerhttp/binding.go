@@ -0,0 +1,306 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+)
+
+const (
+	bindResourceAppGUIDKey = "app_guid"
+	bindResourceRouteKey   = "route"
+)
+
+// internal message body types
+
+type bindRequestBody struct {
+	ServiceID    string                 `json:"service_id"`
+	PlanID       string                 `json:"plan_id"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	BindResource map[string]interface{} `json:"bind_resource,omitempty"`
+	Context      map[string]interface{} `json:"context,omitempty"`
+}
+
+type bindSuccessResponseBody struct {
+	Credentials     map[string]interface{} `json:"credentials"`
+	SyslogDrainURL  *string                `json:"syslog_drain_url"`
+	RouteServiceURL *string                `json:"route_service_url"`
+	VolumeMounts    []interface{}          `json:"volume_mounts"`
+	Operation       *string                `json:"operation"`
+}
+
+// asyncBindingOperationsNotAllowedError returns the error of an asynchronous
+// binding operation the client is not allowed to request.
+func asyncBindingOperationsNotAllowedError(err error) error {
+	return fmt.Errorf("Asynchronous binding operations are not allowed: %v", err)
+}
+
+func (c *client) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	return c.bind(r, nil)
+}
+
+func (c *client) BindServiceInstance(r *BindRequest) (*osb.BindResponse, error) {
+	return c.bind(&r.BindRequest, r.BindResourceExtensions)
+}
+
+// bind sends the bind request, with the given extensions added to its bind
+// resource.
+func (c *client) bind(r *osb.BindRequest, bindResourceExtensions map[string]string) (*osb.BindResponse, error) {
+	if r.AcceptsIncomplete {
+		if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+			return nil, asyncBindingOperationsNotAllowedError(err)
+		}
+	}
+
+	if err := validateBindRequest(r); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(bindingURLFmt, c.url, r.InstanceID, r.BindingID)
+
+	params := map[string]string{}
+	if r.AcceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+
+	requestBody := &bindRequestBody{
+		ServiceID:  r.ServiceID,
+		PlanID:     r.PlanID,
+		Parameters: r.Parameters,
+	}
+
+	if c.apiVersion.AtLeast(osb.Version2_13()) {
+		requestBody.Context = r.Context
+	}
+
+	if r.BindResource != nil || len(bindResourceExtensions) > 0 {
+		requestBody.BindResource = map[string]interface{}{}
+		for key, value := range bindResourceExtensions {
+			requestBody.BindResource[key] = value
+		}
+	}
+	if r.BindResource != nil {
+		if r.BindResource.AppGUID != nil {
+			requestBody.BindResource[bindResourceAppGUIDKey] = *r.BindResource.AppGUID
+		}
+		if r.BindResource.Route != nil {
+			requestBody.BindResource[bindResourceRouteKey] = *r.BindResource.Route
+		}
+	}
+
+	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		userResponse := &osb.BindResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return userResponse, nil
+	case http.StatusAccepted:
+		if !r.AcceptsIncomplete {
+			return nil, c.handleFailureResponse(response)
+		}
+
+		responseBodyObj := &bindSuccessResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		if c.verbose {
+			klog.Infof("broker %q: received asynchronous response", c.name)
+		}
+
+		return &osb.BindResponse{
+			Async:           true,
+			Credentials:     responseBodyObj.Credentials,
+			SyslogDrainURL:  responseBodyObj.SyslogDrainURL,
+			RouteServiceURL: responseBodyObj.RouteServiceURL,
+			VolumeMounts:    responseBodyObj.VolumeMounts,
+			OperationKey:    operationKey(responseBodyObj.Operation),
+		}, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func validateBindRequest(request *osb.BindRequest) error {
+	if request.BindingID == "" {
+		return required("bindingID")
+	}
+
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+
+	if request.PlanID == "" {
+		return required("planID")
+	}
+
+	return nil
+}
+
+func (c *client) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	if r.AcceptsIncomplete {
+		if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+			return nil, asyncBindingOperationsNotAllowedError(err)
+		}
+	}
+
+	if err := validateUnbindRequest(r); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(bindingURLFmt, c.url, r.InstanceID, r.BindingID)
+
+	params := map[string]string{
+		osb.VarKeyServiceID: r.ServiceID,
+		osb.VarKeyPlanID:    r.PlanID,
+	}
+	if r.AcceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+
+	response, err := c.prepareAndDo(http.MethodDelete, fullURL, params, nil, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusGone:
+		userResponse := &osb.UnbindResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return userResponse, nil
+	case http.StatusAccepted:
+		if !r.AcceptsIncomplete {
+			return nil, c.handleFailureResponse(response)
+		}
+
+		responseBodyObj := &asyncSuccessResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		if c.verbose {
+			klog.Infof("broker %q: received asynchronous response", c.name)
+		}
+
+		return &osb.UnbindResponse{
+			Async:        true,
+			OperationKey: operationKey(responseBodyObj.Operation),
+		}, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func validateUnbindRequest(request *osb.UnbindRequest) error {
+	if request.BindingID == "" {
+		return required("bindingID")
+	}
+
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+
+	if request.PlanID == "" {
+		return required("planID")
+	}
+
+	return nil
+}
+
+func (c *client) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+		return nil, fmt.Errorf("GetBinding not allowed: %v", err)
+	}
+
+	fullURL := fmt.Sprintf(bindingURLFmt, c.url, r.InstanceID, r.BindingID)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := &osb.GetBindingResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func (c *client) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+		return nil, asyncBindingOperationsNotAllowedError(err)
+	}
+
+	if r.InstanceID == "" {
+		return nil, required("instanceID")
+	}
+
+	if r.BindingID == "" {
+		return nil, required("bindingID")
+	}
+
+	fullURL := fmt.Sprintf(bindingLastOperationURLFmt, c.url, r.InstanceID, r.BindingID)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, lastOperationParams(r.ServiceID, r.PlanID, r.OperationKey), nil /* request body */, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := &osb.LastOperationResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
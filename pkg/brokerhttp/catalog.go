@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+)
+
+// internal message body types
+
+// catalogResponseBody is a catalog whose plans have their schemas left
+// undecoded, so that the malformed schemas of a plan do not fail the decoding
+// of the whole catalog.
+type catalogResponseBody struct {
+	Services []catalogService `json:"services"`
+}
+
+type catalogService struct {
+	osb.Service
+	InstancesRetrievable bool          `json:"instances_retrievable"`
+	Plans                []catalogPlan `json:"plans"`
+}
+
+type catalogPlan struct {
+	osb.Plan
+	Schemas json.RawMessage `json:"schemas,omitempty"`
+}
+
+func (c *client) GetCatalog() (*osb.CatalogResponse, error) {
+	response, err := c.GetServiceCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return &response.CatalogResponse, nil
+}
+
+func (c *client) GetServiceCatalog() (*CatalogResponse, error) {
+	fullURL := fmt.Sprintf(catalogURL, c.url)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		responseBodyObj := &catalogResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		catalogResponse := c.catalogResponse(responseBodyObj)
+
+		if !c.apiVersion.AtLeast(osb.Version2_13()) {
+			for ii := range catalogResponse.Services {
+				for jj := range catalogResponse.Services[ii].Plans {
+					catalogResponse.Services[ii].Plans[jj].Schemas = nil
+				}
+			}
+		} else if !c.enableAlphaFeatures {
+			for ii := range catalogResponse.Services {
+				for jj := range catalogResponse.Services[ii].Plans {
+					schemas := catalogResponse.Services[ii].Plans[jj].Schemas
+					if schemas != nil && schemas.ServiceBinding != nil && schemas.ServiceBinding.Create != nil {
+						schemas.ServiceBinding.Create.Response = nil
+					}
+				}
+			}
+		}
+
+		return catalogResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+// catalogResponse returns the catalog of the given response body, with the
+// schemas of its plans decoded by decodePlanSchemas.
+func (c *client) catalogResponse(body *catalogResponseBody) *CatalogResponse {
+	catalogResponse := &CatalogResponse{}
+	if body.Services == nil {
+		return catalogResponse
+	}
+	catalogResponse.Services = make([]osb.Service, 0, len(body.Services))
+	for _, s := range body.Services {
+		if s.InstancesRetrievable {
+			if catalogResponse.InstancesRetrievable == nil {
+				catalogResponse.InstancesRetrievable = map[string]bool{}
+			}
+			catalogResponse.InstancesRetrievable[s.ID] = true
+		}
+		service := s.Service
+		if s.Plans != nil {
+			service.Plans = make([]osb.Plan, 0, len(s.Plans))
+		}
+		for _, p := range s.Plans {
+			plan := p.Plan
+			plan.Schemas = c.decodePlanSchemas(plan, p.Schemas)
+			service.Plans = append(service.Plans, plan)
+		}
+		catalogResponse.Services = append(catalogResponse.Services, service)
+	}
+	return catalogResponse
+}
+
+// decodePlanSchemas decodes the schemas of a plan. Each section of the
+// schemas is decoded on its own: a section that is malformed, or schemas that
+// are not a JSON object, are logged and dropped, so that the broker keeps its
+// other plans and schemas.
+func (c *client) decodePlanSchemas(plan osb.Plan, raw json.RawMessage) *osb.Schemas {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		klog.Warningf("broker %q: ignoring the malformed schemas of plan %q (%s): %v", c.name, plan.Name, plan.ID, err)
+		return nil
+	}
+
+	schemas := &osb.Schemas{}
+	if b, ok := sections["service_instance"]; ok {
+		var instanceSchemas *osb.ServiceInstanceSchema
+		if err := json.Unmarshal(b, &instanceSchemas); err != nil {
+			klog.Warningf("broker %q: ignoring the malformed service_instance schemas of plan %q (%s): %v", c.name, plan.Name, plan.ID, err)
+		} else {
+			schemas.ServiceInstance = instanceSchemas
+		}
+	}
+	if b, ok := sections["service_binding"]; ok {
+		var bindingSchemas *osb.ServiceBindingSchema
+		if err := json.Unmarshal(b, &bindingSchemas); err != nil {
+			klog.Warningf("broker %q: ignoring the malformed service_binding schemas of plan %q (%s): %v", c.name, plan.Name, plan.ID, err)
+		} else {
+			schemas.ServiceBinding = bindingSchemas
+		}
+	}
+	return schemas
+}
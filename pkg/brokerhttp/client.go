@@ -0,0 +1,375 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerhttp implements the Open Service Broker API client interface
+// for brokers reached over HTTP.
+//
+// It is derived from the client of go-open-service-broker-client, whose
+// http.Client can not be configured, so that service catalog controls the
+// transport of the requests to the brokers: the headers it adds to them and
+// the way their responses are read.
+package brokerhttp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+const (
+	catalogURL                 = "%s/v2/catalog"
+	serviceInstanceURLFmt      = "%s/v2/service_instances/%s"
+	lastOperationURLFmt        = "%s/v2/service_instances/%s/last_operation"
+	bindingLastOperationURLFmt = "%s/v2/service_instances/%s/service_bindings/%s/last_operation"
+	bindingURLFmt              = "%s/v2/service_instances/%s/service_bindings/%s"
+
+	contentType = "Content-Type"
+	jsonType    = "application/json"
+	eTag        = "ETag"
+	ifMatch     = "If-Match"
+
+	// maxResponseBodySize is the maximum number of bytes of the body of a
+	// response read from a broker, so that a broker can not exhaust the
+	// memory of the controller. It is far above the size of a catalog.
+	maxResponseBodySize = 32 << 20
+
+	// maxResponseBodySnippetLength is the maximum number of bytes of a
+	// failure response body included in errors and logs.
+	maxResponseBodySnippetLength = 256
+)
+
+// Options are the settings of the transport of a client that are not part
+// of the osb.ClientConfiguration.
+type Options struct {
+	// UserAgent is the User-Agent header of the requests to the broker. If
+	// empty, the default of net/http is sent.
+	UserAgent string
+	// URLPolicy, if not nil, refuses the connections to the addresses it
+	// denies and the redirects to the URLs it denies.
+	URLPolicy *brokerurl.Policy
+}
+
+// client is an osb.Client that calls the operations of a broker over HTTP.
+type client struct {
+	name                string
+	url                 string
+	apiVersion          osb.APIVersion
+	authConfig          *osb.AuthConfig
+	enableAlphaFeatures bool
+	verbose             bool
+
+	httpClient *http.Client
+}
+
+var _ Client = &client{}
+
+// NewClient creates the client of a broker reached over HTTP, like the
+// CreateFunc of go-open-service-broker-client, with the given options.
+func NewClient(config *osb.ClientConfiguration, options Options) (osb.Client, error) {
+	if config.AuthConfig != nil {
+		if config.AuthConfig.BasicAuthConfig == nil && config.AuthConfig.BearerConfig == nil {
+			return nil, errors.New("Non-nil AuthConfig cannot be empty")
+		}
+		if config.AuthConfig.BasicAuthConfig != nil && config.AuthConfig.BearerConfig != nil {
+			return nil, errors.New("Only one AuthConfig implementation must be set at a time")
+		}
+	}
+
+	httpClient, err := NewHTTPClient(config, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		name:                config.Name,
+		url:                 strings.TrimRight(config.URL, "/"),
+		apiVersion:          config.APIVersion,
+		authConfig:          config.AuthConfig,
+		enableAlphaFeatures: config.EnableAlphaFeatures,
+		verbose:             config.Verbose,
+		httpClient:          httpClient,
+	}, nil
+}
+
+// NewHTTPClient returns the http.Client of the requests to the broker of the
+// given configuration: it has the timeout and the TLS settings of the
+// configuration, and its transport applies the options to every request.
+func NewHTTPClient(config *osb.ClientConfiguration, options Options) (*http.Client, error) {
+	// use default values lifted from DefaultTransport
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: options.URLPolicy.DialContext(&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}),
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig
+	} else {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if config.Insecure {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if len(config.CAData) != 0 {
+		if transport.TLSClientConfig.RootCAs == nil {
+			transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+		}
+		transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(config.CAData)
+	}
+	if transport.TLSClientConfig.InsecureSkipVerify && transport.TLSClientConfig.RootCAs != nil {
+		return nil, errors.New("Cannot specify root CAs and to skip TLS verification")
+	}
+
+	var roundTripper http.RoundTripper = &limitedBodyRoundTripper{limit: maxResponseBodySize, next: transport}
+	roundTripper = &debugLogRoundTripper{brokerName: config.Name, next: roundTripper}
+	if options.UserAgent != "" {
+		roundTripper = &userAgentRoundTripper{userAgent: options.UserAgent, next: roundTripper}
+	}
+
+	httpClient := &http.Client{
+		Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
+		Transport: roundTripper,
+	}
+	if options.URLPolicy != nil {
+		httpClient.CheckRedirect = options.URLPolicy.CheckRedirect
+	}
+	return httpClient, nil
+}
+
+// prepareAndDo prepares a request for the given method, URL, and message
+// body, and executes the request, returning an http.Response or an error.
+// Errors returned from this function represent http-layer errors and not
+// errors in the Open Service Broker API.
+func (c *client) prepareAndDo(method, URL string, params map[string]string, body interface{}, originatingIdentity *osb.OriginatingIdentity) (*http.Response, error) {
+	return c.prepareAndDoWithStateToken(method, URL, params, body, originatingIdentity, "")
+}
+
+// prepareAndDoWithStateToken is prepareAndDo, sending the given state token,
+// if not empty, in the If-Match header of the request.
+func (c *client) prepareAndDoWithStateToken(method, URL string, params map[string]string, body interface{}, originatingIdentity *osb.OriginatingIdentity, stateToken string) (*http.Response, error) {
+	var bodyReader io.Reader
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequest(method, URL, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set(osb.APIVersionHeader, c.apiVersion.HeaderValue())
+	if bodyReader != nil {
+		request.Header.Set(contentType, jsonType)
+	}
+	if stateToken != "" {
+		request.Header.Set(ifMatch, stateToken)
+	}
+
+	if c.authConfig != nil {
+		if c.authConfig.BasicAuthConfig != nil {
+			basicAuth := c.authConfig.BasicAuthConfig
+			request.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+		} else if c.authConfig.BearerConfig != nil {
+			bearer := c.authConfig.BearerConfig
+			request.Header.Set("Authorization", "Bearer "+bearer.Token)
+		}
+	}
+
+	if c.apiVersion.AtLeast(osb.Version2_13()) && originatingIdentity != nil {
+		headerValue, err := buildOriginatingIdentityHeaderValue(originatingIdentity)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set(osb.OriginatingIdentityHeader, headerValue)
+	}
+
+	if params != nil {
+		q := request.URL.Query()
+		for k, v := range params {
+			q.Set(k, v)
+		}
+		request.URL.RawQuery = q.Encode()
+	}
+
+	if c.verbose {
+		klog.Infof("broker %q: doing request to %q", c.name, URL)
+	}
+
+	return c.httpClient.Do(request)
+}
+
+// unmarshalResponse unmarshals the response body of the given response into
+// the given object or returns an error.
+func (c *client) unmarshalResponse(response *http.Response, obj interface{}) error {
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if c.verbose {
+		klog.Infof("broker %q: response body: %v, type: %T", c.name, string(body), obj)
+	}
+
+	return json.Unmarshal(body, obj)
+}
+
+// handleFailureResponse returns an HTTPStatusCodeError for the given
+// response. The error is classified by the status code of the response alone;
+// if the body is not an OSB error object, for example an HTML error page of a
+// proxy, ResponseError holds a truncated snippet of the body.
+func (c *client) handleFailureResponse(response *http.Response) error {
+	klog.Info("handling failure responses")
+
+	httpErr := osb.HTTPStatusCodeError{
+		StatusCode: response.StatusCode,
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		httpErr.ResponseError = err
+		return httpErr
+	}
+
+	if c.verbose {
+		klog.Infof("broker %q: failure response body: %q", c.name, truncateResponseBody(body))
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		httpErr.ResponseError = errors.New("empty response body")
+		return httpErr
+	}
+
+	brokerResponse := make(map[string]interface{})
+	if err := json.Unmarshal(body, &brokerResponse); err != nil {
+		httpErr.ResponseError = fmt.Errorf("response body is not an OSB error object (%v): %q", err, truncateResponseBody(body))
+		return httpErr
+	}
+
+	if errorMessage, ok := brokerResponse["error"].(string); ok {
+		httpErr.ErrorMessage = &errorMessage
+	}
+
+	if description, ok := brokerResponse["description"].(string); ok {
+		httpErr.Description = &description
+	}
+
+	return httpErr
+}
+
+// truncateResponseBody returns at most maxResponseBodySnippetLength bytes of
+// the given body, cut at a rune boundary, for use in errors and logs.
+func truncateResponseBody(body []byte) string {
+	if len(body) <= maxResponseBodySnippetLength {
+		return string(body)
+	}
+	end := maxResponseBodySnippetLength
+	for end > 0 && !utf8.RuneStart(body[end]) {
+		end--
+	}
+	return string(body[:end]) + "..."
+}
+
+func buildOriginatingIdentityHeaderValue(i *osb.OriginatingIdentity) (string, error) {
+	if i.Platform == "" {
+		return "", errors.New("originating identity platform must not be empty")
+	}
+	if i.Value == "" {
+		return "", errors.New("originating identity value must not be empty")
+	}
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(i.Value), &js); err != nil {
+		return "", fmt.Errorf("originating identity value must be valid JSON: %v", err)
+	}
+	encodedValue := base64.StdEncoding.EncodeToString([]byte(i.Value))
+	return fmt.Sprintf("%v %v", i.Platform, encodedValue), nil
+}
+
+// validateAlphaAPIMethodsAllowed returns an error if alpha API methods are
+// not allowed for this client.
+func (c *client) validateAlphaAPIMethodsAllowed() error {
+	if !c.enableAlphaFeatures {
+		return errors.New("alpha features must be enabled")
+	}
+
+	if !c.apiVersion.AtLeast(osb.LatestAPIVersion()) {
+		return fmt.Errorf("must have latest API Version. Current: %s, Expected: %s", c.apiVersion, osb.LatestAPIVersion())
+	}
+
+	return nil
+}
+
+// drainReader reads and discards the remaining data in reader, so that the
+// connection of the response can be reused if keepalive is enabled.
+func drainReader(reader io.Reader) error {
+	if reader == nil {
+		return nil
+	}
+	_, drainError := io.Copy(ioutil.Discard, io.LimitReader(reader, 4096))
+	return drainError
+}
+
+// closeResponse drains and closes the body of the response.
+func closeResponse(response *http.Response) {
+	drainReader(response.Body)
+	response.Body.Close()
+}
+
+// operationKey converts the operation of an asynchronous response.
+func operationKey(operation *string) *osb.OperationKey {
+	if operation == nil {
+		return nil
+	}
+	op := osb.OperationKey(*operation)
+	return &op
+}
+
+func required(name string) error {
+	return fmt.Errorf("%v is required", name)
+}
+
+// internal message body types
+
+type asyncSuccessResponseBody struct {
+	Operation *string `json:"operation"`
+}
@@ -0,0 +1,598 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+// newTestClient starts a broker that answers every request with handler and
+// returns a client that calls it, and the function that stops the broker.
+func newTestClient(t *testing.T, handler http.HandlerFunc, options Options) (osb.Client, func()) {
+	server := httptest.NewServer(handler)
+	config := osb.DefaultClientConfiguration()
+	config.URL = server.URL + "/"
+	config.EnableAlphaFeatures = true
+	config.AuthConfig = &osb.AuthConfig{BasicAuthConfig: &osb.BasicAuthConfig{Username: "user", Password: "pass"}}
+	client, err := NewClient(config, options)
+	if err != nil {
+		server.Close()
+		t.Fatalf("error creating the client: %v", err)
+	}
+	return client, server.Close
+}
+
+func TestRequestHeaders(t *testing.T) {
+	cases := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{
+			name:      "user agent",
+			userAgent: "service-catalog/v0.3.0 prod-cluster",
+			expected:  "service-catalog/v0.3.0 prod-cluster",
+		},
+		{
+			name:     "default user agent",
+			expected: "Go-http-client/1.1",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var request *http.Request
+			client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				w.Write([]byte(`{"services":[]}`))
+			}, Options{UserAgent: tc.userAgent})
+			defer stop()
+
+			if _, err := client.GetCatalog(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := "/v2/catalog", request.URL.Path; e != a {
+				t.Fatalf("unexpected path; expected %q, got %q", e, a)
+			}
+			if e, a := tc.expected, request.Header.Get("User-Agent"); e != a {
+				t.Fatalf("unexpected User-Agent; expected %q, got %q", e, a)
+			}
+			if e, a := osb.LatestAPIVersion().HeaderValue(), request.Header.Get(osb.APIVersionHeader); e != a {
+				t.Fatalf("unexpected API version; expected %q, got %q", e, a)
+			}
+			if username, password, _ := request.BasicAuth(); username != "user" || password != "pass" {
+				t.Fatalf("unexpected credentials %q:%q", username, password)
+			}
+		})
+	}
+}
+
+// TestGetCatalogMalformedSchemas tests that the malformed schemas of a plan
+// are dropped without failing the catalog or the other schemas.
+func TestGetCatalogMalformedSchemas(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"services":[{"id":"service-id","name":"service","plans":[
+			{"id":"string","name":"string","schemas":"not an object"},
+			{"id":"array","name":"array","schemas":[{"service_instance":{}}]},
+			{"id":"section","name":"section","schemas":{
+				"service_instance":"not an object",
+				"service_binding":{"create":{"parameters":{"type":"object"}}}}},
+			{"id":"valid","name":"valid","schemas":{
+				"service_instance":{"create":{"parameters":{"type":"object"}}}}},
+			{"id":"none","name":"none"}
+		]}]}`))
+	}, Options{})
+	defer stop()
+
+	catalog, err := client.GetCatalog()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parameters := map[string]interface{}{"type": "object"}
+	expected := []osb.Plan{
+		{ID: "string", Name: "string"},
+		{ID: "array", Name: "array"},
+		{ID: "section", Name: "section", Schemas: &osb.Schemas{
+			ServiceBinding: &osb.ServiceBindingSchema{
+				Create: &osb.RequestResponseSchema{InputParametersSchema: osb.InputParametersSchema{Parameters: parameters}},
+			},
+		}},
+		{ID: "valid", Name: "valid", Schemas: &osb.Schemas{
+			ServiceInstance: &osb.ServiceInstanceSchema{
+				Create: &osb.InputParametersSchema{Parameters: parameters},
+			},
+		}},
+		{ID: "none", Name: "none"},
+	}
+	if len(catalog.Services) != 1 || catalog.Services[0].ID != "service-id" {
+		t.Fatalf("unexpected services %+v", catalog.Services)
+	}
+	if e, a := expected, catalog.Services[0].Plans; !jsonEqual(e, a) {
+		t.Fatalf("unexpected plans; expected %+v, got %+v", e, a)
+	}
+}
+
+func TestProvisionInstance(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   *osb.ProvisionResponse
+		err        bool
+	}{
+		{
+			name:       "synchronous",
+			statusCode: http.StatusCreated,
+			body:       `{"dashboard_url":"https://dashboard"}`,
+			expected:   &osb.ProvisionResponse{DashboardURL: strPtr("https://dashboard")},
+		},
+		{
+			name:       "asynchronous",
+			statusCode: http.StatusAccepted,
+			body:       `{"operation":"op"}`,
+			expected:   &osb.ProvisionResponse{Async: true, OperationKey: operationKey(strPtr("op"))},
+		},
+		{
+			name:       "failure",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":"BadRequest","description":"unknown plan"}`,
+			err:        true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var method, acceptsIncomplete string
+			var body provisionRequestBody
+			client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				method, acceptsIncomplete = r.Method, r.URL.Query().Get(osb.AcceptsIncomplete)
+				json.NewDecoder(r.Body).Decode(&body)
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}, Options{})
+			defer stop()
+
+			response, err := client.ProvisionInstance(&osb.ProvisionRequest{
+				InstanceID:        "instance-id",
+				ServiceID:         "service-id",
+				PlanID:            "plan-id",
+				OrganizationGUID:  "org",
+				SpaceGUID:         "space",
+				AcceptsIncomplete: true,
+				Parameters:        map[string]interface{}{"a": "b"},
+			})
+			if method != http.MethodPut || acceptsIncomplete != "true" {
+				t.Fatalf("unexpected request %s with accepts_incomplete=%q", method, acceptsIncomplete)
+			}
+			if body.ServiceID != "service-id" || body.PlanID != "plan-id" || body.Parameters["a"] != "b" {
+				t.Fatalf("unexpected request body %+v", body)
+			}
+			if tc.err {
+				httpErr, ok := osb.IsHTTPError(err)
+				if !ok || httpErr.StatusCode != tc.statusCode || httpErr.Description == nil || *httpErr.Description != "unknown plan" {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expected, response; !jsonEqual(e, a) {
+				t.Fatalf("unexpected response; expected %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestProvisionServiceInstanceMetadata(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "synchronous",
+			statusCode: http.StatusCreated,
+			body:       `{"metadata":{"labels":{"team":"a"},"attributes":{"region":"us-east-1"}}}`,
+		},
+		{
+			name:       "asynchronous",
+			statusCode: http.StatusAccepted,
+			body:       `{"operation":"op","metadata":{"labels":{"team":"a"},"attributes":{"region":"us-east-1"}}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}, Options{})
+			defer stop()
+
+			response, err := ProvisionInstance(client, &osb.ProvisionRequest{
+				InstanceID:        "instance-id",
+				ServiceID:         "service-id",
+				PlanID:            "plan-id",
+				OrganizationGUID:  "org",
+				SpaceGUID:         "space",
+				AcceptsIncomplete: true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := &ServiceInstanceMetadata{
+				Labels:     map[string]interface{}{"team": "a"},
+				Attributes: map[string]interface{}{"region": "us-east-1"},
+			}
+			if e, a := expected, response.Metadata; !jsonEqual(e, a) {
+				t.Fatalf("unexpected metadata; expected %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestGetServiceCatalogInstancesRetrievable(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"services":[
+			{"id":"retrievable","name":"retrievable","instances_retrievable":true,"plans":[]},
+			{"id":"other","name":"other","plans":[]}
+		]}`))
+	}, Options{})
+	defer stop()
+
+	catalog, err := GetCatalog(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(catalog.Services) != 2 {
+		t.Fatalf("unexpected services %+v", catalog.Services)
+	}
+	if e, a := map[string]bool{"retrievable": true}, catalog.InstancesRetrievable; !jsonEqual(e, a) {
+		t.Fatalf("unexpected instances retrievable; expected %v, got %v", e, a)
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	cases := []struct {
+		name               string
+		statusCode         int
+		body               string
+		expectedResponse   *GetInstanceResponse
+		expectedStatusCode int
+	}{
+		{
+			name:       "found",
+			statusCode: http.StatusOK,
+			body:       `{"service_id":"service-id","plan_id":"plan-id","parameters":{"size":"small"}}`,
+			expectedResponse: &GetInstanceResponse{
+				ServiceID:  "service-id",
+				PlanID:     "plan-id",
+				Parameters: map[string]interface{}{"size": "small"},
+			},
+		},
+		{
+			name:               "not found",
+			statusCode:         http.StatusNotFound,
+			body:               `{}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := http.MethodGet, r.Method; e != a {
+					t.Errorf("unexpected method; expected %v, got %v", e, a)
+				}
+				if e, a := "/v2/service_instances/instance-id", r.URL.Path; e != a {
+					t.Errorf("unexpected path; expected %v, got %v", e, a)
+				}
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}, Options{})
+			defer stop()
+
+			response, err := GetInstance(client, &GetInstanceRequest{InstanceID: "instance-id"})
+			if tc.expectedStatusCode != 0 {
+				httpErr, ok := osb.IsHTTPError(err)
+				if !ok || httpErr.StatusCode != tc.expectedStatusCode {
+					t.Fatalf("expected an HTTP error with status %v, got %v", tc.expectedStatusCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expectedResponse, response; !jsonEqual(e, a) {
+				t.Fatalf("unexpected response; expected %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestStateToken(t *testing.T) {
+	var ifMatch []string
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+		w.Header().Set("ETag", `"token-2"`)
+		switch r.Method {
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet, http.MethodPatch, http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write([]byte(`{}`))
+	}, Options{})
+	defer stop()
+
+	provisionResponse, err := ProvisionInstance(client, &osb.ProvisionRequest{
+		InstanceID:       "instance-id",
+		ServiceID:        "service-id",
+		PlanID:           "plan-id",
+		OrganizationGUID: "org",
+		SpaceGUID:        "space",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getResponse, err := GetInstance(client, &GetInstanceRequest{InstanceID: "instance-id"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updateResponse, err := UpdateInstance(client, &UpdateInstanceRequest{
+		UpdateInstanceRequest: osb.UpdateInstanceRequest{InstanceID: "instance-id", ServiceID: "service-id"},
+		StateToken:            `"token-1"`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = DeprovisionInstance(client, &DeprovisionRequest{
+		DeprovisionRequest: osb.DeprovisionRequest{InstanceID: "instance-id", ServiceID: "service-id", PlanID: "plan-id"},
+		StateToken:         `"token-2"`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, stateToken := range []string{provisionResponse.StateToken, getResponse.StateToken, updateResponse.StateToken} {
+		if e, a := `"token-2"`, stateToken; e != a {
+			t.Fatalf("unexpected state token; expected %v, got %v", e, a)
+		}
+	}
+	if e, a := []string{"", "", `"token-1"`, `"token-2"`}, ifMatch; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected If-Match headers; expected %q, got %q", e, a)
+	}
+}
+
+func TestBindResource(t *testing.T) {
+	var body bindRequestBody
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"credentials":{"password":"secret"}}`))
+	}, Options{})
+	defer stop()
+
+	response, err := client.Bind(&osb.BindRequest{
+		BindingID:    "binding-id",
+		InstanceID:   "instance-id",
+		ServiceID:    "service-id",
+		PlanID:       "plan-id",
+		BindResource: &osb.BindResource{AppGUID: strPtr("app"), Route: strPtr("route")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Credentials["password"] != "secret" {
+		t.Fatalf("unexpected credentials %v", response.Credentials)
+	}
+	if body.BindResource["app_guid"] != "app" || body.BindResource["route"] != "route" {
+		t.Fatalf("unexpected bind resource %v", body.BindResource)
+	}
+}
+
+func TestBindResourceExtensions(t *testing.T) {
+	var body bindRequestBody
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"credentials":{"password":"secret"}}`))
+	}, Options{})
+	defer stop()
+
+	_, err := Bind(client, &BindRequest{
+		BindRequest: osb.BindRequest{
+			BindingID:    "binding-id",
+			InstanceID:   "instance-id",
+			ServiceID:    "service-id",
+			PlanID:       "plan-id",
+			BindResource: &osb.BindResource{AppGUID: strPtr("app")},
+		},
+		BindResourceExtensions: map[string]string{"credential_client_id": "client"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"app_guid": "app", "credential_client_id": "client"}
+	if !reflect.DeepEqual(expected, body.BindResource) {
+		t.Fatalf("unexpected bind resource %v", body.BindResource)
+	}
+}
+
+func TestDeprovisionInstanceGone(t *testing.T) {
+	var query map[string][]string
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(`{}`))
+	}, Options{})
+	defer stop()
+
+	response, err := client.DeprovisionInstance(&osb.DeprovisionRequest{InstanceID: "instance-id", ServiceID: "service-id", PlanID: "plan-id"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Async {
+		t.Fatalf("unexpected asynchronous response")
+	}
+	if query[osb.VarKeyServiceID][0] != "service-id" || query[osb.VarKeyPlanID][0] != "plan-id" {
+		t.Fatalf("unexpected query %v", query)
+	}
+}
+
+// TestFailureResponseBodies tests that failure responses whose body is not
+// an OSB error object are classified by their status code, and that the
+// error carries a bounded snippet of the body.
+func TestFailureResponseBodies(t *testing.T) {
+	cases := []struct {
+		name            string
+		statusCode      int
+		body            string
+		expectedMessage string
+	}{
+		{
+			name:            "html error page",
+			statusCode:      http.StatusBadGateway,
+			body:            "<html><body><h1>502 Bad Gateway</h1></body></html>",
+			expectedMessage: `"<html><body><h1>502 Bad Gateway</h1></body></html>"`,
+		},
+		{
+			name:            "empty body",
+			statusCode:      http.StatusServiceUnavailable,
+			expectedMessage: "empty response body",
+		},
+		{
+			name:            "invalid json",
+			statusCode:      http.StatusBadRequest,
+			body:            `{"error": "BadRequest",`,
+			expectedMessage: `"{\"error\": \"BadRequest\","`,
+		},
+		{
+			name:            "large plain text body",
+			statusCode:      http.StatusInternalServerError,
+			body:            strings.Repeat("internal error ", 1000),
+			expectedMessage: `internal error i..."`,
+		},
+		{
+			name:            "osb error object",
+			statusCode:      http.StatusBadRequest,
+			body:            `{"error": "BadRequest", "description": "unknown plan"}`,
+			expectedMessage: "Description: unknown plan; ResponseError: <nil>",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}, Options{})
+			defer stop()
+
+			_, err := client.ProvisionInstance(&osb.ProvisionRequest{
+				InstanceID:        "instance-id",
+				ServiceID:         "service-id",
+				PlanID:            "plan-id",
+				OrganizationGUID:  "org",
+				SpaceGUID:         "space",
+				AcceptsIncomplete: true,
+			})
+			httpErr, ok := osb.IsHTTPError(err)
+			if !ok {
+				t.Fatalf("expected an HTTP error, got %v", err)
+			}
+			if e, a := tc.statusCode, httpErr.StatusCode; e != a {
+				t.Fatalf("unexpected status code; expected %v, got %v", e, a)
+			}
+			message := httpErr.Error()
+			if !strings.Contains(message, tc.expectedMessage) {
+				t.Fatalf("expected error message to contain %q, got %q", tc.expectedMessage, message)
+			}
+			if len(message) > 512 {
+				t.Fatalf("expected error message to be truncated, got %v bytes", len(message))
+			}
+		})
+	}
+}
+
+func TestResponseBodySizeLimit(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"services":[],"padding":"`))
+		w.Write([]byte(strings.Repeat("x", maxResponseBodySize)))
+		w.Write([]byte(`"}`))
+	}, Options{})
+	defer stop()
+
+	_, err := client.GetCatalog()
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum size") {
+		t.Fatalf("expected an error about the size of the response body, got %v", err)
+	}
+}
+
+// TestURLPolicy tests that the client does not connect to the addresses
+// denied by its URL policy, nor follows the redirects to the denied URLs.
+func TestURLPolicy(t *testing.T) {
+	cases := []struct {
+		name         string
+		allowedCIDRs []string
+		handler      http.HandlerFunc
+	}{
+		{
+			name: "denied address",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"services":[]}`))
+			},
+		},
+		{
+			name:         "redirect to a denied address",
+			allowedCIDRs: []string{"127.0.0.0/8"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := brokerurl.NewPolicy(tc.allowedCIDRs, brokerurl.DefaultDeniedCIDRs, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			client, stop := newTestClient(t, tc.handler, Options{URLPolicy: policy})
+			defer stop()
+
+			if _, err := client.GetCatalog(); !brokerurl.IsDeniedError(err) {
+				t.Fatalf("expected the request to be denied, got %v", err)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
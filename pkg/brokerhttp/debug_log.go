@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog"
+)
+
+// debugLogLevel is the verbosity at which the requests to the brokers and
+// their responses are logged, the level at which the Kubernetes clients log
+// the contents of their HTTP requests.
+const debugLogLevel klog.Level = 8
+
+// redactedValue replaces the values of the credentials and of the parameters
+// in the logs.
+const redactedValue = "<redacted>"
+
+// redactedFields are the fields of the bodies of the requests and responses
+// whose values are redacted in the logs: the parameters of the instances and
+// bindings, which may be taken from secrets, and the credentials of the
+// bindings. Their keys are kept, so that a missing one can still be spotted.
+var redactedFields = []string{"parameters", "credentials"}
+
+// debugLogRoundTripper logs the bodies of the requests it sends and of the
+// responses it receives, redacted, at debugLogLevel.
+type debugLogRoundTripper struct {
+	brokerName string
+	next       http.RoundTripper
+}
+
+func (rt *debugLogRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !klog.V(debugLogLevel) {
+		return rt.next.RoundTrip(request)
+	}
+
+	var body []byte
+	if request.Body != nil && request.GetBody != nil {
+		// GetBody returns a copy of the body, so that the request is not
+		// modified.
+		reader, err := request.GetBody()
+		if err == nil {
+			body, err = ioutil.ReadAll(reader)
+		}
+		if err != nil {
+			klog.Infof("broker %q: %s %s: can not read the request body: %v", rt.brokerName, request.Method, request.URL, err)
+		}
+	}
+	klog.Infof("broker %q: %s %s request: %s", rt.brokerName, request.Method, request.URL, redactBody(body))
+
+	response, err := rt.next.RoundTrip(request)
+	if err != nil {
+		klog.Infof("broker %q: %s %s failed: %v", rt.brokerName, request.Method, request.URL, err)
+		return nil, err
+	}
+
+	body, err = ioutil.ReadAll(response.Body)
+	if err != nil {
+		klog.Infof("broker %q: %s %s: can not read the response body: %v", rt.brokerName, request.Method, request.URL, err)
+	}
+	klog.Infof("broker %q: %s %s response %s: %s", rt.brokerName, request.Method, request.URL, response.Status, redactBody(body))
+
+	// The body was read, so the client reads it again from memory, and then
+	// gets the error of the read, if any.
+	response.Body = &replayedReadCloser{
+		Reader: io.MultiReader(bytes.NewReader(body), &errReader{err: err}),
+		Closer: response.Body,
+	}
+	return response, nil
+}
+
+// redactBody returns the given JSON body with the values of redactedFields
+// redacted. A body that is not a JSON object is returned truncated, like the
+// failure response bodies.
+func redactBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "<empty>"
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return truncateResponseBody(body)
+	}
+	for _, field := range redactedFields {
+		if values, ok := object[field].(map[string]interface{}); ok {
+			redacted := make(map[string]interface{}, len(values))
+			for k := range values {
+				redacted[k] = redactedValue
+			}
+			object[field] = redacted
+		} else if object[field] != nil {
+			object[field] = redactedValue
+		}
+	}
+	var redacted bytes.Buffer
+	encoder := json.NewEncoder(&redacted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(object); err != nil {
+		return "<" + err.Error() + ">"
+	}
+	return strings.TrimSuffix(redacted.String(), "\n")
+}
+
+// replayedReadCloser reads a body already read from memory, and closes the
+// original body.
+type replayedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader returns err, or io.EOF if it is nil.
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
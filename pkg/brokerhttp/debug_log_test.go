@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "empty",
+			expected: "<empty>",
+		},
+		{
+			name:     "parameters",
+			body:     `{"plan_id":"plan","parameters":{"password":"secret"}}`,
+			expected: `{"parameters":{"password":"<redacted>"},"plan_id":"plan"}`,
+		},
+		{
+			name:     "credentials",
+			body:     `{"credentials":{"uri":"postgres://admin:secret@db"}}`,
+			expected: `{"credentials":{"uri":"<redacted>"}}`,
+		},
+		{
+			name:     "credentials that are not an object",
+			body:     `{"credentials":"secret"}`,
+			expected: `{"credentials":"<redacted>"}`,
+		},
+		{
+			name:     "not JSON",
+			body:     `<html>Bad Gateway</html>`,
+			expected: `<html>Bad Gateway</html>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if e, a := tc.expected, redactBody([]byte(tc.body)); e != a {
+				t.Fatalf("unexpected redacted body: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDebugLogRedactsBodies(t *testing.T) {
+	var out bytes.Buffer
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	flags.Set("logtostderr", "false")
+	flags.Set("v", "8")
+	klog.SetOutput(&out)
+	defer func() {
+		flags.Set("v", "0")
+		flags.Set("logtostderr", "true")
+	}()
+
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"credentials":{"password":"secret-credential"}}`))
+	}, Options{})
+	defer stop()
+
+	response, err := client.Bind(&osb.BindRequest{
+		BindingID:  "binding",
+		InstanceID: "instance",
+		ServiceID:  "service",
+		PlanID:     "plan",
+		Parameters: map[string]interface{}{"password": "secret-parameter"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "secret-credential", response.Credentials["password"]; e != a {
+		t.Fatalf("the response must be read after it is logged: expected %v, got %v", e, a)
+	}
+
+	logged := out.String()
+	if e, a := 2, strings.Count(logged, `{"password":"<redacted>"}`); e != a {
+		t.Errorf("expected the parameters of the request and the credentials of the response to be redacted, got log:\n%s", logged)
+	}
+	if strings.Contains(logged, "secret-") {
+		t.Errorf("expected the values of the parameters and credentials not to be logged, got log:\n%s", logged)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"errors"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+)
+
+// Client is an osb.Client which also returns the fields of the responses of
+// the brokers that the types of go-open-service-broker-client have no field
+// for. The clients of this package implement it; the clients which wrap
+// another client implement it by forwarding to the functions of this package,
+// so that the fields are not lost on the way.
+type Client interface {
+	osb.Client
+
+	// ProvisionServiceInstance is ProvisionInstance, returning the metadata
+	// of the instance too.
+	ProvisionServiceInstance(r *osb.ProvisionRequest) (*ProvisionResponse, error)
+
+	// GetServiceCatalog is GetCatalog, returning the services whose
+	// instances can be fetched too.
+	GetServiceCatalog() (*CatalogResponse, error)
+
+	// GetInstance fetches a service instance from the broker.
+	GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error)
+
+	// UpdateServiceInstance is UpdateInstance, sending the state token of
+	// the instance too.
+	UpdateServiceInstance(r *UpdateInstanceRequest) (*UpdateInstanceResponse, error)
+
+	// DeprovisionServiceInstance is DeprovisionInstance, sending the state
+	// token of the instance too.
+	DeprovisionServiceInstance(r *DeprovisionRequest) (*osb.DeprovisionResponse, error)
+
+	// BindServiceInstance is Bind, sending the keys of the bind resource that
+	// osb.BindResource has no field for too.
+	BindServiceInstance(r *BindRequest) (*osb.BindResponse, error)
+}
+
+// ErrGetInstanceNotSupported is returned by GetInstance for the clients which
+// can not fetch a service instance.
+var ErrGetInstanceNotSupported = errors.New("the client of the broker does not support fetching a service instance")
+
+// ServiceInstanceMetadata is the metadata that a broker returns for a
+// service instance.
+type ServiceInstanceMetadata struct {
+	// Labels are the labels of the service instance.
+	Labels map[string]interface{} `json:"labels,omitempty"`
+	// Attributes are the attributes of the service instance.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ProvisionResponse is the response to a provision request.
+type ProvisionResponse struct {
+	osb.ProvisionResponse
+
+	// Metadata is the metadata of the instance, if the broker returned any.
+	Metadata *ServiceInstanceMetadata `json:"metadata,omitempty"`
+	// StateToken is the ETag header of the response, if the broker returned
+	// one.
+	StateToken string `json:"-"`
+}
+
+// ProvisionInstance sends the provision request with the given client. The
+// response only has the fields which osb.ProvisionResponse lacks when the
+// client is a Client.
+func ProvisionInstance(client osb.Client, r *osb.ProvisionRequest) (*ProvisionResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.ProvisionServiceInstance(r)
+	}
+	response, err := client.ProvisionInstance(r)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return &ProvisionResponse{ProvisionResponse: *response}, nil
+}
+
+// CatalogResponse is the response to a catalog request.
+type CatalogResponse struct {
+	osb.CatalogResponse
+
+	// InstancesRetrievable holds the IDs of the services whose instances can
+	// be fetched with GetInstance.
+	InstancesRetrievable map[string]bool `json:"instancesRetrievable,omitempty"`
+}
+
+// GetCatalog gets the catalog of the broker with the given client. The
+// response only has the fields which osb.CatalogResponse lacks when the
+// client is a Client.
+func GetCatalog(client osb.Client) (*CatalogResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.GetServiceCatalog()
+	}
+	response, err := client.GetCatalog()
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return &CatalogResponse{CatalogResponse: *response}, nil
+}
+
+// GetInstanceRequest is a request to fetch a service instance.
+type GetInstanceRequest struct {
+	// InstanceID is the ID of the instance.
+	InstanceID string `json:"instance_id"`
+	// OriginatingIdentity is the identity on the platform of the user making
+	// the request.
+	OriginatingIdentity *osb.OriginatingIdentity `json:"originatingIdentity,omitempty"`
+}
+
+// GetInstanceResponse is the response to a request to fetch a service
+// instance.
+type GetInstanceResponse struct {
+	// ServiceID is the ID of the service of the instance.
+	ServiceID string `json:"service_id,omitempty"`
+	// PlanID is the ID of the plan of the instance.
+	PlanID string `json:"plan_id,omitempty"`
+	// DashboardURL is the URL of the dashboard of the instance.
+	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Parameters are the parameters of the instance.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Metadata is the metadata of the instance, if the broker returned any.
+	Metadata *ServiceInstanceMetadata `json:"metadata,omitempty"`
+	// StateToken is the ETag header of the response, if the broker returned
+	// one.
+	StateToken string `json:"-"`
+}
+
+// GetInstance fetches a service instance with the given client. It returns
+// ErrGetInstanceNotSupported if the client is not a Client.
+func GetInstance(client osb.Client, r *GetInstanceRequest) (*GetInstanceResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.GetInstance(r)
+	}
+	return nil, ErrGetInstanceNotSupported
+}
+
+// UpdateInstanceRequest is a request to update a service instance.
+type UpdateInstanceRequest struct {
+	osb.UpdateInstanceRequest
+
+	// StateToken, if not empty, is sent in the If-Match header of the
+	// request, so that the broker refuses it if the instance changed.
+	StateToken string `json:"-"`
+}
+
+// UpdateInstanceResponse is the response to an update request.
+type UpdateInstanceResponse struct {
+	osb.UpdateInstanceResponse
+
+	// StateToken is the ETag header of the response, if the broker returned
+	// one.
+	StateToken string `json:"-"`
+}
+
+// UpdateInstance sends the update request with the given client. The state
+// token is only sent, and returned, when the client is a Client.
+func UpdateInstance(client osb.Client, r *UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.UpdateServiceInstance(r)
+	}
+	response, err := client.UpdateInstance(&r.UpdateInstanceRequest)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return &UpdateInstanceResponse{UpdateInstanceResponse: *response}, nil
+}
+
+// DeprovisionRequest is a request to deprovision a service instance.
+type DeprovisionRequest struct {
+	osb.DeprovisionRequest
+
+	// StateToken, if not empty, is sent in the If-Match header of the
+	// request, so that the broker refuses it if the instance changed.
+	StateToken string `json:"-"`
+}
+
+// DeprovisionInstance sends the deprovision request with the given client.
+// The state token is only sent when the client is a Client.
+func DeprovisionInstance(client osb.Client, r *DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.DeprovisionServiceInstance(r)
+	}
+	return client.DeprovisionInstance(&r.DeprovisionRequest)
+}
+
+// BindRequest is a request to bind to a service instance.
+type BindRequest struct {
+	osb.BindRequest
+
+	// BindResourceExtensions holds the keys of the bind resource other than
+	// app_guid and route, which are sent to the broker as they are, next to
+	// the fields of the BindResource of the request.
+	BindResourceExtensions map[string]string `json:"-"`
+}
+
+// Bind sends the bind request with the given client. The extensions of the
+// bind resource are only sent when the client is a Client.
+func Bind(client osb.Client, r *BindRequest) (*osb.BindResponse, error) {
+	if c, ok := client.(Client); ok {
+		return c.BindServiceInstance(r)
+	}
+	return client.Bind(&r.BindRequest)
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+)
+
+// internal message body types
+
+type provisionRequestBody struct {
+	ServiceID        string                 `json:"service_id"`
+	PlanID           string                 `json:"plan_id"`
+	OrganizationGUID string                 `json:"organization_guid"`
+	SpaceGUID        string                 `json:"space_guid"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Context          map[string]interface{} `json:"context,omitempty"`
+}
+
+type provisionSuccessResponseBody struct {
+	DashboardURL *string                  `json:"dashboard_url"`
+	Operation    *string                  `json:"operation"`
+	Metadata     *ServiceInstanceMetadata `json:"metadata"`
+}
+
+type updateInstanceRequestBody struct {
+	ServiceID      string                 `json:"service_id"`
+	PlanID         *string                `json:"plan_id,omitempty"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	Context        map[string]interface{} `json:"context,omitempty"`
+	PreviousValues *osb.PreviousValues    `json:"previous_values,omitempty"`
+}
+
+type updateInstanceResponseBody struct {
+	DashboardURL *string `json:"dashboard_url"`
+	Operation    *string `json:"operation"`
+}
+
+func (c *client) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	response, err := c.ProvisionServiceInstance(r)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ProvisionResponse, nil
+}
+
+func (c *client) ProvisionServiceInstance(r *osb.ProvisionRequest) (*ProvisionResponse, error) {
+	if err := validateProvisionRequest(r); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	params := map[string]string{}
+	if r.AcceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+
+	requestBody := &provisionRequestBody{
+		ServiceID:        r.ServiceID,
+		PlanID:           r.PlanID,
+		OrganizationGUID: r.OrganizationGUID,
+		SpaceGUID:        r.SpaceGUID,
+		Parameters:       r.Parameters,
+	}
+
+	if c.apiVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+
+	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+		userResponse := &ProvisionResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		if !c.apiVersion.AtLeast(osb.Version2_13()) || !c.enableAlphaFeatures {
+			userResponse.ExtensionAPIs = nil
+		}
+		userResponse.StateToken = response.Header.Get(eTag)
+
+		return userResponse, nil
+	case http.StatusAccepted:
+		if !r.AcceptsIncomplete {
+			// If the client did not signify that it could handle asynchronous
+			// operations, a '202 Accepted' response should be treated as an error.
+			return nil, c.handleFailureResponse(response)
+		}
+
+		responseBodyObj := &provisionSuccessResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		if c.verbose {
+			klog.Infof("broker %q: received asynchronous response", c.name)
+		}
+
+		return &ProvisionResponse{
+			ProvisionResponse: osb.ProvisionResponse{
+				Async:        true,
+				DashboardURL: responseBodyObj.DashboardURL,
+				OperationKey: operationKey(responseBodyObj.Operation),
+			},
+			Metadata:   responseBodyObj.Metadata,
+			StateToken: response.Header.Get(eTag),
+		}, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func validateProvisionRequest(request *osb.ProvisionRequest) error {
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+
+	if request.PlanID == "" {
+		return required("planID")
+	}
+
+	if request.OrganizationGUID == "" {
+		return required("organizationGUID")
+	}
+
+	if request.SpaceGUID == "" {
+		return required("spaceGUID")
+	}
+
+	return nil
+}
+
+func (c *client) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	response, err := c.UpdateServiceInstance(&UpdateInstanceRequest{UpdateInstanceRequest: *r})
+	if err != nil {
+		return nil, err
+	}
+	return &response.UpdateInstanceResponse, nil
+}
+
+func (c *client) UpdateServiceInstance(r *UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
+	if err := validateUpdateInstanceRequest(&r.UpdateInstanceRequest); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	params := map[string]string{}
+	if r.AcceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+
+	requestBody := &updateInstanceRequestBody{
+		ServiceID:      r.ServiceID,
+		PlanID:         r.PlanID,
+		Parameters:     r.Parameters,
+		PreviousValues: r.PreviousValues,
+	}
+
+	if c.apiVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+
+	response, err := c.prepareAndDoWithStateToken(http.MethodPatch, fullURL, params, requestBody, r.OriginatingIdentity, r.StateToken)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		if response.StatusCode == http.StatusAccepted && !r.AcceptsIncomplete {
+			// If the client did not signify that it could handle asynchronous
+			// operations, a '202 Accepted' response should be treated as an error.
+			return nil, c.handleFailureResponse(response)
+		}
+
+		responseBodyObj := &updateInstanceResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		userResponse := &UpdateInstanceResponse{StateToken: response.Header.Get(eTag)}
+		if response.StatusCode == http.StatusAccepted {
+			userResponse.Async = true
+			userResponse.OperationKey = operationKey(responseBodyObj.Operation)
+		}
+		if c.validateAlphaAPIMethodsAllowed() == nil {
+			userResponse.DashboardURL = responseBodyObj.DashboardURL
+		}
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func validateUpdateInstanceRequest(request *osb.UpdateInstanceRequest) error {
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+
+	return nil
+}
+
+func (c *client) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	return c.DeprovisionServiceInstance(&DeprovisionRequest{DeprovisionRequest: *r})
+}
+
+func (c *client) DeprovisionServiceInstance(r *DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := validateDeprovisionRequest(&r.DeprovisionRequest); err != nil {
+		return nil, err
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	params := map[string]string{
+		osb.VarKeyServiceID: r.ServiceID,
+		osb.VarKeyPlanID:    r.PlanID,
+	}
+	if r.AcceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+
+	response, err := c.prepareAndDoWithStateToken(http.MethodDelete, fullURL, params, nil, r.OriginatingIdentity, r.StateToken)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusGone:
+		return &osb.DeprovisionResponse{}, nil
+	case http.StatusAccepted:
+		if !r.AcceptsIncomplete {
+			// If the client did not signify that it could handle asynchronous
+			// operations, a '202 Accepted' response should be treated as an error.
+			return nil, c.handleFailureResponse(response)
+		}
+
+		responseBodyObj := &asyncSuccessResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, err
+		}
+
+		return &osb.DeprovisionResponse{
+			Async:        true,
+			OperationKey: operationKey(responseBodyObj.Operation),
+		}, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func validateDeprovisionRequest(request *osb.DeprovisionRequest) error {
+	if request.InstanceID == "" {
+		return required("instanceID")
+	}
+
+	if request.ServiceID == "" {
+		return required("serviceID")
+	}
+
+	if request.PlanID == "" {
+		return required("planID")
+	}
+
+	return nil
+}
+
+func (c *client) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	if r.InstanceID == "" {
+		return nil, required("instanceID")
+	}
+
+	fullURL := fmt.Sprintf(lastOperationURLFmt, c.url, r.InstanceID)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, lastOperationParams(r.ServiceID, r.PlanID, r.OperationKey), nil /* request body */, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := &osb.LastOperationResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+// lastOperationParams returns the query parameters of a last operation
+// request.
+func lastOperationParams(serviceID, planID *string, operationKey *osb.OperationKey) map[string]string {
+	params := map[string]string{}
+	if serviceID != nil {
+		params[osb.VarKeyServiceID] = *serviceID
+	}
+	if planID != nil {
+		params[osb.VarKeyPlanID] = *planID
+	}
+	if operationKey != nil {
+		params[osb.VarKeyOperation] = string(*operationKey)
+	}
+	return params
+}
+
+func (c *client) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error) {
+	if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+		return nil, fmt.Errorf("GetInstance not allowed: %v", err)
+	}
+
+	if r.InstanceID == "" {
+		return nil, required("instanceID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := &GetInstanceResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		userResponse.StateToken = response.Header.Get(eTag)
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
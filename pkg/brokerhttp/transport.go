@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// userAgentRoundTripper sets the User-Agent header of the requests it sends.
+type userAgentRoundTripper struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (rt *userAgentRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	request = request.Clone(request.Context())
+	request.Header.Set("User-Agent", rt.userAgent)
+	return rt.next.RoundTrip(request)
+}
+
+// limitedBodyRoundTripper bounds the number of bytes read from the bodies of
+// the responses it receives.
+type limitedBodyRoundTripper struct {
+	limit int64
+	next  http.RoundTripper
+}
+
+func (rt *limitedBodyRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := rt.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body = &limitedReadCloser{
+		Reader: io.LimitReader(response.Body, rt.limit+1),
+		Closer: response.Body,
+		limit:  rt.limit,
+	}
+	return response, nil
+}
+
+// limitedReadCloser returns an error once more than limit bytes are read,
+// rather than the truncated body of the response.
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+	limit int64
+	read  int64
+}
+
+func (r *limitedReadCloser) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.limit {
+		return n, fmt.Errorf("the response body exceeds the maximum size of %d bytes", r.limit)
+	}
+	return n, err
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerurl restricts the addresses that the URLs of brokers may
+// point at, so that registering a broker cannot be used to make the
+// controller send requests to the metadata service of a cloud provider or to
+// other internal endpoints.
+package brokerurl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// DefaultDeniedCIDRs are the ranges that the URLs of brokers may not point
+// at unless they are allowed: the unspecified addresses, which connect to the
+// local host, and the loopback and link-local ranges, which include the
+// metadata services of cloud providers such as 169.254.169.254.
+var DefaultDeniedCIDRs = []string{
+	"0.0.0.0/8",
+	"::/128",
+	"127.0.0.0/8",
+	"::1/128",
+	"169.254.0.0/16",
+	"fe80::/10",
+}
+
+// maxRedirects is the number of redirects a client follows, the default of
+// net/http.
+const maxRedirects = 10
+
+// Policy decides whether the URL of a broker is allowed. A host that matches
+// an allowed host pattern is allowed, a host that matches a denied host
+// pattern is denied. Otherwise the host is resolved, and the URL is denied
+// when one of its addresses is in a denied range and not in an allowed one.
+type Policy struct {
+	allowedCIDRs []*net.IPNet
+	deniedCIDRs  []*net.IPNet
+	allowedHosts []string
+	deniedHosts  []string
+
+	// lookupIP resolves the host of a URL, it is replaced in tests.
+	lookupIP func(host string) ([]net.IP, error)
+}
+
+// NewPolicy builds a Policy from lists of CIDRs and of host patterns. A host
+// pattern is either a host name, or "*." followed by a domain to match all of
+// its subdomains. It returns nil when nothing is denied, so that the URLs of
+// brokers are not resolved needlessly.
+func NewPolicy(allowedCIDRs, deniedCIDRs, allowedHosts, deniedHosts []string) (*Policy, error) {
+	p := &Policy{lookupIP: net.LookupIP}
+	var err error
+	if p.allowedCIDRs, err = parseCIDRs(allowedCIDRs); err != nil {
+		return nil, err
+	}
+	if p.deniedCIDRs, err = parseCIDRs(deniedCIDRs); err != nil {
+		return nil, err
+	}
+	if p.allowedHosts, err = parseHostPatterns(allowedHosts); err != nil {
+		return nil, err
+	}
+	if p.deniedHosts, err = parseHostPatterns(deniedHosts); err != nil {
+		return nil, err
+	}
+	if len(p.deniedCIDRs) == 0 && len(p.deniedHosts) == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func parseHostPatterns(patterns []string) ([]string, error) {
+	var hosts []string
+	for _, pattern := range patterns {
+		host := strings.ToLower(strings.TrimSpace(pattern))
+		if host == "" || host == "*." || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, fmt.Errorf("invalid host pattern %q: must be a host name, or *. followed by a domain", pattern)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// Check returns an error when rawURL is denied by the policy. A nil policy
+// allows every URL. A host that cannot be resolved is denied, since the
+// policy can not tell where it points at.
+func (p *Policy) Check(rawURL string) error {
+	if p == nil {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid broker URL %q: %v", rawURL, err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if matchesHost(p.allowedHosts, host) {
+		return nil
+	}
+	if matchesHost(p.deniedHosts, host) {
+		return &DeniedError{URL: rawURL, Reason: fmt.Sprintf("the host %q is denied", host)}
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		if ips, err = p.lookupIP(host); err != nil {
+			return &DeniedError{URL: rawURL, Reason: fmt.Sprintf("the host %q could not be resolved: %v", host, err)}
+		}
+	}
+	for _, ip := range ips {
+		if p.isDeniedIP(ip) {
+			return &DeniedError{URL: rawURL, Reason: fmt.Sprintf("the address %v of host %q is in a denied range", ip, host)}
+		}
+	}
+	return nil
+}
+
+// DialContext returns a function which dials with dialer, like
+// net.Dialer.DialContext, but refuses to connect to the addresses denied by
+// the policy. The address is checked once it is resolved, right before the
+// connection is made, so that a host which resolves to a denied address only
+// after its URL was checked is refused too. With a proxy, the address of the
+// proxy is the one checked. A nil policy returns dialer.DialContext.
+func (p *Policy) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	if p == nil {
+		return dialer.DialContext
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		host = strings.ToLower(host)
+		if matchesHost(p.allowedHosts, host) {
+			return dialer.DialContext(ctx, network, address)
+		}
+		if matchesHost(p.deniedHosts, host) {
+			return nil, &DeniedError{URL: address, Reason: fmt.Sprintf("the host %q is denied", host)}
+		}
+		d := *dialer
+		d.Control = func(network, resolved string, c syscall.RawConn) error {
+			ipString, _, err := net.SplitHostPort(resolved)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(ipString); ip == nil || p.isDeniedIP(ip) {
+				return &DeniedError{URL: address, Reason: fmt.Sprintf("the address %v of host %q is in a denied range", ipString, host)}
+			}
+			if dialer.Control != nil {
+				return dialer.Control(network, resolved, c)
+			}
+			return nil
+		}
+		return d.DialContext(ctx, network, address)
+	}
+}
+
+// CheckRedirect is the CheckRedirect of an http.Client which follows the
+// redirects whose URL is allowed by the policy, up to the number of
+// redirects that net/http follows by default.
+func (p *Policy) CheckRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return p.Check(request.URL.String())
+}
+
+// isDeniedIP returns whether ip is in a denied range and not in an allowed
+// one.
+func (p *Policy) isDeniedIP(ip net.IP) bool {
+	return containsIP(p.deniedCIDRs, ip) && !containsIP(p.allowedCIDRs, ip)
+}
+
+func matchesHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if domain := strings.TrimPrefix(pattern, "*."); domain != pattern {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniedError is returned by Check for a URL denied by the policy.
+type DeniedError struct {
+	URL    string
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("the broker URL %q is not allowed: %s", e.URL, e.Reason)
+}
+
+// IsDeniedError returns whether err is a DeniedError, or an error of a
+// request or of a connection which was refused because of one.
+func IsDeniedError(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *DeniedError:
+			return true
+		case *url.Error:
+			err = e.Err
+		case *net.OpError:
+			err = e.Err
+		case *os.SyscallError:
+			err = e.Err
+		default:
+			return false
+		}
+	}
+	return false
+}
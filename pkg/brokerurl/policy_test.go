@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	policy, err := NewPolicy(
+		[]string{"169.254.10.0/24"},
+		append(DefaultDeniedCIDRs, "10.0.0.0/8"),
+		[]string{"broker.internal.example.com"},
+		[]string{"*.metadata.example.com"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	policy.lookupIP = func(host string) ([]net.IP, error) {
+		switch host {
+		case "broker.example.com":
+			return []net.IP{net.ParseIP("203.0.113.10")}, nil
+		case "metadata.example.com":
+			return []net.IP{net.ParseIP("203.0.113.10"), net.ParseIP("169.254.169.254")}, nil
+		case "broker.internal.example.com":
+			return []net.IP{net.ParseIP("10.0.0.5")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	cases := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://broker.example.com", true},
+		{"https://203.0.113.10:8443/osb", true},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://127.0.0.1:8080", false},
+		{"http://[::1]:8080", false},
+		{"http://[fe80::1]", false},
+		{"http://0.0.0.0:8080", false},
+		{"http://[::]:8080", false},
+		{"http://10.1.2.3", false},
+		{"https://metadata.example.com", false},
+		{"https://compute.metadata.example.com", false},
+		{"https://BROKER.internal.example.com", true},
+		{"http://169.254.10.5", true},
+		{"https://unknown.example.com", false},
+	}
+	for _, tc := range cases {
+		err := policy.Check(tc.url)
+		if tc.allowed && err != nil {
+			t.Errorf("%s: expected the URL to be allowed, got %v", tc.url, err)
+		}
+		if !tc.allowed && !IsDeniedError(err) {
+			t.Errorf("%s: expected the URL to be denied, got %v", tc.url, err)
+		}
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	if policy, err := NewPolicy(nil, nil, []string{"broker.example.com"}, nil); err != nil || policy != nil {
+		t.Errorf("expected no policy when nothing is denied, got %v, %v", policy, err)
+	}
+	if err := (*Policy)(nil).Check("http://169.254.169.254"); err != nil {
+		t.Errorf("expected a nil policy to allow every URL, got %v", err)
+	}
+	if _, err := NewPolicy(nil, []string{"169.254.0.0"}, nil, nil); err == nil {
+		t.Error("expected an error for a CIDR without a prefix length")
+	}
+	if _, err := NewPolicy(nil, DefaultDeniedCIDRs, []string{"broker.*.example.com"}, nil); err == nil {
+		t.Error("expected an error for a host pattern with a wildcard that is not a prefix")
+	}
+}
+
+// TestPolicyDialContext tests that the connections to the addresses denied
+// by the policy are refused when the address is resolved, whatever the URL
+// of the request.
+func TestPolicyDialContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	cases := []struct {
+		name         string
+		allowedCIDRs []string
+		allowedHosts []string
+		deniedHosts  []string
+		address      string
+		allowed      bool
+	}{
+		{
+			name:    "denied address",
+			address: "127.0.0.1:" + port,
+		},
+		{
+			name:    "host resolving to a denied address",
+			address: "localhost:" + port,
+		},
+		{
+			name:         "allowed range",
+			allowedCIDRs: []string{"127.0.0.0/8"},
+			address:      "127.0.0.1:" + port,
+			allowed:      true,
+		},
+		{
+			name:         "allowed host",
+			allowedHosts: []string{"localhost"},
+			address:      "localhost:" + port,
+			allowed:      true,
+		},
+		{
+			name:         "denied host",
+			allowedCIDRs: []string{"127.0.0.0/8"},
+			deniedHosts:  []string{"localhost"},
+			address:      "localhost:" + port,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPolicy(tc.allowedCIDRs, DefaultDeniedCIDRs, tc.allowedHosts, tc.deniedHosts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			conn, err := policy.DialContext(&net.Dialer{})(context.Background(), "tcp", tc.address)
+			if conn != nil {
+				conn.Close()
+			}
+			if tc.allowed && err != nil {
+				t.Fatalf("expected the connection to be allowed, got %v", err)
+			}
+			if !tc.allowed && !IsDeniedError(err) {
+				t.Fatalf("expected the connection to be denied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckRedirect(t *testing.T) {
+	policy, err := NewPolicy(nil, DefaultDeniedCIDRs, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	redirect := func(rawURL string) *http.Request {
+		u, _ := url.Parse(rawURL)
+		return &http.Request{URL: u}
+	}
+	if err := policy.CheckRedirect(redirect("http://169.254.169.254/latest/meta-data"), []*http.Request{{}}); !IsDeniedError(err) {
+		t.Errorf("expected the redirect to be denied, got %v", err)
+	}
+	if err := policy.CheckRedirect(redirect("https://203.0.113.10/v2/catalog"), []*http.Request{{}}); err != nil {
+		t.Errorf("expected the redirect to be allowed, got %v", err)
+	}
+	if err := policy.CheckRedirect(redirect("https://203.0.113.10/v2/catalog"), make([]*http.Request, maxRedirects)); err == nil {
+		t.Errorf("expected an error after %d redirects", maxRedirects)
+	}
+}
+
+func TestIsDeniedError(t *testing.T) {
+	denied := &DeniedError{URL: "http://127.0.0.1", Reason: "denied"}
+	if !IsDeniedError(&url.Error{Op: "Get", URL: "http://127.0.0.1", Err: &net.OpError{Op: "dial", Err: denied}}) {
+		t.Error("expected a request refused by the policy to be a denied error")
+	}
+	if IsDeniedError(&url.Error{Op: "Get", URL: "http://127.0.0.1", Err: errors.New("connection refused")}) {
+		t.Error("expected another error not to be a denied error")
+	}
+}
@@ -56,6 +56,10 @@ func (c *FakeServicecatalogV1beta1) ServiceInstances(namespace string) v1beta1.S
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalogV1beta1) ServiceInstanceTemplates(namespace string) v1beta1.ServiceInstanceTemplateInterface {
+	return &FakeServiceInstanceTemplates{c, namespace}
+}
+
 func (c *FakeServicecatalogV1beta1) ServicePlans(namespace string) v1beta1.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
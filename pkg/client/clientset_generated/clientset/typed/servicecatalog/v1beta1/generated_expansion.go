@@ -32,4 +32,6 @@ type ServiceClassExpansion interface{}
 
 type ServiceInstanceExpansion interface{}
 
+type ServiceInstanceTemplateExpansion interface{}
+
 type ServicePlanExpansion interface{}
@@ -33,6 +33,7 @@ type ServicecatalogV1beta1Interface interface {
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceTemplatesGetter
 	ServicePlansGetter
 }
 
@@ -69,6 +70,10 @@ func (c *ServicecatalogV1beta1Client) ServiceInstances(namespace string) Service
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogV1beta1Client) ServiceInstanceTemplates(namespace string) ServiceInstanceTemplateInterface {
+	return newServiceInstanceTemplates(c, namespace)
+}
+
 func (c *ServicecatalogV1beta1Client) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
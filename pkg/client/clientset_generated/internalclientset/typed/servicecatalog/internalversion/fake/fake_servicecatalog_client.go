@@ -56,6 +56,10 @@ func (c *FakeServicecatalog) ServiceInstances(namespace string) internalversion.
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalog) ServiceInstanceTemplates(namespace string) internalversion.ServiceInstanceTemplateInterface {
+	return &FakeServiceInstanceTemplates{c, namespace}
+}
+
 func (c *FakeServicecatalog) ServicePlans(namespace string) internalversion.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
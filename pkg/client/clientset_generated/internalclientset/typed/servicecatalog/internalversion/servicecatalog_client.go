@@ -32,6 +32,7 @@ type ServicecatalogInterface interface {
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceTemplatesGetter
 	ServicePlansGetter
 }
 
@@ -68,6 +69,10 @@ func (c *ServicecatalogClient) ServiceInstances(namespace string) ServiceInstanc
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogClient) ServiceInstanceTemplates(namespace string) ServiceInstanceTemplateInterface {
+	return newServiceInstanceTemplates(c, namespace)
+}
+
 func (c *ServicecatalogClient) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
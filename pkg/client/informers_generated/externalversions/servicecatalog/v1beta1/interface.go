@@ -38,6 +38,8 @@ type Interface interface {
 	ServiceClasses() ServiceClassInformer
 	// ServiceInstances returns a ServiceInstanceInformer.
 	ServiceInstances() ServiceInstanceInformer
+	// ServiceInstanceTemplates returns a ServiceInstanceTemplateInformer.
+	ServiceInstanceTemplates() ServiceInstanceTemplateInformer
 	// ServicePlans returns a ServicePlanInformer.
 	ServicePlans() ServicePlanInformer
 }
@@ -88,6 +90,11 @@ func (v *version) ServiceInstances() ServiceInstanceInformer {
 	return &serviceInstanceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ServiceInstanceTemplates returns a ServiceInstanceTemplateInformer.
+func (v *version) ServiceInstanceTemplates() ServiceInstanceTemplateInformer {
+	return &serviceInstanceTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServicePlans returns a ServicePlanInformer.
 func (v *version) ServicePlans() ServicePlanInformer {
 	return &servicePlanInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
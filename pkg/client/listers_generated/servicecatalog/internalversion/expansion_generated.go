@@ -62,6 +62,14 @@ type ServiceInstanceListerExpansion interface{}
 // ServiceInstanceNamespaceLister.
 type ServiceInstanceNamespaceListerExpansion interface{}
 
+// ServiceInstanceTemplateListerExpansion allows custom methods to be added to
+// ServiceInstanceTemplateLister.
+type ServiceInstanceTemplateListerExpansion interface{}
+
+// ServiceInstanceTemplateNamespaceListerExpansion allows custom methods to be added to
+// ServiceInstanceTemplateNamespaceLister.
+type ServiceInstanceTemplateNamespaceListerExpansion interface{}
+
 // ServicePlanListerExpansion allows custom methods to be added to
 // ServicePlanLister.
 type ServicePlanListerExpansion interface{}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// bindingCredentialsCache holds the credentials returned by the broker for
+// bindings whose Secret could not be written yet, so that writing the Secret
+// is retried without sending the bind request again. The credentials are
+// only kept in memory: after a restart of the controller they are fetched
+// from the broker when its bindings are retrievable.
+type bindingCredentialsCache struct {
+	mu          sync.Mutex
+	credentials map[types.UID]map[string]interface{}
+}
+
+func newBindingCredentialsCache() *bindingCredentialsCache {
+	return &bindingCredentialsCache{
+		credentials: map[types.UID]map[string]interface{}{},
+	}
+}
+
+// Get returns the cached credentials of the binding with the given UID.
+func (c *bindingCredentialsCache) Get(uid types.UID) (map[string]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	credentials, found := c.credentials[uid]
+	return credentials, found
+}
+
+// Set caches the credentials of the binding with the given UID.
+func (c *bindingCredentialsCache) Set(uid types.UID, credentials map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.credentials[uid] = credentials
+}
+
+// Delete removes the cached credentials of the binding with the given UID.
+func (c *bindingCredentialsCache) Delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.credentials, uid)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+)
+
+// cachedCatalog is a catalog fetched from a broker whose classes and plans
+// could not all be written. It is only used by the worker reconciling the
+// broker, so it is not locked.
+type cachedCatalog struct {
+	// catalog is the catalog returned by the broker.
+	catalog *brokerhttp.CatalogResponse
+	// hash is the hash of the catalog.
+	hash string
+	// generation is the generation of the broker the catalog was fetched
+	// for. A change of the spec of the broker invalidates the catalog.
+	generation int64
+	// fetchTime is when the catalog was fetched.
+	fetchTime time.Time
+	// appliedClasses and appliedPlans hold the names of the classes and of
+	// the plans that were written. They are kept apart since a class and a
+	// plan may have the same name. The classes and plans marked as removed
+	// from the catalog are listed as such by a retry, so they are not
+	// recorded.
+	appliedClasses sets.String
+	appliedPlans   sets.String
+}
+
+// brokerCatalogCache holds the catalogs fetched from brokers whose classes
+// and plans could not all be written, so that a retry of the sync writes the
+// remaining objects without fetching the catalog again. The catalogs are
+// only kept in memory.
+type brokerCatalogCache struct {
+	mu       sync.Mutex
+	catalogs map[types.UID]*cachedCatalog
+}
+
+func newBrokerCatalogCache() *brokerCatalogCache {
+	return &brokerCatalogCache{
+		catalogs: map[types.UID]*cachedCatalog{},
+	}
+}
+
+// Get returns the cached catalog of the broker with the given UID.
+func (c *brokerCatalogCache) Get(uid types.UID) (*cachedCatalog, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	catalog, found := c.catalogs[uid]
+	return catalog, found
+}
+
+// Set caches the catalog of the broker with the given UID.
+func (c *brokerCatalogCache) Set(uid types.UID, catalog *cachedCatalog) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.catalogs[uid] = catalog
+}
+
+// Delete removes the cached catalog of the broker with the given UID.
+func (c *brokerCatalogCache) Delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.catalogs, uid)
+}
+
+// hashCatalog returns the hash of the catalog returned by a broker.
+func hashCatalog(catalog *brokerhttp.CatalogResponse) (string, error) {
+	catalogAsJSON, err := json.Marshal(catalog)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(catalogAsJSON)), nil
+}
+
+// catalogForSync returns the catalog to sync for the broker with the given
+// UID and generation. The catalog cached by a previous sync whose writes
+// failed is reused, unless the spec of the broker changed or the relist
+// interval elapsed since it was fetched. Otherwise the catalog is fetched;
+// the objects written by the previous sync are only skipped when the hash of
+// the fetched catalog is the hash of the cached catalog. The returned boolean
+// reports whether the catalog was fetched.
+func (c *controller) catalogForSync(uid types.UID, generation int64, relistInterval time.Duration, now time.Time, getCatalog func() (*brokerhttp.CatalogResponse, error)) (*cachedCatalog, bool, error) {
+	cached, found := c.brokerCatalogs.Get(uid)
+	if found && cached.generation != generation {
+		c.brokerCatalogs.Delete(uid)
+		found = false
+	}
+	if found && now.Before(cached.fetchTime.Add(relistInterval)) {
+		return cached, false, nil
+	}
+
+	catalog, err := getCatalog()
+	if err != nil {
+		return nil, true, err
+	}
+	hash, err := hashCatalog(catalog)
+	if err != nil {
+		return nil, true, err
+	}
+	fetched := &cachedCatalog{
+		catalog:        catalog,
+		hash:           hash,
+		generation:     generation,
+		fetchTime:      now,
+		appliedClasses: sets.NewString(),
+		appliedPlans:   sets.NewString(),
+	}
+	if found && cached.hash == hash {
+		fetched.appliedClasses = cached.appliedClasses
+		fetched.appliedPlans = cached.appliedPlans
+	}
+	return fetched, true, nil
+}
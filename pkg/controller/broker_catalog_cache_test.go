@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+)
+
+// TestCatalogForSync tests when the cached catalog of a broker is reused, and
+// when the objects written by the previous sync are skipped after fetching
+// the catalog again.
+func TestCatalogForSync(t *testing.T) {
+	const uid = types.UID("broker-uid")
+	fetchTime := time.Now().Add(-10 * time.Minute)
+	changedCatalog := getTestCatalog()
+	changedCatalog.Services[0].Description = "a changed test service"
+	cases := []struct {
+		name            string
+		generation      int64
+		now             time.Time
+		catalog         *osb.CatalogResponse
+		expectedFetched bool
+		expectedApplied bool
+	}{
+		{
+			name:            "within the relist interval",
+			generation:      1,
+			now:             fetchTime.Add(time.Minute),
+			catalog:         getTestCatalog(),
+			expectedApplied: true,
+		},
+		{
+			name:            "spec changed",
+			generation:      2,
+			now:             fetchTime.Add(time.Minute),
+			catalog:         getTestCatalog(),
+			expectedFetched: true,
+		},
+		{
+			name:            "relist interval elapsed with the same catalog",
+			generation:      1,
+			now:             fetchTime.Add(time.Hour),
+			catalog:         getTestCatalog(),
+			expectedFetched: true,
+			expectedApplied: true,
+		},
+		{
+			name:            "relist interval elapsed with a changed catalog",
+			generation:      1,
+			now:             fetchTime.Add(time.Hour),
+			catalog:         changedCatalog,
+			expectedFetched: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+			hash, err := hashCatalog(&brokerhttp.CatalogResponse{CatalogResponse: *getTestCatalog()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cached := &cachedCatalog{
+				catalog:        &brokerhttp.CatalogResponse{CatalogResponse: *getTestCatalog()},
+				hash:           hash,
+				generation:     1,
+				fetchTime:      fetchTime,
+				appliedClasses: sets.NewString(testClusterServiceClassGUID),
+				appliedPlans:   sets.NewString(testClusterServicePlanGUID),
+			}
+			testController.brokerCatalogs.Set(uid, cached)
+
+			getCatalogCalls := 0
+			actual, fetched, err := testController.catalogForSync(uid, tc.generation, 15*time.Minute, tc.now, func() (*brokerhttp.CatalogResponse, error) {
+				getCatalogCalls++
+				return &brokerhttp.CatalogResponse{CatalogResponse: *tc.catalog}, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expectedFetched, fetched; e != a {
+				t.Fatalf("unexpected fetch: %s", expectedGot(e, a))
+			}
+			if e, a := tc.expectedFetched, getCatalogCalls == 1; e != a {
+				t.Fatalf("unexpected call of GetCatalog: %s", expectedGot(e, a))
+			}
+			if e, a := tc.expectedApplied, actual.appliedClasses.Has(testClusterServiceClassGUID); e != a {
+				t.Fatalf("unexpected applied classes: %s", expectedGot(e, a))
+			}
+			if e, a := tc.expectedApplied, actual.appliedPlans.Has(testClusterServicePlanGUID); e != a {
+				t.Fatalf("unexpected applied plans: %s", expectedGot(e, a))
+			}
+			if actual.appliedPlans.Has(testClusterServiceClassGUID) {
+				t.Fatal("the applied class was recorded as an applied plan")
+			}
+			// a reused catalog keeps the time it was fetched
+			expectedFetchTime := fetchTime
+			if tc.expectedFetched {
+				expectedFetchTime = tc.now
+			}
+			if e, a := expectedFetchTime, actual.fetchTime; !e.Equal(a) {
+				t.Fatalf("unexpected fetch time: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
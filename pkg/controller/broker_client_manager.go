@@ -22,6 +22,8 @@ import (
 	"sync"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
 	"k8s.io/klog"
 )
 
@@ -60,16 +62,43 @@ func NewClusterServiceBrokerKey(name string) BrokerKey {
 	}
 }
 
+// BrokerClientConfiguration is the configuration of the client of a broker:
+// the configuration of the OSB client, and the settings of service catalog
+// that the OSB client has no field for.
+type BrokerClientConfiguration struct {
+	osb.ClientConfiguration
+	// UserAgent is the User-Agent of the requests to the broker.
+	UserAgent string
+	// Protocol is the protocol with which the broker is contacted.
+	Protocol v1beta1.ServiceBrokerProtocol
+	// URLPolicy, if not nil, is the policy of the addresses the client may
+	// connect to.
+	URLPolicy *brokerurl.Policy
+}
+
+// BrokerClientCreateFunc creates the client of a broker from its
+// configuration.
+type BrokerClientCreateFunc func(config *BrokerClientConfiguration) (osb.Client, error)
+
+// NewBrokerClientCreateFunc returns a BrokerClientCreateFunc that creates
+// the clients with a CreateFunc of the OSB client, which ignores the settings
+// that the OSB client has no field for.
+func NewBrokerClientCreateFunc(createFunc osb.CreateFunc) BrokerClientCreateFunc {
+	return func(config *BrokerClientConfiguration) (osb.Client, error) {
+		return createFunc(&config.ClientConfiguration)
+	}
+}
+
 // BrokerClientManager stores OSB client instances per broker
 type BrokerClientManager struct {
 	mu      sync.RWMutex
 	clients map[BrokerKey]clientWithConfig
 
-	brokerClientCreateFunc osb.CreateFunc
+	brokerClientCreateFunc BrokerClientCreateFunc
 }
 
 // NewBrokerClientManager creates BrokerClientManager instance
-func NewBrokerClientManager(brokerClientCreateFunc osb.CreateFunc) *BrokerClientManager {
+func NewBrokerClientManager(brokerClientCreateFunc BrokerClientCreateFunc) *BrokerClientManager {
 	return &BrokerClientManager{
 		clients:                map[BrokerKey]clientWithConfig{},
 		brokerClientCreateFunc: brokerClientCreateFunc,
@@ -78,7 +107,7 @@ func NewBrokerClientManager(brokerClientCreateFunc osb.CreateFunc) *BrokerClient
 
 // UpdateBrokerClient creates new broker client if necessary (the ClientConfig has changed or there is no client for the broker),
 // the method returns created or stored osb.Client instance.
-func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
+func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConfig *BrokerClientConfiguration) (osb.Client, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -92,6 +121,17 @@ func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConf
 	return existing.OSBClient, nil
 }
 
+// RecreateBrokerClient creates a new broker client even if the ClientConfig
+// has not changed, so that none of the connections of the previous client are
+// reused. The method returns the created osb.Client instance.
+func (m *BrokerClientManager) RecreateBrokerClient(brokerKey BrokerKey, clientConfig *BrokerClientConfiguration) (osb.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	klog.V(4).Infof("Recreating OSB client for broker %q, URL: %s", brokerKey.String(), clientConfig.URL)
+	return m.createClient(brokerKey, clientConfig)
+}
+
 // RemoveBrokerClient removes broker client broker
 func (m *BrokerClientManager) RemoveBrokerClient(brokerKey BrokerKey) {
 	m.mu.Lock()
@@ -110,8 +150,27 @@ func (m *BrokerClientManager) BrokerClient(brokerKey BrokerKey) (osb.Client, boo
 	return existing.OSBClient, found
 }
 
-func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
-	client, err := m.brokerClientCreateFunc(clientConfig)
+// BrokerAPIVersion returns the OSB API version of the client of the broker
+// specified by the brokerKey
+func (m *BrokerClientManager) BrokerAPIVersion(brokerKey BrokerKey) (osb.APIVersion, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	existing, found := m.clients[brokerKey]
+	if !found || existing.clientConfig == nil {
+		return osb.APIVersion{}, false
+	}
+	return existing.clientConfig.APIVersion, true
+}
+
+func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *BrokerClientConfiguration) (osb.Client, error) {
+	// The OSB client modifies the TLS config it is given, so it gets a copy
+	// and the stored config can still be compared with the next one.
+	createConfig := *clientConfig
+	if clientConfig.TLSConfig != nil {
+		createConfig.TLSConfig = clientConfig.TLSConfig.Clone()
+	}
+	client, err := m.brokerClientCreateFunc(&createConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -123,11 +182,11 @@ func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *os
 	return client, nil
 }
 
-func configHasChanged(cfg1 *osb.ClientConfiguration, cfg2 *osb.ClientConfiguration) bool {
+func configHasChanged(cfg1 *BrokerClientConfiguration, cfg2 *BrokerClientConfiguration) bool {
 	return !reflect.DeepEqual(cfg1, cfg2)
 }
 
 type clientWithConfig struct {
 	OSBClient    osb.Client
-	clientConfig *osb.ClientConfiguration
+	clientConfig *BrokerClientConfiguration
 }
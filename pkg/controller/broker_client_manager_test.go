@@ -17,16 +17,20 @@ limitations under the License.
 package controller_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"testing"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestBrokerClientManager_CreateBrokerClient(t *testing.T) {
 	// GIVEN
-	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
-	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
+	osbCl1, _ := osb.NewClient(&testOsbConfig("osb-1").ClientConfiguration)
+	osbCl2, _ := osb.NewClient(&testOsbConfig("osb-2").ClientConfiguration)
 	brokerClientFunc := clientFunc(osbCl1, osbCl2)
 	manager := controller.NewBrokerClientManager(brokerClientFunc)
 
@@ -65,8 +69,8 @@ func TestBrokerClientManager_CreateBrokerClient(t *testing.T) {
 
 func TestBrokerClientManager_RemoveBrokerClient(t *testing.T) {
 	// GIVEN
-	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
-	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
+	osbCl1, _ := osb.NewClient(&testOsbConfig("osb-1").ClientConfiguration)
+	osbCl2, _ := osb.NewClient(&testOsbConfig("osb-2").ClientConfiguration)
 	brokerClientFunc := clientFunc(osbCl1, osbCl2)
 	manager := controller.NewBrokerClientManager(brokerClientFunc)
 
@@ -88,9 +92,9 @@ func TestBrokerClientManager_RemoveBrokerClient(t *testing.T) {
 
 func TestBrokerClientManager_UpdateBrokerClient(t *testing.T) {
 	// GIVEN
-	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
-	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
-	osbCl3, _ := osb.NewClient(testOsbConfig("osb-3"))
+	osbCl1, _ := osb.NewClient(&testOsbConfig("osb-1").ClientConfiguration)
+	osbCl2, _ := osb.NewClient(&testOsbConfig("osb-2").ClientConfiguration)
+	osbCl3, _ := osb.NewClient(&testOsbConfig("osb-3").ClientConfiguration)
 	brokerClientFunc := clientFunc(osbCl1, osbCl2, osbCl3)
 	manager := controller.NewBrokerClientManager(brokerClientFunc)
 
@@ -124,17 +128,48 @@ func TestBrokerClientManager_UpdateBrokerClient(t *testing.T) {
 	}
 }
 
-func clientFunc(clients ...osb.Client) osb.CreateFunc {
+func TestBrokerClientManager_UpdateBrokerClientWithTLSConfig(t *testing.T) {
+	// GIVEN
+	created := 0
+	brokerClientFunc := func(cfg *controller.BrokerClientConfiguration) (osb.Client, error) {
+		// like the OSB client, modify the TLS config
+		cfg.TLSConfig.RootCAs = x509.NewCertPool()
+		created++
+		return osb.NewClient(&testOsbConfig(cfg.Name).ClientConfiguration)
+	}
+	manager := controller.NewBrokerClientManager(brokerClientFunc)
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	newConfig := func() *controller.BrokerClientConfiguration {
+		return controller.NewClientConfigurationForBroker(metav1.ObjectMeta{Name: "broker1"}, &v1beta1.CommonServiceBrokerSpec{URL: "https://broker"}, nil, 0, tlsConfig, "")
+	}
+
+	// WHEN
+	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), newConfig())
+	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), newConfig())
+
+	// THEN
+	if created != 1 {
+		t.Fatalf("Broker client must not be recreated for the same TLS config, created %d clients", created)
+	}
+	if tlsConfig.RootCAs != nil {
+		t.Fatal("The TLS config of the controller must not be modified")
+	}
+}
+
+func clientFunc(clients ...osb.Client) controller.BrokerClientCreateFunc {
 	var i = 0
-	return func(_ *osb.ClientConfiguration) (osb.Client, error) {
+	return func(_ *controller.BrokerClientConfiguration) (osb.Client, error) {
 		client := clients[i]
 		i++
 		return client, nil
 	}
 }
 
-func testOsbConfig(name string) *osb.ClientConfiguration {
-	return &osb.ClientConfiguration{
-		Name: name,
+func testOsbConfig(name string) *controller.BrokerClientConfiguration {
+	return &controller.BrokerClientConfiguration{
+		ClientConfiguration: osb.ClientConfiguration{
+			Name: name,
+		},
 	}
 }
@@ -0,0 +1,242 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+)
+
+const (
+	brokerReachableReason    string = "BrokerReachable"
+	brokerReachableMessage   string = "The broker answered its health check."
+	brokerUnreachableReason  string = "BrokerUnreachable"
+	brokerUnreachableMessage string = "The broker did not answer its health check: %v"
+)
+
+// BrokerHealthProbeFunc probes the broker of the given client configuration.
+// path is the health check path of the broker, an empty path probes its
+// catalog. It returns an error when the broker is not reachable.
+type BrokerHealthProbeFunc func(config *BrokerClientConfiguration, path string) error
+
+// ProbeBrokerHealth is a BrokerHealthProbeFunc that sends a GET request for
+// the path, or a HEAD request for the catalog when the path is empty, with
+// the transport, the TLS settings and the credentials of the client of the
+// broker. The broker is reachable when it answers with a status below 500, so
+// that a broker that does not implement HEAD for its catalog is reachable too.
+func ProbeBrokerHealth(config *BrokerClientConfiguration, path string) error {
+	method := http.MethodGet
+	if path == "" {
+		method = http.MethodHead
+		path = "/v2/catalog"
+	}
+
+	httpClient, err := brokerhttp.NewHTTPClient(&config.ClientConfiguration, brokerhttp.Options{UserAgent: config.UserAgent, URLPolicy: config.URLPolicy})
+	if err != nil {
+		return err
+	}
+	defer httpClient.CloseIdleConnections()
+
+	request, err := http.NewRequest(method, strings.TrimRight(config.URL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(osb.APIVersionHeader, config.APIVersion.HeaderValue())
+	if config.AuthConfig != nil {
+		if config.AuthConfig.BasicAuthConfig != nil {
+			request.SetBasicAuth(config.AuthConfig.BasicAuthConfig.Username, config.AuthConfig.BasicAuthConfig.Password)
+		} else if config.AuthConfig.BearerConfig != nil {
+			request.Header.Set("Authorization", "Bearer "+config.AuthConfig.BearerConfig.Token)
+		}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s %s returned status %d", method, path, response.StatusCode)
+	}
+	return nil
+}
+
+// errorBrokerHealthProbeNotSupported is the error of the probe of a broker
+// whose protocol is not HTTP.
+var errorBrokerHealthProbeNotSupported = errors.New("the health check of the broker is only supported for the HTTP protocol")
+
+// isBrokerHealthCheckEnabled returns whether the broker of the given spec is
+// probed between relists: it must enable its health check, and the health
+// check interval of the controller must not be zero.
+func (c *controller) isBrokerHealthCheckEnabled(commonSpec *v1beta1.CommonServiceBrokerSpec) bool {
+	return c.brokerHealthCheckInterval > 0 && commonSpec.HealthCheck != nil
+}
+
+// timeUntilNextHealthCheck returns how long until the broker of the given
+// spec and status is probed again, and whether it is probed at all. A broker
+// that was never probed is probed immediately.
+func (c *controller) timeUntilNextHealthCheck(commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, now time.Time) (time.Duration, bool) {
+	if !c.isBrokerHealthCheckEnabled(commonSpec) {
+		return 0, false
+	}
+	if commonStatus.LastHealthCheckTime == nil {
+		return 0, true
+	}
+	d := commonStatus.LastHealthCheckTime.Add(c.brokerHealthCheckInterval).Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// probeCommonBroker probes the broker of the given client configuration.
+func (c *controller) probeCommonBroker(commonSpec *v1beta1.CommonServiceBrokerSpec, clientConfig *BrokerClientConfiguration) error {
+	if commonSpec.Protocol != "" && commonSpec.Protocol != v1beta1.ServiceBrokerProtocolHTTP {
+		return errorBrokerHealthProbeNotSupported
+	}
+	return c.brokerHealthProbe(clientConfig, commonSpec.HealthCheck.Path)
+}
+
+// updateCommonReachableCondition sets the Reachable condition and the last
+// health check time of the given CommonServiceBrokerStatus from the error of
+// the probe of the broker. It returns the message of the warning event to
+// record when the broker became unreachable, or an empty string.
+func updateCommonReachableCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, probeErr error, now time.Time) string {
+	checkTime := metav1.NewTime(now)
+	commonStatus.LastHealthCheckTime = &checkTime
+
+	if probeErr == nil {
+		updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionReachable, v1beta1.ConditionTrue, brokerReachableReason, brokerReachableMessage)
+		return ""
+	}
+
+	wasUnreachable := false
+	for _, cond := range commonStatus.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionReachable && cond.Status == v1beta1.ConditionFalse {
+			wasUnreachable = true
+		}
+	}
+	s := fmt.Sprintf(brokerUnreachableMessage, probeErr)
+	klog.Warning(pcb.Message(s))
+	updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionReachable, v1beta1.ConditionFalse, brokerUnreachableReason, s)
+	if wasUnreachable {
+		return ""
+	}
+	return s
+}
+
+// clearCommonReachableCondition removes the Reachable condition and the last
+// health check time of the given CommonServiceBrokerStatus, once the health
+// check of the broker is disabled. It returns whether the status changed.
+func clearCommonReachableCondition(commonStatus *v1beta1.CommonServiceBrokerStatus) bool {
+	changed := commonStatus.LastHealthCheckTime != nil
+	commonStatus.LastHealthCheckTime = nil
+	for i, cond := range commonStatus.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionReachable {
+			commonStatus.Conditions = append(commonStatus.Conditions[:i], commonStatus.Conditions[i+1:]...)
+			changed = true
+			break
+		}
+	}
+	return changed
+}
+
+// checkClusterServiceBrokerHealth probes the broker when its health check is
+// due, and updates its Reachable condition. It returns the updated broker.
+func (c *controller) checkClusterServiceBrokerHealth(broker *v1beta1.ClusterServiceBroker) (*v1beta1.ClusterServiceBroker, error) {
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+	now := time.Now()
+
+	toUpdate := broker.DeepCopy()
+	message := ""
+	if d, ok := c.timeUntilNextHealthCheck(&broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, now); !ok {
+		if !clearCommonReachableCondition(&toUpdate.Status.CommonServiceBrokerStatus) {
+			return broker, nil
+		}
+	} else if d > 0 {
+		return broker, nil
+	} else {
+		authConfig, err := c.getAuthCredentialsFromClusterServiceBroker(broker)
+		if err != nil {
+			klog.Info(pcb.Messagef("Error getting broker auth credentials for the health check: %v", err))
+			return nil, err
+		}
+		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
+		probeErr := c.probeCommonBroker(&broker.Spec.CommonServiceBrokerSpec, clientConfig)
+		message = updateCommonReachableCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, probeErr, now)
+	}
+
+	toUpdate.RecalculatePrinterColumnStatusFields()
+	updated, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
+	if err != nil {
+		klog.Error(pcb.Messagef("Error updating the health check status: %v", err))
+		return nil, err
+	}
+	if message != "" {
+		c.recorder.Event(updated, corev1.EventTypeWarning, brokerUnreachableReason, message)
+	}
+	return updated, nil
+}
+
+// checkServiceBrokerHealth probes the broker when its health check is due,
+// and updates its Reachable condition. It returns the updated broker.
+func (c *controller) checkServiceBrokerHealth(broker *v1beta1.ServiceBroker) (*v1beta1.ServiceBroker, error) {
+	pcb := pretty.NewServiceBrokerContextBuilder(broker)
+	now := time.Now()
+
+	toUpdate := broker.DeepCopy()
+	message := ""
+	if d, ok := c.timeUntilNextHealthCheck(&broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, now); !ok {
+		if !clearCommonReachableCondition(&toUpdate.Status.CommonServiceBrokerStatus) {
+			return broker, nil
+		}
+	} else if d > 0 {
+		return broker, nil
+	} else {
+		authConfig, err := c.getAuthCredentialsFromServiceBroker(broker)
+		if err != nil {
+			klog.Info(pcb.Messagef("Error getting broker auth credentials for the health check: %v", err))
+			return nil, err
+		}
+		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
+		probeErr := c.probeCommonBroker(&broker.Spec.CommonServiceBrokerSpec, clientConfig)
+		message = updateCommonReachableCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, probeErr, now)
+	}
+
+	toUpdate.RecalculatePrinterColumnStatusFields()
+	updated, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate)
+	if err != nil {
+		klog.Error(pcb.Messagef("Error updating the health check status: %v", err))
+		return nil, err
+	}
+	if message != "" {
+		c.recorder.Event(updated, corev1.EventTypeWarning, brokerUnreachableReason, message)
+	}
+	return updated, nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestProbeBrokerHealth(t *testing.T) {
+	cases := []struct {
+		name           string
+		path           string
+		status         int
+		expectedMethod string
+		expectedPath   string
+		err            string
+	}{
+		{
+			name:           "catalog",
+			status:         http.StatusOK,
+			expectedMethod: http.MethodHead,
+			expectedPath:   "/v2/catalog",
+		},
+		{
+			name:           "catalog without HEAD",
+			status:         http.StatusMethodNotAllowed,
+			expectedMethod: http.MethodHead,
+			expectedPath:   "/v2/catalog",
+		},
+		{
+			name:           "health endpoint",
+			path:           "/healthz",
+			status:         http.StatusOK,
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/healthz",
+		},
+		{
+			name:           "broker down",
+			path:           "/healthz",
+			status:         http.StatusServiceUnavailable,
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/healthz",
+			err:            "GET /healthz returned status 503",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var method, path, username string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path = r.Method, r.URL.Path
+				username, _, _ = r.BasicAuth()
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			config := &BrokerClientConfiguration{ClientConfiguration: *osb.DefaultClientConfiguration()}
+			config.URL = server.URL + "/"
+			config.AuthConfig = &osb.AuthConfig{BasicAuthConfig: &osb.BasicAuthConfig{Username: "user", Password: "pass"}}
+
+			err := ProbeBrokerHealth(config, tc.path)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expectedMethod, method; e != a {
+				t.Fatalf("unexpected method: %s", expectedGot(e, a))
+			}
+			if e, a := tc.expectedPath, path; e != a {
+				t.Fatalf("unexpected path: %s", expectedGot(e, a))
+			}
+			if e, a := "user", username; e != a {
+				t.Fatalf("unexpected username: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestReconcileClusterServiceBrokerHealthCheck tests that a broker whose
+// catalog is fresh is probed when its health check is due, and that the
+// result of the probe is recorded in its Reachable condition.
+func TestReconcileClusterServiceBrokerHealthCheck(t *testing.T) {
+	recent := metav1.NewTime(time.Now().Add(-10 * time.Second))
+	old := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	cases := []struct {
+		name              string
+		healthCheck       *v1beta1.BrokerHealthCheck
+		lastHealthCheck   *metav1.Time
+		reachable         v1beta1.ConditionStatus
+		probeErr          error
+		expectedProbe     bool
+		expectedReachable v1beta1.ConditionStatus
+		expectedEvent     bool
+	}{
+		{
+			name:              "never probed",
+			healthCheck:       &v1beta1.BrokerHealthCheck{Path: "/healthz"},
+			expectedProbe:     true,
+			expectedReachable: v1beta1.ConditionTrue,
+		},
+		{
+			name:            "probed recently",
+			healthCheck:     &v1beta1.BrokerHealthCheck{},
+			lastHealthCheck: &recent,
+		},
+		{
+			name:              "broker down",
+			healthCheck:       &v1beta1.BrokerHealthCheck{},
+			lastHealthCheck:   &old,
+			probeErr:          errors.New("connection refused"),
+			expectedProbe:     true,
+			expectedReachable: v1beta1.ConditionFalse,
+			expectedEvent:     true,
+		},
+		{
+			name:              "broker still down",
+			healthCheck:       &v1beta1.BrokerHealthCheck{},
+			lastHealthCheck:   &old,
+			reachable:         v1beta1.ConditionFalse,
+			probeErr:          errors.New("connection refused"),
+			expectedProbe:     true,
+			expectedReachable: v1beta1.ConditionFalse,
+		},
+		{
+			name:            "health check disabled",
+			lastHealthCheck: &old,
+			reachable:       v1beta1.ConditionTrue,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+			testController.brokerHealthCheckInterval = time.Minute
+			var probedPath *string
+			testController.brokerHealthProbe = func(config *BrokerClientConfiguration, path string) error {
+				probedPath = &path
+				return tc.probeErr
+			}
+			fakeCatalogClient.AddReactor("update", "clusterservicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, action.(clientgotesting.UpdateAction).GetObject(), nil
+			})
+
+			broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+			broker.Spec.HealthCheck = tc.healthCheck
+			broker.Status.LastHealthCheckTime = tc.lastHealthCheck
+			if tc.reachable != "" {
+				broker.Status.Conditions = append(broker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+					Type:   v1beta1.ServiceBrokerConditionReachable,
+					Status: tc.reachable,
+				})
+			}
+
+			if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the catalog is fresh, so the broker is not relisted
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			if tc.expectedProbe != (probedPath != nil) {
+				t.Fatalf("unexpected probe: %s", expectedGot(tc.expectedProbe, probedPath != nil))
+			}
+			if probedPath != nil && *probedPath != tc.healthCheck.Path {
+				t.Fatalf("unexpected probed path: %s", expectedGot(tc.healthCheck.Path, *probedPath))
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedProbe && tc.reachable == "" {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updated := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+			assertClusterServiceBrokerReadyTrue(t, updated)
+			if !tc.expectedProbe {
+				for _, cond := range updated.Status.Conditions {
+					if cond.Type == v1beta1.ServiceBrokerConditionReachable {
+						t.Fatal("expected the Reachable condition to be removed")
+					}
+				}
+				if updated.Status.LastHealthCheckTime != nil {
+					t.Fatal("expected the last health check time to be cleared")
+				}
+				return
+			}
+			assertClusterServiceBrokerCondition(t, updated, v1beta1.ServiceBrokerConditionReachable, tc.expectedReachable)
+			if updated.Status.LastHealthCheckTime == nil || !updated.Status.LastHealthCheckTime.After(old.Time) {
+				t.Fatalf("expected the last health check time to be updated, got %v", updated.Status.LastHealthCheckTime)
+			}
+
+			events := getRecordedEvents(testController)
+			var expectedEvents []string
+			if tc.expectedEvent {
+				expectedEvents = []string{warningEventBuilder(brokerUnreachableReason).String()}
+			}
+			if err := checkEventPrefixes(events, expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBrokerHealthCheck tests that a namespaced broker is
+// probed when its health check is due.
+func TestReconcileServiceBrokerHealthCheck(t *testing.T) {
+	_, fakeCatalogClient, fakeBrokerClient, testController, _ := newTestController(t, noFakeActions())
+	testController.brokerHealthCheckInterval = time.Minute
+	testController.brokerHealthProbe = func(config *BrokerClientConfiguration, path string) error {
+		return nil
+	}
+	fakeCatalogClient.AddReactor("update", "servicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, action.(clientgotesting.UpdateAction).GetObject(), nil
+	})
+
+	broker := getTestServiceBroker()
+	lastRelistTime := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+		Type:               v1beta1.ServiceBrokerConditionReady,
+		Status:             v1beta1.ConditionTrue,
+		LastTransitionTime: lastRelistTime,
+	}}
+	broker.Status.LastCatalogRetrievalTime = &lastRelistTime
+	broker.Spec.HealthCheck = &v1beta1.BrokerHealthCheck{}
+
+	if err := reconcileServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updated := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ServiceBroker)
+	assertServiceBrokerCondition(t, updated, v1beta1.ServiceBrokerConditionReachable, v1beta1.ConditionTrue)
+}
+
+// TestProbeCommonBrokerGRPC tests that the health check of a broker whose
+// protocol is not HTTP fails without probing it.
+func TestProbeCommonBrokerGRPC(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	testController.brokerHealthProbe = func(config *BrokerClientConfiguration, path string) error {
+		t.Fatal("unexpected probe")
+		return nil
+	}
+	spec := &v1beta1.CommonServiceBrokerSpec{
+		Protocol:    v1beta1.ServiceBrokerProtocolGRPC,
+		HealthCheck: &v1beta1.BrokerHealthCheck{},
+	}
+	if err := testController.probeCommonBroker(spec, &BrokerClientConfiguration{ClientConfiguration: *osb.DefaultClientConfiguration()}); err != errorBrokerHealthProbeNotSupported {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
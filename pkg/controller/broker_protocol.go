@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokergrpc"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics/osbclientproxy"
+)
+
+// NewBrokerProtocolCreateFunc returns a BrokerClientCreateFunc that creates the clients
+// of the brokers with the CreateFunc of their protocol, so that the
+// reconcilers program to the client interface whatever the transport of the
+// broker. A broker that does not set its protocol uses HTTP.
+func NewBrokerProtocolCreateFunc(createFuncs map[v1beta1.ServiceBrokerProtocol]BrokerClientCreateFunc) BrokerClientCreateFunc {
+	return func(config *BrokerClientConfiguration) (osb.Client, error) {
+		protocol := config.Protocol
+		if protocol == "" {
+			protocol = v1beta1.ServiceBrokerProtocolHTTP
+		}
+		createFunc, ok := createFuncs[protocol]
+		if !ok {
+			return nil, fmt.Errorf("the protocol %q of the broker is not supported", protocol)
+		}
+		return createFunc(config)
+	}
+}
+
+// NewHTTPBrokerClient is the BrokerClientCreateFunc of the brokers whose
+// protocol is HTTP. The requests of its clients are instrumented.
+func NewHTTPBrokerClient(config *BrokerClientConfiguration) (osb.Client, error) {
+	client, err := brokerhttp.NewClient(&config.ClientConfiguration, brokerhttp.Options{UserAgent: config.UserAgent, URLPolicy: config.URLPolicy})
+	if err != nil {
+		return nil, err
+	}
+	return osbclientproxy.NewProxy(config.Name, client), nil
+}
+
+// NewGRPCBrokerClient is the BrokerClientCreateFunc of the brokers whose
+// protocol is GRPC. The requests of its clients are instrumented.
+func NewGRPCBrokerClient(config *BrokerClientConfiguration) (osb.Client, error) {
+	client, err := brokergrpc.NewClient(&config.ClientConfiguration, brokergrpc.Options{UserAgent: config.UserAgent, URLPolicy: config.URLPolicy})
+	if err != nil {
+		return nil, err
+	}
+	return osbclientproxy.NewProxy(config.Name, client), nil
+}
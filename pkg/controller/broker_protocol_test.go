@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestBrokerProtocolCreateFunc(t *testing.T) {
+	created := ""
+	createFuncFor := func(protocol string) BrokerClientCreateFunc {
+		return func(*BrokerClientConfiguration) (osb.Client, error) {
+			created = protocol
+			return nil, nil
+		}
+	}
+	createFunc := NewBrokerProtocolCreateFunc(map[v1beta1.ServiceBrokerProtocol]BrokerClientCreateFunc{
+		v1beta1.ServiceBrokerProtocolHTTP: createFuncFor("http"),
+		v1beta1.ServiceBrokerProtocolGRPC: createFuncFor("grpc"),
+	})
+
+	cases := []struct {
+		name     string
+		protocol v1beta1.ServiceBrokerProtocol
+		created  string
+		err      bool
+	}{
+		{
+			name:    "default protocol",
+			created: "http",
+		},
+		{
+			name:     "http",
+			protocol: v1beta1.ServiceBrokerProtocolHTTP,
+			created:  "http",
+		},
+		{
+			name:     "grpc",
+			protocol: v1beta1.ServiceBrokerProtocolGRPC,
+			created:  "grpc",
+		},
+		{
+			name:     "unsupported protocol",
+			protocol: "SOAP",
+			err:      true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			created = ""
+			spec := &v1beta1.CommonServiceBrokerSpec{URL: "https://broker", Protocol: tc.protocol}
+			_, err := createFunc(NewClientConfigurationForBroker(metav1.ObjectMeta{Name: "broker"}, spec, nil, 0, nil, ""))
+			if e, a := tc.err, err != nil; e != a {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.created, created; e != a {
+				t.Fatalf("unexpected client created; %s", expectedGot(e, a))
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+const (
+	// brokerRecoveryBurst is the number of instances and bindings that are
+	// requeued at once after their broker became ready again.
+	brokerRecoveryBurst = 10
+	// brokerRecoveryInterval is the delay between two bursts of requeued
+	// instances and bindings.
+	brokerRecoveryInterval = time.Second
+)
+
+var (
+	// brokerUnreachableInstanceReasons are the reasons of the ready condition
+	// of instances whose last request failed to reach their broker.
+	brokerUnreachableInstanceReasons = sets.NewString(
+		errorErrorCallingProvisionReason,
+		errorProvisionRequestNotSentReason,
+		errorErrorCallingUpdateInstanceReason,
+		errorDeprovisionCallFailedReason,
+	)
+	// brokerUnreachableBindingReasons are the reasons of the ready condition
+	// of bindings whose last request failed to reach their broker.
+	brokerUnreachableBindingReasons = sets.NewString(
+		errorBindCallReason,
+		errorUnbindCallReason,
+	)
+)
+
+// isServiceBrokerReady returns whether the broker has a ready condition with
+// status true.
+func isServiceBrokerReady(status *v1beta1.CommonServiceBrokerStatus) bool {
+	for _, condition := range status.Conditions {
+		if condition.Type == v1beta1.ServiceBrokerConditionReady {
+			return condition.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isServiceInstanceWaitingForBroker returns whether the last request of the
+// instance failed to reach its broker and will be retried.
+func isServiceInstanceWaitingForBroker(instance *v1beta1.ServiceInstance) bool {
+	if isServiceInstanceFailed(instance) {
+		return false
+	}
+	for _, condition := range instance.Status.Conditions {
+		if condition.Type == v1beta1.ServiceInstanceConditionReady {
+			return condition.Status != v1beta1.ConditionTrue && brokerUnreachableInstanceReasons.Has(condition.Reason)
+		}
+	}
+	return false
+}
+
+// isServiceBindingWaitingForBroker returns whether the last request of the
+// binding failed to reach its broker and will be retried.
+func isServiceBindingWaitingForBroker(binding *v1beta1.ServiceBinding) bool {
+	if isServiceBindingFailed(binding) {
+		return false
+	}
+	condition := getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady)
+	return condition != nil && condition.Status != v1beta1.ConditionTrue && brokerUnreachableBindingReasons.Has(condition.Reason)
+}
+
+// requeueClusterServiceBrokerDependents requeues the instances and bindings of
+// a cluster broker that became ready again, so that the requests which failed
+// while the broker was unreachable are retried without waiting for their
+// backoff.
+func (c *controller) requeueClusterServiceBrokerDependents(broker *v1beta1.ClusterServiceBroker) {
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+	instances, err := c.instanceLister.List(labels.Everything())
+	if err != nil {
+		klog.Warning(pcb.Messagef("Error listing instances to requeue: %v", err))
+		return
+	}
+	bindings, err := c.bindingLister.List(labels.Everything())
+	if err != nil {
+		klog.Warning(pcb.Messagef("Error listing bindings to requeue: %v", err))
+		return
+	}
+
+	brokerNames := map[string]string{}
+	c.requeueBrokerDependents(pcb, instances, bindings, func(instance *v1beta1.ServiceInstance) bool {
+		if instance.Spec.ClusterServiceClassRef == nil {
+			return false
+		}
+		className := instance.Spec.ClusterServiceClassRef.Name
+		brokerName, ok := brokerNames[className]
+		if !ok {
+			if class, err := c.clusterServiceClassLister.Get(className); err == nil {
+				brokerName = class.Spec.ClusterServiceBrokerName
+			}
+			brokerNames[className] = brokerName
+		}
+		return brokerName == broker.Name
+	})
+}
+
+// requeueServiceBrokerDependents requeues the instances and bindings of a
+// namespaced broker that became ready again, so that the requests which
+// failed while the broker was unreachable are retried without waiting for
+// their backoff.
+func (c *controller) requeueServiceBrokerDependents(broker *v1beta1.ServiceBroker) {
+	pcb := pretty.NewServiceBrokerContextBuilder(broker)
+	instances, err := c.instanceLister.ServiceInstances(broker.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Warning(pcb.Messagef("Error listing instances to requeue: %v", err))
+		return
+	}
+	bindings, err := c.bindingLister.ServiceBindings(broker.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Warning(pcb.Messagef("Error listing bindings to requeue: %v", err))
+		return
+	}
+
+	brokerNames := map[string]string{}
+	c.requeueBrokerDependents(pcb, instances, bindings, func(instance *v1beta1.ServiceInstance) bool {
+		if instance.Spec.ServiceClassRef == nil {
+			return false
+		}
+		className := instance.Spec.ServiceClassRef.Name
+		brokerName, ok := brokerNames[className]
+		if !ok {
+			if class, err := c.serviceClassLister.ServiceClasses(broker.Namespace).Get(className); err == nil {
+				brokerName = class.Spec.ServiceBrokerName
+			}
+			brokerNames[className] = brokerName
+		}
+		return brokerName == broker.Name
+	})
+}
+
+// requeueBrokerDependents requeues the given instances of the broker, and the
+// given bindings to them, whose last request failed to reach the broker. They
+// are requeued in bursts of brokerRecoveryBurst every brokerRecoveryInterval,
+// so that the broker which just recovered is not hit by all of them at once.
+func (c *controller) requeueBrokerDependents(pcb *pretty.ContextBuilder, instances []*v1beta1.ServiceInstance, bindings []*v1beta1.ServiceBinding, isBrokerInstance func(*v1beta1.ServiceInstance) bool) {
+	brokerInstances := sets.NewString()
+	requeued := 0
+	delay := func() time.Duration {
+		d := time.Duration(requeued/brokerRecoveryBurst) * brokerRecoveryInterval
+		requeued++
+		return d
+	}
+
+	for _, instance := range instances {
+		if !isBrokerInstance(instance) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(instance)
+		if err != nil {
+			continue
+		}
+		brokerInstances.Insert(key)
+		if !isServiceInstanceWaitingForBroker(instance) {
+			continue
+		}
+		c.removeInstanceFromRetryMap(instance)
+		c.instanceQueue.Forget(key)
+		c.instanceQueue.AddAfter(key, delay())
+	}
+
+	for _, binding := range bindings {
+		if !brokerInstances.Has(binding.Namespace+"/"+binding.Spec.InstanceRef.Name) || !isServiceBindingWaitingForBroker(binding) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(binding)
+		if err != nil {
+			continue
+		}
+		c.bindingQueue.Forget(key)
+		c.bindingQueue.AddAfter(key, delay())
+	}
+
+	if requeued > 0 {
+		klog.V(4).Info(pcb.Messagef("Requeued %d instances and bindings after the broker became ready", requeued))
+	}
+}
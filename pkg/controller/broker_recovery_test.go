@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+)
+
+func TestRequeueClusterServiceBrokerDependents(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	otherClass := getTestClusterServiceClass()
+	otherClass.Name = "other-class"
+	otherClass.Spec.ClusterServiceBrokerName = "other-broker"
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(otherClass)
+
+	newInstance := func(name string, conditions ...v1beta1.ServiceInstanceCondition) *v1beta1.ServiceInstance {
+		instance := getTestServiceInstanceWithClusterRefs()
+		instance.Name = name
+		instance.Status.Conditions = conditions
+		return instance
+	}
+	unreachable := v1beta1.ServiceInstanceCondition{
+		Type:   v1beta1.ServiceInstanceConditionReady,
+		Status: v1beta1.ConditionFalse,
+		Reason: errorErrorCallingProvisionReason,
+	}
+	otherBrokerInstance := newInstance("other-broker", unreachable)
+	otherBrokerInstance.Spec.ClusterServiceClassRef.Name = otherClass.Name
+
+	for _, instance := range []*v1beta1.ServiceInstance{
+		newInstance("unreachable", unreachable),
+		newInstance("failed", unreachable, v1beta1.ServiceInstanceCondition{
+			Type:   v1beta1.ServiceInstanceConditionFailed,
+			Status: v1beta1.ConditionTrue,
+		}),
+		newInstance("ready", v1beta1.ServiceInstanceCondition{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionTrue,
+		}),
+		newInstance("provision-failed", v1beta1.ServiceInstanceCondition{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionFalse,
+			Reason: errorProvisionCallFailedReason,
+		}),
+		otherBrokerInstance,
+	} {
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+	}
+
+	newBinding := func(name, instanceName string) *v1beta1.ServiceBinding {
+		binding := getTestServiceBinding()
+		binding.Name = name
+		binding.Spec.InstanceRef.Name = instanceName
+		binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+			Type:   v1beta1.ServiceBindingConditionReady,
+			Status: v1beta1.ConditionFalse,
+			Reason: errorBindCallReason,
+		}}
+		return binding
+	}
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(newBinding("unreachable", "ready"))
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(newBinding("other-broker", "other-broker"))
+
+	testController.requeueClusterServiceBrokerDependents(getTestClusterServiceBroker())
+
+	if e, a := 1, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("unexpected number of requeued instances: %s", expectedGot(e, a))
+	}
+	if key, _ := testController.instanceQueue.Get(); key != testNamespace+"/unreachable" {
+		t.Fatalf("unexpected requeued instance %v", key)
+	}
+	if e, a := 1, testController.bindingQueue.Len(); e != a {
+		t.Fatalf("unexpected number of requeued bindings: %s", expectedGot(e, a))
+	}
+	if key, _ := testController.bindingQueue.Get(); key != testNamespace+"/unreachable" {
+		t.Fatalf("unexpected requeued binding %v", key)
+	}
+}
+
+func TestRequeueClusterServiceBrokerDependentsBurst(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	for i := 0; i < brokerRecoveryBurst+5; i++ {
+		instance := getTestServiceInstanceWithClusterRefs()
+		instance.Name = fmt.Sprintf("instance-%d", i)
+		instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionFalse,
+			Reason: errorErrorCallingProvisionReason,
+		}}
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+	}
+
+	testController.requeueClusterServiceBrokerDependents(getTestClusterServiceBroker())
+
+	// the instances beyond the first burst are requeued after a delay
+	if e, a := brokerRecoveryBurst, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("unexpected number of requeued instances: %s", expectedGot(e, a))
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+)
+
+// brokerRequestLimitRequeueDelay is the delay after which a resource whose
+// reconciliation was refused a request to a busy broker is reconciled again.
+const brokerRequestLimitRequeueDelay = 1 * time.Second
+
+// brokerRequestLimitError is returned instead of sending a request to a broker
+// which has the maximum number of requests in flight already.
+type brokerRequestLimitError struct {
+	broker      string
+	maxRequests int
+}
+
+func (e *brokerRequestLimitError) Error() string {
+	return fmt.Sprintf("broker %q has the maximum of %d requests in flight, the request will be retried", e.broker, e.maxRequests)
+}
+
+// isBrokerRequestLimitError returns whether err was returned because the
+// broker had too many requests in flight.
+func isBrokerRequestLimitError(err error) bool {
+	_, ok := err.(*brokerRequestLimitError)
+	return ok
+}
+
+// brokerRequestLimiter counts the requests in flight to each broker and
+// limits them to maxRequests, if maxRequests is not zero.
+type brokerRequestLimiter struct {
+	maxRequests int
+
+	mu       sync.Mutex
+	inFlight map[BrokerKey]int
+}
+
+func newBrokerRequestLimiter(maxRequests int) *brokerRequestLimiter {
+	return &brokerRequestLimiter{
+		maxRequests: maxRequests,
+		inFlight:    map[BrokerKey]int{},
+	}
+}
+
+// limitClient returns a client which sends the requests of brokerClient
+// only while the broker identified by brokerKey is below the limit.
+func (l *brokerRequestLimiter) limitClient(brokerKey BrokerKey, brokerClient osb.Client) osb.Client {
+	return &limitedBrokerClient{
+		Client:    brokerClient,
+		brokerKey: brokerKey,
+		limiter:   l,
+	}
+}
+
+// acquire counts a new request to the broker, unless the broker is at the
+// limit already.
+func (l *brokerRequestLimiter) acquire(brokerKey BrokerKey) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.inFlight[brokerKey]
+	if l.maxRequests > 0 && n >= l.maxRequests {
+		return &brokerRequestLimitError{broker: brokerKey.String(), maxRequests: l.maxRequests}
+	}
+	l.inFlight[brokerKey] = n + 1
+	metrics.OSBRequestsInFlight.WithLabelValues(brokerKey.String()).Set(float64(n + 1))
+	return nil
+}
+
+// release counts a request to the broker as completed.
+func (l *brokerRequestLimiter) release(brokerKey BrokerKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.inFlight[brokerKey] - 1
+	if n <= 0 {
+		delete(l.inFlight, brokerKey)
+		n = 0
+	} else {
+		l.inFlight[brokerKey] = n
+	}
+	metrics.OSBRequestsInFlight.WithLabelValues(brokerKey.String()).Set(float64(n))
+}
+
+// removeBroker drops the metric of a deleted broker.
+func (l *brokerRequestLimiter) removeBroker(brokerKey BrokerKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, found := l.inFlight[brokerKey]; !found {
+		metrics.OSBRequestsInFlight.DeleteLabelValues(brokerKey.String())
+	}
+}
+
+// limitedBrokerClient is an osb.Client whose requests are counted by a
+// brokerRequestLimiter.
+type limitedBrokerClient struct {
+	osb.Client
+	brokerKey BrokerKey
+	limiter   *brokerRequestLimiter
+}
+
+var _ brokerhttp.Client = &limitedBrokerClient{}
+
+func (c *limitedBrokerClient) GetCatalog() (*osb.CatalogResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.GetCatalog()
+}
+
+func (c *limitedBrokerClient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.GetCatalog(c.Client)
+}
+
+func (c *limitedBrokerClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.ProvisionInstance(r)
+}
+
+func (c *limitedBrokerClient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.ProvisionInstance(c.Client, r)
+}
+
+func (c *limitedBrokerClient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.GetInstance(c.Client, r)
+}
+
+func (c *limitedBrokerClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.UpdateInstance(r)
+}
+
+func (c *limitedBrokerClient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.UpdateInstance(c.Client, r)
+}
+
+func (c *limitedBrokerClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.DeprovisionInstance(r)
+}
+
+func (c *limitedBrokerClient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.DeprovisionInstance(c.Client, r)
+}
+
+func (c *limitedBrokerClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.PollLastOperation(r)
+}
+
+func (c *limitedBrokerClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.PollBindingLastOperation(r)
+}
+
+func (c *limitedBrokerClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.Bind(r)
+}
+
+func (c *limitedBrokerClient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return brokerhttp.Bind(c.Client, r)
+}
+
+func (c *limitedBrokerClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.Unbind(r)
+}
+
+func (c *limitedBrokerClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	if err := c.limiter.acquire(c.brokerKey); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release(c.brokerKey)
+	return c.Client.GetBinding(r)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestBrokerRequestLimiter(t *testing.T) {
+	broker1 := NewClusterServiceBrokerKey("broker1")
+	broker2 := NewServiceBrokerKey("ns", "broker1")
+
+	limiter := newBrokerRequestLimiter(2)
+	for i := 0; i < 2; i++ {
+		if err := limiter.acquire(broker1); err != nil {
+			t.Fatalf("unexpected error acquiring request %d: %v", i, err)
+		}
+	}
+	err := limiter.acquire(broker1)
+	if !isBrokerRequestLimitError(err) {
+		t.Fatalf("expected a broker request limit error, got %v", err)
+	}
+	if err := limiter.acquire(broker2); err != nil {
+		t.Fatalf("the limit of one broker should not apply to another one: %v", err)
+	}
+
+	limiter.release(broker1)
+	if err := limiter.acquire(broker1); err != nil {
+		t.Fatalf("unexpected error acquiring a released request: %v", err)
+	}
+
+	unlimited := newBrokerRequestLimiter(0)
+	for i := 0; i < 100; i++ {
+		if err := unlimited.acquire(broker1); err != nil {
+			t.Fatalf("unexpected error acquiring request %d without a limit: %v", i, err)
+		}
+	}
+}
+
+func TestLimitedBrokerClient(t *testing.T) {
+	brokerKey := NewClusterServiceBrokerKey("broker1")
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+	limiter := newBrokerRequestLimiter(1)
+	client := limiter.limitClient(brokerKey, fakeClient)
+
+	if err := limiter.acquire(brokerKey); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.ProvisionInstance(&osb.ProvisionRequest{})
+	if !isBrokerRequestLimitError(err) {
+		t.Fatalf("expected a broker request limit error, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClient.Actions(), 0)
+
+	limiter.release(brokerKey)
+	request := &osb.ProvisionRequest{
+		InstanceID:       testServiceInstanceGUID,
+		ServiceID:        testClusterServiceClassGUID,
+		PlanID:           testClusterServicePlanGUID,
+		OrganizationGUID: testClusterID,
+		SpaceGUID:        testNamespaceGUID,
+	}
+	if _, err := client.ProvisionInstance(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClient.Actions(), 1)
+	if n := limiter.inFlight[brokerKey]; n != 0 {
+		t.Fatalf("expected no requests in flight after the request completed, got %d", n)
+	}
+}
+
+// TestReconcileServiceInstanceBrokerRequestLimitReached tests that an
+// instance is not provisioned while its broker has the maximum number of
+// requests in flight, and that its status is left untouched.
+func TestReconcileServiceInstanceBrokerRequestLimitReached(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+	testController.brokerRequestLimiter = newBrokerRequestLimiter(1)
+	brokerKey := NewClusterServiceBrokerKey(testClusterServiceBrokerName)
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := testController.brokerRequestLimiter.acquire(brokerKey); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := reconcileServiceInstance(t, testController, instance)
+	if !isBrokerRequestLimitError(err) {
+		t.Fatalf("expected a broker request limit error, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	if _, found := testController.instanceOperationRetryQueue.instances[string(instance.UID)]; found {
+		t.Fatal("an instance refused a request to a busy broker should not back off")
+	}
+
+	testController.brokerRequestLimiter.release(brokerKey)
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+}
+
+// TestWorkerRequeuesBrokerRequestLimitErrors tests that a key refused a
+// request to a busy broker is reconciled again without counting as a retry.
+func TestWorkerRequeuesBrokerRequestLimitErrors(t *testing.T) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+	queue.Add("key")
+
+	calls := 0
+	reconciler := func(key string) error {
+		calls++
+		if calls == 1 {
+			return &brokerRequestLimitError{broker: "broker1", maxRequests: 1}
+		}
+		if n := queue.NumRequeues(key); n != 0 {
+			t.Errorf("expected no requeues to be counted, got %d", n)
+		}
+		queue.ShutDown()
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		worker(queue, "Test", 0, true, reconciler)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		queue.ShutDown()
+		t.Fatal("the key was not reconciled again")
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 reconciliations, got %d", calls)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// brokerRetryRateLimiter is an exponential per-item rate limiter whose
+// backoff can be overridden by the retry policy of the broker of the item.
+// The items of brokers without an override are rate limited by
+// defaultLimiter.
+type brokerRetryRateLimiter struct {
+	defaultLimiter workqueue.RateLimiter
+	// baseDelay and maxDelay replace the fields that are not set in the
+	// override of a broker.
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	// backoffFor returns the override of the broker of an item, or nil.
+	backoffFor func(item interface{}) *v1beta1.RetryBackoff
+
+	mutex    sync.Mutex
+	failures map[interface{}]int
+}
+
+var _ workqueue.RateLimiter = &brokerRetryRateLimiter{}
+
+func newBrokerRetryRateLimiter(defaultLimiter workqueue.RateLimiter, baseDelay, maxDelay time.Duration, backoffFor func(item interface{}) *v1beta1.RetryBackoff) *brokerRetryRateLimiter {
+	return &brokerRetryRateLimiter{
+		defaultLimiter: defaultLimiter,
+		baseDelay:      baseDelay,
+		maxDelay:       maxDelay,
+		backoffFor:     backoffFor,
+		failures:       map[interface{}]int{},
+	}
+}
+
+func (r *brokerRetryRateLimiter) When(item interface{}) time.Duration {
+	var backoff *v1beta1.RetryBackoff
+	if r.backoffFor != nil {
+		backoff = r.backoffFor(item)
+	}
+	return r.whenWithBackoff(item, backoff)
+}
+
+// whenWithBackoff returns the delay before the next retry of the item using
+// the given override of its broker.
+func (r *brokerRetryRateLimiter) whenWithBackoff(item interface{}, backoff *v1beta1.RetryBackoff) time.Duration {
+	if backoff == nil {
+		return r.defaultLimiter.When(item)
+	}
+
+	baseDelay, maxDelay := r.baseDelay, r.maxDelay
+	if backoff.InitialDelay != nil {
+		baseDelay = backoff.InitialDelay.Duration
+	}
+	if backoff.MaxDelay != nil {
+		maxDelay = backoff.MaxDelay.Duration
+	}
+	if maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	exp := r.failures[item]
+	r.failures[item] = exp + 1
+
+	delay := float64(baseDelay.Nanoseconds()) * math.Pow(2, float64(exp))
+	if delay > float64(maxDelay.Nanoseconds()) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+func (r *brokerRetryRateLimiter) NumRequeues(item interface{}) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.failures[item] + r.defaultLimiter.NumRequeues(item)
+}
+
+func (r *brokerRetryRateLimiter) Forget(item interface{}) {
+	r.mutex.Lock()
+	delete(r.failures, item)
+	r.mutex.Unlock()
+	r.defaultLimiter.Forget(item)
+}
+
+// catalogRetryBackoff returns the override of the backoff between the
+// retries of the catalog fetches of a broker, or nil.
+func catalogRetryBackoff(spec *v1beta1.CommonServiceBrokerSpec) *v1beta1.RetryBackoff {
+	if spec.RetryPolicy == nil {
+		return nil
+	}
+	return spec.RetryPolicy.Catalog
+}
+
+// operationsRetryBackoff returns the override of the backoff between the
+// retries of the provision, update and bind requests to a broker, or nil.
+func operationsRetryBackoff(spec *v1beta1.CommonServiceBrokerSpec) *v1beta1.RetryBackoff {
+	if spec.RetryPolicy == nil {
+		return nil
+	}
+	return spec.RetryPolicy.Operations
+}
+
+// clusterServiceBrokerCatalogRetryBackoff returns the catalog retry backoff
+// of the cluster broker with the given queue key.
+func (c *controller) clusterServiceBrokerCatalogRetryBackoff(item interface{}) *v1beta1.RetryBackoff {
+	broker, err := c.clusterServiceBrokerLister.Get(item.(string))
+	if err != nil {
+		return nil
+	}
+	return catalogRetryBackoff(&broker.Spec.CommonServiceBrokerSpec)
+}
+
+// serviceBrokerCatalogRetryBackoff returns the catalog retry backoff of the
+// namespaced broker with the given queue key.
+func (c *controller) serviceBrokerCatalogRetryBackoff(item interface{}) *v1beta1.RetryBackoff {
+	namespace, name, err := cache.SplitMetaNamespaceKey(item.(string))
+	if err != nil || c.serviceBrokerLister == nil {
+		return nil
+	}
+	broker, err := c.serviceBrokerLister.ServiceBrokers(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	return catalogRetryBackoff(&broker.Spec.CommonServiceBrokerSpec)
+}
+
+// bindingOperationsRetryBackoff returns the operations retry backoff of the
+// broker of the binding with the given queue key.
+func (c *controller) bindingOperationsRetryBackoff(item interface{}) *v1beta1.RetryBackoff {
+	namespace, name, err := cache.SplitMetaNamespaceKey(item.(string))
+	if err != nil {
+		return nil
+	}
+	binding, err := c.bindingLister.ServiceBindings(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	instance, err := c.instanceLister.ServiceInstances(namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		return nil
+	}
+	return c.instanceOperationsRetryBackoff(instance)
+}
+
+// instanceOperationsRetryBackoff returns the operations retry backoff of the
+// broker of the instance, or nil when the broker does not override it or
+// cannot be found.
+func (c *controller) instanceOperationsRetryBackoff(instance *v1beta1.ServiceInstance) *v1beta1.RetryBackoff {
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil:
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return nil
+		}
+		broker, err := c.clusterServiceBrokerLister.Get(class.Spec.ClusterServiceBrokerName)
+		if err != nil {
+			return nil
+		}
+		return operationsRetryBackoff(&broker.Spec.CommonServiceBrokerSpec)
+	case instance.Spec.ServiceClassRef != nil && c.serviceClassLister != nil && c.serviceBrokerLister != nil:
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return nil
+		}
+		broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(class.Spec.ServiceBrokerName)
+		if err != nil {
+			return nil
+		}
+		return operationsRetryBackoff(&broker.Spec.CommonServiceBrokerSpec)
+	}
+	return nil
+}
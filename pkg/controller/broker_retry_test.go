@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestBrokerRetryRateLimiter(t *testing.T) {
+	overridden := &v1beta1.RetryBackoff{
+		InitialDelay: &metav1.Duration{Duration: 2 * time.Second},
+		MaxDelay:     &metav1.Duration{Duration: 5 * time.Second},
+	}
+	initialDelayOnly := &v1beta1.RetryBackoff{
+		InitialDelay: &metav1.Duration{Duration: 4 * time.Second},
+	}
+	limiter := newBrokerRetryRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Second),
+		time.Second, 10*time.Second,
+		func(item interface{}) *v1beta1.RetryBackoff {
+			switch item {
+			case "overridden":
+				return overridden
+			case "initial-delay-only":
+				return initialDelayOnly
+			}
+			return nil
+		},
+	)
+
+	cases := []struct {
+		item   string
+		delays []time.Duration
+	}{
+		{"overridden", []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"initial-delay-only", []time.Duration{4 * time.Second, 8 * time.Second, 10 * time.Second}},
+		{"default", []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.item, func(t *testing.T) {
+			for i, expected := range tc.delays {
+				if delay := limiter.When(tc.item); delay != expected {
+					t.Fatalf("retry %d: expected a delay of %v, got %v", i, expected, delay)
+				}
+			}
+			if e, a := len(tc.delays), limiter.NumRequeues(tc.item); e != a {
+				t.Fatalf("expected %d requeues, got %d", e, a)
+			}
+
+			limiter.Forget(tc.item)
+			if a := limiter.NumRequeues(tc.item); a != 0 {
+				t.Fatalf("expected no requeues after forgetting the item, got %d", a)
+			}
+			if delay := limiter.When(tc.item); delay != tc.delays[0] {
+				t.Fatalf("expected the backoff to restart at %v, got %v", tc.delays[0], delay)
+			}
+		})
+	}
+}
+
+func TestBrokerRetryBackoffLookups(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	catalog := &v1beta1.RetryBackoff{MaxDelay: &metav1.Duration{Duration: time.Minute}}
+	operations := &v1beta1.RetryBackoff{InitialDelay: &metav1.Duration{Duration: 10 * time.Second}}
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RetryPolicy = &v1beta1.BrokerRetryPolicy{
+		Catalog:    catalog,
+		Operations: operations,
+	}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	instance := getTestServiceInstanceWithClusterRefs()
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+	binding := getTestServiceBinding()
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(binding)
+
+	if a := testController.clusterServiceBrokerCatalogRetryBackoff(broker.Name); a != catalog {
+		t.Errorf("expected the catalog backoff of the broker, got %v", a)
+	}
+	if a := testController.instanceOperationsRetryBackoff(instance); a != operations {
+		t.Errorf("expected the operations backoff of the broker of the instance, got %v", a)
+	}
+	if a := testController.bindingOperationsRetryBackoff(binding.Namespace + "/" + binding.Name); a != operations {
+		t.Errorf("expected the operations backoff of the broker of the binding, got %v", a)
+	}
+
+	if a := testController.clusterServiceBrokerCatalogRetryBackoff("unknown-broker"); a != nil {
+		t.Errorf("expected no backoff for an unknown broker, got %v", a)
+	}
+	orphan := getTestServiceBinding()
+	orphan.Name = "orphan"
+	orphan.Spec.InstanceRef.Name = "unknown-instance"
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(orphan)
+	if a := testController.bindingOperationsRetryBackoff(orphan.Namespace + "/" + orphan.Name); a != nil {
+		t.Errorf("expected no backoff for a binding to an unknown instance, got %v", a)
+	}
+}
+
+// TestInstanceOperationRetryBackoffOverriddenByBroker tests that the retries
+// of a failed provision are delayed by the operations backoff of the broker
+// rather than by the backoff of the controller manager.
+func TestInstanceOperationRetryBackoffOverriddenByBroker(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.recorder = record.NewFakeRecorder(100)
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RetryPolicy = &v1beta1.BrokerRetryPolicy{
+		Operations: &v1beta1.RetryBackoff{InitialDelay: &metav1.Duration{Duration: time.Minute}},
+	}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.UID = "test-uid"
+	testController.setRetryBackoffRequired(instance)
+	if !testController.backoffAndRequeueIfRetrying(instance, "provisioning") {
+		t.Fatal("expected the retry to be delayed")
+	}
+	delay := time.Until(testController.instanceOperationRetryQueue.instances[string(instance.UID)].calculatedRetryTime)
+	if delay > time.Minute || delay < time.Minute-10*time.Second {
+		t.Fatalf("expected the first retry to be delayed by the initial delay of the broker, got %v", delay)
+	}
+}
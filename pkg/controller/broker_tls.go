@@ -0,0 +1,299 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+	"k8s.io/klog"
+)
+
+const (
+	// errorBrokerTLSVerificationReason is the reason of the ready condition
+	// of a broker whose serving certificate could not be verified.
+	errorBrokerTLSVerificationReason string = "ErrorBrokerTLSVerification"
+	// errorBrokerConnectionRefusedReason is the reason of the ready
+	// condition of a broker which refused the connection.
+	errorBrokerConnectionRefusedReason string = "ErrorBrokerConnectionRefused"
+)
+
+// brokerTLSVerificationError is returned when the serving certificate of a
+// broker could not be verified, even with a client rebuilt from the current
+// spec of the broker.
+type brokerTLSVerificationError struct {
+	err error
+}
+
+func (e *brokerTLSVerificationError) Error() string {
+	return fmt.Sprintf("the serving certificate of the broker could not be verified, check the caBundle of the broker: %v", e.err)
+}
+
+// isTLSVerificationError returns whether err is caused by a serving
+// certificate which could not be verified.
+func isTLSVerificationError(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *brokerTLSVerificationError:
+			return true
+		case x509.UnknownAuthorityError, *x509.UnknownAuthorityError,
+			x509.CertificateInvalidError, *x509.CertificateInvalidError,
+			x509.HostnameError, *x509.HostnameError:
+			return true
+		case *url.Error:
+			err = e.Err
+		case *net.OpError:
+			err = e.Err
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// isConnectionRefusedError returns whether err is caused by a connection
+// refused by the broker.
+func isConnectionRefusedError(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case syscall.Errno:
+			return e == syscall.ECONNREFUSED
+		case *url.Error:
+			err = e.Err
+		case *net.OpError:
+			err = e.Err
+		case *os.SyscallError:
+			err = e.Err
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// isRequestNotSentError returns whether err shows that a request was never
+// received by the broker: the connection to the broker was refused, its
+// serving certificate could not be verified, or its URL is denied.
+func isRequestNotSentError(err error) bool {
+	return isConnectionRefusedError(err) || isTLSVerificationError(err) || brokerurl.IsDeniedError(err)
+}
+
+// catalogFetchErrorReason returns the reason of the ready condition of a
+// broker whose catalog could not be fetched because of err, telling apart
+// the TLS verification failures, the refused connections and the denied
+// URLs.
+func catalogFetchErrorReason(err error) string {
+	switch {
+	case isTLSVerificationError(err):
+		return errorBrokerTLSVerificationReason
+	case isConnectionRefusedError(err):
+		return errorBrokerConnectionRefusedReason
+	case brokerurl.IsDeniedError(err):
+		return errorBrokerURLDeniedReason
+	default:
+		return errorFetchingCatalogReason
+	}
+}
+
+// tlsRecoveringBrokerClient is an osb.Client which, when the serving
+// certificate of the broker can not be verified, rebuilds the client from the
+// current spec of the broker and retries the request once. A broker may have
+// rotated its certificate while the client still verified it against the
+// previous CA bundle. The retry is safe because a request whose TLS handshake
+// failed never reached the broker.
+type tlsRecoveringBrokerClient struct {
+	osb.Client
+	brokerName string
+	rebuild    func() (osb.Client, error)
+}
+
+func newTLSRecoveringBrokerClient(brokerName string, brokerClient osb.Client, rebuild func() (osb.Client, error)) osb.Client {
+	return &tlsRecoveringBrokerClient{
+		Client:     brokerClient,
+		brokerName: brokerName,
+		rebuild:    rebuild,
+	}
+}
+
+var _ brokerhttp.Client = &tlsRecoveringBrokerClient{}
+
+// do sends a request with the client, and retries it with a rebuilt client
+// if the certificate of the broker could not be verified.
+func (c *tlsRecoveringBrokerClient) do(request func(osb.Client) error) error {
+	err := request(c.Client)
+	if !isTLSVerificationError(err) {
+		return err
+	}
+
+	klog.V(4).Infof("Rebuilding the client of broker %q after a TLS verification failure: %v", c.brokerName, err)
+	rebuilt, rebuildErr := c.rebuild()
+	if rebuildErr != nil {
+		klog.Warningf("Error rebuilding the client of broker %q: %v", c.brokerName, rebuildErr)
+		return &brokerTLSVerificationError{err: err}
+	}
+	c.Client = rebuilt
+
+	err = request(c.Client)
+	if isTLSVerificationError(err) {
+		return &brokerTLSVerificationError{err: err}
+	}
+	return err
+}
+
+func (c *tlsRecoveringBrokerClient) GetCatalog() (*osb.CatalogResponse, error) {
+	var response *osb.CatalogResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.GetCatalog()
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	var response *brokerhttp.CatalogResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.GetCatalog(client)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	var response *osb.ProvisionResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.ProvisionInstance(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	var response *brokerhttp.ProvisionResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.ProvisionInstance(client, r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	var response *brokerhttp.GetInstanceResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.GetInstance(client, r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	var response *osb.UpdateInstanceResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.UpdateInstance(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	var response *brokerhttp.UpdateInstanceResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.UpdateInstance(client, r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	var response *osb.DeprovisionResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.DeprovisionInstance(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	var response *osb.DeprovisionResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.DeprovisionInstance(client, r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	var response *osb.LastOperationResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.PollLastOperation(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	var response *osb.LastOperationResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.PollBindingLastOperation(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	var response *osb.BindResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.Bind(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	var response *osb.BindResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = brokerhttp.Bind(client, r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	var response *osb.UnbindResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.Unbind(r)
+		return err
+	})
+	return response, err
+}
+
+func (c *tlsRecoveringBrokerClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	var response *osb.GetBindingResponse
+	err := c.do(func(client osb.Client) (err error) {
+		response, err = client.GetBinding(r)
+		return err
+	})
+	return response, err
+}
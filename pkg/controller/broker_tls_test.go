@@ -0,0 +1,195 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+func TestCatalogFetchErrorReason(t *testing.T) {
+	unknownAuthority := &url.Error{Op: "Get", URL: "https://broker", Err: x509.UnknownAuthorityError{}}
+	connectionRefused := &url.Error{Op: "Get", URL: "https://broker", Err: &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	}}
+
+	cases := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			name:   "unknown authority",
+			err:    unknownAuthority,
+			reason: errorBrokerTLSVerificationReason,
+		},
+		{
+			name:   "hostname mismatch",
+			err:    &url.Error{Op: "Get", URL: "https://broker", Err: x509.HostnameError{Host: "broker"}},
+			reason: errorBrokerTLSVerificationReason,
+		},
+		{
+			name:   "after a rebuild",
+			err:    &brokerTLSVerificationError{err: unknownAuthority},
+			reason: errorBrokerTLSVerificationReason,
+		},
+		{
+			name:   "connection refused",
+			err:    connectionRefused,
+			reason: errorBrokerConnectionRefusedReason,
+		},
+		{
+			name:   "denied URL",
+			err:    &brokerurl.DeniedError{URL: "http://127.0.0.1", Reason: "denied"},
+			reason: errorBrokerURLDeniedReason,
+		},
+		{
+			name:   "other error",
+			err:    errors.New("ooops"),
+			reason: errorFetchingCatalogReason,
+		},
+		{
+			name:   "HTTP error",
+			err:    osb.HTTPStatusCodeError{StatusCode: 500},
+			reason: errorFetchingCatalogReason,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if e, a := tc.reason, catalogFetchErrorReason(tc.err); e != a {
+				t.Fatalf("unexpected reason: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestTLSRecoveringBrokerClient(t *testing.T) {
+	tlsErr := &url.Error{Op: "Get", URL: "https://broker", Err: x509.UnknownAuthorityError{}}
+	failingClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Error: tlsErr},
+	})
+	workingClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+	})
+
+	cases := []struct {
+		name          string
+		rebuiltClient *fakeosb.FakeClient
+		rebuildErr    error
+		tlsErr        bool
+	}{
+		{
+			name:          "rebuilt client verifies the certificate",
+			rebuiltClient: workingClient,
+		},
+		{
+			name:          "rebuilt client can not verify the certificate",
+			rebuiltClient: failingClient,
+			tlsErr:        true,
+		},
+		{
+			name:       "client can not be rebuilt",
+			rebuildErr: errors.New("no broker"),
+			tlsErr:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rebuilds := 0
+			client := newTLSRecoveringBrokerClient("broker", failingClient, func() (osb.Client, error) {
+				rebuilds++
+				if tc.rebuildErr != nil {
+					return nil, tc.rebuildErr
+				}
+				return tc.rebuiltClient, nil
+			})
+
+			_, err := client.GetCatalog()
+			if rebuilds != 1 {
+				t.Fatalf("expected the client to be rebuilt once, got %d", rebuilds)
+			}
+			if tc.tlsErr {
+				if _, ok := err.(*brokerTLSVerificationError); !ok {
+					t.Fatalf("expected a TLS verification error, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("other errors are returned right away", func(t *testing.T) {
+		refusing := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+			CatalogReaction: &fakeosb.CatalogReaction{Error: errors.New("ooops")},
+		})
+		client := newTLSRecoveringBrokerClient("broker", refusing, func() (osb.Client, error) {
+			t.Fatal("the client should not be rebuilt")
+			return nil, nil
+		})
+		if _, err := client.GetCatalog(); err == nil || isTLSVerificationError(err) {
+			t.Fatalf("expected the original error, got %v", err)
+		}
+	})
+}
+
+// TestReconcileClusterServiceBrokerTLSVerificationFailure tests that a broker
+// whose certificate can not be verified gets a ready condition telling the
+// TLS failure apart, after the controller rebuilt its client.
+func TestReconcileClusterServiceBrokerTLSVerificationFailure(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: &url.Error{Op: "Get", URL: "https://broker", Err: x509.UnknownAuthorityError{}},
+		},
+	})
+
+	broker := getTestClusterServiceBroker()
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+
+	// The request is retried once with the rebuilt client.
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	condition := updatedClusterServiceBroker.Status.Conditions[0]
+	if condition.Reason != errorBrokerTLSVerificationReason {
+		t.Fatalf("unexpected reason of the ready condition: expected %q, got %q", errorBrokerTLSVerificationReason, condition.Reason)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorBrokerTLSVerificationReason).msg("Error getting broker catalog:").msg("the serving certificate of the broker could not be verified, check the caBundle of the broker:").msg(`Get "https://broker": x509: certificate signed by unknown authority`)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+// errorBrokerURLDeniedReason is the reason of the ready condition of a broker
+// whose URL is denied by the broker URL policy of the controller.
+const errorBrokerURLDeniedReason string = "ErrorBrokerURLDenied"
+
+// NewBrokerURLPolicyCreateFunc returns a BrokerClientCreateFunc whose clients check the
+// URL of their broker against the policy before each request, so that a URL
+// whose host resolves to a denied address after the broker was admitted is
+// not called either. The policy is set in the configuration of the clients
+// too, for them to refuse the connections to the denied addresses whatever
+// the host resolves to when the connection is made. A nil policy returns
+// createFunc.
+func NewBrokerURLPolicyCreateFunc(policy *brokerurl.Policy, createFunc BrokerClientCreateFunc) BrokerClientCreateFunc {
+	if policy == nil {
+		return createFunc
+	}
+	return func(config *BrokerClientConfiguration) (osb.Client, error) {
+		policyConfig := *config
+		policyConfig.URLPolicy = policy
+		client, err := createFunc(&policyConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerURLPolicyClient{Client: client, url: config.URL, policy: policy}, nil
+	}
+}
+
+// NewBrokerURLPolicyHealthProbe returns a BrokerHealthProbeFunc that checks
+// the URL of the broker against the policy before probing it, and probes it
+// with the policy set in the configuration. A nil policy returns probe.
+func NewBrokerURLPolicyHealthProbe(policy *brokerurl.Policy, probe BrokerHealthProbeFunc) BrokerHealthProbeFunc {
+	if policy == nil {
+		return probe
+	}
+	return func(config *BrokerClientConfiguration, path string) error {
+		if err := policy.Check(config.URL); err != nil {
+			return err
+		}
+		policyConfig := *config
+		policyConfig.URLPolicy = policy
+		return probe(&policyConfig, path)
+	}
+}
+
+// brokerURLPolicyClient is an osb.Client that only sends the requests whose
+// broker URL is allowed by the policy.
+type brokerURLPolicyClient struct {
+	osb.Client
+	url    string
+	policy *brokerurl.Policy
+}
+
+var _ brokerhttp.Client = &brokerURLPolicyClient{}
+
+func (c *brokerURLPolicyClient) GetCatalog() (*osb.CatalogResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.GetCatalog()
+}
+
+func (c *brokerURLPolicyClient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.GetCatalog(c.Client)
+}
+
+func (c *brokerURLPolicyClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.ProvisionInstance(r)
+}
+
+func (c *brokerURLPolicyClient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.ProvisionInstance(c.Client, r)
+}
+
+func (c *brokerURLPolicyClient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.GetInstance(c.Client, r)
+}
+
+func (c *brokerURLPolicyClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.UpdateInstance(r)
+}
+
+func (c *brokerURLPolicyClient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.UpdateInstance(c.Client, r)
+}
+
+func (c *brokerURLPolicyClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.DeprovisionInstance(r)
+}
+
+func (c *brokerURLPolicyClient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.DeprovisionInstance(c.Client, r)
+}
+
+func (c *brokerURLPolicyClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.PollLastOperation(r)
+}
+
+func (c *brokerURLPolicyClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.PollBindingLastOperation(r)
+}
+
+func (c *brokerURLPolicyClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.Bind(r)
+}
+
+func (c *brokerURLPolicyClient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return brokerhttp.Bind(c.Client, r)
+}
+
+func (c *brokerURLPolicyClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.Unbind(r)
+}
+
+func (c *brokerURLPolicyClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	if err := c.policy.Check(c.url); err != nil {
+		return nil, err
+	}
+	return c.Client.GetBinding(r)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+func TestBrokerURLPolicyClient(t *testing.T) {
+	policy, err := brokerurl.NewPolicy(nil, brokerurl.DefaultDeniedCIDRs, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		policy *brokerurl.Policy
+		url    string
+		denied bool
+	}{
+		{
+			name:   "allowed URL",
+			policy: policy,
+			url:    "http://10.0.0.1:8080",
+		},
+		{
+			name:   "metadata service",
+			policy: policy,
+			url:    "http://169.254.169.254",
+			denied: true,
+		},
+		{
+			name:   "loopback",
+			policy: policy,
+			url:    "http://127.0.0.1:8080",
+			denied: true,
+		},
+		{
+			name: "no policy",
+			url:  "http://127.0.0.1:8080",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+				CatalogReaction:     &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+				DeprovisionReaction: &fakeosb.DeprovisionReaction{Response: &osb.DeprovisionResponse{}},
+			})
+			var clientPolicy *brokerurl.Policy
+			createFunc := NewBrokerURLPolicyCreateFunc(tc.policy, func(config *BrokerClientConfiguration) (osb.Client, error) {
+				clientPolicy = config.URLPolicy
+				return fakeClient, nil
+			})
+			client, err := createFunc(&BrokerClientConfiguration{ClientConfiguration: osb.ClientConfiguration{URL: tc.url}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if clientPolicy != tc.policy {
+				t.Fatalf("expected the policy to be set in the configuration of the client")
+			}
+
+			_, catalogErr := client.GetCatalog()
+			_, deprovisionErr := client.DeprovisionInstance(&osb.DeprovisionRequest{InstanceID: "instance"})
+			for _, err := range []error{catalogErr, deprovisionErr} {
+				if e, a := tc.denied, brokerurl.IsDeniedError(err); e != a {
+					t.Fatalf("expected denied error %v, got %v", e, err)
+				}
+			}
+			if tc.denied {
+				if e, a := 0, len(fakeClient.Actions()); e != a {
+					t.Fatalf("expected %d requests to the broker, got %d", e, a)
+				}
+				if !isRequestNotSentError(catalogErr) {
+					t.Fatalf("expected a denied URL to be an error of a request not sent")
+				}
+			} else if e, a := 2, len(fakeClient.Actions()); e != a {
+				t.Fatalf("expected %d requests to the broker, got %d", e, a)
+			}
+		})
+	}
+}
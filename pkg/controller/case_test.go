@@ -87,7 +87,7 @@ type controllerTest struct {
 	k8sClient        *fakek8s.Clientset
 	fakeOSBClient    *fakeosb.FakeClient
 	catalogReactions []fakeosb.CatalogReaction
-	osbClientCfg     *osb.ClientConfiguration
+	osbClientCfg     *controller.BrokerClientConfiguration
 	stopCh           chan struct{}
 
 	serviceBindingHandler        *serviceBindingHandler
@@ -124,7 +124,7 @@ func newControllerTest(t *testing.T) *controllerTest {
 	}
 
 	// wrap the ClientFunc with a helper which saves last used OSG Client Config (it can be asserted in the test)
-	brokerClFunc := testCase.spyOSBClientFunc(fakeosb.ReturnFakeClientFunc(fakeOSBClient))
+	brokerClFunc := testCase.spyOSBClientFunc(controller.NewBrokerClientCreateFunc(fakeosb.ReturnFakeClientFunc(fakeOSBClient)))
 
 	fakeRecorder := record.NewFakeRecorder(1)
 	// start goroutine which flushes events (prevent hanging recording function)
@@ -136,6 +136,8 @@ func newControllerTest(t *testing.T) *controllerTest {
 	testController, err := controller.NewController(
 		k8sClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
+		coreInformers.V1().Namespaces(),
 		scClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -151,9 +153,34 @@ func newControllerTest(t *testing.T) *controllerTest {
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		20*time.Minute,
 		"DefaultClusterIDConfigMapName",
 		"DefaultClusterIDConfigMapNamespace",
 		60*time.Second,
+		"",
+		controller.BindingSecretRetentionPolicyDelete,
+		0,
+		0,
+		"",
+		nil,
+		0,
+		0,
+		true,
+		true,
+		0,
+		"",
+		"",
+		0,
+		ClassWithoutPlansPolicyReject,
+		EmptyBindingCredentialsPolicyAllow,
+		0,
+		0,
+		"",
+		0,
+		nil,
+		nil,
+		0,
+		false,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -435,8 +462,8 @@ func (ct *controllerTest) SetOSBBindReactionWithHTTPError(code int) {
 }
 
 // spyOSBClientFunc wraps the ClientFunc with a helper which saves last used OSG Client Config
-func (ct *controllerTest) spyOSBClientFunc(target osb.CreateFunc) osb.CreateFunc {
-	return func(osbCfg *osb.ClientConfiguration) (osb.Client, error) {
+func (ct *controllerTest) spyOSBClientFunc(target controller.BrokerClientCreateFunc) controller.BrokerClientCreateFunc {
+	return func(osbCfg *controller.BrokerClientConfiguration) (osb.Client, error) {
 		ct.osbClientCfg = osbCfg
 		return target(osbCfg)
 	}
@@ -19,9 +19,12 @@ package controller
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +46,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	cliflag "k8s.io/component-base/cli/flag"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalogclientset "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
@@ -51,6 +55,9 @@ import (
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/filter"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util"
+	"github.com/kubernetes-sigs/service-catalog/pkg/version"
 	v12 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/listers/core/v1"
 )
@@ -64,6 +71,9 @@ const (
 	maxRetries = 15
 	// pollingStartInterval is the initial interval to use when polling async OSB operations.
 	pollingStartInterval = 1 * time.Second
+	// relistStuckMonitorInterval is the interval at which the relists in
+	// progress are checked against the relist timeout.
+	relistStuckMonitorInterval = 30 * time.Second
 
 	// ContextProfilePlatformKubernetes is the platform name sent in the OSB
 	// ContextProfile for requests coming from Kubernetes.
@@ -72,12 +82,61 @@ const (
 	DefaultClusterIDConfigMapName string = "cluster-info"
 	// DefaultClusterIDConfigMapNamespace is the k8s namespace that the clusterid configmap will be stored in.
 	DefaultClusterIDConfigMapNamespace string = "default"
+
+	asyncRequiredReason  string = "AsyncRequired"
+	asyncRequiredMessage string = "The broker requires the operation to be asynchronous; the request was sent again with accepts_incomplete=true"
+)
+
+// BindingSecretRetentionPolicy controls what happens to the Secret of a
+// ServiceBinding when the binding is unbound.
+type BindingSecretRetentionPolicy string
+
+const (
+	// BindingSecretRetentionPolicyDelete deletes the Secret when the binding
+	// is unbound. This is the default policy.
+	BindingSecretRetentionPolicyDelete BindingSecretRetentionPolicy = "Delete"
+	// BindingSecretRetentionPolicyRetain keeps the Secret when the binding is
+	// unbound. The credentials are removed from the Secret and so is its owner
+	// reference to the binding, so that the Secret is not garbage collected
+	// together with the binding.
+	BindingSecretRetentionPolicyRetain BindingSecretRetentionPolicy = "Retain"
+)
+
+// ClassWithoutPlansPolicy controls what happens when the catalog of a broker
+// contains a service without plans, which can not be provisioned.
+type ClassWithoutPlansPolicy string
+
+const (
+	// ClassWithoutPlansPolicyReject rejects the whole catalog, the broker
+	// gets the ErrorSyncingCatalog reason naming the service. This is the
+	// default policy.
+	ClassWithoutPlansPolicyReject ClassWithoutPlansPolicy = "Reject"
+	// ClassWithoutPlansPolicySkip skips the services without plans, no class
+	// is created for them, and syncs the rest of the catalog. A warning
+	// event naming the skipped services is recorded on the broker.
+	ClassWithoutPlansPolicySkip ClassWithoutPlansPolicy = "Skip"
+)
+
+// EmptyBindingCredentialsPolicy controls what happens when a broker returns
+// no credentials, or an empty credentials object, for a binding.
+type EmptyBindingCredentialsPolicy string
+
+const (
+	// EmptyBindingCredentialsPolicyAllow treats the binding as any other
+	// binding, its Secret has no keys. This is the default policy.
+	EmptyBindingCredentialsPolicyAllow EmptyBindingCredentialsPolicy = "Allow"
+	// EmptyBindingCredentialsPolicyWarn also writes the empty Secret, but
+	// sets the NoCredentials condition on the binding and records a warning
+	// event.
+	EmptyBindingCredentialsPolicyWarn EmptyBindingCredentialsPolicy = "Warn"
 )
 
 // NewController returns a new Open Service Broker catalog controller.
 func NewController(
 	kubeClient kubernetes.Interface,
 	secretInformer v12.SecretInformer,
+	configMapInformer v12.ConfigMapInformer,
+	namespaceInformer v12.NamespaceInformer,
 	serviceCatalogClient servicecatalogclientset.ServicecatalogV1beta1Interface,
 	clusterServiceBrokerInformer informers.ClusterServiceBrokerInformer,
 	serviceBrokerInformer informers.ServiceBrokerInformer,
@@ -87,40 +146,154 @@ func NewController(
 	bindingInformer informers.ServiceBindingInformer,
 	clusterServicePlanInformer informers.ClusterServicePlanInformer,
 	servicePlanInformer informers.ServicePlanInformer,
-	brokerClientCreateFunc osb.CreateFunc,
+	brokerClientCreateFunc BrokerClientCreateFunc,
 	brokerRelistInterval time.Duration,
 	osbAPIPreferredVersion string,
 	recorder record.EventRecorder,
 	reconciliationRetryDuration time.Duration,
 	operationPollingMaximumBackoffDuration time.Duration,
+	operationRetryMaximumBackoffDuration time.Duration,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
 	osbAPITimeOut time.Duration,
+	osbAPIUserAgentSuffix string,
+	bindingSecretRetentionPolicy BindingSecretRetentionPolicy,
+	catalogStaleRelistMultiple float64,
+	bindingInstanceWaitTimeout time.Duration,
+	brokerTLSMinVersion string,
+	brokerTLSCipherSuites []string,
+	namespaceDeletionDeprovisionTimeout time.Duration,
+	brokerMaxConcurrentRequests int,
+	osbAPIUpdateContext bool,
+	osbAPIAcceptsIncomplete bool,
+	asyncOperationTimeout time.Duration,
+	osbAPIContextPlatform string,
+	clusterID string,
+	deprovisionTimeout time.Duration,
+	classWithoutPlansPolicy ClassWithoutPlansPolicy,
+	emptyBindingCredentialsPolicy EmptyBindingCredentialsPolicy,
+	brokerRelistTimeout time.Duration,
+	parametersResyncInterval time.Duration,
+	osbAPIContext string,
+	brokerHealthCheckInterval time.Duration,
+	brokerHealthProbe BrokerHealthProbeFunc,
+	tracer *tracing.Tracer,
+	orphanMitigationGracePeriod time.Duration,
+	orphanMitigationCheckInstance bool,
 ) (Controller, error) {
+	switch bindingSecretRetentionPolicy {
+	case BindingSecretRetentionPolicyDelete, BindingSecretRetentionPolicyRetain:
+	default:
+		return nil, fmt.Errorf("invalid binding secret retention policy %q, allowed values are: %v, %v", bindingSecretRetentionPolicy, BindingSecretRetentionPolicyDelete, BindingSecretRetentionPolicyRetain)
+	}
+
+	switch classWithoutPlansPolicy {
+	case ClassWithoutPlansPolicyReject, ClassWithoutPlansPolicySkip:
+	default:
+		return nil, fmt.Errorf("invalid class without plans policy %q, allowed values are: %v, %v", classWithoutPlansPolicy, ClassWithoutPlansPolicyReject, ClassWithoutPlansPolicySkip)
+	}
+
+	if !isValidEmptyBindingCredentialsPolicy(emptyBindingCredentialsPolicy) {
+		return nil, fmt.Errorf("invalid empty binding credentials policy %q, allowed values are: %v, %v", emptyBindingCredentialsPolicy, EmptyBindingCredentialsPolicyAllow, EmptyBindingCredentialsPolicyWarn)
+	}
+
+	if brokerMaxConcurrentRequests < 0 {
+		return nil, fmt.Errorf("invalid maximum of concurrent requests to a broker %d, it must not be negative", brokerMaxConcurrentRequests)
+	}
+
+	if brokerHealthCheckInterval < 0 {
+		return nil, fmt.Errorf("invalid broker health check interval %v, it must not be negative", brokerHealthCheckInterval)
+	}
+
+	if orphanMitigationGracePeriod < 0 {
+		return nil, fmt.Errorf("invalid orphan mitigation grace period %v, it must not be negative", orphanMitigationGracePeriod)
+	}
+
+	brokerTLSConfig, err := newBrokerTLSConfig(brokerTLSMinVersion, brokerTLSCipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.IsPrintableASCII(osbAPIUserAgentSuffix) {
+		return nil, fmt.Errorf("invalid OSB API user agent suffix %q, it must only contain printable ASCII characters", osbAPIUserAgentSuffix)
+	}
+
+	if osbAPIContextPlatform == "" {
+		osbAPIContextPlatform = ContextProfilePlatformKubernetes
+	}
+
+	requestContext, err := parseRequestContext(osbAPIContext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OSB API context %q, %v", osbAPIContext, err)
+	}
+
+	if brokerHealthProbe == nil {
+		brokerHealthProbe = ProbeBrokerHealth
+	}
+
 	controller := &controller{
-		kubeClient:                  kubeClient,
-		secretLister:                secretInformer.Lister(),
-		serviceCatalogClient:        serviceCatalogClient,
-		brokerRelistInterval:        brokerRelistInterval,
-		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
-		OSBAPITimeOut:               osbAPITimeOut,
-		recorder:                    recorder,
-		reconciliationRetryDuration: reconciliationRetryDuration,
-		clusterServiceBrokerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "cluster-service-broker"),
-		serviceBrokerQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "service-broker"),
-		clusterServiceClassQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
-		serviceClassQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-class"),
-		clusterServicePlanQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-plan"),
-		servicePlanQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-plan"),
-		instanceQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance"),
-		bindingQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-binding"),
-		instancePollingQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "instance-poller"),
-		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
-		clusterIDConfigMapName:      clusterIDConfigMapName,
-		clusterIDConfigMapNamespace: clusterIDConfigMapNamespace,
-		brokerClientCreateFunc:      brokerClientCreateFunc,
+		kubeClient:                           kubeClient,
+		secretLister:                         secretInformer.Lister(),
+		namespaceLister:                      namespaceInformer.Lister(),
+		serviceCatalogClient:                 serviceCatalogClient,
+		brokerRelistInterval:                 brokerRelistInterval,
+		OSBAPIPreferredVersion:               osbAPIPreferredVersion,
+		OSBAPITimeOut:                        osbAPITimeOut,
+		osbAPIUserAgentSuffix:                osbAPIUserAgentSuffix,
+		osbAPIUpdateContext:                  osbAPIUpdateContext,
+		namespaceLabelsChangeDelay:           namespaceLabelsChangeDelay,
+		osbAPIAcceptsIncomplete:              osbAPIAcceptsIncomplete,
+		osbAPIContextPlatform:                osbAPIContextPlatform,
+		osbAPIContext:                        requestContext,
+		brokerTLSConfig:                      brokerTLSConfig,
+		bindingSecretRetentionPolicy:         bindingSecretRetentionPolicy,
+		classWithoutPlansPolicy:              classWithoutPlansPolicy,
+		emptyBindingCredentialsPolicy:        emptyBindingCredentialsPolicy,
+		brokerRelistTimeout:                  brokerRelistTimeout,
+		parametersResyncInterval:             parametersResyncInterval,
+		brokerHealthCheckInterval:            brokerHealthCheckInterval,
+		brokerHealthProbe:                    brokerHealthProbe,
+		tracer:                               tracer,
+		reconcileSpans:                       newReconcileSpans(),
+		orphanMitigationGracePeriod:          orphanMitigationGracePeriod,
+		orphanMitigationCheckInstance:        orphanMitigationCheckInstance,
+		catalogStaleRelistMultiple:           catalogStaleRelistMultiple,
+		bindingInstanceWaitTimeout:           bindingInstanceWaitTimeout,
+		namespaceDeletionDeprovisionTimeout:  namespaceDeletionDeprovisionTimeout,
+		asyncOperationTimeout:                asyncOperationTimeout,
+		deprovisionTimeout:                   deprovisionTimeout,
+		operationRetryMaximumBackoffDuration: operationRetryMaximumBackoffDuration,
+		serviceAccountTokens:                 newServiceAccountTokenCache(kubeClient),
+		bindingCredentials:                   newBindingCredentialsCache(),
+		brokerCatalogs:                       newBrokerCatalogCache(),
+		brokerRequestLimiter:                 newBrokerRequestLimiter(brokerMaxConcurrentRequests),
+		recorder:                             recorder,
+		reconciliationRetryDuration:          reconciliationRetryDuration,
+		clusterServiceClassQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
+		serviceClassQueue:                    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-class"),
+		clusterServicePlanQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-plan"),
+		servicePlanQueue:                     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-plan"),
+		instanceQueue:                        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance"),
+		instancePollingQueue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "instance-poller"),
+		bindingPollingQueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
+		clusterIDConfigMapName:               clusterIDConfigMapName,
+		clusterIDConfigMapNamespace:          clusterIDConfigMapNamespace,
+		clusterID:                            clusterID,
+		configuredClusterID:                  clusterID,
+		brokerClientCreateFunc:               brokerClientCreateFunc,
 	}
 	controller.brokerClientManager = NewBrokerClientManager(brokerClientCreateFunc)
+	// The brokers can override the backoff between the retries of their
+	// catalog fetches and of the bind requests to them.
+	controller.clusterServiceBrokerQueue = workqueue.NewNamedRateLimitingQueue(newBrokerRetryRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration),
+		pollingStartInterval, operationPollingMaximumBackoffDuration, controller.clusterServiceBrokerCatalogRetryBackoff), "cluster-service-broker")
+	controller.serviceBrokerQueue = workqueue.NewNamedRateLimitingQueue(newBrokerRetryRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration),
+		pollingStartInterval, operationPollingMaximumBackoffDuration, controller.serviceBrokerCatalogRetryBackoff), "service-broker")
+	controller.bindingQueue = workqueue.NewNamedRateLimitingQueue(newBrokerRetryRateLimiter(
+		workqueue.DefaultControllerRateLimiter(),
+		minBrokerOperationRetryDelay, operationRetryMaximumBackoffDuration, controller.bindingOperationsRetryBackoff), "service-binding")
 
 	controller.clusterServiceBrokerLister = clusterServiceBrokerInformer.Lister()
 	clusterServiceBrokerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -157,6 +330,18 @@ func NewController(
 		DeleteFunc: controller.bindingDelete,
 	})
 
+	secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.secretUpdate,
+	})
+
+	configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.configMapUpdate,
+	})
+
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.namespaceUpdate,
+	})
+
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
 		controller.serviceBrokerLister = serviceBrokerInformer.Lister()
 		serviceBrokerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -178,7 +363,9 @@ func NewController(
 		})
 	}
 	controller.instanceOperationRetryQueue.instances = make(map[string]backoffEntry)
-	controller.instanceOperationRetryQueue.rateLimiter = workqueue.NewItemExponentialFailureRateLimiter(minBrokerOperationRetryDelay, maxBrokerOperationRetryDelay)
+	controller.instanceOperationRetryQueue.rateLimiter = newBrokerRetryRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(minBrokerOperationRetryDelay, operationRetryMaximumBackoffDuration),
+		minBrokerOperationRetryDelay, operationRetryMaximumBackoffDuration, nil)
 
 	return controller, nil
 }
@@ -205,6 +392,7 @@ type controller struct {
 	clusterServicePlanLister    listers.ClusterServicePlanLister
 	servicePlanLister           listers.ServicePlanLister
 	secretLister                v1.SecretLister
+	namespaceLister             v1.NamespaceLister
 	brokerRelistInterval        time.Duration
 	OSBAPIPreferredVersion      string
 	OSBAPITimeOut               time.Duration
@@ -231,6 +419,10 @@ type controller struct {
 	// value. If there is a configmap with a different value, it
 	// will be reconciled to become the value in the configmap.
 	clusterID string
+	// configuredClusterID is the cluster ID given at startup. When set, it
+	// takes precedence over the value in the configmap, which is reconciled
+	// to become this value instead.
+	configuredClusterID string
 	// clusterIDLock protects access to clusterID between the
 	// monitor writing the value from the configmap, and any
 	// readers passing the clusterID to a broker.
@@ -238,8 +430,106 @@ type controller struct {
 	instanceOperationRetryQueue instanceOperationBackoff
 	// BrokerClientManager holds all OSB clients for brokers.
 	brokerClientManager *BrokerClientManager
-
-	brokerClientCreateFunc osb.CreateFunc
+	// bindingSecretRetentionPolicy controls whether the Secret of a binding
+	// is deleted or kept when the binding is unbound.
+	bindingSecretRetentionPolicy BindingSecretRetentionPolicy
+	// classWithoutPlansPolicy controls whether a broker catalog with a
+	// service without plans is rejected, or synced without that service.
+	classWithoutPlansPolicy ClassWithoutPlansPolicy
+	// emptyBindingCredentialsPolicy controls whether a binding for which the
+	// broker returned no credentials gets the NoCredentials condition.
+	emptyBindingCredentialsPolicy EmptyBindingCredentialsPolicy
+	// brokerRelistTimeout is how long the relist of a broker may be in
+	// progress before the broker gets the RelistStuck condition. Zero
+	// disables recording the relist in the status of the broker.
+	brokerRelistTimeout time.Duration
+	// catalogStaleRelistMultiple is the number of relist intervals after
+	// which a broker whose catalog can not be retrieved gets the
+	// CatalogStale condition. Zero disables the condition.
+	catalogStaleRelistMultiple float64
+	// bindingInstanceWaitTimeout is how long a binding waits for its
+	// instance to become ready before the binding fails. Zero disables
+	// waiting, bindings of instances that are not ready are then retried
+	// as errors.
+	bindingInstanceWaitTimeout time.Duration
+	// namespaceDeletionDeprovisionTimeout is how long the deprovisioning of
+	// an instance is retried once the deletion of its namespace started,
+	// before the deprovisioning fails. Zero retries until the reconciliation
+	// retry duration is exceeded.
+	namespaceDeletionDeprovisionTimeout time.Duration
+	// asyncOperationTimeout is how long an asynchronous operation of an
+	// instance is polled before it fails as timed out. Zero polls until the
+	// reconciliation retry duration is exceeded. The async-operation-timeout
+	// annotation of an instance overrides it.
+	asyncOperationTimeout time.Duration
+	// deprovisionTimeout is how long the deprovisioning of a deleted
+	// instance may keep failing before the controller reports it as timed
+	// out, and removes the finalizer of instances with the force-orphan
+	// annotation. Zero disables the timeout. The deprovision-timeout
+	// annotation of an instance overrides it.
+	deprovisionTimeout time.Duration
+	// operationRetryMaximumBackoffDuration is the maximum delay between the
+	// retries of a failed provision or update of an instance. It is
+	// independent of the backoff used to poll in-progress operations.
+	operationRetryMaximumBackoffDuration time.Duration
+	// serviceAccountTokens caches the ServiceAccount tokens sent to brokers
+	// that authenticate with serviceAccountToken auth info.
+	serviceAccountTokens *serviceAccountTokenCache
+	// bindingCredentials caches the credentials of the bindings whose Secret
+	// could not be written after the broker bound them.
+	bindingCredentials *bindingCredentialsCache
+	// brokerCatalogs caches the catalogs of the brokers whose classes and
+	// plans could not all be written.
+	brokerCatalogs *brokerCatalogCache
+	// brokerTLSConfig is the template of the TLS configuration of the
+	// connections to the brokers, nil to use the defaults of Go.
+	brokerTLSConfig *tls.Config
+	// osbAPIUserAgentSuffix is appended to the User-Agent of the requests to
+	// the brokers that do not set a userAgentSuffix of their own.
+	osbAPIUserAgentSuffix string
+	// osbAPIUpdateContext is whether the OSB context is sent in update
+	// requests, and instances are updated when their context changes.
+	osbAPIUpdateContext bool
+	// namespaceLabelsChangeDelay is how long the instances of a namespace
+	// whose labels changed wait before being reconciled.
+	namespaceLabelsChangeDelay time.Duration
+	// parametersResyncInterval is how often the parametersFrom sources of
+	// ready instances are read again to detect parameters that changed
+	// without a Secret event. Zero disables the periodic read. The
+	// parameters-resync-interval annotation of an instance overrides it.
+	parametersResyncInterval time.Duration
+	// brokerHealthCheckInterval is how often the brokers that enable their
+	// health check are probed between relists. Zero disables the probes.
+	brokerHealthCheckInterval time.Duration
+	// brokerHealthProbe probes the brokers that enable their health check.
+	brokerHealthProbe BrokerHealthProbeFunc
+	// osbAPIAcceptsIncomplete is whether the first request of an operation
+	// accepts an asynchronous operation. Otherwise, the request is sent
+	// again with accepts_incomplete only when the broker requires it.
+	osbAPIAcceptsIncomplete bool
+	// osbAPIContextPlatform is the platform sent in the OSB context.
+	osbAPIContextPlatform string
+	// osbAPIContext holds the additional keys sent in the OSB context of
+	// all requests. The context annotation of a broker overrides them.
+	osbAPIContext map[string]interface{}
+	// brokerRequestLimiter limits the number of requests in flight to each
+	// broker.
+	brokerRequestLimiter *brokerRequestLimiter
+	// tracer records the spans of the reconciles and of the requests to the
+	// brokers. It is nil when tracing is disabled.
+	tracer *tracing.Tracer
+	// reconcileSpans holds the spans of the reconciles in progress.
+	reconcileSpans *reconcileSpans
+	// orphanMitigationGracePeriod is how long after a failed provision the
+	// orphan mitigation of an instance waits before deprovisioning it. Zero
+	// deprovisions it right away.
+	orphanMitigationGracePeriod time.Duration
+	// orphanMitigationCheckInstance controls whether the broker is asked for
+	// the state of the instance during the orphan mitigation grace period,
+	// to cancel the orphan mitigation of an instance it provisioned.
+	orphanMitigationCheckInstance bool
+
+	brokerClientCreateFunc BrokerClientCreateFunc
 }
 
 // Run runs the controller until the given stop channel can be read from.
@@ -250,18 +540,22 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 
 	var waitGroup sync.WaitGroup
 
+	// read the cluster ID before the workers start, so that the first
+	// requests to the brokers already carry it
+	c.monitorConfigMap()
+
 	for i := 0; i < workers; i++ {
-		createWorker(c.clusterServiceBrokerQueue, "ClusterServiceBroker", maxRetries, true, c.reconcileClusterServiceBrokerKey, stopCh, &waitGroup)
-		createWorker(c.clusterServiceClassQueue, "ClusterServiceClass", maxRetries, true, c.reconcileClusterServiceClassKey, stopCh, &waitGroup)
-		createWorker(c.clusterServicePlanQueue, "ClusterServicePlan", maxRetries, true, c.reconcileClusterServicePlanKey, stopCh, &waitGroup)
-		createWorker(c.instanceQueue, "ServiceInstance", maxRetries, true, c.reconcileServiceInstanceKey, stopCh, &waitGroup)
-		createWorker(c.bindingQueue, "ServiceBinding", maxRetries, true, c.reconcileServiceBindingKey, stopCh, &waitGroup)
+		createWorker(c.clusterServiceBrokerQueue, "ClusterServiceBroker", maxRetries, true, c.traceReconciler("ClusterServiceBroker", c.reconcileClusterServiceBrokerKey), stopCh, &waitGroup)
+		createWorker(c.clusterServiceClassQueue, "ClusterServiceClass", maxRetries, true, c.traceReconciler("ClusterServiceClass", c.reconcileClusterServiceClassKey), stopCh, &waitGroup)
+		createWorker(c.clusterServicePlanQueue, "ClusterServicePlan", maxRetries, true, c.traceReconciler("ClusterServicePlan", c.reconcileClusterServicePlanKey), stopCh, &waitGroup)
+		createWorker(c.instanceQueue, "ServiceInstance", maxRetries, true, c.traceReconciler("ServiceInstance", c.reconcileServiceInstanceKey), stopCh, &waitGroup)
+		createWorker(c.bindingQueue, "ServiceBinding", maxRetries, true, c.traceReconciler("ServiceBinding", c.reconcileServiceBindingKey), stopCh, &waitGroup)
 		createWorker(c.instancePollingQueue, "InstancePoller", maxRetries, false, c.requeueServiceInstanceForPoll, stopCh, &waitGroup)
 
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
-			createWorker(c.serviceBrokerQueue, "ServiceBroker", maxRetries, true, c.reconcileServiceBrokerKey, stopCh, &waitGroup)
-			createWorker(c.serviceClassQueue, "ServiceClass", maxRetries, true, c.reconcileServiceClassKey, stopCh, &waitGroup)
-			createWorker(c.servicePlanQueue, "ServicePlan", maxRetries, true, c.reconcileServicePlanKey, stopCh, &waitGroup)
+			createWorker(c.serviceBrokerQueue, "ServiceBroker", maxRetries, true, c.traceReconciler("ServiceBroker", c.reconcileServiceBrokerKey), stopCh, &waitGroup)
+			createWorker(c.serviceClassQueue, "ServiceClass", maxRetries, true, c.traceReconciler("ServiceClass", c.reconcileServiceClassKey), stopCh, &waitGroup)
+			createWorker(c.servicePlanQueue, "ServicePlan", maxRetries, true, c.traceReconciler("ServicePlan", c.reconcileServicePlanKey), stopCh, &waitGroup)
 		}
 
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
@@ -279,6 +573,12 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 	// instance operation retry entries
 	c.createPurgeExpiredRetryEntriesWorker(stopCh, &waitGroup)
 
+	// create a task that runs periodically to flag the relists which are
+	// stuck, since the worker of a stuck relist can not flag it itself
+	if c.brokerRelistTimeout > 0 {
+		c.createRelistStuckMonitorWorker(stopCh, &waitGroup)
+	}
+
 	<-stopCh
 	klog.Info("Shutting down service-catalog controller")
 
@@ -324,7 +624,18 @@ func (c *controller) createConfigMapMonitorWorker(stopCh <-chan struct{}, waitGr
 func (c *controller) createPurgeExpiredRetryEntriesWorker(stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
 	waitGroup.Add(1)
 	go func() {
-		wait.Until(c.purgeExpiredRetryEntries, 2*maxBrokerOperationRetryDelay, stopCh)
+		wait.Until(c.purgeExpiredRetryEntries, 2*c.operationRetryMaximumBackoffDuration, stopCh)
+		waitGroup.Done()
+	}()
+}
+
+// createRelistStuckMonitorWorker creates a task that runs periodically to set
+// the RelistStuck condition of the brokers whose relist has been in progress
+// for longer than the relist timeout.
+func (c *controller) createRelistStuckMonitorWorker(stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
+	waitGroup.Add(1)
+	go func() {
+		wait.Until(c.monitorStuckRelists, relistStuckMonitorInterval, stopCh)
 		waitGroup.Done()
 	}()
 }
@@ -340,6 +651,7 @@ func (c *controller) monitorConfigMap() {
 	klog.V(9).Info("cluster ID monitor loop enter")
 	cm, err := c.kubeClient.CoreV1().ConfigMaps(c.clusterIDConfigMapNamespace).Get(c.clusterIDConfigMapName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
+		c.defaultClusterID()
 		m := make(map[string]string)
 		m["id"] = c.getClusterID()
 		cm := &corev1.ConfigMap{
@@ -357,9 +669,11 @@ func (c *controller) monitorConfigMap() {
 	} else if err == nil {
 		// cluster id exists and is set
 		// get id out of cm
-		if id := cm.Data["id"]; "" != id {
+		if id := cm.Data["id"]; "" != id && (c.configuredClusterID == "" || id == c.configuredClusterID) {
 			c.setClusterID(id)
 		} else {
+			// the configmap has no id, or not the one given at startup
+			c.defaultClusterID()
 			m := cm.Data
 			if m == nil {
 				m = make(map[string]string)
@@ -398,6 +712,15 @@ func worker(queue workqueue.RateLimitingInterface, resourceType string, maxRetri
 					return false
 				}
 
+				// A broker busy with other requests is not a failure of
+				// the resource, so it neither counts as a retry nor
+				// increases the backoff.
+				if isBrokerRequestLimitError(err) {
+					klog.V(4).Infof("Requeuing %s %v: %v", resourceType, key, err)
+					queue.AddAfter(key, wait.Jitter(brokerRequestLimitRequeueDelay, 1.0))
+					return false
+				}
+
 				numRequeues := queue.NumRequeues(key)
 				if numRequeues < maxRetries {
 					klog.V(4).Infof("Error syncing %s %v (retry: %d/%d): %v", resourceType, key, numRequeues, maxRetries, err)
@@ -504,6 +827,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 	if err != nil {
 		return nil, "", nil, err
 	}
+	brokerClient = c.traceBrokerClient("ServiceInstance", instance.ObjectMeta, NewClusterServiceBrokerKey(broker.Name), brokerClient)
 
 	return serviceClass, broker.Name, brokerClient, nil
 }
@@ -539,6 +863,7 @@ func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceIn
 	if err != nil {
 		return nil, "", nil, err
 	}
+	brokerClient = c.traceBrokerClient("ServiceInstance", instance.ObjectMeta, NewServiceBrokerKey(broker.Namespace, broker.Name), brokerClient)
 	return serviceClass, broker.Name, brokerClient, nil
 }
 
@@ -660,6 +985,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		if err != nil {
 			return nil, err
 		}
+		brokerClient = c.traceBrokerClient("ServiceBinding", binding.ObjectMeta, NewClusterServiceBrokerKey(broker.Name), brokerClient)
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, err := c.getServiceClassForServiceBinding(instance, binding)
 		if err != nil {
@@ -675,6 +1001,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		if err != nil {
 			return nil, err
 		}
+		brokerClient = c.traceBrokerClient("ServiceBinding", binding.ObjectMeta, NewServiceBrokerKey(broker.Namespace, broker.Name), brokerClient)
 	}
 
 	return brokerClient, nil
@@ -716,6 +1043,17 @@ func (c *controller) getAuthCredentialsFromClusterServiceBroker(broker *v1beta1.
 		return &osb.AuthConfig{
 			BearerConfig: bearerConfig,
 		}, nil
+	} else if authInfo.ServiceAccountToken != nil {
+		saRef := authInfo.ServiceAccountToken.ServiceAccountRef
+		token, err := c.serviceAccountTokens.Token(saRef.Namespace, saRef.Name, authInfo.ServiceAccountToken.Audience)
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{
+				Token: token,
+			},
+		}, nil
 	}
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %v", authInfo)
 }
@@ -754,6 +1092,17 @@ func (c *controller) getAuthCredentialsFromServiceBroker(broker *v1beta1.Service
 		return &osb.AuthConfig{
 			BearerConfig: bearerConfig,
 		}, nil
+	} else if authInfo.ServiceAccountToken != nil {
+		saRef := authInfo.ServiceAccountToken.ServiceAccountRef
+		token, err := c.serviceAccountTokens.Token(broker.Namespace, saRef.Name, authInfo.ServiceAccountToken.Audience)
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{
+				Token: token,
+			},
+		}, nil
 	}
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %v", authInfo)
 }
@@ -786,6 +1135,28 @@ func getBearerConfig(secret *corev1.Secret) (*osb.BearerConfig, error) {
 	}, nil
 }
 
+// serviceWithoutPlansError is the error that rejects a broker catalog with a
+// service that has no plans, since no class can be provisioned for it.
+func serviceWithoutPlansError(svc osb.Service) error {
+	return fmt.Errorf("service %q (ID %q) has no plans", svc.Name, svc.ID)
+}
+
+// skipServicesWithoutPlans removes the services that have no plans from a
+// broker catalog, and returns the names of the removed services.
+func skipServicesWithoutPlans(catalog *osb.CatalogResponse) []string {
+	var skipped []string
+	services := make([]osb.Service, 0, len(catalog.Services))
+	for _, svc := range catalog.Services {
+		if len(svc.Plans) == 0 {
+			skipped = append(skipped, fmt.Sprintf("%q (ID %q)", svc.Name, svc.ID))
+			continue
+		}
+		services = append(services, svc)
+	}
+	catalog.Services = services
+	return skipped
+}
+
 // convertAndFilterCatalogToNamespacedTypes converts a service broker catalog
 // into an array of ServiceClasses and an array of ServicePlans and filters
 // these through the restrictions provided. The ServiceClasses and
@@ -844,6 +1215,9 @@ func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogR
 
 		// If this service class passes the predicate, process the plans for the class.
 		if fields := v1beta1.ConvertServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+			if len(svc.Plans) == 0 {
+				return nil, nil, serviceWithoutPlansError(svc)
+			}
 			// set up the plans using the ServiceClass Name
 			plans, err := convertServicePlans(namespace, svc.Plans, serviceClass.Name, existingServicePlans)
 			if err != nil {
@@ -973,6 +1347,9 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 
 		// If this service class passes the predicate, process the plans for the class.
 		if fields := v1beta1.ConvertClusterServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+			if len(svc.Plans) == 0 {
+				return nil, nil, serviceWithoutPlansError(svc)
+			}
 			// set up the plans using the ClusterServiceClass Name
 			plans, err := convertClusterServicePlans(svc.Plans, serviceClass.Name, existingServicePlans)
 			if err != nil {
@@ -1107,50 +1484,8 @@ func convertCommonServicePlan(plan osb.Plan, commonServicePlanSpec *v1beta1.Comm
 		commonServicePlanSpec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
 	}
 
-	if schemas := plan.Schemas; schemas != nil {
-		if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
-			if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
-				schema, err := json.Marshal(instanceCreateSchema.Parameters)
-				if err != nil {
-					err = fmt.Errorf("Failed to marshal instance create schema \n%+v\n %v", instanceCreateSchema.Parameters, err)
-					klog.Error(err)
-					return err
-				}
-				commonServicePlanSpec.InstanceCreateParameterSchema = &runtime.RawExtension{Raw: schema}
-			}
-			if instanceUpdateSchema := instanceSchemas.Update; instanceUpdateSchema != nil && instanceUpdateSchema.Parameters != nil {
-				schema, err := json.Marshal(instanceUpdateSchema.Parameters)
-				if err != nil {
-					err = fmt.Errorf("Failed to marshal instance update schema \n%+v\n %v", instanceUpdateSchema.Parameters, err)
-					klog.Error(err)
-					return err
-				}
-				commonServicePlanSpec.InstanceUpdateParameterSchema = &runtime.RawExtension{Raw: schema}
-			}
-		}
-		if bindingSchemas := schemas.ServiceBinding; bindingSchemas != nil {
-			if bindingCreateSchema := bindingSchemas.Create; bindingCreateSchema != nil {
-				if bindingCreateSchema.Parameters != nil {
-					schema, err := json.Marshal(bindingCreateSchema.Parameters)
-					if err != nil {
-						err = fmt.Errorf("Failed to marshal binding create schema \n%+v\n %v", bindingCreateSchema.Parameters, err)
-						klog.Error(err)
-						return err
-					}
-					commonServicePlanSpec.ServiceBindingCreateParameterSchema = &runtime.RawExtension{Raw: schema}
-				}
-				if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) && bindingCreateSchema.Response != nil {
-					schema, err := json.Marshal(bindingCreateSchema.Response)
-					if err != nil {
-						err = fmt.Errorf("Failed to marshal binding create response schema \n%+v\n %v", bindingCreateSchema.Response, err)
-						klog.Error(err)
-						return err
-					}
-					commonServicePlanSpec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{Raw: schema}
-				}
-			}
-		}
-	}
+	convertPlanSchemas(plan, commonServicePlanSpec)
+	convertPlanCosts(plan, commonServicePlanSpec)
 	return nil
 }
 
@@ -1194,50 +1529,8 @@ func convertClusterServicePlans(plans []osb.Plan, serviceClassID string, existin
 			servicePlans[i].Spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
 		}
 
-		if schemas := plan.Schemas; schemas != nil {
-			if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
-				if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
-					schema, err := json.Marshal(instanceCreateSchema.Parameters)
-					if err != nil {
-						err = fmt.Errorf("Failed to marshal instance create schema \n%+v\n %v", instanceCreateSchema.Parameters, err)
-						klog.Error(err)
-						return nil, err
-					}
-					servicePlans[i].Spec.InstanceCreateParameterSchema = &runtime.RawExtension{Raw: schema}
-				}
-				if instanceUpdateSchema := instanceSchemas.Update; instanceUpdateSchema != nil && instanceUpdateSchema.Parameters != nil {
-					schema, err := json.Marshal(instanceUpdateSchema.Parameters)
-					if err != nil {
-						err = fmt.Errorf("Failed to marshal instance update schema \n%+v\n %v", instanceUpdateSchema.Parameters, err)
-						klog.Error(err)
-						return nil, err
-					}
-					servicePlans[i].Spec.InstanceUpdateParameterSchema = &runtime.RawExtension{Raw: schema}
-				}
-			}
-			if bindingSchemas := schemas.ServiceBinding; bindingSchemas != nil {
-				if bindingCreateSchema := bindingSchemas.Create; bindingCreateSchema != nil {
-					if bindingCreateSchema.Parameters != nil {
-						schema, err := json.Marshal(bindingCreateSchema.Parameters)
-						if err != nil {
-							err = fmt.Errorf("Failed to marshal binding create schema \n%+v\n %v", bindingCreateSchema.Parameters, err)
-							klog.Error(err)
-							return nil, err
-						}
-						servicePlans[i].Spec.ServiceBindingCreateParameterSchema = &runtime.RawExtension{Raw: schema}
-					}
-					if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) && bindingCreateSchema.Response != nil {
-						schema, err := json.Marshal(bindingCreateSchema.Response)
-						if err != nil {
-							err = fmt.Errorf("Failed to marshal binding create response schema \n%+v\n %v", bindingCreateSchema.Response, err)
-							klog.Error(err)
-							return nil, err
-						}
-						servicePlans[i].Spec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{Raw: schema}
-					}
-				}
-			}
-		}
+		convertPlanSchemas(plan, &servicePlans[i].Spec.CommonServicePlanSpec)
+		convertPlanCosts(plan, &servicePlans[i].Spec.CommonServicePlanSpec)
 	}
 	return servicePlans, nil
 }
@@ -1260,6 +1553,49 @@ func isServiceInstanceReady(instance *v1beta1.ServiceInstance) bool {
 	return isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionReady)
 }
 
+// conditionTypeLess orders condition types canonically, so that the same set of
+// conditions is always written in the same order: Ready comes first, then
+// Failed, then all other types by name.
+func conditionTypeLess(a, b string) bool {
+	rank := func(conditionType string) int {
+		switch conditionType {
+		case string(v1beta1.ServiceInstanceConditionReady):
+			return 0
+		case string(v1beta1.ServiceInstanceConditionFailed):
+			return 1
+		}
+		return 2
+	}
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	return a < b
+}
+
+// sortServiceInstanceConditions sorts the conditions of an instance in the
+// canonical order.
+func sortServiceInstanceConditions(conditions []v1beta1.ServiceInstanceCondition) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditionTypeLess(string(conditions[i].Type), string(conditions[j].Type))
+	})
+}
+
+// sortServiceBindingConditions sorts the conditions of a binding in the
+// canonical order.
+func sortServiceBindingConditions(conditions []v1beta1.ServiceBindingCondition) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditionTypeLess(string(conditions[i].Type), string(conditions[j].Type))
+	})
+}
+
+// sortServiceBrokerConditions sorts the conditions of a broker in the
+// canonical order.
+func sortServiceBrokerConditions(conditions []v1beta1.ServiceBrokerCondition) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditionTypeLess(string(conditions[i].Type), string(conditions[j].Type))
+	})
+}
+
 // isServiceInstanceFailed returns whether the instance has a failed condition with
 // status true.
 func isServiceInstanceFailed(instance *v1beta1.ServiceInstance) bool {
@@ -1272,10 +1608,132 @@ func isServiceInstanceOrphanMitigation(instance *v1beta1.ServiceInstance) bool {
 	return isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionOrphanMitigation)
 }
 
+// isServiceInstancePaused returns whether the instance carries the paused
+// annotation.
+func isServiceInstancePaused(instance *v1beta1.ServiceInstance) bool {
+	return instance.Annotations[v1beta1.ServiceInstancePausedAnnotation] == "true"
+}
+
+// asyncOperationTimeoutOf returns how long an asynchronous operation of the
+// given instance is polled before it fails as timed out: the value of the
+// async-operation-timeout annotation of the instance, or the timeout of the
+// controller when the instance has no valid annotation. Zero disables the
+// timeout.
+func (c *controller) asyncOperationTimeoutOf(instance *v1beta1.ServiceInstance) time.Duration {
+	value, ok := instance.Annotations[v1beta1.ServiceInstanceAsyncOperationTimeoutAnnotation]
+	if !ok {
+		return c.asyncOperationTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Ignoring the invalid %s annotation %q", v1beta1.ServiceInstanceAsyncOperationTimeoutAnnotation, value))
+		return c.asyncOperationTimeout
+	}
+	return timeout
+}
+
+// asyncOperationTimedOut returns whether the asynchronous operation of the
+// given instance has been in progress for longer than its timeout.
+func (c *controller) asyncOperationTimedOut(instance *v1beta1.ServiceInstance) bool {
+	timeout := c.asyncOperationTimeoutOf(instance)
+	if timeout == 0 || instance.Status.OperationStartTime == nil {
+		return false
+	}
+	return time.Since(instance.Status.OperationStartTime.Time) > timeout
+}
+
+// deprovisionTimeoutOf returns how long the deprovisioning of the given
+// deleted instance may keep failing before it is reported as timed out: the
+// value of the deprovision-timeout annotation of the instance, or the timeout
+// of the controller when the instance has no valid annotation. Zero disables
+// the timeout.
+func (c *controller) deprovisionTimeoutOf(instance *v1beta1.ServiceInstance) time.Duration {
+	value, ok := instance.Annotations[v1beta1.ServiceInstanceDeprovisionTimeoutAnnotation]
+	if !ok {
+		return c.deprovisionTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Ignoring the invalid %s annotation %q", v1beta1.ServiceInstanceDeprovisionTimeoutAnnotation, value))
+		return c.deprovisionTimeout
+	}
+	return timeout
+}
+
+// parametersResyncIntervalOf returns how often the parametersFrom sources of
+// the given instance are read again: the value of the
+// parameters-resync-interval annotation of the instance, or the interval of
+// the controller when the instance has no valid annotation. Zero disables the
+// periodic read.
+func (c *controller) parametersResyncIntervalOf(instance *v1beta1.ServiceInstance) time.Duration {
+	value, ok := instance.Annotations[v1beta1.ServiceInstanceParametersResyncIntervalAnnotation]
+	if !ok {
+		return c.parametersResyncInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Ignoring the invalid %s annotation %q", v1beta1.ServiceInstanceParametersResyncIntervalAnnotation, value))
+		return c.parametersResyncInterval
+	}
+	return interval
+}
+
+// isServiceInstanceForceOrphan returns whether the instance has the
+// force-orphan annotation set to "true".
+func isServiceInstanceForceOrphan(instance *v1beta1.ServiceInstance) bool {
+	return instance.Annotations[v1beta1.ServiceInstanceForceOrphanAnnotation] == "true"
+}
+
+// newBrokerTLSConfig returns the TLS configuration for the connections to the
+// brokers with the given minimum version and cipher suites, or nil to use the
+// defaults of Go when neither is set.
+func newBrokerTLSConfig(minVersion string, cipherSuites []string) (*tls.Config, error) {
+	if minVersion == "" && len(cipherSuites) == 0 {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if minVersion != "" {
+		version, err := cliflag.TLSVersion(minVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid broker TLS minimum version: %v, allowed values are: %v", err, strings.Join(cliflag.TLSPossibleVersions(), ", "))
+		}
+		tlsConfig.MinVersion = version
+	}
+	suites, err := cliflag.TLSCipherSuites(cipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker TLS cipher suites: %v", err)
+	}
+	tlsConfig.CipherSuites = suites
+	return tlsConfig, nil
+}
+
+// brokerUserAgentProduct identifies service catalog in the User-Agent of the
+// requests to the brokers, followed by its version.
+const brokerUserAgentProduct = "service-catalog"
+
+// brokerUserAgent returns the User-Agent of the requests to a broker, e.g.
+// "service-catalog/v0.3.0 prod-cluster" for the suffix "prod-cluster".
+func brokerUserAgent(suffix string) string {
+	userAgent := brokerUserAgentProduct + "/" + version.Get().GitVersion
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
 // NewClientConfigurationForBroker creates a new ClientConfiguration for connecting
-// to the specified Broker
-func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, authConfig *osb.AuthConfig, osbAPITimeOut time.Duration) *osb.ClientConfiguration {
-	clientConfig := osb.DefaultClientConfiguration()
+// to the specified Broker. The tlsConfig is copied, it may be nil to use the
+// defaults of Go. The userAgentSuffix of the broker spec takes precedence over
+// the given defaultUserAgentSuffix.
+func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, authConfig *osb.AuthConfig, osbAPITimeOut time.Duration, tlsConfig *tls.Config, defaultUserAgentSuffix string) *BrokerClientConfiguration {
+	clientConfig := &BrokerClientConfiguration{ClientConfiguration: *osb.DefaultClientConfiguration()}
+	if tlsConfig != nil {
+		clientConfig.TLSConfig = tlsConfig.Clone()
+	}
 	clientConfig.Name = meta.Name
 	clientConfig.URL = commonSpec.URL
 	clientConfig.AuthConfig = authConfig
@@ -1283,6 +1741,12 @@ func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1
 	clientConfig.Insecure = commonSpec.InsecureSkipTLSVerify
 	clientConfig.CAData = commonSpec.CABundle
 	clientConfig.TimeoutSeconds = int(osbAPITimeOut.Seconds())
+	userAgentSuffix := commonSpec.UserAgentSuffix
+	if userAgentSuffix == "" {
+		userAgentSuffix = defaultUserAgentSuffix
+	}
+	clientConfig.UserAgent = brokerUserAgent(userAgentSuffix)
+	clientConfig.Protocol = commonSpec.Protocol
 	return clientConfig
 }
 
@@ -1310,6 +1774,20 @@ func isRetriableHTTPStatus(statusCode int) bool {
 	return statusCode != http.StatusBadRequest
 }
 
+// retryAsAsyncOperation returns whether a request that failed with err has
+// to be sent again with accepts_incomplete, because the broker responded with
+// 422 AsyncRequired to a request that did not accept an asynchronous
+// operation. In that case an event is recorded on obj. asyncAllowed is
+// whether the controller can handle an asynchronous operation for the
+// request.
+func (c *controller) retryAsAsyncOperation(obj runtime.Object, acceptsIncomplete bool, asyncAllowed bool, err error) bool {
+	if acceptsIncomplete || !asyncAllowed || !osb.IsAsyncRequiredError(err) {
+		return false
+	}
+	c.recorder.Event(obj, corev1.EventTypeNormal, asyncRequiredReason, asyncRequiredMessage)
+	return true
+}
+
 // ReconciliationAction represents a type of action the reconciler should take
 // for a resource.
 type ReconciliationAction string
@@ -1350,6 +1828,32 @@ func (c *controller) setClusterID(id string) {
 	c.clusterIDLock.Unlock()
 }
 
+// defaultClusterID sets the cluster ID to the UID of the kube-system
+// namespace if no cluster ID is set yet. The UID identifies the cluster for
+// as long as it exists, so the same ID is sent again should the configmap
+// holding it be lost. If the namespace can not be read, a random ID is
+// generated on first access instead.
+func (c *controller) defaultClusterID() {
+	c.clusterIDLock.RLock()
+	id := c.clusterID
+	c.clusterIDLock.RUnlock()
+	if id != "" {
+		return
+	}
+
+	ns, err := c.kubeClient.CoreV1().Namespaces().Get(metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		klog.Warningf("could not get the %s namespace to use its UID as the cluster id: %v", metav1.NamespaceSystem, err)
+		return
+	}
+
+	c.clusterIDLock.Lock()
+	if c.clusterID == "" {
+		c.clusterID = string(ns.UID)
+	}
+	c.clusterIDLock.Unlock()
+}
+
 // getServiceClassPlanAndServiceBrokerForServiceBinding is a sequence of operations that's
 // done to validate service plan, service class exist, and handles creating
 // a brokerclient to use for a given ServiceInstance.
@@ -1464,6 +1968,10 @@ func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *
 		// conditions, we should reconcile it.
 		return true
 	}
+	if brokerStatus.CurrentOperation != "" {
+		// A relist was interrupted, e.g. by a restart of the controller.
+		return true
+	}
 
 	// find the ready condition in the broker's status
 	for _, condition := range brokerStatus.Conditions {
@@ -1484,13 +1992,9 @@ func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *
 
 				// By default, the broker should relist if it has been longer than the
 				// RelistDuration since the last time we fetched the Catalog
-				duration := defaultRelistInterval
-				if brokerSpec.RelistDuration != nil {
-					duration = brokerSpec.RelistDuration.Duration
-				}
-
 				intervalPassed := true
 				if brokerStatus.LastCatalogRetrievalTime != nil {
+					duration := relistDuration(brokerMeta, brokerSpec, brokerStatus.LastCatalogRetrievalTime.Time, defaultRelistInterval)
 					intervalPassed = now.After(brokerStatus.LastCatalogRetrievalTime.Time.Add(duration))
 				}
 				if intervalPassed == false {
@@ -1509,6 +2013,42 @@ func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *
 	return true
 }
 
+// timeUntilNextRelist returns how long it is until the relist interval of a
+// broker, which is not due to be relisted yet, elapses. It returns false if the
+// broker is not relisted on a schedule or has not been relisted before.
+func timeUntilNextRelist(brokerMeta *metav1.ObjectMeta, brokerSpec *v1beta1.CommonServiceBrokerSpec, brokerStatus *v1beta1.CommonServiceBrokerStatus, now time.Time, defaultRelistInterval time.Duration) (time.Duration, bool) {
+	if brokerSpec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorManual || brokerStatus.LastCatalogRetrievalTime == nil {
+		return 0, false
+	}
+	duration := relistDuration(brokerMeta, brokerSpec, brokerStatus.LastCatalogRetrievalTime.Time, defaultRelistInterval)
+	remaining := brokerStatus.LastCatalogRetrievalTime.Time.Add(duration).Sub(now)
+	if remaining <= 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// relistDuration returns how long after the catalog of a broker was last
+// retrieved at lastRetrieval the broker is relisted: its RelistDuration, or
+// the default relist interval, delayed by up to its
+// CatalogRefreshJitterPercent percent of it. The delay is derived from the
+// UID of the broker and the time of the last retrieval rather than chosen at
+// random, so that it stays the same across the reconciles between two
+// relists while differing from broker to broker and from relist to relist.
+func relistDuration(brokerMeta *metav1.ObjectMeta, brokerSpec *v1beta1.CommonServiceBrokerSpec, lastRetrieval time.Time, defaultRelistInterval time.Duration) time.Duration {
+	duration := defaultRelistInterval
+	if brokerSpec.RelistDuration != nil {
+		duration = brokerSpec.RelistDuration.Duration
+	}
+	if brokerSpec.CatalogRefreshJitterPercent <= 0 {
+		return duration
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s/%d", brokerMeta.UID, lastRetrieval.Unix())
+	fraction := float64(h.Sum64()>>11) / (1 << 53)
+	return duration + time.Duration(fraction*float64(brokerSpec.CatalogRefreshJitterPercent)/100*float64(duration))
+}
+
 func toJSON(obj interface{}) string {
 	bytes, _ := json.Marshal(obj)
 	return string(bytes)
@@ -21,17 +21,22 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
@@ -47,9 +52,16 @@ const (
 	errorNonbindableClusterServiceClassReason string = "ErrorNonbindableServiceClass"
 	errorServiceInstanceRefsUnresolved        string = "ErrorInstanceRefsUnresolved"
 	errorServiceInstanceNotReadyReason        string = "ErrorInstanceNotReady"
+	errorServiceInstanceFailedReason          string = "ErrorInstanceFailed"
+	errorWaitingForInstanceTimeoutReason      string = "WaitingForInstanceTimeout"
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
+	errorImportingBindingFailedReason         string = "ImportingBindingFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	errorUnbindStaleBindingReason             string = "UnbindStaleBindingFailed"
+	errorRebindFailedReason                   string = "RebindFailed"
+	errorRebindFailedMessage                  string = "The rebind failed; the Secret keeps the previous credentials"
+	errorSecretNamespaceForbiddenReason       string = "SecretNamespaceForbidden"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
@@ -62,11 +74,32 @@ const (
 	bindingInFlightMessage           string = "Binding request for ServiceBinding in-flight to Broker"
 	unbindingInFlightReason          string = "UnbindingRequestInFlight"
 	unbindingInFlightMessage         string = "Unbind request for ServiceBinding in-flight to Broker"
+	waitingForInstanceReason         string = "WaitingForInstance"
+	instanceReadyReason              string = "InstanceReady"
+	instanceReadyMessage             string = "The referenced ServiceInstance is ready"
+	importingBindingReason           string = "ImportingBinding"
+	importingBindingMessage          string = "Fetching the existing binding %q from the broker instead of creating a new one"
+	rebindingReason                  string = "Rebinding"
+	rebindingMessage                 string = "Binding again under a new ID; the Secret keeps the previous credentials until the new ones are written"
+	successReboundReason             string = "Rebound"
+	successReboundMessage            string = "The binding was rebound and its new credentials were written into the Secret"
+	unboundStaleBindingReason        string = "UnboundStaleBinding"
+	unboundStaleBindingMessage       string = "The stale binding %q was unbound at the broker"
+	noCredentialsReason              string = "NoCredentials"
+	noCredentialsMessage             string = "The broker returned no credentials for the binding; its Secret has no keys"
+	credentialsReturnedReason        string = "CredentialsReturned"
+	credentialsReturnedMessage       string = "The broker returned credentials for the binding"
+	parametersChangedReason          string = "ParametersChanged"
+	parametersChangedMessage         string = "The parameters read from spec.parametersFrom changed; rebinding to send them to the broker"
 )
 
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
 var bindingControllerKind = v1beta1.SchemeGroupVersion.WithKind("ServiceBinding")
 
+// bindingSecretVerbs are the verbs the controller uses on the Secret of a
+// binding.
+var bindingSecretVerbs = []string{"get", "create", "update", "delete"}
+
 // ServiceBinding handlers and control-loop
 
 func (c *controller) bindingAdd(obj interface{}) {
@@ -110,6 +143,129 @@ func (c *controller) bindingDelete(obj interface{}) {
 
 	pcb := pretty.NewBindingContextBuilder(binding)
 	klog.V(4).Info(pcb.Messagef("Received DELETE event; no further processing will occur; resourceVersion %v", binding.ResourceVersion))
+	c.bindingCredentials.Delete(binding.UID)
+}
+
+// configMapUpdate handles the ConfigMap UPDATED watch event. The bind
+// parameters are only sent to the broker when binding, so the ready bindings
+// that read a changed key in their spec.parametersFrom are queued, and
+// rebound by the reconciler if their parameters changed.
+func (c *controller) configMapUpdate(oldObj, newObj interface{}) {
+	oldConfigMap, ok := oldObj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	configMap, ok := newObj.(*corev1.ConfigMap)
+	if !ok || reflect.DeepEqual(oldConfigMap.Data, configMap.Data) {
+		return
+	}
+
+	bindings, err := c.bindingLister.ServiceBindings(configMap.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Couldn't list bindings referencing config map %s/%s: %v", configMap.Namespace, configMap.Name, err)
+		return
+	}
+	for _, binding := range bindings {
+		if !isServiceBindingReady(binding) || isServiceBindingRebindPending(binding) {
+			continue
+		}
+		if referencesChangedConfigMapKey(binding, oldConfigMap, configMap) {
+			pcb := pretty.NewBindingContextBuilder(binding)
+			klog.V(eventHandlerLogLevel).Info(pcb.Messagef("Parameters read from config map %q changed", configMap.Name))
+			c.bindingAdd(binding)
+		}
+	}
+}
+
+// referencesChangedConfigMapKey returns whether the binding sources parameters
+// from a key of the config map whose value differs between its old and new
+// versions.
+func referencesChangedConfigMapKey(binding *v1beta1.ServiceBinding, oldConfigMap, configMap *corev1.ConfigMap) bool {
+	for _, p := range binding.Spec.ParametersFrom {
+		ref := p.ConfigMapKeyRef
+		if ref == nil || ref.Name != configMap.Name {
+			continue
+		}
+		oldValue, oldOk := oldConfigMap.Data[ref.Key]
+		value, ok := configMap.Data[ref.Key]
+		if oldOk != ok || oldValue != value {
+			return true
+		}
+	}
+	return false
+}
+
+// configMapParametersChanged returns whether the parameters of a ready
+// binding which sources parameters from config maps no longer match the
+// parameters last sent to the broker. The config maps are read again on
+// every reconciliation of the binding, so that the changes of the config maps
+// which are not watched are noticed on the periodic resync of the bindings.
+func (c *controller) configMapParametersChanged(binding *v1beta1.ServiceBinding) bool {
+	if binding.Status.ExternalProperties == nil || !isServiceBindingReady(binding) ||
+		isServiceBindingRebindPending(binding) || binding.Status.CurrentOperation != "" {
+		return false
+	}
+	if !referencesConfigMap(binding) {
+		return false
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		klog.Warning(pcb.Messagef("Unable to check the config map parameters for changes: %v", err))
+		return false
+	}
+	_, parametersChecksum, _, err := prepareInProgressPropertyParameters(
+		c.kubeClient,
+		binding.Namespace,
+		binding.Spec.Parameters,
+		bindingParametersFrom(binding, instance),
+	)
+	if err != nil {
+		klog.Warning(pcb.Messagef("Unable to check the config map parameters for changes: %v", err))
+		return false
+	}
+	return parametersChecksum != binding.Status.ExternalProperties.ParameterChecksum
+}
+
+// requestRebindForChangedParameters increments spec.rebindRequests of the
+// binding so that the changed parameters are sent to the broker in the bind
+// request of a rebind. The previous credentials keep working until the
+// rebind replaces them.
+func (c *controller) requestRebindForChangedParameters(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	klog.V(4).Info(pcb.Message("Requesting a rebind because the parameters changed"))
+
+	toUpdate := binding.DeepCopy()
+	toUpdate.Spec.RebindRequests++
+	if _, err := c.serviceCatalogClient.ServiceBindings(toUpdate.Namespace).Update(toUpdate); err != nil {
+		klog.Error(pcb.Messagef("Failed to request a rebind for the changed parameters: %v", err))
+		return err
+	}
+
+	c.recorder.Event(binding, corev1.EventTypeNormal, parametersChangedReason, parametersChangedMessage)
+	return nil
+}
+
+// referencesConfigMap returns whether the binding sources parameters from a
+// config map.
+func referencesConfigMap(binding *v1beta1.ServiceBinding) bool {
+	for _, p := range binding.Spec.ParametersFrom {
+		if p.ConfigMapKeyRef != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isServiceBindingReady returns whether the binding has the Ready condition.
+func isServiceBindingReady(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady && condition.Status == v1beta1.ConditionTrue {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *controller) reconcileServiceBindingKey(key string) error {
@@ -131,6 +287,38 @@ func (c *controller) reconcileServiceBindingKey(key string) error {
 	return c.reconcileServiceBinding(binding)
 }
 
+// isServiceBindingImported returns whether the binding carries the import
+// annotation.
+func isServiceBindingImported(binding *v1beta1.ServiceBinding) bool {
+	return binding.Annotations[v1beta1.ServiceBindingImportAnnotation] == "true"
+}
+
+// isServiceBindingRebindPending returns whether a rebind of the binding was
+// requested by increasing its rebind requests, and has not been processed
+// yet.
+func isServiceBindingRebindPending(binding *v1beta1.ServiceBinding) bool {
+	return binding.Spec.RebindRequests > binding.Status.RebindCount
+}
+
+// serviceBindingExternalID returns the ID of the binding at the broker, which
+// is the one of the spec until a rebind replaces it.
+func serviceBindingExternalID(binding *v1beta1.ServiceBinding) string {
+	if binding.Status.ExternalID != "" {
+		return binding.Status.ExternalID
+	}
+	return binding.Spec.ExternalID
+}
+
+// serviceBindingOperationExternalID returns the ID of the binding that the
+// current operation is about: the new ID of a rebind in progress, or else the
+// ID of the binding at the broker.
+func serviceBindingOperationExternalID(binding *v1beta1.ServiceBinding) string {
+	if binding.Status.RebindExternalID != "" {
+		return binding.Status.RebindExternalID
+	}
+	return serviceBindingExternalID(binding)
+}
+
 func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	for _, condition := range binding.Status.Conditions {
 		if condition.Type == v1beta1.ServiceBindingConditionFailed && condition.Status == v1beta1.ConditionTrue {
@@ -140,6 +328,17 @@ func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	return false
 }
 
+// getServiceBindingCondition returns the condition of the given type of the
+// binding, or nil if the binding does not have such a condition.
+func getServiceBindingCondition(binding *v1beta1.ServiceBinding, conditionType v1beta1.ServiceBindingConditionType) *v1beta1.ServiceBindingCondition {
+	for i, condition := range binding.Status.Conditions {
+		if condition.Type == conditionType {
+			return &binding.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // getReconciliationActionForServiceBinding gets the action the reconciler
 // should be taking on the given binding.
 func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) ReconciliationAction {
@@ -187,12 +386,21 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		return nil
 	}
 
+	// The bindings that a rebind left behind are unbound once no operation
+	// is in progress, that is once the Secret holds the new credentials.
+	if len(binding.Status.StaleExternalIDs) > 0 && binding.Status.CurrentOperation == "" {
+		return c.reconcileStaleServiceBindings(binding)
+	}
+
 	if isServiceBindingFailed(binding) {
 		klog.V(4).Info(pcb.Message("not processing event; status showed that it has failed"))
 		return nil
 	}
 
 	if binding.Status.ReconciledGeneration == binding.Generation {
+		if c.configMapParametersChanged(binding) {
+			return c.requestRebindForChangedParameters(binding)
+		}
 		klog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
 		return nil
 	}
@@ -210,6 +418,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 
 	var prettyName string
 	var brokerClient osb.Client
+	var bindingRetrievable bool
 	var request *osb.BindRequest
 	var inProgressProperties *v1beta1.ServiceBindingPropertiesState
 
@@ -227,18 +436,20 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 
 		if !isClusterServicePlanBindable(serviceClass, servicePlan) {
-			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ClusterServiceClassName(serviceClass), instance.Spec.ClusterServicePlanExternalName)
+			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ClusterServiceClassName(serviceClass), servicePlan.Spec.ExternalName)
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
 			return c.processBindFailure(binding, readyCond, failedCond, false)
 		}
 
 		if !isServiceInstanceReady(instance) {
-			msg := fmt.Sprintf(`Binding cannot begin because referenced %s is not ready`, pretty.ServiceInstanceName(instance))
-			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorServiceInstanceNotReadyReason, msg)
-			return c.processServiceBindingOperationError(binding, readyCond)
+			return c.processServiceBindingInstanceNotReady(binding, instance)
+		}
+		if err := c.finishWaitingForServiceInstance(binding); err != nil {
+			return err
 		}
 
 		klog.V(4).Info(pcb.Message("Adding/Updating"))
@@ -264,18 +475,20 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 
 		if !isServicePlanBindable(serviceClass, servicePlan) {
-			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ServiceClassName(serviceClass), instance.Spec.ClusterServicePlanExternalName)
+			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ServiceClassName(serviceClass), servicePlan.Spec.ExternalName)
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
 			return c.processBindFailure(binding, readyCond, failedCond, false)
 		}
 
 		if !isServiceInstanceReady(instance) {
-			msg := fmt.Sprintf(`Binding cannot begin because referenced %s is not ready`, pretty.ServiceInstanceName(instance))
-			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorServiceInstanceNotReadyReason, msg)
-			return c.processServiceBindingOperationError(binding, readyCond)
+			return c.processServiceBindingInstanceNotReady(binding, instance)
+		}
+		if err := c.finishWaitingForServiceInstance(binding); err != nil {
+			return err
 		}
 
 		klog.V(4).Info(pcb.Message("Adding/Updating"))
@@ -288,6 +501,11 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		prettyName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	if err := c.checkServiceBindingSecretNamespaceAccess(binding); err != nil {
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorSecretNamespaceForbiddenReason, err.Error())
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
 	if binding.Status.CurrentOperation == "" {
 		binding, err = c.recordStartOfServiceBindingOperation(binding, v1beta1.ServiceBindingOperationBind, inProgressProperties)
 		if err != nil {
@@ -295,11 +513,34 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 			// over with a fresh view of the binding.
 			return err
 		}
+		if binding.Status.RebindExternalID != "" {
+			c.recorder.Event(binding, corev1.EventTypeNormal, rebindingReason, rebindingMessage)
+		}
 		// recordStartOfServiceBindingOperation has updated the binding, so we need to continue in the next iteration
 		return nil
 	}
 
-	response, err := brokerClient.Bind(request)
+	if isServiceBindingImported(binding) && bindingRetrievable && !isServiceBindingRebindPending(binding) {
+		return c.importServiceBinding(binding, brokerClient, request, prettyName)
+	}
+
+	if isServiceBindingBoundAtBroker(binding) {
+		if handled, err := c.injectBoundServiceBinding(binding, brokerClient, request, bindingRetrievable); handled {
+			return err
+		}
+	}
+
+	bindResourceExtensions := newBindResourceExtensions(binding)
+	response, err := brokerhttp.Bind(brokerClient, &brokerhttp.BindRequest{BindRequest: *request, BindResourceExtensions: bindResourceExtensions})
+	if c.retryAsAsyncOperation(binding, request.AcceptsIncomplete, isAsyncBindingOperationAllowed(bindingRetrievable), err) {
+		asyncRequest := *request
+		asyncRequest.AcceptsIncomplete = true
+		request = &asyncRequest
+		response, err = brokerhttp.Bind(brokerClient, &brokerhttp.BindRequest{BindRequest: *request, BindResourceExtensions: bindResourceExtensions})
+	}
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ServiceBroker returned failure; bind operation will not be retried: %v", err.Error())
@@ -334,10 +575,72 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	// credentials injection fails. The Broker has already processed the
 	// request, so this is what the Broker knows about the state of the
 	// binding.
-	binding.Status.ExternalProperties = binding.Status.InProgressProperties
+	recordServiceBindingBoundAtBroker(binding)
+
+	return c.processBindResult(binding, response.Credentials)
+}
+
+// isServiceBindingBoundAtBroker returns whether the broker already bound the
+// binding in its ongoing bind operation, and only the Secret with its
+// credentials remains to be written. The external properties of the binding
+// are only set to its in-progress properties once the broker bound it.
+func isServiceBindingBoundAtBroker(binding *v1beta1.ServiceBinding) bool {
+	return binding.Status.CurrentOperation == v1beta1.ServiceBindingOperationBind &&
+		!binding.Status.AsyncOpInProgress &&
+		binding.Status.RebindExternalID == "" &&
+		binding.Status.InProgressProperties != nil &&
+		reflect.DeepEqual(binding.Status.ExternalProperties, binding.Status.InProgressProperties)
+}
+
+// injectBoundServiceBinding writes the Secret of a binding that the broker
+// already bound, without sending the bind request again. The credentials are
+// those cached when writing the Secret failed or, after a restart of the
+// controller, those fetched from the broker when its bindings are
+// retrievable. It returns false when the credentials are not available, in
+// which case the bind request has to be sent again; the OSB API requires
+// brokers to return the existing binding for an identical request.
+func (c *controller) injectBoundServiceBinding(binding *v1beta1.ServiceBinding, brokerClient osb.Client, request *osb.BindRequest, bindingRetrievable bool) (bool, error) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	if credentials, found := c.bindingCredentials.Get(binding.UID); found {
+		klog.V(4).Info(pcb.Message("Retrying to inject the cached bind result"))
+		return true, c.processBindResult(binding, credentials)
+	}
+
+	if !bindingRetrievable {
+		klog.V(4).Info(pcb.Message("The bind result is not cached and the binding is not retrievable; sending the bind request again"))
+		return false, nil
+	}
 
-	err = c.injectServiceBinding(binding, response.Credentials)
+	klog.V(4).Info(pcb.Message("Fetching the bind result from the broker"))
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: request.InstanceID,
+		BindingID:  request.BindingID,
+	})
+	if isBrokerRequestLimitError(err) {
+		return true, err
+	}
 	if err != nil {
+		msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorFetchingBindingFailedReason, msg)
+
+		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+			msg := "Stopping reconciliation retries, too much time has elapsed"
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
+			return true, c.processBindFailure(binding, readyCond, failedCond, true)
+		}
+
+		return true, c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	return true, c.processBindResult(binding, response.Credentials)
+}
+
+// processBindResult writes the Secret of a binding that the broker bound with
+// the given credentials. When writing the Secret fails, the credentials are
+// cached so that the next reconciliation only retries writing the Secret.
+func (c *controller) processBindResult(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
+	if err := c.injectServiceBinding(binding, credentials); err != nil {
 		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
 
@@ -347,12 +650,144 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 			return c.processBindFailure(binding, readyCond, failedCond, true)
 		}
 
-		// TODO: solve scenario where bind request successful, credential injection fails, later reconciliations have non-failing errors
-		// with Bind request. After retry duration, reconciler gives up but will not do orphan mitigation.
+		c.bindingCredentials.Set(binding.UID, credentials)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	return c.processBindSuccess(binding, credentials)
+}
+
+// recordServiceBindingBoundAtBroker records that the broker bound the binding
+// in its ongoing bind operation. The binding of a rebind replaces the previous
+// one, which becomes stale: it is only unbound once the new credentials are
+// written into the Secret.
+func recordServiceBindingBoundAtBroker(binding *v1beta1.ServiceBinding) {
+	binding.Status.ExternalProperties = binding.Status.InProgressProperties
+	if binding.Status.RebindExternalID != "" {
+		binding.Status.StaleExternalIDs = append(binding.Status.StaleExternalIDs, serviceBindingExternalID(binding))
+		binding.Status.ExternalID = binding.Status.RebindExternalID
+		binding.Status.RebindExternalID = ""
+	}
+}
+
+// reconcileStaleServiceBindings unbinds the stale bindings of the given
+// binding at the broker. The binding itself is not changed, so failures are
+// only recorded as events and retried.
+func (c *controller) reconcileStaleServiceBindings(binding *v1beta1.ServiceBinding) error {
+	binding = binding.DeepCopy()
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		return err
+	}
+	brokerClient, err := c.getBrokerClientForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+
+	staleCount := len(binding.Status.StaleExternalIDs)
+	err = c.unbindStaleServiceBindings(binding, instance, brokerClient)
+	if err != nil && !isBrokerRequestLimitError(err) {
+		msg := fmt.Sprintf("Error unbinding a stale binding: %s", err)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindStaleBindingReason, msg)
+	}
+	if len(binding.Status.StaleExternalIDs) != staleCount {
+		if _, updateErr := c.updateServiceBindingStatus(binding); updateErr != nil {
+			return updateErr
+		}
+	}
+	return err
+}
+
+// unbindStaleServiceBindings sends the unbind requests of the stale bindings
+// of the given binding, and removes them from its status once the broker
+// unbound them. The requests are synchronous, as their operations are not
+// polled.
+func (c *controller) unbindStaleServiceBindings(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osb.Client) error {
+	if len(binding.Status.StaleExternalIDs) == 0 {
+		return nil
+	}
+	if instance.Status.ExternalProperties == nil {
+		return fmt.Errorf("the plan of %s has not been set yet", pretty.ServiceInstanceName(instance))
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+	request, err := c.prepareUnbindRequest(binding, instance)
+	if err != nil {
+		return err
+	}
+	request.AcceptsIncomplete = false
+
+	for len(binding.Status.StaleExternalIDs) > 0 {
+		id := binding.Status.StaleExternalIDs[0]
+		staleRequest := *request
+		staleRequest.BindingID = id
+		if _, err := brokerClient.Unbind(&staleRequest); err != nil && !osb.IsGoneError(err) {
+			return err
+		}
+
+		binding.Status.StaleExternalIDs = binding.Status.StaleExternalIDs[1:]
+		msg := fmt.Sprintf(unboundStaleBindingMessage, id)
+		klog.V(4).Info(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeNormal, unboundStaleBindingReason, msg)
+	}
+	binding.Status.StaleExternalIDs = nil
+	return nil
+}
+
+// importServiceBinding fetches the binding with the external ID of the given
+// binding from the broker and injects its credentials, instead of sending a
+// bind request. Orphan mitigation is never started for imported bindings,
+// as it would remove a binding that the controller did not create.
+func (c *controller) importServiceBinding(binding *v1beta1.ServiceBinding, brokerClient osb.Client, request *osb.BindRequest, prettyName string) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	msg := fmt.Sprintf(importingBindingMessage, binding.Spec.ExternalID)
+	klog.V(4).Info(pcb.Message(msg))
+	c.recorder.Event(binding, corev1.EventTypeNormal, importingBindingReason, msg)
+
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: request.InstanceID,
+		BindingID:  request.BindingID,
+	})
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
+	if err != nil {
+		if httpErr, ok := osb.IsHTTPError(err); ok {
+			msg := fmt.Sprintf("ServiceBroker returned failure; import of the binding %q will not be retried: %v", binding.Spec.ExternalID, httpErr)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorImportingBindingFailedReason, msg)
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorImportingBindingFailedReason, msg)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
+
+		msg := fmt.Sprintf(`Error importing ServiceBinding %q for %s: %s`, binding.Spec.ExternalID, prettyName, err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorImportingBindingFailedReason, msg)
+
+		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+			msg := "Stopping reconciliation retries, too much time has elapsed"
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
+
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	binding.Status.ExternalProperties = binding.Status.InProgressProperties
+
+	if err := c.injectServiceBinding(binding, response.Credentials); err != nil {
+		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
+
+		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+			msg := "Stopping reconciliation retries, too much time has elapsed"
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
+
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
-	return c.processBindSuccess(binding)
+	return c.processBindSuccess(binding, response.Credentials)
 }
 
 func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBinding) error {
@@ -430,6 +865,7 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 
 	var brokerClient osb.Client
 	var prettyBrokerName string
+	var bindingRetrievable bool
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -446,6 +882,7 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 		prettyBrokerName = pretty.FromServiceInstanceOfClusterServiceClassAtBrokerName(instance, serviceClass, brokerName)
 
 	} else if instance.Spec.ServiceClassSpecified() {
@@ -463,15 +900,34 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 		prettyBrokerName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	if err := c.unbindStaleServiceBindings(binding, instance, brokerClient); err != nil {
+		if isBrokerRequestLimitError(err) {
+			return err
+		}
+		msg := fmt.Sprintf(`Error unbinding a stale binding from %s: %s`, prettyBrokerName, err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindStaleBindingReason, msg)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
 	request, err := c.prepareUnbindRequest(binding, instance)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
 	response, err := brokerClient.Unbind(request)
+	if c.retryAsAsyncOperation(binding, request.AcceptsIncomplete, isAsyncBindingOperationAllowed(bindingRetrievable), err) {
+		asyncRequest := *request
+		asyncRequest.AcceptsIncomplete = true
+		request = &asyncRequest
+		response, err = brokerClient.Unbind(request)
+	}
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error unbinding from %s: %s`, prettyBrokerName, err,
@@ -494,6 +950,14 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	return c.processUnbindSuccess(binding)
 }
 
+// isAsyncBindingOperationAllowed returns whether the bind and unbind requests
+// for a class with the given bindingRetrievable can be asynchronous. An
+// asynchronous binding is fetched from the broker once it is done, and its
+// operation is polled, which requires the AsyncBindingOperations feature gate.
+func isAsyncBindingOperationAllowed(bindingRetrievable bool) bool {
+	return bindingRetrievable && utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations)
+}
+
 // isClusterServicePlanBindable returns whether the given ClusterServiceClass and ClusterServicePlan
 // combination is bindable.  Plans may override the service-level bindable
 // attribute, so if the plan provides a value, return that value.  Otherwise,
@@ -524,12 +988,81 @@ func isServicePlanBindable(serviceClass *v1beta1.ServiceClass, plan *v1beta1.Ser
 	return serviceClass.Spec.Bindable
 }
 
+// checkServiceBindingSecretNamespaceAccess returns an error when the
+// controller is not permitted to manage the Secret of the binding. The access
+// is only checked when the Secret is not in the namespace of the binding, so
+// that the binding fails before the broker binds it.
+func (c *controller) checkServiceBindingSecretNamespaceAccess(binding *v1beta1.ServiceBinding) error {
+	secretNamespace := binding.GetSecretNamespace()
+	if secretNamespace == binding.Namespace {
+		return nil
+	}
+
+	for _, verb := range bindingSecretVerbs {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: secretNamespace,
+					Verb:      verb,
+					Group:     corev1.SchemeGroupVersion.Group,
+					Version:   corev1.SchemeGroupVersion.Version,
+					Resource:  corev1.ResourceSecrets.String(),
+				},
+			},
+		}
+		result, err := c.kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(review)
+		if err != nil {
+			return fmt.Errorf(`Unable to check the access to the Secrets of namespace %q: %v`, secretNamespace, err)
+		}
+		if !result.Status.Allowed {
+			return fmt.Errorf(`The controller is not permitted to %s the Secrets of namespace %q: %s`, verb, secretNamespace, result.Status.Reason)
+		}
+	}
+	return nil
+}
+
+// isServiceBindingSecretOwner returns whether the binding owns the Secret. In
+// the namespace of the binding the Secret is controlled by the binding, in
+// another namespace the owner annotation records the UID of the binding.
+func isServiceBindingSecretOwner(secret *corev1.Secret, binding *v1beta1.ServiceBinding) bool {
+	if binding.GetSecretNamespace() == binding.Namespace {
+		return metav1.IsControlledBy(secret, binding)
+	}
+	uid, ok := secret.Annotations[v1beta1.ServiceBindingSecretOwnerAnnotation]
+	return ok && uid == string(binding.UID)
+}
+
+// setServiceBindingSecretOwner makes the binding the owner of the Secret.
+// Owner references can not cross namespaces, so a Secret in another namespace
+// is annotated with the binding instead and is not garbage collected.
+func setServiceBindingSecretOwner(secret *corev1.Secret, binding *v1beta1.ServiceBinding) {
+	if binding.GetSecretNamespace() == binding.Namespace {
+		secret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(binding, bindingControllerKind),
+		}
+		return
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[v1beta1.ServiceBindingSecretOwnerAnnotation] = string(binding.UID)
+	secret.Annotations[v1beta1.ServiceBindingSecretBindingAnnotation] = fmt.Sprintf("%s/%s", binding.Namespace, binding.Name)
+}
+
 func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
 	pcb := pretty.NewBindingContextBuilder(binding)
+	secretNamespace := binding.GetSecretNamespace()
 	klog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
-		binding.Namespace, binding.Spec.SecretName, len(credentials),
+		secretNamespace, binding.Spec.SecretName, len(credentials),
 	))
 
+	// The credentials are transformed in a copy, so that they can be
+	// injected again when writing the Secret fails
+	transformed := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		transformed[k] = v
+	}
+	credentials = transformed
 	if err := c.transformCredentials(binding.Spec.SecretTransforms, credentials); err != nil {
 		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
 	}
@@ -543,48 +1076,46 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 	}
 
 	// Creating/updating the Secret
-	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
+	secretClient := c.kubeClient.CoreV1().Secrets(secretNamespace)
 	existingSecret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
 	if err == nil {
 		// Update existing secret
-		if !metav1.IsControlledBy(existingSecret, binding) {
+		if !isServiceBindingSecretOwner(existingSecret, binding) {
 			controllerRef := metav1.GetControllerOf(existingSecret)
-			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
+			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, secretNamespace, existingSecret.Name, controllerRef)
 		}
 		existingSecret.Data = secretData
 		if _, err = secretClient.Update(existingSecret); err != nil {
 			if apierrors.IsConflict(err) {
 				// Conflicting update detected, try again later
-				return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, binding.Namespace, existingSecret.Name)
+				return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, secretNamespace, existingSecret.Name)
 			}
-			return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
+			return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, secretNamespace, existingSecret.Name, err)
 		}
 	} else {
 		if !apierrors.IsNotFound(err) {
 			// Terminal error
-			return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
+			return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, secretNamespace, existingSecret.Name, err)
 		}
 		err = nil
 		// Create new secret
 		secret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      binding.Spec.SecretName,
-				Namespace: binding.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(binding, bindingControllerKind),
-				},
+				Namespace: secretNamespace,
 			},
 			Data: secretData,
 		}
+		setServiceBindingSecretOwner(secret, binding)
 
 		if _, err = secretClient.Create(secret); err != nil {
 			if apierrors.IsAlreadyExists(err) {
 				// Concurrent controller has created secret under the same name,
 				// Update the secret at the next retry iteration
-				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
+				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, secretNamespace, secret.Name)
 			}
 			// Terminal error
-			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, secretNamespace, secret.Name, err)
 		}
 	}
 
@@ -644,19 +1175,80 @@ func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (stri
 }
 
 func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error {
+	if c.bindingSecretRetentionPolicy == BindingSecretRetentionPolicyRetain {
+		return c.releaseServiceBindingSecret(binding)
+	}
+
 	var err error
 	pcb := pretty.NewBindingContextBuilder(binding)
+	secretNamespace := binding.GetSecretNamespace()
 	klog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`,
-		binding.Namespace, binding.Spec.SecretName,
+		secretNamespace, binding.Spec.SecretName,
 	))
 
-	if err = c.kubeClient.CoreV1().Secrets(binding.Namespace).Delete(binding.Spec.SecretName, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+	deleteOptions := &metav1.DeleteOptions{}
+	if secretNamespace != binding.Namespace {
+		// A Secret in another namespace may belong to another team, so it is
+		// only deleted when it is still the one of the binding
+		secret, err := c.kubeClient.CoreV1().Secrets(secretNamespace).Get(binding.Spec.SecretName, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !isServiceBindingSecretOwner(secret, binding) {
+			klog.V(4).Info(pcb.Messagef(`Not deleting Secret "%s/%s", it is not owned by the binding`, secretNamespace, secret.Name))
+			return nil
+		}
+		deleteOptions.Preconditions = metav1.NewUIDPreconditions(string(secret.UID))
+	}
+
+	if err = c.kubeClient.CoreV1().Secrets(secretNamespace).Delete(binding.Spec.SecretName, deleteOptions); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
+// releaseServiceBindingSecret removes the credentials and the owner reference
+// to the binding from the Secret of the binding, leaving an empty Secret
+// behind that is not garbage collected when the binding is deleted.
+func (c *controller) releaseServiceBindingSecret(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	secretNamespace := binding.GetSecretNamespace()
+	klog.V(5).Info(pcb.Messagef(`Releasing Secret "%s/%s"`,
+		secretNamespace, binding.Spec.SecretName,
+	))
+
+	secretClient := c.kubeClient.CoreV1().Secrets(secretNamespace)
+	secret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isServiceBindingSecretOwner(secret, binding) {
+		// The Secret has already been released, or it was never ours
+		return nil
+	}
+
+	ownerReferences := make([]metav1.OwnerReference, 0, len(secret.OwnerReferences))
+	for _, ref := range secret.OwnerReferences {
+		if ref.UID != binding.UID {
+			ownerReferences = append(ownerReferences, ref)
+		}
+	}
+	secret.OwnerReferences = ownerReferences
+	delete(secret.Annotations, v1beta1.ServiceBindingSecretOwnerAnnotation)
+	delete(secret.Annotations, v1beta1.ServiceBindingSecretBindingAnnotation)
+	secret.Data = nil
+
+	_, err = secretClient.Update(secret)
+	return err
+}
+
 // setServiceBindingCondition sets a single condition on a ServiceBinding's
 // status: if the condition already exists in the status, it is mutated; if the
 // condition does not already exist in the status, it is added. Other
@@ -704,6 +1296,7 @@ func setServiceBindingConditionInternal(toUpdate *v1beta1.ServiceBinding,
 		))
 		newCondition.LastTransitionTime = t
 		toUpdate.Status.Conditions = []v1beta1.ServiceBindingCondition{newCondition}
+		toUpdate.Status.LastConditionUpdateTime = &t
 		return
 	}
 	for i, cond := range toUpdate.Status.Conditions {
@@ -717,8 +1310,14 @@ func setServiceBindingConditionInternal(toUpdate *v1beta1.ServiceBinding,
 			} else {
 				newCondition.LastTransitionTime = cond.LastTransitionTime
 			}
+			if cond.Status != newCondition.Status || cond.Reason != newCondition.Reason || cond.Message != newCondition.Message {
+				toUpdate.Status.LastConditionUpdateTime = &t
+			}
 
 			toUpdate.Status.Conditions[i] = newCondition
+			// Conditions written by earlier versions may be in any order,
+			// so they are sorted even when this one is unchanged
+			sortServiceBindingConditions(toUpdate.Status.Conditions)
 			return
 		}
 	}
@@ -730,6 +1329,8 @@ func setServiceBindingConditionInternal(toUpdate *v1beta1.ServiceBinding,
 
 	newCondition.LastTransitionTime = t
 	toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
+	sortServiceBindingConditions(toUpdate.Status.Conditions)
+	toUpdate.Status.LastConditionUpdateTime = &t
 }
 
 func (c *controller) updateServiceBindingStatus(toUpdate *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, error) {
@@ -788,6 +1389,8 @@ func (c *controller) initializeServiceBindingStatus(binding *v1beta1.ServiceBind
 	updated.Status = v1beta1.ServiceBindingStatus{
 		Conditions:   []v1beta1.ServiceBindingCondition{},
 		UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+		// A new binding is bound anyway, whatever its rebind requests
+		RebindCount: binding.Spec.RebindRequests,
 	}
 
 	_, err := c.serviceCatalogClient.ServiceBindings(updated.Namespace).UpdateStatus(updated)
@@ -826,6 +1429,13 @@ func (c *controller) recordStartOfServiceBindingOperation(
 	case v1beta1.ServiceBindingOperationBind:
 		reason = bindingInFlightReason
 		message = bindingInFlightMessage
+		// A rebind binds the binding under a new ID, so that its previous
+		// credentials keep working until the new ones are written.
+		if isServiceBindingRebindPending(toUpdate) && toUpdate.Status.ExternalProperties != nil {
+			toUpdate.Status.RebindExternalID = string(uuid.NewUUID())
+			reason = rebindingReason
+			message = rebindingMessage
+		}
 		toUpdate.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
 	case v1beta1.ServiceBindingOperationUnbind:
 		reason = unbindingInFlightReason
@@ -852,6 +1462,11 @@ func clearServiceBindingCurrentOperation(toUpdate *v1beta1.ServiceBinding) {
 	toUpdate.Status.ReconciledGeneration = toUpdate.Generation
 	toUpdate.Status.InProgressProperties = nil
 	toUpdate.Status.OrphanMitigationInProgress = false
+	// The broker may have created the binding of an unfinished rebind
+	if toUpdate.Status.RebindExternalID != "" {
+		toUpdate.Status.StaleExternalIDs = append(toUpdate.Status.StaleExternalIDs, toUpdate.Status.RebindExternalID)
+		toUpdate.Status.RebindExternalID = ""
+	}
 }
 
 // rollbackBindingReconciledGenerationOnDeletion resets the ReconciledGeneration
@@ -936,6 +1551,9 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
 	response, err := brokerClient.PollBindingLastOperation(request)
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	if err != nil {
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec.
@@ -1004,15 +1622,18 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 		// Update the in progress/external properties, as the changes have been
 		// persisted in the broker
-		binding.Status.ExternalProperties = binding.Status.InProgressProperties
+		recordServiceBindingBoundAtBroker(binding)
 
 		getBindingRequest := &osb.GetBindingRequest{
 			InstanceID: instance.Spec.ExternalID,
-			BindingID:  binding.Spec.ExternalID,
+			BindingID:  serviceBindingExternalID(binding),
 		}
 
 		// TODO(mkibbe): Break this logic out so that GET and inject are retried separately on error
 		getBindingResponse, err := brokerClient.GetBinding(getBindingRequest)
+		if isBrokerRequestLimitError(err) {
+			return err
+		}
 		if err != nil {
 			reason := errorFetchingBindingFailedReason
 			msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
@@ -1040,7 +1661,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 			return c.finishPollingServiceBinding(binding)
 		}
 
-		if err := c.processBindSuccess(binding); err != nil {
+		if err := c.processBindSuccess(binding, getBindingResponse.Credentials); err != nil {
 			return err
 		}
 
@@ -1173,6 +1794,7 @@ func (c *controller) prepareBindRequest(
 	var scExternalID string
 	var spExternalID string
 	var scBindingRetrievable bool
+	var brokerContext map[string]interface{}
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -1195,6 +1817,7 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		brokerContext = c.brokerRequestContext(true, "", serviceClass.Spec.ClusterServiceBrokerName)
 
 	} else if instance.Spec.ServiceClassSpecified() {
 
@@ -1217,6 +1840,7 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		brokerContext = c.brokerRequestContext(false, instance.Namespace, serviceClass.Spec.ServiceBrokerName)
 	}
 
 	ns, err := c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
@@ -1231,7 +1855,7 @@ func (c *controller) prepareBindRequest(
 		c.kubeClient,
 		binding.Namespace,
 		binding.Spec.Parameters,
-		binding.Spec.ParametersFrom,
+		bindingParametersFrom(binding, instance),
 	)
 	if err != nil {
 		return nil, nil, &operationError{
@@ -1247,23 +1871,25 @@ func (c *controller) prepareBindRequest(
 	}
 
 	appGUID := string(ns.UID)
+	bindResource := newBindResource(binding, appGUID)
 	clusterID := c.getClusterID()
 
 	requestContext := map[string]interface{}{
-		"platform":           ContextProfilePlatformKubernetes,
+		"platform":           c.osbAPIContextPlatform,
 		"namespace":          instance.Namespace,
 		clusterIdentifierKey: clusterID,
 		"instance_name":      instance.Name,
 	}
+	c.addConfiguredRequestContext(requestContext, brokerContext)
 
 	request := &osb.BindRequest{
-		BindingID:    binding.Spec.ExternalID,
+		BindingID:    serviceBindingOperationExternalID(binding),
 		InstanceID:   instance.Spec.ExternalID,
 		ServiceID:    scExternalID,
 		PlanID:       spExternalID,
-		AppGUID:      &appGUID,
+		AppGUID:      bindResource.AppGUID,
 		Parameters:   parameters,
-		BindResource: &osb.BindResource{AppGUID: &appGUID},
+		BindResource: bindResource,
 		Context:      requestContext,
 	}
 
@@ -1272,10 +1898,8 @@ func (c *controller) prepareBindRequest(
 	// AsyncBindingOperations feature gate. This may be easily set
 	// by setting `asyncBindingOperationsEnabled=true` when
 	// deploying the Service Catalog via the Helm charts.
-	if scBindingRetrievable &&
-		utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
-
-		request.AcceptsIncomplete = true
+	if isAsyncBindingOperationAllowed(scBindingRetrievable) {
+		request.AcceptsIncomplete = c.osbAPIAcceptsIncomplete
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
@@ -1292,6 +1916,38 @@ func (c *controller) prepareBindRequest(
 	return request, inProgressProperties, nil
 }
 
+// newBindResource returns the bind resource to send to the broker for the
+// given binding. The GUID of the application defaults to the UID of the
+// namespace of the binding.
+func newBindResource(binding *v1beta1.ServiceBinding, defaultAppGUID string) *osb.BindResource {
+	appGUID := defaultAppGUID
+	if guid, ok := binding.Spec.BindResource[v1beta1.BindResourceAppGUIDKey]; ok {
+		appGUID = guid
+	}
+	bindResource := &osb.BindResource{AppGUID: &appGUID}
+	if route, ok := binding.Spec.BindResource[v1beta1.BindResourceRouteKey]; ok {
+		bindResource.Route = &route
+	}
+	return bindResource
+}
+
+// newBindResourceExtensions returns the keys of the bind resource of the
+// given binding that the OSB API does not define. They are passed to the
+// broker as they are; brokers ignore the keys they do not know.
+func newBindResourceExtensions(binding *v1beta1.ServiceBinding) map[string]string {
+	var extensions map[string]string
+	for key, value := range binding.Spec.BindResource {
+		if key == v1beta1.BindResourceAppGUIDKey || key == v1beta1.BindResourceRouteKey {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]string{}
+		}
+		extensions[key] = value
+	}
+	return extensions
+}
+
 // prepareUnbindRequest creates an unbind request object to be passed to the
 // broker client to delete the given binding.
 func (c *controller) prepareUnbindRequest(
@@ -1326,7 +1982,7 @@ func (c *controller) prepareUnbindRequest(
 	}
 
 	request := &osb.UnbindRequest{
-		BindingID:  binding.Spec.ExternalID,
+		BindingID:  serviceBindingExternalID(binding),
 		InstanceID: instance.Spec.ExternalID,
 		ServiceID:  scExternalID,
 		PlanID:     planExternalID,
@@ -1337,10 +1993,8 @@ func (c *controller) prepareUnbindRequest(
 	// AsyncBindingOperations feature gate. This may be easily set
 	// by setting `asyncBindingOperationsEnabled=true` when
 	// deploying the Service Catalog via the Helm charts.
-	if scBindingRetrievable &&
-		utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
-
-		request.AcceptsIncomplete = true
+	if isAsyncBindingOperationAllowed(scBindingRetrievable) {
+		request.AcceptsIncomplete = c.osbAPIAcceptsIncomplete
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
@@ -1398,7 +2052,7 @@ func (c *controller) prepareServiceBindingLastOperationRequest(
 
 	request := &osb.BindingLastOperationRequest{
 		InstanceID: instance.Spec.ExternalID,
-		BindingID:  binding.Spec.ExternalID,
+		BindingID:  serviceBindingOperationExternalID(binding),
 		ServiceID:  &scExternalID,
 		PlanID:     &spExternalID,
 	}
@@ -1433,11 +2087,81 @@ func (c *controller) processServiceBindingOperationError(binding *v1beta1.Servic
 	return fmt.Errorf(readyCond.Message)
 }
 
+// processServiceBindingInstanceNotReady handles a binding whose instance is
+// not ready yet. Unless the controller is configured to wait for instances,
+// this is reported as an error. Otherwise the binding is requeued with a
+// WaitingForInstance condition until the instance becomes ready, fails, or
+// the wait timeout elapses.
+func (c *controller) processServiceBindingInstanceNotReady(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance) error {
+	if c.bindingInstanceWaitTimeout <= 0 {
+		msg := fmt.Sprintf(`Binding cannot begin because referenced %s is not ready`, pretty.ServiceInstanceName(instance))
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorServiceInstanceNotReadyReason, msg)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	if isServiceInstanceFailed(instance) {
+		msg := fmt.Sprintf(`Binding cannot begin because referenced %s has failed`, pretty.ServiceInstanceName(instance))
+		return c.failWaitingForServiceInstance(binding, errorServiceInstanceFailedReason, msg)
+	}
+
+	waitingCond := getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance)
+	if waitingCond == nil || waitingCond.Status != v1beta1.ConditionTrue {
+		msg := fmt.Sprintf(`Waiting for referenced %s to become ready`, pretty.ServiceInstanceName(instance))
+		c.recorder.Event(binding, corev1.EventTypeNormal, waitingForInstanceReason, msg)
+		setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, waitingForInstanceReason, msg)
+		setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance, v1beta1.ConditionTrue, waitingForInstanceReason, msg)
+		if _, err := c.updateServiceBindingStatus(binding); err != nil {
+			return err
+		}
+	} else if time.Since(waitingCond.LastTransitionTime.Time) > c.bindingInstanceWaitTimeout {
+		msg := fmt.Sprintf(`Stopped waiting for referenced %s to become ready after %v`, pretty.ServiceInstanceName(instance), c.bindingInstanceWaitTimeout)
+		return c.failWaitingForServiceInstance(binding, errorWaitingForInstanceTimeoutReason, msg)
+	}
+
+	return c.beginPollingServiceBinding(binding)
+}
+
+// failWaitingForServiceInstance marks a binding that was waiting for its
+// instance as failed.
+func (c *controller) failWaitingForServiceInstance(binding *v1beta1.ServiceBinding, reason, msg string) error {
+	if waitingCond := getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance); waitingCond != nil {
+		setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance, v1beta1.ConditionFalse, reason, msg)
+	}
+	if err := c.finishPollingServiceBinding(binding); err != nil {
+		return err
+	}
+	readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, reason, msg)
+	failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, reason, msg)
+	return c.processBindFailure(binding, readyCond, failedCond, false)
+}
+
+// finishWaitingForServiceInstance resets the WaitingForInstance condition of
+// a binding once its instance is ready. The condition is persisted with the
+// next status update of the binding.
+func (c *controller) finishWaitingForServiceInstance(binding *v1beta1.ServiceBinding) error {
+	waitingCond := getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance)
+	if waitingCond == nil || waitingCond.Status != v1beta1.ConditionTrue {
+		return nil
+	}
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionWaitingForInstance, v1beta1.ConditionFalse, instanceReadyReason, instanceReadyMessage)
+	return c.finishPollingServiceBinding(binding)
+}
+
 // processBindSuccess handles the logging and updating of a ServiceBinding that
 // has successfully been created at the broker and has had its credentials
-// injected in the cluster.
-func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
+// injected in the cluster. Depending on the empty credentials policy, a
+// binding without credentials gets the NoCredentials condition.
+func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
+	c.bindingCredentials.Delete(binding.UID)
+	rebound := isServiceBindingRebindPending(binding)
+	binding.Status.RebindCount = binding.Spec.RebindRequests
 	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
+	noCredentials := len(credentials) == 0 && c.emptyBindingCredentialsPolicyForServiceBinding(binding) == EmptyBindingCredentialsPolicyWarn
+	if noCredentials {
+		setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionNoCredentials, v1beta1.ConditionTrue, noCredentialsReason, noCredentialsMessage)
+	} else if getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionNoCredentials) != nil {
+		setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionNoCredentials, v1beta1.ConditionFalse, credentialsReturnedReason, credentialsReturnedMessage)
+	}
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
 	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
@@ -1447,12 +2171,86 @@ func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
 	}
 
 	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	if rebound {
+		c.recorder.Event(binding, corev1.EventTypeNormal, successReboundReason, successReboundMessage)
+	}
+	if noCredentials {
+		c.recorder.Event(binding, corev1.EventTypeWarning, noCredentialsReason, noCredentialsMessage)
+	}
 	return nil
 }
 
+// isValidEmptyBindingCredentialsPolicy returns whether policy is one of the
+// known empty binding credentials policies.
+func isValidEmptyBindingCredentialsPolicy(policy EmptyBindingCredentialsPolicy) bool {
+	return policy == EmptyBindingCredentialsPolicyAllow || policy == EmptyBindingCredentialsPolicyWarn
+}
+
+// emptyBindingCredentialsPolicyForServiceBinding returns the policy for a
+// binding for which the broker returned no credentials. The
+// empty-credentials-policy annotation of the broker of the instance
+// overrides the policy of the controller.
+func (c *controller) emptyBindingCredentialsPolicyForServiceBinding(binding *v1beta1.ServiceBinding) EmptyBindingCredentialsPolicy {
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		return c.emptyBindingCredentialsPolicy
+	}
+
+	var annotations map[string]string
+	var brokerName string
+	if instance.Spec.ClusterServiceClassSpecified() && instance.Spec.ClusterServiceClassRef != nil {
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return c.emptyBindingCredentialsPolicy
+		}
+		brokerName = class.Spec.ClusterServiceBrokerName
+		broker, err := c.clusterServiceBrokerLister.Get(brokerName)
+		if err != nil {
+			return c.emptyBindingCredentialsPolicy
+		}
+		annotations = broker.Annotations
+	} else if instance.Spec.ServiceClassSpecified() && instance.Spec.ServiceClassRef != nil && c.serviceBrokerLister != nil {
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return c.emptyBindingCredentialsPolicy
+		}
+		brokerName = class.Spec.ServiceBrokerName
+		broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(brokerName)
+		if err != nil {
+			return c.emptyBindingCredentialsPolicy
+		}
+		annotations = broker.Annotations
+	}
+
+	value, ok := annotations[v1beta1.ServiceBrokerEmptyCredentialsPolicyAnnotation]
+	if !ok {
+		return c.emptyBindingCredentialsPolicy
+	}
+	policy := EmptyBindingCredentialsPolicy(value)
+	if !isValidEmptyBindingCredentialsPolicy(policy) {
+		pcb := pretty.NewBindingContextBuilder(binding)
+		klog.Warning(pcb.Messagef("Ignoring the invalid %s annotation %q of broker %q", v1beta1.ServiceBrokerEmptyCredentialsPolicyAnnotation, value, brokerName))
+		return c.emptyBindingCredentialsPolicy
+	}
+	return policy
+}
+
 // processBindFailure handles the logging and updating of a ServiceBinding that
 // hit a terminal failure during bind reconciliation.
 func (c *controller) processBindFailure(binding *v1beta1.ServiceBinding, readyCond, failedCond *v1beta1.ServiceBindingCondition, shouldMitigateOrphan bool) error {
+	c.bindingCredentials.Delete(binding.UID)
+	if binding.Status.RebindExternalID != "" {
+		return c.processRebindFailure(binding, readyCond, failedCond)
+	}
+	if isServiceBindingRebindPending(binding) {
+		binding.Status.RebindCount = binding.Spec.RebindRequests
+	}
+	if shouldMitigateOrphan && isServiceBindingImported(binding) {
+		// The binding at the broker was not created by the controller, its
+		// credentials may be in use elsewhere and must not be unbound.
+		klog.V(4).Info(pretty.NewBindingContextBuilder(binding).Message("Skipping orphan mitigation of an imported binding"))
+		shouldMitigateOrphan = false
+	}
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	if readyCond != nil {
 		c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
@@ -1483,6 +2281,31 @@ func (c *controller) processBindFailure(binding *v1beta1.ServiceBinding, readyCo
 	return nil
 }
 
+// processRebindFailure handles the logging and updating of a ServiceBinding
+// whose rebind hit a terminal failure before the broker bound it under its new
+// ID. The binding keeps its previous ID and the Secret its previous
+// credentials, which are still valid, so the binding stays ready. The binding
+// that the broker may have created under the new ID becomes stale.
+func (c *controller) processRebindFailure(binding *v1beta1.ServiceBinding, readyCond, failedCond *v1beta1.ServiceBindingCondition) error {
+	if readyCond != nil {
+		c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
+	}
+	c.recorder.Event(binding, corev1.EventTypeWarning, failedCond.Reason, failedCond.Message)
+
+	binding.Status.RebindCount = binding.Spec.RebindRequests
+	currentReconciledGeneration := binding.Status.ReconciledGeneration
+	clearServiceBindingCurrentOperation(binding)
+	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, errorRebindFailedReason, errorRebindFailedMessage)
+
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+
+	c.recorder.Event(binding, corev1.EventTypeWarning, errorRebindFailedReason, errorRebindFailedMessage)
+	return nil
+}
+
 // processBindAsyncResponse handles the logging and updating of a
 // ServiceInstance that received an asynchronous response from the broker when
 // requesting a bind.
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -32,6 +33,7 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	v1beta1informers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	sctestutil "github.com/kubernetes-sigs/service-catalog/test/util"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -435,6 +437,134 @@ func TestReconcileServiceBindingWithSecretConflict(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingRetriesSecretWrite tests that when the Secret of
+// a binding the broker bound can not be written, the next reconciliation only
+// retries writing the Secret: with the cached credentials, with the
+// credentials fetched from the broker after a restart of the controller when
+// the binding is retrievable, or else by sending the bind request again.
+func TestReconcileServiceBindingRetriesSecretWrite(t *testing.T) {
+	cases := []struct {
+		name        string
+		retrievable bool
+		restart     bool
+		brokerCall  fakeosb.ActionType
+	}{
+		{
+			name: "cached credentials",
+		},
+		{
+			name:        "restart with a retrievable binding",
+			retrievable: true,
+			restart:     true,
+			brokerCall:  fakeosb.GetBinding,
+		},
+		{
+			name:       "restart with a binding that is not retrievable",
+			restart:    true,
+			brokerCall: fakeosb.Bind,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			credentials := map[string]interface{}{"a": "b"}
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				BindReaction: &fakeosb.BindReaction{
+					Response: &osb.BindResponse{Credentials: credentials},
+				},
+				GetBindingReaction: &fakeosb.GetBindingReaction{
+					Response: &osb.GetBindingResponse{Credentials: credentials},
+				},
+			})
+
+			addGetNamespaceReaction(fakeKubeClient)
+			addGetSecretNotFoundReaction(fakeKubeClient)
+			secretCreated := false
+			fakeKubeClient.AddReactor("create", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if !secretCreated {
+					secretCreated = true
+					return true, nil, apierrors.NewForbidden(action.GetResource().GroupResource(), testServiceBindingSecretName, errors.New("exceeded quota"))
+				}
+				return true, action.(clientgotesting.CreateAction).GetObject(), nil
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			if tc.retrievable {
+				sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+			} else {
+				sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			}
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			binding := getTestServiceBinding()
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+			fakeCatalogClient.ClearActions()
+
+			if err := reconcileServiceBinding(t, testController, binding); err == nil {
+				t.Fatal("expected the Secret write to fail")
+			}
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			assertBind(t, brokerActions[0], &osb.BindRequest{
+				BindingID:  testServiceBindingGUID,
+				InstanceID: testServiceInstanceGUID,
+				ServiceID:  testClusterServiceClassGUID,
+				PlanID:     testClusterServicePlanGUID,
+				AppGUID:    strPtr(testNamespaceGUID),
+				BindResource: &osb.BindResource{
+					AppGUID: strPtr(testNamespaceGUID),
+				},
+				Context: testContext,
+			})
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			binding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingReadyFalse(t, binding, errorInjectingBindResultReason)
+			assertServiceBindingCurrentOperation(t, binding, v1beta1.ServiceBindingOperationBind)
+			fakeCatalogClient.ClearActions()
+			fakeKubeClient.ClearActions()
+
+			if tc.restart {
+				testController.bindingCredentials = newBindingCredentialsCache()
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions = fakeClusterServiceBrokerClient.Actions()[1:]
+			if tc.brokerCall == "" {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+			} else {
+				assertNumberOfBrokerActions(t, brokerActions, 1)
+				if e, a := tc.brokerCall, brokerActions[0].Type; e != a {
+					t.Fatalf("unexpected broker action; %s", expectedGot(e, a))
+				}
+			}
+
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+			assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+
+			kubeActions := fakeKubeClient.Actions()
+			assertNumberOfActions(t, kubeActions, 3)
+			assertActionEquals(t, kubeActions[2], "create", "secrets")
+			actionSecret := kubeActions[2].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+			if e, a := "b", string(actionSecret.Data["a"]); e != a {
+				t.Fatalf("Unexpected value of key 'a' in created secret; %s", expectedGot(e, a))
+			}
+
+			if _, found := testController.bindingCredentials.Get(binding.UID); found {
+				t.Fatal("expected the credentials to be removed from the cache")
+			}
+		})
+	}
+}
+
 // TestReconcileBindingWithParameters tests reconcileBinding to ensure a
 // binding with parameters will be passed to the broker properly.
 func TestReconcileServiceBindingWithParameters(t *testing.T) {
@@ -645,74 +775,928 @@ func TestReconcileServiceBindingWithSecretTransform(t *testing.T) {
 	assertGetNamespaceAction(t, fakeKubeClient.Actions())
 	fakeKubeClient.ClearActions()
 
-	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	err := testController.reconcileServiceBinding(binding)
+	if err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertBind(t, brokerActions[0], &osb.BindRequest{
+		BindingID:  testServiceBindingGUID,
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+		AppGUID:    strPtr(testNamespaceGUID),
+		BindResource: &osb.BindResource{
+			AppGUID: strPtr(testNamespaceGUID),
+		},
+		Context: testContext,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+	assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 3)
+
+	// first action is a get on the namespace
+	// second action is a get on the secret
+	action := kubeActions[2].(clientgotesting.CreateAction)
+	if e, a := "secrets", action.GetResource().Resource; e != a {
+		t.Fatalf("Unexpected resource on action; %s", expectedGot(e, a))
+	}
+	actionSecret, ok := action.GetObject().(*corev1.Secret)
+	if !ok {
+		t.Fatal("couldn't convert secret into a corev1.Secret")
+	}
+	if e, a := testServiceBindingSecretName, actionSecret.Name; e != a {
+		t.Fatalf("Unexpected name of secret; %s", expectedGot(e, a))
+	}
+	value, ok := actionSecret.Data["renamedA"]
+	if !ok {
+		t.Fatal("Didn't find secret key 'renamedA' in created secret")
+	}
+	if e, a := "b", string(value); e != a {
+		t.Fatalf("Unexpected value of key 'renamedA' in created secret; %s", expectedGot(e, a))
+	}
+	value, ok = actionSecret.Data["e"]
+	if !ok {
+		t.Fatal("Didn't find secret key 'e' in created secret")
+	}
+	if e, a := "e", string(value); e != a {
+		t.Fatalf("Unexpected value of key 'e' in created secret; %s", expectedGot(e, a))
+	}
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 1)
+
+	expectedEvent := normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const testSecretNamespace = "central-secrets"
+
+// addSelfSubjectAccessReviewReaction answers the access reviews of the
+// controller, allowing or denying all of them.
+func addSelfSubjectAccessReviewReaction(fakeKubeClient *clientgofake.Clientset, allowed bool) {
+	fakeKubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		review := action.(clientgotesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		review.Status.Allowed = allowed
+		if !allowed {
+			review.Status.Reason = "no RBAC policy matched"
+		}
+		return true, review, nil
+	})
+}
+
+// TestReconcileServiceBindingWithSecretNamespace tests that the Secret of a
+// binding with a Secret namespace is created in that namespace, annotated
+// with the binding instead of being owned by it, after the controller checked
+// that it is permitted to manage the Secrets of the namespace.
+func TestReconcileServiceBindingWithSecretNamespace(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+	addSelfSubjectAccessReviewReaction(fakeKubeClient, true)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceBindingName,
+			Namespace:  testNamespace,
+			UID:        "binding-uid",
+			Finalizers: []string{v1beta1.FinalizerServiceCatalog},
+			Generation: 1,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef:     v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ExternalID:      testServiceBindingGUID,
+			SecretName:      testServiceBindingSecretName,
+			SecretNamespace: testSecretNamespace,
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			UnbindStatus:         v1beta1.ServiceBindingUnbindStatusNotRequired,
+			CurrentOperation:     v1beta1.ServiceBindingOperationBind,
+			OperationStartTime:   &metav1.Time{Time: time.Now()},
+			InProgressProperties: &v1beta1.ServiceBindingPropertiesState{},
+		},
+	}
+
+	if err := testController.reconcileServiceBinding(binding); err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyTrue(t, updatedServiceBinding)
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 7)
+	assertActionEquals(t, kubeActions[0], "get", "namespaces")
+	for i, verb := range []string{"get", "create", "update", "delete"} {
+		assertActionEquals(t, kubeActions[i+1], "create", "selfsubjectaccessreviews")
+		review := kubeActions[i+1].(clientgotesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		if e, a := (authorizationv1.ResourceAttributes{Namespace: testSecretNamespace, Verb: verb, Version: "v1", Resource: "secrets"}), *review.Spec.ResourceAttributes; e != a {
+			t.Fatalf("unexpected access review; %s", expectedGot(e, a))
+		}
+	}
+	assertActionEquals(t, kubeActions[5], "get", "secrets")
+	assertActionEquals(t, kubeActions[6], "create", "secrets")
+	if e, a := testSecretNamespace, kubeActions[6].GetNamespace(); e != a {
+		t.Fatalf("unexpected namespace of the secret; %s", expectedGot(e, a))
+	}
+	actionSecret := kubeActions[6].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+	if len(actionSecret.OwnerReferences) != 0 {
+		t.Fatalf("expected no owner references across namespaces, got %v", actionSecret.OwnerReferences)
+	}
+	expectedAnnotations := map[string]string{
+		v1beta1.ServiceBindingSecretOwnerAnnotation:   "binding-uid",
+		v1beta1.ServiceBindingSecretBindingAnnotation: testNamespace + "/" + testServiceBindingName,
+	}
+	if e, a := expectedAnnotations, actionSecret.Annotations; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected annotations of the secret; %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceBindingWithForbiddenSecretNamespace tests that a
+// binding whose Secret namespace the controller is not permitted to write to
+// is not bound at the broker, and is retried.
+func TestReconcileServiceBindingWithForbiddenSecretNamespace(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addSelfSubjectAccessReviewReaction(fakeKubeClient, false)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBinding()
+	binding.Spec.SecretNamespace = testSecretNamespace
+
+	if err := testController.reconcileServiceBinding(binding); err == nil {
+		t.Fatal("expected an error for a forbidden secret namespace")
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyFalse(t, updatedServiceBinding, errorSecretNamespaceForbiddenReason)
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 2)
+	assertActionEquals(t, kubeActions[0], "get", "namespaces")
+	assertActionEquals(t, kubeActions[1], "create", "selfsubjectaccessreviews")
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorSecretNamespaceForbiddenReason).msg(
+		`The controller is not permitted to get the Secrets of namespace "central-secrets": no RBAC policy matched`,
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingWithEmptyCredentials tests that a binding for
+// which the broker returns no credentials gets the NoCredentials condition
+// only under the Warn policy of the controller or of its broker.
+func TestReconcileServiceBindingWithEmptyCredentials(t *testing.T) {
+	cases := []struct {
+		name                  string
+		credentials           map[string]interface{}
+		policy                EmptyBindingCredentialsPolicy
+		brokerAnnotations     map[string]string
+		existingNoCredentials bool
+		expectNoCredentials   bool
+		expectCondition       v1beta1.ConditionStatus
+	}{
+		{
+			name:   "absent credentials, allow",
+			policy: EmptyBindingCredentialsPolicyAllow,
+		},
+		{
+			name:        "empty credentials, allow",
+			credentials: map[string]interface{}{},
+			policy:      EmptyBindingCredentialsPolicyAllow,
+		},
+		{
+			name:                "absent credentials, warn",
+			policy:              EmptyBindingCredentialsPolicyWarn,
+			expectNoCredentials: true,
+			expectCondition:     v1beta1.ConditionTrue,
+		},
+		{
+			name:                "empty credentials, warn",
+			credentials:         map[string]interface{}{},
+			policy:              EmptyBindingCredentialsPolicyWarn,
+			expectNoCredentials: true,
+			expectCondition:     v1beta1.ConditionTrue,
+		},
+		{
+			name:        "credentials, warn",
+			credentials: map[string]interface{}{"a": "b"},
+			policy:      EmptyBindingCredentialsPolicyWarn,
+		},
+		{
+			name:                  "credentials after no credentials, warn",
+			credentials:           map[string]interface{}{"a": "b"},
+			policy:                EmptyBindingCredentialsPolicyWarn,
+			existingNoCredentials: true,
+			expectCondition:       v1beta1.ConditionFalse,
+		},
+		{
+			name:                "absent credentials, broker warns",
+			policy:              EmptyBindingCredentialsPolicyAllow,
+			brokerAnnotations:   map[string]string{v1beta1.ServiceBrokerEmptyCredentialsPolicyAnnotation: "Warn"},
+			expectNoCredentials: true,
+			expectCondition:     v1beta1.ConditionTrue,
+		},
+		{
+			name:              "absent credentials, broker allows",
+			policy:            EmptyBindingCredentialsPolicyWarn,
+			brokerAnnotations: map[string]string{v1beta1.ServiceBrokerEmptyCredentialsPolicyAnnotation: "Allow"},
+		},
+		{
+			name:                "absent credentials, invalid broker annotation",
+			policy:              EmptyBindingCredentialsPolicyWarn,
+			brokerAnnotations:   map[string]string{v1beta1.ServiceBrokerEmptyCredentialsPolicyAnnotation: "sometimes"},
+			expectNoCredentials: true,
+			expectCondition:     v1beta1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				BindReaction: &fakeosb.BindReaction{
+					Response: &osb.BindResponse{
+						Credentials: tc.credentials,
+					},
+				},
+			})
+			testController.emptyBindingCredentialsPolicy = tc.policy
+
+			addGetNamespaceReaction(fakeKubeClient)
+			addGetSecretNotFoundReaction(fakeKubeClient)
+
+			broker := getTestClusterServiceBroker()
+			broker.Annotations = tc.brokerAnnotations
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := &v1beta1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testServiceBindingName,
+					Namespace:  testNamespace,
+					Finalizers: []string{v1beta1.FinalizerServiceCatalog},
+					Generation: 1,
+				},
+				Spec: v1beta1.ServiceBindingSpec{
+					InstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+					ExternalID:  testServiceBindingGUID,
+					SecretName:  testServiceBindingSecretName,
+				},
+				Status: v1beta1.ServiceBindingStatus{
+					UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+				},
+			}
+			if tc.existingNoCredentials {
+				setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionNoCredentials, v1beta1.ConditionTrue, noCredentialsReason, noCredentialsMessage)
+			}
+
+			if err := testController.reconcileServiceBinding(binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+			fakeCatalogClient.ClearActions()
+			fakeKubeClient.ClearActions()
+
+			if err := testController.reconcileServiceBinding(binding); err != nil {
+				t.Fatalf("a valid binding should not fail: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+			condition := getServiceBindingCondition(updatedServiceBinding, v1beta1.ServiceBindingConditionNoCredentials)
+			if tc.expectCondition == "" {
+				if condition != nil {
+					t.Fatalf("unexpected NoCredentials condition: %+v", condition)
+				}
+			} else {
+				assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionNoCredentials, tc.expectCondition)
+			}
+
+			kubeActions := fakeKubeClient.Actions()
+			assertNumberOfActions(t, kubeActions, 3)
+			actionSecret, ok := kubeActions[2].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+			if !ok {
+				t.Fatal("couldn't convert secret into a corev1.Secret")
+			}
+			if e, a := len(tc.credentials), len(actionSecret.Data); e != a {
+				t.Fatalf("Unexpected number of keys in the secret; %s", expectedGot(e, a))
+			}
+
+			events := getRecordedEvents(testController)
+			expectedEvents := []string{normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage).String()}
+			if tc.expectNoCredentials {
+				expectedEvents = append(expectedEvents, warningEventBuilder(noCredentialsReason).msg(noCredentialsMessage).String())
+			}
+			if err := checkEvents(events, expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// getTestServiceBindingImport returns a binding of the test instance that
+// imports the binding with the test external ID from the broker.
+func getTestServiceBindingImport() *v1beta1.ServiceBinding {
+	return &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testServiceBindingName,
+			Namespace:   testNamespace,
+			Finalizers:  []string{v1beta1.FinalizerServiceCatalog},
+			Generation:  1,
+			Annotations: map[string]string{v1beta1.ServiceBindingImportAnnotation: "true"},
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ExternalID:  testServiceBindingGUID,
+			SecretName:  testServiceBindingSecretName,
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+		},
+	}
+}
+
+// TestReconcileServiceBindingImport tests that a binding with the import
+// annotation fetches the existing binding from the broker and injects its
+// credentials, when the class declares bindings as retrievable.
+func TestReconcileServiceBindingImport(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		GetBindingReaction: &fakeosb.GetBindingReaction{
+			Response: &osb.GetBindingResponse{
+				Credentials: map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	binding := getTestServiceBindingImport()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertGetBinding(t, brokerActions[0], &osb.GetBindingRequest{
+		InstanceID: testServiceInstanceGUID,
+		BindingID:  testServiceBindingGUID,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+	assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 3)
+	assertActionEquals(t, kubeActions[2], "create", "secrets")
+	actionSecret := kubeActions[2].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+	if e, a := "b", string(actionSecret.Data["a"]); e != a {
+		t.Fatalf("Unexpected value of key 'a' in created secret; %s", expectedGot(e, a))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(importingBindingReason).msgf(importingBindingMessage, testServiceBindingGUID).String(),
+		normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingImportNotRetrievable tests that a binding with
+// the import annotation is sent as a bind request when the class does not
+// declare bindings as retrievable.
+func TestReconcileServiceBindingImportNotRetrievable(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	binding := getTestServiceBindingImport()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	if e, a := fakeosb.Bind, brokerActions[0].Type; e != a {
+		t.Fatalf("unexpected action type; %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceBindingImportNotFound tests that a binding fails without
+// orphan mitigation when the broker does not return the imported binding.
+func TestReconcileServiceBindingImportNotFound(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		GetBindingReaction: &fakeosb.GetBindingReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	binding := getTestServiceBindingImport()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+	assertServiceBindingRequestFailingError(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, errorImportingBindingFailedReason, errorImportingBindingFailedReason, binding)
+	assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+}
+
+// TestReconcileServiceBindingImportNotRetrievableFailure tests that an
+// imported binding whose bind request fails with an error that would start
+// orphan mitigation fails without unbinding, since the controller did not
+// create the binding at the broker.
+func TestReconcileServiceBindingImportNotRetrievableFailure(t *testing.T) {
+	cases := []struct {
+		name    string
+		bindErr error
+	}{
+		{
+			name: "server error",
+			bindErr: osb.HTTPStatusCodeError{
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:    "timeout",
+			bindErr: &url.Error{Err: getTestTimeoutError()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				BindReaction: &fakeosb.BindReaction{
+					Error: tc.bindErr,
+				},
+			})
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			binding := getTestServiceBindingImport()
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+			fakeCatalogClient.ClearActions()
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			if e, a := fakeosb.Bind, brokerActions[0].Type; e != a {
+				t.Fatalf("unexpected action type; %s", expectedGot(e, a))
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionTrue)
+		})
+	}
+}
+
+// getTestServiceBindingRebindRequested returns a bound binding whose rebind
+// requests were increased.
+func getTestServiceBindingRebindRequested() *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingGUID
+	binding.Generation = 2
+	binding.Spec.RebindRequests = 1
+	binding.Status = v1beta1.ServiceBindingStatus{
+		Conditions: []v1beta1.ServiceBindingCondition{{
+			Type:   v1beta1.ServiceBindingConditionReady,
+			Status: v1beta1.ConditionTrue,
+			Reason: successInjectedBindResultReason,
+		}},
+		ReconciledGeneration: 1,
+		ExternalProperties:   &v1beta1.ServiceBindingPropertiesState{},
+		UnbindStatus:         v1beta1.ServiceBindingUnbindStatusRequired,
+	}
+	return binding
+}
+
+// TestReconcileServiceBindingRebind tests that increasing the rebind requests
+// of a binding binds it at the broker under a new ID, writes the new
+// credentials into the existing Secret, and only then unbinds the previous
+// binding.
+func TestReconcileServiceBindingRebind(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{Credentials: map[string]interface{}{"password": "new"}},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBindingRebindRequested()
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testServiceBindingSecretName,
+			Namespace:       testNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+		},
+		Data: map[string][]byte{"password": []byte("old")},
+	})
+
+	// The rebind starts as a bind operation under a new ID
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	binding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyFalse(t, binding, rebindingReason)
+	assertServiceBindingCurrentOperation(t, binding, v1beta1.ServiceBindingOperationBind)
+	rebindID := binding.Status.RebindExternalID
+	if rebindID == "" || rebindID == testServiceBindingGUID {
+		t.Fatalf("expected a new ID for the rebind, got %q", rebindID)
+	}
+	fakeCatalogClient.ClearActions()
+
+	// The binding is bound under the new ID and the new credentials are
+	// written into the same Secret, while the previous binding is kept
+	fakeKubeClient.ClearActions()
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	if e, a := fakeosb.Bind, brokerActions[0].Type; e != a {
+		t.Fatalf("unexpected broker action; %s", expectedGot(e, a))
+	}
+	if e, a := rebindID, brokerActions[0].Request.(*osb.BindRequest).BindingID; e != a {
+		t.Fatalf("unexpected binding ID of the bind request; %s", expectedGot(e, a))
+	}
+
+	updatedSecret := false
+	for _, action := range fakeKubeClient.Actions() {
+		if action.GetVerb() == "update" && action.GetResource().Resource == "secrets" {
+			updatedSecret = true
+			secret := action.(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+			if e, a := "new", string(secret.Data["password"]); e != a {
+				t.Fatalf("unexpected password in the Secret; %s", expectedGot(e, a))
+			}
+		}
+	}
+	if !updatedSecret {
+		t.Fatal("expected the Secret to be updated")
+	}
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	binding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyTrue(t, binding)
+	assertServiceBindingCurrentOperationClear(t, binding)
+	if e, a := int64(1), binding.Status.RebindCount; e != a {
+		t.Fatalf("unexpected rebind count; %s", expectedGot(e, a))
+	}
+	if e, a := rebindID, binding.Status.ExternalID; e != a {
+		t.Fatalf("unexpected external ID; %s", expectedGot(e, a))
+	}
+	if e, a := []string{testServiceBindingGUID}, binding.Status.StaleExternalIDs; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected stale external IDs; %s", expectedGot(e, a))
+	}
+	fakeCatalogClient.ClearActions()
+
+	// The previous binding is unbound once the Secret holds the new
+	// credentials
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions = fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertUnbind(t, brokerActions[1], &osb.UnbindRequest{
+		BindingID:  testServiceBindingGUID,
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+	})
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	binding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyTrue(t, binding)
+	if len(binding.Status.StaleExternalIDs) != 0 {
+		t.Fatalf("expected no stale external IDs, got %v", binding.Status.StaleExternalIDs)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		corev1.EventTypeNormal + " " + rebindingReason + " " + rebindingMessage,
+		corev1.EventTypeNormal + " " + successInjectedBindResultReason + " " + successInjectedBindResultMessage,
+		corev1.EventTypeNormal + " " + successReboundReason + " " + successReboundMessage,
+		corev1.EventTypeNormal + " " + unboundStaleBindingReason + " " + fmt.Sprintf(unboundStaleBindingMessage, testServiceBindingGUID),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestPollServiceBindingRebind tests that the asynchronous bind operation of
+// a rebind is polled and fetched under the new ID, which then replaces the
+// previous one.
+func TestPollServiceBindingRebind(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollBindingLastOperationReaction: &fakeosb.PollBindingLastOperationReaction{
+			Response: &osb.LastOperationResponse{State: osb.StateSucceeded},
+		},
+		GetBindingReaction: &fakeosb.GetBindingReaction{
+			Response: &osb.GetBindingResponse{Credentials: map[string]interface{}{"password": "new"}},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	const rebindID = "rebind-binding-id"
+	binding := getTestServiceBindingRebindRequested()
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	binding.Status.AsyncOpInProgress = true
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+	binding.Status.RebindExternalID = rebindID
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testServiceBindingSecretName,
+			Namespace:       testNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+		},
+		Data: map[string][]byte{"password": []byte("old")},
+	})
 
-	err := testController.reconcileServiceBinding(binding)
-	if err != nil {
-		t.Fatalf("a valid binding should not fail: %v", err)
+	if err := testController.pollServiceBinding(binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
 	brokerActions := fakeClusterServiceBrokerClient.Actions()
-	assertNumberOfBrokerActions(t, brokerActions, 1)
-	assertBind(t, brokerActions[0], &osb.BindRequest{
-		BindingID:  testServiceBindingGUID,
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertPollBindingLastOperation(t, brokerActions[0], &osb.BindingLastOperationRequest{
 		InstanceID: testServiceInstanceGUID,
-		ServiceID:  testClusterServiceClassGUID,
-		PlanID:     testClusterServicePlanGUID,
-		AppGUID:    strPtr(testNamespaceGUID),
-		BindResource: &osb.BindResource{
-			AppGUID: strPtr(testNamespaceGUID),
-		},
-		Context: testContext,
+		BindingID:  rebindID,
+		ServiceID:  strPtr(testClusterServiceClassGUID),
+		PlanID:     strPtr(testClusterServicePlanGUID),
+	})
+	assertGetBinding(t, brokerActions[1], &osb.GetBindingRequest{
+		InstanceID: testServiceInstanceGUID,
+		BindingID:  rebindID,
 	})
 
 	actions := fakeCatalogClient.Actions()
 	assertNumberOfActions(t, actions, 1)
-
 	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
-	assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
-	assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+	assertServiceBindingReadyTrue(t, updatedServiceBinding)
+	if e, a := rebindID, updatedServiceBinding.Status.ExternalID; e != a {
+		t.Fatalf("unexpected external ID; %s", expectedGot(e, a))
+	}
+	if e, a := "", updatedServiceBinding.Status.RebindExternalID; e != a {
+		t.Fatalf("unexpected rebind external ID; %s", expectedGot(e, a))
+	}
+	if e, a := []string{testServiceBindingGUID}, updatedServiceBinding.Status.StaleExternalIDs; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected stale external IDs; %s", expectedGot(e, a))
+	}
+}
 
-	kubeActions := fakeKubeClient.Actions()
-	assertNumberOfActions(t, kubeActions, 3)
+// TestReconcileServiceBindingRebindFailure tests that a rebind whose bind
+// request fails keeps the binding ready with its previous credentials, and
+// unbinds the binding that the broker may have created under the new ID.
+func TestReconcileServiceBindingRebindFailure(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	})
 
-	// first action is a get on the namespace
-	// second action is a get on the secret
-	action := kubeActions[2].(clientgotesting.CreateAction)
-	if e, a := "secrets", action.GetResource().Resource; e != a {
-		t.Fatalf("Unexpected resource on action; %s", expectedGot(e, a))
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	addGetNamespaceReaction(fakeKubeClient)
+
+	const rebindID = "rebind-binding-id"
+	binding := getTestServiceBindingRebindRequested()
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+	binding.Status.RebindExternalID = rebindID
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	actionSecret, ok := action.GetObject().(*corev1.Secret)
-	if !ok {
-		t.Fatal("couldn't convert secret into a corev1.Secret")
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	if e, a := fakeosb.Bind, brokerActions[0].Type; e != a {
+		t.Fatalf("unexpected broker action; %s", expectedGot(e, a))
 	}
-	if e, a := testServiceBindingSecretName, actionSecret.Name; e != a {
-		t.Fatalf("Unexpected name of secret; %s", expectedGot(e, a))
+
+	for _, action := range fakeKubeClient.Actions() {
+		if action.GetResource().Resource == "secrets" {
+			t.Fatalf("unexpected action on the Secret: %v", action)
+		}
 	}
-	value, ok := actionSecret.Data["renamedA"]
-	if !ok {
-		t.Fatal("Didn't find secret key 'renamedA' in created secret")
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyCondition(t, updatedServiceBinding, v1beta1.ConditionTrue, errorRebindFailedReason)
+	if getServiceBindingCondition(updatedServiceBinding, v1beta1.ServiceBindingConditionFailed) != nil {
+		t.Fatalf("expected the binding not to fail, got %+v", updatedServiceBinding.Status.Conditions)
 	}
-	if e, a := "b", string(value); e != a {
-		t.Fatalf("Unexpected value of key 'renamedA' in created secret; %s", expectedGot(e, a))
+	assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+	assertServiceBindingCurrentOperationClear(t, updatedServiceBinding)
+	if e, a := int64(1), updatedServiceBinding.Status.RebindCount; e != a {
+		t.Fatalf("unexpected rebind count; %s", expectedGot(e, a))
 	}
-	value, ok = actionSecret.Data["e"]
-	if !ok {
-		t.Fatal("Didn't find secret key 'e' in created secret")
+	if e, a := "", updatedServiceBinding.Status.ExternalID; e != a {
+		t.Fatalf("unexpected external ID; %s", expectedGot(e, a))
 	}
-	if e, a := "e", string(value); e != a {
-		t.Fatalf("Unexpected value of key 'e' in created secret; %s", expectedGot(e, a))
+	if e, a := []string{rebindID}, updatedServiceBinding.Status.StaleExternalIDs; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected stale external IDs; %s", expectedGot(e, a))
 	}
 
 	events := getRecordedEvents(testController)
-	assertNumEvents(t, events, 1)
-
-	expectedEvent := normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage)
-	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+	if err := checkEventPrefixes(events[len(events)-1:], []string{warningEventBuilder(errorRebindFailedReason).msg(errorRebindFailedMessage).String()}); err != nil {
 		t.Fatal(err)
 	}
 }
 
+// TestReconcileServiceBindingDeleteRebound tests that deleting a rebound
+// binding unbinds it under the ID of the rebind, after its stale bindings.
+func TestReconcileServiceBindingDeleteRebound(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefsAndExternalProperties())
+
+	binding := getTestServiceBindingRebindRequested()
+	binding.DeletionTimestamp = &metav1.Time{}
+	binding.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationUnbind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.ExternalID = "rebound-binding-id"
+	binding.Status.StaleExternalIDs = []string{testServiceBindingGUID}
+	fakeCatalogClient.AddReactor("get", "servicebindings", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, binding, nil
+	})
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	for i, id := range []string{testServiceBindingGUID, "rebound-binding-id"} {
+		assertUnbind(t, brokerActions[i], &osb.UnbindRequest{
+			BindingID:  id,
+			InstanceID: testServiceInstanceGUID,
+			ServiceID:  testClusterServiceClassGUID,
+			PlanID:     testClusterServicePlanGUID,
+		})
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+	updatedServiceBinding := assertUpdate(t, actions[1], binding).(*v1beta1.ServiceBinding)
+	if len(updatedServiceBinding.Status.StaleExternalIDs) != 0 {
+		t.Fatalf("expected no stale external IDs, got %v", updatedServiceBinding.Status.StaleExternalIDs)
+	}
+}
+
 // TestReconcileBindingNonbindableClusterServiceClass tests reconcileBinding to ensure a
 // binding for an instance that references a non-bindable service class and a
 // non-bindable plan fails as expected.
@@ -1002,6 +1986,118 @@ func TestReconcileServiceBindingServiceInstanceNotReady(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingWaitingForServiceInstance tests that a binding
+// for an instance that is not ready waits for the instance when the
+// controller is configured to, and fails once the instance fails or the wait
+// times out.
+func TestReconcileServiceBindingWaitingForServiceInstance(t *testing.T) {
+	waitingSince := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	cases := []struct {
+		name                string
+		instance            *v1beta1.ServiceInstance
+		waitingCondition    *v1beta1.ServiceBindingCondition
+		expectedReason      string
+		expectedWaiting     v1beta1.ConditionStatus
+		expectedFailed      bool
+		expectedPollRequeue bool
+		expectedEventType   string
+	}{
+		{
+			name:                "instance not ready",
+			instance:            getTestServiceInstanceWithClusterRefs(),
+			expectedReason:      waitingForInstanceReason,
+			expectedWaiting:     v1beta1.ConditionTrue,
+			expectedPollRequeue: true,
+			expectedEventType:   corev1.EventTypeNormal,
+		},
+		{
+			name:     "instance failed",
+			instance: getTestServiceInstanceWithFailedStatus(),
+			waitingCondition: &v1beta1.ServiceBindingCondition{
+				Type:               v1beta1.ServiceBindingConditionWaitingForInstance,
+				Status:             v1beta1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			},
+			expectedReason:    errorServiceInstanceFailedReason,
+			expectedWaiting:   v1beta1.ConditionFalse,
+			expectedFailed:    true,
+			expectedEventType: corev1.EventTypeWarning,
+		},
+		{
+			name:     "wait timed out",
+			instance: getTestServiceInstanceWithClusterRefs(),
+			waitingCondition: &v1beta1.ServiceBindingCondition{
+				Type:               v1beta1.ServiceBindingConditionWaitingForInstance,
+				Status:             v1beta1.ConditionTrue,
+				LastTransitionTime: waitingSince,
+			},
+			expectedReason:    errorWaitingForInstanceTimeoutReason,
+			expectedWaiting:   v1beta1.ConditionFalse,
+			expectedFailed:    true,
+			expectedEventType: corev1.EventTypeWarning,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.bindingInstanceWaitTimeout = 10 * time.Minute
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(tc.instance)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			binding := &v1beta1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testServiceBindingName,
+					Namespace:  testNamespace,
+					Generation: 1,
+				},
+				Spec: v1beta1.ServiceBindingSpec{
+					InstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+					ExternalID:  testServiceBindingGUID,
+				},
+				Status: v1beta1.ServiceBindingStatus{
+					UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+				},
+			}
+			if tc.waitingCondition != nil {
+				binding.Status.Conditions = []v1beta1.ServiceBindingCondition{*tc.waitingCondition}
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+			assertServiceBindingReadyFalse(t, updatedServiceBinding, tc.expectedReason)
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionWaitingForInstance, tc.expectedWaiting)
+			if tc.expectedFailed {
+				assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionTrue, tc.expectedReason)
+			}
+
+			key := testNamespace + "/" + testServiceBindingName
+			if e, a := tc.expectedPollRequeue, testController.bindingPollingQueue.NumRequeues(key) == 1; e != a {
+				t.Fatalf("unexpected requeue of the binding; %s", expectedGot(e, a))
+			}
+
+			events := getRecordedEvents(testController)
+			if len(events) == 0 || !strings.HasPrefix(events[0], tc.expectedEventType+" "+tc.expectedReason) {
+				t.Fatalf("unexpected events: %v", events)
+			}
+		})
+	}
+}
+
 // TestReconcileBindingNamespaceError tests reconcileBinding to ensure a binding
 // with an invalid namespace fails as expected.
 func TestReconcileServiceBindingNamespaceError(t *testing.T) {
@@ -1199,11 +2295,197 @@ func TestReconcileServiceBindingDelete(t *testing.T) {
 			assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationUnbind, binding)
 			assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
 
-			events := getRecordedEvents(testController)
+			events := getRecordedEvents(testController)
+
+			expectedEvent := normalEventBuilder(successUnboundReason)
+			if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestEjectServiceBindingRetainPolicy tests that with the Retain policy the
+// Secret of a binding is kept, without credentials and owner reference, when
+// the binding is ejected.
+func TestEjectServiceBindingRetainPolicy(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+
+	cases := []struct {
+		name          string
+		secret        *corev1.Secret
+		expectUpdate  bool
+		expectedOwner []metav1.OwnerReference
+	}{
+		{
+			name: "owned secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(binding, bindingControllerKind),
+						{Name: "other", UID: "other-uid"},
+					},
+				},
+				Data: map[string][]byte{"password": []byte("secret")},
+			},
+			expectUpdate:  true,
+			expectedOwner: []metav1.OwnerReference{{Name: "other", UID: "other-uid"}},
+		},
+		{
+			name: "secret not owned by the binding",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+				},
+				Data: map[string][]byte{"password": []byte("secret")},
+			},
+		},
+		{
+			name: "no secret",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+			testController.bindingSecretRetentionPolicy = BindingSecretRetentionPolicyRetain
+
+			fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if tc.secret == nil {
+					return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+				}
+				return true, tc.secret.DeepCopy(), nil
+			})
+
+			if err := testController.ejectServiceBinding(binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			kubeActions := fakeKubeClient.Actions()
+			if !tc.expectUpdate {
+				assertNumberOfActions(t, kubeActions, 1)
+				assertActionEquals(t, kubeActions[0], "get", "secrets")
+				return
+			}
+
+			assertNumberOfActions(t, kubeActions, 2)
+			assertActionEquals(t, kubeActions[0], "get", "secrets")
+			assertActionEquals(t, kubeActions[1], "update", "secrets")
+			updatedSecret := kubeActions[1].(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+			if updatedSecret.Data != nil {
+				t.Fatalf("expected the credentials to be removed, got %v keys", len(updatedSecret.Data))
+			}
+			if e, a := tc.expectedOwner, updatedSecret.OwnerReferences; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected owner references: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestEjectServiceBindingSecretNamespace tests that the Secret of a binding
+// in another namespace is only deleted or released when the binding owns it.
+func TestEjectServiceBindingSecretNamespace(t *testing.T) {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+	binding.Spec.SecretNamespace = testSecretNamespace
+
+	ownedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testServiceBindingSecretName,
+			Namespace: testSecretNamespace,
+			UID:       "secret-uid",
+			Annotations: map[string]string{
+				v1beta1.ServiceBindingSecretOwnerAnnotation:   "binding-uid",
+				v1beta1.ServiceBindingSecretBindingAnnotation: testNamespace + "/" + testServiceBindingName,
+				"sync": "vault",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("secret")},
+	}
+	foreignSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testServiceBindingSecretName,
+			Namespace: testSecretNamespace,
+			Annotations: map[string]string{
+				v1beta1.ServiceBindingSecretOwnerAnnotation: "other-uid",
+			},
+		},
+	}
+
+	cases := []struct {
+		name            string
+		retentionPolicy BindingSecretRetentionPolicy
+		secret          *corev1.Secret
+		expectedVerb    string
+	}{
+		{
+			name:         "owned secret",
+			secret:       ownedSecret,
+			expectedVerb: "delete",
+		},
+		{
+			name:   "secret not owned by the binding",
+			secret: foreignSecret,
+		},
+		{
+			name: "no secret",
+		},
+		{
+			name:            "owned secret, retain policy",
+			retentionPolicy: BindingSecretRetentionPolicyRetain,
+			secret:          ownedSecret,
+			expectedVerb:    "update",
+		},
+		{
+			name:            "secret not owned by the binding, retain policy",
+			retentionPolicy: BindingSecretRetentionPolicyRetain,
+			secret:          foreignSecret,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+			if tc.retentionPolicy != "" {
+				testController.bindingSecretRetentionPolicy = tc.retentionPolicy
+			}
+
+			fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if tc.secret == nil {
+					return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+				}
+				return true, tc.secret.DeepCopy(), nil
+			})
+
+			if err := testController.ejectServiceBinding(binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			kubeActions := fakeKubeClient.Actions()
+			if tc.expectedVerb == "" {
+				assertNumberOfActions(t, kubeActions, 1)
+				assertActionEquals(t, kubeActions[0], "get", "secrets")
+				return
+			}
 
-			expectedEvent := normalEventBuilder(successUnboundReason)
-			if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
-				t.Fatal(err)
+			assertNumberOfActions(t, kubeActions, 2)
+			assertActionEquals(t, kubeActions[0], "get", "secrets")
+			assertActionEquals(t, kubeActions[1], tc.expectedVerb, "secrets")
+			if e, a := testSecretNamespace, kubeActions[1].GetNamespace(); e != a {
+				t.Fatalf("unexpected namespace of the secret; %s", expectedGot(e, a))
+			}
+			if tc.expectedVerb == "update" {
+				updatedSecret := kubeActions[1].(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+				if e, a := map[string]string{"sync": "vault"}, updatedSecret.Annotations; !reflect.DeepEqual(e, a) {
+					t.Fatalf("expected the owner annotations to be removed; %s", expectedGot(e, a))
+				}
+				if updatedSecret.Data != nil {
+					t.Fatalf("expected the credentials to be removed, got %v keys", len(updatedSecret.Data))
+				}
 			}
 		})
 	}
@@ -1335,6 +2617,13 @@ func TestSetServiceBindingCondition(t *testing.T) {
 		return c
 	}
 
+	// withUpdateTime sets the LastConditionUpdateTime to the 'new' basis time
+	// and returns it.
+	withUpdateTime := func(o *v1beta1.ServiceBinding) *v1beta1.ServiceBinding {
+		o.Status.LastConditionUpdateTime = &newTs
+		return o
+	}
+
 	// this test works by calling setServiceBindingCondition with the input and
 	// condition fields of the test case, and ensuring that afterward the
 	// input (which is mutated by the setServiceBindingCondition call) is deep-equal
@@ -1353,7 +2642,7 @@ func TestSetServiceBindingCondition(t *testing.T) {
 			name:      "new ready condition",
 			input:     getTestServiceBinding(),
 			condition: readyFalse(),
-			result:    bindingWithCondition(withNewTs(readyFalse())),
+			result:    withUpdateTime(bindingWithCondition(withNewTs(readyFalse()))),
 		},
 		{
 			name:      "not ready -> not ready; no ts update",
@@ -1365,13 +2654,13 @@ func TestSetServiceBindingCondition(t *testing.T) {
 			name:      "not ready -> not ready, reason and message change; no ts update",
 			input:     bindingWithCondition(readyFalse()),
 			condition: readyFalsef("DifferentReason", "DifferentMessage"),
-			result:    bindingWithCondition(readyFalsef("DifferentReason", "DifferentMessage")),
+			result:    withUpdateTime(bindingWithCondition(readyFalsef("DifferentReason", "DifferentMessage"))),
 		},
 		{
 			name:      "not ready -> ready",
 			input:     bindingWithCondition(readyFalse()),
 			condition: readyTrue(),
-			result:    bindingWithCondition(withNewTs(readyTrue())),
+			result:    withUpdateTime(bindingWithCondition(withNewTs(readyTrue()))),
 		},
 		{
 			name:      "ready -> ready; no ts update",
@@ -1383,7 +2672,7 @@ func TestSetServiceBindingCondition(t *testing.T) {
 			name:      "ready -> not ready",
 			input:     bindingWithCondition(readyTrue()),
 			condition: readyFalse(),
-			result:    bindingWithCondition(withNewTs(readyFalse())),
+			result:    withUpdateTime(bindingWithCondition(withNewTs(readyFalse()))),
 		},
 		{
 			name:      "not ready -> not ready + failed",
@@ -1392,6 +2681,20 @@ func TestSetServiceBindingCondition(t *testing.T) {
 			result: func() *v1beta1.ServiceBinding {
 				i := bindingWithCondition(readyFalse())
 				i.Status.Conditions = append(i.Status.Conditions, *withNewTs(failedTrue()))
+				return withUpdateTime(i)
+			}(),
+		},
+		{
+			name: "conditions are sorted; no ts update",
+			input: func() *v1beta1.ServiceBinding {
+				i := bindingWithCondition(failedTrue())
+				i.Status.Conditions = append(i.Status.Conditions, *readyFalse())
+				return i
+			}(),
+			condition: readyFalse(),
+			result: func() *v1beta1.ServiceBinding {
+				i := bindingWithCondition(readyFalse())
+				i.Status.Conditions = append(i.Status.Conditions, *failedTrue())
 				return i
 			}(),
 		},
@@ -1406,6 +2709,50 @@ func TestSetServiceBindingCondition(t *testing.T) {
 	}
 }
 
+// TestSetServiceBindingConditionStable ensures that setting the same conditions
+// again, in any order and at a later time, does not change the serialized
+// conditions of a binding nor its lastConditionUpdateTime.
+func TestSetServiceBindingConditionStable(t *testing.T) {
+	type conditionSet struct {
+		conditionType v1beta1.ServiceBindingConditionType
+		status        v1beta1.ConditionStatus
+	}
+	conditions := []conditionSet{
+		{v1beta1.ServiceBindingConditionWaitingForInstance, v1beta1.ConditionTrue},
+		{v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionTrue},
+		{v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse},
+	}
+
+	binding := getTestServiceBinding()
+	firstTs := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	for _, c := range conditions {
+		setServiceBindingConditionInternal(binding, c.conditionType, c.status, "Reason", "Message", firstTs)
+	}
+	if e, a := v1beta1.ServiceBindingConditionReady, binding.Status.Conditions[0].Type; e != a {
+		t.Fatalf("unexpected first condition: expected %v, got %v", e, a)
+	}
+	first, err := json.Marshal(binding.Status.Conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secondTs := metav1.Now()
+	for i := len(conditions) - 1; i >= 0; i-- {
+		setServiceBindingConditionInternal(binding, conditions[i].conditionType, conditions[i].status, "Reason", "Message", secondTs)
+	}
+	second, err := json.Marshal(binding.Status.Conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Fatalf("conditions changed\nfirst:  %s\nsecond: %s", first, second)
+	}
+	if e, a := firstTs, *binding.Status.LastConditionUpdateTime; !e.Equal(&a) {
+		t.Fatalf("unexpected lastConditionUpdateTime: expected %v, got %v", e, a)
+	}
+}
+
 // TestReconcileServiceBindingDeleteFailedServiceBinding tests reconcileServiceBinding to ensure
 // a binding with a failed status is deleted properly.
 func TestReconcileServiceBindingDeleteFailedServiceBinding(t *testing.T) {
@@ -3282,6 +4629,143 @@ func TestReconcileServiceBindingAsynchronousUnbind(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingAsyncRequiredOnBind tests that a synchronous bind
+// request which the broker rejects with 422 AsyncRequired is sent again with
+// accepts_incomplete, and that the binding continues asynchronously.
+func TestReconcileServiceBindingAsyncRequiredOnBind(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	fakeKubeClient, fakeCatalogClient, fakeServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: fakeosb.DynamicBindReaction(func(r *osb.BindRequest) (*osb.BindResponse, error) {
+			if !r.AcceptsIncomplete {
+				return nil, fakeosb.AsyncRequiredError()
+			}
+			return &osb.BindResponse{Async: true, OperationKey: &key}, nil
+		}),
+	})
+	testController.osbAPIAcceptsIncomplete = false
+
+	utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.AsyncBindingOperations))
+	defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.AsyncBindingOperations))
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBinding()
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	brokerActions := fakeServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	expectedRequest := &osb.BindRequest{
+		BindingID:  testServiceBindingGUID,
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+		AppGUID:    strPtr(testNamespaceGUID),
+		BindResource: &osb.BindResource{
+			AppGUID: strPtr(testNamespaceGUID),
+		},
+		AcceptsIncomplete: false,
+		Context:           testContext,
+	}
+	assertBind(t, brokerActions[0], expectedRequest)
+	expectedRequest.AcceptsIncomplete = true
+	assertBind(t, brokerActions[1], expectedRequest)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingAsyncInProgress(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, asyncBindingReason, testOperation, binding)
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(asyncRequiredReason).msg(asyncRequiredMessage).String(),
+		normalEventBuilder(asyncBindingReason).msg(asyncBindingMessage).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingAsyncRequiredOnUnbind tests that a synchronous
+// unbind request which the broker rejects with 422 AsyncRequired is sent
+// again with accepts_incomplete, and that the unbinding continues
+// asynchronously.
+func TestReconcileServiceBindingAsyncRequiredOnUnbind(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	_, fakeCatalogClient, fakeServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: fakeosb.DynamicUnbindReaction(func(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+			if !r.AcceptsIncomplete {
+				return nil, fakeosb.AsyncRequiredError()
+			}
+			return &osb.UnbindResponse{Async: true, OperationKey: &key}, nil
+		}),
+	})
+	testController.osbAPIAcceptsIncomplete = false
+
+	utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.AsyncBindingOperations))
+	defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.AsyncBindingOperations))
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBindingUnbinding()
+	fakeCatalogClient.AddReactor("get", "servicebindings", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, binding, nil
+	})
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertServiceBindingUnbindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	brokerActions := fakeServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	expectedRequest := &osb.UnbindRequest{
+		BindingID:         testServiceBindingGUID,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		AcceptsIncomplete: false,
+	}
+	assertUnbind(t, brokerActions[0], expectedRequest)
+	expectedRequest.AcceptsIncomplete = true
+	assertUnbind(t, brokerActions[1], expectedRequest)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingAsyncInProgress(t, updatedServiceBinding, v1beta1.ServiceBindingOperationUnbind, asyncUnbindingReason, testOperation, binding)
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(asyncRequiredReason).msg(asyncRequiredMessage).String(),
+		normalEventBuilder(asyncUnbindingReason).msg(asyncUnbindingMessage).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestPollServiceBinding(t *testing.T) {
 	utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.AsyncBindingOperations))
 	defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.AsyncBindingOperations))
@@ -4255,3 +5739,244 @@ func reconcileServiceBinding(t *testing.T, testController *controller, binding *
 	}
 	return err
 }
+
+func TestNewBindResource(t *testing.T) {
+	cases := []struct {
+		name         string
+		bindResource map[string]string
+		expected     *osb.BindResource
+	}{
+		{
+			name:     "no bind resource",
+			expected: &osb.BindResource{AppGUID: strPtr(testNamespaceGUID)},
+		},
+		{
+			name:         "app guid and route",
+			bindResource: map[string]string{"app_guid": "test-app", "route": "test.example.com"},
+			expected:     &osb.BindResource{AppGUID: strPtr("test-app"), Route: strPtr("test.example.com")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := getTestServiceBinding()
+			binding.Spec.BindResource = tc.bindResource
+			if e, a := tc.expected, newBindResource(binding, testNamespaceGUID); !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected bind resource: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+func TestNewBindResourceExtensions(t *testing.T) {
+	cases := []struct {
+		name         string
+		bindResource map[string]string
+		expected     map[string]string
+	}{
+		{
+			name: "no bind resource",
+		},
+		{
+			name:         "only keys of the OSB API",
+			bindResource: map[string]string{"app_guid": "test-app", "route": "test.example.com"},
+		},
+		{
+			name:         "other keys",
+			bindResource: map[string]string{"app_guid": "test-app", "credential_client_id": "test-client"},
+			expected:     map[string]string{"credential_client_id": "test-client"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := getTestServiceBinding()
+			binding.Spec.BindResource = tc.bindResource
+			if e, a := tc.expected, newBindResourceExtensions(binding); !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected bind resource extensions: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestPrepareBindRequestPlatformAndClusterID tests that the OSB context of a
+// bind request carries the platform and the cluster ID the controller was
+// configured with.
+func TestPrepareBindRequestPlatformAndClusterID(t *testing.T) {
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.osbAPIContextPlatform = "openshift"
+	testController.configuredClusterID = "configured-cluster-id"
+	testController.setClusterID("configured-cluster-id")
+
+	addGetNamespaceReaction(fakeKubeClient)
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	request, _, err := testController.prepareBindRequest(getTestServiceBinding(), getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"platform":           "openshift",
+		"namespace":          testNamespace,
+		clusterIdentifierKey: "configured-cluster-id",
+		"instance_name":      testServiceInstanceName,
+	}
+	if !reflect.DeepEqual(expected, request.Context) {
+		t.Fatalf("unexpected request context: %v", diff.ObjectReflectDiff(expected, request.Context))
+	}
+}
+
+// TestConfigMapUpdateQueuesChangedBindings tests that a change of a config map
+// key referenced in the parametersFrom of a ready binding queues the binding,
+// and that other changes are ignored.
+func TestConfigMapUpdateQueuesChangedBindings(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	binding := getTestServiceBinding()
+	binding.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+		{ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{Name: "config-map", Key: "bind-parameters"}},
+	}
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+		Type:   v1beta1.ServiceBindingConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(binding)
+
+	oldConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "config-map"},
+		Data: map[string]string{
+			"bind-parameters": `{"role":"reader"}`,
+			"other-key":       "value",
+		},
+	}
+
+	cases := []struct {
+		name          string
+		configMapName string
+		data          map[string]string
+		expectQueued  bool
+	}{
+		{
+			name:          "data unchanged",
+			configMapName: "config-map",
+			data:          oldConfigMap.Data,
+		},
+		{
+			name:          "other key changed",
+			configMapName: "config-map",
+			data: map[string]string{
+				"bind-parameters": `{"role":"reader"}`,
+				"other-key":       "other-value",
+			},
+		},
+		{
+			name:          "other config map changed",
+			configMapName: "other-config-map",
+			data:          map[string]string{"bind-parameters": `{"role":"writer"}`},
+		},
+		{
+			name:          "referenced key changed",
+			configMapName: "config-map",
+			data:          map[string]string{"bind-parameters": `{"role":"writer"}`},
+			expectQueued:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			old := oldConfigMap.DeepCopy()
+			old.Name = tc.configMapName
+			newConfigMap := old.DeepCopy()
+			newConfigMap.ResourceVersion = "2"
+			newConfigMap.Data = tc.data
+
+			testController.configMapUpdate(old, newConfigMap)
+
+			expectedLen := 0
+			if tc.expectQueued {
+				expectedLen = 1
+			}
+			if e, a := expectedLen, testController.bindingQueue.Len(); e != a {
+				t.Fatalf("unexpected number of queued bindings: expected %v, got %v", e, a)
+			}
+			if err := checkEvents(getRecordedEvents(testController), nil); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBindingConfigMapParametersChanged tests that a ready
+// binding which reads parameters from a config map requests a rebind when the
+// parameters read from the config map no longer match the parameters sent to
+// the broker.
+func TestReconcileServiceBindingConfigMapParametersChanged(t *testing.T) {
+	cases := []struct {
+		name          string
+		configMapData string
+		expectRequest bool
+	}{
+		{
+			name:          "config map unchanged",
+			configMapData: `{"role":"reader"}`,
+		},
+		{
+			name:          "config map changed",
+			configMapData: `{"role":"writer"}`,
+			expectRequest: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithClusterRefs())
+			addGetConfigMapReaction(fakeKubeClient, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "config-map"},
+				Data:       map[string]string{"bind-parameters": tc.configMapData},
+			})
+
+			binding := getTestServiceBinding()
+			binding.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{Name: "config-map", Key: "bind-parameters"}},
+			}
+			binding.Status.ReconciledGeneration = binding.Generation
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+				Type:   v1beta1.ServiceBindingConditionReady,
+				Status: v1beta1.ConditionTrue,
+			}}
+			binding.Status.ExternalProperties = &v1beta1.ServiceBindingPropertiesState{
+				ParameterChecksum: generateChecksumOfParametersOrFail(t, map[string]interface{}{"role": "reader"}),
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			events := getRecordedEvents(testController)
+			if !tc.expectRequest {
+				assertNumberOfActions(t, actions, 0)
+				if err := checkEvents(events, []string{}); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdate(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if e, a := binding.Spec.RebindRequests+1, updatedServiceBinding.Spec.RebindRequests; e != a {
+				t.Fatalf("unexpected rebindRequests: expected %v, got %v", e, a)
+			}
+
+			expectedEvent := normalEventBuilder(parametersChangedReason).msg(parametersChangedMessage)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
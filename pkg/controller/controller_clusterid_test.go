@@ -25,9 +25,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 )
 
+const testKubeSystemUID = "test-kube-system-uid"
+
 // TestGetClusterIDDefaulting ensures the ID defaulting works to
 // create an ID on access if one is not set.
 func TestGetClusterIDDefaulting(t *testing.T) {
@@ -100,29 +103,94 @@ func TestMonitorConfigMapNoConfigmap(t *testing.T) {
 }
 
 // TestMonitorConfigMapNoConfigmapNoExistingClusterID checks that if a
-// configmap does not exist, it is created and filled in with a
-// generated ID
+// configmap does not exist, it is created and filled in with the UID
+// of the kube-system namespace
 func TestMonitorConfigMapNoConfigmapNoExistingClusterID(t *testing.T) {
 	kc, _, _, tc, _ := newTestController(t, noFakeActions())
 	kc.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
-		m := make(map[string]string)
-		m["id"] = testClusterID
 		return true, nil, errors.NewNotFound(schema.GroupResource{Group: "core", Resource: "configmap"}, DefaultClusterIDConfigMapName)
 	})
+	kc.PrependReactor("get", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if name := action.(clientgotesting.GetAction).GetName(); name != metav1.NamespaceSystem {
+			t.Fatalf("expected to get the %s namespace, got %q", metav1.NamespaceSystem, name)
+		}
+		return true, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: metav1.NamespaceSystem,
+				UID:  types.UID(testKubeSystemUID),
+			},
+		}, nil
+	})
+	tc.setClusterID("")
+	tc.monitorConfigMap()
+	if id := tc.getClusterID(); id != testKubeSystemUID {
+		t.Fatalf("cluster id should have been defaulted to the UID of the kube-system namespace, was %q", id)
+	}
+	if kc.Actions()[2].Matches("create", "configmaps") {
+		createdCM := kc.Actions()[2].(clientgotesting.CreateAction).GetObject().(*corev1.ConfigMap)
+		if id := createdCM.Data["id"]; id != testKubeSystemUID {
+			t.Fatalf("new configmap should have the UID of the kube-system namespace as id. Had id %q", id)
+		}
+	} else {
+		t.Fatalf("should have created a new configmap")
+	}
+}
+
+// TestMonitorConfigMapNoConfigmapNoKubeSystemNamespace checks that if
+// neither a configmap nor the kube-system namespace can be read, the
+// configmap is created and filled in with a generated ID
+func TestMonitorConfigMapNoConfigmapNoKubeSystemNamespace(t *testing.T) {
+	kc, _, _, tc, _ := newTestController(t, noFakeActions())
+	kc.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewNotFound(schema.GroupResource{Group: "core", Resource: "configmap"}, DefaultClusterIDConfigMapName)
+	})
+	kc.PrependReactor("get", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewForbidden(schema.GroupResource{Group: "core", Resource: "namespaces"}, metav1.NamespaceSystem, nil)
+	})
 	tc.setClusterID("")
 	tc.monitorConfigMap()
 	if tc.getClusterID() == "" {
 		t.Fatalf("cluster id should have been generated and filled in upon request")
 	}
-	if kc.Actions()[1].Matches("create", "configmaps") {
-		createdCM := kc.Actions()[1].(clientgotesting.CreateAction).GetObject().(*corev1.ConfigMap)
+	if kc.Actions()[2].Matches("create", "configmaps") {
+		createdCM := kc.Actions()[2].(clientgotesting.CreateAction).GetObject().(*corev1.ConfigMap)
 		if id, ok := createdCM.Data["id"]; !(ok && id != "") {
 			t.Fatalf("new configmap should have a non-blank id")
 		}
 	} else {
 		t.Fatalf("should have created a new configmap")
 	}
+}
 
+// TestMonitorConfigMapConfiguredClusterID checks that a cluster ID
+// given at startup is not overridden by the configmap, and that the
+// configmap is updated to hold it instead.
+func TestMonitorConfigMapConfiguredClusterID(t *testing.T) {
+	kc, _, _, tc, _ := newTestController(t, noFakeActions())
+	kc.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		m := make(map[string]string)
+		m["id"] = "stored-cluster-id"
+		return true, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: DefaultClusterIDConfigMapName,
+			},
+			Data: m,
+		}, nil
+	})
+	tc.configuredClusterID = testClusterID
+	tc.setClusterID(testClusterID)
+	tc.monitorConfigMap()
+	if id := tc.getClusterID(); id != testClusterID {
+		t.Fatalf("should have kept the configured cluster id, was %q", id)
+	}
+	if expectedCMupdate := kc.Actions()[1]; expectedCMupdate.GetVerb() == "update" {
+		updatedCM := expectedCMupdate.(clientgotesting.UpdateAction).GetObject().(*corev1.ConfigMap)
+		if id := updatedCM.Data["id"]; id != testClusterID {
+			t.Fatalf("configmap should have been updated with the configured clusterid, was %q, expected %q", id, testClusterID)
+		}
+	} else {
+		t.Fatalf("configmap should have been updated with the configured clusterid")
+	}
 }
 
 // TestMonitorConfigMapConfigmapOverride checks that the ID is set to
@@ -32,6 +32,7 @@ import (
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
@@ -61,6 +62,17 @@ const (
 	successFetchedCatalogReason           string = "FetchedCatalog"
 	successFetchedCatalogMessage          string = "Successfully fetched catalog entries from broker."
 	errorReconciliationRetryTimeoutReason string = "ErrorReconciliationRetryTimeout"
+	catalogStaleReason                    string = "CatalogStale"
+	skippedServicesWithoutPlansReason     string = "SkippedServicesWithoutPlans"
+	insecureTLSReason                     string = "InsecureSkipTLSVerify"
+	insecureTLSMessage                    string = "The TLS certificate of the broker is not verified because spec.insecureSkipTLSVerify is set; use it only for brokers outside of production."
+	tlsVerifiedReason                     string = "TLSVerified"
+	tlsVerifiedMessage                    string = "The TLS certificate of the broker is verified."
+	skippedServicesWithoutPlansMessage    string = "Skipped the services of the catalog that have no plans: %s"
+	relistStuckReason                     string = "RelistStuck"
+	relistStuckMessage                    string = "The relist of the broker has been in progress for %v."
+	relistFinishedReason                  string = "RelistFinished"
+	relistFinishedMessage                 string = "The relist of the broker is no longer in progress."
 )
 
 func (c *controller) clusterServiceBrokerAdd(obj interface{}) {
@@ -88,6 +100,7 @@ func (c *controller) clusterServiceBrokerDelete(obj interface{}) {
 		return
 	}
 
+	c.brokerCatalogs.Delete(broker.UID)
 	klog.V(4).Infof("Received delete event for ClusterServiceBroker %v; no further processing will occur", broker.Name)
 }
 
@@ -138,7 +151,7 @@ func (c *controller) clusterServiceBrokerClient(broker *v1beta1.ClusterServiceBr
 		}
 		return nil, err
 	}
-	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut)
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
 	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewClusterServiceBrokerKey(broker.Name), clientConfig)
 	if err != nil {
 		s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
@@ -149,7 +162,27 @@ func (c *controller) clusterServiceBrokerClient(broker *v1beta1.ClusterServiceBr
 		}
 		return nil, err
 	}
-	return brokerClient, nil
+	brokerKey := NewClusterServiceBrokerKey(broker.Name)
+	brokerClient = newTLSRecoveringBrokerClient(brokerKey.String(), brokerClient, func() (osb.Client, error) {
+		return c.recreateClusterServiceBrokerClient(broker.Name)
+	})
+	return c.brokerRequestLimiter.limitClient(brokerKey, brokerClient), nil
+}
+
+// recreateClusterServiceBrokerClient creates a new client for the broker from
+// its current spec, including its CA bundle, without reusing the connections
+// of the previous client.
+func (c *controller) recreateClusterServiceBrokerClient(name string) (osb.Client, error) {
+	broker, err := c.clusterServiceBrokerLister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	authConfig, err := c.getAuthCredentialsFromClusterServiceBroker(broker)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
+	return c.brokerClientManager.RecreateBrokerClient(NewClusterServiceBrokerKey(broker.Name), clientConfig)
 }
 
 // reconcileClusterServiceBroker is the control-loop that reconciles a Broker. An
@@ -159,11 +192,38 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
+	// Expose the age of the catalog also for brokers which are not relisted
+	// yet, e.g. after a restart of the controller.
+	if broker.DeletionTimestamp == nil && broker.Status.LastCatalogRetrievalTime != nil {
+		metrics.BrokerSecondsSinceLastRelist.Set(broker.Status.LastCatalogRetrievalTime.Time, broker.Name)
+	}
+
+	// Probe the broker independently of its relists, so that a broker that
+	// is down can be told apart from a broker whose catalog is invalid.
+	if broker.DeletionTimestamp == nil {
+		var err error
+		broker, err = c.checkClusterServiceBrokerHealth(broker)
+		if err != nil {
+			return err
+		}
+		if d, ok := c.timeUntilNextHealthCheck(&broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, time.Now()); ok {
+			klog.V(10).Info(pcb.Messagef("Probing the health of the broker in %v", d))
+			c.clusterServiceBrokerQueue.AddAfter(broker.Name, d)
+		}
+	}
+
 	// * If the broker's ready condition is true and the RelistBehavior has been
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
 	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+		// The catalog was fetched recently, possibly by the previous leader;
+		// relist it when its relist interval elapses instead of waiting for
+		// the next resync.
+		if d, ok := timeUntilNextRelist(&broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, time.Now(), c.brokerRelistInterval); ok {
+			klog.V(10).Info(pcb.Messagef("Relisting in %v", d))
+			c.clusterServiceBrokerQueue.AddAfter(broker.Name, d)
+		}
 		return nil
 	}
 
@@ -174,15 +234,42 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		if err != nil {
 			return err
 		}
+		brokerClient = c.traceBrokerClient("ClusterServiceBroker", broker.ObjectMeta, NewClusterServiceBrokerKey(broker.Name), brokerClient)
+
+		// record the relist in the status, so that a relist which takes
+		// long can be told apart from one that is wedged
+		toUpdate := broker.DeepCopy()
+		if changed, stuckMessage := c.startCommonRelist(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now()); changed {
+			toUpdate.RecalculatePrinterColumnStatusFields()
+			updated, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
+			if err != nil {
+				klog.Error(pcb.Messagef("Error recording the start of the relist: %v", err))
+				return err
+			}
+			broker = updated
+			if stuckMessage != "" {
+				c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
+			}
+		}
 
 		// get the broker's catalog
 		now := metav1.Now()
-		brokerCatalog, err := brokerClient.GetCatalog()
+		relistInterval := c.brokerRelistInterval
+		if broker.Spec.RelistDuration != nil {
+			relistInterval = broker.Spec.RelistDuration.Duration
+		}
+		syncCatalog, fetched, err := c.catalogForSync(broker.UID, broker.Generation, relistInterval, now.Time, func() (*brokerhttp.CatalogResponse, error) {
+			return brokerhttp.GetCatalog(brokerClient)
+		})
+		if isBrokerRequestLimitError(err) {
+			return err
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			reason := catalogFetchErrorReason(err)
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, errorFetchingCatalogMessage+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -197,8 +284,10 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				klog.Info(pcb.Message(s))
 				c.recorder.Event(broker, corev1.EventTypeWarning, errorReconciliationRetryTimeoutReason, s)
 				toUpdate := broker.DeepCopy()
+				toUpdate.Status.CurrentOperation = ""
 				toUpdate.Status.OperationStartTime = nil
 				toUpdate.Status.ReconciledGeneration = toUpdate.Generation
+				toUpdate.Status.LastRelistRequestProcessed = toUpdate.Spec.RelistRequests
 				return c.updateClusterServiceBrokerCondition(toUpdate,
 					v1beta1.ServiceBrokerConditionFailed,
 					v1beta1.ConditionTrue,
@@ -208,10 +297,19 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			return err
 		}
 
-		klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
+		brokerCatalog := syncCatalog.catalog
+		if fetched {
+			klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
+		} else {
+			klog.V(4).Info(pcb.Messagef("Reusing the catalog fetched at %v to retry writing %v catalog entries", syncCatalog.fetchTime, len(brokerCatalog.Services)))
+		}
+		// keep the catalog until all of its classes and plans are written,
+		// so that a retry does not fetch it again
+		c.brokerCatalogs.Set(broker.UID, syncCatalog)
 
-		// set the operation start time if not already set
-		if broker.Status.OperationStartTime != nil {
+		// clear the operation start time of the retries, unless it is
+		// the start time of the recorded relist
+		if broker.Status.OperationStartTime != nil && broker.Status.CurrentOperation == "" {
 			toUpdate := broker.DeepCopy()
 			toUpdate.Status.OperationStartTime = nil
 			updated, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
@@ -233,10 +331,19 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		existingServiceClassMap := convertClusterServiceClassListToMap(existingServiceClasses)
 		existingServicePlanMap := convertClusterServicePlanListToMap(existingServicePlans)
 
+		if c.classWithoutPlansPolicy == ClassWithoutPlansPolicySkip {
+			if skipped := skipServicesWithoutPlans(&brokerCatalog.CatalogResponse); len(skipped) > 0 {
+				s := fmt.Sprintf(skippedServicesWithoutPlansMessage, strings.Join(skipped, ", "))
+				klog.Warning(pcb.Message(s))
+				c.recorder.Event(broker, corev1.EventTypeWarning, skippedServicesWithoutPlansReason, s)
+			}
+		}
+
 		// convert the broker's catalog payload into our API objects
 		klog.V(4).Info(pcb.Message("Converting catalog response into service-catalog API"))
-		payloadServiceClasses, payloadServicePlans, err := convertAndFilterCatalog(brokerCatalog, broker.Spec.CatalogRestrictions, existingServiceClassMap, existingServicePlanMap)
+		payloadServiceClasses, payloadServicePlans, err := convertAndFilterCatalog(&brokerCatalog.CatalogResponse, broker.Spec.CatalogRestrictions, existingServiceClassMap, existingServicePlanMap)
 		if err != nil {
+			c.brokerCatalogs.Delete(broker.UID)
 			s := fmt.Sprintf("Error converting catalog payload for broker %q to service-catalog API: %s", broker.Name, err)
 			klog.Warning(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
@@ -246,9 +353,16 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			return err
 		}
 		klog.V(5).Info(pcb.Message("Successfully converted catalog payload from to service-catalog API"))
+		for _, payloadServiceClass := range payloadServiceClasses {
+			payloadServiceClass.Spec.InstancesRetrievable = brokerCatalog.InstancesRetrievable[payloadServiceClass.Spec.ExternalID]
+		}
 
 		// reconcile the serviceClasses that were part of the broker's catalog
-		// payload
+		// payload. The classes are written before the plans, and the plans
+		// are marked as removed before the classes, so that a plan never
+		// refers to a class that is missing or removed while the catalog is
+		// synced.
+		syncedServiceClassNames := sets.NewString()
 		for _, payloadServiceClass := range payloadServiceClasses {
 			existingServiceClass, _ := existingServiceClassMap[payloadServiceClass.Name]
 			delete(existingServiceClassMap, payloadServiceClass.Name)
@@ -257,6 +371,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				delete(existingServiceClassMap, payloadServiceClass.Spec.ExternalID)
 			}
 
+			if syncCatalog.appliedClasses.Has(payloadServiceClass.Name) {
+				klog.V(5).Info(pcb.Messagef("%s was written by the previous attempt", pretty.ClusterServiceClassName(payloadServiceClass)))
+				syncedServiceClassNames.Insert(payloadServiceClass.Name)
+				continue
+			}
+
 			klog.V(4).Info(pcb.Messagef("Reconciling %s", pretty.ClusterServiceClassName(payloadServiceClass)))
 			if err := c.reconcileClusterServiceClassFromClusterServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass); err != nil {
 				s := fmt.Sprintf(
@@ -273,27 +393,16 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServiceClassName(payloadServiceClass)))
+			syncedServiceClassNames.Insert(payloadServiceClass.Name)
+			syncCatalog.appliedClasses.Insert(payloadServiceClass.Name)
 		}
 
-		// handle the serviceClasses that were not in the broker's payload;
-		// mark these as having been removed from the broker's catalog
-		for _, existingServiceClass := range existingServiceClassMap {
-			if existingServiceClass.Status.RemovedFromBrokerCatalog {
-				continue
-			}
-
-			// Do not delete user-defined classes
-			if !isServiceCatalogManagedResource(existingServiceClass) {
-				continue
-			}
-
-			klog.V(4).Info(pcb.Messagef("%s has been removed from broker's catalog; marking", pretty.ClusterServiceClassName(existingServiceClass)))
-			existingServiceClass.Status.RemovedFromBrokerCatalog = true
-			_, err := c.serviceCatalogClient.ClusterServiceClasses().UpdateStatus(existingServiceClass)
-			if err != nil {
+		// reconcile the plans that were part of the broker's catalog payload
+		for _, payloadServicePlan := range payloadServicePlans {
+			if !syncedServiceClassNames.Has(payloadServicePlan.Spec.ClusterServiceClassRef.Name) {
 				s := fmt.Sprintf(
-					"Error updating status of %s: %v",
-					pretty.ClusterServiceClassName(existingServiceClass), err,
+					"Error reconciling %s: its class %q was not synced",
+					pretty.ClusterServicePlanName(payloadServicePlan), payloadServicePlan.Spec.ClusterServiceClassRef.Name,
 				)
 				klog.Warning(pcb.Message(s))
 				c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
@@ -301,12 +410,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 					errorSyncingCatalogMessage+s); err != nil {
 					return err
 				}
-				return err
+				return fmt.Errorf("%s", s)
 			}
-		}
-
-		// reconcile the plans that were part of the broker's catalog payload
-		for _, payloadServicePlan := range payloadServicePlans {
 			existingServicePlan, _ := existingServicePlanMap[payloadServicePlan.Name]
 			delete(existingServicePlanMap, payloadServicePlan.Name)
 			if existingServicePlan == nil {
@@ -314,6 +419,11 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				delete(existingServicePlanMap, payloadServicePlan.Spec.ExternalID)
 			}
 
+			if syncCatalog.appliedPlans.Has(payloadServicePlan.Name) {
+				klog.V(5).Info(pcb.Messagef("%s was written by the previous attempt", pretty.ClusterServicePlanName(payloadServicePlan)))
+				continue
+			}
+
 			klog.V(4).Infof(
 				"ClusterServiceBroker %q: reconciling %s",
 				broker.Name, pretty.ClusterServicePlanName(payloadServicePlan),
@@ -330,7 +440,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				return err
 			}
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServicePlanName(payloadServicePlan)))
-
+			syncCatalog.appliedPlans.Insert(payloadServicePlan.Name)
 		}
 
 		// handle the servicePlans that were not in the broker's payload;
@@ -364,17 +474,62 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 		}
 
+		// handle the serviceClasses that were not in the broker's payload;
+		// mark these as having been removed from the broker's catalog, once
+		// their plans were marked
+		for _, existingServiceClass := range existingServiceClassMap {
+			if existingServiceClass.Status.RemovedFromBrokerCatalog {
+				continue
+			}
+
+			// Do not delete user-defined classes
+			if !isServiceCatalogManagedResource(existingServiceClass) {
+				continue
+			}
+
+			klog.V(4).Info(pcb.Messagef("%s has been removed from broker's catalog; marking", pretty.ClusterServiceClassName(existingServiceClass)))
+			existingServiceClass.Status.RemovedFromBrokerCatalog = true
+			_, err := c.serviceCatalogClient.ClusterServiceClasses().UpdateStatus(existingServiceClass)
+			if err != nil {
+				s := fmt.Sprintf(
+					"Error updating status of %s: %v",
+					pretty.ClusterServiceClassName(existingServiceClass), err,
+				)
+				klog.Warning(pcb.Message(s))
+				c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
+				if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason,
+					errorSyncingCatalogMessage+s); err != nil {
+					return err
+				}
+				return err
+			}
+		}
+
+		// all of the classes and plans of the catalog were written
+		c.brokerCatalogs.Delete(broker.UID)
+
 		// everything worked correctly; update the broker's ready condition to
-		// status true
+		// status true. The catalog was retrieved when it was fetched, which
+		// is earlier than now when a retry reused it.
+		wasReady := isServiceBrokerReady(&broker.Status.CommonServiceBrokerStatus)
+		fetchTime := metav1.NewTime(syncCatalog.fetchTime)
+		broker = broker.DeepCopy()
+		broker.Status.LastCatalogRetrievalTime = &fetchTime
 		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
+		// retry the requests that failed while the broker was unreachable
+		if !wasReady {
+			c.requeueClusterServiceBrokerDependents(broker)
+		}
+
 		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
 
 		// Update metrics with the number of serviceclasses and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
 		metrics.BrokerServicePlanCount.WithLabelValues(broker.Name).Set(float64(len(payloadServicePlans)))
+		metrics.BrokerSecondsSinceLastRelist.Set(syncCatalog.fetchTime, broker.Name)
 
 		return nil
 	}
@@ -449,6 +604,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+		metrics.BrokerSecondsSinceLastRelist.DeleteLabelValues(broker.Name)
+		c.brokerRequestLimiter.removeBroker(NewClusterServiceBrokerKey(broker.Name))
 		return nil
 	}
 
@@ -511,6 +668,7 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 	// update it.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstancesRetrievable = serviceClass.Spec.InstancesRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -604,6 +762,7 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	toUpdate.Spec.Free = servicePlan.Spec.Free
 	toUpdate.Spec.ExternalName = servicePlan.Spec.ExternalName
 	toUpdate.Spec.ExternalMetadata = servicePlan.Spec.ExternalMetadata
+	toUpdate.Spec.Costs = servicePlan.Spec.Costs
 	toUpdate.Spec.InstanceCreateParameterSchema = servicePlan.Spec.InstanceCreateParameterSchema
 	toUpdate.Spec.InstanceUpdateParameterSchema = servicePlan.Spec.InstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
@@ -638,47 +797,20 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 // updateClusterServiceBrokerCondition updates the ready condition for the given Broker
 // with the given status, reason, and message.
 func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.ClusterServiceBroker, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) error {
-	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	toUpdate := broker.DeepCopy()
-	newCondition := v1beta1.ServiceBrokerCondition{
-		Type:    conditionType,
-		Status:  status,
-		Reason:  reason,
-		Message: message,
-	}
-
-	t := time.Now()
 
-	if len(broker.Status.Conditions) == 0 {
-		klog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
-		newCondition.LastTransitionTime = metav1.NewTime(t)
-		toUpdate.Status.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
-	} else {
-		for i, cond := range broker.Status.Conditions {
-			if cond.Type == conditionType {
-				if cond.Status != newCondition.Status {
-					klog.Info(pcb.Messagef(
-						"Found status change for condition %q: %q -> %q; setting lastTransitionTime to %v",
-						conditionType, cond.Status, status, t,
-					))
-					newCondition.LastTransitionTime = metav1.NewTime(t)
-				} else {
-					newCondition.LastTransitionTime = cond.LastTransitionTime
-				}
-
-				toUpdate.Status.Conditions[i] = newCondition
-				break
-			}
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(toUpdate)
+	updateCommonStatusCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, conditionType, status, reason, message)
+	if conditionType == v1beta1.ServiceBrokerConditionReady {
+		c.updateCommonCatalogStaleCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now())
+		if updateCommonInsecureTLSCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus) {
+			c.recorder.Event(broker, corev1.EventTypeWarning, insecureTLSReason, insecureTLSMessage)
 		}
 	}
-
-	// Set status.ReconciledGeneration && status.LastCatalogRetrievalTime if updating ready condition to true
-
-	if conditionType == v1beta1.ServiceBrokerConditionReady && status == v1beta1.ConditionTrue {
-		toUpdate.Status.ReconciledGeneration = toUpdate.Generation
-		now := metav1.NewTime(t)
-		toUpdate.Status.LastCatalogRetrievalTime = &now
+	if stuckMessage := c.updateCommonRelistStuckCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now()); stuckMessage != "" {
+		c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
 	}
+
 	toUpdate.RecalculatePrinterColumnStatusFields()
 
 	klog.V(4).Info(pcb.Messagef("Updating ready condition to %v", status))
@@ -28,12 +28,16 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"strings"
 
@@ -173,6 +177,19 @@ func TestShouldReconcileClusterServiceBroker(t *testing.T) {
 			now:       time.Now(),
 			reconcile: true,
 		},
+		{
+			name: "ready, interval not elapsed, relist in progress",
+			broker: func() *v1beta1.ClusterServiceBroker {
+				broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+				broker.Spec.RelistDuration = &metav1.Duration{Duration: 3 * time.Hour}
+				startTime := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+				broker.Status.CurrentOperation = v1beta1.ServiceBrokerOperationRelist
+				broker.Status.OperationStartTime = &startTime
+				return broker
+			}(),
+			now:       time.Now(),
+			reconcile: true,
+		},
 		{
 			name: "ready, duration behavior, nil duration, interval not elapsed",
 			broker: func() *v1beta1.ClusterServiceBroker {
@@ -233,6 +250,154 @@ func TestShouldReconcileClusterServiceBroker(t *testing.T) {
 	}
 }
 
+func TestTimeUntilNextRelist(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name      string
+		broker    *v1beta1.ClusterServiceBroker
+		remaining time.Duration
+		scheduled bool
+	}{
+		{
+			name:   "never relisted",
+			broker: getTestClusterServiceBroker(),
+		},
+		{
+			name: "relisted recently",
+			broker: func() *v1beta1.ClusterServiceBroker {
+				lastRelistTime := metav1.NewTime(now.Add(-2 * time.Minute))
+				broker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, lastRelistTime, lastRelistTime)
+				broker.Spec.RelistDuration = &metav1.Duration{Duration: 15 * time.Minute}
+				return broker
+			}(),
+			remaining: 13 * time.Minute,
+			scheduled: true,
+		},
+		{
+			name: "relisted recently, default interval",
+			broker: func() *v1beta1.ClusterServiceBroker {
+				lastRelistTime := metav1.NewTime(now.Add(-2 * time.Hour))
+				broker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, lastRelistTime, lastRelistTime)
+				broker.Spec.RelistDuration = nil
+				return broker
+			}(),
+			remaining: 22 * time.Hour,
+			scheduled: true,
+		},
+		{
+			name: "interval elapsed",
+			broker: func() *v1beta1.ClusterServiceBroker {
+				lastRelistTime := metav1.NewTime(now.Add(-20 * time.Minute))
+				broker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, lastRelistTime, lastRelistTime)
+				broker.Spec.RelistDuration = &metav1.Duration{Duration: 15 * time.Minute}
+				return broker
+			}(),
+		},
+		{
+			name: "manual behavior",
+			broker: func() *v1beta1.ClusterServiceBroker {
+				lastRelistTime := metav1.NewTime(now.Add(-2 * time.Minute))
+				broker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, lastRelistTime, lastRelistTime)
+				broker.Spec.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorManual
+				return broker
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			remaining, scheduled := timeUntilNextRelist(&tc.broker.ObjectMeta, &tc.broker.Spec.CommonServiceBrokerSpec, &tc.broker.Status.CommonServiceBrokerStatus, now, 24*time.Hour)
+			if e, a := tc.scheduled, scheduled; e != a {
+				t.Fatalf("unexpected scheduled: %s", expectedGot(e, a))
+			}
+			if e, a := tc.remaining, remaining; e != a {
+				t.Fatalf("unexpected remaining time: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestRelistDuration verifies that the relists of a broker are delayed by up
+// to its catalog refresh jitter, by the same amount for a given relist.
+func TestRelistDuration(t *testing.T) {
+	lastRetrieval := time.Now()
+	cases := []struct {
+		name     string
+		jitter   int64
+		duration *metav1.Duration
+		min      time.Duration
+		max      time.Duration
+	}{
+		{
+			name: "default interval, no jitter",
+			min:  24 * time.Hour,
+			max:  24 * time.Hour,
+		},
+		{
+			name:     "relist duration, no jitter",
+			duration: &metav1.Duration{Duration: 15 * time.Minute},
+			min:      15 * time.Minute,
+			max:      15 * time.Minute,
+		},
+		{
+			name:     "relist duration with jitter",
+			jitter:   50,
+			duration: &metav1.Duration{Duration: 15 * time.Minute},
+			min:      15 * time.Minute,
+			max:      22*time.Minute + 30*time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			broker := getTestClusterServiceBroker()
+			broker.Spec.RelistDuration = tc.duration
+			broker.Spec.CatalogRefreshJitterPercent = tc.jitter
+			for _, uid := range []types.UID{"broker-1", "broker-2", "broker-3"} {
+				broker.UID = uid
+				d := relistDuration(&broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, lastRetrieval, 24*time.Hour)
+				if d < tc.min || d > tc.max {
+					t.Fatalf("relist duration %v of broker %s not in [%v, %v]", d, uid, tc.min, tc.max)
+				}
+				if again := relistDuration(&broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, lastRetrieval, 24*time.Hour); again != d {
+					t.Fatalf("relist duration of broker %s changed: %s", uid, expectedGot(d, again))
+				}
+			}
+		})
+	}
+}
+
+// TestReconcileClusterServiceBrokerRecentlyRelisted verifies that a controller
+// which has just become the leader does not fetch the catalog of a broker
+// which was relisted recently, and relists it when its relist interval
+// elapses.
+func TestReconcileClusterServiceBrokerRecentlyRelisted(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	relistDuration := 3 * time.Minute
+	lastRelistTime := metav1.NewTime(time.Now().Add(-relistDuration + 100*time.Millisecond))
+	broker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, lastRelistTime, lastRelistTime)
+	broker.Spec.RelistDuration = &metav1.Duration{Duration: relistDuration}
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail : %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return testController.clusterServiceBrokerQueue.Len() == 1, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the broker to be queued when its relist interval elapses: %v", err)
+	}
+	if key, _ := testController.clusterServiceBrokerQueue.Get(); key != broker.Name {
+		t.Fatalf("unexpected queued key: %s", expectedGot(broker.Name, key))
+	}
+}
+
 // TestReconcileClusterServiceBrokerSetOSBTimeOut
 // verifies that timeout of any request to the
 // broker takes effect.
@@ -313,6 +478,42 @@ func TestReconcileClusterServiceBrokerExistingServiceClassAndServicePlan(t *test
 	}
 }
 
+// TestReconcileClusterServiceBrokerInstancesRetrievable tests that the
+// classes of the services whose instances the broker can return are marked
+// as instancesRetrievable.
+func TestReconcileClusterServiceBrokerInstancesRetrievable(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
+	brokerClient := &fakeBrokerHTTPClient{
+		FakeClient:           fakeClusterServiceBrokerClient,
+		instancesRetrievable: map[string]bool{testClusterServiceClassGUID: true},
+	}
+	testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+		return brokerClient, nil
+	}
+
+	testClusterServiceClass := getTestClusterServiceClass()
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(testClusterServiceClass)
+
+	fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServiceClassList{
+			Items: []v1beta1.ClusterServiceClass{
+				*testClusterServiceClass,
+			},
+		}, nil
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 6)
+	updatedServiceClass := assertUpdate(t, actions[2], testClusterServiceClass).(*v1beta1.ClusterServiceClass)
+	if !updatedServiceClass.Spec.InstancesRetrievable {
+		t.Fatalf("expected the class to be instancesRetrievable")
+	}
+}
+
 func TestReconcileClusterServiceBrokerRemovedClusterServiceClass(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
 
@@ -352,9 +553,9 @@ func TestReconcileClusterServiceBrokerRemovedClusterServiceClass(t *testing.T) {
 	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
 	assertUpdate(t, actions[2], testClusterServiceClass)
-	assertUpdateStatus(t, actions[3], testRemovedClusterServiceClass)
-	assertCreate(t, actions[4], testClusterServicePlan)
-	assertCreate(t, actions[5], testClusterServicePlanNonbindable)
+	assertCreate(t, actions[3], testClusterServicePlan)
+	assertCreate(t, actions[4], testClusterServicePlanNonbindable)
+	assertUpdateStatus(t, actions[5], testRemovedClusterServiceClass)
 
 	updatedClusterServiceBroker := assertUpdateStatus(t, actions[6], getTestClusterServiceBroker())
 	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
@@ -493,6 +694,81 @@ func TestReconcileClusterServiceBrokerRemovedClusterServicePlan(t *testing.T) {
 	assertNumberOfActions(t, kubeActions, 0)
 }
 
+// TestReconcileClusterServiceBrokerSyncOrder tests that no plan is written
+// before its class within a sync of the catalog, and that no class is marked
+// as removed before its plans.
+func TestReconcileClusterServiceBrokerSyncOrder(t *testing.T) {
+	catalog := getTestCatalog()
+	catalog.Services = append(catalog.Services, osb.Service{
+		Name:        "second-class",
+		ID:          "second-class-id",
+		Description: "a second test service",
+		Bindable:    true,
+		Plans: []osb.Plan{{
+			Name:        "second-plan",
+			Free:        truePtr(),
+			ID:          "second-plan-id",
+			Description: "a test plan",
+		}},
+	})
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Response: catalog},
+	})
+
+	testRemovedClusterServiceClass := getTestRemovedClusterServiceClass()
+	testRemovedClusterServicePlan := getTestRemovedClusterServicePlan()
+	testRemovedClusterServicePlan.Spec.ClusterServiceClassRef.Name = testRemovedClusterServiceClass.Name
+	fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServiceClassList{
+			Items: []v1beta1.ClusterServiceClass{*testRemovedClusterServiceClass},
+		}, nil
+	})
+	fakeCatalogClient.AddReactor("list", "clusterserviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServicePlanList{
+			Items: []v1beta1.ClusterServicePlan{*testRemovedClusterServicePlan},
+		}, nil
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	writtenClasses := sets.NewString()
+	removedPlanClasses := sets.NewString()
+	planWrites := 0
+	for i, action := range fakeCatalogClient.Actions() {
+		objectAction, ok := action.(interface{ GetObject() runtime.Object })
+		if !ok {
+			continue
+		}
+		switch obj := objectAction.GetObject().(type) {
+		case *v1beta1.ClusterServiceClass:
+			if obj.Status.RemovedFromBrokerCatalog {
+				if !removedPlanClasses.Has(obj.Name) {
+					t.Fatalf("action %d: class %q was marked as removed before its plans", i, obj.Name)
+				}
+				continue
+			}
+			writtenClasses.Insert(obj.Name)
+		case *v1beta1.ClusterServicePlan:
+			if obj.Status.RemovedFromBrokerCatalog {
+				removedPlanClasses.Insert(obj.Spec.ClusterServiceClassRef.Name)
+				continue
+			}
+			if !writtenClasses.Has(obj.Spec.ClusterServiceClassRef.Name) {
+				t.Fatalf("action %d: plan %q was written before its class %q", i, obj.Name, obj.Spec.ClusterServiceClassRef.Name)
+			}
+			planWrites++
+		}
+	}
+	if e, a := 3, planWrites; e != a {
+		t.Fatalf("unexpected number of plan writes: %s", expectedGot(e, a))
+	}
+	if !removedPlanClasses.Has(testRemovedClusterServiceClass.Name) {
+		t.Fatal("expected the plan of the removed class to be marked as removed")
+	}
+}
+
 // TestReconcileClusterServiceBrokerExistingClusterServiceClassDifferentBroker simulates catalog
 // refresh where broker lists a service which matches an existing, already
 // cataloged service but the service points to a different ClusterServiceBroker.  Results in an error.
@@ -661,7 +937,7 @@ func TestReconcileClusterServiceBrokerDelete(t *testing.T) {
 			broker.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
 
 			updateBrokerClientCalled := false
-			testController.brokerClientManager = NewBrokerClientManager(func(_ *osb.ClientConfiguration) (osb.Client, error) {
+			testController.brokerClientManager = NewBrokerClientManager(func(_ *BrokerClientConfiguration) (osb.Client, error) {
 				updateBrokerClientCalled = true
 				return nil, nil
 			})
@@ -830,6 +1106,79 @@ func TestReconcileClusterServiceBrokerZeroServices(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerServiceWithoutPlans tests that a catalog
+// with a service without plans is rejected or synced without that service,
+// depending on the class without plans policy.
+func TestReconcileClusterServiceBrokerServiceWithoutPlans(t *testing.T) {
+	cases := []struct {
+		name          string
+		policy        ClassWithoutPlansPolicy
+		expectedError bool
+		expectedEvent string
+	}{
+		{
+			name:          "reject",
+			policy:        ClassWithoutPlansPolicyReject,
+			expectedError: true,
+			expectedEvent: corev1.EventTypeWarning + " " + errorSyncingCatalogReason + " " + `Error converting catalog payload for broker "test-clusterservicebroker" to service-catalog API: service "empty-service" (ID "empty-service-id") has no plans`,
+		},
+		{
+			name:          "skip",
+			policy:        ClassWithoutPlansPolicySkip,
+			expectedEvent: corev1.EventTypeWarning + " " + skippedServicesWithoutPlansReason + " " + `Skipped the services of the catalog that have no plans: "empty-service" (ID "empty-service-id")`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			catalog := getTestCatalog()
+			catalog.Services = append(catalog.Services, osb.Service{
+				Name:        "empty-service",
+				ID:          "empty-service-id",
+				Description: "a service without plans",
+				Bindable:    true,
+			})
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+				CatalogReaction: &fakeosb.CatalogReaction{
+					Response: catalog,
+				},
+			})
+			testController.classWithoutPlansPolicy = tc.policy
+
+			err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker())
+			if tc.expectedError && err == nil {
+				t.Fatal("Expected the reconciliation to fail")
+			}
+			if !tc.expectedError && err != nil {
+				t.Fatalf("This should not fail: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], getTestClusterServiceBroker())
+			if tc.expectedError {
+				assertNumberOfActions(t, actions, 3)
+				assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+			} else {
+				assertNumberOfActions(t, actions, 6)
+				assertCreate(t, actions[2], getTestClusterServiceClass())
+				assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+			}
+			for _, action := range actions {
+				if create, ok := action.(clientgotesting.CreateAction); ok {
+					if class, ok := create.GetObject().(*v1beta1.ClusterServiceClass); ok && class.Spec.ExternalID == "empty-service-id" {
+						t.Fatal("Expected no class to be created for the service without plans")
+					}
+				}
+			}
+
+			events := getRecordedEvents(testController)
+			if e, a := tc.expectedEvent, events[0]; e != a {
+				t.Fatalf("Received unexpected event, %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
 func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 	// The test cases here are testing the correctness of authentication with broker
 	//
@@ -1008,6 +1357,98 @@ func TestReconcileClusterServiceBrokerWithReconcileError(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerRetryWritesWithCachedCatalog tests that
+// when writing some of the plans of a fetched catalog fails, the retry of the
+// sync reuses the catalog instead of fetching it again, and only writes the
+// plans that failed.
+func TestReconcileClusterServiceBrokerRetryWritesWithCachedCatalog(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.UID = "test-clusterservicebroker-uid"
+
+	failCreate := true
+	fakeCatalogClient.AddReactor("create", "clusterserviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		plan := action.(clientgotesting.CreateAction).GetObject().(*v1beta1.ClusterServicePlan)
+		if failCreate && plan.Name == testNonbindableClusterServicePlanGUID {
+			return true, nil, apierrors.NewConflict(v1beta1.Resource("clusterserviceplans"), plan.Name, errors.New("conflict"))
+		}
+		return true, plan, nil
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("There should have been an error.")
+	}
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertGetCatalog(t, brokerActions[0])
+	getRecordedEvents(testController)
+	cached, found := testController.brokerCatalogs.Get(broker.UID)
+	if !found {
+		t.Fatal("expected the catalog to be cached after its writes failed")
+	}
+
+	failCreate = false
+	fakeCatalogClient.ClearActions()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	// the catalog is not fetched again
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	listRestrictions := clientgotesting.ListRestrictions{
+		Labels: labels.SelectorFromSet(labels.Set{
+			v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceBrokerName: util.GenerateSHA("test-clusterservicebroker"),
+		}),
+		Fields: fields.Everything(),
+	}
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 4)
+	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
+	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
+	assertCreate(t, actions[2], getTestClusterServicePlanNonbindable())
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[3], broker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+
+	// the catalog was retrieved by the first attempt, not by the retry
+	retrievalTime := updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.LastCatalogRetrievalTime
+	if retrievalTime == nil || !retrievalTime.Time.Equal(cached.fetchTime) {
+		t.Fatalf("unexpected last catalog retrieval time: %s", expectedGot(cached.fetchTime, retrievalTime))
+	}
+
+	if _, found := testController.brokerCatalogs.Get(broker.UID); found {
+		t.Fatal("expected the catalog to be removed from the cache once it was written")
+	}
+}
+
+// TestReconcileClusterServiceBrokerRefetchesCatalogAfterSpecChange tests that
+// the catalog cached by a sync whose writes failed is not reused once the
+// spec of the broker changed.
+func TestReconcileClusterServiceBrokerRefetchesCatalogAfterSpecChange(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.UID = "test-clusterservicebroker-uid"
+
+	fakeCatalogClient.AddReactor("create", "clusterserviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("exceeded quota")
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("There should have been an error.")
+	}
+	broker.Generation++
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("There should have been an error.")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertGetCatalog(t, brokerActions[0])
+	assertGetCatalog(t, brokerActions[1])
+}
+
 // TestReconcileClusterServiceBrokerSuccessOnFinalRetry verifies that reconciliation can
 // succeed on the last attempt before timing out of the retry loop
 func TestReconcileClusterServiceBrokerSuccessOnFinalRetry(t *testing.T) {
@@ -1075,6 +1516,7 @@ func TestReconcileClusterServiceBrokerFailureOnFinalRetry(t *testing.T) {
 	broker := getTestClusterServiceBroker()
 	startTime := metav1.NewTime(time.Now().Add(-7 * 24 * time.Hour))
 	broker.Status.OperationStartTime = &startTime
+	broker.Spec.RelistRequests = 2
 
 	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
 		t.Fatalf("Should have return no error because the retry duration has elapsed: %v", err)
@@ -1093,6 +1535,9 @@ func TestReconcileClusterServiceBrokerFailureOnFinalRetry(t *testing.T) {
 	updatedClusterServiceBroker = assertUpdateStatus(t, actions[1], broker)
 	assertClusterServiceBrokerCondition(t, updatedClusterServiceBroker, v1beta1.ServiceBrokerConditionFailed, v1beta1.ConditionTrue)
 	assertClusterServiceBrokerOperationStartTimeSet(t, updatedClusterServiceBroker, false)
+	if e, a := int64(2), updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.LastRelistRequestProcessed; e != a {
+		t.Fatalf("Unexpected last relist request processed: %s", expectedGot(e, a))
+	}
 
 	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
 
@@ -1108,6 +1553,150 @@ func TestReconcileClusterServiceBrokerFailureOnFinalRetry(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerRecordsRelist verifies that the relist is
+// recorded in the status of the broker while it is in progress, and cleared
+// once the catalog has been reconciled.
+func TestReconcileClusterServiceBrokerRecordsRelist(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+	testController.brokerRelistTimeout = 10 * time.Minute
+
+	broker := getTestClusterServiceBroker()
+
+	fakeCatalogClient.AddReactor("update", "clusterservicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		e := action.(clientgotesting.UpdateAction)
+		return true, e.GetObject(), nil
+	})
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail : %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertGetCatalog(t, brokerActions[0])
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 7)
+
+	// first action should be an update action to record the relist
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	if e, a := v1beta1.ServiceBrokerOperationRelist, updatedClusterServiceBroker.Status.CurrentOperation; e != a {
+		t.Fatalf("Unexpected current operation: %s", expectedGot(e, a))
+	}
+	assertClusterServiceBrokerOperationStartTimeSet(t, updatedClusterServiceBroker, true)
+
+	updatedClusterServiceBroker = assertUpdateStatus(t, actions[6], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if a := updatedClusterServiceBroker.Status.CurrentOperation; a != "" {
+		t.Fatalf("Unexpected current operation: %s", expectedGot("", a))
+	}
+	assertClusterServiceBrokerOperationStartTimeSet(t, updatedClusterServiceBroker, false)
+}
+
+// TestReconcileClusterServiceBrokerRelistStuck verifies that the RelistStuck
+// condition is set when the recorded relist has been in progress for longer
+// than the relist timeout.
+func TestReconcileClusterServiceBrokerRelistStuck(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: errors.New("ooops"),
+		},
+	})
+	testController.brokerRelistTimeout = 10 * time.Minute
+
+	broker := getTestClusterServiceBroker()
+	startTime := metav1.NewTime(time.Now().Add(-20 * time.Minute))
+	broker.Status.CurrentOperation = v1beta1.ServiceBrokerOperationRelist
+	broker.Status.OperationStartTime = &startTime
+
+	fakeCatalogClient.AddReactor("update", "clusterservicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		e := action.(clientgotesting.UpdateAction)
+		return true, e.GetObject(), nil
+	})
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerCondition(t, updatedClusterServiceBroker, v1beta1.ServiceBrokerConditionRelistStuck, v1beta1.ConditionTrue)
+	found := false
+	for _, cond := range updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionRelistStuck {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a RelistStuck condition")
+	}
+
+	updatedClusterServiceBroker = assertUpdateStatus(t, actions[1], broker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	assertClusterServiceBrokerCondition(t, updatedClusterServiceBroker, v1beta1.ServiceBrokerConditionRelistStuck, v1beta1.ConditionTrue)
+	assertClusterServiceBrokerOperationStartTimeSet(t, updatedClusterServiceBroker, true)
+
+	events := getRecordedEvents(testController)
+
+	expectedEventPrefixes := []string{
+		warningEventBuilder(relistStuckReason).String(),
+		warningEventBuilder(errorFetchingCatalogReason).String(),
+	}
+
+	if err := checkEventPrefixes(events, expectedEventPrefixes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestMonitorStuckRelists verifies that the RelistStuck condition is set
+// apart from the reconciliation of the broker, and only for the relists which
+// have been in progress for longer than the relist timeout.
+func TestMonitorStuckRelists(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.brokerRelistTimeout = 10 * time.Minute
+
+	stuck := getTestClusterServiceBroker()
+	stuckStartTime := metav1.NewTime(time.Now().Add(-20 * time.Minute))
+	stuck.Status.CurrentOperation = v1beta1.ServiceBrokerOperationRelist
+	stuck.Status.OperationStartTime = &stuckStartTime
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(stuck)
+
+	relisting := getTestClusterServiceBroker()
+	relisting.Name = "relisting-broker"
+	relistingStartTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	relisting.Status.CurrentOperation = v1beta1.ServiceBrokerOperationRelist
+	relisting.Status.OperationStartTime = &relistingStartTime
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(relisting)
+
+	idle := getTestClusterServiceBroker()
+	idle.Name = "idle-broker"
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(idle)
+
+	fakeCatalogClient.AddReactor("update", "clusterservicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		e := action.(clientgotesting.UpdateAction)
+		return true, e.GetObject(), nil
+	})
+	testController.monitorStuckRelists()
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], stuck)
+	assertClusterServiceBrokerCondition(t, updatedClusterServiceBroker, v1beta1.ServiceBrokerConditionRelistStuck, v1beta1.ConditionTrue)
+
+	events := getRecordedEvents(testController)
+
+	expectedEventPrefixes := []string{
+		warningEventBuilder(relistStuckReason).String(),
+	}
+
+	if err := checkEventPrefixes(events, expectedEventPrefixes); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestReconcileClusterServiceBrokerWithStatusUpdateError verifies that the reconciler
 // returns an error when there is a conflict updating the status of the resource.
 // This is an otherwise successful scenario where the update to set the
@@ -1280,6 +1869,224 @@ func TestUpdateServiceBrokerCondition(t *testing.T) {
 	}
 }
 
+// TestUpdateServiceBrokerConditionLastRelistRequestProcessed verifies that
+// the relist request of the spec is echoed in the status once the catalog
+// has been fetched successfully.
+func TestUpdateServiceBrokerConditionLastRelistRequestProcessed(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   v1beta1.ConditionStatus
+		expected int64
+	}{
+		{
+			name:     "not ready",
+			status:   v1beta1.ConditionFalse,
+			expected: 1,
+		},
+		{
+			name:     "ready",
+			status:   v1beta1.ConditionTrue,
+			expected: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+			broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionFalse)
+			broker.Spec.RelistRequests = 3
+			broker.Status.LastRelistRequestProcessed = 1
+
+			if err := testController.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, tc.status, "", ""); err != nil {
+				t.Fatalf("error updating broker condition: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+
+			if e, a := tc.expected, updatedClusterServiceBroker.Status.LastRelistRequestProcessed; e != a {
+				t.Fatalf("Unexpected last relist request processed: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+func TestUpdateServiceBrokerConditionCatalogStale(t *testing.T) {
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	recently := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+
+	staleBroker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionFalse, longAgo, longAgo)
+	staleBroker.Status.Conditions = append(staleBroker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+		Type:               v1beta1.ServiceBrokerConditionCatalogStale,
+		Status:             v1beta1.ConditionTrue,
+		LastTransitionTime: longAgo,
+	})
+
+	manualBroker := getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, longAgo, longAgo)
+	manualBroker.Spec.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorManual
+	manualBroker.Spec.RelistDuration = nil
+
+	cases := []struct {
+		name           string
+		input          *v1beta1.ClusterServiceBroker
+		relistMultiple float64
+		status         v1beta1.ConditionStatus
+		expectedStale  v1beta1.ConditionStatus
+	}{
+		{
+			name:           "catalog older than the threshold",
+			input:          getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, longAgo, longAgo),
+			relistMultiple: 3,
+			status:         v1beta1.ConditionFalse,
+			expectedStale:  v1beta1.ConditionTrue,
+		},
+		{
+			name:           "catalog younger than the threshold",
+			input:          getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, recently, recently),
+			relistMultiple: 3,
+			status:         v1beta1.ConditionFalse,
+		},
+		{
+			name:           "condition disabled",
+			input:          getTestClusterServiceBrokerWithStatusAndTime(v1beta1.ConditionTrue, longAgo, longAgo),
+			relistMultiple: 0,
+			status:         v1beta1.ConditionFalse,
+		},
+		{
+			name:           "manual relist behavior",
+			input:          manualBroker,
+			relistMultiple: 3,
+			status:         v1beta1.ConditionFalse,
+		},
+		{
+			name:           "catalog retrieved again",
+			input:          staleBroker,
+			relistMultiple: 3,
+			status:         v1beta1.ConditionTrue,
+			expectedStale:  v1beta1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+			testController.catalogStaleRelistMultiple = tc.relistMultiple
+
+			err := testController.updateClusterServiceBrokerCondition(tc.input, v1beta1.ServiceBrokerConditionReady, tc.status, "", "")
+			if err != nil {
+				t.Fatalf("error updating broker condition: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], tc.input).(*v1beta1.ClusterServiceBroker)
+
+			var stale *v1beta1.ServiceBrokerCondition
+			for i, cond := range updatedClusterServiceBroker.Status.Conditions {
+				if cond.Type == v1beta1.ServiceBrokerConditionCatalogStale {
+					stale = &updatedClusterServiceBroker.Status.Conditions[i]
+				}
+			}
+			switch {
+			case tc.expectedStale == "" && stale != nil:
+				t.Fatalf("unexpected CatalogStale condition: %+v", stale)
+			case tc.expectedStale != "" && stale == nil:
+				t.Fatalf("expected a CatalogStale condition with status %v", tc.expectedStale)
+			case stale != nil && stale.Status != tc.expectedStale:
+				t.Fatalf("unexpected CatalogStale condition status; %s", expectedGot(tc.expectedStale, stale.Status))
+			}
+		})
+	}
+}
+
+func TestUpdateServiceBrokerConditionInsecureTLS(t *testing.T) {
+	insecureBroker := func(conditionStatus v1beta1.ConditionStatus) *v1beta1.ClusterServiceBroker {
+		broker := getTestClusterServiceBroker()
+		broker.Spec.InsecureSkipTLSVerify = true
+		if conditionStatus != "" {
+			broker.Status.Conditions = append(broker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+				Type:   v1beta1.ServiceBrokerConditionInsecureTLS,
+				Status: conditionStatus,
+			})
+		}
+		return broker
+	}
+	verifiedBroker := insecureBroker(v1beta1.ConditionTrue)
+	verifiedBroker.Spec.InsecureSkipTLSVerify = false
+
+	cases := []struct {
+		name             string
+		input            *v1beta1.ClusterServiceBroker
+		expectedInsecure v1beta1.ConditionStatus
+		expectedEvent    bool
+	}{
+		{
+			name:  "TLS verified",
+			input: getTestClusterServiceBroker(),
+		},
+		{
+			name:             "insecureSkipTLSVerify set",
+			input:            insecureBroker(""),
+			expectedInsecure: v1beta1.ConditionTrue,
+			expectedEvent:    true,
+		},
+		{
+			name:             "insecureSkipTLSVerify still set",
+			input:            insecureBroker(v1beta1.ConditionTrue),
+			expectedInsecure: v1beta1.ConditionTrue,
+		},
+		{
+			name:             "insecureSkipTLSVerify unset",
+			input:            verifiedBroker,
+			expectedInsecure: v1beta1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+			err := testController.updateClusterServiceBrokerCondition(tc.input, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, "", "")
+			if err != nil {
+				t.Fatalf("error updating broker condition: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], tc.input).(*v1beta1.ClusterServiceBroker)
+
+			var insecure *v1beta1.ServiceBrokerCondition
+			for i, cond := range updatedClusterServiceBroker.Status.Conditions {
+				if cond.Type == v1beta1.ServiceBrokerConditionInsecureTLS {
+					insecure = &updatedClusterServiceBroker.Status.Conditions[i]
+				}
+			}
+			switch {
+			case tc.expectedInsecure == "" && insecure != nil:
+				t.Fatalf("unexpected InsecureTLS condition: %+v", insecure)
+			case tc.expectedInsecure != "" && insecure == nil:
+				t.Fatalf("expected an InsecureTLS condition with status %v", tc.expectedInsecure)
+			case insecure != nil && insecure.Status != tc.expectedInsecure:
+				t.Fatalf("unexpected InsecureTLS condition status; %s", expectedGot(tc.expectedInsecure, insecure.Status))
+			}
+
+			events := getRecordedEvents(testController)
+			if tc.expectedEvent {
+				expectedEvent := corev1.EventTypeWarning + " " + insecureTLSReason + " " + insecureTLSMessage
+				if len(events) != 1 || events[0] != expectedEvent {
+					t.Fatalf("Received unexpected events, %s", expectedGot([]string{expectedEvent}, events))
+				}
+			} else if len(events) != 0 {
+				t.Fatalf("Received unexpected events: %v", events)
+			}
+		})
+	}
+}
+
 func TestReconcileClusterServicePlanFromClusterServiceBrokerCatalog(t *testing.T) {
 	updatedPlan := func() *v1beta1.ClusterServicePlan {
 		p := getTestClusterServicePlan()
@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"strconv"
 	"sync"
 	"time"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
@@ -40,7 +42,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 )
 
@@ -59,6 +60,7 @@ const (
 	errorErrorCallingProvisionReason           string = "ErrorCallingProvision"
 	errorUpdateInstanceCallFailedReason        string = "UpdateInstanceCallFailed"
 	errorErrorCallingUpdateInstanceReason      string = "ErrorCallingUpdateInstance"
+	errorProvisionRequestNotSentReason         string = "ProvisionRequestNotSent"
 	errorDeprovisionCallFailedReason           string = "DeprovisionCallFailed"
 	errorDeprovisionBlockedByCredentialsReason string = "DeprovisionBlockedByExistingCredentials"
 	errorPollingLastOperationReason            string = "ErrorPollingLastOperation"
@@ -78,6 +80,7 @@ const (
 	errorFindingNamespaceServiceInstanceReason string = "ErrorFindingNamespaceForInstance"
 	errorOrphanMitigationFailedReason          string = "OrphanMitigationFailed"
 	errorInvalidDeprovisionStatusReason        string = "InvalidDeprovisionStatus"
+	errorLastOperationGoneReason               string = "LastOperationGone"
 
 	errorAmbiguousPlanReferenceScope string = "couldn't determine if the instance refers to a Cluster or Namespaced ServiceClass/Plan"
 
@@ -97,11 +100,45 @@ const (
 	deprovisioningInFlightMessage           string = "Deprovision request for ServiceInstance in-flight to Broker"
 	startingInstanceOrphanMitigationReason  string = "StartingInstanceOrphanMitigation"
 	startingInstanceOrphanMitigationMessage string = "The instance provision call failed with an ambiguous error; attempting to deprovision the instance in order to mitigate an orphaned resource"
-
-	clusterIdentifierKey string = "clusterid"
+	pausedReason                            string = "ReconciliationPaused"
+	pausedMessage                           string = "Reconciliation of the instance is paused by the " + v1beta1.ServiceInstancePausedAnnotation + " annotation"
+	resumedReason                           string = "ReconciliationResumed"
+	resumedMessage                          string = "Reconciliation of the instance has resumed"
+	namespaceDeletionReason                 string = "DeprovisioningForNamespaceDeletion"
+	namespaceDeletionMessage                string = "The namespace %q is being deleted and waits for the instance to be deprovisioned; if the deprovisioning fails, set the " + v1beta1.ServiceInstanceForceOrphanAnnotation + " annotation to \"true\" to remove the instance without deprovisioning it"
+	namespaceDeletionTimedOutReason         string = "NamespaceDeletionDeprovisionTimedOut"
+	namespaceDeletionTimedOutMessage        string = "Stopped retrying to deprovision the instance %v after the deletion of the namespace %q started; set the " + v1beta1.ServiceInstanceForceOrphanAnnotation + " annotation to \"true\" to remove the instance without deprovisioning it"
+	deprovisionTimedOutReason               string = "DeprovisionTimedOut"
+	deprovisionTimedOutMessage              string = "The deprovisioning of the instance has been failing for %v since the instance was deleted, longer than its deprovision timeout of %v; still retrying. Set the " + v1beta1.ServiceInstanceForceOrphanAnnotation + " annotation to \"true\" to remove the instance without it being deprovisioned at the broker"
+	forceOrphanedReason                     string = "ForceOrphaned"
+	forceOrphanedMessage                    string = "The instance was removed %v after its deletion without being deprovisioned at the broker, because of the " + v1beta1.ServiceInstanceForceOrphanAnnotation + " annotation; its resources at the broker may be orphaned"
+	forceOrphanBlockedMessage               string = "The instance is not removed despite the " + v1beta1.ServiceInstanceForceOrphanAnnotation + " annotation: %v"
+	secretParametersChangedReason           string = "SecretParametersChanged"
+	secretParametersChangedMessage          string = "The secrets referenced by spec.secretParameterRefs changed; updating the instance"
+	requestContextChangedReason             string = "RequestContextChanged"
+	requestContextChangedMessage            string = "The OSB context of the instance changed; updating the instance"
+	operationTimedOutReason                 string = "OperationTimedOut"
+	operationTimedOutMessage                string = "Stopped polling the asynchronous operation of the instance because it did not complete within %v"
+	noProvisionedResourceReason             string = "NoProvisionedResource"
+	noProvisionedResourceMessage            string = "The instance was never provisioned by the broker; it was removed without sending a deprovision request"
+	updateNotNeededReason                   string = "UpdateNotNeeded"
+	updateNotNeededMessage                  string = "The plan, parameters and context of the instance are unchanged from those last sent to the broker; no update request was sent"
+
+	clusterIdentifierKey      string = "clusterid"
+	namespaceLabelsContextKey string = "namespace_labels"
 
 	minBrokerOperationRetryDelay time.Duration = time.Second * 1
-	maxBrokerOperationRetryDelay time.Duration = time.Minute * 20
+
+	// namespaceLabelsChangeDelay is the default delay before the instances
+	// of a namespace whose labels changed are reconciled, so that a burst of
+	// label changes results in a single update of each instance
+	namespaceLabelsChangeDelay time.Duration = time.Second * 10
+
+	// maxLastOperationDescriptionLength is the maximum number of characters
+	// of the description of the last operation recorded in the status of an
+	// instance, including truncatedSuffix
+	maxLastOperationDescriptionLength = 256
+	truncatedSuffix                   = "..."
 
 	eventHandlerLogLevel = 4 // TODO: move all logLevel settings to a central location
 )
@@ -116,7 +153,7 @@ type instanceOperationBackoff struct {
 	// lock to be used for accessing retry map
 	mutex       sync.RWMutex
 	instances   map[string]backoffEntry // Key is K8s metadata UID
-	rateLimiter workqueue.RateLimiter   // used to calculate next retry time, key is UID
+	rateLimiter *brokerRetryRateLimiter // used to calculate next retry time, key is UID
 }
 
 // ServiceInstance handlers and control-loop
@@ -161,6 +198,15 @@ func (c *controller) instanceUpdate(oldObj, newObj interface{}) {
 		klog.Info(pcb.Messagef("Received UPDATE event: %v", toJSON(instance)))
 	}
 
+	// Polling stops while an instance is paused, so an instance which has
+	// just been resumed has to be enqueued even if an asynchronous operation
+	// is in progress.
+	if oldInstance, ok := oldObj.(*v1beta1.ServiceInstance); ok && isServiceInstancePaused(oldInstance) && !isServiceInstancePaused(instance) {
+		klog.V(eventHandlerLogLevel).Info(pcb.Message("Enqueueing instance because it was resumed"))
+		c.enqueueInstance(newObj)
+		return
+	}
+
 	// Instances with ongoing asynchronous operations will be manually added
 	// to the polling queue by the reconciler. They should be ignored here in
 	// order to enforce polling rate-limiting.
@@ -187,6 +233,70 @@ func (c *controller) instanceDelete(obj interface{}) {
 	}
 }
 
+// secretUpdate handles the Secret UPDATED watch event. The instances in the
+// namespace of the secret which reference it in spec.secretParameterRefs are
+// enqueued so that the changed parameters are sent to the broker.
+func (c *controller) secretUpdate(oldObj, newObj interface{}) {
+	oldSecret, ok := oldObj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	secret, ok := newObj.(*corev1.Secret)
+	if !ok || reflect.DeepEqual(oldSecret.Data, secret.Data) {
+		return
+	}
+
+	instances, err := c.instanceLister.ServiceInstances(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Couldn't list instances referencing secret %s/%s: %v", secret.Namespace, secret.Name, err)
+		return
+	}
+	for _, instance := range instances {
+		if referencesSecretParameter(instance, secret.Name) {
+			pcb := pretty.NewInstanceContextBuilder(instance)
+			klog.V(eventHandlerLogLevel).Info(pcb.Messagef("Enqueueing instance because secret %q changed", secret.Name))
+			c.enqueueInstance(instance)
+		}
+	}
+}
+
+// namespaceUpdate enqueues the instances of a namespace whose labels changed,
+// because the labels are part of the OSB context of the instances. The
+// instances are enqueued after a delay, which coalesces the changes made in
+// the meantime.
+func (c *controller) namespaceUpdate(oldObj, newObj interface{}) {
+	oldNamespace, ok := oldObj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	namespace, ok := newObj.(*corev1.Namespace)
+	if !ok || reflect.DeepEqual(oldNamespace.Labels, namespace.Labels) {
+		return
+	}
+
+	instances, err := c.instanceLister.ServiceInstances(namespace.Name).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Couldn't list instances of namespace %s: %v", namespace.Name, err)
+		return
+	}
+	for _, instance := range instances {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.V(eventHandlerLogLevel).Info(pcb.Message("Enqueueing instance because the labels of its namespace changed"))
+		c.enqueueInstanceAfter(instance, c.namespaceLabelsChangeDelay)
+	}
+}
+
+// referencesSecretParameter returns whether the instance sources parameters
+// from the secret with the given name through spec.secretParameterRefs.
+func referencesSecretParameter(instance *v1beta1.ServiceInstance, secretName string) bool {
+	for _, ref := range instance.Spec.SecretParameterRefs {
+		if ref.SecretKeyRef.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
 // Async operations on instances have a somewhat convoluted flow in order to
 // ensure that only a single goroutine works on an instance at any given time.
 // The flow is:
@@ -309,6 +419,12 @@ func (c *controller) reconcileServiceInstanceKey(key string) error {
 // error is returned to indicate that the instance has not been fully
 // processed and should be resubmitted at a later time.
 func (c *controller) reconcileServiceInstance(instance *v1beta1.ServiceInstance) error {
+	if isServiceInstancePaused(instance) {
+		return c.pauseServiceInstance(instance)
+	}
+	if isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionPaused) {
+		return c.resumeServiceInstance(instance)
+	}
 	updated, err := c.initObservedGeneration(instance)
 	if err != nil {
 		return err
@@ -345,6 +461,44 @@ func (c *controller) reconcileServiceInstance(instance *v1beta1.ServiceInstance)
 	}
 }
 
+// pauseServiceInstance records that the instance is paused. No other work
+// is done on the instance until the paused annotation is removed.
+func (c *controller) pauseServiceInstance(instance *v1beta1.ServiceInstance) error {
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	if isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionPaused) {
+		klog.V(4).Info(pcb.Message("Not reconciling instance because it is paused"))
+		return nil
+	}
+
+	instance = instance.DeepCopy()
+	c.recorder.Event(instance, corev1.EventTypeNormal, pausedReason, pausedMessage)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionPaused,
+		v1beta1.ConditionTrue,
+		pausedReason,
+		pausedMessage)
+
+	_, err := c.updateServiceInstanceStatus(instance)
+	return err
+}
+
+// resumeServiceInstance removes the Paused condition from an instance whose
+// paused annotation has been removed. The status update adds the instance
+// back to the queue, except when an asynchronous operation is in progress, in
+// which case polling of the operation is restarted.
+func (c *controller) resumeServiceInstance(instance *v1beta1.ServiceInstance) error {
+	instance = instance.DeepCopy()
+	c.recorder.Event(instance, corev1.EventTypeNormal, resumedReason, resumedMessage)
+	removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionPaused)
+
+	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+		return err
+	}
+	if instance.Status.AsyncOpInProgress {
+		return c.beginPollingServiceInstance(instance)
+	}
+	return nil
+}
+
 // initObservedGeneration implements ObservedGeneration initialization based on
 // ReconciledGeneration for status API migration.
 // Returns true if the status was updated (i.e. the iteration has finished and no
@@ -443,7 +597,7 @@ func (c *controller) backoffAndRequeueIfRetrying(instance *v1beta1.ServiceInstan
 		}
 		if retryEntry.dirty {
 			// calculate earliest retry time with exponential backoff
-			retryEntry.calculatedRetryTime = time.Now().Add(c.instanceOperationRetryQueue.rateLimiter.When(key))
+			retryEntry.calculatedRetryTime = time.Now().Add(c.instanceOperationRetryQueue.rateLimiter.whenWithBackoff(key, c.instanceOperationsRetryBackoff(instance)))
 			retryEntry.dirty = false
 			c.instanceOperationRetryQueue.instances[key] = retryEntry
 			klog.V(4).Infof(pcb.Messagef("BrokerOpRetry: generation %v retryTime calculated as %v", instance.Generation, retryEntry.calculatedRetryTime))
@@ -475,9 +629,9 @@ func (c *controller) purgeExpiredRetryEntries() {
 
 	// Ensure we only purge items that aren't being acted on by retries.
 	// Due to queues and potential delays, only remove entries that are at
-	// least maxBrokerOperationRetryDelay past next retry time to ensure
+	// least operationRetryMaximumBackoffDuration past next retry time to ensure
 	// entries are not prematurely removed
-	overDue := now.Add(-maxBrokerOperationRetryDelay)
+	overDue := now.Add(-c.operationRetryMaximumBackoffDuration)
 	purgedEntries := 0
 	for k, v := range c.instanceOperationRetryQueue.instances {
 		if v.calculatedRetryTime.Before(overDue) {
@@ -616,8 +770,17 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		prettyClass, brokerName,
 	))
 
+	response, err := brokerhttp.ProvisionInstance(brokerClient, request)
+	if c.retryAsAsyncOperation(instance, request.AcceptsIncomplete, true, err) {
+		asyncRequest := *request
+		asyncRequest.AcceptsIncomplete = true
+		request = &asyncRequest
+		response, err = brokerhttp.ProvisionInstance(brokerClient, request)
+	}
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	c.setRetryBackoffRequired(instance)
-	response, err := brokerClient.ProvisionInstance(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf(
@@ -646,6 +809,14 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 			return c.processTemporaryProvisionFailure(instance, readyCond, true)
 		}
 
+		// A request that never reached the broker did not create anything
+		// that would have to be deprovisioned, unless an earlier attempt
+		// may have.
+		if isRequestNotSentError(err) && !hasAmbiguousProvisionAttempt(instance) {
+			reason = errorProvisionRequestNotSentReason
+			instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusNotRequired
+		}
+
 		// All other errors should be retried, unless the
 		// reconciliation retry time limit has passed.
 		msg := fmt.Sprintf("The provision call failed and will be retried: Error communicating with broker for provisioning: %v", err)
@@ -660,8 +831,10 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		return c.processServiceInstanceOperationError(instance, readyCond)
 	}
 
+	setServiceInstanceProvisionMetadata(instance, response.Metadata)
+	instance.Status.StateToken = response.StateToken
 	if response.Async {
-		return c.processProvisionAsyncResponse(instance, response)
+		return c.processProvisionAsyncResponse(instance, &response.ProvisionResponse)
 	}
 
 	return c.processProvisionSuccess(instance, response.DashboardURL)
@@ -673,6 +846,13 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	pcb := pretty.NewInstanceContextBuilder(instance)
 
 	if isServiceInstanceProcessedAlready(instance) {
+		if c.secretParametersChanged(instance) {
+			return c.requestUpdateForSecretParameters(instance)
+		}
+		if c.requestContextChanged(instance) {
+			return c.requestUpdateForRequestContext(instance)
+		}
+		c.scheduleParametersResync(instance)
 		klog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
 		return nil
 	}
@@ -702,6 +882,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	klog.V(4).Info(pcb.Message("Processing updating event"))
 
 	var brokerClient osb.Client
+	var instancesRetrievable bool
 	var request *osb.UpdateInstanceRequest
 
 	if instance.Spec.ClusterServiceClassSpecified() {
@@ -712,6 +893,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		}
 
 		brokerClient = bClient
+		instancesRetrievable = serviceClass.Spec.InstancesRetrievable
 
 		// Check if the ServiceClass or ServicePlan has been deleted. If so, do
 		// not allow plan upgrades, but do allow parameter changes.
@@ -725,6 +907,10 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		}
 		request = req
 
+		if isServiceInstanceUpdateNoOp(instance, request, inProgressProperties) {
+			return c.processServiceInstanceUpdateNoOp(instance)
+		}
+
 		if instance.Status.CurrentOperation == "" || !isServiceInstancePropertiesStateEqual(instance.Status.InProgressProperties, inProgressProperties) {
 			updatedInstance, err := c.recordStartOfServiceInstanceOperation(instance, v1beta1.ServiceInstanceOperationUpdate, inProgressProperties)
 			if err != nil {
@@ -752,6 +938,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		}
 
 		brokerClient = bClient
+		instancesRetrievable = serviceClass.Spec.InstancesRetrievable
 
 		// Check if the ServiceClass or ServicePlan has been deleted. If so, do
 		// not allow plan upgrades, but do allow parameter changes.
@@ -765,6 +952,10 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		}
 		request = req
 
+		if isServiceInstanceUpdateNoOp(instance, request, inProgressProperties) {
+			return c.processServiceInstanceUpdateNoOp(instance)
+		}
+
 		if instance.Status.CurrentOperation == "" || !isServiceInstancePropertiesStateEqual(instance.Status.InProgressProperties, inProgressProperties) {
 			updatedInstance, err := c.recordStartOfServiceInstanceOperation(instance, v1beta1.ServiceInstanceOperationUpdate, inProgressProperties)
 			if err != nil {
@@ -794,8 +985,17 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		instance.ResourceVersion = updatedInstance.ResourceVersion
 	}
 
+	response, err := c.updateServiceInstanceAtBroker(instance, instancesRetrievable, brokerClient, request)
+	if c.retryAsAsyncOperation(instance, request.AcceptsIncomplete, true, err) {
+		asyncRequest := *request
+		asyncRequest.AcceptsIncomplete = true
+		request = &asyncRequest
+		response, err = c.updateServiceInstanceAtBroker(instance, instancesRetrievable, brokerClient, request)
+	}
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	c.setRetryBackoffRequired(instance)
-	response, err := brokerClient.UpdateInstance(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			if isRetriableHTTPStatus(httpErr.StatusCode) {
@@ -842,8 +1042,11 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 			instance.Status.DashboardURL = response.DashboardURL
 		}
 	}
+	if response.StateToken != "" {
+		instance.Status.StateToken = response.StateToken
+	}
 	if response.Async {
-		return c.processUpdateServiceInstanceAsyncResponse(instance, response)
+		return c.processUpdateServiceInstanceAsyncResponse(instance, &response.UpdateInstanceResponse)
 	}
 
 	return c.processUpdateServiceInstanceSuccess(instance)
@@ -860,6 +1063,9 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 	// If deprovisioning has already failed, do not do anything more
 	if instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusFailed {
+		if handled, err := c.reconcileServiceInstanceDeprovisionTimeout(instance.DeepCopy()); handled {
+			return err
+		}
 		klog.V(4).Info(pcb.Message("Not processing deleting event because deprovisioning has failed"))
 		return nil
 	}
@@ -883,6 +1089,11 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	if instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusNotRequired ||
 		instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusSucceeded {
 
+		if instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusNotRequired &&
+			instance.Status.ProvisionStatus != v1beta1.ServiceInstanceProvisionStatusProvisioned {
+			klog.V(4).Info(pcb.Message("Not deprovisioning because the instance was never provisioned"))
+			setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionFalse, noProvisionedResourceReason, noProvisionedResourceMessage)
+		}
 		return c.processServiceInstanceGracefulDeletionSuccess(instance)
 	}
 
@@ -905,6 +1116,10 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		return c.processDeprovisionFailure(instance, readyCond, failedCond)
 	}
 
+	if handled, err := c.reconcileServiceInstanceNamespaceDeletion(instance); handled {
+		return err
+	}
+
 	// We don't want to delete the instance if there are any bindings associated.
 	if err := c.checkServiceInstanceHasExistingBindings(instance); err != nil {
 		// if the CascadingDeletion feature flag is set, delete existing bindings instead of update the status with an error
@@ -922,6 +1137,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	var prettyName string
 	var brokerName string
 	var brokerClient osb.Client
+	var instancesRetrievable bool
 	if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, name, bClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 		if err != nil {
@@ -930,7 +1146,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 		brokerName = name
 		brokerClient = bClient
-		// we need the serviceClass SOLELY to get a value for a msg string >:(
+		instancesRetrievable = serviceClass.Spec.InstancesRetrievable
 		prettyName = pretty.ClusterServiceClassName(serviceClass)
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, name, bClient, err := c.getServiceClassAndServiceBroker(instance)
@@ -940,10 +1156,14 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 		brokerName = name
 		brokerClient = bClient
-		// we need the serviceClass SOLELY to get a value for a msg string >:(
+		instancesRetrievable = serviceClass.Spec.InstancesRetrievable
 		prettyName = pretty.ServiceClassName(serviceClass)
 	}
 
+	if handled, err := c.reconcileServiceInstanceOrphanMitigationGrace(instance, instancesRetrievable, brokerClient); handled {
+		return err
+	}
+
 	request, inProgressProperties, err := c.prepareDeprovisionRequest(instance)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
@@ -979,7 +1199,16 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	}
 
 	klog.V(4).Info(pcb.Message("Sending deprovision request to broker"))
-	response, err := brokerClient.DeprovisionInstance(request)
+	response, err := c.deprovisionServiceInstanceAtBroker(instance, instancesRetrievable, brokerClient, request)
+	if c.retryAsAsyncOperation(instance, request.AcceptsIncomplete, true, err) {
+		asyncRequest := *request
+		asyncRequest.AcceptsIncomplete = true
+		request = &asyncRequest
+		response, err = c.deprovisionServiceInstanceAtBroker(instance, instancesRetrievable, brokerClient, request)
+	}
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error deprovisioning, %s at ClusterServiceBroker %q: %v`,
@@ -999,7 +1228,108 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	return c.processDeprovisionSuccess(instance)
 }
 
+// reconcileServiceInstanceNamespaceDeletion handles an instance that has to
+// be deprovisioned while its namespace is being deleted. The deletion of the
+// namespace waits for the finalizer of the instance, so the instance gets the
+// NamespaceDeletion condition and an event explaining it, once. When
+// namespaceDeletionDeprovisionTimeout has elapsed since the deletion of the
+// namespace started, the deprovisioning fails instead of being retried. It
+// returns true when the instance was handled and the reconciliation stops
+// there.
+func (c *controller) reconcileServiceInstanceNamespaceDeletion(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.DeletionTimestamp == nil {
+		return false, nil
+	}
+	namespace, err := c.namespaceLister.Get(instance.Namespace)
+	if err != nil || namespace.DeletionTimestamp == nil {
+		return false, nil
+	}
+
+	if c.namespaceDeletionDeprovisionTimeout > 0 && time.Since(namespace.DeletionTimestamp.Time) > c.namespaceDeletionDeprovisionTimeout {
+		msg := fmt.Sprintf(namespaceDeletionTimedOutMessage, c.namespaceDeletionDeprovisionTimeout, namespace.Name)
+		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, namespaceDeletionTimedOutReason, msg)
+		failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, namespaceDeletionTimedOutReason, msg)
+		return true, c.processDeprovisionFailure(instance, readyCond, failedCond)
+	}
+
+	if isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionNamespaceDeletion) {
+		return false, nil
+	}
+
+	msg := fmt.Sprintf(namespaceDeletionMessage, namespace.Name)
+	c.recorder.Event(instance, corev1.EventTypeNormal, namespaceDeletionReason, msg)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionNamespaceDeletion,
+		v1beta1.ConditionTrue,
+		namespaceDeletionReason,
+		msg)
+
+	// The updated instance will be automatically added back to the queue
+	// and processed again
+	_, err = c.updateServiceInstanceStatus(instance)
+	return true, err
+}
+
+// reconcileServiceInstanceDeprovisionTimeout handles a deleted instance
+// whose deprovisioning keeps failing. Once the deprovision timeout of the
+// instance has elapsed since its deletion, a warning event reports for how
+// long the deprovisioning has been failing, and the deprovisioning is retried
+// as before. Only when the instance also has the force-orphan annotation, and
+// its deprovisioning either timed out or failed for good, is its finalizer
+// removed, leaving the resources at the broker possibly orphaned. While a
+// ServiceBinding still references the instance, the finalizer is kept and the
+// instance gets the DeprovisionBlockedByExistingCredentials reason instead. It
+// returns true when the instance was handled and the reconciliation stops
+// there.
+func (c *controller) reconcileServiceInstanceDeprovisionTimeout(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.DeletionTimestamp == nil {
+		return false, nil
+	}
+	elapsed := time.Since(instance.DeletionTimestamp.Time)
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	// A deprovisioning that failed for good is not retried, so the
+	// annotation is honored right away; one that is still retried only
+	// once its timeout has elapsed.
+	if instance.Status.DeprovisionStatus != v1beta1.ServiceInstanceDeprovisionStatusFailed {
+		timeout := c.deprovisionTimeoutOf(instance)
+		if timeout == 0 || elapsed <= timeout {
+			return false, nil
+		}
+		if !isServiceInstanceForceOrphan(instance) {
+			msg := fmt.Sprintf(deprovisionTimedOutMessage, elapsed.Round(time.Second), timeout)
+			klog.Warning(pcb.Message(msg))
+			c.recorder.Event(instance, corev1.EventTypeWarning, deprovisionTimedOutReason, msg)
+			return false, nil
+		}
+	} else if !isServiceInstanceForceOrphan(instance) {
+		return false, nil
+	}
+
+	// As when deprovisioning, the bindings of the instance must be removed
+	// first, or they would be left referencing an instance that no longer
+	// exists.
+	if err := c.checkServiceInstanceHasExistingBindings(instance); err != nil {
+		if _, ok := err.(*operationError); ok {
+			err = &operationError{
+				reason:  errorDeprovisionBlockedByCredentialsReason,
+				message: fmt.Sprintf(forceOrphanBlockedMessage, err),
+			}
+		}
+		return true, c.handleServiceInstanceReconciliationError(instance, err)
+	}
+
+	msg := fmt.Sprintf(forceOrphanedMessage, elapsed.Round(time.Second))
+	klog.Warning(pcb.Message(msg))
+	c.recorder.Event(instance, corev1.EventTypeWarning, forceOrphanedReason, msg)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionUnknown, forceOrphanedReason, msg)
+	return true, c.processServiceInstanceGracefulDeletionSuccess(instance)
+}
+
 func (c *controller) processDeprovisionError(instance *v1beta1.ServiceInstance, msg string) error {
+	if handled, err := c.reconcileServiceInstanceDeprovisionTimeout(instance); handled {
+		return err
+	}
+
 	readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCallFailedReason, msg)
 
 	if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
@@ -1043,6 +1373,9 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
 	response, err := brokerClient.PollLastOperation(request)
+	if isBrokerRequestLimitError(err) {
+		return err
+	}
 	if err != nil {
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec
@@ -1053,6 +1386,18 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 			return c.finishPollingServiceInstance(instance)
 		}
 
+		// For provision and update, a http.StatusGone means that the broker
+		// does not know the operation. This is terminal: a provision may
+		// have left an instance behind at the broker, so orphan mitigation
+		// is started, while a failed update leaves the instance as it was.
+		if osb.IsGoneError(err) {
+			message := fmt.Sprintf("The broker does not know the last operation of the instance anymore: %v", err)
+			klog.V(4).Info(pcb.Message(message))
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorLastOperationGoneReason, message)
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorLastOperationGoneReason, message)
+			return c.processServiceInstancePollingTerminalFailure(instance, readyCond, failedCond)
+		}
+
 		reason := errorPollingLastOperationReason
 		message := fmt.Sprintf("Error polling last operation: %v", err)
 		klog.V(4).Info(pcb.Message(message))
@@ -1103,13 +1448,29 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		}
 
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
+		if c.asyncOperationTimedOut(instance) {
+			msg := fmt.Sprintf(operationTimedOutMessage, c.asyncOperationTimeoutOf(instance))
+			klog.Warning(pcb.Message(msg))
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, operationTimedOutReason, msg)
+			return c.processServiceInstancePollingTerminalFailure(instance, readyCond, failedCond)
+		}
 		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 		}
 
-		// only need to update the resource if there was a description for the operation provided
+		lastOperationDescription := ""
 		if response.Description != nil {
-			c.recorder.Event(instance, corev1.EventTypeNormal, readyCond.Reason, readyCond.Message)
+			lastOperationDescription = truncateLastOperationDescription(*response.Description)
+		}
+		descriptionChanged := instance.Status.LastOperationDescription != lastOperationDescription
+		instance.Status.LastOperationDescription = lastOperationDescription
+
+		// only need to update the resource if there was a description for the operation provided
+		// or if the description of the previous poll has to be cleared
+		if response.Description != nil || descriptionChanged {
+			if response.Description != nil {
+				c.recorder.Event(instance, corev1.EventTypeNormal, readyCond.Reason, readyCond.Message)
+			}
 
 			setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, readyCond.Status, readyCond.Reason, readyCond.Message)
 			if _, err := c.updateServiceInstanceStatus(instance); err != nil {
@@ -1187,6 +1548,19 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 func clearServiceInstanceAsyncOsbOperation(instance *v1beta1.ServiceInstance) {
 	instance.Status.AsyncOpInProgress = false
 	instance.Status.LastOperation = nil
+	instance.Status.LastOperationDescription = ""
+}
+
+// truncateLastOperationDescription truncates the description of the last
+// operation returned by a broker to maxLastOperationDescriptionLength
+// characters, so that a verbose broker does not bloat the status of the
+// instance.
+func truncateLastOperationDescription(description string) string {
+	runes := []rune(description)
+	if len(runes) <= maxLastOperationDescriptionLength {
+		return description
+	}
+	return string(runes[:maxLastOperationDescriptionLength-len(truncatedSuffix)]) + truncatedSuffix
 }
 
 // isServiceInstanceProcessedAlready returns true if there is no further processing
@@ -1199,6 +1573,193 @@ func isServiceInstanceProcessedAlready(instance *v1beta1.ServiceInstance) bool {
 		!instance.Status.OrphanMitigationInProgress
 }
 
+// secretParametersChanged returns whether the parameters of a ready instance
+// which sources parameters from spec.secretParameterRefs no longer match the
+// parameters last sent to the broker, for example because one of the
+// referenced secrets was rotated. The secrets of spec.parametersFrom are only
+// compared when the periodic read of the parameters is enabled for the
+// instance.
+func (c *controller) secretParametersChanged(instance *v1beta1.ServiceInstance) bool {
+	if instance.Status.ExternalProperties == nil || !isServiceInstanceReady(instance) {
+		return false
+	}
+	if len(instance.Spec.SecretParameterRefs) == 0 &&
+		(len(instance.Spec.ParametersFrom) == 0 || c.parametersResyncIntervalOf(instance) == 0) {
+		return false
+	}
+
+	specParameters, err := instanceSpecParameters(instance)
+	if err != nil {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Unable to check the secret parameters for changes: %v", err))
+		return false
+	}
+	_, parametersChecksum, _, err := prepareInProgressPropertyParameters(
+		c.kubeClient,
+		instance.Namespace,
+		specParameters,
+		instanceParametersFrom(instance),
+	)
+	if err != nil {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Unable to check the secret parameters for changes: %v", err))
+		return false
+	}
+	return parametersChecksum != instance.Status.ExternalProperties.ParameterChecksum
+}
+
+// scheduleParametersResync queues the given ready instance again once its
+// parameters resync interval elapsed, so that its parametersFrom sources are
+// read again even when no Secret event is received. The work queue coalesces
+// the instance with the reconciliations triggered by Secret events, and an
+// update is only requested by secretParametersChanged once the latest
+// generation of the instance was reconciled, so a changed Secret results in
+// a single update whichever path notices it first.
+func (c *controller) scheduleParametersResync(instance *v1beta1.ServiceInstance) {
+	if len(instance.Spec.ParametersFrom) == 0 && len(instance.Spec.SecretParameterRefs) == 0 {
+		return
+	}
+	if !isServiceInstanceReady(instance) {
+		return
+	}
+	interval := c.parametersResyncIntervalOf(instance)
+	if interval == 0 {
+		return
+	}
+	c.enqueueInstanceAfter(instance, interval)
+}
+
+// requestUpdateForSecretParameters increments spec.updateRequests of the
+// instance so that the changed secret parameters are sent to the broker in
+// an update request.
+func (c *controller) requestUpdateForSecretParameters(instance *v1beta1.ServiceInstance) error {
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Message("Requesting an update because the secret parameters changed"))
+
+	toUpdate := instance.DeepCopy()
+	toUpdate.Spec.UpdateRequests++
+	if _, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).Update(toUpdate); err != nil {
+		klog.Error(pcb.Messagef("Failed to request an update for the changed secret parameters: %v", err))
+		return err
+	}
+
+	c.recorder.Event(instance, corev1.EventTypeNormal, secretParametersChangedReason, secretParametersChangedMessage)
+	return nil
+}
+
+// requestContextChanged returns whether the OSB context of a ready instance
+// no longer matches the context last sent to its broker, for example because
+// the labels of its namespace changed. Only brokers that accept the context
+// in update requests are considered, and only for classes whose instances
+// may be updated.
+func (c *controller) requestContextChanged(instance *v1beta1.ServiceInstance) bool {
+	if instance.Status.ExternalProperties == nil || !isServiceInstanceReady(instance) {
+		return false
+	}
+	// Instances provisioned before the checksum of the context was recorded
+	// are not updated until something else changes
+	if instance.Status.ExternalProperties.ContextChecksum == "" {
+		return false
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	var brokerKey BrokerKey
+	var planUpdatable bool
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, brokerName, _, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Unable to check the context for changes: %v", err))
+			return false
+		}
+		brokerKey = NewClusterServiceBrokerKey(brokerName)
+		planUpdatable = serviceClass.Spec.PlanUpdatable
+	} else {
+		serviceClass, brokerName, _, err := c.getServiceClassAndServiceBroker(instance)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Unable to check the context for changes: %v", err))
+			return false
+		}
+		brokerKey = NewServiceBrokerKey(instance.Namespace, brokerName)
+		planUpdatable = serviceClass.Spec.PlanUpdatable
+	}
+	if !c.brokerUpdatesContext(instance, brokerKey.name) {
+		return false
+	}
+	// Brokers are only asked to update instances of classes that advertise
+	// plan_updateable, as the others may reject any update
+	if !planUpdatable {
+		klog.V(4).Info(pcb.Message("Not checking the context for changes because the class of the instance is not plan updatable"))
+		return false
+	}
+	// The OSB client only sends the context of update requests from
+	// version 2.12 of the API on
+	if apiVersion, ok := c.brokerClientManager.BrokerAPIVersion(brokerKey); !ok || !apiVersion.AtLeast(osb.Version2_12()) {
+		return false
+	}
+
+	ns, err := c.namespaceLister.Get(instance.Namespace)
+	if err != nil {
+		klog.V(4).Info(pcb.Messagef("Unable to check the context for changes: %v", err))
+		return false
+	}
+	contextChecksum, err := generateChecksumOfParameters(c.buildRequestContext(instance, ns))
+	if err != nil {
+		klog.Warning(pcb.Messagef("Unable to check the context for changes: %v", err))
+		return false
+	}
+	return contextChecksum != instance.Status.ExternalProperties.ContextChecksum
+}
+
+// brokerUpdatesContext returns whether the OSB context is sent in the update
+// requests of the instances of the given broker. The update-context
+// annotation of the broker overrides the setting of the controller.
+func (c *controller) brokerUpdatesContext(instance *v1beta1.ServiceInstance, brokerName string) bool {
+	var annotations map[string]string
+	if instance.Spec.ClusterServiceClassSpecified() {
+		broker, err := c.clusterServiceBrokerLister.Get(brokerName)
+		if err != nil {
+			return c.osbAPIUpdateContext
+		}
+		annotations = broker.Annotations
+	} else if c.serviceBrokerLister != nil {
+		broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(brokerName)
+		if err != nil {
+			return c.osbAPIUpdateContext
+		}
+		annotations = broker.Annotations
+	}
+
+	value, ok := annotations[v1beta1.ServiceBrokerUpdateContextAnnotation]
+	if !ok {
+		return c.osbAPIUpdateContext
+	}
+	updateContext, err := strconv.ParseBool(value)
+	if err != nil {
+		pcb := pretty.NewInstanceContextBuilder(instance)
+		klog.Warning(pcb.Messagef("Ignoring the invalid %s annotation %q of broker %q", v1beta1.ServiceBrokerUpdateContextAnnotation, value, brokerName))
+		return c.osbAPIUpdateContext
+	}
+	return updateContext
+}
+
+// requestUpdateForRequestContext increments spec.updateRequests of the
+// instance so that the changed context is sent to the broker in an update
+// request.
+func (c *controller) requestUpdateForRequestContext(instance *v1beta1.ServiceInstance) error {
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Message("Requesting an update because the context changed"))
+
+	toUpdate := instance.DeepCopy()
+	toUpdate.Spec.UpdateRequests++
+	if _, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).Update(toUpdate); err != nil {
+		klog.Error(pcb.Messagef("Failed to request an update for the changed context: %v", err))
+		return err
+	}
+
+	c.recorder.Event(instance, corev1.EventTypeNormal, requestContextChangedReason, requestContextChangedMessage)
+	return nil
+}
+
 // processServiceInstancePollingFailureRetryTimeout marks the instance as having
 // failed polling due to its reconciliation retry duration expiring
 func (c *controller) processServiceInstancePollingFailureRetryTimeout(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
@@ -1375,7 +1936,7 @@ func (c *controller) resolveNamespacedReferences(instance *v1beta1.ServiceInstan
 func (c *controller) resolveClusterServiceClassRef(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, error) {
 	if !instance.Spec.ClusterServiceClassSpecified() {
 		// ServiceInstance is in invalid state, should not ever happen. check
-		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ClusterServiceClassExternalName, ClusterServiceClassExternalID, nor ClusterServiceClassName is set", instance.Namespace, instance.Name)
+		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ClusterServiceClassExternalName, ClusterServiceClassExternalID, ClusterServiceClassName, nor ClusterServiceClassSelector is set", instance.Namespace, instance.Name)
 	}
 
 	pcb := pretty.NewInstanceContextBuilder(instance)
@@ -1400,6 +1961,44 @@ func (c *controller) resolveClusterServiceClassRef(instance *v1beta1.ServiceInst
 				instance.Spec.PlanReference,
 			)
 		}
+	} else if instance.Spec.ClusterServiceClassSelector != nil {
+		klog.V(4).Info(pcb.Messagef("looking up a ClusterServiceClass from selector %q", instance.Spec.GetSpecifiedClusterServiceClass()))
+
+		selector, err := metav1.LabelSelectorAsSelector(instance.Spec.ClusterServiceClassSelector)
+		if err != nil {
+			return nil, fmt.Errorf("References ClusterServiceClasses with an invalid selector %c: %v", instance.Spec.PlanReference, err)
+		}
+		serviceClasses, err := c.serviceCatalogClient.ClusterServiceClasses().List(metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error listing the ClusterServiceClasses selected by %c: %v", instance.Spec.PlanReference, err)
+		}
+
+		// Classes removed from the catalog of their broker can not be
+		// provisioned, so they are not candidates
+		var candidates []*v1beta1.ClusterServiceClass
+		for i := range serviceClasses.Items {
+			if !serviceClasses.Items[i].Status.RemovedFromBrokerCatalog {
+				candidates = append(candidates, &serviceClasses.Items[i])
+			}
+		}
+		klog.Info(pcb.Messagef("Found %d ClusterServiceClasses", len(candidates)))
+
+		if len(candidates) != 1 {
+			return nil, fmt.Errorf(
+				"The selector %c must match exactly one ClusterServiceClass (found: %d)",
+				instance.Spec.PlanReference, len(candidates),
+			)
+		}
+		sc = candidates[0]
+		instance.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{
+			Name: sc.Name,
+		}
+		klog.V(4).Info(pcb.Messagef(
+			"resolved %c to ClusterServiceClass %q",
+			instance.Spec.PlanReference, sc.Name,
+		))
 	} else {
 		filterLabel := instance.Spec.GetClusterServiceClassFilterLabelName()
 		filterValue := instance.Spec.GetSpecifiedClusterServiceClass()
@@ -1447,7 +2046,7 @@ func (c *controller) resolveClusterServiceClassRef(instance *v1beta1.ServiceInst
 func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, error) {
 	if !instance.Spec.ServiceClassSpecified() {
 		// ServiceInstance is in invalid state, should not ever happen. check
-		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServiceClassExternalName, ServiceClassExternalID, nor ServiceClassName is set", instance.Namespace, instance.Name)
+		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServiceClassExternalName, ServiceClassExternalID, ServiceClassName, nor ServiceClassSelector is set", instance.Namespace, instance.Name)
 	}
 
 	pcb := pretty.NewInstanceContextBuilder(instance)
@@ -1459,6 +2058,9 @@ func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (
 		var err error
 		sc, err = c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassName)
 		if err == nil {
+			if err := checkNamespacedReference(instance, "ServiceClass", sc.ObjectMeta); err != nil {
+				return nil, err
+			}
 			instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
 				Name: sc.Name,
 			}
@@ -1472,6 +2074,47 @@ func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (
 				instance.Spec.PlanReference,
 			)
 		}
+	} else if instance.Spec.ServiceClassSelector != nil {
+		klog.V(4).Info(pcb.Messagef("looking up a ServiceClass from selector %q", instance.Spec.GetSpecifiedServiceClass()))
+
+		selector, err := metav1.LabelSelectorAsSelector(instance.Spec.ServiceClassSelector)
+		if err != nil {
+			return nil, fmt.Errorf("References ServiceClasses with an invalid selector %c: %v", instance.Spec.PlanReference, err)
+		}
+		serviceClasses, err := c.serviceCatalogClient.ServiceClasses(instance.Namespace).List(metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error listing the ServiceClasses selected by %c: %v", instance.Spec.PlanReference, err)
+		}
+
+		// Classes removed from the catalog of their broker can not be
+		// provisioned, so they are not candidates
+		var candidates []*v1beta1.ServiceClass
+		for i := range serviceClasses.Items {
+			if !serviceClasses.Items[i].Status.RemovedFromBrokerCatalog {
+				candidates = append(candidates, &serviceClasses.Items[i])
+			}
+		}
+		klog.Info(pcb.Messagef("Found %d ServiceClasses", len(candidates)))
+
+		if len(candidates) != 1 {
+			return nil, fmt.Errorf(
+				"The selector %c must match exactly one ServiceClass (found: %d)",
+				instance.Spec.PlanReference, len(candidates),
+			)
+		}
+		sc = candidates[0]
+		if err := checkNamespacedReference(instance, "ServiceClass", sc.ObjectMeta); err != nil {
+			return nil, err
+		}
+		instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
+			Name: sc.Name,
+		}
+		klog.V(4).Info(pcb.Messagef(
+			"resolved %c to K8S ServiceClass %q",
+			instance.Spec.PlanReference, sc.Name,
+		))
 	} else {
 		filterLabel := instance.Spec.GetServiceClassFilterLabelName()
 		filterValue := instance.Spec.GetSpecifiedServiceClass()
@@ -1491,6 +2134,9 @@ func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (
 
 		if err == nil && len(serviceClasses.Items) == 1 {
 			sc = &serviceClasses.Items[0]
+			if err := checkNamespacedReference(instance, "ServiceClass", sc.ObjectMeta); err != nil {
+				return nil, err
+			}
 			instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
 				Name: sc.Name,
 			}
@@ -1524,6 +2170,14 @@ func (c *controller) resolveClusterServicePlanRef(instance *v1beta1.ServiceInsta
 	if instance.Spec.ClusterServicePlanName != "" {
 		sp, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanName)
 		if err == nil {
+			// Kubernetes names are unique across classes, so make sure that
+			// the plan really is one of the class of the instance
+			if instance.Spec.ClusterServiceClassRef != nil && sp.Spec.ClusterServiceClassRef.Name != instance.Spec.ClusterServiceClassRef.Name {
+				return fmt.Errorf(
+					"References ClusterServicePlan %q which does not belong to ClusterServiceClass %q",
+					sp.Name, instance.Spec.ClusterServiceClassRef.Name,
+				)
+			}
 			instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{
 				Name: sp.Name,
 			}
@@ -1584,6 +2238,17 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 	if instance.Spec.ServicePlanName != "" {
 		sp, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanName)
 		if err == nil {
+			if err := checkNamespacedReference(instance, "ServicePlan", sp.ObjectMeta); err != nil {
+				return err
+			}
+			// Kubernetes names are unique across classes, so make sure that
+			// the plan really is one of the class of the instance
+			if instance.Spec.ServiceClassRef != nil && sp.Spec.ServiceClassRef.Name != instance.Spec.ServiceClassRef.Name {
+				return fmt.Errorf(
+					"References ServicePlan %q which does not belong to ServiceClass %q",
+					sp.Name, instance.Spec.ServiceClassRef.Name,
+				)
+			}
 			instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{
 				Name: sp.Name,
 			}
@@ -1612,6 +2277,9 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 
 		if err == nil && len(servicePlans.Items) == 1 {
 			sp := &servicePlans.Items[0]
+			if err := checkNamespacedReference(instance, "ServicePlan", sp.ObjectMeta); err != nil {
+				return err
+			}
 			instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{
 				Name: sp.Name,
 			}
@@ -1630,6 +2298,20 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 	return nil
 }
 
+// checkNamespacedReference returns an error if a namespaced class or plan
+// resolved for the instance belongs to another namespace. Namespaced classes
+// and plans come from the brokers of their namespace and must only be used by
+// the instances of that namespace.
+func checkNamespacedReference(instance *v1beta1.ServiceInstance, kind string, meta metav1.ObjectMeta) error {
+	if meta.Namespace == instance.Namespace {
+		return nil
+	}
+	return fmt.Errorf(
+		"References %s %q of namespace %q; an instance can only use the classes and plans of its own namespace %q",
+		kind, meta.Name, meta.Namespace, instance.Namespace,
+	)
+}
+
 // applyDefaultProvisioningParameters applies any default provisioning parameters for an instance.
 // If parameter defaults were applied, and the instance status was successfully updated, the method returns true
 // If either can not be resolved, returns an error and sets the InstanceCondition
@@ -1845,6 +2527,7 @@ func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
 		))
 		newCondition.LastTransitionTime = t
 		toUpdate.Status.Conditions = []v1beta1.ServiceInstanceCondition{newCondition}
+		toUpdate.Status.LastConditionUpdateTime = &t
 		return
 	}
 
@@ -1858,8 +2541,14 @@ func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
 			} else {
 				newCondition.LastTransitionTime = cond.LastTransitionTime
 			}
+			if cond.Status != newCondition.Status || cond.Reason != newCondition.Reason || cond.Message != newCondition.Message {
+				toUpdate.Status.LastConditionUpdateTime = &t
+			}
 
 			toUpdate.Status.Conditions[i] = newCondition
+			// Conditions written by earlier versions may be in any order,
+			// so they are sorted even when this one is unchanged
+			sortServiceInstanceConditions(toUpdate.Status.Conditions)
 			return
 		}
 	}
@@ -1870,6 +2559,8 @@ func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
 	))
 	newCondition.LastTransitionTime = t
 	toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
+	sortServiceInstanceConditions(toUpdate.Status.Conditions)
+	toUpdate.Status.LastConditionUpdateTime = &t
 }
 
 // updateServiceInstanceReferences updates the refs for the given instance.
@@ -2204,6 +2895,7 @@ func clearServiceInstanceCurrentOperation(toUpdate *v1beta1.ServiceInstance) {
 	toUpdate.Status.OperationStartTime = nil
 	toUpdate.Status.AsyncOpInProgress = false
 	toUpdate.Status.LastOperation = nil
+	toUpdate.Status.LastOperationDescription = ""
 	toUpdate.Status.InProgressProperties = nil
 }
 
@@ -2304,11 +2996,18 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 	rh.ns = ns
 
 	if setInProgressProperties {
+		specParameters, err := instanceSpecParameters(instance)
+		if err != nil {
+			return nil, &operationError{
+				reason:  errorWithParametersReason,
+				message: err.Error(),
+			}
+		}
 		parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
 			c.kubeClient,
 			instance.Namespace,
-			instance.Spec.Parameters,
-			instance.Spec.ParametersFrom,
+			specParameters,
+			instanceParametersFrom(instance),
 		)
 		if err != nil {
 			return nil, &operationError{
@@ -2335,14 +3034,40 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 
 	// osb client handles whether or not to really send this based
 	// on the version of the client.
-	id := c.getClusterID()
-	rh.requestContext = map[string]interface{}{
-		"platform":           ContextProfilePlatformKubernetes,
+	rh.requestContext = c.buildRequestContext(instance, ns)
+	if rh.inProgressProperties != nil {
+		contextChecksum, err := generateChecksumOfParameters(rh.requestContext)
+		if err != nil {
+			return nil, &operationError{
+				reason:  errorWithParametersReason,
+				message: fmt.Sprintf("Failed to generate the checksum of the context: %v", err),
+			}
+		}
+		rh.inProgressProperties.ContextChecksum = contextChecksum
+	}
+	return rh, nil
+}
+
+// buildRequestContext returns the OSB context of the requests for the
+// instance. The labels of the namespace are included when it has any, so
+// that a change to them is a change of the context. The context configured
+// for the broker and for the controller is added to it.
+func (c *controller) buildRequestContext(instance *v1beta1.ServiceInstance, ns *corev1.Namespace) map[string]interface{} {
+	requestContext := map[string]interface{}{
+		"platform":           c.osbAPIContextPlatform,
 		"namespace":          instance.Namespace,
-		clusterIdentifierKey: id,
+		clusterIdentifierKey: c.getClusterID(),
 		"instance_name":      instance.Name,
 	}
-	return rh, nil
+	if len(ns.Labels) > 0 {
+		namespaceLabels := make(map[string]interface{}, len(ns.Labels))
+		for k, v := range ns.Labels {
+			namespaceLabels[k] = v
+		}
+		requestContext[namespaceLabelsContextKey] = namespaceLabels
+	}
+	c.addConfiguredRequestContext(requestContext, c.instanceBrokerRequestContext(instance))
+	return requestContext
 }
 
 // innerPrepareProvisionRequest creates a provision request object to be passed to
@@ -2355,7 +3080,7 @@ func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInsta
 	}
 
 	request := &osb.ProvisionRequest{
-		AcceptsIncomplete: true,
+		AcceptsIncomplete: c.osbAPIAcceptsIncomplete,
 		InstanceID:        instance.Spec.ExternalID,
 		ServiceID:         classCommon.ExternalID,
 		PlanID:            planCommon.ExternalID,
@@ -2381,12 +3106,14 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 
 	var rh *requestHelper
 	var request *osb.UpdateInstanceRequest
+	var brokerName string
 
 	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		serviceClass, servicePlan, name, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
 			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
+		brokerName = name
 
 		rh, err = c.prepareRequestHelper(instance, servicePlan.Spec.ExternalName, servicePlan.Spec.ExternalID, true)
 		if err != nil {
@@ -2394,7 +3121,7 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		}
 
 		request = &osb.UpdateInstanceRequest{
-			AcceptsIncomplete:   true,
+			AcceptsIncomplete:   c.osbAPIAcceptsIncomplete,
 			InstanceID:          instance.Spec.ExternalID,
 			ServiceID:           serviceClass.Spec.ExternalID,
 			Context:             rh.requestContext,
@@ -2422,10 +3149,11 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		}
 
 	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
+		serviceClass, servicePlan, name, _, err := c.getServiceClassPlanAndServiceBroker(instance)
 		if err != nil {
 			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
+		brokerName = name
 
 		rh, err = c.prepareRequestHelper(instance, servicePlan.Spec.ExternalName, servicePlan.Spec.ExternalID, true)
 		if err != nil {
@@ -2433,7 +3161,7 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		}
 
 		request = &osb.UpdateInstanceRequest{
-			AcceptsIncomplete:   true,
+			AcceptsIncomplete:   c.osbAPIAcceptsIncomplete,
 			InstanceID:          instance.Spec.ExternalID,
 			ServiceID:           serviceClass.Spec.ExternalID,
 			Context:             rh.requestContext,
@@ -2462,6 +3190,15 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 
 	}
 
+	if !c.brokerUpdatesContext(instance, brokerName) {
+		// The broker does not learn about the current context, so it still
+		// knows the instance by the context that was last sent
+		request.Context = nil
+		if instance.Status.ExternalProperties != nil {
+			rh.inProgressProperties.ContextChecksum = instance.Status.ExternalProperties.ContextChecksum
+		}
+	}
+
 	return request, rh.inProgressProperties, nil
 }
 
@@ -2493,7 +3230,12 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 	// The plan reference in the spec might be updated since the latest
 	// provisioning/update request, thus we need to take values from the original
 	// provisioning request instead that we previously stored in status
-	if instance.Status.CurrentOperation != "" || instance.Status.OrphanMitigationInProgress {
+	if instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationUpdate && instance.Status.ExternalProperties != nil {
+		// An update that has not succeeded may not have been applied by the
+		// broker, which then still knows the instance by the plan of the
+		// last successful provision or update
+		rh.inProgressProperties = instance.Status.ExternalProperties
+	} else if instance.Status.CurrentOperation != "" || instance.Status.OrphanMitigationInProgress {
 		if instance.Status.InProgressProperties == nil {
 			return nil, nil, stderrors.New("InProgressProperties must be set when there is an operation or orphan mitigation in progress")
 		}
@@ -2552,7 +3294,7 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 		ServiceID:           scExternalID,
 		PlanID:              planExternalID,
 		OriginatingIdentity: rh.originatingIdentity,
-		AcceptsIncomplete:   true,
+		AcceptsIncomplete:   c.osbAPIAcceptsIncomplete,
 	}
 
 	return request, rh.inProgressProperties, nil
@@ -2569,53 +3311,37 @@ func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta
 		return nil, err
 	}
 
-	var rh *requestHelper
+	// The plan of the operation is taken from InProgressProperties rather
+	// than from the plan referenced by the spec, so that an operation in
+	// progress can still be polled when the plan was changed in the spec or
+	// was deleted after the broker removed it from its catalog.
 	var scExternalID string
+	var spExternalName string
 	var spExternalID string
 
 	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		serviceClass, _, _, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 		if err != nil {
 			return nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
 
 		scExternalID = serviceClass.Spec.ExternalID
-
-		var spExternalName string
-		if servicePlan != nil {
-			spExternalName = servicePlan.Spec.ExternalName
-			spExternalID = servicePlan.Spec.ExternalID
-		} else {
-			// If the ServicePlan is nil, pull from the InProgressProperties
-			spExternalID = instance.Status.InProgressProperties.ClusterServicePlanExternalID
-		}
-
-		rh, err = c.prepareRequestHelper(instance, spExternalName, spExternalID, false)
-		if err != nil {
-			return nil, err
-		}
+		spExternalName = instance.Status.InProgressProperties.ClusterServicePlanExternalName
+		spExternalID = instance.Status.InProgressProperties.ClusterServicePlanExternalID
 	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
+		serviceClass, _, _, err := c.getServiceClassAndServiceBroker(instance)
 		if err != nil {
 			return nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
 
 		scExternalID = serviceClass.Spec.ExternalID
+		spExternalName = instance.Status.InProgressProperties.ServicePlanExternalName
+		spExternalID = instance.Status.InProgressProperties.ServicePlanExternalID
+	}
 
-		// Sometimes the servicePlan is nil (deprovision)
-		var spExternalName string
-		if servicePlan != nil {
-			spExternalName = servicePlan.Spec.ExternalName
-			spExternalID = servicePlan.Spec.ExternalID
-		} else {
-			// If the ServicePlan is nil, pull from the InProgressProperties
-			spExternalID = instance.Status.InProgressProperties.ServicePlanExternalID
-		}
-
-		rh, err = c.prepareRequestHelper(instance, spExternalName, spExternalID, false)
-		if err != nil {
-			return nil, err
-		}
+	rh, err := c.prepareRequestHelper(instance, spExternalName, spExternalID, false)
+	if err != nil {
+		return nil, err
 	}
 
 	request := &osb.LastOperationRequest{
@@ -2673,6 +3399,18 @@ func (c *controller) handleServiceInstanceReconciliationError(instance *v1beta1.
 	return err
 }
 
+// hasAmbiguousProvisionAttempt returns whether an earlier attempt to
+// provision the instance failed in a way that does not tell whether the
+// broker received the request.
+func hasAmbiguousProvisionAttempt(instance *v1beta1.ServiceInstance) bool {
+	for _, condition := range instance.Status.Conditions {
+		if condition.Type == v1beta1.ServiceInstanceConditionReady {
+			return condition.Reason == errorErrorCallingProvisionReason
+		}
+	}
+	return false
+}
+
 // processServiceInstanceOperationError handles the logging and updating of
 // a ServiceInstance that hit a retryable error during reconciliation.
 func (c *controller) processServiceInstanceOperationError(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
@@ -2816,6 +3554,45 @@ func (c *controller) processUpdateServiceInstanceSuccess(instance *v1beta1.Servi
 	return nil
 }
 
+// isServiceInstanceUpdateNoOp returns whether the update request prepared
+// for the given ready instance would send the broker nothing it does not
+// already have: the plan is unchanged, the checksum of the canonical
+// parameters matches the checksum of the parameters last applied, and the
+// context, if sent, is unchanged too. This is the case, for example, when
+// only the ordering of the keys of the parameters changed.
+func isServiceInstanceUpdateNoOp(instance *v1beta1.ServiceInstance, request *osb.UpdateInstanceRequest, inProgressProperties *v1beta1.ServiceInstancePropertiesState) bool {
+	if instance.Status.CurrentOperation != "" || instance.Status.ExternalProperties == nil || !isServiceInstanceReady(instance) {
+		return false
+	}
+	if request.PlanID != nil || request.Parameters != nil {
+		return false
+	}
+	// Instances provisioned before the checksum of the context was recorded
+	// are not updated until something else changes
+	externalContextChecksum := instance.Status.ExternalProperties.ContextChecksum
+	if request.Context != nil && externalContextChecksum != "" &&
+		inProgressProperties.ContextChecksum != externalContextChecksum {
+		return false
+	}
+	return true
+}
+
+// processServiceInstanceUpdateNoOp records the generation of the given
+// instance as reconciled without sending an update request to the broker.
+func (c *controller) processServiceInstanceUpdateNoOp(instance *v1beta1.ServiceInstance) error {
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Message("Not sending an update request because nothing changed since the last one"))
+
+	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
+	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+		return err
+	}
+
+	c.removeInstanceFromRetryMap(instance)
+	c.recorder.Event(instance, corev1.EventTypeNormal, updateNotNeededReason, updateNotNeededMessage)
+	return nil
+}
+
 // processTerminalUpdateServiceInstanceFailure handles the logging and updating of a
 // ServiceInstance that hit a terminal failure during update reconciliation.
 func (c *controller) processTerminalUpdateServiceInstanceFailure(instance *v1beta1.ServiceInstance, readyCond, failedCond *v1beta1.ServiceInstanceCondition) error {
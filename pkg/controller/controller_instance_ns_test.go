@@ -870,3 +870,88 @@ func TestResolveNamespacedReferencesWorks(t *testing.T) {
 	events := getRecordedEvents(testController)
 	assertNumEvents(t, events, 0)
 }
+
+// TestResolveNamespacedReferencesServiceClassOfAnotherNamespace tests that
+// resolveReferences rejects a ServiceClass synced into another namespace
+// than the one of the instance.
+func TestResolveNamespacedReferencesServiceClassOfAnotherNamespace(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithNamespacedPlanReference()
+
+	sc := getTestServiceClass()
+	sc.Namespace = "other-namespace"
+	fakeCatalogClient.AddReactor("list", "serviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServiceClassList{Items: []v1beta1.ServiceClass{*sc}}, nil
+	})
+
+	if _, err := testController.resolveReferences(instance); err == nil {
+		t.Fatal("Should have failed because the class belongs to another namespace")
+	}
+
+	// We should get the following actions:
+	// list call for ServiceClass
+	// updating the ready condition
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[1], instance)
+	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorNonexistentServiceClassReason)
+	if updatedServiceInstance.(*v1beta1.ServiceInstance).Spec.ServiceClassRef != nil {
+		t.Fatal("ServiceClassRef should not have been set")
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorNonexistentServiceClassReason).msgf(
+		"References ServiceClass %q of namespace %q; an instance can only use the classes and plans of its own namespace %q",
+		testServiceClassGUID, "other-namespace", testNamespace,
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestResolveNamespacedReferencesServicePlanOfAnotherNamespace tests that
+// resolveReferences rejects a ServicePlan synced into another namespace than
+// the one of the instance.
+func TestResolveNamespacedReferencesServicePlanOfAnotherNamespace(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithNamespacedPlanReference()
+
+	sc := getTestServiceClass()
+	fakeCatalogClient.AddReactor("list", "serviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServiceClassList{Items: []v1beta1.ServiceClass{*sc}}, nil
+	})
+	sp := getTestServicePlan()
+	sp.Namespace = "other-namespace"
+	fakeCatalogClient.AddReactor("list", "serviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServicePlanList{Items: []v1beta1.ServicePlan{*sp}}, nil
+	})
+
+	if _, err := testController.resolveReferences(instance); err == nil {
+		t.Fatal("Should have failed because the plan belongs to another namespace")
+	}
+
+	// We should get the following actions:
+	// list call for ServiceClass
+	// list call for ServicePlan
+	// updating the ready condition
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 3)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[2], instance)
+	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorNonexistentServicePlanReason)
+	if updatedServiceInstance.(*v1beta1.ServiceInstance).Spec.ServicePlanRef != nil {
+		t.Fatal("ServicePlanRef should not have been set")
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorNonexistentServicePlanReason).msgf(
+		"References ServicePlan %q of namespace %q; an instance can only use the classes and plans of its own namespace %q",
+		testServicePlanGUID, "other-namespace", testNamespace,
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
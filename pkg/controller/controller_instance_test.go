@@ -20,10 +20,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"reflect"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -39,13 +42,17 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
 	sctestutil "github.com/kubernetes-sigs/service-catalog/test/util"
 	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -100,6 +107,472 @@ func TestReconcileServiceInstanceNotInitializedStatus(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstancePaused tests that reconcileInstance only records
+// the Paused condition for an instance that carries the paused annotation.
+func TestReconcileServiceInstancePaused(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Annotations = map[string]string{v1beta1.ServiceInstancePausedAnnotation: "true"}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionPaused, v1beta1.ConditionTrue, pausedReason)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(pausedReason).msg(pausedMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+
+	// A paused instance which already has the condition is left alone
+	fakeCatalogClient.ClearActions()
+	pausedInstance := updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if err := reconcileServiceInstance(t, testController, pausedInstance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+}
+
+// TestReconcileServiceInstanceResumed tests that reconcileInstance removes the
+// Paused condition once the paused annotation has been removed.
+func TestReconcileServiceInstanceResumed(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionPaused,
+		Status: v1beta1.ConditionTrue,
+		Reason: pausedReason,
+	}}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	if e, a := 0, len(updatedServiceInstance.Status.Conditions); e != a {
+		t.Fatalf("expected %v conditions, got %v: %+v", e, a, updatedServiceInstance.Status.Conditions)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(resumedReason).msg(resumedMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestInstanceUpdateEnqueuesResumedInstance tests that an instance with an
+// asynchronous operation in progress is enqueued when it is resumed.
+func TestInstanceUpdateEnqueuesResumedInstance(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	paused := getTestServiceInstanceAsyncProvisioning(testOperation)
+	paused.Annotations = map[string]string{v1beta1.ServiceInstancePausedAnnotation: "true"}
+	resumed := paused.DeepCopy()
+	resumed.Annotations = nil
+
+	testController.instanceUpdate(paused, paused)
+	if e, a := 0, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+
+	testController.instanceUpdate(paused, resumed)
+	if e, a := 1, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+}
+
+// TestSecretUpdateEnqueuesInstancesWithSecretParameterRefs tests that a
+// change to a secret enqueues the instances which reference it in
+// spec.secretParameterRefs.
+func TestSecretUpdateEnqueuesInstancesWithSecretParameterRefs(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Spec.SecretParameterRefs = []v1beta1.SecretParameterReference{
+		{SecretKeyRef: v1beta1.SecretKeyReference{Name: "secret-name", Key: "secret-key"}},
+	}
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+
+	oldSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "secret-name"},
+		Data:       map[string][]byte{"secret-key": []byte(`{"password":"old"}`)},
+	}
+	newSecret := oldSecret.DeepCopy()
+	newSecret.ResourceVersion = "2"
+
+	testController.secretUpdate(oldSecret, newSecret)
+	if e, a := 0, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+
+	otherSecret := newSecret.DeepCopy()
+	otherSecret.Name = "other-secret-name"
+	otherSecret.Data = map[string][]byte{"secret-key": []byte(`{"password":"new"}`)}
+	testController.secretUpdate(oldSecret, otherSecret)
+	if e, a := 0, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+
+	newSecret.Data = map[string][]byte{"secret-key": []byte(`{"password":"new"}`)}
+	testController.secretUpdate(oldSecret, newSecret)
+	if e, a := 1, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+}
+
+// TestReconcileServiceInstanceSecretParametersChanged tests that a ready
+// instance with spec.secretParameterRefs requests an update when the
+// parameters read from the secrets no longer match the parameters sent to
+// the broker.
+func TestReconcileServiceInstanceSecretParametersChanged(t *testing.T) {
+	cases := []struct {
+		name          string
+		secretData    string
+		expectRequest bool
+	}{
+		{
+			name:          "secret unchanged",
+			secretData:    `{"password":"old"}`,
+			expectRequest: false,
+		},
+		{
+			name:          "secret rotated",
+			secretData:    `{"password":"new"}`,
+			expectRequest: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "secret-name"},
+				Data:       map[string][]byte{"secret-key": []byte(tc.secretData)},
+			})
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.ParameterChecksum = generateChecksumOfParametersOrFail(t, map[string]interface{}{"password": "old"})
+			instance.Spec.SecretParameterRefs = []v1beta1.SecretParameterReference{
+				{SecretKeyRef: v1beta1.SecretKeyReference{Name: "secret-name", Key: "secret-key"}},
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			events := getRecordedEvents(testController)
+			if !tc.expectRequest {
+				assertNumberOfActions(t, actions, 0)
+				if err := checkEvents(events, []string{}); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdate(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if e, a := instance.Spec.UpdateRequests+1, updatedServiceInstance.Spec.UpdateRequests; e != a {
+				t.Fatalf("unexpected updateRequests: expected %v, got %v", e, a)
+			}
+
+			expectedEvent := normalEventBuilder(secretParametersChangedReason).msg(secretParametersChangedMessage)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceParametersResync tests that a ready instance
+// with spec.parametersFrom requests an update when the parameters read from
+// its secrets changed only if the periodic read of its parameters is enabled,
+// and that it is queued again once the interval elapsed when the parameters
+// did not change.
+func TestReconcileServiceInstanceParametersResync(t *testing.T) {
+	cases := []struct {
+		name          string
+		interval      time.Duration
+		annotation    string
+		secretData    string
+		expectRequest bool
+		expectResync  bool
+	}{
+		{
+			name:       "disabled",
+			secretData: `{"password":"new"}`,
+		},
+		{
+			name:          "enabled by the controller",
+			interval:      time.Hour,
+			secretData:    `{"password":"new"}`,
+			expectRequest: true,
+		},
+		{
+			name:          "enabled by the annotation",
+			annotation:    "1h",
+			secretData:    `{"password":"new"}`,
+			expectRequest: true,
+		},
+		{
+			name:       "disabled by the annotation",
+			interval:   time.Hour,
+			annotation: "0",
+			secretData: `{"password":"new"}`,
+		},
+		{
+			name:         "unchanged",
+			interval:     50 * time.Millisecond,
+			secretData:   `{"password":"old"}`,
+			expectResync: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.parametersResyncInterval = tc.interval
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "secret-name"},
+				Data:       map[string][]byte{"secret-key": []byte(tc.secretData)},
+			})
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.ParameterChecksum = generateChecksumOfParametersOrFail(t, map[string]interface{}{"password": "old"})
+			instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "secret-name", Key: "secret-key"}},
+			}
+			if tc.annotation != "" {
+				instance.Annotations = map[string]string{v1beta1.ServiceInstanceParametersResyncIntervalAnnotation: tc.annotation}
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectRequest {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdate(t, actions[0], instance).(*v1beta1.ServiceInstance)
+				if e, a := instance.Spec.UpdateRequests+1, updatedServiceInstance.Spec.UpdateRequests; e != a {
+					t.Fatalf("unexpected updateRequests: expected %v, got %v", e, a)
+				}
+			} else {
+				assertNumberOfActions(t, actions, 0)
+			}
+
+			if !tc.expectResync {
+				if e, a := 0, testController.instanceQueue.Len(); e != a {
+					t.Fatalf("expected %v queued instances, got %v", e, a)
+				}
+				return
+			}
+			err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+				return testController.instanceQueue.Len() > 0, nil
+			})
+			if err != nil {
+				t.Fatalf("the instance was not queued again: %v", err)
+			}
+		})
+	}
+}
+
+// TestNamespaceUpdateEnqueuesInstances tests that a change to the labels of
+// a namespace enqueues the instances of the namespace once the delay that
+// coalesces the changes elapsed.
+func TestNamespaceUpdateEnqueuesInstances(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.namespaceLabelsChangeDelay = 100 * time.Millisecond
+
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithClusterRefs())
+
+	oldNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: testNamespace, UID: testNamespaceGUID},
+	}
+	newNamespace := oldNamespace.DeepCopy()
+	newNamespace.ResourceVersion = "2"
+
+	testController.namespaceUpdate(oldNamespace, newNamespace)
+	if e, a := 0, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+
+	newNamespace.Labels = map[string]string{"team": "a"}
+	testController.namespaceUpdate(oldNamespace, newNamespace)
+	newerNamespace := newNamespace.DeepCopy()
+	newerNamespace.ResourceVersion = "3"
+	newerNamespace.Labels = map[string]string{"team": "b"}
+	testController.namespaceUpdate(newNamespace, newerNamespace)
+	if e, a := 0, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances before the delay elapsed, got %v", e, a)
+	}
+
+	err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return testController.instanceQueue.Len() > 0, nil
+	})
+	if err != nil {
+		t.Fatalf("the instance was not queued: %v", err)
+	}
+	time.Sleep(2 * testController.namespaceLabelsChangeDelay)
+	if e, a := 1, testController.instanceQueue.Len(); e != a {
+		t.Fatalf("expected %v queued instances, got %v", e, a)
+	}
+}
+
+// TestReconcileServiceInstanceRequestContextChanged tests that a ready
+// instance requests an update when its OSB context no longer matches the
+// context sent to the broker, as long as its broker accepts the context in
+// update requests and its class is plan updatable.
+func TestReconcileServiceInstanceRequestContextChanged(t *testing.T) {
+	cases := []struct {
+		name              string
+		namespaceLabels   map[string]string
+		disableContext    bool
+		brokerAnnotations map[string]string
+		notPlanUpdatable  bool
+		expectRequest     bool
+	}{
+		{
+			name:          "context unchanged",
+			expectRequest: false,
+		},
+		{
+			name:            "namespace labels changed",
+			namespaceLabels: map[string]string{"team": "a"},
+			expectRequest:   true,
+		},
+		{
+			name:            "context updates disabled",
+			namespaceLabels: map[string]string{"team": "a"},
+			disableContext:  true,
+			expectRequest:   false,
+		},
+		{
+			name:              "context updates disabled for the broker",
+			namespaceLabels:   map[string]string{"team": "a"},
+			brokerAnnotations: map[string]string{v1beta1.ServiceBrokerUpdateContextAnnotation: "false"},
+			expectRequest:     false,
+		},
+		{
+			name:              "context updates enabled for the broker",
+			namespaceLabels:   map[string]string{"team": "a"},
+			disableContext:    true,
+			brokerAnnotations: map[string]string{v1beta1.ServiceBrokerUpdateContextAnnotation: "true"},
+			expectRequest:     true,
+		},
+		{
+			name:              "invalid broker annotation",
+			namespaceLabels:   map[string]string{"team": "a"},
+			brokerAnnotations: map[string]string{v1beta1.ServiceBrokerUpdateContextAnnotation: "sometimes"},
+			expectRequest:     true,
+		},
+		{
+			name:             "class not plan updatable",
+			namespaceLabels:  map[string]string{"team": "a"},
+			notPlanUpdatable: true,
+			expectRequest:    false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.osbAPIUpdateContext = !tc.disableContext
+
+			broker := getTestClusterServiceBroker()
+			broker.Annotations = tc.brokerAnnotations
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.PlanUpdatable = !tc.notPlanUpdatable
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			err := indexer.Add(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   testNamespace,
+					UID:    testNamespaceGUID,
+					Labels: tc.namespaceLabels,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			testController.namespaceLister = corelisters.NewNamespaceLister(indexer)
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.ContextChecksum = generateChecksumOfParametersOrFail(t, map[string]interface{}{
+				"platform":           ContextProfilePlatformKubernetes,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: testClusterID,
+				"instance_name":      testServiceInstanceName,
+			})
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			events := getRecordedEvents(testController)
+			if !tc.expectRequest {
+				assertNumberOfActions(t, actions, 0)
+				if err := checkEvents(events, []string{}); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdate(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if e, a := instance.Spec.UpdateRequests+1, updatedServiceInstance.Spec.UpdateRequests; e != a {
+				t.Fatalf("unexpected updateRequests: expected %v, got %v", e, a)
+			}
+
+			expectedEvent := normalEventBuilder(requestContextChangedReason).msg(requestContextChangedMessage)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 // TestReconcileServiceInstanceNonExistentClusterServiceClass tests that reconcileInstance gets a failure when
 // the specified service class is not found
 func TestReconcileServiceInstanceNonExistentClusterServiceClass(t *testing.T) {
@@ -843,20 +1316,110 @@ func TestReconcileServiceInstanceResolvesReferences(t *testing.T) {
 	assertNumEvents(t, getRecordedEvents(testController), 0)
 }
 
-func TestReconcileServiceInstanceAppliesDefaultProvisioningParams(t *testing.T) {
-	err := utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanDefaults))
-	if err != nil {
-		t.Fatalf("Could not enable ServicePlanDefaults feature flag.")
-	}
-
-	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
-		ProvisionReaction: &fakeosb.ProvisionReaction{
-			Response: &osb.ProvisionResponse{},
+// TestReconcileServiceInstanceWithParameterTemplates tests that the templates
+// in the parameters of an instance are resolved from its metadata when the
+// ParameterTemplates feature is enabled, and sent as is otherwise.
+func TestReconcileServiceInstanceWithParameterTemplates(t *testing.T) {
+	cases := []struct {
+		name           string
+		enabled        bool
+		params         string
+		expectedParams map[string]interface{}
+		expectedError  string
+	}{
+		{
+			name:    "resolved templates",
+			enabled: true,
+			params:  `{"project":"{{ .Namespace }}","owner":{"team":"{{ .Labels.team }}"}}`,
+			expectedParams: map[string]interface{}{
+				"project": testNamespace,
+				"owner":   map[string]interface{}{"team": "payments"},
+			},
 		},
-	})
+		{
+			name:          "missing label",
+			enabled:       true,
+			params:        `{"owner":"{{ .Labels.owner }}"}`,
+			expectedError: "failed to resolve the templates of the parameters",
+		},
+		{
+			name:    "feature disabled",
+			enabled: false,
+			params:  `{"project":"{{ .Namespace }}"}`,
+			expectedParams: map[string]interface{}{
+				"project": "{{ .Namespace }}",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.ParameterTemplates, tc.enabled)); err != nil {
+				t.Fatalf("Could not set the ParameterTemplates feature flag: %v", err)
+			}
+			defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParameterTemplates))
 
-	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
-	sc := getTestClusterServiceClass()
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				ProvisionReaction: &fakeosb.ProvisionReaction{
+					Response: &osb.ProvisionResponse{},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.Labels = map[string]string{"team": "payments"}
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(tc.params)}
+
+			err := reconcileServiceInstance(t, testController, instance)
+			if tc.expectedError != "" && err == nil {
+				t.Fatalf("Reconcile expected to fail")
+			} else if tc.expectedError == "" && err != nil {
+				t.Fatalf("Reconcile not expected to fail : %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 2)
+			updatedServiceInstance := assertUpdateStatus(t, actions[1], instance)
+
+			events := getRecordedEvents(testController)
+			if tc.expectedError != "" {
+				assertServiceInstanceErrorBeforeRequest(t, updatedServiceInstance, errorWithParametersReason, instance)
+				expectedEvent := warningEventBuilder(errorWithParametersReason).msg(tc.expectedError)
+				if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			assertServiceInstanceOperationInProgressWithParameters(t,
+				updatedServiceInstance,
+				v1beta1.ServiceInstanceOperationProvision,
+				testClusterServicePlanName,
+				testClusterServicePlanGUID,
+				tc.expectedParams,
+				generateChecksumOfParametersOrFail(t, tc.expectedParams),
+				instance,
+			)
+		})
+	}
+}
+
+func TestReconcileServiceInstanceAppliesDefaultProvisioningParams(t *testing.T) {
+	err := utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanDefaults))
+	if err != nil {
+		t.Fatalf("Could not enable ServicePlanDefaults feature flag.")
+	}
+
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sc := getTestClusterServiceClass()
 	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(sc)
 	sp := getTestClusterServicePlan()
 
@@ -1111,6 +1674,110 @@ func TestReconcileServiceInstanceWithProvisionCallFailure(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceDeleteAfterProvisionFailure tests that an
+// instance whose provision request failed without creating anything at the
+// broker is deleted without sending a deprovision request, unless an earlier
+// attempt may have reached the broker.
+func TestReconcileServiceInstanceDeleteAfterProvisionFailure(t *testing.T) {
+	connectionRefused := &url.Error{Op: "Put", URL: "https://broker", Err: &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	}}
+
+	cases := []struct {
+		name                      string
+		provisionErr              error
+		ambiguousEarlierAttempt   bool
+		expectedReason            string
+		expectedDeprovisionStatus v1beta1.ServiceInstanceDeprovisionStatus
+	}{
+		{
+			name:                      "rejected by the broker",
+			provisionErr:              osb.HTTPStatusCodeError{StatusCode: http.StatusBadRequest},
+			expectedReason:            errorProvisionCallFailedReason,
+			expectedDeprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
+		},
+		{
+			name:                      "connection refused",
+			provisionErr:              connectionRefused,
+			expectedReason:            errorProvisionRequestNotSentReason,
+			expectedDeprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
+		},
+		{
+			name:                      "connection refused after an ambiguous attempt",
+			provisionErr:              connectionRefused,
+			ambiguousEarlierAttempt:   true,
+			expectedReason:            errorErrorCallingProvisionReason,
+			expectedDeprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusRequired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				ProvisionReaction: &fakeosb.ProvisionReaction{
+					Error: tc.provisionErr,
+				},
+				DeprovisionReaction: &fakeosb.DeprovisionReaction{
+					Response: &osb.DeprovisionResponse{},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+			if tc.ambiguousEarlierAttempt {
+				setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionFalse, errorErrorCallingProvisionReason, "fake ambiguous failure")
+			}
+			fakeCatalogClient.ClearActions()
+
+			reconcileServiceInstance(t, testController, instance)
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+			actions := fakeCatalogClient.Actions()
+			if len(actions) == 0 {
+				t.Fatal("expected the status of the instance to be updated")
+			}
+			instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			assertServiceInstanceReadyFalse(t, instance, tc.expectedReason)
+			assertServiceInstanceDeprovisionStatus(t, instance, tc.expectedDeprovisionStatus)
+
+			instance.DeletionTimestamp = &metav1.Time{}
+			instance.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+			fakeCatalogClient.ClearActions()
+			fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, instance, nil
+			})
+			fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+			if tc.expectedDeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusRequired {
+				// The deprovision request is sent once the start of the
+				// operation is recorded.
+				assertServiceInstanceOperationInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID)
+				return
+			}
+
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 2)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			assertServiceInstanceReadyFalse(t, updatedServiceInstance, noProvisionedResourceReason)
+			updatedServiceInstance = assertUpdate(t, actions[1], instance)
+			assertEmptyFinalizers(t, updatedServiceInstance)
+		})
+	}
+}
+
 // TestReconcileServiceInstanceWithTemporaryProvisionFailure tests that when the
 // provision call to the broker fails with a retriable HTTP error, the ready condition
 // becomes false, and the failure condition is not set.
@@ -1823,21 +2490,401 @@ func TestReconcileServiceInstanceDelete(t *testing.T) {
 		PlanID:            testClusterServicePlanGUID,
 	})
 
-	// Verify no core kube actions occurred
-	kubeActions := fakeKubeClient.Actions()
-	assertNumberOfActions(t, kubeActions, 0)
+	// Verify no core kube actions occurred
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := normalEventBuilder(successDeprovisionReason).msg("The instance was deprovisioned successfully")
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setTestNamespaceDeleted makes the namespace lister of the test controller
+// return the test namespace, with its deletion started at the given time.
+func setTestNamespaceDeleted(t *testing.T, testController *controller, deletionTime time.Time) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	err := indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              testNamespace,
+			UID:               testNamespaceGUID,
+			DeletionTimestamp: &metav1.Time{Time: deletionTime},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testController.namespaceLister = corelisters.NewNamespaceLister(indexer)
+}
+
+// getTestServiceInstanceDeprovisionRequired returns a deleted instance that
+// has to be deprovisioned at the broker.
+func getTestServiceInstanceDeprovisionRequired() *v1beta1.ServiceInstance {
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Generation = 1
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	return instance
+}
+
+// TestReconcileServiceInstanceDeleteNamespaceDeletion tests that an instance
+// whose namespace is being deleted gets the NamespaceDeletion condition once,
+// before it is deprovisioned.
+func TestReconcileServiceInstanceDeleteNamespaceDeletion(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	setTestNamespaceDeleted(t, testController, time.Now())
+
+	instance := getTestServiceInstanceDeprovisionRequired()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionNamespaceDeletion, v1beta1.ConditionTrue, namespaceDeletionReason)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(namespaceDeletionReason).msgf(namespaceDeletionMessage, testNamespace)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The instance which already has the condition is deprovisioned
+	fakeCatalogClient.ClearActions()
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+	instance = updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertDeprovision(t, brokerActions[0], &osb.DeprovisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+	})
+}
+
+// TestReconcileServiceInstanceDeleteNamespaceDeletionTimedOut tests that the
+// deprovisioning of an instance fails, without a request to the broker, once
+// the namespace deletion deprovision timeout has elapsed.
+func TestReconcileServiceInstanceDeleteNamespaceDeletionTimedOut(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	testController.namespaceDeletionDeprovisionTimeout = time.Hour
+	setTestNamespaceDeleted(t, testController, time.Now().Add(-2*time.Hour))
+
+	instance := getTestServiceInstanceDeprovisionRequired()
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionNamespaceDeletion, v1beta1.ConditionTrue, namespaceDeletionReason, "")
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusFailed)
+	assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue, namespaceDeletionTimedOutReason)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(namespaceDeletionTimedOutReason).msgf(namespaceDeletionTimedOutMessage, time.Hour, testNamespace)
+	if err := checkEvents(events, []string{expectedEvent.String(), expectedEvent.String()}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphanNoTimeout
+// tests that the finalizer of an instance whose deprovisioning has failed for
+// good is removed because of the force-orphan annotation without waiting for
+// a deprovision timeout, even when none is set.
+func TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphanNoTimeout(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceDeprovisionRequired()
+	instance.Annotations = map[string]string{v1beta1.ServiceInstanceForceOrphanAnnotation: "true"}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertEmptyFinalizers(t, updatedServiceInstance)
+
+	events := getRecordedEvents(testController)
+	if err := checkEventPrefixes(events, []string{warningEventBuilder(forceOrphanedReason).String()}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceDeleteDeprovisionTimeout tests that a failed
+// deprovisioning of a deleted instance is reported once the deprovision
+// timeout has elapsed, and that the finalizer is only removed when the
+// instance also has the force-orphan annotation.
+func TestReconcileServiceInstanceDeleteDeprovisionTimeout(t *testing.T) {
+	cases := []struct {
+		name              string
+		timeout           time.Duration
+		annotations       map[string]string
+		deletedAgo        time.Duration
+		expectedEvents    []string
+		expectedFinalizer bool
+	}{
+		{
+			name:              "timeout not elapsed",
+			timeout:           time.Hour,
+			deletedAgo:        30 * time.Minute,
+			expectedEvents:    []string{warningEventBuilder(errorDeprovisionCallFailedReason).String()},
+			expectedFinalizer: true,
+		},
+		{
+			name:       "timeout elapsed",
+			timeout:    time.Hour,
+			deletedAgo: 2 * time.Hour,
+			expectedEvents: []string{
+				warningEventBuilder(deprovisionTimedOutReason).String(),
+				warningEventBuilder(errorDeprovisionCallFailedReason).String(),
+			},
+			expectedFinalizer: true,
+		},
+		{
+			name:              "force-orphan before the timeout elapsed",
+			timeout:           time.Hour,
+			annotations:       map[string]string{v1beta1.ServiceInstanceForceOrphanAnnotation: "true"},
+			deletedAgo:        30 * time.Minute,
+			expectedEvents:    []string{warningEventBuilder(errorDeprovisionCallFailedReason).String()},
+			expectedFinalizer: true,
+		},
+		{
+			name:              "force-orphan after the timeout elapsed",
+			timeout:           time.Hour,
+			annotations:       map[string]string{v1beta1.ServiceInstanceForceOrphanAnnotation: "true"},
+			deletedAgo:        2 * time.Hour,
+			expectedEvents:    []string{warningEventBuilder(forceOrphanedReason).String()},
+			expectedFinalizer: false,
+		},
+		{
+			name:    "annotation overrides the timeout of the controller",
+			timeout: 0,
+			annotations: map[string]string{
+				v1beta1.ServiceInstanceDeprovisionTimeoutAnnotation: "1h",
+				v1beta1.ServiceInstanceForceOrphanAnnotation:        "true",
+			},
+			deletedAgo:        2 * time.Hour,
+			expectedEvents:    []string{warningEventBuilder(forceOrphanedReason).String()},
+			expectedFinalizer: false,
+		},
+		{
+			name:    "annotation disables the timeout",
+			timeout: time.Hour,
+			annotations: map[string]string{
+				v1beta1.ServiceInstanceDeprovisionTimeoutAnnotation: "0",
+				v1beta1.ServiceInstanceForceOrphanAnnotation:        "true",
+			},
+			deletedAgo:        2 * time.Hour,
+			expectedEvents:    []string{warningEventBuilder(errorDeprovisionCallFailedReason).String()},
+			expectedFinalizer: true,
+		},
+		{
+			name:    "invalid annotation falls back to the timeout of the controller",
+			timeout: time.Hour,
+			annotations: map[string]string{
+				v1beta1.ServiceInstanceDeprovisionTimeoutAnnotation: "soon",
+				v1beta1.ServiceInstanceForceOrphanAnnotation:        "true",
+			},
+			deletedAgo:        2 * time.Hour,
+			expectedEvents:    []string{warningEventBuilder(forceOrphanedReason).String()},
+			expectedFinalizer: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				DeprovisionReaction: &fakeosb.DeprovisionReaction{
+					Error: osb.HTTPStatusCodeError{
+						StatusCode: http.StatusInternalServerError,
+					},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			testController.deprovisionTimeout = tc.timeout
+
+			instance := getTestServiceInstanceDeprovisionRequired()
+			instance.Annotations = tc.annotations
+			instance.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-tc.deletedAgo)}
+			instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationDeprovision
+			instance.Status.InProgressProperties = instance.Status.ExternalProperties
+			startTime := metav1.NewTime(time.Now().Add(-tc.deletedAgo))
+			instance.Status.OperationStartTime = &startTime
+
+			fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, instance, nil
+			})
+			fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+			err := reconcileServiceInstance(t, testController, instance)
+			if tc.expectedFinalizer && err == nil {
+				t.Fatal("expected the deprovision error to be returned")
+			}
+			if !tc.expectedFinalizer && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectedFinalizer {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertCatalogFinalizerExists(t, updatedServiceInstance)
+			} else {
+				assertNumberOfActions(t, actions, 2)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionUnknown, forceOrphanedReason)
+				updatedServiceInstance = assertUpdate(t, actions[1], instance)
+				assertEmptyFinalizers(t, updatedServiceInstance)
+			}
+
+			events := getRecordedEvents(testController)
+			if err := checkEventPrefixes(events, tc.expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphan tests that
+// the finalizer of an instance whose deprovisioning has failed is removed,
+// without a request to the broker, once the deprovision timeout has elapsed
+// and the instance has the force-orphan annotation.
+func TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphan(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+	testController.deprovisionTimeout = time.Hour
+
+	instance := getTestServiceInstanceDeprovisionRequired()
+	instance.Annotations = map[string]string{v1beta1.ServiceInstanceForceOrphanAnnotation: "true"}
+	instance.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertEmptyFinalizers(t, updatedServiceInstance)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(forceOrphanedReason).msgf(forceOrphanedMessage, 2*time.Hour)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphanWithBindings
+// tests that the finalizer of an instance with the force-orphan annotation is
+// kept, with the DeprovisionBlockedByExistingCredentials reason, while
+// ServiceBindings still reference the instance.
+func TestReconcileServiceInstanceDeleteFailedDeprovisionForceOrphanWithBindings(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.deprovisionTimeout = time.Hour
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(getTestServiceBinding())
+
+	instance := getTestServiceInstanceDeprovisionRequired()
+	instance.Annotations = map[string]string{v1beta1.ServiceInstanceForceOrphanAnnotation: "true"}
+	instance.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the instance to be requeued while it has bindings")
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 2)
-
-	assertUpdateStatus(t, actions[0], instance)
-	updatedServiceInstance := assertUpdate(t, actions[1], instance)
-	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionFalse, errorDeprovisionBlockedByCredentialsReason)
+	assertCatalogFinalizerExists(t, updatedServiceInstance)
 
 	events := getRecordedEvents(testController)
-
-	expectedEvent := normalEventBuilder(successDeprovisionReason).msg("The instance was deprovisioned successfully")
-	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+	expectedEvent := warningEventBuilder(errorDeprovisionBlockedByCredentialsReason)
+	if err := checkEventPrefixes(events, []string{expectedEvent.String()}); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -2365,6 +3412,172 @@ func TestReconcileServiceInstanceDeleteFailedUpdate(t *testing.T) {
 	assertEmptyFinalizers(t, updatedServiceInstance)
 }
 
+// TestReconcileServiceInstanceDeleteAfterFailedPlanUpdate tests that an
+// instance deleted while an update to a new plan is failing is deprovisioned
+// with the plan of the last successful provision, not the new plan.
+func TestReconcileServiceInstanceDeleteAfterFailedPlanUpdate(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlanNonbindable())
+
+	// The instance was provisioned with the test plan, then updated to the
+	// non-bindable plan; the update failed with an error that is retried
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Spec.ClusterServicePlanExternalName = testNonbindableClusterServicePlanName
+	instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: testNonbindableClusterServicePlanGUID}
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 2
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+	startTime := metav1.NewTime(time.Now().Add(-1 * time.Hour))
+	instance.Status.OperationStartTime = &startTime
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testNonbindableClusterServicePlanName,
+		ClusterServicePlanExternalID:   testNonbindableClusterServicePlanGUID,
+	}
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionFalse, errorUpdateInstanceCallFailedReason, "update failed")
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+
+	// simulate real update and return updated object,
+	// without that fake client will return empty ServiceInstances struct
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertDeprovision(t, brokerActions[0], &osb.DeprovisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+	assertEmptyFinalizers(t, updatedServiceInstance)
+}
+
+// TestReconcileServiceInstanceDeleteAfterTerminalPlanUpdateFailure tests
+// that a plan update which the broker rejects keeps the plan of the last
+// successful provision in status.externalProperties, and that the instance is
+// then deprovisioned with that plan rather than the plan of the spec.
+func TestReconcileServiceInstanceDeleteAfterTerminalPlanUpdateFailure(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: strPtr("BadRequest"),
+				Description:  strPtr("The plan can not be changed"),
+			},
+		},
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceUpdatingPlan()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceUpdateInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceCondition(t, instance, v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue, errorUpdateInstanceCallFailedReason)
+
+	// The failed update is not recorded as the provisioned plan
+	expectedExternalProperties := &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: "old-plan-name",
+		ClusterServicePlanExternalID:   "old-plan-id",
+	}
+	if e, a := expectedExternalProperties, instance.Status.ExternalProperties; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected external properties after the failed update: %v", diff.ObjectReflectDiff(e, a))
+	}
+	if instance.Status.InProgressProperties != nil {
+		t.Fatalf("expected no in-progress properties after the failed update, got %+v", instance.Status.InProgressProperties)
+	}
+
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceOperationInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance, v1beta1.ServiceInstanceOperationDeprovision, "old-plan-name", "old-plan-id")
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertDeprovision(t, brokerActions[1], &osb.DeprovisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            "old-plan-id",
+	})
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertEmptyFinalizers(t, updatedServiceInstance)
+}
+
 // TestReconcileServiceInstanceDeleteDoesNotInvokeClusterServiceBroker verifies that if an instance
 // is created that is never actually provisioned the instance is able to be
 // deleted and is not blocked by any interaction with a broker (since its very
@@ -2661,8 +3874,176 @@ func TestPollServiceInstanceSuccessProvisioningWithOperation(t *testing.T) {
 	actions := fakeCatalogClient.Actions()
 	assertNumberOfActions(t, actions, 1)
 
-	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
-	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+}
+
+// TestPollServiceInstanceSuccessProvisioningWithDeletedPlan tests polling an
+// instance where provision was in process asynchronously and the plan was
+// deleted from the catalog in the meantime. The poll must use the plan
+// persisted in InProgressProperties and complete the provision.
+func TestPollServiceInstanceSuccessProvisioningWithDeletedPlan(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{
+				State:       osb.StateSucceeded,
+				Description: strPtr(lastOperationDescription),
+			},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+	instance := getTestServiceInstanceAsyncProvisioning(testOperation)
+	instanceKey := testNamespace + "/" + testServiceInstanceName
+
+	err := testController.pollServiceInstance(instance)
+	if err != nil {
+		t.Fatalf("pollServiceInstance failed: %s", err)
+	}
+
+	if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
+		t.Fatalf("Expected polling queue to not have requeues of test instance after polling have completed with a 'success' state")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	operationKey := osb.OperationKey(testOperation)
+	assertPollLastOperation(t, brokerActions[0], &osb.LastOperationRequest{
+		InstanceID:   testServiceInstanceGUID,
+		ServiceID:    strPtr(testClusterServiceClassGUID),
+		PlanID:       strPtr(testClusterServicePlanGUID),
+		OperationKey: &operationKey,
+	})
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+}
+
+// TestPollServiceInstanceInProgressUpdatingWithChangedPlan tests that polling
+// an asynchronous update reports the plan of the update to the broker, even
+// when the spec of the instance was changed to another plan in the meantime.
+func TestPollServiceInstanceInProgressUpdatingWithChangedPlan(t *testing.T) {
+	_, _, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{
+				State: osb.StateInProgress,
+			},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceAsyncUpdating(testOperation)
+	instance.Status.InProgressProperties.ClusterServicePlanExternalName = "in-flight-plan"
+	instance.Status.InProgressProperties.ClusterServicePlanExternalID = "in-flight-plan-id"
+
+	err := testController.pollServiceInstance(instance)
+	if err != nil {
+		t.Fatalf("pollServiceInstance failed: %s", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	operationKey := osb.OperationKey(testOperation)
+	assertPollLastOperation(t, brokerActions[0], &osb.LastOperationRequest{
+		InstanceID:   testServiceInstanceGUID,
+		ServiceID:    strPtr(testClusterServiceClassGUID),
+		PlanID:       strPtr("in-flight-plan-id"),
+		OperationKey: &operationKey,
+	})
+}
+
+// TestPollServiceInstanceLastOperationDescription tests that the description
+// of the last operation returned by the broker is recorded in the status of
+// the instance on each poll, truncated if too long, and cleared when the
+// operation completes.
+func TestPollServiceInstanceLastOperationDescription(t *testing.T) {
+	longDescription := strings.Repeat("creating database, 40%; ", 20)
+	cases := []struct {
+		name                string
+		state               osb.LastOperationState
+		description         *string
+		previousDescription string
+		expectedUpdate      bool
+		expectedDescription string
+	}{
+		{
+			name:                "in progress",
+			state:               osb.StateInProgress,
+			description:         strPtr(lastOperationDescription),
+			expectedUpdate:      true,
+			expectedDescription: lastOperationDescription,
+		},
+		{
+			name:                "in progress with a long description",
+			state:               osb.StateInProgress,
+			description:         strPtr(longDescription),
+			expectedUpdate:      true,
+			expectedDescription: longDescription[:maxLastOperationDescriptionLength-len(truncatedSuffix)] + truncatedSuffix,
+		},
+		{
+			name:                "in progress without description",
+			state:               osb.StateInProgress,
+			previousDescription: lastOperationDescription,
+			expectedUpdate:      true,
+		},
+		{
+			name:           "in progress without any description",
+			state:          osb.StateInProgress,
+			expectedUpdate: false,
+		},
+		{
+			name:                "succeeded",
+			state:               osb.StateSucceeded,
+			description:         strPtr(lastOperationDescription),
+			previousDescription: lastOperationDescription,
+			expectedUpdate:      true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+					Response: &osb.LastOperationResponse{
+						State:       tc.state,
+						Description: tc.description,
+					},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceAsyncProvisioning(testOperation)
+			instance.Status.LastOperationDescription = tc.previousDescription
+
+			if err := testController.pollServiceInstance(instance); err != nil {
+				t.Fatalf("pollServiceInstance failed: %s", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedUpdate {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if e, a := tc.expectedDescription, updatedServiceInstance.Status.LastOperationDescription; e != a {
+				t.Fatalf("unexpected last operation description: expected %q, got %q", e, a)
+			}
+		})
+	}
 }
 
 // TestPollServiceInstanceFailureProvisioningWithOperation tests polling an
@@ -3083,6 +4464,125 @@ func TestPollServiceInstanceStatusGoneDeprovisioningWithOperationNoFinalizer(t *
 	}
 }
 
+// TestPollServiceInstanceStatusGone tests polling the last operation of an
+// instance when the broker answers with a Gone status, for each operation.
+func TestPollServiceInstanceStatusGone(t *testing.T) {
+	cases := []struct {
+		name     string
+		instance func() *v1beta1.ServiceInstance
+		// errorExpected is whether polling returns an error, which makes
+		// the instance queue retry the instance to carry out orphan
+		// mitigation.
+		errorExpected bool
+		verify        func(t *testing.T, actions []clientgotesting.Action, instance *v1beta1.ServiceInstance)
+	}{
+		{
+			// The broker does not know the provision operation, the instance
+			// may still exist at the broker: the provision fails and orphan
+			// mitigation starts.
+			name: "provision",
+			instance: func() *v1beta1.ServiceInstance {
+				return getTestServiceInstanceAsyncProvisioning(testOperation)
+			},
+			errorExpected: true,
+			verify: func(t *testing.T, actions []clientgotesting.Action, instance *v1beta1.ServiceInstance) {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceRequestFailingErrorStartOrphanMitigation(
+					t,
+					updatedServiceInstance,
+					v1beta1.ServiceInstanceOperationProvision,
+					startingInstanceOrphanMitigationReason,
+					errorLastOperationGoneReason,
+					errorLastOperationGoneReason,
+					instance,
+				)
+			},
+		},
+		{
+			// The broker does not know the update operation: the update
+			// fails, the instance is left as it was and is not mitigated.
+			name: "update",
+			instance: func() *v1beta1.ServiceInstance {
+				return getTestServiceInstanceAsyncUpdating(testOperation)
+			},
+			verify: func(t *testing.T, actions []clientgotesting.Action, instance *v1beta1.ServiceInstance) {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceUpdateRequestFailingErrorNoOrphanMitigation(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, errorLastOperationGoneReason, errorLastOperationGoneReason, instance)
+			},
+		},
+		{
+			// The instance is gone at the broker: the deprovision succeeds
+			// and the finalizer is removed.
+			name: "deprovision",
+			instance: func() *v1beta1.ServiceInstance {
+				return getTestServiceInstanceAsyncDeprovisioning(testOperation)
+			},
+			verify: func(t *testing.T, actions []clientgotesting.Action, instance *v1beta1.ServiceInstance) {
+				assertNumberOfActions(t, actions, 2)
+				assertUpdateStatus(t, actions[0], instance)
+				updatedServiceInstance := assertUpdate(t, actions[1], instance)
+				assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+			},
+		},
+		{
+			// A Gone status is a success even when the retry duration of the
+			// deprovision has been exceeded.
+			name: "deprovision after the retry duration",
+			instance: func() *v1beta1.ServiceInstance {
+				instance := getTestServiceInstanceAsyncDeprovisioning(testOperation)
+				startTime := metav1.NewTime(time.Now().Add(-7 * 24 * time.Hour))
+				instance.Status.OperationStartTime = &startTime
+				return instance
+			},
+			verify: func(t *testing.T, actions []clientgotesting.Action, instance *v1beta1.ServiceInstance) {
+				assertNumberOfActions(t, actions, 2)
+				assertUpdateStatus(t, actions[0], instance)
+				updatedServiceInstance := assertUpdate(t, actions[1], instance)
+				assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+					Error: osb.HTTPStatusCodeError{
+						StatusCode: http.StatusGone,
+					},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			// simulate real update and return updated object,
+			// without that fake client will return empty ServiceInstances struct
+			fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+			instance := tc.instance()
+			instanceKey := testNamespace + "/" + testServiceInstanceName
+
+			err := testController.pollServiceInstance(instance)
+			if tc.errorExpected && err == nil {
+				t.Fatal("expected an error from pollServiceInstance")
+			} else if !tc.errorExpected && err != nil {
+				t.Fatalf("pollServiceInstance failed: %s", err)
+			}
+
+			if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
+				t.Fatalf("Expected polling queue to not have any record of test instance as polling should have completed")
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+			tc.verify(t, fakeCatalogClient.Actions(), instance)
+		})
+	}
+}
+
 // TestPollServiceInstanceClusterServiceBrokerTemporaryError simulates polling a broker and getting a
 // Forbidden status on the poll.  Test simulates that the ClusterServiceBroker was already
 // in the process of being deleted prior to the Forbidden status.
@@ -3281,6 +4781,55 @@ func TestPollServiceInstanceSuccessDeprovisioningWithOperationWithFinalizer(t *t
 
 // TestReconcileServiceInstanceSuccessOnFinalRetry verifies that reconciliation
 // can succeed on the last attempt before timing out of the retry loop
+// TestOperationRetryBackoffIndependentOfPolling tests that the backoff of
+// failed provision retries and the cadence of polls of in-progress operations
+// do not affect each other.
+func TestOperationRetryBackoffIndependentOfPolling(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	testController.recorder = record.NewFakeRecorder(100)
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.UID = "test-uid"
+	key, err := cache.MetaNamespaceKeyFunc(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Polls of an in-progress operation do not delay the first retry.
+	for i := 0; i < 10; i++ {
+		if err := testController.continuePollingServiceInstance(instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	testController.setRetryBackoffRequired(instance)
+	if !testController.backoffAndRequeueIfRetrying(instance, "provisioning") {
+		t.Fatal("expected the retry to be delayed")
+	}
+	delay := time.Until(testController.instanceOperationRetryQueue.instances[string(instance.UID)].calculatedRetryTime)
+	if delay > minBrokerOperationRetryDelay {
+		t.Fatalf("expected the first retry to be delayed by at most %v, got %v", minBrokerOperationRetryDelay, delay)
+	}
+
+	// The retry backoff is capped by its own maximum, not by the polling one.
+	for i := 0; i < 20; i++ {
+		testController.setRetryBackoffRequired(instance)
+		testController.backoffAndRequeueIfRetrying(instance, "provisioning")
+	}
+	delay = time.Until(testController.instanceOperationRetryQueue.instances[string(instance.UID)].calculatedRetryTime)
+	if delay > testController.operationRetryMaximumBackoffDuration || delay < testController.operationRetryMaximumBackoffDuration-time.Minute {
+		t.Fatalf("expected the retry to be delayed by about %v, got %v", testController.operationRetryMaximumBackoffDuration, delay)
+	}
+
+	// Failed retries do not slow down the polling of a new operation.
+	testController.instancePollingQueue.Forget(key)
+	if err := testController.continuePollingServiceInstance(instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := 1, testController.instancePollingQueue.NumRequeues(key); e != a {
+		t.Fatalf("expected the poll to be requeued with the initial polling interval after %v requeue, got %v", e, a)
+	}
+}
+
 func TestReconcileServiceInstanceSuccessOnFinalRetry(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
 		ProvisionReaction: &fakeosb.ProvisionReaction{
@@ -3613,6 +5162,124 @@ func TestPollServiceInstanceFailureOnFinalRetry(t *testing.T) {
 	assertNumberOfActions(t, kubeActions, 0)
 }
 
+// TestPollServiceInstanceAsyncOperationTimeout verifies that polling an
+// operation the broker keeps reporting as in progress stops once the
+// operation has been in progress for longer than the async operation timeout
+// of the controller, or of the async-operation-timeout annotation of the
+// instance.
+func TestPollServiceInstanceAsyncOperationTimeout(t *testing.T) {
+	cases := []struct {
+		name       string
+		timeout    time.Duration
+		annotation string
+		timedOut   bool
+	}{
+		{
+			name:     "timeout exceeded",
+			timeout:  time.Hour,
+			timedOut: true,
+		},
+		{
+			name:    "timeout not exceeded",
+			timeout: 3 * time.Hour,
+		},
+		{
+			name: "timeout disabled",
+		},
+		{
+			name:       "timeout extended by the annotation",
+			timeout:    time.Hour,
+			annotation: "3h",
+		},
+		{
+			name:       "timeout disabled by the annotation",
+			timeout:    time.Hour,
+			annotation: "0",
+		},
+		{
+			name:       "timeout set by the annotation",
+			annotation: "1h",
+			timedOut:   true,
+		},
+		{
+			name:       "invalid annotation",
+			timeout:    time.Hour,
+			annotation: "forever",
+			timedOut:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+					Response: &osb.LastOperationResponse{
+						State:       osb.StateInProgress,
+						Description: strPtr(lastOperationDescription),
+					},
+				},
+			})
+			testController.asyncOperationTimeout = tc.timeout
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceAsyncProvisioning(testOperation)
+			instanceKey := testNamespace + "/" + testServiceInstanceName
+			startTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+			instance.Status.OperationStartTime = &startTime
+			if tc.annotation != "" {
+				instance.Annotations = map[string]string{v1beta1.ServiceInstanceAsyncOperationTimeoutAnnotation: tc.annotation}
+			}
+
+			err := testController.pollServiceInstance(instance)
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			operationKey := osb.OperationKey(testOperation)
+			assertPollLastOperation(t, brokerActions[0], &osb.LastOperationRequest{
+				InstanceID:   testServiceInstanceGUID,
+				ServiceID:    strPtr(testClusterServiceClassGUID),
+				PlanID:       strPtr(testClusterServicePlanGUID),
+				OperationKey: &operationKey,
+			})
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+
+			if tc.timedOut {
+				if err == nil {
+					t.Fatalf("Expected error to be returned in order to requeue instance for orphan mitigation")
+				}
+				if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
+					t.Fatalf("Expected polling queue to not have any record of test instance as polling should have completed")
+				}
+				assertServiceInstanceRequestFailingErrorStartOrphanMitigation(
+					t,
+					updatedServiceInstance,
+					v1beta1.ServiceInstanceOperationProvision,
+					startingInstanceOrphanMitigationReason,
+					operationTimedOutReason,
+					asyncProvisioningReason,
+					instance,
+				)
+			} else {
+				if err != nil {
+					t.Fatalf("pollServiceInstance failed: %s", err)
+				}
+				if testController.instancePollingQueue.NumRequeues(instanceKey) != 1 {
+					t.Fatalf("Expected polling queue to have record of seeing test instance once")
+				}
+				assertServiceInstanceAsyncStartInProgress(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testOperation, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+			}
+
+			kubeActions := fakeKubeClient.Actions()
+			assertNumberOfActions(t, kubeActions, 0)
+		})
+	}
+}
+
 // TestReconcileServiceInstanceWithStatusUpdateError verifies that the reconciler
 // returns an error when there is a conflict updating the status of the resource.
 // This is an otherwise successful scenario where the update to set the
@@ -3730,6 +5397,13 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 		return c
 	}
 
+	// withUpdateTime sets the LastConditionUpdateTime to the 'new' basis time
+	// and returns it.
+	withUpdateTime := func(o *v1beta1.ServiceInstance) *v1beta1.ServiceInstance {
+		o.Status.LastConditionUpdateTime = &newTs
+		return o
+	}
+
 	// this test works by calling setServiceInstanceCondition with the input and
 	// condition fields of the test case, and ensuring that afterward the
 	// input (which is mutated by the setServiceInstanceCondition call) is deep-equal
@@ -3753,7 +5427,7 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 			name:      "new ready condition",
 			input:     getTestServiceInstance(),
 			condition: readyFalse(),
-			result:    instanceWithCondition(withNewTs(readyFalse())),
+			result:    withUpdateTime(instanceWithCondition(withNewTs(readyFalse()))),
 		},
 		{
 			name:      "not ready -> not ready; no ts update",
@@ -3765,13 +5439,13 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 			name:      "not ready -> not ready, reason and message change; no ts update",
 			input:     instanceWithCondition(readyFalse()),
 			condition: readyFalsef("DifferentReason", "DifferentMessage"),
-			result:    instanceWithCondition(readyFalsef("DifferentReason", "DifferentMessage")),
+			result:    withUpdateTime(instanceWithCondition(readyFalsef("DifferentReason", "DifferentMessage"))),
 		},
 		{
 			name:      "not ready -> ready",
 			input:     instanceWithCondition(readyFalse()),
 			condition: readyTrue(),
-			result:    instanceWithCondition(withNewTs(readyTrue())),
+			result:    withUpdateTime(instanceWithCondition(withNewTs(readyTrue()))),
 		},
 		{
 			name:      "ready -> ready; no ts update",
@@ -3783,7 +5457,7 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 			name:      "ready -> not ready",
 			input:     instanceWithCondition(readyTrue()),
 			condition: readyFalse(),
-			result:    instanceWithCondition(withNewTs(readyFalse())),
+			result:    withUpdateTime(instanceWithCondition(withNewTs(readyFalse()))),
 		},
 		{
 			name:      "not ready -> not ready + failed",
@@ -3792,6 +5466,20 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 			result: func() *v1beta1.ServiceInstance {
 				i := instanceWithCondition(readyFalse())
 				i.Status.Conditions = append(i.Status.Conditions, *withNewTs(failedTrue()))
+				return withUpdateTime(i)
+			}(),
+		},
+		{
+			name: "conditions are sorted; no ts update",
+			input: func() *v1beta1.ServiceInstance {
+				i := instanceWithCondition(failedTrue())
+				i.Status.Conditions = append(i.Status.Conditions, *readyFalse())
+				return i
+			}(),
+			condition: readyFalse(),
+			result: func() *v1beta1.ServiceInstance {
+				i := instanceWithCondition(readyFalse())
+				i.Status.Conditions = append(i.Status.Conditions, *failedTrue())
 				return i
 			}(),
 		},
@@ -3806,6 +5494,50 @@ func TestSetServiceInstanceCondition(t *testing.T) {
 	}
 }
 
+// TestSetServiceInstanceConditionStable ensures that setting the same conditions
+// again, in any order and at a later time, does not change the serialized
+// conditions of a instance nor its lastConditionUpdateTime.
+func TestSetServiceInstanceConditionStable(t *testing.T) {
+	type conditionSet struct {
+		conditionType v1beta1.ServiceInstanceConditionType
+		status        v1beta1.ConditionStatus
+	}
+	conditions := []conditionSet{
+		{v1beta1.ServiceInstanceConditionOrphanMitigation, v1beta1.ConditionTrue},
+		{v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue},
+		{v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionFalse},
+	}
+
+	instance := getTestServiceInstance()
+	firstTs := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	for _, c := range conditions {
+		setServiceInstanceConditionInternal(instance, c.conditionType, c.status, "Reason", "Message", firstTs)
+	}
+	if e, a := v1beta1.ServiceInstanceConditionReady, instance.Status.Conditions[0].Type; e != a {
+		t.Fatalf("unexpected first condition: expected %v, got %v", e, a)
+	}
+	first, err := json.Marshal(instance.Status.Conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secondTs := metav1.Now()
+	for i := len(conditions) - 1; i >= 0; i-- {
+		setServiceInstanceConditionInternal(instance, conditions[i].conditionType, conditions[i].status, "Reason", "Message", secondTs)
+	}
+	second, err := json.Marshal(instance.Status.Conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Fatalf("conditions changed\nfirst:  %s\nsecond: %s", first, second)
+	}
+	if e, a := firstTs, *instance.Status.LastConditionUpdateTime; !e.Equal(&a) {
+		t.Fatalf("unexpected lastConditionUpdateTime: expected %v, got %v", e, a)
+	}
+}
+
 // TestUpdateServiceInstanceCondition ensures that with the expected conditions the
 // updateServiceInstanceCondition() results in a correct status & associated
 // conditions and the expected client actions are verified test cases prove:
@@ -4928,29 +6660,92 @@ func TestReconcileServiceInstanceDeleteParameters(t *testing.T) {
 	assertNumberOfActions(t, actions, 1)
 
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
-	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, testClusterServicePlanName, testClusterServicePlanGUID, instance)
-
-	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
-	if !ok {
-		t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
-	}
-
-	if updateObject.Spec.Parameters != nil {
-		t.Fatalf("Parameters was unexpectedly not empty")
-	}
-
-	// verify no kube resources created
-	// One single action comes from getting namespace uid
-	kubeActions := fakeKubeClient.Actions()
-	if err := checkKubeClientActions(kubeActions, []kubeClientAction{
-		{verb: "get", resourceName: "namespaces", checkType: checkGetActionType},
-	}); err != nil {
-		t.Fatal(err)
-	}
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+
+	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if !ok {
+		t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
+	}
+
+	if updateObject.Spec.Parameters != nil {
+		t.Fatalf("Parameters was unexpectedly not empty")
+	}
+
+	// verify no kube resources created
+	// One single action comes from getting namespace uid
+	kubeActions := fakeKubeClient.Actions()
+	if err := checkKubeClientActions(kubeActions, []kubeClientAction{
+		{verb: "get", resourceName: "namespaces", checkType: checkGetActionType},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := normalEventBuilder(successUpdateInstanceReason).msg("The instance was updated successfully")
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceUpdateReorderedParameters tests that updating
+// a ServiceInstance with parameters that only differ from those last sent to
+// the broker in the ordering of their keys and in whitespace does not send an
+// update request to the broker
+func TestReconcileServiceInstanceUpdateReorderedParameters(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+			Response: &osb.UpdateInstanceResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	oldParameters := map[string]interface{}{
+		"args": map[string]interface{}{
+			"first":  "first-arg",
+			"second": "second-arg",
+		},
+		"name": "test-param",
+	}
+	oldParametersMarshaled, err := MarshalRawParameters(oldParameters)
+	if err != nil {
+		t.Fatalf("Failed to marshal parameters: %v", err)
+	}
+
+	instance := getTestServiceInstanceUpdatingPlan()
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+		Parameters:                     &runtime.RawExtension{Raw: oldParametersMarshaled},
+		ParameterChecksum:              generateChecksumOfParametersOrFail(t, oldParameters),
+	}
+	instance.Spec.Parameters = &runtime.RawExtension{
+		Raw: []byte(`{ "name": "test-param", "args": { "second": "second-arg", "first": "first-arg" } }`),
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance)
+	assertServiceInstanceCurrentOperationClear(t, updatedServiceInstance)
+	assertServiceInstanceReconciledGeneration(t, updatedServiceInstance, instance.Generation)
+	assertServiceInstanceObservedGeneration(t, updatedServiceInstance, instance.Generation)
+	assertServiceInstanceExternalPropertiesParameters(t, updatedServiceInstance, oldParameters, instance.Status.ExternalProperties.ParameterChecksum)
 
 	events := getRecordedEvents(testController)
 
-	expectedEvent := normalEventBuilder(successUpdateInstanceReason).msg("The instance was updated successfully")
+	expectedEvent := normalEventBuilder(updateNotNeededReason).msg(updateNotNeededMessage)
 	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
 		t.Fatal(err)
 	}
@@ -5668,6 +7463,139 @@ func TestResolveReferencesWorksK8SNames(t *testing.T) {
 	assertNumEvents(t, events, 0)
 }
 
+// TestResolveReferencesK8SNamesPlanOfAnotherClass tests that a plan referenced
+// by its Kubernetes name is not resolved when it belongs to another class.
+func TestResolveReferencesK8SNamesPlanOfAnotherClass(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	plan := getTestClusterServicePlan()
+	plan.Spec.ClusterServiceClassRef.Name = "another-class"
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
+
+	instance := getTestServiceInstanceK8SNames()
+
+	if _, err := testController.resolveReferences(instance); err == nil {
+		t.Fatal("Should have failed because the plan belongs to another class")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorNonexistentClusterServicePlanReason)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorNonexistentClusterServicePlanReason).msgf(
+		"References ClusterServicePlan %q which does not belong to ClusterServiceClass %q",
+		testClusterServicePlanGUID, testClusterServiceClassGUID,
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestResolveReferencesClusterServiceClassSelector tests that a class selector
+// is resolved to the only class it matches, ignoring the classes removed from
+// the catalog of their broker, and that the instance references no class when
+// the selector matches zero or several classes.
+func TestResolveReferencesClusterServiceClassSelector(t *testing.T) {
+	selectedClass := func(name string, removed bool) v1beta1.ClusterServiceClass {
+		class := getTestClusterServiceClass()
+		class.Name = name
+		class.Labels = map[string]string{"database": "postgres"}
+		class.Status.RemovedFromBrokerCatalog = removed
+		return *class
+	}
+	otherClass := getTestClusterServiceClass()
+	otherClass.Name = "other-class"
+	otherClass.Labels = map[string]string{"database": "mysql"}
+
+	cases := []struct {
+		name    string
+		classes []v1beta1.ClusterServiceClass
+		found   int
+	}{
+		{
+			name:    "single match",
+			classes: []v1beta1.ClusterServiceClass{selectedClass(testClusterServiceClassGUID, false), *otherClass},
+			found:   1,
+		},
+		{
+			name:    "removed class ignored",
+			classes: []v1beta1.ClusterServiceClass{selectedClass(testClusterServiceClassGUID, false), selectedClass("removed-class", true)},
+			found:   1,
+		},
+		{
+			name:    "no match",
+			classes: []v1beta1.ClusterServiceClass{*otherClass},
+			found:   0,
+		},
+		{
+			name:    "several matches",
+			classes: []v1beta1.ClusterServiceClass{selectedClass(testClusterServiceClassGUID, false), selectedClass("another-class", false)},
+			found:   2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+			fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ClusterServiceClassList{Items: tc.classes}, nil
+			})
+			fakeCatalogClient.AddReactor("list", "clusterserviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ClusterServicePlanList{Items: []v1beta1.ClusterServicePlan{*getTestClusterServicePlan()}}, nil
+			})
+
+			instance := getTestServiceInstance()
+			instance.Spec.PlanReference = v1beta1.PlanReference{
+				ClusterServiceClassSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"database": "postgres"}},
+				ClusterServicePlanExternalName: testClusterServicePlanName,
+			}
+
+			_, err := testController.resolveReferences(instance)
+
+			actions := fakeCatalogClient.Actions()
+			assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, clientgotesting.ListRestrictions{
+				Labels: labels.SelectorFromSet(labels.Set{"database": "postgres"}),
+				Fields: fields.Everything(),
+			})
+
+			if tc.found != 1 {
+				if err == nil {
+					t.Fatal("Should have failed to resolve the class")
+				}
+				assertNumberOfActions(t, actions, 2)
+				updatedServiceInstance := assertUpdateStatus(t, actions[1], instance)
+				assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorNonexistentClusterServiceClassReason)
+				events := getRecordedEvents(testController)
+				expectedEvent := warningEventBuilder(errorNonexistentClusterServiceClassReason).msgf(
+					"The selector %c must match exactly one ClusterServiceClass (found: %d)",
+					instance.Spec.PlanReference, tc.found,
+				)
+				if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Should not have failed, but failed with: %q", err)
+			}
+			assertNumberOfActions(t, actions, 3)
+			updatedServiceInstance := assertUpdate(t, actions[2], instance).(*v1beta1.ServiceInstance)
+			if ref := updatedServiceInstance.Spec.ClusterServiceClassRef; ref == nil || ref.Name != testClusterServiceClassGUID {
+				t.Fatalf("ClusterServiceClassRef was not resolved correctly: %+v", ref)
+			}
+			if ref := updatedServiceInstance.Spec.ClusterServicePlanRef; ref == nil || ref.Name != testClusterServicePlanGUID {
+				t.Fatalf("ClusterServicePlanRef was not resolved correctly: %+v", ref)
+			}
+		})
+	}
+}
+
 // TestReconcileServiceInstanceUpdateAsynchronous tests updating a ServiceInstance
 // when the request results in an async response. Resulting status will indicate
 // not ready and polling in progress.
@@ -5746,6 +7674,216 @@ func TestReconcileServiceInstanceUpdateAsynchronous(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceAsyncRequiredOnProvision tests that a
+// synchronous provision request which the broker rejects with 422
+// AsyncRequired is sent again with accepts_incomplete, and that the
+// provisioning continues asynchronously.
+func TestReconcileServiceInstanceAsyncRequiredOnProvision(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: fakeosb.DynamicProvisionReaction(func(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+			if !r.AcceptsIncomplete {
+				return nil, fakeosb.AsyncRequiredError()
+			}
+			return &osb.ProvisionResponse{Async: true, OperationKey: &key}, nil
+		}),
+	})
+	testController.osbAPIAcceptsIncomplete = false
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	expectedRequest := &osb.ProvisionRequest{
+		AcceptsIncomplete: false,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		OrganizationGUID:  testClusterID,
+		SpaceGUID:         testNamespaceGUID,
+		Context:           testContext,
+	}
+	assertProvision(t, brokerActions[0], expectedRequest)
+	expectedRequest.AcceptsIncomplete = true
+	assertProvision(t, brokerActions[1], expectedRequest)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceAsyncStartInProgress(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testOperation, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(asyncRequiredReason).msg(asyncRequiredMessage).String(),
+		normalEventBuilder(asyncProvisioningReason).String(),
+	}
+	if err := checkEventPrefixes(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceAsyncRequiredOnUpdate tests that a synchronous
+// update request which the broker rejects with 422 AsyncRequired is sent
+// again with accepts_incomplete, and that the update continues
+// asynchronously.
+func TestReconcileServiceInstanceAsyncRequiredOnUpdate(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: fakeosb.DynamicUpdateInstanceReaction(func(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+			if !r.AcceptsIncomplete {
+				return nil, fakeosb.AsyncRequiredError()
+			}
+			return &osb.UpdateInstanceResponse{Async: true, OperationKey: &key}, nil
+		}),
+	})
+	testController.osbAPIAcceptsIncomplete = false
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: "old-plan-name",
+		ClusterServicePlanExternalID:   "old-plan-id",
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceUpdateInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	expectedPlanID := testClusterServicePlanGUID
+	expectedRequest := &osb.UpdateInstanceRequest{
+		AcceptsIncomplete: false,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            &expectedPlanID,
+		Context:           testContext,
+		PreviousValues:    &osb.PreviousValues{PlanID: "old-plan-id", ServiceID: testClusterServiceClassGUID},
+	}
+	assertUpdateInstance(t, brokerActions[0], expectedRequest)
+	expectedRequest.AcceptsIncomplete = true
+	assertUpdateInstance(t, brokerActions[1], expectedRequest)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceAsyncStartInProgress(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, testOperation, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(asyncRequiredReason).msg(asyncRequiredMessage).String(),
+		normalEventBuilder(asyncUpdatingInstanceReason).String(),
+	}
+	if err := checkEventPrefixes(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceAsyncRequiredOnDeprovision tests that a
+// synchronous deprovision request which the broker rejects with 422
+// AsyncRequired is sent again with accepts_incomplete, and that the
+// deprovisioning continues asynchronously.
+func TestReconcileServiceInstanceAsyncRequiredOnDeprovision(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: fakeosb.DynamicDeprovisionReaction(func(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+			if !r.AcceptsIncomplete {
+				return nil, fakeosb.AsyncRequiredError()
+			}
+			return &osb.DeprovisionResponse{Async: true, OperationKey: &key}, nil
+		}),
+	})
+	testController.osbAPIAcceptsIncomplete = false
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	expectedRequest := &osb.DeprovisionRequest{
+		AcceptsIncomplete: false,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+	}
+	assertDeprovision(t, brokerActions[0], expectedRequest)
+	expectedRequest.AcceptsIncomplete = true
+	assertDeprovision(t, brokerActions[1], expectedRequest)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceAsyncStartInProgress(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testOperation, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(asyncRequiredReason).msg(asyncRequiredMessage).String(),
+		normalEventBuilder(asyncDeprovisioningReason).String(),
+	}
+	if err := checkEventPrefixes(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestPollServiceInstanceAsyncInProgressUpdating tests polling an instance that
 // is already in process of updating (background/asynchronously) and is still in
 // progress (should be re-polled)
@@ -6448,3 +8586,52 @@ func assertServiceInstanceOperationInProgressWithParameterAndUserSpecifiedFields
 
 	return updateObject
 }
+
+// TestBuildRequestContextPlatformAndClusterID tests that the OSB context of
+// an instance carries the platform and the cluster ID the controller was
+// configured with.
+func TestBuildRequestContextPlatformAndClusterID(t *testing.T) {
+	cases := []struct {
+		name      string
+		platform  string
+		clusterID string
+	}{
+		{
+			name:      "defaults",
+			platform:  "",
+			clusterID: testClusterID,
+		},
+		{
+			name:      "configured platform and cluster ID",
+			platform:  "openshift",
+			clusterID: "configured-cluster-id",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+			if tc.platform != "" {
+				testController.osbAPIContextPlatform = tc.platform
+			}
+			testController.configuredClusterID = tc.clusterID
+			testController.setClusterID(tc.clusterID)
+
+			expectedPlatform := tc.platform
+			if expectedPlatform == "" {
+				expectedPlatform = ContextProfilePlatformKubernetes
+			}
+			expected := map[string]interface{}{
+				"platform":           expectedPlatform,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: tc.clusterID,
+				"instance_name":      testServiceInstanceName,
+			}
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, UID: testNamespaceGUID}}
+			actual := testController.buildRequestContext(getTestServiceInstance(), ns)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("unexpected request context: %v", diff.ObjectReflectDiff(expected, actual))
+			}
+		})
+	}
+}
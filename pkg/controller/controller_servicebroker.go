@@ -18,6 +18,7 @@ package controller
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
@@ -76,6 +78,7 @@ func (c *controller) serviceBrokerDelete(obj interface{}) {
 		return
 	}
 
+	c.brokerCatalogs.Delete(broker.UID)
 	klog.V(4).Infof("Received delete event for ServiceBroker %v; no further processing will occur", broker.Name)
 }
 
@@ -127,7 +130,7 @@ func (c *controller) serviceBrokerClient(broker *v1beta1.ServiceBroker) (osb.Cli
 		return nil, err
 	}
 
-	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut)
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
 
 	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig)
 	if err != nil {
@@ -140,7 +143,27 @@ func (c *controller) serviceBrokerClient(broker *v1beta1.ServiceBroker) (osb.Cli
 		return nil, err
 	}
 
-	return brokerClient, nil
+	brokerKey := NewServiceBrokerKey(broker.Namespace, broker.Name)
+	brokerClient = newTLSRecoveringBrokerClient(brokerKey.String(), brokerClient, func() (osb.Client, error) {
+		return c.recreateServiceBrokerClient(broker.Namespace, broker.Name)
+	})
+	return c.brokerRequestLimiter.limitClient(brokerKey, brokerClient), nil
+}
+
+// recreateServiceBrokerClient creates a new client for the broker from its
+// current spec, including its CA bundle, without reusing the connections of
+// the previous client.
+func (c *controller) recreateServiceBrokerClient(namespace, name string) (osb.Client, error) {
+	broker, err := c.serviceBrokerLister.ServiceBrokers(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	authConfig, err := c.getAuthCredentialsFromServiceBroker(broker)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut, c.brokerTLSConfig, c.osbAPIUserAgentSuffix)
+	return c.brokerClientManager.RecreateBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig)
 }
 
 // reconcileServiceBroker is the control-loop that reconciles a ServiceBroker. An
@@ -150,11 +173,38 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
+	// Expose the age of the catalog also for brokers which are not relisted
+	// yet, e.g. after a restart of the controller.
+	if broker.DeletionTimestamp == nil && broker.Status.LastCatalogRetrievalTime != nil {
+		metrics.BrokerSecondsSinceLastRelist.Set(broker.Status.LastCatalogRetrievalTime.Time, broker.Name)
+	}
+
+	// Probe the broker independently of its relists, so that a broker that
+	// is down can be told apart from a broker whose catalog is invalid.
+	if broker.DeletionTimestamp == nil {
+		var err error
+		broker, err = c.checkServiceBrokerHealth(broker)
+		if err != nil {
+			return err
+		}
+		if d, ok := c.timeUntilNextHealthCheck(&broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, time.Now()); ok {
+			klog.V(10).Info(pcb.Messagef("Probing the health of the broker in %v", d))
+			c.serviceBrokerQueue.AddAfter(broker.Namespace+"/"+broker.Name, d)
+		}
+	}
+
 	// * If the broker's ready condition is true and the RelistBehavior has been
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
 	if !shouldReconcileServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+		// The catalog was fetched recently, possibly by the previous leader;
+		// relist it when its relist interval elapses instead of waiting for
+		// the next resync.
+		if d, ok := timeUntilNextRelist(&broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, time.Now(), c.brokerRelistInterval); ok {
+			klog.V(10).Info(pcb.Messagef("Relisting in %v", d))
+			c.serviceBrokerQueue.AddAfter(broker.Namespace+"/"+broker.Name, d)
+		}
 		return nil
 	}
 
@@ -165,15 +215,42 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		if err != nil {
 			return err
 		}
+		brokerClient = c.traceBrokerClient("ServiceBroker", broker.ObjectMeta, NewServiceBrokerKey(broker.Namespace, broker.Name), brokerClient)
+
+		// record the relist in the status, so that a relist which takes
+		// long can be told apart from one that is wedged
+		toUpdate := broker.DeepCopy()
+		if changed, stuckMessage := c.startCommonRelist(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now()); changed {
+			toUpdate.RecalculatePrinterColumnStatusFields()
+			updated, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate)
+			if err != nil {
+				klog.Error(pcb.Messagef("Error recording the start of the relist: %v", err))
+				return err
+			}
+			broker = updated
+			if stuckMessage != "" {
+				c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
+			}
+		}
 
 		// get the broker's catalog
 		now := metav1.Now()
-		brokerCatalog, err := brokerClient.GetCatalog()
+		relistInterval := c.brokerRelistInterval
+		if broker.Spec.RelistDuration != nil {
+			relistInterval = broker.Spec.RelistDuration.Duration
+		}
+		syncCatalog, fetched, err := c.catalogForSync(broker.UID, broker.Generation, relistInterval, now.Time, func() (*brokerhttp.CatalogResponse, error) {
+			return brokerhttp.GetCatalog(brokerClient)
+		})
+		if isBrokerRequestLimitError(err) {
+			return err
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			reason := catalogFetchErrorReason(err)
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, errorFetchingCatalogMessage+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -190,8 +267,10 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				klog.Info(pcb.Message(s))
 				c.recorder.Event(broker, corev1.EventTypeWarning, errorReconciliationRetryTimeoutReason, s)
 				toUpdate := broker.DeepCopy()
+				toUpdate.Status.CurrentOperation = ""
 				toUpdate.Status.OperationStartTime = nil
 				toUpdate.Status.ReconciledGeneration = toUpdate.Generation
+				toUpdate.Status.LastRelistRequestProcessed = toUpdate.Spec.RelistRequests
 				return c.updateServiceBrokerCondition(toUpdate,
 					v1beta1.ServiceBrokerConditionFailed,
 					v1beta1.ConditionTrue,
@@ -201,10 +280,19 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			return err
 		}
 
-		klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
+		brokerCatalog := syncCatalog.catalog
+		if fetched {
+			klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
+		} else {
+			klog.V(4).Info(pcb.Messagef("Reusing the catalog fetched at %v to retry writing %v catalog entries", syncCatalog.fetchTime, len(brokerCatalog.Services)))
+		}
+		// keep the catalog until all of its classes and plans are written,
+		// so that a retry does not fetch it again
+		c.brokerCatalogs.Set(broker.UID, syncCatalog)
 
-		// set the operation start time if not already set
-		if broker.Status.OperationStartTime != nil {
+		// clear the operation start time of the retries, unless it is
+		// the start time of the recorded relist
+		if broker.Status.OperationStartTime != nil && broker.Status.CurrentOperation == "" {
 			toUpdate := broker.DeepCopy()
 			toUpdate.Status.OperationStartTime = nil
 			if _, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate); err != nil {
@@ -224,11 +312,20 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		existingServiceClassMap := convertServiceClassListToMap(existingServiceClasses)
 		existingServicePlanMap := convertServicePlanListToMap(existingServicePlans)
 
+		if c.classWithoutPlansPolicy == ClassWithoutPlansPolicySkip {
+			if skipped := skipServicesWithoutPlans(&brokerCatalog.CatalogResponse); len(skipped) > 0 {
+				s := fmt.Sprintf(skippedServicesWithoutPlansMessage, strings.Join(skipped, ", "))
+				klog.Warning(pcb.Message(s))
+				c.recorder.Event(broker, corev1.EventTypeWarning, skippedServicesWithoutPlansReason, s)
+			}
+		}
+
 		// convert the broker's catalog payload into our API objects
 		klog.V(4).Info(pcb.Message("Converting catalog response into service-catalog API"))
 
-		payloadServiceClasses, payloadServicePlans, err := convertAndFilterCatalogToNamespacedTypes(broker.Namespace, brokerCatalog, broker.Spec.CatalogRestrictions, existingServiceClassMap, existingServicePlanMap)
+		payloadServiceClasses, payloadServicePlans, err := convertAndFilterCatalogToNamespacedTypes(broker.Namespace, &brokerCatalog.CatalogResponse, broker.Spec.CatalogRestrictions, existingServiceClassMap, existingServicePlanMap)
 		if err != nil {
+			c.brokerCatalogs.Delete(broker.UID)
 			s := fmt.Sprintf("Error converting catalog payload for broker %q to service-catalog API: %s", broker.Name, err)
 			klog.Warning(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
@@ -239,9 +336,16 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		}
 
 		klog.V(5).Info(pcb.Message("Successfully converted catalog payload from to service-catalog API"))
+		for _, payloadServiceClass := range payloadServiceClasses {
+			payloadServiceClass.Spec.InstancesRetrievable = brokerCatalog.InstancesRetrievable[payloadServiceClass.Spec.ExternalID]
+		}
 
 		// reconcile the serviceClasses that were part of the broker's catalog
-		// payload
+		// payload. The classes are written before the plans, and the plans
+		// are marked as removed before the classes, so that a plan never
+		// refers to a class that is missing or removed while the catalog is
+		// synced.
+		syncedServiceClassNames := sets.NewString()
 		for _, payloadServiceClass := range payloadServiceClasses {
 			existingServiceClass, _ := existingServiceClassMap[payloadServiceClass.Name]
 			delete(existingServiceClassMap, payloadServiceClass.Name)
@@ -250,6 +354,12 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				delete(existingServiceClassMap, payloadServiceClass.Spec.ExternalID)
 			}
 
+			if syncCatalog.appliedClasses.Has(payloadServiceClass.Name) {
+				klog.V(5).Info(pcb.Messagef("%s was written by the previous attempt", pretty.ServiceClassName(payloadServiceClass)))
+				syncedServiceClassNames.Insert(payloadServiceClass.Name)
+				continue
+			}
+
 			klog.V(4).Info(pcb.Messagef("Reconciling %s", pretty.ServiceClassName(payloadServiceClass)))
 			if err := c.reconcileServiceClassFromServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass); err != nil {
 				s := fmt.Sprintf(
@@ -266,22 +376,16 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServiceClassName(payloadServiceClass)))
+			syncedServiceClassNames.Insert(payloadServiceClass.Name)
+			syncCatalog.appliedClasses.Insert(payloadServiceClass.Name)
 		}
 
-		// handle the serviceClasses that were not in the broker's payload;
-		// mark these as having been removed from the broker's catalog
-		for _, existingServiceClass := range existingServiceClassMap {
-			if existingServiceClass.Status.RemovedFromBrokerCatalog {
-				continue
-			}
-
-			klog.V(4).Info(pcb.Messagef("%s has been removed from broker's catalog; marking", pretty.ServiceClassName(existingServiceClass)))
-			existingServiceClass.Status.RemovedFromBrokerCatalog = true
-			_, err := c.serviceCatalogClient.ServiceClasses(broker.Namespace).UpdateStatus(existingServiceClass)
-			if err != nil {
+		// reconcile the plans that were part of the broker's catalog payload
+		for _, payloadServicePlan := range payloadServicePlans {
+			if !syncedServiceClassNames.Has(payloadServicePlan.Spec.ServiceClassRef.Name) {
 				s := fmt.Sprintf(
-					"Error updating status of %s: %v",
-					pretty.ServiceClassName(existingServiceClass), err,
+					"Error reconciling %s: its class %q was not synced",
+					pretty.ServicePlanName(payloadServicePlan), payloadServicePlan.Spec.ServiceClassRef.Name,
 				)
 				klog.Warning(pcb.Message(s))
 				c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
@@ -289,12 +393,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 					errorSyncingCatalogMessage+s); err != nil {
 					return err
 				}
-				return err
+				return fmt.Errorf("%s", s)
 			}
-		}
-
-		// reconcile the plans that were part of the broker's catalog payload
-		for _, payloadServicePlan := range payloadServicePlans {
 			existingServicePlan, _ := existingServicePlanMap[payloadServicePlan.Name]
 			delete(existingServicePlanMap, payloadServicePlan.Name)
 			if existingServicePlan == nil {
@@ -302,6 +402,11 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				delete(existingServicePlanMap, payloadServicePlan.Spec.ExternalID)
 			}
 
+			if syncCatalog.appliedPlans.Has(payloadServicePlan.Name) {
+				klog.V(5).Info(pcb.Messagef("%s was written by the previous attempt", pretty.ServicePlanName(payloadServicePlan)))
+				continue
+			}
+
 			klog.V(4).Infof(
 				"ServiceBroker %q: reconciling %s",
 				broker.Name, pretty.ServicePlanName(payloadServicePlan),
@@ -318,7 +423,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				return err
 			}
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServicePlanName(payloadServicePlan)))
-
+			syncCatalog.appliedPlans.Insert(payloadServicePlan.Name)
 		}
 
 		// handle the servicePlans that were not in the broker's payload;
@@ -346,17 +451,57 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 		}
 
+		// handle the serviceClasses that were not in the broker's payload;
+		// mark these as having been removed from the broker's catalog, once
+		// their plans were marked
+		for _, existingServiceClass := range existingServiceClassMap {
+			if existingServiceClass.Status.RemovedFromBrokerCatalog {
+				continue
+			}
+
+			klog.V(4).Info(pcb.Messagef("%s has been removed from broker's catalog; marking", pretty.ServiceClassName(existingServiceClass)))
+			existingServiceClass.Status.RemovedFromBrokerCatalog = true
+			_, err := c.serviceCatalogClient.ServiceClasses(broker.Namespace).UpdateStatus(existingServiceClass)
+			if err != nil {
+				s := fmt.Sprintf(
+					"Error updating status of %s: %v",
+					pretty.ServiceClassName(existingServiceClass), err,
+				)
+				klog.Warning(pcb.Message(s))
+				c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
+				if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason,
+					errorSyncingCatalogMessage+s); err != nil {
+					return err
+				}
+				return err
+			}
+		}
+
+		// all of the classes and plans of the catalog were written
+		c.brokerCatalogs.Delete(broker.UID)
+
 		// everything worked correctly; update the broker's ready condition to
-		// status true
+		// status true. The catalog was retrieved when it was fetched, which
+		// is earlier than now when a retry reused it.
+		wasReady := isServiceBrokerReady(&broker.Status.CommonServiceBrokerStatus)
+		fetchTime := metav1.NewTime(syncCatalog.fetchTime)
+		broker = broker.DeepCopy()
+		broker.Status.LastCatalogRetrievalTime = &fetchTime
 		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
+		// retry the requests that failed while the broker was unreachable
+		if !wasReady {
+			c.requeueServiceBrokerDependents(broker)
+		}
+
 		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
 
 		// Update metrics with the number of serviceclass and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
 		metrics.BrokerServicePlanCount.WithLabelValues(broker.Name).Set(float64(len(payloadServicePlans)))
+		metrics.BrokerSecondsSinceLastRelist.Set(syncCatalog.fetchTime, broker.Name)
 
 		return nil
 	}
@@ -431,6 +576,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+		metrics.BrokerSecondsSinceLastRelist.DeleteLabelValues(broker.Name)
+		c.brokerRequestLimiter.removeBroker(NewServiceBrokerKey(broker.Namespace, broker.Name))
 		return nil
 	}
 
@@ -491,6 +638,7 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 	// update it.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstancesRetrievable = serviceClass.Spec.InstancesRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -580,6 +728,7 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 	toUpdate.Spec.Free = servicePlan.Spec.Free
 	toUpdate.Spec.ExternalName = servicePlan.Spec.ExternalName
 	toUpdate.Spec.ExternalMetadata = servicePlan.Spec.ExternalMetadata
+	toUpdate.Spec.Costs = servicePlan.Spec.Costs
 	toUpdate.Spec.InstanceCreateParameterSchema = servicePlan.Spec.InstanceCreateParameterSchema
 	toUpdate.Spec.InstanceUpdateParameterSchema = servicePlan.Spec.InstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
@@ -611,7 +760,7 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 
 // updateCommonStatusCondition updates the common ready condition for the given CommonServiceBrokerStatus
 // with the given status, reason, and message.
-func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonStatus *v1beta1.CommonServiceBrokerStatus, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) {
+func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) {
 	newCondition := v1beta1.ServiceBrokerCondition{
 		Type:    conditionType,
 		Status:  status,
@@ -621,34 +770,235 @@ func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectM
 
 	t := time.Now()
 
-	if len(commonStatus.Conditions) == 0 {
-		klog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
-		newCondition.LastTransitionTime = metav1.NewTime(t)
-		commonStatus.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
-	} else {
-		for i, cond := range commonStatus.Conditions {
-			if cond.Type == conditionType {
-				if cond.Status != newCondition.Status {
-					klog.Info(pcb.Messagef(
-						"Found status change for condition %q: %q -> %q; setting lastTransitionTime to %v",
-						conditionType, cond.Status, status, t,
-					))
-					newCondition.LastTransitionTime = metav1.NewTime(t)
-				} else {
-					newCondition.LastTransitionTime = cond.LastTransitionTime
-				}
-
-				commonStatus.Conditions[i] = newCondition
-				break
+	found := false
+	for i, cond := range commonStatus.Conditions {
+		if cond.Type == conditionType {
+			if cond.Status != newCondition.Status {
+				klog.Info(pcb.Messagef(
+					"Found status change for condition %q: %q -> %q; setting lastTransitionTime to %v",
+					conditionType, cond.Status, status, t,
+				))
+				newCondition.LastTransitionTime = metav1.NewTime(t)
+			} else {
+				newCondition.LastTransitionTime = cond.LastTransitionTime
 			}
+			if cond.Status != newCondition.Status || cond.Reason != newCondition.Reason || cond.Message != newCondition.Message {
+				updateTime := metav1.NewTime(t)
+				commonStatus.LastConditionUpdateTime = &updateTime
+			}
+
+			commonStatus.Conditions[i] = newCondition
+			found = true
+			break
 		}
 	}
+	if !found {
+		klog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
+		newCondition.LastTransitionTime = metav1.NewTime(t)
+		commonStatus.Conditions = append(commonStatus.Conditions, newCondition)
+		updateTime := metav1.NewTime(t)
+		commonStatus.LastConditionUpdateTime = &updateTime
+	}
+	sortServiceBrokerConditions(commonStatus.Conditions)
 
-	// Set status.ReconciledGeneration && status.LastCatalogRetrievalTime if updating ready condition to true
+	// Set status.ReconciledGeneration and status.LastRelistRequestProcessed
+	// if updating ready condition to true. The caller sets
+	// status.LastCatalogRetrievalTime to the time the catalog was fetched.
 	if conditionType == v1beta1.ServiceBrokerConditionReady && status == v1beta1.ConditionTrue {
 		commonStatus.ReconciledGeneration = meta.Generation
-		now := metav1.NewTime(t)
-		commonStatus.LastCatalogRetrievalTime = &now
+		commonStatus.LastRelistRequestProcessed = commonSpec.RelistRequests
+		if commonStatus.CurrentOperation != "" {
+			commonStatus.CurrentOperation = ""
+			commonStatus.OperationStartTime = nil
+		}
+	}
+}
+
+// updateCommonCatalogStaleCondition sets the CatalogStale condition of the
+// given CommonServiceBrokerStatus from its ready condition. The catalog is
+// stale when the broker is not ready and the last successful relist is older
+// than catalogStaleRelistMultiple relist intervals. The condition is reset
+// once the catalog has been retrieved again.
+func (c *controller) updateCommonCatalogStaleCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, now time.Time) {
+	var ready, stale *v1beta1.ServiceBrokerCondition
+	for i, cond := range commonStatus.Conditions {
+		switch cond.Type {
+		case v1beta1.ServiceBrokerConditionReady:
+			ready = &commonStatus.Conditions[i]
+		case v1beta1.ServiceBrokerConditionCatalogStale:
+			stale = &commonStatus.Conditions[i]
+		}
+	}
+	if ready == nil {
+		return
+	}
+
+	if ready.Status == v1beta1.ConditionTrue {
+		if stale != nil && stale.Status != v1beta1.ConditionFalse {
+			updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionCatalogStale, v1beta1.ConditionFalse, successFetchedCatalogReason, successFetchedCatalogMessage)
+		}
+		return
+	}
+
+	if c.catalogStaleRelistMultiple <= 0 ||
+		(stale != nil && stale.Status == v1beta1.ConditionTrue) ||
+		meta.DeletionTimestamp != nil ||
+		commonSpec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorManual ||
+		commonStatus.LastCatalogRetrievalTime == nil {
+		return
+	}
+
+	interval := c.brokerRelistInterval
+	if commonSpec.RelistDuration != nil {
+		interval = commonSpec.RelistDuration.Duration
+	}
+	age := now.Sub(commonStatus.LastCatalogRetrievalTime.Time)
+	if age <= time.Duration(float64(interval)*c.catalogStaleRelistMultiple) {
+		return
+	}
+
+	s := fmt.Sprintf("The catalog was last retrieved successfully %v ago.", age.Round(time.Second))
+	klog.Warning(pcb.Message(s))
+	updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionCatalogStale, v1beta1.ConditionTrue, catalogStaleReason, s)
+}
+
+// updateCommonInsecureTLSCondition sets the InsecureTLS condition of the given
+// CommonServiceBrokerStatus from the insecureSkipTLSVerify field of the spec,
+// so that a broker whose certificate is not verified is always flagged. It
+// returns whether the condition became true, in which case the caller records
+// a warning event.
+func updateCommonInsecureTLSCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus) bool {
+	var insecure *v1beta1.ServiceBrokerCondition
+	for i, cond := range commonStatus.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionInsecureTLS {
+			insecure = &commonStatus.Conditions[i]
+		}
+	}
+
+	if commonSpec.InsecureSkipTLSVerify {
+		if insecure != nil && insecure.Status == v1beta1.ConditionTrue {
+			return false
+		}
+		klog.Warning(pcb.Message(insecureTLSMessage))
+		updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionInsecureTLS, v1beta1.ConditionTrue, insecureTLSReason, insecureTLSMessage)
+		return true
+	}
+
+	if insecure != nil && insecure.Status != v1beta1.ConditionFalse {
+		updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionInsecureTLS, v1beta1.ConditionFalse, tlsVerifiedReason, tlsVerifiedMessage)
+	}
+	return false
+}
+
+// startCommonRelist records in the given CommonServiceBrokerStatus that a
+// relist is in progress. A relist that is already recorded, because it is
+// retried after a failure or was interrupted by a restart of the controller,
+// keeps its start time. It returns whether the status changed, and the message
+// of the RelistStuck condition when it became true.
+func (c *controller) startCommonRelist(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, now time.Time) (bool, string) {
+	if c.brokerRelistTimeout <= 0 {
+		return false, ""
+	}
+
+	changed := false
+	if commonStatus.CurrentOperation != v1beta1.ServiceBrokerOperationRelist {
+		commonStatus.CurrentOperation = v1beta1.ServiceBrokerOperationRelist
+		if commonStatus.OperationStartTime == nil {
+			startTime := metav1.NewTime(now)
+			commonStatus.OperationStartTime = &startTime
+		}
+		changed = true
+	}
+	if stuckMessage := c.updateCommonRelistStuckCondition(pcb, meta, commonSpec, commonStatus, now); stuckMessage != "" {
+		return true, stuckMessage
+	}
+	return changed, ""
+}
+
+// updateCommonRelistStuckCondition sets the RelistStuck condition of the given
+// CommonServiceBrokerStatus when its recorded relist has been in progress for
+// longer than the relist timeout, and resets it once no relist is in progress.
+// It returns the message of the condition when it became true, and an empty
+// string otherwise.
+func (c *controller) updateCommonRelistStuckCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, commonStatus *v1beta1.CommonServiceBrokerStatus, now time.Time) string {
+	var stuck *v1beta1.ServiceBrokerCondition
+	for i, cond := range commonStatus.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionRelistStuck {
+			stuck = &commonStatus.Conditions[i]
+		}
+	}
+
+	if commonStatus.CurrentOperation != v1beta1.ServiceBrokerOperationRelist || commonStatus.OperationStartTime == nil {
+		if stuck != nil && stuck.Status != v1beta1.ConditionFalse {
+			updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionRelistStuck, v1beta1.ConditionFalse, relistFinishedReason, relistFinishedMessage)
+		}
+		return ""
+	}
+
+	if c.brokerRelistTimeout <= 0 || (stuck != nil && stuck.Status == v1beta1.ConditionTrue) {
+		return ""
+	}
+	age := now.Sub(commonStatus.OperationStartTime.Time)
+	if age <= c.brokerRelistTimeout {
+		return ""
+	}
+
+	s := fmt.Sprintf(relistStuckMessage, age.Round(time.Second))
+	klog.Warning(pcb.Message(s))
+	updateCommonStatusCondition(pcb, meta, commonSpec, commonStatus, v1beta1.ServiceBrokerConditionRelistStuck, v1beta1.ConditionTrue, relistStuckReason, s)
+	return s
+}
+
+// monitorStuckRelists sets the RelistStuck condition of the brokers whose
+// relist has been in progress for longer than the relist timeout. It runs
+// apart from the workers, since the worker of a stuck relist is blocked on
+// the broker and would only flag the relist once it returns.
+func (c *controller) monitorStuckRelists() {
+	clusterBrokers, err := c.clusterServiceBrokerLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Couldn't list ClusterServiceBrokers to check their relists: %v", err)
+	}
+	for _, broker := range clusterBrokers {
+		if broker.Status.CurrentOperation != v1beta1.ServiceBrokerOperationRelist {
+			continue
+		}
+		toUpdate := broker.DeepCopy()
+		pcb := pretty.NewClusterServiceBrokerContextBuilder(toUpdate)
+		stuckMessage := c.updateCommonRelistStuckCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now())
+		if stuckMessage == "" {
+			continue
+		}
+		toUpdate.RecalculatePrinterColumnStatusFields()
+		if _, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate); err != nil {
+			klog.Error(pcb.Messagef("Error setting the RelistStuck condition: %v", err))
+			continue
+		}
+		c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
+	}
+
+	if c.serviceBrokerLister == nil {
+		return
+	}
+	brokers, err := c.serviceBrokerLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Couldn't list ServiceBrokers to check their relists: %v", err)
+	}
+	for _, broker := range brokers {
+		if broker.Status.CurrentOperation != v1beta1.ServiceBrokerOperationRelist {
+			continue
+		}
+		toUpdate := broker.DeepCopy()
+		pcb := pretty.NewServiceBrokerContextBuilder(toUpdate)
+		stuckMessage := c.updateCommonRelistStuckCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now())
+		if stuckMessage == "" {
+			continue
+		}
+		toUpdate.RecalculatePrinterColumnStatusFields()
+		if _, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate); err != nil {
+			klog.Error(pcb.Messagef("Error setting the RelistStuck condition: %v", err))
+			continue
+		}
+		c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
 	}
 }
 
@@ -658,7 +1008,16 @@ func (c *controller) updateServiceBrokerCondition(broker *v1beta1.ServiceBroker,
 	toUpdate := broker.DeepCopy()
 
 	pcb := pretty.NewServiceBrokerContextBuilder(toUpdate)
-	updateCommonStatusCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Status.CommonServiceBrokerStatus, conditionType, status, reason, message)
+	updateCommonStatusCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, conditionType, status, reason, message)
+	if conditionType == v1beta1.ServiceBrokerConditionReady {
+		c.updateCommonCatalogStaleCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now())
+		if updateCommonInsecureTLSCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus) {
+			c.recorder.Event(broker, corev1.EventTypeWarning, insecureTLSReason, insecureTLSMessage)
+		}
+	}
+	if stuckMessage := c.updateCommonRelistStuckCondition(pcb, toUpdate.ObjectMeta, &toUpdate.Spec.CommonServiceBrokerSpec, &toUpdate.Status.CommonServiceBrokerStatus, time.Now()); stuckMessage != "" {
+		c.recorder.Event(broker, corev1.EventTypeWarning, relistStuckReason, stuckMessage)
+	}
 
 	toUpdate.RecalculatePrinterColumnStatusFields()
 
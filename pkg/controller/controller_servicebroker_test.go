@@ -132,7 +132,7 @@ func TestReconcileServiceBrokerDelete(t *testing.T) {
 			broker.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
 
 			updateBrokerClientCalled := false
-			testController.brokerClientManager = NewBrokerClientManager(func(_ *osb.ClientConfiguration) (osb.Client, error) {
+			testController.brokerClientManager = NewBrokerClientManager(func(_ *BrokerClientConfiguration) (osb.Client, error) {
 				updateBrokerClientCalled = true
 				return nil, nil
 			})
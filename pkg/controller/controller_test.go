@@ -17,10 +17,14 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"runtime/debug"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,9 +33,11 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	servicecataloginformers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions"
 	v1beta1informers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
+	"github.com/kubernetes-sigs/service-catalog/pkg/version"
 
 	servicecatalogclientset "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -1399,6 +1405,31 @@ func TestEmptyCatalogConversion(t *testing.T) {
 	}
 }
 
+func TestSkipServicesWithoutPlans(t *testing.T) {
+	catalog := getTestCatalog()
+	catalog.Services = append([]osb.Service{{Name: "empty-service", ID: "empty-service-id", Plans: []osb.Plan{}}}, catalog.Services...)
+
+	_, _, err := convertAndFilterCatalog(catalog, nil, emptyServiceClasses, emptyServicePlans)
+	if err == nil || !strings.Contains(err.Error(), `service "empty-service" (ID "empty-service-id") has no plans`) {
+		t.Fatalf("Expected the catalog to be rejected because of the service without plans, got %v", err)
+	}
+
+	skipped := skipServicesWithoutPlans(catalog)
+	if e, a := []string{`"empty-service" (ID "empty-service-id")`}, skipped; !reflect.DeepEqual(e, a) {
+		t.Fatalf("Unexpected skipped services, %s", expectedGot(e, a))
+	}
+	serviceClasses, servicePlans, err := convertAndFilterCatalog(catalog, nil, emptyServiceClasses, emptyServicePlans)
+	if err != nil {
+		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
+	}
+	if len(serviceClasses) != 1 {
+		t.Fatalf("Expected 1 serviceclass, got %d", len(serviceClasses))
+	}
+	if len(servicePlans) != 2 {
+		t.Fatalf("Expected 2 serviceplans, got %d", len(servicePlans))
+	}
+}
+
 func TestCatalogConversion(t *testing.T) {
 	catalog := &osb.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalog), &catalog)
@@ -2423,7 +2454,7 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	fakeCatalogClient := &fake.Clientset{Clientset: &servicecatalogclientset.Clientset{}}
 
 	fakeOSBClient := fakeosb.NewFakeClient(config) // error should always be nil
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	brokerClFunc := NewBrokerClientCreateFunc(fakeosb.ReturnFakeClientFunc(fakeOSBClient))
 
 	// create informers
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(fakeCatalogClient, 0)
@@ -2438,6 +2469,8 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	testController, err := NewController(
 		fakeKubeClient,
 		k8sInformers.Secrets(),
+		k8sInformers.ConfigMaps(),
+		k8sInformers.Namespaces(),
 		fakeCatalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -2453,9 +2486,34 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		20*time.Minute,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		"",
+		BindingSecretRetentionPolicyDelete,
+		0,
+		0,
+		"",
+		nil,
+		0,
+		0,
+		true,
+		true,
+		0,
+		"",
+		"",
+		0,
+		ClassWithoutPlansPolicyReject,
+		EmptyBindingCredentialsPolicyAllow,
+		0,
+		0,
+		"",
+		0,
+		nil,
+		nil,
+		0,
+		false,
 	)
 
 	if err != nil {
@@ -4105,6 +4163,18 @@ func addGetSecretReaction(fakeKubeClient *clientgofake.Clientset, secret *corev1
 	})
 }
 
+func addGetConfigMapNotFoundReaction(fakeKubeClient *clientgofake.Clientset) {
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+	})
+}
+
+func addGetConfigMapReaction(fakeKubeClient *clientgofake.Clientset, configMap *corev1.ConfigMap) {
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, configMap, nil
+	})
+}
+
 // updateObjectReactor is used to simulate real update and return updated object,
 // without that fake client will return empty struct
 // TODO: in future we should consider refactor of newTestController method to use servicecatalogclientset.NewSimpleClientset() instead of &servicecatalogclientset.Clientset{}
@@ -4115,3 +4185,199 @@ func updateObjectReactor(resource string) (string, string, clientgotesting.React
 		return true, e.GetObject(), nil
 	}
 }
+
+func TestNewBrokerTLSConfig(t *testing.T) {
+	cases := []struct {
+		name         string
+		minVersion   string
+		cipherSuites []string
+		expected     *tls.Config
+		expectedErr  bool
+	}{
+		{
+			name: "defaults of Go",
+		},
+		{
+			name:       "minimum version",
+			minVersion: "VersionTLS12",
+			expected:   &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+		{
+			name:         "cipher suites",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+			expected: &tls.Config{CipherSuites: []uint16{
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			}},
+		},
+		{
+			name:        "unknown minimum version",
+			minVersion:  "VersionTLS99",
+			expectedErr: true,
+		},
+		{
+			name:         "unknown cipher suite",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_UNKNOWN"},
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := newBrokerTLSConfig(tc.minVersion, tc.cipherSuites)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expected, tlsConfig; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected TLS config: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+func TestNewClientConfigurationForBrokerUserAgent(t *testing.T) {
+	product := "service-catalog/" + version.Get().GitVersion
+	cases := []struct {
+		name          string
+		brokerSuffix  string
+		defaultSuffix string
+		expected      string
+	}{
+		{
+			name:     "no suffix",
+			expected: product,
+		},
+		{
+			name:          "suffix of the controller",
+			defaultSuffix: "prod-cluster",
+			expected:      product + " prod-cluster",
+		},
+		{
+			name:          "suffix of the broker overrides the controller",
+			brokerSuffix:  "prod-cluster (team payments)",
+			defaultSuffix: "prod-cluster",
+			expected:      product + " prod-cluster (team payments)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var userAgent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userAgent = r.Header.Get("User-Agent")
+				w.Write([]byte(`{"services":[]}`))
+			}))
+			defer server.Close()
+
+			spec := &v1beta1.CommonServiceBrokerSpec{URL: server.URL, UserAgentSuffix: tc.brokerSuffix}
+			clientConfig := NewClientConfigurationForBroker(metav1.ObjectMeta{Name: "broker"}, spec, nil, 0, nil, tc.defaultSuffix)
+			if e, a := tc.expected, clientConfig.UserAgent; e != a {
+				t.Fatalf("unexpected user agent: %s", expectedGot(e, a))
+			}
+
+			client, err := NewHTTPBrokerClient(clientConfig)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := client.GetCatalog(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expected, userAgent; e != a {
+				t.Fatalf("unexpected User-Agent header sent to the broker: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// fakeBrokerHTTPClient is a brokerhttp.Client which returns the responses of
+// the fake client, with the fields of the responses that the fake client has
+// no field for.
+type fakeBrokerHTTPClient struct {
+	*fakeosb.FakeClient
+	// metadata is the metadata of the provisioned instances.
+	metadata *brokerhttp.ServiceInstanceMetadata
+	// instancesRetrievable holds the IDs of the services of the catalog
+	// whose instances can be fetched.
+	instancesRetrievable map[string]bool
+	// getInstanceResponse and getInstanceError are returned by GetInstance.
+	getInstanceResponse *brokerhttp.GetInstanceResponse
+	getInstanceError    error
+	// getInstanceRequests are the requests of the calls to GetInstance.
+	getInstanceRequests []*brokerhttp.GetInstanceRequest
+	// stateToken is the state token of the instance at the broker. The
+	// update and deprovision requests sent with another state token fail
+	// with 412 Precondition Failed.
+	stateToken string
+	// sentStateTokens are the state tokens of the update and deprovision
+	// requests.
+	sentStateTokens []string
+	// bindRequests are the requests of the calls to BindServiceInstance.
+	bindRequests []*brokerhttp.BindRequest
+}
+
+var _ brokerhttp.Client = &fakeBrokerHTTPClient{}
+
+func (c *fakeBrokerHTTPClient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	response, err := c.ProvisionInstance(r)
+	if err != nil {
+		return nil, err
+	}
+	return &brokerhttp.ProvisionResponse{ProvisionResponse: *response, Metadata: c.metadata, StateToken: c.stateToken}, nil
+}
+
+func (c *fakeBrokerHTTPClient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	response, err := c.GetCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return &brokerhttp.CatalogResponse{CatalogResponse: *response, InstancesRetrievable: c.instancesRetrievable}, nil
+}
+
+func (c *fakeBrokerHTTPClient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	c.getInstanceRequests = append(c.getInstanceRequests, r)
+	if c.getInstanceError != nil {
+		return nil, c.getInstanceError
+	}
+	response := *c.getInstanceResponse
+	response.StateToken = c.stateToken
+	return &response, nil
+}
+
+func (c *fakeBrokerHTTPClient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	if err := c.checkStateToken(r.StateToken); err != nil {
+		return nil, err
+	}
+	response, err := c.UpdateInstance(&r.UpdateInstanceRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &brokerhttp.UpdateInstanceResponse{UpdateInstanceResponse: *response, StateToken: c.stateToken}, nil
+}
+
+func (c *fakeBrokerHTTPClient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.checkStateToken(r.StateToken); err != nil {
+		return nil, err
+	}
+	return c.DeprovisionInstance(&r.DeprovisionRequest)
+}
+
+func (c *fakeBrokerHTTPClient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	c.bindRequests = append(c.bindRequests, r)
+	return c.Bind(&r.BindRequest)
+}
+
+// checkStateToken records the state token of a request, and returns the
+// error of the broker if it is not the state token of the instance.
+func (c *fakeBrokerHTTPClient) checkStateToken(stateToken string) error {
+	c.sentStateTokens = append(c.sentStateTokens, stateToken)
+	if stateToken != "" && stateToken != c.stateToken {
+		return osb.HTTPStatusCodeError{StatusCode: http.StatusPreconditionFailed}
+	}
+	return nil
+}
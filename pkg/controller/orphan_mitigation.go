@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+)
+
+const (
+	// orphanMitigationCheckInterval is how often the broker is asked for the
+	// state of an instance during the grace period of its orphan mitigation.
+	orphanMitigationCheckInterval = 10 * time.Second
+
+	orphanMitigationCancelledReason  string = "OrphanMitigationCancelled"
+	orphanMitigationCancelledMessage string = "The broker reported the instance as provisioned during the grace period of the orphan mitigation; the instance was not deprovisioned"
+)
+
+// getServiceInstanceOrphanMitigationStartTime returns when the orphan
+// mitigation of the instance started, or nil if it has no OrphanMitigation
+// condition.
+func getServiceInstanceOrphanMitigationStartTime(instance *v1beta1.ServiceInstance) *time.Time {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionOrphanMitigation && cond.Status == v1beta1.ConditionTrue {
+			return &cond.LastTransitionTime.Time
+		}
+	}
+	return nil
+}
+
+// reconcileServiceInstanceOrphanMitigationGrace delays the deprovision request
+// of an orphan mitigation until the orphan mitigation grace period passed
+// since the provision failed, so that a broker which is still finishing the
+// provision is not asked to delete the instance it is creating. When
+// orphanMitigationCheckInstance is set and the class of the instance is
+// instancesRetrievable, the instance is fetched from the broker during the
+// grace period: if the broker has it, the provision succeeded and the orphan
+// mitigation is cancelled. Returns true if the reconciliation was handled,
+// with the error to return; the instance is not deprovisioned yet in that
+// case.
+func (c *controller) reconcileServiceInstanceOrphanMitigationGrace(instance *v1beta1.ServiceInstance, instancesRetrievable bool, brokerClient osb.Client) (bool, error) {
+	if c.orphanMitigationGracePeriod <= 0 || instance.DeletionTimestamp != nil || !instance.Status.OrphanMitigationInProgress {
+		return false, nil
+	}
+	startTime := getServiceInstanceOrphanMitigationStartTime(instance)
+	if startTime == nil {
+		return false, nil
+	}
+	remaining := time.Until(startTime.Add(c.orphanMitigationGracePeriod))
+	if remaining <= 0 {
+		return false, nil
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	delay := remaining
+	if c.orphanMitigationCheckInstance && instancesRetrievable {
+		response, provisioned, err := c.isServiceInstanceProvisionedAtBroker(instance, brokerClient)
+		if isBrokerRequestLimitError(err) {
+			return true, err
+		}
+		if err != nil {
+			klog.Warning(pcb.Messagef("Error checking the instance during the orphan mitigation grace period: %v", err))
+		}
+		if provisioned {
+			return true, c.processOrphanMitigationCancelled(instance, response.DashboardURL)
+		}
+		if delay > orphanMitigationCheckInterval {
+			delay = orphanMitigationCheckInterval
+		}
+	}
+
+	klog.V(4).Info(pcb.Messagef("Delaying orphan mitigation until %v, checking again in %v", startTime.Add(c.orphanMitigationGracePeriod), delay))
+	c.enqueueInstanceAfter(instance, delay)
+	return true, nil
+}
+
+// isServiceInstanceProvisionedAtBroker returns whether the broker has the
+// instance, by fetching it with a GET on its endpoint. A broker answers 404
+// for an instance that does not exist or whose provision is still in
+// progress, and 410 for an instance it deleted; both mean that the instance
+// is not provisioned. Any other failure is returned. The response of the
+// broker is returned for a provisioned instance, and its state token is
+// recorded on the instance.
+func (c *controller) isServiceInstanceProvisionedAtBroker(instance *v1beta1.ServiceInstance, brokerClient osb.Client) (*brokerhttp.GetInstanceResponse, bool, error) {
+	response, err := brokerhttp.GetInstance(brokerClient, &brokerhttp.GetInstanceRequest{
+		InstanceID: instance.Spec.ExternalID,
+	})
+	if err == nil {
+		instance.Status.StateToken = response.StateToken
+		return response, true, nil
+	}
+	if httpErr, ok := osb.IsHTTPError(err); ok && (httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusGone) {
+		return nil, false, nil
+	}
+	return nil, false, err
+}
+
+// processOrphanMitigationCancelled handles the logging and updating of a
+// ServiceInstance whose orphan mitigation is cancelled because the broker
+// provisioned it after all. dashboardURL is the one the broker returned for
+// the instance.
+func (c *controller) processOrphanMitigationCancelled(instance *v1beta1.ServiceInstance, dashboardURL *string) error {
+	c.recorder.Event(instance, corev1.EventTypeNormal, orphanMitigationCancelledReason, orphanMitigationCancelledMessage)
+	removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionOrphanMitigation)
+	removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionFailed)
+	instance.Status.OrphanMitigationInProgress = false
+	return c.processProvisionSuccess(instance, dashboardURL)
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+)
+
+// TestReconcileServiceInstanceOrphanMitigationGrace tests that the orphan
+// mitigation of an instance is delayed during the grace period, and cancelled
+// when the broker has the instance of an instancesRetrievable class during
+// it.
+func TestReconcileServiceInstanceOrphanMitigationGrace(t *testing.T) {
+	cases := []struct {
+		name                 string
+		gracePeriod          time.Duration
+		checkInstance        bool
+		instancesRetrievable bool
+		elapsed              time.Duration
+		deleted              bool
+		// getInstanceError is the error of the GET of the instance; nil
+		// returns the instance.
+		getInstanceError error
+		// expectedGetInstance is whether the instance is fetched from the
+		// broker.
+		expectedGetInstance bool
+		// expectedBrokerActions are the types of the requests sent to the
+		// broker.
+		expectedBrokerActions []fakeosb.ActionType
+		// deprovisioned is whether the orphan mitigation deprovisions the
+		// instance.
+		deprovisioned bool
+		// cancelled is whether the orphan mitigation is cancelled, the
+		// instance then being provisioned.
+		cancelled bool
+	}{
+		{
+			name:                  "no grace period",
+			expectedBrokerActions: []fakeosb.ActionType{fakeosb.DeprovisionInstance},
+			deprovisioned:         true,
+		},
+		{
+			name:        "in grace period",
+			gracePeriod: time.Minute,
+			elapsed:     10 * time.Second,
+		},
+		{
+			name:                  "grace period elapsed",
+			gracePeriod:           time.Minute,
+			elapsed:               2 * time.Minute,
+			expectedBrokerActions: []fakeosb.ActionType{fakeosb.DeprovisionInstance},
+			deprovisioned:         true,
+		},
+		{
+			name:        "in grace period, deleted instance",
+			gracePeriod: time.Minute,
+			elapsed:     10 * time.Second,
+			deleted:     true,
+		},
+		{
+			name:                 "check in grace period, instance exists",
+			gracePeriod:          time.Minute,
+			checkInstance:        true,
+			instancesRetrievable: true,
+			elapsed:              10 * time.Second,
+			expectedGetInstance:  true,
+			cancelled:            true,
+		},
+		{
+			name:                 "check in grace period, instance not found",
+			gracePeriod:          time.Minute,
+			checkInstance:        true,
+			instancesRetrievable: true,
+			elapsed:              10 * time.Second,
+			getInstanceError:     osb.HTTPStatusCodeError{StatusCode: http.StatusNotFound},
+			expectedGetInstance:  true,
+		},
+		{
+			name:                 "check in grace period, instance gone",
+			gracePeriod:          time.Minute,
+			checkInstance:        true,
+			instancesRetrievable: true,
+			elapsed:              10 * time.Second,
+			getInstanceError:     osb.HTTPStatusCodeError{StatusCode: http.StatusGone},
+			expectedGetInstance:  true,
+		},
+		{
+			name:                 "check in grace period, bad request",
+			gracePeriod:          time.Minute,
+			checkInstance:        true,
+			instancesRetrievable: true,
+			elapsed:              10 * time.Second,
+			getInstanceError:     osb.HTTPStatusCodeError{StatusCode: http.StatusBadRequest},
+			expectedGetInstance:  true,
+		},
+		{
+			name:          "check in grace period, instances not retrievable",
+			gracePeriod:   time.Minute,
+			checkInstance: true,
+			elapsed:       10 * time.Second,
+		},
+		{
+			name:                  "check after grace period",
+			gracePeriod:           time.Minute,
+			checkInstance:         true,
+			instancesRetrievable:  true,
+			elapsed:               2 * time.Minute,
+			expectedBrokerActions: []fakeosb.ActionType{fakeosb.DeprovisionInstance},
+			deprovisioned:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				DeprovisionReaction: &fakeosb.DeprovisionReaction{
+					Response: &osb.DeprovisionResponse{},
+				},
+			})
+			testController.orphanMitigationGracePeriod = tc.gracePeriod
+			testController.orphanMitigationCheckInstance = tc.checkInstance
+			brokerClient := &fakeBrokerHTTPClient{
+				FakeClient:          fakeBrokerClient,
+				getInstanceResponse: &brokerhttp.GetInstanceResponse{ServiceID: testClusterServiceClassGUID, PlanID: testClusterServicePlanGUID, DashboardURL: &testDashboardURL},
+				getInstanceError:    tc.getInstanceError,
+			}
+			testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+				return brokerClient, nil
+			}
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.InstancesRetrievable = tc.instancesRetrievable
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+			if tc.deleted {
+				instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+			}
+			instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationProvision
+			instance.Status.OrphanMitigationInProgress = true
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+				*newServiceInstanceReadyCondition(v1beta1.ConditionFalse, startingInstanceOrphanMitigationReason, startingInstanceOrphanMitigationMessage),
+				*newServiceInstanceFailedCondition(v1beta1.ConditionTrue, "ProvisionCallFailed", "Communication with the ServiceBroker timed out"),
+				*newServiceInstanceCondition(v1beta1.ConditionTrue, v1beta1.ServiceInstanceConditionOrphanMitigation, "ProvisionCallFailed", "Communication with the ServiceBroker timed out"),
+			}
+			instance.Status.Conditions[2].LastTransitionTime = metav1.NewTime(time.Now().Add(-tc.elapsed))
+			instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+			instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: testClusterServicePlanName,
+				ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+			}
+			startTime := metav1.NewTime(time.Now().Add(-tc.elapsed))
+			instance.Status.OperationStartTime = &startTime
+
+			fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, instance, nil
+			})
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if e, a := tc.expectedGetInstance, len(brokerClient.getInstanceRequests) == 1; e != a {
+				t.Fatalf("unexpected GET of the instance: %s", expectedGot(e, a))
+			}
+			if tc.expectedGetInstance {
+				if e, a := testServiceInstanceGUID, brokerClient.getInstanceRequests[0].InstanceID; e != a {
+					t.Fatalf("unexpected instance ID: %s", expectedGot(e, a))
+				}
+			}
+			brokerActions := fakeBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, len(tc.expectedBrokerActions))
+			for i, e := range tc.expectedBrokerActions {
+				if a := brokerActions[i].Type; e != a {
+					t.Fatalf("unexpected broker action %d: %s", i, expectedGot(e, a))
+				}
+			}
+
+			actions := fakeCatalogClient.Actions()
+			switch {
+			case tc.cancelled:
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceReadyTrue(t, updatedServiceInstance, successProvisionReason)
+				assertServiceInstanceOrphanMitigationMissing(t, updatedServiceInstance)
+				assertServiceInstanceConditionMissing(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionFailed)
+				assertServiceInstanceOrphanMitigationInProgressFalse(t, updatedServiceInstance)
+				assertServiceInstanceProvisioned(t, updatedServiceInstance, v1beta1.ServiceInstanceProvisionStatusProvisioned)
+				assertServiceInstanceDashboardURL(t, updatedServiceInstance, testDashboardURL)
+
+				expectedEvents := []string{
+					normalEventBuilder(orphanMitigationCancelledReason).msg(orphanMitigationCancelledMessage).String(),
+					normalEventBuilder(successProvisionReason).msg(successProvisionMessage).String(),
+				}
+				if err := checkEvents(getRecordedEvents(testController), expectedEvents); err != nil {
+					t.Fatal(err)
+				}
+			case tc.deleted:
+				// The deprovisioning of the deleted instance starts
+				// without waiting for the grace period.
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+				assertServiceInstanceOrphanMitigationMissing(t, updatedServiceInstance)
+				assertServiceInstanceCurrentOperation(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision)
+			case tc.deprovisioned:
+				if len(actions) == 0 {
+					t.Fatal("expected the status of the deprovisioned instance to be updated")
+				}
+				updatedServiceInstance := assertUpdateStatus(t, actions[len(actions)-1], instance)
+				assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusSucceeded)
+			default:
+				// The orphan mitigation is delayed without updating the
+				// instance.
+				assertNumberOfActions(t, actions, 0)
+			}
+		})
+	}
+}
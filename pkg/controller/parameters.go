@@ -22,9 +22,12 @@ import (
 	"fmt"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+	"github.com/kubernetes-sigs/service-catalog/pkg/paramtemplate"
 	"github.com/peterbourgon/mergemap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 )
@@ -34,7 +37,8 @@ import (
 // The first return value is a map of parameters to send to the Broker, including
 // secret values.
 // The second return value is a map of parameters with secret values redacted,
-// replaced with "<redacted>".
+// replaced with "<redacted>". The values read from config maps are not
+// sensitive and are not redacted.
 // The third return value is any error that caused the function to fail.
 func buildParameters(kubeClient kubernetes.Interface, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
 	params := make(map[string]interface{})
@@ -50,7 +54,11 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 					return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
 				}
 				params[k] = v
-				paramsWithSecretsRedacted[k] = "<redacted>"
+				if p.SecretKeyRef != nil {
+					paramsWithSecretsRedacted[k] = "<redacted>"
+				} else {
+					paramsWithSecretsRedacted[k] = v
+				}
 			}
 		}
 	}
@@ -94,6 +102,17 @@ func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string
 		params = p
 
 	}
+	if parametersFrom.ConfigMapKeyRef != nil {
+		data, err := fetchConfigMapKeyValue(kubeClient, namespace, parametersFrom.ConfigMapKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		p, err := unmarshalJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		params = p
+	}
 	return params, nil
 }
 
@@ -134,13 +153,26 @@ func fetchSecretKeyValue(kubeClient kubernetes.Interface, namespace string, secr
 	return secret.Data[secretKeyRef.Key], nil
 }
 
+// fetchConfigMapKeyValue requests and returns the contents of the given config
+// map key
+func fetchConfigMapKeyValue(kubeClient kubernetes.Interface, namespace string, configMapKeyRef *v1beta1.ConfigMapKeyReference) ([]byte, error) {
+	configMap, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(configMapKeyRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(configMap.Data[configMapKeyRef.Key]), nil
+}
+
 // generateChecksumOfParameters generates a checksum for the map of parameters.
-// This checksum is used to determine if parameters have changed.
+// This checksum is used to determine if parameters have changed. The
+// parameters are canonicalized first, so that the checksum does not change
+// when only the ordering of the keys, the whitespace or the representation of
+// a number (for example 2 and 2.0) differs.
 func generateChecksumOfParameters(params map[string]interface{}) (string, error) {
 	if params == nil || len(params) == 0 {
 		return "", nil
 	}
-	paramsAsJSON, err := json.Marshal(params)
+	paramsAsJSON, err := canonicalizeParameters(params)
 	if err != nil {
 		return "", err
 	}
@@ -148,6 +180,24 @@ func generateChecksumOfParameters(params map[string]interface{}) (string, error)
 	return fmt.Sprintf("%x", hash), nil
 }
 
+// canonicalizeParameters returns the canonical JSON form of the map of
+// parameters: the keys of every object are sorted, there is no insignificant
+// whitespace and all of the values have their generic JSON representation.
+func canonicalizeParameters(params map[string]interface{}) ([]byte, error) {
+	paramsAsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	// Decoding into a generic value converts typed values (such as ints or
+	// structs) into the form they would have when read back from the API,
+	// and encoding the result sorts the keys of every nested object.
+	var generic interface{}
+	if err := json.Unmarshal(paramsAsJSON, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
 // prepareInProgressPropertyParameters generates the required parameters for setting
 // the in-progress status of a Type.
 // Returns (parameters, parametersChecksum, rawParametersWithRedaction, err) where
@@ -218,3 +268,59 @@ func mergeParameters(params *runtime.RawExtension, defaultParams *runtime.RawExt
 
 	return &runtime.RawExtension{Raw: result}, nil
 }
+
+// instanceParametersFrom returns the sources of the parameters of the
+// instance: the entries of spec.parametersFrom followed by the secrets
+// referenced in spec.secretParameterRefs.
+func instanceParametersFrom(instance *v1beta1.ServiceInstance) []v1beta1.ParametersFromSource {
+	if len(instance.Spec.SecretParameterRefs) == 0 {
+		return instance.Spec.ParametersFrom
+	}
+	parametersFrom := make([]v1beta1.ParametersFromSource, 0, len(instance.Spec.ParametersFrom)+len(instance.Spec.SecretParameterRefs))
+	parametersFrom = append(parametersFrom, instance.Spec.ParametersFrom...)
+	for _, ref := range instance.Spec.SecretParameterRefs {
+		parametersFrom = append(parametersFrom, secretParameterRefSource(ref))
+	}
+	return parametersFrom
+}
+
+// instanceSpecParameters returns the spec.parameters of the instance, with
+// their templates resolved from the metadata of the instance when the
+// ParameterTemplates feature is enabled.
+func instanceSpecParameters(instance *v1beta1.ServiceInstance) (*runtime.RawExtension, error) {
+	if instance.Spec.Parameters == nil || !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParameterTemplates) {
+		return instance.Spec.Parameters, nil
+	}
+	resolved, err := paramtemplate.Resolve(instance.Spec.Parameters.Raw, paramtemplate.Values{
+		Name:      instance.Name,
+		Namespace: instance.Namespace,
+		Labels:    instance.Labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the templates of the parameters: %v", err)
+	}
+	return &runtime.RawExtension{Raw: resolved}, nil
+}
+
+// bindingParametersFrom returns the sources of the parameters of the binding:
+// the entries of spec.parametersFrom followed by the secretParameterRefs of
+// the instance that are propagated to bindings.
+func bindingParametersFrom(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance) []v1beta1.ParametersFromSource {
+	var propagated []v1beta1.ParametersFromSource
+	for _, ref := range instance.Spec.SecretParameterRefs {
+		if ref.PropagateToBindings {
+			propagated = append(propagated, secretParameterRefSource(ref))
+		}
+	}
+	if len(propagated) == 0 {
+		return binding.Spec.ParametersFrom
+	}
+	parametersFrom := make([]v1beta1.ParametersFromSource, 0, len(binding.Spec.ParametersFrom)+len(propagated))
+	parametersFrom = append(parametersFrom, binding.Spec.ParametersFrom...)
+	return append(parametersFrom, propagated...)
+}
+
+func secretParameterRefSource(ref v1beta1.SecretParameterReference) v1beta1.ParametersFromSource {
+	secretKeyRef := ref.SecretKeyRef
+	return v1beta1.ParametersFromSource{SecretKeyRef: &secretKeyRef}
+}
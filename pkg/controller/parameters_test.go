@@ -34,12 +34,19 @@ func TestBuildParameters(t *testing.T) {
 			"string-key": []byte("textFromSecret"),
 		},
 	}
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"json-key":   `{ "region": "eu-west-1" }`,
+			"string-key": "textFromConfigMap",
+		},
+	}
 
 	cases := []struct {
 		name                                  string
 		parametersFrom                        []v1beta1.ParametersFromSource
 		parameters                            *runtime.RawExtension
 		secret                                *corev1.Secret
+		configMap                             *corev1.ConfigMap
 		expectedParameters                    map[string]interface{}
 		expectedParametersWithSecretsRedacted map[string]interface{}
 		shouldSucceed                         bool
@@ -138,16 +145,88 @@ func TestBuildParameters(t *testing.T) {
 			secret:        secret,
 			shouldSucceed: false,
 		},
+		{
+			name: "parametersFrom: configMapKey with blob",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "config-map",
+						Key:  "json-key",
+					},
+				},
+			},
+			configMap: configMap,
+			expectedParameters: map[string]interface{}{
+				"region": "eu-west-1",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"region": "eu-west-1",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: configMapKey with invalid blob",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "config-map",
+						Key:  "string-key",
+					},
+				},
+			},
+			configMap:     configMap,
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: configMapKey not found",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "config-map",
+						Key:  "json-key",
+					},
+				},
+			},
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: secretKey + configMapKey",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					SecretKeyRef: &v1beta1.SecretKeyReference{
+						Name: "secret",
+						Key:  "json-key",
+					},
+				},
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "config-map",
+						Key:  "json-key",
+					},
+				},
+			},
+			secret:    secret,
+			configMap: configMap,
+			expectedParameters: map[string]interface{}{
+				"json":   true,
+				"region": "eu-west-1",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"json":   "<redacted>",
+				"region": "eu-west-1",
+			},
+			shouldSucceed: true,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			testBuildParameters(t, tc.parametersFrom, tc.parameters, tc.secret, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
+			testBuildParameters(t, tc.parametersFrom, tc.parameters, tc.secret, tc.configMap, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
 		})
 	}
 }
 
-func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
+func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, configMap *corev1.ConfigMap, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
 	// create a fake kube client
 	fakeKubeClient := &clientgofake.Clientset{}
 	if secret != nil {
@@ -155,6 +234,11 @@ func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSo
 	} else {
 		addGetSecretNotFoundReaction(fakeKubeClient)
 	}
+	if configMap != nil {
+		addGetConfigMapReaction(fakeKubeClient, configMap)
+	} else {
+		addGetConfigMapNotFoundReaction(fakeKubeClient)
+	}
 
 	actual, actualWithSecretsRedacted, err := buildParameters(fakeKubeClient, "test-ns", parametersFrom, parameters)
 	if shouldSucceed {
@@ -205,6 +289,46 @@ func TestGenerateChecksumOfParameters(t *testing.T) {
 			},
 			expectedEquality: false,
 		},
+		{
+			name: "integer and float",
+			oldParams: map[string]interface{}{
+				"a": "first",
+				"b": 2,
+			},
+			newParams: map[string]interface{}{
+				"a": "first",
+				"b": 2.0,
+			},
+			expectedEquality: true,
+		},
+		{
+			name: "nested typed values",
+			oldParams: map[string]interface{}{
+				"a": map[string]string{
+					"x": "1",
+					"y": "2",
+				},
+				"b": []int{1, 2},
+			},
+			newParams: map[string]interface{}{
+				"a": map[string]interface{}{
+					"y": "2",
+					"x": "1",
+				},
+				"b": []interface{}{1.0, 2.0},
+			},
+			expectedEquality: true,
+		},
+		{
+			name: "different array ordering",
+			oldParams: map[string]interface{}{
+				"b": []interface{}{1, 2},
+			},
+			newParams: map[string]interface{}{
+				"b": []interface{}{2, 1},
+			},
+			expectedEquality: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -286,3 +410,145 @@ func TestMergeParameters(t *testing.T) {
 func stringPtr(val string) *string {
 	return &val
 }
+
+func TestInstanceParametersFrom(t *testing.T) {
+	fromSecret := v1beta1.ParametersFromSource{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "from-name", Key: "from-key"}}
+	cases := []struct {
+		name           string
+		parametersFrom []v1beta1.ParametersFromSource
+		refs           []v1beta1.SecretParameterReference
+		expected       []v1beta1.ParametersFromSource
+	}{
+		{
+			name:           "no secretParameterRefs",
+			parametersFrom: []v1beta1.ParametersFromSource{fromSecret},
+			expected:       []v1beta1.ParametersFromSource{fromSecret},
+		},
+		{
+			name:           "secretParameterRefs follow parametersFrom",
+			parametersFrom: []v1beta1.ParametersFromSource{fromSecret},
+			refs: []v1beta1.SecretParameterReference{
+				{SecretKeyRef: v1beta1.SecretKeyReference{Name: "ref-name", Key: "ref-key"}},
+				{SecretKeyRef: v1beta1.SecretKeyReference{Name: "propagated-name", Key: "propagated-key"}, PropagateToBindings: true},
+			},
+			expected: []v1beta1.ParametersFromSource{
+				fromSecret,
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "ref-name", Key: "ref-key"}},
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "propagated-name", Key: "propagated-key"}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &v1beta1.ServiceInstance{
+				Spec: v1beta1.ServiceInstanceSpec{
+					ParametersFrom:      tc.parametersFrom,
+					SecretParameterRefs: tc.refs,
+				},
+			}
+			if e, a := tc.expected, instanceParametersFrom(instance); !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected parametersFrom: %v", diff.ObjectReflectDiff(e, a))
+			}
+		})
+	}
+}
+
+func TestBindingParametersFrom(t *testing.T) {
+	fromSecret := v1beta1.ParametersFromSource{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "from-name", Key: "from-key"}}
+	cases := []struct {
+		name     string
+		refs     []v1beta1.SecretParameterReference
+		expected []v1beta1.ParametersFromSource
+	}{
+		{
+			name:     "no secretParameterRefs",
+			expected: []v1beta1.ParametersFromSource{fromSecret},
+		},
+		{
+			name: "only propagated secretParameterRefs",
+			refs: []v1beta1.SecretParameterReference{
+				{SecretKeyRef: v1beta1.SecretKeyReference{Name: "ref-name", Key: "ref-key"}},
+				{SecretKeyRef: v1beta1.SecretKeyReference{Name: "propagated-name", Key: "propagated-key"}, PropagateToBindings: true},
+			},
+			expected: []v1beta1.ParametersFromSource{
+				fromSecret,
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "propagated-name", Key: "propagated-key"}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := &v1beta1.ServiceBinding{
+				Spec: v1beta1.ServiceBindingSpec{
+					ParametersFrom: []v1beta1.ParametersFromSource{fromSecret},
+				},
+			}
+			instance := &v1beta1.ServiceInstance{
+				Spec: v1beta1.ServiceInstanceSpec{
+					SecretParameterRefs: tc.refs,
+				},
+			}
+			if e, a := tc.expected, bindingParametersFrom(binding, instance); !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected parametersFrom: %v", diff.ObjectReflectDiff(e, a))
+			}
+			if e, a := 1, len(binding.Spec.ParametersFrom); e != a {
+				t.Fatalf("binding parametersFrom was modified: expected %v entries, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestPrepareInProgressPropertyParametersChecksumIgnoresFormatting(t *testing.T) {
+	cases := []struct {
+		name          string
+		oldParameters string
+		newParameters string
+		expectedEqual bool
+	}{
+		{
+			name:          "reordered keys",
+			oldParameters: `{"a":"first","b":{"x":1,"y":[1,2]}}`,
+			newParameters: `{"b":{"y":[1,2],"x":1},"a":"first"}`,
+			expectedEqual: true,
+		},
+		{
+			name:          "whitespace",
+			oldParameters: `{"a":"first","b":2}`,
+			newParameters: "{\n  \"a\" : \"first\",\n  \"b\" : 2\n}",
+			expectedEqual: true,
+		},
+		{
+			name:          "yaml and json",
+			oldParameters: `{"a":"first","b":2}`,
+			newParameters: "b: 2\na: first\n",
+			expectedEqual: true,
+		},
+		{
+			name:          "number representation",
+			oldParameters: `{"b":2}`,
+			newParameters: `{"b":2.0}`,
+			expectedEqual: true,
+		},
+		{
+			name:          "changed value",
+			oldParameters: `{"a":"first","b":2}`,
+			newParameters: `{"b":3,"a":"first"}`,
+			expectedEqual: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, oldChecksum, _, err := prepareInProgressPropertyParameters(nil, "", &runtime.RawExtension{Raw: []byte(tc.oldParameters)}, nil)
+			if err != nil {
+				t.Fatalf("failed to prepare old parameters: %v", err)
+			}
+			_, newChecksum, _, err := prepareInProgressPropertyParameters(nil, "", &runtime.RawExtension{Raw: []byte(tc.newParameters)}, nil)
+			if err != nil {
+				t.Fatalf("failed to prepare new parameters: %v", err)
+			}
+			if e, a := tc.expectedEqual, oldChecksum == newChecksum; e != a {
+				t.Fatalf("expected checksums equality to be %v: old %q, new %q", e, oldChecksum, newChecksum)
+			}
+		})
+	}
+}
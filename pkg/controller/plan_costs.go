@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+const (
+	// planMetadataCostsKey is the key of the plan metadata under which
+	// brokers advertise the costs of a plan.
+	planMetadataCostsKey = "costs"
+	// planMetadataAmountKey and planMetadataUnitKey are the keys of the
+	// amount and the unit of a cost. Some brokers put a single cost directly
+	// in the plan metadata.
+	planMetadataAmountKey = "amount"
+	planMetadataUnitKey   = "unit"
+	// planMetadataCurrencyKey is the key some brokers use for the currency
+	// of an amount that is sent as a plain number.
+	planMetadataCurrencyKey = "currency"
+)
+
+// convertPlanCosts sets the costs of a plan spec from the costs in the
+// metadata of the broker's plan. Besides the conventional list of costs, a
+// single cost object, in the costs key or directly in the metadata, and
+// amounts sent as strings or as a number with a separate currency are
+// accepted. A cost that can not be parsed is logged and skipped, so it does
+// not prevent the rest of the catalog from being converted.
+func convertPlanCosts(plan osb.Plan, spec *v1beta1.CommonServicePlanSpec) {
+	var raw []interface{}
+	if costs, ok := plan.Metadata[planMetadataCostsKey]; ok && costs != nil {
+		switch costs := costs.(type) {
+		case []interface{}:
+			raw = costs
+		case map[string]interface{}:
+			raw = []interface{}{costs}
+		default:
+			klog.Warningf("Ignoring the costs in the metadata of plan %q (%s): expected a list of costs, got %T", plan.Name, plan.ID, costs)
+			return
+		}
+	} else if _, ok := plan.Metadata[planMetadataAmountKey]; ok {
+		raw = []interface{}{plan.Metadata}
+	}
+
+	var costs []v1beta1.ServicePlanCost
+	for i, r := range raw {
+		cost, err := convertPlanCost(r)
+		if err != nil {
+			klog.Warningf("Ignoring cost %d in the metadata of plan %q (%s): %v", i, plan.Name, plan.ID, err)
+			continue
+		}
+		costs = append(costs, cost)
+	}
+	spec.Costs = costs
+}
+
+// convertPlanCost converts a single cost from the metadata of a plan.
+func convertPlanCost(raw interface{}) (v1beta1.ServicePlanCost, error) {
+	cost := v1beta1.ServicePlanCost{}
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return cost, fmt.Errorf("expected a JSON object, got %T", raw)
+	}
+
+	if unit, ok := fields[planMetadataUnitKey]; ok && unit != nil {
+		s, ok := unit.(string)
+		if !ok {
+			return cost, fmt.Errorf("expected the unit to be a string, got %T", unit)
+		}
+		cost.Unit = s
+	}
+
+	switch amount := fields[planMetadataAmountKey].(type) {
+	case map[string]interface{}:
+		if len(amount) == 0 {
+			return cost, fmt.Errorf("the amount has no currencies")
+		}
+		cost.Amount = make(map[string]string, len(amount))
+		for currency, value := range amount {
+			a, err := convertPlanCostAmount(value)
+			if err != nil {
+				return cost, fmt.Errorf("invalid amount in %q: %v", currency, err)
+			}
+			cost.Amount[strings.ToLower(currency)] = a
+		}
+	case nil:
+		return cost, fmt.Errorf("the cost has no amount")
+	default:
+		currency, ok := fields[planMetadataCurrencyKey].(string)
+		if !ok || currency == "" {
+			return cost, fmt.Errorf("the amount is not keyed by currency and the cost has no currency")
+		}
+		a, err := convertPlanCostAmount(amount)
+		if err != nil {
+			return cost, fmt.Errorf("invalid amount: %v", err)
+		}
+		cost.Amount = map[string]string{strings.ToLower(currency): a}
+	}
+	return cost, nil
+}
+
+// convertPlanCostAmount returns an amount sent as a number or as a string
+// containing a number in its canonical decimal form.
+func convertPlanCostAmount(value interface{}) (string, error) {
+	var f float64
+	switch value := value.(type) {
+	case float64:
+		f = value
+	case string:
+		var err error
+		if f, err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+	default:
+		return "", fmt.Errorf("expected a number, got %T", value)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%v is not a finite number", value)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), nil
+}
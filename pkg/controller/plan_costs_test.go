@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// TestConvertPlanCosts checks the conversion of the cost metadata layouts
+// found in the catalogs of real brokers.
+func TestConvertPlanCosts(t *testing.T) {
+	cases := []struct {
+		name  string
+		plan  string
+		costs []v1beta1.ServicePlanCost
+	}{
+		{
+			name: "no metadata",
+			plan: `{"id":"p1","name":"small"}`,
+		},
+		{
+			name: "no costs",
+			plan: `{"id":"p1","name":"small","metadata":{"displayName":"Small"}}`,
+		},
+		{
+			name: "conventional costs",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":[
+				{"amount":{"usd":99.0,"eur":49.5},"unit":"MONTHLY"},
+				{"amount":{"usd":0.99},"unit":"1GB of messages over 20GB"}]}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"usd": "99", "eur": "49.5"}, Unit: "MONTHLY"},
+				{Amount: map[string]string{"usd": "0.99"}, Unit: "1GB of messages over 20GB"},
+			},
+		},
+		{
+			name: "single cost object",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":{"amount":{"USD":"10.00"},"unit":"MONTHLY"}}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"usd": "10"}, Unit: "MONTHLY"},
+			},
+		},
+		{
+			name: "cost directly in the metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"amount":{"usd":5},"unit":"HOURLY"}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"usd": "5"}, Unit: "HOURLY"},
+			},
+		},
+		{
+			name: "amount with a separate currency",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":[{"amount":12.5,"currency":"EUR","unit":"MONTHLY"}]}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"eur": "12.5"}, Unit: "MONTHLY"},
+			},
+		},
+		{
+			name: "cost without a unit",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":[{"amount":{"usd":1}}]}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"usd": "1"}},
+			},
+		},
+		{
+			name: "unparseable costs are skipped",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":[
+				"free for now",
+				{"unit":"MONTHLY"},
+				{"amount":{},"unit":"MONTHLY"},
+				{"amount":{"usd":"a lot"},"unit":"MONTHLY"},
+				{"amount":{"usd":true},"unit":"MONTHLY"},
+				{"amount":7,"unit":"MONTHLY"},
+				{"amount":{"usd":1},"unit":30},
+				{"amount":{"usd":"NaN"},"unit":"MONTHLY"},
+				{"amount":{"usd":2},"unit":"MONTHLY"}]}}`,
+			costs: []v1beta1.ServicePlanCost{
+				{Amount: map[string]string{"usd": "2"}, Unit: "MONTHLY"},
+			},
+		},
+		{
+			name: "costs that are not a list",
+			plan: `{"id":"p1","name":"small","metadata":{"costs":"contact sales"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := osb.Plan{}
+			if err := json.Unmarshal([]byte(tc.plan), &plan); err != nil {
+				t.Fatalf("Failed to unmarshal the plan: %v", err)
+			}
+			spec := &v1beta1.CommonServicePlanSpec{}
+			convertPlanCosts(plan, spec)
+
+			if !reflect.DeepEqual(tc.costs, spec.Costs) {
+				t.Errorf("Unexpected costs: expected %+v, got %+v", tc.costs, spec.Costs)
+			}
+		})
+	}
+}
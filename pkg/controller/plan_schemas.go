@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+)
+
+// planMetadataSchemasKey is the key of the plan metadata under which some
+// brokers send the schemas of a plan instead of the schemas field.
+const planMetadataSchemasKey = "schemas"
+
+// convertPlanSchemas sets the parameter and response schemas of a plan spec
+// from the schemas of the broker's plan. The schemas are taken from the OSB
+// schemas field or, if the broker did not set it, from the schemas key of
+// the plan metadata. A schema that can not be used is logged and skipped, so
+// it does not prevent the rest of the catalog from being converted.
+func convertPlanSchemas(plan osb.Plan, spec *v1beta1.CommonServicePlanSpec) {
+	schemas := plan.Schemas
+	if schemas == nil {
+		schemas = planMetadataSchemas(plan)
+	}
+	if schemas == nil {
+		return
+	}
+
+	if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
+		if create := instanceSchemas.Create; create != nil {
+			spec.InstanceCreateParameterSchema = convertPlanSchema(plan, "service_instance.create.parameters", create.Parameters)
+		}
+		if update := instanceSchemas.Update; update != nil {
+			spec.InstanceUpdateParameterSchema = convertPlanSchema(plan, "service_instance.update.parameters", update.Parameters)
+		}
+	}
+	if bindingSchemas := schemas.ServiceBinding; bindingSchemas != nil {
+		if create := bindingSchemas.Create; create != nil {
+			spec.ServiceBindingCreateParameterSchema = convertPlanSchema(plan, "service_binding.create.parameters", create.Parameters)
+			if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) {
+				spec.ServiceBindingCreateResponseSchema = convertPlanSchema(plan, "service_binding.create.response", create.Response)
+			}
+		}
+	}
+}
+
+// planMetadataSchemas returns the schemas found in the metadata of a plan, or
+// nil if there are none or they do not have the layout of the schemas field.
+// Each section is decoded on its own, so a section that can not be used is
+// logged and skipped without dropping the others.
+func planMetadataSchemas(plan osb.Plan) *osb.Schemas {
+	raw, ok := plan.Metadata[planMetadataSchemasKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		klog.Warningf("Ignoring the schemas in the metadata of plan %q (%s): %v", plan.Name, plan.ID, err)
+		return nil
+	}
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &sections); err != nil {
+		klog.Warningf("Ignoring the schemas in the metadata of plan %q (%s): %v", plan.Name, plan.ID, err)
+		return nil
+	}
+
+	schemas := &osb.Schemas{}
+	for section, b := range sections {
+		switch section {
+		case "service_instance":
+			instanceSchemas := &osb.ServiceInstanceSchema{}
+			if err := json.Unmarshal(b, instanceSchemas); err != nil {
+				klog.Warningf("Ignoring the %s schemas in the metadata of plan %q (%s): %v", section, plan.Name, plan.ID, err)
+				continue
+			}
+			schemas.ServiceInstance = instanceSchemas
+		case "service_binding":
+			schemas.ServiceBinding = planMetadataBindingSchemas(plan, b)
+		default:
+			klog.Warningf("Ignoring the %s schemas in the metadata of plan %q (%s): unknown section", section, plan.Name, plan.ID)
+		}
+	}
+	return schemas
+}
+
+// planMetadataBindingSchemas decodes the service_binding section of the
+// schemas in the metadata of a plan. OSB only defines schemas for creating a
+// binding: bindings can not be updated, so schemas for any other operation,
+// such as service_binding.update, are logged and ignored. The OSB client drops
+// the same keys from the schemas field when it decodes the catalog.
+func planMetadataBindingSchemas(plan osb.Plan, b json.RawMessage) *osb.ServiceBindingSchema {
+	operations := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &operations); err != nil {
+		klog.Warningf("Ignoring the service_binding schemas in the metadata of plan %q (%s): %v", plan.Name, plan.ID, err)
+		return nil
+	}
+	bindingSchemas := &osb.ServiceBindingSchema{}
+	for operation, b := range operations {
+		if operation != "create" {
+			klog.Warningf("Ignoring the service_binding.%s schema in the metadata of plan %q (%s): only service_binding.create is supported, bindings can not be updated", operation, plan.Name, plan.ID)
+			continue
+		}
+		create := &osb.RequestResponseSchema{}
+		if err := json.Unmarshal(b, create); err != nil {
+			klog.Warningf("Ignoring the service_binding.create schemas in the metadata of plan %q (%s): %v", plan.Name, plan.ID, err)
+			continue
+		}
+		bindingSchemas.Create = create
+	}
+	return bindingSchemas
+}
+
+// convertPlanSchema returns the given schema of a plan as a raw extension, or
+// nil if the broker did not send it or sent something other than a JSON
+// object. Schemas sent as a JSON encoded string are decoded first.
+func convertPlanSchema(plan osb.Plan, path string, schema interface{}) *runtime.RawExtension {
+	if schema == nil {
+		return nil
+	}
+	if s, ok := schema.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			klog.Warningf("Ignoring the %s schema of plan %q (%s): it is a string that does not contain JSON: %v", path, plan.Name, plan.ID, err)
+			return nil
+		}
+		schema = decoded
+	}
+	if _, ok := schema.(map[string]interface{}); !ok {
+		klog.Warningf("Ignoring the %s schema of plan %q (%s): expected a JSON object, got %T", path, plan.Name, plan.ID, schema)
+		return nil
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		klog.Warningf("Ignoring the %s schema of plan %q (%s): %v", path, plan.Name, plan.ID, err)
+		return nil
+	}
+	return &runtime.RawExtension{Raw: b}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+)
+
+// TestConvertPlanSchemas checks the conversion of the plan schema layouts
+// found in the catalogs of real brokers.
+func TestConvertPlanSchemas(t *testing.T) {
+	utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ResponseSchema))
+	defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ResponseSchema))
+
+	const schema = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object"}`
+
+	cases := []struct {
+		name                  string
+		plan                  string
+		instanceCreate        string
+		instanceUpdate        string
+		bindingCreate         string
+		bindingCreateResponse string
+	}{
+		{
+			name: "no schemas",
+			plan: `{"id":"p1","name":"small"}`,
+		},
+		{
+			name: "standard layout",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_instance":{"create":{"parameters":` + schema + `},"update":{"parameters":` + schema + `}},
+				"service_binding":{"create":{"parameters":` + schema + `,"response":` + schema + `}}}}`,
+			instanceCreate:        schema,
+			instanceUpdate:        schema,
+			bindingCreate:         schema,
+			bindingCreateResponse: schema,
+		},
+		{
+			name: "only binding schemas",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_binding":{"create":{"parameters":` + schema + `}}}}`,
+			bindingCreate: schema,
+		},
+		{
+			name: "empty create section",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_instance":{"create":{}},"service_binding":{}}}`,
+		},
+		{
+			name: "schema sent as a JSON string",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_instance":{"create":{"parameters":` + fmt.Sprintf("%q", schema) + `}}}}`,
+			instanceCreate: schema,
+		},
+		{
+			name: "schemas nested in the plan metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"displayName":"Small","schemas":{
+				"service_instance":{"create":{"parameters":` + schema + `}},
+				"service_binding":{"create":{"parameters":` + schema + `}}}}}`,
+			instanceCreate: schema,
+			bindingCreate:  schema,
+		},
+		{
+			name: "schemas field takes precedence over the plan metadata",
+			plan: `{"id":"p1","name":"small",
+				"schemas":{"service_binding":{"create":{"parameters":` + schema + `}}},
+				"metadata":{"schemas":{"service_instance":{"create":{"parameters":` + schema + `}}}}}`,
+			bindingCreate: schema,
+		},
+		{
+			name: "unparseable schemas in the plan metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"schemas":["not","an","object"]}}`,
+		},
+		{
+			name: "string that is not JSON",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_instance":{"create":{"parameters":"see the documentation"},"update":{"parameters":` + schema + `}}}}`,
+			instanceUpdate: schema,
+		},
+		{
+			name: "schema that is not an object",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_instance":{"create":{"parameters":[` + schema + `]}},
+				"service_binding":{"create":{"parameters":true}}}}`,
+		},
+		{
+			name: "binding update schema",
+			plan: `{"id":"p1","name":"small","schemas":{
+				"service_binding":{"create":{"parameters":` + schema + `},"update":{"parameters":` + schema + `}}}}`,
+			bindingCreate: schema,
+		},
+		{
+			name: "binding update schema in the plan metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"schemas":{
+				"service_binding":{"create":{"parameters":` + schema + `},"update":{"parameters":` + schema + `}}}}}`,
+			bindingCreate: schema,
+		},
+		{
+			name: "unparseable binding schemas in the plan metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"schemas":{
+				"service_instance":{"create":{"parameters":` + schema + `}},
+				"service_binding":{"update":"not an object"}}}}`,
+			instanceCreate: schema,
+		},
+		{
+			name: "unknown section in the plan metadata",
+			plan: `{"id":"p1","name":"small","metadata":{"schemas":{
+				"service_instance":{"update":{"parameters":` + schema + `}},
+				"service_key":{"create":{"parameters":` + schema + `}}}}}`,
+			instanceUpdate: schema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := osb.Plan{}
+			if err := json.Unmarshal([]byte(tc.plan), &plan); err != nil {
+				t.Fatalf("Failed to unmarshal the plan: %v", err)
+			}
+			spec := &v1beta1.CommonServicePlanSpec{}
+			convertPlanSchemas(plan, spec)
+
+			checkPlanSchema(t, "instance create", tc.instanceCreate, spec.InstanceCreateParameterSchema)
+			checkPlanSchema(t, "instance update", tc.instanceUpdate, spec.InstanceUpdateParameterSchema)
+			checkPlanSchema(t, "binding create", tc.bindingCreate, spec.ServiceBindingCreateParameterSchema)
+			checkPlanSchema(t, "binding create response", tc.bindingCreateResponse, spec.ServiceBindingCreateResponseSchema)
+		})
+	}
+}
+
+func checkPlanSchema(t *testing.T, name, expected string, actual *runtime.RawExtension) {
+	t.Helper()
+	if expected == "" {
+		if actual != nil {
+			t.Errorf("Expected no %s schema, got %s", name, actual.Raw)
+		}
+		return
+	}
+	if actual == nil {
+		t.Errorf("Expected the %s schema %s, got nil", name, expected)
+		return
+	}
+	if string(actual.Raw) != expected {
+		t.Errorf("Unexpected %s schema: expected %s, got %s", name, expected, actual.Raw)
+	}
+}
+
+// TestCatalogConversionWithBindingUpdateSchemas checks that a catalog with
+// schemas for updating a binding, which OSB does not define, is converted
+// with only the schemas for creating a binding.
+func TestCatalogConversionWithBindingUpdateSchemas(t *testing.T) {
+	const schema = `{"type":"object"}`
+	const catalogJSON = `{"services":[{"id":"s1","name":"db","description":"A database","bindable":true,"plans":[
+		{"id":"p1","name":"small","description":"Small","schemas":{
+			"service_binding":{"create":{"parameters":` + schema + `},"update":{"parameters":` + schema + `}}}},
+		{"id":"p2","name":"large","description":"Large","metadata":{"schemas":{
+			"service_binding":{"create":{"parameters":` + schema + `},"update":{"parameters":` + schema + `}}}}}]}]}`
+
+	catalog := &osb.CatalogResponse{}
+	if err := json.Unmarshal([]byte(catalogJSON), catalog); err != nil {
+		t.Fatalf("Failed to unmarshal the catalog: %v", err)
+	}
+	_, servicePlans, err := convertAndFilterCatalog(catalog, nil, emptyServiceClasses, emptyServicePlans)
+	if err != nil {
+		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
+	}
+	if len(servicePlans) != 2 {
+		t.Fatalf("Expected 2 serviceplans, got %d", len(servicePlans))
+	}
+	for _, plan := range servicePlans {
+		checkPlanSchema(t, plan.Spec.ExternalName+" binding create", schema, plan.Spec.ServiceBindingCreateParameterSchema)
+	}
+}
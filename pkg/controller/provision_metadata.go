@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+)
+
+const (
+	// maxProvisionMetadataEntries is the maximum number of labels, and of
+	// attributes, of the provision metadata recorded for an instance. The
+	// entries with the greatest keys are dropped.
+	maxProvisionMetadataEntries = 32
+	// maxProvisionMetadataKeyLength is the maximum length of the keys of the
+	// provision metadata; the entries with longer keys are dropped.
+	maxProvisionMetadataKeyLength = 63
+	// maxProvisionMetadataValueLength is the maximum number of characters of
+	// the values of the provision metadata; longer values are truncated.
+	maxProvisionMetadataValueLength = 256
+
+	// redactedProvisionMetadataValue replaces the values of the provision
+	// metadata whose key looks sensitive.
+	redactedProvisionMetadataValue = "<redacted>"
+)
+
+// sensitiveProvisionMetadataKeys are the words which make a key of the
+// provision metadata look sensitive, once lowercased and stripped of its
+// separators.
+var sensitiveProvisionMetadataKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"credential",
+	"privatekey",
+	"apikey",
+	"accesskey",
+}
+
+// setServiceInstanceProvisionMetadata records on the given instance the
+// metadata that the broker returned in the response to its provision
+// request, sanitized to be bounded in size and to not expose the values of
+// the keys that look sensitive. The instance keeps its metadata when the
+// response has none.
+func setServiceInstanceProvisionMetadata(instance *v1beta1.ServiceInstance, metadata *brokerhttp.ServiceInstanceMetadata) {
+	if metadata == nil {
+		return
+	}
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	labels := sanitizeProvisionMetadata(pcb, "label", metadata.Labels)
+	attributes := sanitizeProvisionMetadata(pcb, "attribute", metadata.Attributes)
+	if labels == nil && attributes == nil {
+		return
+	}
+	instance.Status.ProvisionMetadata = &v1beta1.ServiceInstanceProvisionMetadata{
+		Labels:     labels,
+		Attributes: attributes,
+	}
+}
+
+// sanitizeProvisionMetadata returns the entries of the given labels or
+// attributes of the provision metadata as strings, keeping at most
+// maxProvisionMetadataEntries of them, truncating their values and redacting
+// the values of the keys that look sensitive.
+func sanitizeProvisionMetadata(pcb *pretty.ContextBuilder, kind string, entries map[string]interface{}) map[string]string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		if k == "" || len(k) > maxProvisionMetadataKeyLength {
+			klog.V(4).Info(pcb.Messagef("Dropping the provision metadata %s %q: the key is empty or longer than %d characters", kind, k, maxProvisionMetadataKeyLength))
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	if len(keys) > maxProvisionMetadataEntries {
+		klog.V(4).Info(pcb.Messagef("Dropping %d provision metadata %ss beyond the first %d", len(keys)-maxProvisionMetadataEntries, kind, maxProvisionMetadataEntries))
+		keys = keys[:maxProvisionMetadataEntries]
+	}
+
+	sanitized := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if isSensitiveProvisionMetadataKey(k) {
+			sanitized[k] = redactedProvisionMetadataValue
+			continue
+		}
+		sanitized[k] = truncateProvisionMetadataValue(provisionMetadataValue(entries[k]))
+	}
+	return sanitized
+}
+
+// isSensitiveProvisionMetadataKey returns whether the given key of the
+// provision metadata looks like it holds a secret, e.g. "db_password" or
+// "apiKey".
+func isSensitiveProvisionMetadataKey(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "", ".", "").Replace(strings.ToLower(key))
+	for _, word := range sensitiveProvisionMetadataKeys {
+		if strings.Contains(normalized, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// provisionMetadataValue returns the given value of the provision metadata as
+// a string, encoding the values which are not strings as JSON.
+func provisionMetadataValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// truncateProvisionMetadataValue truncates a value of the provision metadata
+// to maxProvisionMetadataValueLength characters.
+func truncateProvisionMetadataValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= maxProvisionMetadataValueLength {
+		return value
+	}
+	return string(runes[:maxProvisionMetadataValueLength-len(truncatedSuffix)]) + truncatedSuffix
+}
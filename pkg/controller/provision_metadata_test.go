@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+)
+
+// TestSetServiceInstanceProvisionMetadata tests that the metadata returned by
+// a broker on provision is recorded bounded in size and with the values of
+// the sensitive-looking keys redacted.
+func TestSetServiceInstanceProvisionMetadata(t *testing.T) {
+	manyAttributes := map[string]interface{}{}
+	for i := 0; i < maxProvisionMetadataEntries+5; i++ {
+		manyAttributes[fmt.Sprintf("attr-%02d", i)] = "value"
+	}
+	expectedManyAttributes := map[string]string{}
+	for i := 0; i < maxProvisionMetadataEntries; i++ {
+		expectedManyAttributes[fmt.Sprintf("attr-%02d", i)] = "value"
+	}
+
+	cases := []struct {
+		name     string
+		existing *v1beta1.ServiceInstanceProvisionMetadata
+		metadata *brokerhttp.ServiceInstanceMetadata
+		expected *v1beta1.ServiceInstanceProvisionMetadata
+	}{
+		{
+			name: "no metadata",
+		},
+		{
+			name:     "no metadata keeps the existing one",
+			existing: &v1beta1.ServiceInstanceProvisionMetadata{Labels: map[string]string{"team": "payments"}},
+			expected: &v1beta1.ServiceInstanceProvisionMetadata{Labels: map[string]string{"team": "payments"}},
+		},
+		{
+			name: "empty metadata",
+			metadata: &brokerhttp.ServiceInstanceMetadata{
+				Labels: map[string]interface{}{},
+			},
+		},
+		{
+			name: "labels and attributes",
+			metadata: &brokerhttp.ServiceInstanceMetadata{
+				Labels: map[string]interface{}{
+					"team": "payments",
+				},
+				Attributes: map[string]interface{}{
+					"arn":      "arn:aws:rds:us-east-1:123456789012:db:mydb",
+					"region":   "us-east-1",
+					"replicas": 3,
+					"tags":     []interface{}{"a", "b"},
+				},
+			},
+			expected: &v1beta1.ServiceInstanceProvisionMetadata{
+				Labels: map[string]string{
+					"team": "payments",
+				},
+				Attributes: map[string]string{
+					"arn":      "arn:aws:rds:us-east-1:123456789012:db:mydb",
+					"region":   "us-east-1",
+					"replicas": "3",
+					"tags":     `["a","b"]`,
+				},
+			},
+		},
+		{
+			name: "sensitive keys",
+			metadata: &brokerhttp.ServiceInstanceMetadata{
+				Attributes: map[string]interface{}{
+					"db_password":     "hunter2",
+					"apiKey":          "abc",
+					"Access-Key-ID":   "AKIA",
+					"auth.token":      "t",
+					"secretName":      "s",
+					"connectionLimit": "10",
+				},
+			},
+			expected: &v1beta1.ServiceInstanceProvisionMetadata{
+				Attributes: map[string]string{
+					"db_password":     redactedProvisionMetadataValue,
+					"apiKey":          redactedProvisionMetadataValue,
+					"Access-Key-ID":   redactedProvisionMetadataValue,
+					"auth.token":      redactedProvisionMetadataValue,
+					"secretName":      redactedProvisionMetadataValue,
+					"connectionLimit": "10",
+				},
+			},
+		},
+		{
+			name: "bounded",
+			metadata: &brokerhttp.ServiceInstanceMetadata{
+				Labels: map[string]interface{}{
+					strings.Repeat("k", maxProvisionMetadataKeyLength+1): "dropped",
+					"long": strings.Repeat("v", maxProvisionMetadataValueLength+1),
+				},
+				Attributes: manyAttributes,
+			},
+			expected: &v1beta1.ServiceInstanceProvisionMetadata{
+				Labels: map[string]string{
+					"long": strings.Repeat("v", maxProvisionMetadataValueLength-len(truncatedSuffix)) + truncatedSuffix,
+				},
+				Attributes: expectedManyAttributes,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := getTestServiceInstance()
+			instance.Status.ProvisionMetadata = tc.existing
+			setServiceInstanceProvisionMetadata(instance, tc.metadata)
+			if e, a := tc.expected, instance.Status.ProvisionMetadata; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected provision metadata: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceProvisionMetadata tests that the metadata
+// returned by the broker on a synchronous provision is recorded in the status
+// of the instance.
+func TestReconcileServiceInstanceProvisionMetadata(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+	brokerClient := &fakeBrokerHTTPClient{
+		FakeClient: fakeClusterServiceBrokerClient,
+		metadata: &brokerhttp.ServiceInstanceMetadata{
+			Attributes: map[string]interface{}{
+				"region":   "us-east-1",
+				"password": "hunter2",
+			},
+		},
+	}
+	testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+		return brokerClient, nil
+	}
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance, successProvisionReason)
+
+	expected := &v1beta1.ServiceInstanceProvisionMetadata{
+		Attributes: map[string]string{
+			"region":   "us-east-1",
+			"password": redactedProvisionMetadataValue,
+		},
+	}
+	if e, a := expected, updatedServiceInstance.(*v1beta1.ServiceInstance).Status.ProvisionMetadata; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected provision metadata: %s", expectedGot(e, a))
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+)
+
+// reservedContextKeys are the keys of the OSB context that are set by the
+// controller or defined by the Kubernetes profile of the OSB API. The
+// context configured for the controller or for a broker can not set them.
+var reservedContextKeys = sets.NewString(
+	"platform",
+	"namespace",
+	namespaceLabelsContextKey,
+	clusterIdentifierKey,
+	"instance_name",
+	"instance_annotations",
+)
+
+// parseRequestContext parses the JSON object holding additional keys of the
+// OSB context. An empty value holds no keys.
+func parseRequestContext(value string) (map[string]interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var requestContext map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &requestContext); err != nil {
+		return nil, fmt.Errorf("it must be a JSON object: %v", err)
+	}
+	var reserved []string
+	for key := range requestContext {
+		if reservedContextKeys.Has(key) {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) > 0 {
+		sort.Strings(reserved)
+		return nil, fmt.Errorf("the keys %v are reserved, reserved keys are: %v", reserved, reservedContextKeys.List())
+	}
+	return requestContext, nil
+}
+
+// brokerRequestContext returns the additional keys of the OSB context of the
+// given broker, read from its context annotation. A missing broker or an
+// invalid annotation holds no keys.
+func (c *controller) brokerRequestContext(clusterScoped bool, namespace, brokerName string) map[string]interface{} {
+	var annotations map[string]string
+	if clusterScoped {
+		broker, err := c.clusterServiceBrokerLister.Get(brokerName)
+		if err != nil {
+			return nil
+		}
+		annotations = broker.Annotations
+	} else if c.serviceBrokerLister != nil {
+		broker, err := c.serviceBrokerLister.ServiceBrokers(namespace).Get(brokerName)
+		if err != nil {
+			return nil
+		}
+		annotations = broker.Annotations
+	}
+
+	value, ok := annotations[v1beta1.ServiceBrokerContextAnnotation]
+	if !ok {
+		return nil
+	}
+	requestContext, err := parseRequestContext(value)
+	if err != nil {
+		klog.Warningf("Ignoring the invalid %s annotation of broker %q: %v", v1beta1.ServiceBrokerContextAnnotation, brokerName, err)
+		return nil
+	}
+	return requestContext
+}
+
+// instanceBrokerRequestContext returns the additional keys of the OSB context
+// of the broker of the class the instance refers to.
+func (c *controller) instanceBrokerRequestContext(instance *v1beta1.ServiceInstance) map[string]interface{} {
+	if instance.Spec.ClusterServiceClassSpecified() {
+		if instance.Spec.ClusterServiceClassRef == nil {
+			return nil
+		}
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return nil
+		}
+		return c.brokerRequestContext(true, "", class.Spec.ClusterServiceBrokerName)
+	}
+	if instance.Spec.ServiceClassRef == nil || c.serviceClassLister == nil {
+		return nil
+	}
+	class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+	if err != nil {
+		return nil
+	}
+	return c.brokerRequestContext(false, instance.Namespace, class.Spec.ServiceBrokerName)
+}
+
+// addConfiguredRequestContext adds the keys of the context of the broker and
+// of the controller to the given OSB context. The keys set by the controller
+// take precedence over the context of the broker, which takes precedence
+// over the context of the controller.
+func (c *controller) addConfiguredRequestContext(requestContext, brokerContext map[string]interface{}) {
+	for key, value := range brokerContext {
+		if _, ok := requestContext[key]; !ok {
+			requestContext[key] = value
+		}
+	}
+	for key, value := range c.osbAPIContext {
+		if _, ok := requestContext[key]; !ok {
+			requestContext[key] = value
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+func TestParseRequestContext(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected map[string]interface{}
+		err      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "hints",
+			value:    `{"encrypt": true, "region": "eu-west-1"}`,
+			expected: map[string]interface{}{"encrypt": true, "region": "eu-west-1"},
+		},
+		{
+			name:  "not an object",
+			value: `["encrypt"]`,
+			err:   "it must be a JSON object",
+		},
+		{
+			name:  "reserved keys",
+			value: `{"namespace": "other", "platform": "cloudfoundry", "region": "eu-west-1"}`,
+			err:   "the keys [namespace platform] are reserved",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseRequestContext(tc.value)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Fatalf("unexpected context: %v", diff.ObjectReflectDiff(tc.expected, actual))
+			}
+		})
+	}
+}
+
+// TestBuildRequestContextConfiguredContext tests that the context configured
+// for the controller and the context annotation of the broker are added to the
+// OSB context of an instance, the broker taking precedence over the
+// controller, and neither overriding the keys set by the controller.
+func TestBuildRequestContextConfiguredContext(t *testing.T) {
+	cases := []struct {
+		name              string
+		controllerContext map[string]interface{}
+		brokerAnnotation  string
+		expected          map[string]interface{}
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:              "controller",
+			controllerContext: map[string]interface{}{"encrypt": true, "region": "eu-west-1"},
+			expected:          map[string]interface{}{"encrypt": true, "region": "eu-west-1"},
+		},
+		{
+			name:              "broker takes precedence",
+			controllerContext: map[string]interface{}{"encrypt": true, "region": "eu-west-1"},
+			brokerAnnotation:  `{"region": "us-east-1"}`,
+			expected:          map[string]interface{}{"encrypt": true, "region": "us-east-1"},
+		},
+		{
+			name:              "invalid broker annotation",
+			controllerContext: map[string]interface{}{"region": "eu-west-1"},
+			brokerAnnotation:  `{"namespace": "other"}`,
+			expected:          map[string]interface{}{"region": "eu-west-1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.osbAPIContext = tc.controllerContext
+
+			broker := getTestClusterServiceBroker()
+			if tc.brokerAnnotation != "" {
+				broker.Annotations = map[string]string{v1beta1.ServiceBrokerContextAnnotation: tc.brokerAnnotation}
+			}
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+			expected := map[string]interface{}{
+				"platform":           ContextProfilePlatformKubernetes,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: testClusterID,
+				"instance_name":      testServiceInstanceName,
+			}
+			for key, value := range tc.expected {
+				expected[key] = value
+			}
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, UID: testNamespaceGUID}}
+			actual := testController.buildRequestContext(getTestServiceInstanceWithClusterRefs(), ns)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("unexpected request context: %v", diff.ObjectReflectDiff(expected, actual))
+			}
+		})
+	}
+}
+
+// TestPrepareBindRequestConfiguredContext tests that the context configured
+// for the controller and the context annotation of the broker are added to the
+// OSB context of bind requests.
+func TestPrepareBindRequestConfiguredContext(t *testing.T) {
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.osbAPIContext = map[string]interface{}{"encrypt": true, "region": "eu-west-1"}
+
+	addGetNamespaceReaction(fakeKubeClient)
+	broker := getTestClusterServiceBroker()
+	broker.Annotations = map[string]string{v1beta1.ServiceBrokerContextAnnotation: `{"region": "us-east-1"}`}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	request, _, err := testController.prepareBindRequest(getTestServiceBinding(), getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"platform":           ContextProfilePlatformKubernetes,
+		"namespace":          testNamespace,
+		clusterIdentifierKey: testClusterID,
+		"instance_name":      testServiceInstanceName,
+		"encrypt":            true,
+		"region":             "us-east-1",
+	}
+	if !reflect.DeepEqual(expected, request.Context) {
+		t.Fatalf("unexpected request context: %v", diff.ObjectReflectDiff(expected, request.Context))
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// serviceAccountTokenExpirationSeconds is the lifetime requested for the
+// ServiceAccount tokens sent to brokers.
+const serviceAccountTokenExpirationSeconds int64 = 3600
+
+// serviceAccountTokenKey identifies a token of a ServiceAccount for an audience.
+type serviceAccountTokenKey struct {
+	namespace string
+	name      string
+	audience  string
+}
+
+type serviceAccountToken struct {
+	token      string
+	expiration time.Time
+	refreshAt  time.Time
+}
+
+// serviceAccountTokenCache requests the tokens of ServiceAccounts through the
+// TokenRequest API and caches them until most of their lifetime has elapsed.
+type serviceAccountTokenCache struct {
+	mu     sync.Mutex
+	tokens map[serviceAccountTokenKey]serviceAccountToken
+
+	kubeClient kubernetes.Interface
+	now        func() time.Time
+}
+
+func newServiceAccountTokenCache(kubeClient kubernetes.Interface) *serviceAccountTokenCache {
+	return &serviceAccountTokenCache{
+		tokens:     map[serviceAccountTokenKey]serviceAccountToken{},
+		kubeClient: kubeClient,
+		now:        time.Now,
+	}
+}
+
+// Token returns a token of the given ServiceAccount for the given audience.
+// A new token is requested once 80% of the lifetime of the cached one has
+// elapsed. Should that request fail, the cached token is returned for as long
+// as it is valid.
+func (c *serviceAccountTokenCache) Token(namespace, name, audience string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := serviceAccountTokenKey{namespace: namespace, name: name, audience: audience}
+	now := c.now()
+	cached, found := c.tokens[key]
+	if found && now.Before(cached.refreshAt) {
+		return cached.token, nil
+	}
+
+	expirationSeconds := serviceAccountTokenExpirationSeconds
+	tr, err := c.kubeClient.CoreV1().ServiceAccounts(namespace).CreateToken(name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{audience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	})
+	if err != nil {
+		if found && now.Before(cached.expiration) {
+			klog.Warningf("Failed to renew the token of ServiceAccount %s/%s, using the current token until it expires at %v: %v", namespace, name, cached.expiration, err)
+			return cached.token, nil
+		}
+		delete(c.tokens, key)
+		return "", fmt.Errorf("unable to request a token of ServiceAccount %s/%s: %v", namespace, name, err)
+	}
+
+	expiration := tr.Status.ExpirationTimestamp.Time
+	c.tokens[key] = serviceAccountToken{
+		token:      tr.Status.Token,
+		expiration: expiration,
+		refreshAt:  now.Add(expiration.Sub(now) * 4 / 5),
+	}
+	return tr.Status.Token, nil
+}
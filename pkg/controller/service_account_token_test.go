@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+func TestServiceAccountTokenCache(t *testing.T) {
+	now := time.Now()
+	requests := 0
+	var requestErr error
+
+	kubeClient := &clientgofake.Clientset{}
+	kubeClient.AddReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		if requestErr != nil {
+			return true, nil, requestErr
+		}
+		requests++
+		tr := action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		if e, a := []string{"test-broker"}, tr.Spec.Audiences; len(a) != 1 || a[0] != e[0] {
+			t.Fatalf("unexpected audiences: expected %v, got %v", e, a)
+		}
+		return true, &authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{
+				Token:               fmt.Sprintf("token-%d", requests),
+				ExpirationTimestamp: metav1.NewTime(now.Add(time.Hour)),
+			},
+		}, nil
+	})
+
+	cache := newServiceAccountTokenCache(kubeClient)
+	cache.now = func() time.Time { return now }
+
+	assertToken := func(expected string) {
+		t.Helper()
+		token, err := cache.Token("test-ns", "test-sa", "test-broker")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != expected {
+			t.Fatalf("unexpected token: expected %q, got %q", expected, token)
+		}
+	}
+
+	// The first token is requested and then cached
+	assertToken("token-1")
+	assertToken("token-1")
+	if requests != 1 {
+		t.Fatalf("expected 1 token request, got %v", requests)
+	}
+
+	// Once 80% of its lifetime has elapsed, the token is renewed
+	now = now.Add(50 * time.Minute)
+	assertToken("token-2")
+
+	// The current token is used while renewals fail, until it expires
+	requestErr = errors.New("the API server is not available")
+	now = now.Add(50 * time.Minute)
+	assertToken("token-2")
+
+	now = now.Add(time.Hour)
+	if _, err := cache.Token("test-ns", "test-sa", "test-broker"); err == nil {
+		t.Fatal("expected an error once the current token has expired")
+	}
+}
+
+func TestGetAuthCredentialsWithServiceAccountToken(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	fakeKubeClient.PrependReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{
+				Token:               fmt.Sprintf("token-of-%s/%s", action.GetNamespace(), action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenRequest).Spec.Audiences[0]),
+				ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+			},
+		}, nil
+	})
+
+	clusterBroker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		ServiceAccountToken: &v1beta1.ClusterServiceAccountTokenAuthConfig{
+			ServiceAccountRef: &v1beta1.ObjectReference{Namespace: "broker-ns", Name: "test-sa"},
+			Audience:          "cluster-broker",
+		},
+	})
+	authConfig, err := testController.getAuthCredentialsFromClusterServiceBroker(clusterBroker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "token-of-broker-ns/cluster-broker", authConfig.BearerConfig.Token; e != a {
+		t.Fatalf("unexpected token: expected %q, got %q", e, a)
+	}
+
+	broker := getTestServiceBrokerWithAuth(&v1beta1.ServiceBrokerAuthInfo{
+		ServiceAccountToken: &v1beta1.ServiceAccountTokenAuthConfig{
+			ServiceAccountRef: &v1beta1.LocalObjectReference{Name: "test-sa"},
+			Audience:          "broker",
+		},
+	})
+	authConfig, err = testController.getAuthCredentialsFromServiceBroker(broker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := fmt.Sprintf("token-of-%s/broker", broker.Namespace), authConfig.BearerConfig.Token; e != a {
+		t.Fatalf("unexpected token: expected %q, got %q", e, a)
+	}
+}
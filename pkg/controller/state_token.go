@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+)
+
+// isStateTokenConflictError returns whether the error is the response of a
+// broker refusing the state token sent with a request, because the instance
+// changed since the token was recorded.
+func isStateTokenConflictError(err error) bool {
+	httpErr, ok := osb.IsHTTPError(err)
+	return ok && (httpErr.StatusCode == http.StatusConflict || httpErr.StatusCode == http.StatusPreconditionFailed)
+}
+
+// doWithServiceInstanceStateToken sends a request for the instance with its
+// state token. When the broker refuses the token, and the class of the
+// instance is instancesRetrievable, the instance is fetched from the broker
+// for its current token, which is recorded on the instance, and the request
+// is sent again with it. Otherwise, the error of the request is returned.
+func (c *controller) doWithServiceInstanceStateToken(instance *v1beta1.ServiceInstance, instancesRetrievable bool, brokerClient osb.Client, do func(stateToken string) error) error {
+	err := do(instance.Status.StateToken)
+	if err == nil || instance.Status.StateToken == "" || !instancesRetrievable || !isStateTokenConflictError(err) {
+		return err
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Messagef("The broker refused the state token of the instance, fetching the instance: %v", err))
+	response, getErr := brokerhttp.GetInstance(brokerClient, &brokerhttp.GetInstanceRequest{
+		InstanceID: instance.Spec.ExternalID,
+	})
+	if getErr != nil {
+		klog.Warning(pcb.Messagef("Error fetching the instance for its state token: %v", getErr))
+		return err
+	}
+	instance.Status.StateToken = response.StateToken
+	return do(instance.Status.StateToken)
+}
+
+// updateServiceInstanceAtBroker sends the update request of the instance
+// with its state token, see doWithServiceInstanceStateToken.
+func (c *controller) updateServiceInstanceAtBroker(instance *v1beta1.ServiceInstance, instancesRetrievable bool, brokerClient osb.Client, request *osb.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	var response *brokerhttp.UpdateInstanceResponse
+	err := c.doWithServiceInstanceStateToken(instance, instancesRetrievable, brokerClient, func(stateToken string) (err error) {
+		response, err = brokerhttp.UpdateInstance(brokerClient, &brokerhttp.UpdateInstanceRequest{
+			UpdateInstanceRequest: *request,
+			StateToken:            stateToken,
+		})
+		return err
+	})
+	return response, err
+}
+
+// deprovisionServiceInstanceAtBroker sends the deprovision request of the
+// instance with its state token, see doWithServiceInstanceStateToken.
+func (c *controller) deprovisionServiceInstanceAtBroker(instance *v1beta1.ServiceInstance, instancesRetrievable bool, brokerClient osb.Client, request *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	var response *osb.DeprovisionResponse
+	err := c.doWithServiceInstanceStateToken(instance, instancesRetrievable, brokerClient, func(stateToken string) (err error) {
+		response, err = brokerhttp.DeprovisionInstance(brokerClient, &brokerhttp.DeprovisionRequest{
+			DeprovisionRequest: *request,
+			StateToken:         stateToken,
+		})
+		return err
+	})
+	return response, err
+}
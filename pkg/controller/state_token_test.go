@@ -0,0 +1,245 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+)
+
+// TestReconcileServiceInstanceProvisionStateToken tests that the state token
+// returned by the broker on provision is recorded in the status of the
+// instance.
+func TestReconcileServiceInstanceProvisionStateToken(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+	brokerClient := &fakeBrokerHTTPClient{
+		FakeClient: fakeClusterServiceBrokerClient,
+		stateToken: "token-1",
+	}
+	testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+		return brokerClient, nil
+	}
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance, successProvisionReason)
+	if e, a := "token-1", updatedServiceInstance.(*v1beta1.ServiceInstance).Status.StateToken; e != a {
+		t.Fatalf("unexpected state token: %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceInstanceUpdateStateTokenConflict tests that an update
+// request refused by the broker because of a stale state token is sent again
+// with the state token of the instance fetched from the broker, when the
+// class of the instance is instancesRetrievable.
+func TestReconcileServiceInstanceUpdateStateTokenConflict(t *testing.T) {
+	cases := []struct {
+		name                 string
+		instancesRetrievable bool
+		// expectedStateTokens are the state tokens sent with the update
+		// requests.
+		expectedStateTokens []string
+		// expectedStateToken is the state token of the instance after the
+		// update.
+		expectedStateToken string
+		expectedReady      bool
+	}{
+		{
+			name:                 "instances retrievable",
+			instancesRetrievable: true,
+			expectedStateTokens:  []string{"token-1", "token-2"},
+			expectedStateToken:   "token-2",
+			expectedReady:        true,
+		},
+		{
+			name:                "instances not retrievable",
+			expectedStateTokens: []string{"token-1"},
+			expectedStateToken:  "token-1",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+					Response: &osb.UpdateInstanceResponse{},
+				},
+			})
+			brokerClient := &fakeBrokerHTTPClient{
+				FakeClient:          fakeClusterServiceBrokerClient,
+				getInstanceResponse: &brokerhttp.GetInstanceResponse{ServiceID: testClusterServiceClassGUID, PlanID: testClusterServicePlanGUID},
+				stateToken:          "token-2",
+			}
+			testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+				return brokerClient, nil
+			}
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.InstancesRetrievable = tc.instancesRetrievable
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.Generation = 2
+			instance.Status.ReconciledGeneration = 1
+			instance.Status.ObservedGeneration = 1
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: "old-plan-name",
+				ClusterServicePlanExternalID:   "old-plan-id",
+			}
+			instance.Status.StateToken = "token-1"
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			fakeCatalogClient.ClearActions()
+
+			// A refused update is retried with backoff.
+			err := reconcileServiceInstance(t, testController, instance)
+			if e, a := !tc.expectedReady, err != nil; e != a {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if e, a := tc.expectedStateTokens, brokerClient.sentStateTokens; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected state tokens sent: %s", expectedGot(e, a))
+			}
+			if e, a := tc.instancesRetrievable, len(brokerClient.getInstanceRequests) == 1; e != a {
+				t.Fatalf("unexpected GET of the instance: %s", expectedGot(e, a))
+			}
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			if e, a := tc.expectedStateToken, updatedServiceInstance.(*v1beta1.ServiceInstance).Status.StateToken; e != a {
+				t.Fatalf("unexpected state token: %s", expectedGot(e, a))
+			}
+			if tc.expectedReady {
+				assertServiceInstanceReadyTrue(t, updatedServiceInstance, successUpdateInstanceReason)
+			} else {
+				assertServiceInstanceReadyFalse(t, updatedServiceInstance)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceDeleteStateTokenConflict tests that a
+// deprovision request refused by the broker because of a stale state token
+// is sent again with the state token of the instance fetched from the broker.
+func TestReconcileServiceInstanceDeleteStateTokenConflict(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+	brokerClient := &fakeBrokerHTTPClient{
+		FakeClient:          fakeClusterServiceBrokerClient,
+		getInstanceResponse: &brokerhttp.GetInstanceResponse{ServiceID: testClusterServiceClassGUID, PlanID: testClusterServicePlanGUID},
+		stateToken:          "token-2",
+	}
+	testController.brokerClientManager.brokerClientCreateFunc = func(*BrokerClientConfiguration) (osb.Client, error) {
+		return brokerClient, nil
+	}
+
+	serviceClass := getTestClusterServiceClass()
+	serviceClass.Spec.InstancesRetrievable = true
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.StateToken = "token-1"
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+	fakeCatalogClient.AddReactor(updateObjectReactor("serviceinstances"))
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := []string{"token-1", "token-2"}, brokerClient.sentStateTokens; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected state tokens sent: %s", expectedGot(e, a))
+	}
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertDeprovision(t, brokerActions[0], &osb.DeprovisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+	assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance := assertUpdate(t, actions[1], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+)
+
+// The attributes of the spans of the reconciles and of the requests to the
+// brokers.
+const (
+	spanAttributeKind       = "servicecatalog.resource.kind"
+	spanAttributeNamespace  = "servicecatalog.resource.namespace"
+	spanAttributeName       = "servicecatalog.resource.name"
+	spanAttributeBroker     = "servicecatalog.broker"
+	spanAttributeOperation  = "servicecatalog.operation"
+	spanAttributeOutcome    = "servicecatalog.outcome"
+	spanAttributeStatusCode = "http.status_code"
+)
+
+// The outcomes of the reconciles and of the requests to the brokers.
+const (
+	spanOutcomeSuccess    = "success"
+	spanOutcomeError      = "error"
+	spanOutcomeBrokerBusy = "broker_busy"
+	spanOutcomeAsync      = "async"
+)
+
+// reconcileSpans holds the spans of the reconciles in progress, by resource
+// type and key, so that the requests sent to the brokers by a reconcile are
+// traced as part of it.
+type reconcileSpans struct {
+	mu    sync.Mutex
+	spans map[string]*tracing.Span
+}
+
+func newReconcileSpans() *reconcileSpans {
+	return &reconcileSpans{
+		spans: map[string]*tracing.Span{},
+	}
+}
+
+func (s *reconcileSpans) get(resourceType, key string) *tracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.spans[resourceType+"/"+key]
+}
+
+func (s *reconcileSpans) set(resourceType, key string, span *tracing.Span) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spans[resourceType+"/"+key] = span
+}
+
+func (s *reconcileSpans) delete(resourceType, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.spans, resourceType+"/"+key)
+}
+
+// traceReconciler returns a reconciler which records a span for each
+// reconcile of the resources of resourceType by reconciler. The reconciler
+// is returned unchanged when tracing is disabled.
+func (c *controller) traceReconciler(resourceType string, reconciler func(key string) error) func(key string) error {
+	if c.tracer == nil {
+		return reconciler
+	}
+	return func(key string) error {
+		namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+		span := c.tracer.Start("reconcile "+resourceType, nil, tracing.SpanKindInternal,
+			tracing.String(spanAttributeKind, resourceType),
+			tracing.String(spanAttributeNamespace, namespace),
+			tracing.String(spanAttributeName, name),
+			tracing.String(spanAttributeOperation, "reconcile"),
+		)
+		c.reconcileSpans.set(resourceType, key, span)
+		err := reconciler(key)
+		c.reconcileSpans.delete(resourceType, key)
+
+		switch {
+		case err == nil:
+			span.SetAttributes(tracing.String(spanAttributeOutcome, spanOutcomeSuccess))
+		case isBrokerRequestLimitError(err):
+			span.SetAttributes(tracing.String(spanAttributeOutcome, spanOutcomeBrokerBusy))
+		default:
+			span.SetAttributes(tracing.String(spanAttributeOutcome, spanOutcomeError))
+		}
+		span.End(err)
+		return err
+	}
+}
+
+// traceBrokerClient returns a client which records a span for each request
+// to the broker identified by brokerKey, as part of the span of the
+// reconcile of the resource of resourceType with the given metadata. The
+// client is returned unchanged when tracing is disabled.
+func (c *controller) traceBrokerClient(resourceType string, meta metav1.ObjectMeta, brokerKey BrokerKey, brokerClient osb.Client) osb.Client {
+	if c.tracer == nil {
+		return brokerClient
+	}
+	key := meta.Name
+	if meta.Namespace != "" {
+		key = meta.Namespace + "/" + key
+	}
+	parent := c.reconcileSpans.get(resourceType, key)
+	parent.SetAttributes(tracing.String(spanAttributeBroker, brokerKey.String()))
+	return &tracingBrokerClient{
+		Client:       brokerClient,
+		tracer:       c.tracer,
+		parent:       parent,
+		resourceType: resourceType,
+		meta:         meta,
+		brokerKey:    brokerKey,
+	}
+}
+
+// tracingBrokerClient is an osb.Client which records a span for each request
+// to the broker.
+type tracingBrokerClient struct {
+	osb.Client
+	tracer       *tracing.Tracer
+	parent       *tracing.Span
+	resourceType string
+	meta         metav1.ObjectMeta
+	brokerKey    BrokerKey
+}
+
+var _ brokerhttp.Client = &tracingBrokerClient{}
+
+func (c *tracingBrokerClient) start(operation string) *tracing.Span {
+	return c.tracer.Start("osb "+operation, c.parent, tracing.SpanKindClient,
+		tracing.String(spanAttributeKind, c.resourceType),
+		tracing.String(spanAttributeNamespace, c.meta.Namespace),
+		tracing.String(spanAttributeName, c.meta.Name),
+		tracing.String(spanAttributeBroker, c.brokerKey.String()),
+		tracing.String(spanAttributeOperation, operation),
+	)
+}
+
+// endBrokerSpan ends the span of a request to a broker, whose outcome is
+// outcome unless the request failed.
+func endBrokerSpan(span *tracing.Span, outcome string, err error) {
+	if err != nil {
+		if httpErr, ok := osb.IsHTTPError(err); ok {
+			span.SetAttributes(tracing.Int(spanAttributeStatusCode, httpErr.StatusCode))
+		}
+		outcome = spanOutcomeError
+		if isBrokerRequestLimitError(err) {
+			outcome = spanOutcomeBrokerBusy
+		}
+	}
+	span.SetAttributes(tracing.String(spanAttributeOutcome, outcome))
+	span.End(err)
+}
+
+func (c *tracingBrokerClient) GetCatalog() (*osb.CatalogResponse, error) {
+	span := c.start("GetCatalog")
+	response, err := c.Client.GetCatalog()
+	endBrokerSpan(span, spanOutcomeSuccess, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	span := c.start("GetCatalog")
+	response, err := brokerhttp.GetCatalog(c.Client)
+	endBrokerSpan(span, spanOutcomeSuccess, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	span := c.start("ProvisionInstance")
+	response, err := c.Client.ProvisionInstance(r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	span := c.start("ProvisionInstance")
+	response, err := brokerhttp.ProvisionInstance(c.Client, r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	span := c.start("GetInstance")
+	response, err := brokerhttp.GetInstance(c.Client, r)
+	endBrokerSpan(span, spanOutcomeSuccess, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	span := c.start("UpdateInstance")
+	response, err := c.Client.UpdateInstance(r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	span := c.start("UpdateInstance")
+	response, err := brokerhttp.UpdateInstance(c.Client, r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	span := c.start("DeprovisionInstance")
+	response, err := c.Client.DeprovisionInstance(r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	span := c.start("DeprovisionInstance")
+	response, err := brokerhttp.DeprovisionInstance(c.Client, r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	span := c.start("PollLastOperation")
+	response, err := c.Client.PollLastOperation(r)
+	outcome := spanOutcomeSuccess
+	if err == nil {
+		outcome = string(response.State)
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	span := c.start("PollBindingLastOperation")
+	response, err := c.Client.PollBindingLastOperation(r)
+	outcome := spanOutcomeSuccess
+	if err == nil {
+		outcome = string(response.State)
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	span := c.start("Bind")
+	response, err := c.Client.Bind(r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	span := c.start("Bind")
+	response, err := brokerhttp.Bind(c.Client, r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	span := c.start("Unbind")
+	response, err := c.Client.Unbind(r)
+	outcome := spanOutcomeSuccess
+	if err == nil && response.Async {
+		outcome = spanOutcomeAsync
+	}
+	endBrokerSpan(span, outcome, err)
+	return response, err
+}
+
+func (c *tracingBrokerClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	span := c.start("GetBinding")
+	response, err := c.Client.GetBinding(r)
+	endBrokerSpan(span, spanOutcomeSuccess, err)
+	return response, err
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+)
+
+// exportedSpan is the part of a span exported as OTLP JSON checked by the
+// tests.
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+}
+
+func (s *exportedSpan) attribute(key string) string {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.StringValue
+		}
+	}
+	return ""
+}
+
+// TestTraceReconcilerDisabled tests that the reconcilers and the broker
+// clients are not wrapped when tracing is disabled.
+func TestTraceReconcilerDisabled(t *testing.T) {
+	_, _, fakeBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+	called := false
+	reconciler := testController.traceReconciler("ServiceInstance", func(key string) error {
+		called = true
+		return nil
+	})
+	if err := reconciler(testNamespace + "/" + testServiceInstanceName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatal("expected the reconciler to be called")
+	}
+	if len(testController.reconcileSpans.spans) != 0 {
+		t.Fatalf("unexpected reconcile spans: %v", testController.reconcileSpans.spans)
+	}
+
+	instance := getTestServiceInstance()
+	brokerClient := testController.traceBrokerClient("ServiceInstance", instance.ObjectMeta, NewClusterServiceBrokerKey(testClusterServiceBrokerName), fakeBrokerClient)
+	if brokerClient != osb.Client(fakeBrokerClient) {
+		t.Fatalf("expected the broker client to be returned unchanged, got %T", brokerClient)
+	}
+}
+
+// TestTraceReconciler tests that a span is exported for a reconcile and for
+// each request sent to a broker by the reconcile, as part of its trace.
+func TestTraceReconciler(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		spans []exportedSpan
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("unexpected error decoding the request: %v", err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	defer server.Close()
+
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	tracer, err := tracing.NewTracer("test", server.URL, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testController.tracer = tracer
+
+	fakeBrokerClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{Async: true},
+		},
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Error: errors.New("connection refused"),
+		},
+	})
+	instance := getTestServiceInstance()
+	reconciler := testController.traceReconciler("ServiceInstance", func(key string) error {
+		brokerClient := testController.traceBrokerClient("ServiceInstance", instance.ObjectMeta, NewClusterServiceBrokerKey(testClusterServiceBrokerName), fakeBrokerClient)
+		brokerClient.ProvisionInstance(&osb.ProvisionRequest{
+			AcceptsIncomplete: true,
+			ServiceID:         testClusterServiceClassGUID,
+			PlanID:            testClusterServicePlanGUID,
+			OrganizationGUID:  testNamespaceGUID,
+			SpaceGUID:         testNamespaceGUID,
+		})
+		_, err := brokerClient.PollLastOperation(&osb.LastOperationRequest{})
+		return err
+	})
+	if err := reconciler(instance.Namespace + "/" + instance.Name); err == nil {
+		t.Fatal("expected the error of the reconciler")
+	}
+	if len(testController.reconcileSpans.spans) != 0 {
+		t.Fatalf("unexpected reconcile spans: %v", testController.reconcileSpans.spans)
+	}
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	tracer.Run(stopCh)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	provision, poll, reconcile := spans[0], spans[1], spans[2]
+	brokerKey := NewClusterServiceBrokerKey(testClusterServiceBrokerName)
+	if e, a := "reconcile ServiceInstance", reconcile.Name; e != a {
+		t.Fatalf("unexpected name: %s", expectedGot(e, a))
+	}
+	for _, s := range []exportedSpan{provision, poll} {
+		if s.TraceID != reconcile.TraceID || s.ParentSpanID != reconcile.SpanID {
+			t.Fatalf("expected span %q to be a child of the reconcile span", s.Name)
+		}
+		if e, a := brokerKey.String(), s.attribute(spanAttributeBroker); e != a {
+			t.Fatalf("unexpected broker of span %q: %s", s.Name, expectedGot(e, a))
+		}
+	}
+
+	cases := []struct {
+		span      exportedSpan
+		name      string
+		operation string
+		outcome   string
+	}{
+		{span: provision, name: "osb ProvisionInstance", operation: "ProvisionInstance", outcome: spanOutcomeAsync},
+		{span: poll, name: "osb PollLastOperation", operation: "PollLastOperation", outcome: spanOutcomeError},
+		{span: reconcile, name: "reconcile ServiceInstance", operation: "reconcile", outcome: spanOutcomeError},
+	}
+	for _, tc := range cases {
+		if e, a := tc.name, tc.span.Name; e != a {
+			t.Fatalf("unexpected name: %s", expectedGot(e, a))
+		}
+		for key, expected := range map[string]string{
+			spanAttributeKind:      "ServiceInstance",
+			spanAttributeNamespace: testNamespace,
+			spanAttributeName:      testServiceInstanceName,
+			spanAttributeOperation: tc.operation,
+			spanAttributeOutcome:   tc.outcome,
+		} {
+			if a := tc.span.attribute(key); expected != a {
+				t.Fatalf("unexpected attribute %q of span %q: %s", key, tc.name, expectedGot(expected, a))
+			}
+		}
+	}
+}
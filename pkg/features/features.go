@@ -83,6 +83,12 @@ const (
 	// owner: @piotrmiskiewicz
 	// alpha: v0.3.0
 	CascadingDeletion utilfeature.Feature = "CascadingDeletion"
+
+	// ParameterTemplates enables the resolution of the templates, such as
+	// {{ .Namespace }}, in the parameters of ServiceInstances
+	// owner: @tedyu
+	// alpha: v0.3.0
+	ParameterTemplates utilfeature.Feature = "ParameterTemplates"
 )
 
 func init() {
@@ -102,4 +108,5 @@ var defaultServiceCatalogFeatureGates = map[utilfeature.Feature]utilfeature.Feat
 	OriginatingIdentityLocking: {Default: true, PreRelease: utilfeature.Alpha},
 	ServicePlanDefaults:        {Default: false, PreRelease: utilfeature.Alpha},
 	CascadingDeletion:          {Default: false, PreRelease: utilfeature.Alpha},
+	ParameterTemplates:         {Default: false, PreRelease: utilfeature.Alpha},
 }
@@ -61,6 +61,17 @@ var (
 		[]string{"broker"},
 	)
 
+	// BrokerSecondsSinceLastRelist exposes the number of seconds since the
+	// catalog of each broker was last retrieved successfully.
+	BrokerSecondsSinceLastRelist = NewSinceGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_seconds_since_last_relist",
+			Help:      "Number of seconds since the catalog of the Broker was last retrieved successfully.",
+		},
+		[]string{"broker"},
+	)
+
 	// OSBRequestCount exposes the number of HTTP requests made to Open Service
 	// Brokers.  The metric is broken out by broker name and response status
 	// group (1xx/2xx/3xx/4xx/5xx or 'client-error')
@@ -72,13 +83,38 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// OSBRequestsInFlight exposes the number of requests to each Open Service
+	// Broker which have not completed yet.
+	OSBRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_requests_in_flight",
+			Help:      "Number of HTTP requests from the OSB Client to the specified Service Broker which have not completed yet.",
+		},
+		[]string{"broker"},
+	)
+
+	// ClientRateLimiterWaitCount exposes the number of requests to the API
+	// servers which were delayed by the client-side rate limit, per client.
+	ClientRateLimiterWaitCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "client_rate_limiter_wait_count",
+			Help:      "Cumulative number of requests to the API servers which had to wait for the client-side rate limit, grouped by client.",
+		},
+		[]string{"client"},
+	)
 )
 
 func register(registry *prometheus.Registry) {
 	registerMetrics.Do(func() {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
+		registry.MustRegister(BrokerSecondsSinceLastRelist)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(OSBRequestsInFlight)
+		registry.MustRegister(ClientRateLimiterWaitCount)
 	})
 }
 
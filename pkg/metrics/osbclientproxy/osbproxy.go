@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerhttp"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"k8s.io/klog"
 )
@@ -33,19 +34,14 @@ type proxyclient struct {
 	realOSBClient osb.Client
 }
 
-// NewClient is a CreateFunc for creating a new functional Client and
-// implements the CreateFunc interface.
-func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
-	osbClient, err := osb.NewClient(config)
-	if err != nil {
-		return nil, err
-	}
-	proxy := proxyclient{realOSBClient: osbClient}
-	proxy.brokerName = config.Name
-	return proxy, nil
+// NewProxy returns a client that proxies the client of the broker with the
+// given name, so that the requests of the broker are instrumented whatever
+// its protocol.
+func NewProxy(brokerName string, client osb.Client) osb.Client {
+	return proxyclient{brokerName: brokerName, realOSBClient: client}
 }
 
-var _ osb.CreateFunc = NewClient
+var _ brokerhttp.Client = proxyclient{}
 
 const (
 	getCatalog               = "GetCatalog"
@@ -57,6 +53,7 @@ const (
 	bind                     = "Bind"
 	unbind                   = "Unbind"
 	getBinding               = "GetBinding"
+	getInstance              = "GetInstance"
 )
 
 // GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog by
@@ -69,6 +66,15 @@ func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 	return response, err
 }
 
+// GetServiceCatalog implements brokerhttp.Client.GetServiceCatalog like
+// GetCatalog.
+func (pc proxyclient) GetServiceCatalog() (*brokerhttp.CatalogResponse, error) {
+	klog.V(9).Info("OSBClientProxy GetServiceCatalog()")
+	response, err := brokerhttp.GetCatalog(pc.realOSBClient)
+	pc.updateMetrics(getCatalog, err)
+	return response, err
+}
+
 // ProvisionInstance implements
 // go-open-service-broker-client/v2/Client.ProvisionInstance by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -80,6 +86,24 @@ func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.Provision
 
 }
 
+// ProvisionServiceInstance implements brokerhttp.Client.ProvisionServiceInstance
+// like ProvisionInstance.
+func (pc proxyclient) ProvisionServiceInstance(r *osb.ProvisionRequest) (*brokerhttp.ProvisionResponse, error) {
+	klog.V(9).Info("OSBClientProxy ProvisionServiceInstance()")
+	response, err := brokerhttp.ProvisionInstance(pc.realOSBClient, r)
+	pc.updateMetrics(provisionInstance, err)
+	return response, err
+}
+
+// GetInstance implements brokerhttp.Client.GetInstance by proxying the method
+// to the underlying implementation and capturing request metrics.
+func (pc proxyclient) GetInstance(r *brokerhttp.GetInstanceRequest) (*brokerhttp.GetInstanceResponse, error) {
+	klog.V(9).Info("OSBClientProxy GetInstance()")
+	response, err := brokerhttp.GetInstance(pc.realOSBClient, r)
+	pc.updateMetrics(getInstance, err)
+	return response, err
+}
+
 // UpdateInstance implements
 // go-open-service-broker-client/v2/Client.UpdateInstance by proxying the method
 // to the underlying implementation and capturing request metrics.
@@ -90,6 +114,15 @@ func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateI
 	return response, err
 }
 
+// UpdateServiceInstance implements brokerhttp.Client.UpdateServiceInstance
+// like UpdateInstance.
+func (pc proxyclient) UpdateServiceInstance(r *brokerhttp.UpdateInstanceRequest) (*brokerhttp.UpdateInstanceResponse, error) {
+	klog.V(9).Info("OSBClientProxy UpdateServiceInstance()")
+	response, err := brokerhttp.UpdateInstance(pc.realOSBClient, r)
+	pc.updateMetrics(updateInstance, err)
+	return response, err
+}
+
 // DeprovisionInstance implements
 // go-open-service-broker-client/v2/Client.DeprovisionInstance by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -100,6 +133,15 @@ func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.Depro
 	return response, err
 }
 
+// DeprovisionServiceInstance implements
+// brokerhttp.Client.DeprovisionServiceInstance like DeprovisionInstance.
+func (pc proxyclient) DeprovisionServiceInstance(r *brokerhttp.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	klog.V(9).Info("OSBClientProxy DeprovisionServiceInstance()")
+	response, err := brokerhttp.DeprovisionInstance(pc.realOSBClient, r)
+	pc.updateMetrics(deprovisionInstance, err)
+	return response, err
+}
+
 // PollLastOperation implements
 // go-open-service-broker-client/v2/Client.PollLastOperation by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -129,6 +171,15 @@ func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 	return response, err
 }
 
+// BindServiceInstance implements brokerhttp.Client.BindServiceInstance like
+// Bind.
+func (pc proxyclient) BindServiceInstance(r *brokerhttp.BindRequest) (*osb.BindResponse, error) {
+	klog.V(9).Info("OSBClientProxy BindServiceInstance().")
+	response, err := brokerhttp.Bind(pc.realOSBClient, r)
+	pc.updateMetrics(bind, err)
+	return response, err
+}
+
 // Unbind implements go-open-service-broker-client/v2/Client.Unbind by proxying
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// countingRateLimiter is a client-side rate limiter which counts the
+// requests that had to wait for a token.
+type countingRateLimiter struct {
+	flowcontrol.RateLimiter
+	waits prometheus.Counter
+}
+
+// NewClientRateLimiter returns a token bucket rate limiter for the requests of
+// a client to an API server, which counts the requests it delays in
+// ClientRateLimiterWaitCount. When qps or burst is zero, the defaults of
+// client-go are used.
+func NewClientRateLimiter(client string, qps float32, burst int) flowcontrol.RateLimiter {
+	if qps == 0 {
+		qps = rest.DefaultQPS
+	}
+	if burst == 0 {
+		burst = rest.DefaultBurst
+	}
+	return &countingRateLimiter{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		waits:       ClientRateLimiterWaitCount.WithLabelValues(client),
+	}
+}
+
+// Accept returns once a token becomes available, counting the wait when no
+// token is available immediately.
+func (l *countingRateLimiter) Accept() {
+	if l.RateLimiter.TryAccept() {
+		return
+	}
+	l.waits.Inc()
+	l.RateLimiter.Accept()
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+func TestCountingRateLimiter(t *testing.T) {
+	waits := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_waits"})
+	limiter := &countingRateLimiter{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(10, 2),
+		waits:       waits,
+	}
+
+	// The first two requests use the burst, the third one waits for a token.
+	for i := 0; i < 3; i++ {
+		limiter.Accept()
+	}
+
+	out := &dto.Metric{}
+	if err := waits.Write(out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := 1.0, out.GetCounter().GetValue(); e != a {
+		t.Fatalf("unexpected number of waits; expected %v, got %v", e, a)
+	}
+}
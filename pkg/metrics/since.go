@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SinceGaugeVec is a gauge that reports, at collection time, the number of
+// seconds elapsed since the time recorded for each set of label values.
+type SinceGaugeVec struct {
+	desc *prometheus.Desc
+	now  func() time.Time
+
+	mutex  sync.RWMutex
+	values map[string]sinceValue
+}
+
+type sinceValue struct {
+	labelValues []string
+	time        time.Time
+}
+
+var _ prometheus.Collector = &SinceGaugeVec{}
+
+// NewSinceGaugeVec creates a new SinceGaugeVec based on the provided
+// GaugeOpts and partitioned by the given label names.
+func NewSinceGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *SinceGaugeVec {
+	return &SinceGaugeVec{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(opts.Namespace, opts.Subsystem, opts.Name),
+			opts.Help,
+			labelNames,
+			opts.ConstLabels,
+		),
+		now:    time.Now,
+		values: map[string]sinceValue{},
+	}
+}
+
+// Set records the time to measure from for the given label values.
+func (v *SinceGaugeVec) Set(t time.Time, labelValues ...string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.values[labelKey(labelValues)] = sinceValue{labelValues: labelValues, time: t}
+}
+
+// DeleteLabelValues removes the metric for the given label values.
+func (v *SinceGaugeVec) DeleteLabelValues(labelValues ...string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.values, labelKey(labelValues))
+}
+
+// Describe implements prometheus.Collector.
+func (v *SinceGaugeVec) Describe(ch chan<- *prometheus.Desc) {
+	ch <- v.desc
+}
+
+// Collect implements prometheus.Collector.
+func (v *SinceGaugeVec) Collect(ch chan<- prometheus.Metric) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	now := v.now()
+	for _, value := range v.values {
+		ch <- prometheus.MustNewConstMetric(v.desc, prometheus.GaugeValue, now.Sub(value.time).Seconds(), value.labelValues...)
+	}
+}
+
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
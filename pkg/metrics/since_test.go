@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestSinceGaugeVec(t *testing.T) {
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	vec := NewSinceGaugeVec(prometheus.GaugeOpts{Name: "test_seconds_since"}, []string{"broker"})
+	vec.now = func() time.Time { return now }
+
+	vec.Set(now.Add(-90*time.Second), "a")
+	vec.Set(now.Add(-time.Hour), "b")
+	vec.Set(now.Add(-30*time.Second), "a")
+	vec.DeleteLabelValues("b")
+
+	ch := make(chan prometheus.Metric, 10)
+	vec.Collect(ch)
+	close(ch)
+
+	var metrics []*dto.Metric
+	for m := range ch {
+		out := &dto.Metric{}
+		if err := m.Write(out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		metrics = append(metrics, out)
+	}
+
+	if e, a := 1, len(metrics); e != a {
+		t.Fatalf("unexpected number of metrics; expected %v, got %v", e, a)
+	}
+	if e, a := "a", metrics[0].GetLabel()[0].GetValue(); e != a {
+		t.Fatalf("unexpected label value; expected %v, got %v", e, a)
+	}
+	if e, a := 30.0, metrics[0].GetGauge().GetValue(); e != a {
+		t.Fatalf("unexpected value; expected %v, got %v", e, a)
+	}
+}
@@ -645,17 +645,26 @@ func (m *Service) BackupResources() (*ServiceCatalogResources, error) {
 	}, nil
 }
 
-// AddOwnerReferenceToSecret updates a secret (referenced in the given ServiceBinding) by adding proper owner reference
+// AddOwnerReferenceToSecret updates a secret (referenced in the given ServiceBinding) by adding proper owner reference.
+// A secret in another namespace than the binding is annotated with the UID of the binding instead.
 func (m *Service) AddOwnerReferenceToSecret(sb *sc.ServiceBinding) error {
+	secretNamespace := sb.GetSecretNamespace()
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		secret, err := m.coreInterface.Secrets(sb.Namespace).Get(sb.Spec.SecretName, metav1.GetOptions{})
+		secret, err := m.coreInterface.Secrets(secretNamespace).Get(sb.Spec.SecretName, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
-		secret.OwnerReferences = []metav1.OwnerReference{
-			*metav1.NewControllerRef(sb, bindingControllerKind),
+		if secretNamespace != sb.Namespace {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[sc.ServiceBindingSecretOwnerAnnotation] = string(sb.UID)
+		} else {
+			secret.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(sb, bindingControllerKind),
+			}
 		}
-		_, err = m.coreInterface.Secrets(sb.Namespace).Update(secret)
+		_, err = m.coreInterface.Secrets(secretNamespace).Update(secret)
 		return err
 	})
 	if err != nil {
@@ -673,13 +682,13 @@ func (m *Service) RemoveOwnerReferenceFromSecrets() error {
 	}
 	for _, sb := range serviceBindings.Items {
 		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			secret, err := m.coreInterface.Secrets(sb.Namespace).Get(sb.Spec.SecretName, metav1.GetOptions{})
+			secret, err := m.coreInterface.Secrets(sb.GetSecretNamespace()).Get(sb.Spec.SecretName, metav1.GetOptions{})
 			if err != nil {
 				return err
 			}
 
 			secret.OwnerReferences = []metav1.OwnerReference{}
-			_, err = m.coreInterface.Secrets(sb.Namespace).Update(secret)
+			_, err = m.coreInterface.Secrets(sb.GetSecretNamespace()).Update(secret)
 			return err
 		})
 		if err != nil {
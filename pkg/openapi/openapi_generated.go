@@ -31,72 +31,84 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform":                schema_pkg_apis_servicecatalog_v1beta1_AddKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform":           schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig":                schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig":          schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions":            schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig":         schema_pkg_apis_servicecatalog_v1beta1_ClusterBasicAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig":   schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference":         schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBroker":           schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo":   schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerList":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerSpec":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerStatus":     schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClass":            schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClass(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassList":        schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassSpec":        schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassStatus":      schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlan":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlan(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanList":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanSpec":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanStatus":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec":        schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus":      schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec":         schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":       schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":          schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":        schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":           schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":           schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference":                  schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RemoveKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference":             schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform":                schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding":                 schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingList":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState":  schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus":           schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBroker":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceBroker(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo":          schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition":         schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerList":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerSpec":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerStatus":            schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClass":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceClass(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassList":               schema_pkg_apis_servicecatalog_v1beta1_ServiceClassList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassSpec":               schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus":             schema_pkg_apis_servicecatalog_v1beta1_ServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance":                schema_pkg_apis_servicecatalog_v1beta1_ServiceInstance(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition":       schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState": schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus":          schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlan":                    schema_pkg_apis_servicecatalog_v1beta1_ServicePlan(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                       schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                           schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                       schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetSpec":                       schema_pkg_apis_settings_v1alpha1_PodPresetSpec(ref),
-		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                                       schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform":                      schema_pkg_apis_servicecatalog_v1beta1_AddKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform":                 schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig":                      schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig":                schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck":                    schema_pkg_apis_servicecatalog_v1beta1_BrokerHealthCheck(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy":                    schema_pkg_apis_servicecatalog_v1beta1_BrokerRetryPolicy(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions":                  schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig":               schema_pkg_apis_servicecatalog_v1beta1_ClusterBasicAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig":         schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference":               schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig": schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceAccountTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBroker":                 schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerList":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerSpec":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerStatus":           schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClass":                  schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClass(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassList":              schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassSpec":              schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassStatus":            schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlan":                   schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlan(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanList":               schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanSpec":               schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanStatus":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec":              schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus":            schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec":               schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":             schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapKeyReference":                schema_pkg_apis_servicecatalog_v1beta1_ConfigMapKeyReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":                 schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                      schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":                 schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference":                        schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform":                   schema_pkg_apis_servicecatalog_v1beta1_RemoveKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform":                   schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RetryBackoff":                         schema_pkg_apis_servicecatalog_v1beta1_RetryBackoff(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference":                   schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretParameterReference":             schema_pkg_apis_servicecatalog_v1beta1_SecretParameterReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform":                      schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig":        schema_pkg_apis_servicecatalog_v1beta1_ServiceAccountTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding":                       schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingList":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus":                 schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBroker":                        schema_pkg_apis_servicecatalog_v1beta1_ServiceBroker(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo":                schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition":               schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerList":                    schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerSpec":                    schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerStatus":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClass":                         schema_pkg_apis_servicecatalog_v1beta1_ServiceClass(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassList":                     schema_pkg_apis_servicecatalog_v1beta1_ServiceClassList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassSpec":                     schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance":                      schema_pkg_apis_servicecatalog_v1beta1_ServiceInstance(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition":             schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState":       schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceProvisionMetadata":     schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceProvisionMetadata(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus":                schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplate":              schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplate(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplateList":          schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplateList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplateSpec":          schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplateSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlan":                          schema_pkg_apis_servicecatalog_v1beta1_ServicePlan(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost":                      schema_pkg_apis_servicecatalog_v1beta1_ServicePlanCost(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                      schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                      schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":                    schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                             schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                                 schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                             schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetSpec":                             schema_pkg_apis_settings_v1alpha1_PodPresetSpec(ref),
+		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                                             schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
 		"k8s.io/api/core/v1.Affinity":                                    schema_k8sio_api_core_v1_Affinity(ref),
 		"k8s.io/api/core/v1.AttachedVolume":                              schema_k8sio_api_core_v1_AttachedVolume(ref),
 		"k8s.io/api/core/v1.AvoidPods":                                   schema_k8sio_api_core_v1_AvoidPods(ref),
@@ -456,6 +468,53 @@ func schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_BrokerHealthCheck(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BrokerHealthCheck configures the probe that tells whether a broker is reachable.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path, relative to the URL of the broker, of an endpoint that the controller sends a GET request to. When it is empty, the controller sends a HEAD request for the catalog, whose response body is not read.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_BrokerRetryPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BrokerRetryPolicy is the backoff between the retries of the failed requests to a broker. Fetching the catalog and operating on instances and bindings back off independently, so that a broker that is flaky on one of them can be tuned without affecting the other.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"catalog": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Catalog is the backoff between the retries of a failed catalog fetch.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RetryBackoff"),
+						},
+					},
+					"operations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operations is the backoff between the retries of failed provision, update and bind requests.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RetryBackoff"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RetryBackoff"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -559,6 +618,35 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref common.Re
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceAccountTokenAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterServiceAccountTokenAuthConfig provides config for the ServiceAccount token authentication of cluster scoped brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceAccountRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountRef is a reference to the ServiceAccount whose token is sent to this ServiceBroker.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience is the intended audience of the token, which the broker should verify. It must not be empty, so that the token can not be used against the Kubernetes API server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"audience"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -630,11 +718,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref com
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"),
 						},
 					},
+					"serviceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceAccountTokenAuthConfig provides configuration to send a short-lived token of a ServiceAccount as a bearer token. The token is requested for the given audience through the TokenRequest API and renewed before it expires.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig"},
 	}
 }
 
@@ -701,7 +795,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 					},
 					"insecureSkipTLSVerify": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead.",
+							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead. It can not be combined with CABundle, and the Broker gets the InsecureTLS condition and a warning event while it is set.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -739,6 +833,39 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"userAgentSuffix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UserAgentSuffix is appended to the User-Agent header of the requests to the broker, for example to tell the broker which cluster is calling it. It overrides the suffix configured in the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPolicy overrides the backoff of the controller manager between the retries of the requests to the broker that failed.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy"),
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is the protocol with which the controller contacts the broker: HTTP, the protocol of the Open Service Broker API, or GRPC, which maps its operations to gRPC calls. Defaults to HTTP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheck enables probing the broker between relists, without fetching its catalog, to set its Reachable condition. The broker is only probed when the health check interval of the controller manager is not zero.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck"),
+						},
+					},
+					"catalogRefreshJitterPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CatalogRefreshJitterPercent is the percentage of the relist duration, from 0 to 99, by which the relists of the broker are delayed at most, so that they do not all hit the broker on the same schedule. Zero, the default, relists the broker exactly every relist duration.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ClusterServiceBroker.",
@@ -750,7 +877,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -773,6 +900,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref commo
 							},
 						},
 					},
+					"lastConditionUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastConditionUpdateTime is the last time any of the conditions changed their status, reason or message.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"reconciledGeneration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that was last processed by the controller. The reconciled generation is updated even if the controller failed to process the spec.",
@@ -780,6 +913,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref commo
 							Format:      "int64",
 						},
 					},
+					"currentOperation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentOperation is the operation the controller is currently performing on the broker. It is only set while a relist is tracked, see the --broker-relist-timeout flag of the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"operationStartTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "OperationStartTime is the time at which the current operation began.",
@@ -792,6 +932,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref commo
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastRelistRequestProcessed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRelistRequestProcessed is the value of spec.relistRequests that was last processed by the controller. It is updated together with the reconciled generation, so a client that increments relistRequests can wait for this field to match it to know that the relist has completed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastHealthCheckTime is the time the broker was last probed, see spec.healthCheck.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"lastConditionState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastConditionState aggregates state from the Conditions array It is used for printing in a kubectl output via additionalPrinterColumns",
@@ -949,6 +1102,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref common.R
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1210,6 +1370,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"costs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Costs are the costs of this plan that the Service Broker advertises in the costs of the plan metadata. Costs that can not be parsed are left out; they remain available in ExternalMetadata.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost"),
+									},
+								},
+							},
+						},
+					},
 					"clusterServiceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ClusterServiceBrokerName is the name of the ClusterServiceBroker that offers this ClusterServicePlan.",
@@ -1228,7 +1401,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1269,7 +1442,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 					},
 					"insecureSkipTLSVerify": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead.",
+							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead. It can not be combined with CABundle, and the Broker gets the InsecureTLS condition and a warning event while it is set.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -1307,12 +1480,45 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"userAgentSuffix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UserAgentSuffix is appended to the User-Agent header of the requests to the broker, for example to tell the broker which cluster is calling it. It overrides the suffix configured in the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPolicy overrides the backoff of the controller manager between the retries of the requests to the broker that failed.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy"),
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is the protocol with which the controller contacts the broker: HTTP, the protocol of the Open Service Broker API, or GRPC, which maps its operations to gRPC calls. Defaults to HTTP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheck enables probing the broker between relists, without fetching its catalog, to set its Reachable condition. The broker is only probed when the health check interval of the controller manager is not zero.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck"),
+						},
+					},
+					"catalogRefreshJitterPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CatalogRefreshJitterPercent is the percentage of the relist duration, from 0 to 99, by which the relists of the broker are delayed at most, so that they do not all hit the broker on the same schedule. Zero, the default, relists the broker exactly every relist duration.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -1335,6 +1541,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref common
 							},
 						},
 					},
+					"lastConditionUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastConditionUpdateTime is the last time any of the conditions changed their status, reason or message.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"reconciledGeneration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that was last processed by the controller. The reconciled generation is updated even if the controller failed to process the spec.",
@@ -1342,6 +1554,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref common
 							Format:      "int64",
 						},
 					},
+					"currentOperation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentOperation is the operation the controller is currently performing on the broker. It is only set while a relist is tracked, see the --broker-relist-timeout flag of the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"operationStartTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "OperationStartTime is the time at which the current operation began.",
@@ -1354,6 +1573,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref common
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastRelistRequestProcessed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRelistRequestProcessed is the value of spec.relistRequests that was last processed by the controller. It is updated together with the reconciled generation, so a client that increments relistRequests can wait for this field to match it to know that the relist has completed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastHealthCheckTime is the time the broker was last probed, see spec.healthCheck.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"lastConditionState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastConditionState aggregates state from the Conditions array It is used for printing in a kubectl output via additionalPrinterColumns",
@@ -1412,6 +1644,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref common.Re
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1567,12 +1806,25 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref common.Ref
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"costs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Costs are the costs of this plan that the Service Broker advertises in the costs of the plan metadata. Costs that can not be parsed are left out; they remain available in ExternalMetadata.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"externalName", "externalID", "description", "free"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1597,6 +1849,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref common.R
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ConfigMapKeyReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConfigMapKeyReference references a key of a ConfigMap.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the ConfigMap in the namespace of the resource to select from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The key of the ConfigMap to select from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1657,11 +1937,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref common.Refe
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"),
 						},
 					},
+					"configMapKeyRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The ConfigMap key to select from, for parameters which are not sensitive. The value must be a JSON object. It is only supported in the parametersFrom of ServiceBindings.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapKeyReference"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapKeyReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
 	}
 }
 
@@ -1669,7 +1955,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref common.ReferenceCa
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PlanReference defines the user specification for the desired (Cluster)ServicePlan and (Cluster)ServiceClass. Because there are multiple ways to specify the desired Class/Plan, this structure specifies the allowed ways to specify the intent. Note: a user may specify either cluster scoped OR namespace scoped identifiers, but NOT both, as they are mutually exclusive.\n\nCurrently supported ways:\n - ClusterServiceClassExternalName and ClusterServicePlanExternalName\n - ClusterServiceClassExternalID and ClusterServicePlanExternalID\n - ClusterServiceClassName and ClusterServicePlanName\n - ServiceClassExternalName and ServicePlanExternalName\n - ServiceClassExternalID and ServicePlanExternalID\n - ServiceClassName and ServicePlanName\n\nFor any of these ways, if a ClusterServiceClass only has one plan then the corresponding service plan field is optional.",
+				Description: "PlanReference defines the user specification for the desired (Cluster)ServicePlan and (Cluster)ServiceClass. Because there are multiple ways to specify the desired Class/Plan, this structure specifies the allowed ways to specify the intent. Note: a user may specify either cluster scoped OR namespace scoped identifiers, but NOT both, as they are mutually exclusive.\n\nCurrently supported ways:\n - ClusterServiceClassExternalName and ClusterServicePlanExternalName\n - ClusterServiceClassExternalID and ClusterServicePlanExternalID\n - ClusterServiceClassName and ClusterServicePlanName\n - ServiceClassExternalName and ServicePlanExternalName\n - ServiceClassExternalID and ServicePlanExternalID\n - ServiceClassName and ServicePlanName\n - ClusterServiceClassSelector and ClusterServicePlanExternalName\n - ServiceClassSelector and ServicePlanExternalName\n\nFor any of these ways, if a ClusterServiceClass only has one plan then the corresponding service plan field is optional.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"clusterServiceClassExternalName": {
@@ -1714,6 +2000,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"clusterServiceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassSelector selects the ClusterServiceClass by its labels. The selector must match exactly one ClusterServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ClusterServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 					"serviceClassExternalName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceClassExternalName is the human-readable name of the service as reported by the ServiceBroker. Note that if the ServiceBroker changes the name of the ServiceClass, it will not be reflected here, and to see the current name of the ServiceClass, you should follow the ServiceClassRef below.\n\nImmutable.",
@@ -1756,9 +2048,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"serviceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassSelector selects the ServiceClass in the namespace of the instance by its labels. The selector must match exactly one ServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -1811,6 +2111,33 @@ func schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_RetryBackoff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetryBackoff is an exponential backoff: the delay before the first retry is InitialDelay, and it doubles with each failure up to MaxDelay. A field that is not set takes the value configured in the controller manager.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"initialDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialDelay is the delay before the first retry.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDelay is the maximum delay between two retries.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1839,34 +2166,63 @@ func schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref common.Refere
 	}
 }
 
-func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_servicecatalog_v1beta1_SecretParameterReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SecretTransform is a single transformation that is applied to the credentials returned from the broker before they are inserted into the Secret associated with the ServiceBinding. Because different brokers providing the same type of service may each return a different credentials structure, users can specify the transformations that should be applied to the Secret to adapt its entries to whatever the service consumer expects. For example, the credentials returned by the broker may include the key \"USERNAME\", but the consumer requires the username to be exposed under the key \"DB_USER\" instead. To have the Service Catalog transform the Secret, the following SecretTransform must be specified in ServiceBinding.spec.secretTransform: - {\"renameKey\": {\"from\": \"USERNAME\", \"to\": \"DB_USER\"}} Only one of the SecretTransform's members may be specified.",
+				Description: "SecretParameterReference references a Secret key that populates parameters of a ServiceInstance.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"renameKey": {
-						SchemaProps: spec.SchemaProps{
-							Description: "RenameKey represents a transform that renames a credentials Secret entry's key",
-							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform"),
-						},
-					},
-					"addKey": {
+					"secretKeyRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AddKey represents a transform that adds an additional key to the credentials Secret",
-							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform"),
+							Description: "The Secret key to select from. The value must be a JSON object.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"),
 						},
 					},
-					"addKeysFrom": {
+					"propagateToBindings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AddKeysFrom represents a transform that merges all the entries of an existing Secret into the credentials Secret",
-							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform"),
+							Description: "PropagateToBindings adds the parameters to the bind requests of the ServiceBindings of the instance. They are read when a binding is bound, existing bindings are not bound again when they change.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"removeKey": {
-						SchemaProps: spec.SchemaProps{
-							Description: "RemoveKey represents a transform that removes a credentials Secret entry",
+				},
+				Required: []string{"secretKeyRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretTransform is a single transformation that is applied to the credentials returned from the broker before they are inserted into the Secret associated with the ServiceBinding. Because different brokers providing the same type of service may each return a different credentials structure, users can specify the transformations that should be applied to the Secret to adapt its entries to whatever the service consumer expects. For example, the credentials returned by the broker may include the key \"USERNAME\", but the consumer requires the username to be exposed under the key \"DB_USER\" instead. To have the Service Catalog transform the Secret, the following SecretTransform must be specified in ServiceBinding.spec.secretTransform: - {\"renameKey\": {\"from\": \"USERNAME\", \"to\": \"DB_USER\"}} Only one of the SecretTransform's members may be specified.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"renameKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenameKey represents a transform that renames a credentials Secret entry's key",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform"),
+						},
+					},
+					"addKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddKey represents a transform that adds an additional key to the credentials Secret",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform"),
+						},
+					},
+					"addKeysFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddKeysFrom represents a transform that merges all the entries of an existing Secret into the credentials Secret",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform"),
+						},
+					},
+					"removeKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoveKey represents a transform that removes a credentials Secret entry",
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform"),
 						},
 					},
@@ -1878,6 +2234,35 @@ func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceAccountTokenAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceAccountTokenAuthConfig provides config for the ServiceAccount token authentication of namespaced brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceAccountRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountRef is a reference to the ServiceAccount whose token is sent to this ServiceBroker. The ServiceAccount must be in the namespace of the broker.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience is the intended audience of the token, which the broker should verify. It must not be empty, so that the token can not be used against the Kubernetes API server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"audience"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2065,7 +2450,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ServiceBindingSpec represents the desired state of a ServiceBinding.\n\nThe spec field cannot be changed after a ServiceBinding is created.  Changes submitted to the spec field will be ignored.",
+				Description: "ServiceBindingSpec represents the desired state of a ServiceBinding.\n\nThe spec field cannot be changed after a ServiceBinding is created.  Changes submitted to the spec field will be ignored, except for RebindRequests.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"instanceRef": {
@@ -2095,7 +2480,14 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 					},
 					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretName is the name of the secret to create in the ServiceBinding's namespace that will hold the credentials associated with the ServiceBinding.",
+							Description: "SecretName is the name of the secret to create in the SecretNamespace that will hold the credentials associated with the ServiceBinding.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretNamespace is the namespace of the secret that holds the credentials, for example a central namespace that is synchronized with a secrets manager. It defaults to the ServiceBinding's namespace. The controller must be permitted to write Secrets in the namespace.\n\nImmutable.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -2113,13 +2505,35 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							},
 						},
 					},
+					"bindResource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindResource holds the data of the platform resource that the binding is for, for example the \"app_guid\" of an application or a \"route\". It is sent to the broker as the \"bind_resource\" of the bind request. The keys defined by the OSB API, \"app_guid\" and \"route\", must not be empty; other keys are sent as they are.\n\nImmutable.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 					"externalID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
+							Description: "ExternalID is the identity of this object for use with the OSB API. It defaults to a new UUID. To import a binding that was created at the broker out-of-band, set it to the ID of that binding on creation and set the ServiceBindingImportAnnotation.\n\nImmutable.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"rebindRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RebindRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to rebind the ServiceBinding, for example to rotate compromised credentials. The binding is then bound again at the broker under a new ID, the new credentials are written into the same Secret, and the previous binding is unbound.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"userInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nUserInfo contains information about the user that last modified this ServiceBinding. This field is set by the API server and not settable by the end-user. User-provided values for this field are not saved.",
@@ -2154,6 +2568,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 							},
 						},
 					},
+					"lastConditionUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastConditionUpdateTime is the last time any of the conditions changed their status, reason or message.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"asyncOpInProgress": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nAsyncOpInProgress is set to true if there is an ongoing async operation against this ServiceBinding in progress.",
@@ -2214,6 +2634,41 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 							Format:      "",
 						},
 					},
+					"rebindCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RebindCount is the value of RebindRequests that the controller last processed. A rebind is pending while RebindRequests is greater.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"externalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalID is the ID of the binding at the broker once a rebind replaced the one of the spec. It is empty until then.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rebindExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RebindExternalID is the ID under which a rebind in progress binds the ServiceBinding again. The binding keeps its previous ID, and the Secret its previous credentials, until the new credentials are written.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"staleExternalIDs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StaleExternalIDs are the IDs of the bindings at the broker that a rebind replaced, or that a failed rebind may have created, and which remain to be unbound.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 					"lastConditionState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastConditionState aggregates state from the Conditions array It is used for printing in a kubectl output via additionalPrinterColumns",
@@ -2301,11 +2756,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref common.Ref
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"),
 						},
 					},
+					"serviceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountTokenAuthConfig provides configuration to send a short-lived token of a ServiceAccount as a bearer token. The token is requested for the given audience through the TokenRequest API and renewed before it expires.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig"},
 	}
 }
 
@@ -2422,7 +2883,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 					},
 					"insecureSkipTLSVerify": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead.",
+							Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead. It can not be combined with CABundle, and the Broker gets the InsecureTLS condition and a warning event while it is set.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -2460,6 +2921,39 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"userAgentSuffix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UserAgentSuffix is appended to the User-Agent header of the requests to the broker, for example to tell the broker which cluster is calling it. It overrides the suffix configured in the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPolicy overrides the backoff of the controller manager between the retries of the requests to the broker that failed.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy"),
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is the protocol with which the controller contacts the broker: HTTP, the protocol of the Open Service Broker API, or GRPC, which maps its operations to gRPC calls. Defaults to HTTP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheck enables probing the broker between relists, without fetching its catalog, to set its Reachable condition. The broker is only probed when the health check interval of the controller manager is not zero.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck"),
+						},
+					},
+					"catalogRefreshJitterPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CatalogRefreshJitterPercent is the percentage of the relist duration, from 0 to 99, by which the relists of the broker are delayed at most, so that they do not all hit the broker on the same schedule. Zero, the default, relists the broker exactly every relist duration.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ServiceBroker.",
@@ -2471,7 +2965,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerHealthCheck", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BrokerRetryPolicy", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -2494,6 +2988,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref common.Refer
 							},
 						},
 					},
+					"lastConditionUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastConditionUpdateTime is the last time any of the conditions changed their status, reason or message.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"reconciledGeneration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that was last processed by the controller. The reconciled generation is updated even if the controller failed to process the spec.",
@@ -2501,6 +3001,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref common.Refer
 							Format:      "int64",
 						},
 					},
+					"currentOperation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentOperation is the operation the controller is currently performing on the broker. It is only set while a relist is tracked, see the --broker-relist-timeout flag of the controller manager.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"operationStartTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "OperationStartTime is the time at which the current operation began.",
@@ -2513,6 +3020,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref common.Refer
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastRelistRequestProcessed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRelistRequestProcessed is the value of spec.relistRequests that was last processed by the controller. It is updated together with the reconciled generation, so a client that increments relistRequests can wait for this field to match it to know that the relist has completed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastHealthCheckTime is the time the broker was last probed, see spec.healthCheck.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"lastConditionState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastConditionState aggregates state from the Conditions array It is used for printing in a kubectl output via additionalPrinterColumns",
@@ -2665,6 +3185,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"instancesRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -2946,6 +3473,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref c
 							Format:      "",
 						},
 					},
+					"contextChecksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContextChecksum is the checksum of the OSB context that was sent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"userInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "UserInfo is information about the user that made the request.",
@@ -2961,6 +3495,49 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref c
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceProvisionMetadata(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceProvisionMetadata is the metadata of a ServiceInstance returned by the broker in the response to its provision request. The values which are not strings are encoded as JSON.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels are the labels that the broker assigned to the instance.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"attributes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attributes are the attributes that the broker assigned to the instance.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3010,6 +3587,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 							Format:      "",
 						},
 					},
+					"clusterServiceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassSelector selects the ClusterServiceClass by its labels. The selector must match exactly one ClusterServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ClusterServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 					"serviceClassExternalName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceClassExternalName is the human-readable name of the service as reported by the ServiceBroker. Note that if the ServiceBroker changes the name of the ServiceClass, it will not be reflected here, and to see the current name of the ServiceClass, you should follow the ServiceClassRef below.\n\nImmutable.",
@@ -3052,6 +3635,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 							Format:      "",
 						},
 					},
+					"serviceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassSelector selects the ServiceClass in the namespace of the instance by its labels. The selector must match exactly one ServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 					"clusterServiceClassRef": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ClusterServiceClassRef is a reference to the ClusterServiceClass that the user selected. This is set by the controller based on the cluster-scoped values specified in the PlanReference.",
@@ -3095,6 +3684,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 							},
 						},
 					},
+					"secretParameterRefs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretParameterRefs are Secret keys that populate parameters like the secretKeyRef sources of ParametersFrom. Unlike ParametersFrom, the instance is updated when the parameters read from these Secrets change, and the parameters can be propagated to new bindings.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretParameterReference"),
+									},
+								},
+							},
+						},
+					},
 					"externalID": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ExternalID is the identity of this object for use with the OSB SB API.\n\nImmutable.",
@@ -3119,7 +3721,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretParameterReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3143,6 +3745,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							},
 						},
 					},
+					"lastConditionUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastConditionUpdateTime is the last time any of the conditions changed their status, reason or message.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"asyncOpInProgress": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AsyncOpInProgress is set to true if there is an ongoing async operation against this Service Instance in progress.",
@@ -3164,6 +3772,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"lastOperationDescription": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastOperationDescription is the description of the progress of the ongoing async operation that the broker returned on the last poll request, truncated if too long. It is cleared when the operation completes.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dashboardURL": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DashboardURL is the URL of a web-based management user interface for the service instance.",
@@ -3171,6 +3786,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"provisionMetadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProvisionMetadata is the metadata that the broker returned for the instance in the response to its provision request, such as the identifier or the region of the resource it created. It is limited in size, and the values of the keys that look sensitive are redacted.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceProvisionMetadata"),
+						},
+					},
+					"stateToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StateToken is the state of the instance at the broker, the ETag that the broker returned for it. It is sent back in the If-Match header of the update and deprovision requests, so that the broker refuses them when the instance changed in the meantime.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"currentOperation": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CurrentOperation is the operation the Controller is currently performing on the ServiceInstance.",
@@ -3251,12 +3879,244 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"appliedPlanName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppliedPlanName is the external name of the plan that the broker last accepted for the instance, taken from ExternalProperties. It differs from UserSpecifiedPlanName while a plan change is in progress. It is used for printing in a kubectl output via additionalPrinterColumns",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"appliedParameterChecksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppliedParameterChecksum is the checksum of the parameters that the broker last accepted for the instance, taken from ExternalProperties.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "asyncOpInProgress", "orphanMitigationInProgress", "reconciledGeneration", "observedGeneration", "provisionStatus", "deprovisionStatus", "lastConditionState", "userSpecifiedPlanName", "userSpecifiedClassName"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceProvisionMetadata", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceTemplate captures the class, plan and base parameters shared by ServiceInstances in its namespace. A ServiceInstance refers to a template with the ServiceInstanceTemplateAnnotation and only specifies the values that differ from the template.\n\nTemplates are expanded once, when the ServiceInstance is created. Later changes to a template are not propagated to the ServiceInstances created from it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of this resource in etcd is in ObjectMeta.Name. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec defines the values applied to the ServiceInstances created from the template.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplateSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplateList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceTemplateList is a list of ServiceInstanceTemplates.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplate"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceTemplate", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceInstanceTemplateSpec represents the values a ServiceInstanceTemplate applies to the ServiceInstances created from it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusterServiceClassExternalName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassExternalName is the human-readable name of the service as reported by the ClusterServiceBroker. Note that if the ClusterServiceBroker changes the name of the ClusterServiceClass, it will not be reflected here, and to see the current name of the ClusterServiceClass, you should follow the ClusterServiceClassRef below.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServicePlanExternalName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServicePlanExternalName is the human-readable name of the plan as reported by the ClusterServiceBroker. Note that if the ClusterServiceBroker changes the name of the ClusterServicePlan, it will not be reflected here, and to see the current name of the ClusterServicePlan, you should follow the ClusterServicePlanRef below.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServiceClassExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassExternalID is the ClusterServiceBroker's external id for the class.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServicePlanExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServicePlanExternalID is the ClusterServiceBroker's external id for the plan.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServiceClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassName is the kubernetes name of the ClusterServiceClass.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServicePlanName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServicePlanName is kubernetes name of the ClusterServicePlan.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServiceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassSelector selects the ClusterServiceClass by its labels. The selector must match exactly one ClusterServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ClusterServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"serviceClassExternalName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassExternalName is the human-readable name of the service as reported by the ServiceBroker. Note that if the ServiceBroker changes the name of the ServiceClass, it will not be reflected here, and to see the current name of the ServiceClass, you should follow the ServiceClassRef below.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servicePlanExternalName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePlanExternalName is the human-readable name of the plan as reported by the ServiceBroker. Note that if the ServiceBroker changes the name of the ServicePlan, it will not be reflected here, and to see the current name of the ServicePlan, you should follow the ServicePlanRef below.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceClassExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassExternalID is the ServiceBroker's external id for the class.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servicePlanExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePlanExternalID is the ServiceBroker's external id for the plan.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassName is the kubernetes name of the ServiceClass.\n\nImmutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servicePlanName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePlanName is kubernetes name of the ServicePlan.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceClassSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassSelector selects the ServiceClass in the namespace of the instance by its labels. The selector must match exactly one ServiceClass that has not been removed from the catalog of its broker. The plan is either omitted or given by ServicePlanExternalName, and is looked up among the plans of the selected class. The class is only resolved once: changing the labels of the classes afterwards does not change the class of the instance.\n\nImmutable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"parameters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Parameters are the base parameters of the instances. The parameters of an instance are merged on top of them, so an instance only needs to specify the parameters that differ from the template.\n\nThe Parameters field is NOT secret or secured in any way and should NEVER be used to hold sensitive information.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"parametersFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParametersFrom are the sources of parameters added before the parametersFrom of the instances.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3312,6 +4172,42 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlan(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanCost(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServicePlanCost is a cost of a ServicePlan, following the OSB convention for the costs in the plan metadata.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"amount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Amount maps a lowercase currency code, such as \"usd\", to the amount charged in that currency for each Unit, such as \"99.95\".",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"unit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Unit is what the amount is charged for, such as \"MONTHLY\" or \"1GB of messages\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"amount", "unit"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3437,6 +4333,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"costs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Costs are the costs of this plan that the Service Broker advertises in the costs of the plan metadata. Costs that can not be parsed are left out; they remain available in ExternalMetadata.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost"),
+									},
+								},
+							},
+						},
+					},
 					"serviceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceBrokerName is the name of the ServiceBroker that offers this ServicePlan.",
@@ -3455,7 +4364,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanCost", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paramtemplate resolves the templates in the parameters of
+// ServiceInstances. A template is a `{{ expression }}` inside a string value
+// of the parameters, where the expression is one of the variables:
+//
+//	.Name          the name of the instance
+//	.Namespace     the namespace of the instance
+//	.Labels.<key>  the value of the label <key> of the instance
+//
+// There are no functions, pipelines or other expressions, so that resolving
+// the parameters never runs anything but a lookup of the variables.
+package paramtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const labelsPrefix = ".Labels."
+
+// templateRegexp matches a template; the expression is the first group.
+var templateRegexp = regexp.MustCompile(`\{\{(.*?)\}\}`)
+
+// Values are the variables the templates are resolved with.
+type Values struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+}
+
+// Validate returns an error when a string value of the raw YAML or JSON
+// parameters holds an invalid template.
+func Validate(raw []byte) error {
+	_, err := walk(raw, func(expression string) (string, error) {
+		return "", validateExpression(expression)
+	})
+	return err
+}
+
+// Resolve returns the raw parameters with the templates of their string
+// values replaced by the values of their variables, as JSON. It returns an
+// error when a template is invalid or refers to a label which is not set.
+// Parameters without a template are returned unchanged.
+func Resolve(raw []byte, values Values) ([]byte, error) {
+	return walk(raw, func(expression string) (string, error) {
+		if err := validateExpression(expression); err != nil {
+			return "", err
+		}
+		switch expression {
+		case ".Name":
+			return values.Name, nil
+		case ".Namespace":
+			return values.Namespace, nil
+		}
+		key := strings.TrimPrefix(expression, labelsPrefix)
+		value, ok := values.Labels[key]
+		if !ok {
+			return "", fmt.Errorf("the template {{ %s }} refers to the label %q, which is not set", expression, key)
+		}
+		return value, nil
+	})
+}
+
+func validateExpression(expression string) error {
+	switch {
+	case expression == ".Name" || expression == ".Namespace":
+		return nil
+	case strings.HasPrefix(expression, labelsPrefix):
+		key := strings.TrimPrefix(expression, labelsPrefix)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("the template {{ %s }} refers to an invalid label key: %s", expression, strings.Join(errs, "; "))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported template {{ %s }}: the supported variables are .Name, .Namespace and .Labels.<key>", expression)
+	}
+}
+
+// walk calls resolve with the expression of each template of the string
+// values of the raw parameters, and returns the parameters with the
+// templates replaced by the results.
+func walk(raw []byte, resolve func(expression string) (string, error)) ([]byte, error) {
+	if len(raw) == 0 || !strings.Contains(string(raw), "{{") {
+		return raw, nil
+	}
+	var params interface{}
+	if err := yaml.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the parameters: %v", err)
+	}
+	params, err := walkValue(params, resolve)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(params)
+}
+
+func walkValue(value interface{}, resolve func(expression string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			resolved, err := walkValue(item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := walkValue(item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	case string:
+		return resolveString(v, resolve)
+	}
+	return value, nil
+}
+
+func resolveString(s string, resolve func(expression string) (string, error)) (string, error) {
+	var (
+		result strings.Builder
+		last   int
+	)
+	for _, match := range templateRegexp.FindAllStringSubmatchIndex(s, -1) {
+		value, err := resolve(strings.TrimSpace(s[match[2]:match[3]]))
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(s[last:match[0]])
+		result.WriteString(value)
+		last = match[1]
+	}
+	if strings.Contains(s[last:], "{{") {
+		return "", fmt.Errorf("unterminated template in %q", s)
+	}
+	result.WriteString(s[last:])
+	return result.String(), nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramtemplate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	values := Values{
+		Name:      "db",
+		Namespace: "team-a",
+		Labels:    map[string]string{"team": "payments", "app.kubernetes.io/name": "shop"},
+	}
+
+	cases := []struct {
+		name     string
+		raw      string
+		expected string
+		err      string
+	}{
+		{
+			name:     "no template",
+			raw:      `{"size": 2, "project": "static"}`,
+			expected: `{"size": 2, "project": "static"}`,
+		},
+		{
+			name:     "namespace and name",
+			raw:      `{"project": "{{ .Namespace }}", "id": "{{.Namespace}}-{{ .Name }}"}`,
+			expected: `{"id":"team-a-db","project":"team-a"}`,
+		},
+		{
+			name:     "labels in nested values",
+			raw:      `{"tags": [{"team": "{{ .Labels.team }}"}, "{{ .Labels.app.kubernetes.io/name }}"], "size": 2}`,
+			expected: `{"size":2,"tags":[{"team":"payments"},"shop"]}`,
+		},
+		{
+			name:     "YAML",
+			raw:      "project: '{{ .Namespace }}'",
+			expected: `{"project":"team-a"}`,
+		},
+		{
+			name: "missing label",
+			raw:  `{"owner": "{{ .Labels.owner }}"}`,
+			err:  `refers to the label "owner", which is not set`,
+		},
+		{
+			name: "function",
+			raw:  `{"project": "{{ printf \"%s\" .Namespace }}"}`,
+			err:  "unsupported template",
+		},
+		{
+			name: "unterminated",
+			raw:  `{"project": "{{ .Namespace"}`,
+			err:  "unterminated template",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := Resolve([]byte(tc.raw), values)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expected, string(resolved); e != a {
+				t.Fatalf("unexpected parameters: expected %s, got %s", e, a)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		err  string
+	}{
+		{
+			name: "valid templates",
+			raw:  `{"project": "{{ .Namespace }}", "owner": "{{ .Labels.owner }}", "id": "{{ .Name }}"}`,
+		},
+		{
+			name: "no parameters",
+		},
+		{
+			name: "unknown variable",
+			raw:  `{"uid": "{{ .UID }}"}`,
+			err:  "unsupported template {{ .UID }}",
+		},
+		{
+			name: "invalid label key",
+			raw:  `{"owner": "{{ .Labels.not a key }}"}`,
+			err:  "refers to an invalid label key",
+		},
+		{
+			name: "unterminated",
+			raw:  `{"owner": "{{ .Labels.owner }} {{"}`,
+			err:  "unterminated template",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate([]byte(tc.raw))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ import (
 
 const (
 	// CRDsAmount define the whole number of CRDs registered by the Service Catalog
-	CRDsAmount = 8
+	CRDsAmount = 9
 
 	// ClusterServiceBroker define the name of the ClusterServiceBroker CRD
 	ClusterServiceBroker = "clusterservicebrokers.servicecatalog.k8s.io"
@@ -46,6 +46,8 @@ const (
 	ClusterServicePlan = "clusterserviceplans.servicecatalog.k8s.io"
 	// ServiceInstance define the name of the ServiceInstance CRD
 	ServiceInstance = "serviceinstances.servicecatalog.k8s.io"
+	// ServiceInstanceTemplate define the name of the ServiceInstanceTemplate CRD
+	ServiceInstanceTemplate = "serviceinstancetemplates.servicecatalog.k8s.io"
 	// ServiceBinding define the name of the ServiceBinding CRD
 	ServiceBinding = "servicebindings.servicecatalog.k8s.io"
 
@@ -63,6 +65,7 @@ var customResourceDefinitionNames = []string{
 	ServicePlan,
 	ClusterServicePlan,
 	ServiceInstance,
+	ServiceInstanceTemplate,
 	ServiceBinding,
 }
 
@@ -170,6 +170,20 @@ func newTestCRD() []runtime.Object {
 				},
 			},
 		},
+		&extv1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ServiceInstanceTemplate,
+				Labels: map[string]string{"svcat": "true"},
+			},
+			Status: extv1beta1.CustomResourceDefinitionStatus{
+				Conditions: []extv1beta1.CustomResourceDefinitionCondition{
+					{
+						Type:   extv1beta1.Established,
+						Status: "True",
+					},
+				},
+			},
+		},
 		&extv1beta1.CustomResourceDefinition{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:   ServiceBinding,
@@ -231,6 +245,12 @@ func newTestCRDNotReady() []runtime.Object {
 				Labels: map[string]string{"svcat": "true"},
 			},
 		},
+		&extv1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ServiceInstanceTemplate,
+				Labels: map[string]string{"svcat": "true"},
+			},
+		},
 		&extv1beta1.CustomResourceDefinition{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:   ServiceBinding,
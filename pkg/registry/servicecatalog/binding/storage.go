@@ -92,7 +92,8 @@ func Match(label labels.Selector, field fields.Selector) storage.SelectionPredic
 func toSelectableFields(binding *servicecatalog.ServiceBinding) fields.Set {
 	// If you add a new selectable field, you also need to modify
 	// pkg/apis/servicecatalog/v1beta1/conversion[_test].go
-	specFieldSet := make(fields.Set, 1)
+	specFieldSet := make(fields.Set, 2)
+	specFieldSet["spec.instanceRef.name"] = binding.Spec.InstanceRef.Name
 	specFieldSet["spec.externalID"] = binding.Spec.ExternalID
 	return generic.AddObjectMetaFieldsSet(specFieldSet, &binding.ObjectMeta, true)
 }
@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func TestNewListNilItems(t *testing.T) {
@@ -30,3 +33,35 @@ func TestNewListNilItems(t *testing.T) {
 		t.Fatalf("nil incorrectly set on Items field")
 	}
 }
+
+func TestMatchFieldSelector(t *testing.T) {
+	binding := &servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-binding", Namespace: "test-ns"},
+		Spec: servicecatalog.ServiceBindingSpec{
+			InstanceRef: servicecatalog.LocalObjectReference{Name: "test-instance"},
+		},
+	}
+
+	cases := []struct {
+		selector string
+		matches  bool
+	}{
+		{"spec.instanceRef.name=test-instance", true},
+		{"spec.instanceRef.name=other-instance", false},
+	}
+
+	for _, tc := range cases {
+		field, err := fields.ParseSelector(tc.selector)
+		if err != nil {
+			t.Fatalf("%s: unexpected error parsing selector: %v", tc.selector, err)
+		}
+		predicate := Match(labels.Everything(), field)
+		matches, err := predicate.Matches(binding)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.selector, err)
+		}
+		if matches != tc.matches {
+			t.Errorf("%s: expected match to be %v", tc.selector, tc.matches)
+		}
+	}
+}
@@ -145,13 +145,20 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	// proper validation of allowed changes needs to be implemented in
 	// ValidateUpdate. Also, the check for whether the generation needs
 	// to be updated needs to be un-commented.
+	//
+	// The only exception is RebindRequests, which is bumped to rebind the
+	// binding. Ignore the RebindRequests field when it is the default value.
+	rebindRequests := newServiceBinding.Spec.RebindRequests
 	newServiceBinding.Spec = oldServiceBinding.Spec
+	if rebindRequests != 0 {
+		newServiceBinding.Spec.RebindRequests = rebindRequests
+	}
 
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
 	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
+	// Note that since RebindRequests is the only change to the spec that is
+	// handled, the generation is only incremented by rebind requests
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(ctx, newServiceBinding)
@@ -66,6 +66,29 @@ func TestInstanceCredentialUpdate(t *testing.T) {
 			older: getTestInstanceCredential(),
 			newer: getTestInstanceCredential(),
 		},
+		{
+			name:  "rebind requests increased",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.RebindRequests = 1
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name: "unhandled spec change with default rebind requests",
+			older: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.RebindRequests = 1
+				return ic
+			}(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.SecretName = "other-secret"
+				return ic
+			}(),
+		},
 		//		{
 		//			name:  "spec change",
 		//			older: getTestInstanceCredential(),
@@ -92,13 +92,19 @@ func Match(label labels.Selector, field fields.Selector) storage.SelectionPredic
 func toSelectableFields(instance *servicecatalog.ServiceInstance) fields.Set {
 	// If you add a new selectable field, you also need to modify
 	// pkg/apis/servicecatalog/v1beta1/conversion[_test].go
-	specFieldSet := make(fields.Set, 3)
+	specFieldSet := make(fields.Set, 5)
 	if instance.Spec.ClusterServiceClassRef != nil {
 		specFieldSet["spec.clusterServiceClassRef.name"] = instance.Spec.ClusterServiceClassRef.Name
 	}
 	if instance.Spec.ClusterServicePlanRef != nil {
 		specFieldSet["spec.clusterServicePlanRef.name"] = instance.Spec.ClusterServicePlanRef.Name
 	}
+	if instance.Spec.ServiceClassRef != nil {
+		specFieldSet["spec.serviceClassRef.name"] = instance.Spec.ServiceClassRef.Name
+	}
+	if instance.Spec.ServicePlanRef != nil {
+		specFieldSet["spec.servicePlanRef.name"] = instance.Spec.ServicePlanRef.Name
+	}
 	specFieldSet["spec.externalID"] = instance.Spec.ExternalID
 	return generic.AddObjectMetaFieldsSet(specFieldSet, &instance.ObjectMeta, true)
 }
@@ -150,6 +156,7 @@ func NewStorage(opts server.Options) (rest.Storage, rest.Storage, rest.Storage)
 				{Name: "Name", Type: "string", Format: "name"},
 				{Name: "Class", Type: "string"},
 				{Name: "Plan", Type: "string"},
+				{Name: "Applied Plan", Type: "string", Priority: 1},
 				{Name: "Status", Type: "string"},
 				{Name: "Age", Type: "string"},
 			},
@@ -165,6 +172,16 @@ func NewStorage(opts server.Options) (rest.Storage, rest.Storage, rest.Storage)
 					return ""
 				}
 
+				getAppliedPlan := func(status servicecatalog.ServiceInstanceStatus) string {
+					if props := status.ExternalProperties; props != nil {
+						if props.ClusterServicePlanExternalName != "" {
+							return props.ClusterServicePlanExternalName
+						}
+						return props.ServicePlanExternalName
+					}
+					return ""
+				}
+
 				instance := obj.(*servicecatalog.ServiceInstance)
 
 				var class, plan string
@@ -180,6 +197,7 @@ func NewStorage(opts server.Options) (rest.Storage, rest.Storage, rest.Storage)
 					name,
 					class,
 					plan,
+					getAppliedPlan(instance.Status),
 					getStatus(instance.Status),
 					age,
 				}
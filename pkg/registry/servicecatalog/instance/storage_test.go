@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func TestNewListNilField(t *testing.T) {
@@ -30,3 +33,48 @@ func TestNewListNilField(t *testing.T) {
 		t.Fatalf("nil incorrectly set on Items field")
 	}
 }
+
+func TestMatchFieldSelector(t *testing.T) {
+	clusterInstance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-instance", Namespace: "test-ns"},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			ClusterServiceClassRef: &servicecatalog.ClusterObjectReference{Name: "cluster-class"},
+			ClusterServicePlanRef:  &servicecatalog.ClusterObjectReference{Name: "cluster-plan"},
+		},
+	}
+	namespacedInstance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "namespaced-instance", Namespace: "test-ns"},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			ServiceClassRef: &servicecatalog.LocalObjectReference{Name: "namespaced-class"},
+			ServicePlanRef:  &servicecatalog.LocalObjectReference{Name: "namespaced-plan"},
+		},
+	}
+
+	cases := []struct {
+		selector string
+		instance *servicecatalog.ServiceInstance
+		matches  bool
+	}{
+		{"spec.clusterServiceClassRef.name=cluster-class", clusterInstance, true},
+		{"spec.clusterServicePlanRef.name=cluster-plan", clusterInstance, true},
+		{"spec.clusterServiceClassRef.name=cluster-class", namespacedInstance, false},
+		{"spec.serviceClassRef.name=namespaced-class", namespacedInstance, true},
+		{"spec.servicePlanRef.name=namespaced-plan", namespacedInstance, true},
+		{"spec.serviceClassRef.name=namespaced-class", clusterInstance, false},
+	}
+
+	for _, tc := range cases {
+		field, err := fields.ParseSelector(tc.selector)
+		if err != nil {
+			t.Fatalf("%s: unexpected error parsing selector: %v", tc.selector, err)
+		}
+		predicate := Match(labels.Everything(), field)
+		matches, err := predicate.Matches(tc.instance)
+		if err != nil {
+			t.Fatalf("%s: unexpected error matching %s: %v", tc.selector, tc.instance.Name, err)
+		}
+		if matches != tc.matches {
+			t.Errorf("%s: expected match of %s to be %v", tc.selector, tc.instance.Name, tc.matches)
+		}
+	}
+}
@@ -32,9 +32,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
-// RetrieveBindings lists all bindings in a namespace.
-func (sdk *SDK) RetrieveBindings(ns string) (*v1beta1.ServiceBindingList, error) {
-	bindings, err := sdk.ServiceCatalog().ServiceBindings(ns).List(v1.ListOptions{})
+// RetrieveBindings lists all bindings in a namespace that match the label
+// selector.
+func (sdk *SDK) RetrieveBindings(ns, labelSelector string) (*v1beta1.ServiceBindingList, error) {
+	bindings, err := sdk.ServiceCatalog().ServiceBindings(ns).List(v1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to list bindings in %s", ns)
 	}
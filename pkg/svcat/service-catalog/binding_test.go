@@ -75,12 +75,21 @@ var _ = Describe("Binding", func() {
 
 	Describe("RetrieveBindings", func() {
 		It("Calls the generated v1beta1 List method with the specified namespace", func() {
-			bindings, err := sdk.RetrieveBindings(sb.Namespace)
+			bindings, err := sdk.RetrieveBindings(sb.Namespace, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(bindings.Items).Should(ConsistOf(*sb, *sb2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "servicebindings")).To(BeTrue())
 		})
+		It("Passes the label selector to the List method", func() {
+			bindings, err := sdk.RetrieveBindings(sb.Namespace, "team=payments")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings.Items).Should(BeEmpty())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "servicebindings")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("team=payments"))
+		})
 		It("Bubbles up errors", func() {
 			badClient := &fake.Clientset{}
 			errorMessage := "error retrieving list"
@@ -89,7 +98,7 @@ var _ = Describe("Binding", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			bindings, err := sdk.RetrieveBindings(sb.Namespace)
+			bindings, err := sdk.RetrieveBindings(sb.Namespace, "")
 
 			Expect(bindings).To(BeNil())
 			Expect(err).To(HaveOccurred())
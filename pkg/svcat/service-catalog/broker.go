@@ -22,6 +22,7 @@ import (
 	"math"
 	"time"
 
+	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -259,6 +260,96 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 	return result, nil
 }
 
+// BrokerCatalogSummary describes the catalog returned by a broker.
+type BrokerCatalogSummary struct {
+	APIVersion string
+	Classes    int
+	Plans      int
+}
+
+// ValidateBroker retrieves the catalog of the broker at the given url with
+// the connection settings of the register options, without registering the
+// broker.
+func (sdk *SDK) ValidateBroker(brokerName string, url string, opts *RegisterOptions) (*BrokerCatalogSummary, error) {
+	clientConfig := osb.DefaultClientConfiguration()
+	clientConfig.Name = brokerName
+	clientConfig.URL = url
+	clientConfig.EnableAlphaFeatures = true
+	clientConfig.Insecure = opts.SkipTLS
+	if opts.CAFile != "" {
+		caBytes, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening CA file: %v", err.Error())
+		}
+		clientConfig.CAData = caBytes
+	}
+
+	authConfig, err := sdk.brokerAuthConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig.AuthConfig = authConfig
+
+	client, err := osb.NewClient(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a client for the broker (%s)", err)
+	}
+	catalog, err := client.GetCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the catalog of the broker (%s)", err)
+	}
+
+	summary := &BrokerCatalogSummary{
+		APIVersion: clientConfig.APIVersion.HeaderValue(),
+		Classes:    len(catalog.Services),
+	}
+	for _, service := range catalog.Services {
+		summary.Plans += len(service.Plans)
+	}
+	return summary, nil
+}
+
+// brokerAuthConfig reads the credentials of the secret named in the register
+// options the same way the controller does.
+func (sdk *SDK) brokerAuthConfig(opts *RegisterOptions) (*osb.AuthConfig, error) {
+	if opts.BasicSecret != "" {
+		secret, err := sdk.Core().Secrets(opts.Namespace).Get(opts.BasicSecret, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get secret %s/%s (%s)", opts.Namespace, opts.BasicSecret, err)
+		}
+		username, ok := secret.Data["username"]
+		if !ok {
+			return nil, fmt.Errorf("auth secret didn't contain username")
+		}
+		password, ok := secret.Data["password"]
+		if !ok {
+			return nil, fmt.Errorf("auth secret didn't contain password")
+		}
+		return &osb.AuthConfig{
+			BasicAuthConfig: &osb.BasicAuthConfig{
+				Username: string(username),
+				Password: string(password),
+			},
+		}, nil
+	}
+	if opts.BearerSecret != "" {
+		secret, err := sdk.Core().Secrets(opts.Namespace).Get(opts.BearerSecret, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get secret %s/%s (%s)", opts.Namespace, opts.BearerSecret, err)
+		}
+		token, ok := secret.Data["token"]
+		if !ok {
+			return nil, fmt.Errorf("auth secret didn't contain token")
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{
+				Token: string(token),
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
 // Sync or relist a broker to refresh its broker metadata.
 func (sdk *SDK) Sync(name string, scopeOpts ScopeOptions, retries int) error {
 	success := false
@@ -0,0 +1,266 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultMetricsNamespace is the namespace of the Service of the
+	// controller manager when Service Catalog is installed with the Helm
+	// chart as described in the installation guide.
+	DefaultMetricsNamespace = "catalog"
+	// DefaultMetricsService is the name of the Service of the controller
+	// manager when Service Catalog is installed with the Helm chart as
+	// described in the installation guide.
+	DefaultMetricsService = "catalog-catalog-controller-manager"
+
+	// osbRequestCountMetric is the metric of the controller manager counting
+	// the requests sent to the brokers.
+	osbRequestCountMetric = "servicecatalog_osb_request_count"
+	// metricsServicePort is the name of the port of the Service of the
+	// controller manager serving the metrics.
+	metricsServicePort = "secure"
+)
+
+// BrokerMetricsSource is where the counts of BrokerMetrics come from.
+type BrokerMetricsSource string
+
+const (
+	// BrokerMetricsSourceController means that the counts are the requests
+	// sent to the broker since the controller manager started.
+	BrokerMetricsSourceController BrokerMetricsSource = "controller"
+	// BrokerMetricsSourceStatus means that the counts are the instances and
+	// bindings of the broker, by their status, because the metrics of the
+	// controller manager could not be read.
+	BrokerMetricsSourceStatus BrokerMetricsSource = "status"
+)
+
+// osbOperations maps the methods of the OSB client, as recorded in the
+// metrics of the controller manager, to the operations shown to the user, in
+// the order in which they are shown.
+var osbOperations = []struct {
+	method    string
+	operation string
+}{
+	{"GetCatalog", "Catalog"},
+	{"ProvisionInstance", "Provision"},
+	{"UpdateInstance", "Update"},
+	{"DeprovisionInstance", "Deprovision"},
+	{"PollLastOperation", "Poll Instance"},
+	{"Bind", "Bind"},
+	{"Unbind", "Unbind"},
+	{"GetBinding", "Get Binding"},
+	{"PollBindingLastOperation", "Poll Binding"},
+}
+
+// MetricsOptions identifies the Service of the controller manager whose
+// metrics are read.
+type MetricsOptions struct {
+	Namespace string
+	Service   string
+}
+
+// BrokerOperationCount is the number of succeeded and failed operations of
+// one kind for a broker.
+type BrokerOperationCount struct {
+	Operation string
+	Succeeded int
+	Failed    int
+}
+
+// BrokerMetrics summarizes the recent operations of a broker.
+type BrokerMetrics struct {
+	// Source is where the counts come from.
+	Source BrokerMetricsSource
+	// MetricsError is why the metrics of the controller manager could not
+	// be read, when Source is BrokerMetricsSourceStatus.
+	MetricsError error
+	// Operations are the counts, by operation.
+	Operations []BrokerOperationCount
+}
+
+// RetrieveBrokerMetrics returns the number of succeeded and failed requests
+// the controller manager sent to the broker, read from its metrics through
+// the proxy of the API server. When the metrics can not be read, the counts
+// of ready and failed instances and bindings of the broker are returned
+// instead.
+func (sdk *SDK) RetrieveBrokerMetrics(broker Broker, opts MetricsOptions) (*BrokerMetrics, error) {
+	operations, err := sdk.retrieveBrokerRequestCounts(broker, opts)
+	if err == nil {
+		return &BrokerMetrics{Source: BrokerMetricsSourceController, Operations: operations}, nil
+	}
+
+	operations, statusErr := sdk.retrieveBrokerStatusCounts(broker)
+	if statusErr != nil {
+		return nil, statusErr
+	}
+	return &BrokerMetrics{Source: BrokerMetricsSourceStatus, MetricsError: err, Operations: operations}, nil
+}
+
+// retrieveBrokerRequestCounts reads the request counts of the broker from
+// the metrics of the controller manager. The metrics identify brokers by
+// name only, so the counts of namespaced brokers include the brokers of the
+// same name in other namespaces.
+func (sdk *SDK) retrieveBrokerRequestCounts(broker Broker, opts MetricsOptions) ([]BrokerOperationCount, error) {
+	raw, err := sdk.Core().Services(opts.Namespace).ProxyGet("https", opts.Service, metricsServicePort, "metrics", nil).DoRaw()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the metrics of the controller manager from service %s/%s (%s)", opts.Namespace, opts.Service, err)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the metrics of the controller manager (%s)", err)
+	}
+
+	succeeded := map[string]int{}
+	failed := map[string]int{}
+	if family, ok := families[osbRequestCountMetric]; ok {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["broker"] != broker.GetName() {
+				continue
+			}
+			count := int(metric.GetCounter().GetValue())
+			if labels["status"] == "2xx" {
+				succeeded[labels["method"]] += count
+			} else {
+				failed[labels["method"]] += count
+			}
+		}
+	}
+
+	operations := []BrokerOperationCount{}
+	known := map[string]bool{}
+	for _, op := range osbOperations {
+		known[op.method] = true
+		operations = append(operations, BrokerOperationCount{
+			Operation: op.operation,
+			Succeeded: succeeded[op.method],
+			Failed:    failed[op.method],
+		})
+	}
+	// Methods added to the OSB client after this version of svcat are
+	// shown by their name, after the known operations
+	var unknown []string
+	for _, counts := range []map[string]int{succeeded, failed} {
+		for method := range counts {
+			if !known[method] {
+				known[method] = true
+				unknown = append(unknown, method)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	for _, method := range unknown {
+		operations = append(operations, BrokerOperationCount{
+			Operation: method,
+			Succeeded: succeeded[method],
+			Failed:    failed[method],
+		})
+	}
+	return operations, nil
+}
+
+// retrieveBrokerStatusCounts counts the ready and failed instances and
+// bindings of the classes of the broker.
+func (sdk *SDK) retrieveBrokerStatusCounts(broker Broker) ([]BrokerOperationCount, error) {
+	classes := map[string]bool{}
+	var instances []v1beta1.ServiceInstance
+	if broker.GetNamespace() == "" {
+		classList, err := sdk.ServiceCatalog().ClusterServiceClasses().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list classes (%s)", err)
+		}
+		for _, class := range classList.Items {
+			if class.Spec.ClusterServiceBrokerName == broker.GetName() {
+				classes[class.Name] = true
+			}
+		}
+		instanceList, err := sdk.ServiceCatalog().ServiceInstances(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list instances (%s)", err)
+		}
+		for _, instance := range instanceList.Items {
+			if instance.Spec.ClusterServiceClassRef != nil && classes[instance.Spec.ClusterServiceClassRef.Name] {
+				instances = append(instances, instance)
+			}
+		}
+	} else {
+		classList, err := sdk.ServiceCatalog().ServiceClasses(broker.GetNamespace()).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list classes (%s)", err)
+		}
+		for _, class := range classList.Items {
+			if class.Spec.ServiceBrokerName == broker.GetName() {
+				classes[class.Name] = true
+			}
+		}
+		instanceList, err := sdk.ServiceCatalog().ServiceInstances(broker.GetNamespace()).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list instances (%s)", err)
+		}
+		for _, instance := range instanceList.Items {
+			if instance.Spec.ServiceClassRef != nil && classes[instance.Spec.ServiceClassRef.Name] {
+				instances = append(instances, instance)
+			}
+		}
+	}
+
+	provision := BrokerOperationCount{Operation: "Provision"}
+	brokerInstances := map[string]bool{}
+	for i := range instances {
+		instance := &instances[i]
+		brokerInstances[instance.Namespace+"/"+instance.Name] = true
+		if sdk.IsInstanceReady(instance) {
+			provision.Succeeded++
+		} else if sdk.IsInstanceFailed(instance) {
+			provision.Failed++
+		}
+	}
+
+	bind := BrokerOperationCount{Operation: "Bind"}
+	if len(instances) > 0 {
+		bindingList, err := sdk.ServiceCatalog().ServiceBindings(broker.GetNamespace()).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list bindings (%s)", err)
+		}
+		for i := range bindingList.Items {
+			binding := &bindingList.Items[i]
+			if !brokerInstances[binding.Namespace+"/"+binding.Spec.InstanceRef.Name] {
+				continue
+			}
+			if sdk.IsBindingReady(binding) {
+				bind.Succeeded++
+			} else if sdk.IsBindingFailed(binding) {
+				bind.Failed++
+			}
+		}
+	}
+
+	return []BrokerOperationCount{provision, bind}, nil
+}
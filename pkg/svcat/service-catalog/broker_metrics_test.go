@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"errors"
+	"io"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// rawResponse is a restclient.ResponseWrapper returning a fixed response.
+type rawResponse struct {
+	body []byte
+	err  error
+}
+
+func (r rawResponse) DoRaw() ([]byte, error) { return r.body, r.err }
+
+func (r rawResponse) Stream() (io.ReadCloser, error) { return nil, errors.New("not implemented") }
+
+const controllerMetrics = `# HELP servicecatalog_osb_request_count Cumulative number of HTTP requests from the OSB Client to the specified Service Broker grouped by broker name, broker method, and response status.
+# TYPE servicecatalog_osb_request_count counter
+servicecatalog_osb_request_count{broker="mybroker",method="Bind",status="2xx"} 4
+servicecatalog_osb_request_count{broker="mybroker",method="Bind",status="5xx"} 1
+servicecatalog_osb_request_count{broker="mybroker",method="Bind",status="client-error"} 2
+servicecatalog_osb_request_count{broker="mybroker",method="GetCatalog",status="2xx"} 10
+servicecatalog_osb_request_count{broker="mybroker",method="FetchSomething",status="2xx"} 1
+servicecatalog_osb_request_count{broker="otherbroker",method="ProvisionInstance",status="2xx"} 7
+`
+
+var _ = Describe("Broker Metrics", func() {
+	var (
+		sdk          *SDK
+		k8sClient    *k8sfake.Clientset
+		svcCatClient *fake.Clientset
+		broker       *v1beta1.ClusterServiceBroker
+		opts         MetricsOptions
+	)
+
+	ready := v1beta1.ServiceInstanceStatus{
+		Conditions: []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+		},
+	}
+	failed := v1beta1.ServiceInstanceStatus{
+		Conditions: []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue},
+		},
+	}
+
+	BeforeEach(func() {
+		broker = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "mybroker"}}
+		class := &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "myclass"},
+			Spec:       v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: "mybroker"},
+		}
+		otherClass := &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "otherclass"},
+			Spec:       v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: "otherbroker"},
+		}
+		readyInstance := &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "a"},
+			Spec:       v1beta1.ServiceInstanceSpec{ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "myclass"}},
+			Status:     ready,
+		}
+		failedInstance := &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "b"},
+			Spec:       v1beta1.ServiceInstanceSpec{ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "myclass"}},
+			Status:     failed,
+		}
+		otherInstance := &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "a"},
+			Spec:       v1beta1.ServiceInstanceSpec{ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "otherclass"}},
+			Status:     ready,
+		}
+		readyBinding := &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "a"},
+			Spec:       v1beta1.ServiceBindingSpec{InstanceRef: v1beta1.LocalObjectReference{Name: "ready"}},
+			Status: v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+				},
+			},
+		}
+		otherBinding := &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "a"},
+			Spec:       v1beta1.ServiceBindingSpec{InstanceRef: v1beta1.LocalObjectReference{Name: "other"}},
+			Status: v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+				},
+			},
+		}
+		svcCatClient = fake.NewSimpleClientset(class, otherClass, readyInstance, failedInstance, otherInstance, readyBinding, otherBinding)
+		k8sClient = k8sfake.NewSimpleClientset()
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: svcCatClient,
+		}
+		opts = MetricsOptions{Namespace: DefaultMetricsNamespace, Service: DefaultMetricsService}
+	})
+
+	Describe("RetrieveBrokerMetrics", func() {
+		It("counts the requests of the broker in the metrics of the controller manager", func() {
+			k8sClient.AddProxyReactor("services", func(action testing.Action) (bool, restclient.ResponseWrapper, error) {
+				return true, rawResponse{body: []byte(controllerMetrics)}, nil
+			})
+
+			metrics, err := sdk.RetrieveBrokerMetrics(broker, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metrics.Source).To(Equal(BrokerMetricsSourceController))
+			Expect(metrics.Operations).To(ContainElement(BrokerOperationCount{Operation: "Catalog", Succeeded: 10}))
+			Expect(metrics.Operations).To(ContainElement(BrokerOperationCount{Operation: "Bind", Succeeded: 4, Failed: 3}))
+			Expect(metrics.Operations).To(ContainElement(BrokerOperationCount{Operation: "Provision"}))
+			Expect(metrics.Operations[len(metrics.Operations)-1]).To(Equal(BrokerOperationCount{Operation: "FetchSomething", Succeeded: 1}))
+
+			actions := k8sClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			proxy := actions[0].(testing.ProxyGetAction)
+			Expect(proxy.GetNamespace()).To(Equal(DefaultMetricsNamespace))
+			Expect(proxy.GetName()).To(Equal(DefaultMetricsService))
+			Expect(proxy.GetPath()).To(Equal("metrics"))
+		})
+		It("counts the instances and bindings of the broker when the metrics can not be read", func() {
+			k8sClient.AddProxyReactor("services", func(action testing.Action) (bool, restclient.ResponseWrapper, error) {
+				return true, rawResponse{err: errors.New("services is forbidden")}, nil
+			})
+
+			metrics, err := sdk.RetrieveBrokerMetrics(broker, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metrics.Source).To(Equal(BrokerMetricsSourceStatus))
+			Expect(metrics.MetricsError.Error()).To(ContainSubstring("services is forbidden"))
+			Expect(metrics.Operations).To(Equal([]BrokerOperationCount{
+				{Operation: "Provision", Succeeded: 1, Failed: 1},
+				{Operation: "Bind", Succeeded: 1},
+			}))
+		})
+		It("bubbles up errors when the status can not be read either", func() {
+			k8sClient.AddProxyReactor("services", func(action testing.Action) (bool, restclient.ResponseWrapper, error) {
+				return true, rawResponse{err: errors.New("services is forbidden")}, nil
+			})
+			svcCatClient.PrependReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("oops")
+			})
+
+			_, err := sdk.RetrieveBrokerMetrics(broker, opts)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("oops"))
+		})
+	})
+})
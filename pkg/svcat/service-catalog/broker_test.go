@@ -19,14 +19,18 @@ package servicecatalog_test
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	apisservicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 
 	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
@@ -659,6 +663,69 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(csb.Name))
 		})
 	})
+	Describe("ValidateBroker", func() {
+		var (
+			server      *httptest.Server
+			requestAuth string
+		)
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestAuth = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"services": [
+					{"id": "a", "name": "class-a", "plans": [{"id": "a1", "name": "plan-a1"}, {"id": "a2", "name": "plan-a2"}]},
+					{"id": "b", "name": "class-b", "plans": [{"id": "b1", "name": "plan-b1"}]}
+				]}`)
+			}))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+		It("retrieves the catalog with the credentials of the basic secret without registering the broker", func() {
+			sdk.K8sClient = k8sfake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "potatosecret", Namespace: "potatonamespace"},
+				Data: map[string][]byte{
+					"username": []byte("potato"),
+					"password": []byte("mashed"),
+				},
+			})
+			opts := &RegisterOptions{
+				BasicSecret: "potatosecret",
+				Namespace:   "potatonamespace",
+			}
+
+			summary, err := sdk.ValidateBroker("potato_broker", server.URL, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.Classes).To(Equal(2))
+			Expect(summary.Plans).To(Equal(3))
+			Expect(summary.APIVersion).NotTo(BeEmpty())
+			Expect(requestAuth).To(HavePrefix("Basic "))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+		It("errors if the bearer secret does not contain a token", func() {
+			sdk.K8sClient = k8sfake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "potatosecret", Namespace: "potatonamespace"},
+			})
+			opts := &RegisterOptions{
+				BearerSecret: "potatosecret",
+				Namespace:    "potatonamespace",
+			}
+
+			_, err := sdk.ValidateBroker("potato_broker", server.URL, opts)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("auth secret didn't contain token"))
+		})
+		It("errors if the catalog can not be retrieved", func() {
+			server.Close()
+
+			_, err := sdk.ValidateBroker("potato_broker", server.URL, &RegisterOptions{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to retrieve the catalog of the broker"))
+		})
+	})
 	Describe("WaitForBroker", func() {
 		var (
 			counter                  int
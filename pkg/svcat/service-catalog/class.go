@@ -70,11 +70,14 @@ type Class interface {
 	IsClusterServiceClass() bool
 }
 
-// RetrieveClasses lists all classes defined in the cluster.
-func (sdk *SDK) RetrieveClasses(opts ScopeOptions) ([]Class, error) {
+// RetrieveClasses lists all classes defined in the cluster that match the
+// label selector.
+func (sdk *SDK) RetrieveClasses(opts ScopeOptions, labelSelector string) ([]Class, error) {
+	listOpts := metav1.ListOptions{LabelSelector: labelSelector}
+
 	var classes []Class
 	if opts.Scope.Matches(ClusterScope) {
-		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(metav1.ListOptions{})
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(listOpts)
 		if err != nil {
 			return nil, fmt.Errorf("unable to list cluster-scoped classes (%s)", err)
 		}
@@ -85,7 +88,7 @@ func (sdk *SDK) RetrieveClasses(opts ScopeOptions) ([]Class, error) {
 	}
 
 	if opts.Scope.Matches(NamespaceScope) {
-		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(metav1.ListOptions{})
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(listOpts)
 		if err != nil {
 			// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
 			if apierrors.IsNotFound(err) {
@@ -159,6 +162,25 @@ func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, erro
 	return searchResults[0], nil
 }
 
+// RetrieveClassByExternalID gets a class by the ID the broker knows it by.
+func (sdk *SDK) RetrieveClassByExternalID(externalID string, opts ScopeOptions) (Class, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecExternalID: util.GenerateSHA(externalID),
+	}).String()
+	classes, err := sdk.RetrieveClasses(opts, selector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search classes by external ID (%s)", err)
+	}
+
+	if len(classes) > 1 {
+		return nil, fmt.Errorf("more than one matching class found for external ID '%s' %d", externalID, len(classes))
+	}
+	if len(classes) == 0 {
+		return nil, fmt.Errorf("no matching class found for external ID '%s'", externalID)
+	}
+	return classes[0], nil
+}
+
 // RetrieveClassByID gets a class by its Kubernetes name.
 func (sdk *SDK) RetrieveClassByID(kubeName string, opts ScopeOptions) (Class, error) {
 	var csc *v1beta1.ClusterServiceClass
@@ -263,3 +285,17 @@ func (sdk *SDK) createServiceClass(from *v1beta1.ServiceClass, name, namespace s
 
 	return created, nil
 }
+
+// DeleteClass deletes a class.
+func (sdk *SDK) DeleteClass(class Class) error {
+	var err error
+	if class.GetNamespace() == "" {
+		err = sdk.ServiceCatalog().ClusterServiceClasses().Delete(class.GetName(), &metav1.DeleteOptions{})
+	} else {
+		err = sdk.ServiceCatalog().ServiceClasses(class.GetNamespace()).Delete(class.GetName(), &metav1.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to delete class %s (%s)", class.GetName(), err)
+	}
+	return nil
+}
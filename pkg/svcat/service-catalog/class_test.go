@@ -78,15 +78,26 @@ var _ = Describe("Class", func() {
 
 	Describe("RetrieveClasses", func() {
 		It("Calls the generated v1beta1 List methods", func() {
-			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope})
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(classes).Should(ConsistOf(csc, csc2, sc, sc2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
 			Expect(svcCatClient.Actions()[1].Matches("list", "serviceclasses")).To(BeTrue())
 		})
+		It("Passes the label selector to the List methods", func() {
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope}, "team=payments")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(BeEmpty())
+			actions := svcCatClient.Actions()
+			Expect(actions).Should(HaveLen(2))
+			for _, action := range actions {
+				Expect(action.(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("team=payments"))
+			}
+		})
 		It("Filters by namespace scope", func() {
-			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: NamespaceScope, Namespace: "default"}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(classes).Should(ConsistOf(sc))
@@ -95,7 +106,7 @@ var _ = Describe("Class", func() {
 
 		})
 		It("Filters by cluster scope", func() {
-			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: ClusterScope, Namespace: "default"})
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: ClusterScope, Namespace: "default"}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(classes).Should(ConsistOf(csc, csc2))
@@ -113,7 +124,7 @@ var _ = Describe("Class", func() {
 				ServiceCatalogClient: badClient,
 			}
 
-			_, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope})
+			_, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope}, "")
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -165,6 +176,38 @@ var _ = Describe("Class", func() {
 			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.externalName=" + util.GenerateSHA("notreal_class")))
 		})
 	})
+	Describe("RetrieveClassByExternalID", func() {
+		It("Calls the generated v1beta1 List method with the passed in external ID", func() {
+			class := &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{
+				Name: "foobar-kube-name",
+				Labels: map[string]string{
+					v1beta1.GroupName + "/" + v1beta1.FilterSpecExternalID: util.GenerateSHA("foobar-id"),
+				},
+			}}
+			realClient := fake.NewSimpleClientset(class)
+			sdk = &SDK{
+				ServiceCatalogClient: realClient,
+			}
+
+			retrieved, err := sdk.RetrieveClassByExternalID("foobar-id", ScopeOptions{Scope: AllScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved).To(Equal(class))
+			actions := realClient.Actions()
+			Expect(actions[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			requirements, selectable := actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.Requirements()
+			Expect(selectable).Should(BeTrue())
+			Expect(requirements).ShouldNot(BeEmpty())
+			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.externalID=" + util.GenerateSHA("foobar-id")))
+		})
+		It("Errors when no class has the external ID", func() {
+			class, err := sdk.RetrieveClassByExternalID("notreal-id", ScopeOptions{Scope: AllScope})
+
+			Expect(class).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("no matching class found for external ID 'notreal-id'"))
+		})
+	})
 	Describe("RetrieveClassByID", func() {
 		It("Calls the generated v1beta1 get methods for clusterserviceclass and serviceclass with the passed in name", func() {
 			classID := csc.Name
@@ -507,4 +550,34 @@ var _ = Describe("Class", func() {
 			Expect(actions[1].Matches("create", "serviceclasses")).To(BeTrue())
 		})
 	})
+	Describe("DeleteClass", func() {
+		It("Deletes a cluster service class", func() {
+			err := sdk.DeleteClass(csc)
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "clusterserviceclasses")).To(BeTrue())
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(csc.Name))
+		})
+		It("Deletes a service class in its namespace", func() {
+			err := sdk.DeleteClass(sc)
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "serviceclasses")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(sc.Namespace))
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(sc.Name))
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "error deleting class"
+			svcCatClient.PrependReactor("delete", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+
+			err := sdk.DeleteClass(csc)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
 })
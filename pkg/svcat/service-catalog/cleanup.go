@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// RemovedCatalog are the classes and plans that were removed from the catalog
+// of their broker and are not referenced by any instance anymore.
+type RemovedCatalog struct {
+	Classes []Class
+	Plans   []Plan
+}
+
+// RetrieveRemovedCatalog lists the classes and plans that were removed from
+// the catalog of their broker and can be deleted. An object is kept when an
+// instance refers to it, and a class is kept as long as one of its plans is
+// kept.
+func (sdk *SDK) RetrieveRemovedCatalog(opts ScopeOptions) (*RemovedCatalog, error) {
+	classes, err := sdk.RetrieveClasses(opts, "")
+	if err != nil {
+		return nil, err
+	}
+	plans, err := sdk.RetrievePlans("", opts, "")
+	if err != nil {
+		return nil, err
+	}
+	// The instances of all namespaces may refer to cluster-scoped objects
+	instances, err := sdk.RetrieveInstances("", "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := newCatalogReferences(instances.Items)
+	removed := &RemovedCatalog{}
+	keptClasses := map[string]bool{}
+	for _, p := range plans {
+		if isPlanRemovedFromBrokerCatalog(p) && !refs.refersToPlan(p) {
+			removed.Plans = append(removed.Plans, p)
+			continue
+		}
+		keptClasses[catalogKey(p.GetNamespace(), p.GetClassID())] = true
+	}
+	for _, c := range classes {
+		if isClassRemovedFromBrokerCatalog(c) && !refs.refersToClass(c) && !keptClasses[catalogKey(c.GetNamespace(), c.GetName())] {
+			removed.Classes = append(removed.Classes, c)
+		}
+	}
+	return removed, nil
+}
+
+// catalogReferences are the classes and plans that instances refer to, by
+// their k8s names and, for the instances whose references are not resolved
+// yet, by their external names and IDs.
+type catalogReferences struct {
+	names             map[string]bool
+	classExternalRefs map[string]bool
+	planExternalRefs  map[string]bool
+}
+
+func newCatalogReferences(instances []v1beta1.ServiceInstance) *catalogReferences {
+	refs := &catalogReferences{
+		names:             map[string]bool{},
+		classExternalRefs: map[string]bool{},
+		planExternalRefs:  map[string]bool{},
+	}
+	add := func(m map[string]bool, namespace, name string) {
+		if name != "" {
+			m[catalogKey(namespace, name)] = true
+		}
+	}
+	for _, instance := range instances {
+		spec := instance.Spec
+		ns := instance.Namespace
+		add(refs.names, "", spec.ClusterServiceClassName)
+		add(refs.names, "", spec.ClusterServicePlanName)
+		add(refs.names, ns, spec.ServiceClassName)
+		add(refs.names, ns, spec.ServicePlanName)
+		if spec.ClusterServiceClassRef != nil {
+			add(refs.names, "", spec.ClusterServiceClassRef.Name)
+		} else {
+			add(refs.classExternalRefs, "", spec.ClusterServiceClassExternalName)
+			add(refs.classExternalRefs, "", spec.ClusterServiceClassExternalID)
+		}
+		if spec.ClusterServicePlanRef != nil {
+			add(refs.names, "", spec.ClusterServicePlanRef.Name)
+		} else {
+			add(refs.planExternalRefs, "", spec.ClusterServicePlanExternalName)
+			add(refs.planExternalRefs, "", spec.ClusterServicePlanExternalID)
+		}
+		if spec.ServiceClassRef != nil {
+			add(refs.names, ns, spec.ServiceClassRef.Name)
+		} else {
+			add(refs.classExternalRefs, ns, spec.ServiceClassExternalName)
+			add(refs.classExternalRefs, ns, spec.ServiceClassExternalID)
+		}
+		if spec.ServicePlanRef != nil {
+			add(refs.names, ns, spec.ServicePlanRef.Name)
+		} else {
+			add(refs.planExternalRefs, ns, spec.ServicePlanExternalName)
+			add(refs.planExternalRefs, ns, spec.ServicePlanExternalID)
+		}
+	}
+	return refs
+}
+
+func (r *catalogReferences) refersToClass(c Class) bool {
+	ns := c.GetNamespace()
+	return r.names[catalogKey(ns, c.GetName())] ||
+		r.classExternalRefs[catalogKey(ns, c.GetSpec().ExternalName)] ||
+		r.classExternalRefs[catalogKey(ns, c.GetSpec().ExternalID)]
+}
+
+func (r *catalogReferences) refersToPlan(p Plan) bool {
+	ns := p.GetNamespace()
+	return r.names[catalogKey(ns, p.GetName())] ||
+		r.planExternalRefs[catalogKey(ns, p.GetExternalName())] ||
+		r.planExternalRefs[catalogKey(ns, planExternalID(p))]
+}
+
+func catalogKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func isClassRemovedFromBrokerCatalog(c Class) bool {
+	switch class := c.(type) {
+	case *v1beta1.ClusterServiceClass:
+		return class.Status.RemovedFromBrokerCatalog
+	case *v1beta1.ServiceClass:
+		return class.Status.RemovedFromBrokerCatalog
+	}
+	return false
+}
+
+func isPlanRemovedFromBrokerCatalog(p Plan) bool {
+	switch plan := p.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return plan.Status.RemovedFromBrokerCatalog
+	case *v1beta1.ServicePlan:
+		return plan.Status.RemovedFromBrokerCatalog
+	}
+	return false
+}
+
+func planExternalID(p Plan) string {
+	switch plan := p.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return plan.Spec.ExternalID
+	case *v1beta1.ServicePlan:
+		return plan.Spec.ExternalID
+	}
+	return ""
+}
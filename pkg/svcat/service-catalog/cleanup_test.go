@@ -0,0 +1,228 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"errors"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cleanup", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		removedClass *v1beta1.ClusterServiceClass
+		removedPlan  *v1beta1.ClusterServicePlan
+		liveClass    *v1beta1.ClusterServiceClass
+		livePlan     *v1beta1.ClusterServicePlan
+		removedNsCls *v1beta1.ServiceClass
+		removedNsPln *v1beta1.ServicePlan
+	)
+
+	BeforeEach(func() {
+		removedClass = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "removed-class"},
+			Spec: v1beta1.ClusterServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "oldclass", ExternalID: "removed-class"},
+			},
+			Status: v1beta1.ClusterServiceClassStatus{
+				CommonServiceClassStatus: v1beta1.CommonServiceClassStatus{RemovedFromBrokerCatalog: true},
+			},
+		}
+		removedPlan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "removed-plan"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec:  v1beta1.CommonServicePlanSpec{ExternalName: "oldplan", ExternalID: "removed-plan"},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: removedClass.Name},
+			},
+			Status: v1beta1.ClusterServicePlanStatus{
+				CommonServicePlanStatus: v1beta1.CommonServicePlanStatus{RemovedFromBrokerCatalog: true},
+			},
+		}
+		liveClass = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "live-class"},
+			Spec: v1beta1.ClusterServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "liveclass", ExternalID: "live-class"},
+			},
+		}
+		livePlan = &v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "live-plan"},
+			Spec: v1beta1.ClusterServicePlanSpec{
+				CommonServicePlanSpec:  v1beta1.CommonServicePlanSpec{ExternalName: "liveplan", ExternalID: "live-plan"},
+				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: liveClass.Name},
+			},
+		}
+		removedNsCls = &v1beta1.ServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "removed-ns-class", Namespace: "default"},
+			Spec: v1beta1.ServiceClassSpec{
+				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "oldnsclass", ExternalID: "removed-ns-class"},
+			},
+			Status: v1beta1.ServiceClassStatus{
+				CommonServiceClassStatus: v1beta1.CommonServiceClassStatus{RemovedFromBrokerCatalog: true},
+			},
+		}
+		removedNsPln = &v1beta1.ServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "removed-ns-plan", Namespace: "default"},
+			Spec: v1beta1.ServicePlanSpec{
+				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "oldnsplan", ExternalID: "removed-ns-plan"},
+				ServiceClassRef:       v1beta1.LocalObjectReference{Name: removedNsCls.Name},
+			},
+			Status: v1beta1.ServicePlanStatus{
+				CommonServicePlanStatus: v1beta1.CommonServicePlanStatus{RemovedFromBrokerCatalog: true},
+			},
+		}
+	})
+
+	newSDK := func(objects ...runtime.Object) {
+		svcCatClient = fake.NewSimpleClientset(objects...)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	}
+	catalog := func() []runtime.Object {
+		return []runtime.Object{removedClass, removedPlan, liveClass, livePlan, removedNsCls, removedNsPln}
+	}
+
+	Describe("RetrieveRemovedCatalog", func() {
+		It("Returns the classes and plans removed from the broker catalog", func() {
+			newSDK(catalog()...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(ConsistOf(removedPlan, removedNsPln))
+			Expect(removed.Classes).Should(ConsistOf(removedClass, removedNsCls))
+		})
+		It("Limits the objects to the scope", func() {
+			newSDK(catalog()...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(ConsistOf(removedPlan))
+			Expect(removed.Classes).Should(ConsistOf(removedClass))
+		})
+		It("Keeps the plan and class resolved for an instance", func() {
+			instance := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "inst", Namespace: "other"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalName: removedClass.Spec.ExternalName,
+						ClusterServicePlanExternalName:  removedPlan.Spec.ExternalName,
+					},
+					ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: removedClass.Name},
+					ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: removedPlan.Name},
+				},
+			}
+			newSDK(append(catalog(), instance)...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(ConsistOf(removedNsPln))
+			Expect(removed.Classes).Should(ConsistOf(removedNsCls))
+		})
+		It("Keeps the class of a plan that is referenced by an instance", func() {
+			instance := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "inst", Namespace: "default"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ServicePlanName: removedNsPln.Name,
+					},
+				},
+			}
+			newSDK(append(catalog(), instance)...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(ConsistOf(removedPlan))
+			Expect(removed.Classes).Should(ConsistOf(removedClass))
+		})
+		It("Keeps the objects referenced by the external names of an unresolved instance", func() {
+			instance := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "inst", Namespace: "default"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalID: removedClass.Spec.ExternalID,
+						ClusterServicePlanExternalID:  removedPlan.Spec.ExternalID,
+					},
+				},
+			}
+			newSDK(append(catalog(), instance)...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(BeEmpty())
+			Expect(removed.Classes).Should(BeEmpty())
+		})
+		It("Does not mistake a namespaced reference for a cluster-scoped one", func() {
+			instance := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "inst", Namespace: "default"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ServiceClassName: removedClass.Name,
+						ServicePlanName:  removedPlan.Name,
+					},
+				},
+			}
+			newSDK(append(catalog(), instance)...)
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed.Plans).Should(ConsistOf(removedPlan))
+			Expect(removed.Classes).Should(ConsistOf(removedClass))
+		})
+		It("Lists the instances of all namespaces", func() {
+			newSDK(catalog()...)
+
+			_, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			for _, action := range svcCatClient.Actions() {
+				if action.Matches("list", "serviceinstances") {
+					Expect(action.GetNamespace()).To(BeEmpty())
+				}
+			}
+		})
+		It("Bubbles up errors", func() {
+			newSDK(catalog()...)
+			errorMessage := "error listing instances"
+			svcCatClient.PrependReactor("list", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+
+			removed, err := sdk.RetrieveRemovedCatalog(ScopeOptions{Scope: AllScope})
+
+			Expect(removed).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
+})
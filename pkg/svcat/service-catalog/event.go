@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// RetrieveEvents lists the events involving the specified object, sorted with the oldest first.
+// Cluster-scoped objects should be requested with an empty namespace.
+func (sdk *SDK) RetrieveEvents(ns, kind, name string) (*corev1.EventList, error) {
+	events, err := sdk.Core().Events(ns).List(metav1.ListOptions{
+		FieldSelector: eventFieldSelector(kind, name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list events for %s %q (%s)", kind, name, err)
+	}
+
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return GetEventTime(events.Items[i]).Before(GetEventTime(events.Items[j]))
+	})
+
+	return events, nil
+}
+
+// WatchEvents watches for events involving the specified object,
+// starting after the specified resource version.
+// Cluster-scoped objects should be requested with an empty namespace.
+func (sdk *SDK) WatchEvents(ns, kind, name, resourceVersion string) (watch.Interface, error) {
+	w, err := sdk.Core().Events(ns).Watch(metav1.ListOptions{
+		FieldSelector:   eventFieldSelector(kind, name),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch events for %s %q (%s)", kind, name, err)
+	}
+
+	return w, nil
+}
+
+// GetEventTime returns the time that an event was last observed.
+func GetEventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
+func eventFieldSelector(kind, name string) string {
+	return fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	}.AsSelector().String()
+}
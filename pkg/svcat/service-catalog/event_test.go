@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event", func() {
+	var (
+		sdk       *SDK
+		k8sClient *k8sfake.Clientset
+		newer     *corev1.Event
+		older     *corev1.Event
+	)
+
+	BeforeEach(func() {
+		now := time.Now()
+		newer = &corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Name: "newer", Namespace: "foobar_namespace"},
+			Reason:        "ProvisionedSuccessfully",
+			LastTimestamp: metav1.NewTime(now),
+		}
+		older = &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "older", Namespace: "foobar_namespace"},
+			Reason:         "Provisioning",
+			FirstTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+		}
+		k8sClient = k8sfake.NewSimpleClientset(newer, older)
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: fake.NewSimpleClientset(),
+		}
+	})
+
+	Describe("RetrieveEvents", func() {
+		It("Lists the events for the object, oldest first", func() {
+			events, err := sdk.RetrieveEvents("foobar_namespace", "ServiceInstance", "foobar")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events.Items).To(HaveLen(2))
+			Expect(events.Items[0].Name).To(Equal(older.Name))
+			Expect(events.Items[1].Name).To(Equal(newer.Name))
+
+			actions := k8sClient.Actions()
+			Expect(actions[0].Matches("list", "events")).To(BeTrue())
+			fieldSelector := actions[0].(testing.ListActionImpl).GetListRestrictions().Fields
+			Expect(fieldSelector.String()).To(Equal("involvedObject.kind=ServiceInstance,involvedObject.name=foobar"))
+		})
+		It("Bubbles up errors", func() {
+			badClient := k8sfake.NewSimpleClientset()
+			badClient.PrependReactor("list", "events", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("oops")
+			})
+			sdk.K8sClient = badClient
+
+			events, err := sdk.RetrieveEvents("foobar_namespace", "ServiceInstance", "foobar")
+
+			Expect(err).To(HaveOccurred())
+			Expect(events).To(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Describe("WatchEvents", func() {
+		It("Watches the events for the object", func() {
+			w, err := sdk.WatchEvents("foobar_namespace", "ServiceInstance", "foobar", "1")
+
+			Expect(err).NotTo(HaveOccurred())
+			w.Stop()
+
+			actions := k8sClient.Actions()
+			Expect(actions[0].Matches("watch", "events")).To(BeTrue())
+			fieldSelector := actions[0].(testing.WatchActionImpl).GetWatchRestrictions().Fields
+			Expect(fieldSelector.String()).To(Equal("involvedObject.kind=ServiceInstance,involvedObject.name=foobar"))
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,9 +31,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
-// RetrieveInstances lists all instances in a namespace.
-func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter string) (*v1beta1.ServiceInstanceList, error) {
-	instances, err := sdk.ServiceCatalog().ServiceInstances(ns).List(v1.ListOptions{})
+// RetrieveInstances lists all instances in a namespace that match the label
+// selector.
+func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter, labelSelector string) (*v1beta1.ServiceInstanceList, error) {
+	instances, err := sdk.ServiceCatalog().ServiceInstances(ns).List(v1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to list instances in %s", ns)
 	}
@@ -81,19 +83,43 @@ func (sdk *SDK) RetrieveInstanceByBinding(b *v1beta1.ServiceBinding,
 	return inst, nil
 }
 
-// RetrieveInstancesByPlan retrieves all instances of a plan.
+// RetrieveInstancesByPlan retrieves all instances whose resolved plan
+// reference points to the plan. The instances of a cluster-scoped plan are
+// searched in all namespaces, the ones of a namespaced plan in the namespace of
+// the plan.
 func (sdk *SDK) RetrieveInstancesByPlan(plan Plan) ([]v1beta1.ServiceInstance, error) {
+	refLabel := v1beta1.FilterSpecClusterServicePlanRefName
+	if plan.GetNamespace() != "" {
+		refLabel = v1beta1.FilterSpecServicePlanRefName
+	}
 	planOpts := v1.ListOptions{
 		LabelSelector: labels.SelectorFromSet(labels.Set{
-			v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServicePlanRefName: plan.GetName(),
+			v1beta1.GroupName + "/" + refLabel: util.GenerateSHA(plan.GetName()),
 		}).String(),
 	}
-	instances, err := sdk.ServiceCatalog().ServiceInstances("").List(planOpts)
+	instances, err := sdk.ServiceCatalog().ServiceInstances(plan.GetNamespace()).List(planOpts)
 	if err != nil {
-		return nil, fmt.Errorf("unable to list instances (%s)", err)
+		return nil, errors.Wrap(err, "unable to list instances")
+	}
+
+	// The labels are hashes of the plan names, so make sure that the
+	// instances really reference the plan.
+	var planInstances []v1beta1.ServiceInstance
+	for _, instance := range instances.Items {
+		if instanceReferencesPlan(&instance, plan) {
+			planInstances = append(planInstances, instance)
+		}
 	}
+	return planInstances, nil
+}
 
-	return instances.Items, nil
+// instanceReferencesPlan returns whether the resolved plan reference of the
+// instance points to the plan.
+func instanceReferencesPlan(instance *v1beta1.ServiceInstance, plan Plan) bool {
+	if plan.GetNamespace() == "" {
+		return instance.Spec.ClusterServicePlanRef != nil && instance.Spec.ClusterServicePlanRef.Name == plan.GetName()
+	}
+	return instance.Namespace == plan.GetNamespace() && instance.Spec.ServicePlanRef != nil && instance.Spec.ServicePlanRef.Name == plan.GetName()
 }
 
 // InstanceParentHierarchy retrieves all ancestor resources of an instance.
@@ -166,39 +192,19 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 // by their k8s names. Depending on provisionClusterInstance, it will create either
 // an instance of a cluster class/plan or a namespaced class/plan
 func (sdk *SDK) Provision(instanceName, classKubeName, planKubeName string, provisionClusterInstance bool, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
-	var request *v1beta1.ServiceInstance
-	if provisionClusterInstance {
-		request = &v1beta1.ServiceInstance{
-			ObjectMeta: v1.ObjectMeta{
-				Name:      instanceName,
-				Namespace: opts.Namespace,
-			},
-			Spec: v1beta1.ServiceInstanceSpec{
-				ExternalID: opts.ExternalID,
-				PlanReference: v1beta1.PlanReference{
-					ClusterServiceClassName: classKubeName,
-					ClusterServicePlanName:  planKubeName,
-				},
-				Parameters:     BuildParameters(opts.Params),
-				ParametersFrom: BuildParametersFrom(opts.Secrets),
-			},
-		}
-	} else {
-		request = &v1beta1.ServiceInstance{
-			ObjectMeta: v1.ObjectMeta{
-				Name:      instanceName,
-				Namespace: opts.Namespace,
-			},
-			Spec: v1beta1.ServiceInstanceSpec{
-				ExternalID: opts.ExternalID,
-				PlanReference: v1beta1.PlanReference{
-					ServiceClassName: classKubeName,
-					ServicePlanName:  planKubeName,
-				},
-				Parameters:     BuildParameters(opts.Params),
-				ParametersFrom: BuildParametersFrom(opts.Secrets),
-			},
-		}
+	parametersFrom := append(append([]v1beta1.ParametersFromSource{}, opts.ParametersFrom...), BuildParametersFrom(opts.Secrets)...)
+	request := &v1beta1.ServiceInstance{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      instanceName,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			ExternalID:          opts.ExternalID,
+			PlanReference:       buildPlanReference(classKubeName, planKubeName, provisionClusterInstance, opts),
+			Parameters:          BuildParameters(opts.Params),
+			ParametersFrom:      parametersFrom,
+			SecretParameterRefs: opts.SecretParameterRefs,
+		},
 	}
 	result, err := sdk.ServiceCatalog().ServiceInstances(opts.Namespace).Create(request)
 	if err != nil {
@@ -207,6 +213,35 @@ func (sdk *SDK) Provision(instanceName, classKubeName, planKubeName string, prov
 	return result, nil
 }
 
+// buildPlanReference returns the reference of a new instance to its class and
+// plan, by external ID when the options set them, by Kubernetes name
+// otherwise.
+func buildPlanReference(classKubeName, planKubeName string, provisionClusterInstance bool, opts *ProvisionOptions) v1beta1.PlanReference {
+	byID := opts.ClassExternalID != ""
+	switch {
+	case provisionClusterInstance && byID:
+		return v1beta1.PlanReference{
+			ClusterServiceClassExternalID: opts.ClassExternalID,
+			ClusterServicePlanExternalID:  opts.PlanExternalID,
+		}
+	case provisionClusterInstance:
+		return v1beta1.PlanReference{
+			ClusterServiceClassName: classKubeName,
+			ClusterServicePlanName:  planKubeName,
+		}
+	case byID:
+		return v1beta1.PlanReference{
+			ServiceClassExternalID: opts.ClassExternalID,
+			ServicePlanExternalID:  opts.PlanExternalID,
+		}
+	default:
+		return v1beta1.PlanReference{
+			ServiceClassName: classKubeName,
+			ServicePlanName:  planKubeName,
+		}
+	}
+}
+
 // Deprovision deletes an instance.
 func (sdk *SDK) Deprovision(namespace, instanceName string) error {
 	err := sdk.ServiceCatalog().ServiceInstances(namespace).Delete(instanceName, &v1.DeleteOptions{})
@@ -241,6 +276,70 @@ func (sdk *SDK) TouchInstance(ns, name string, retries int) error {
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
 
+// UpdateInstancePlan changes the plan of an instance to the given plan, which
+// must be a plan of the class of the instance. The new plan is referenced the
+// same way as the current plan: by external name, external ID or Kubernetes
+// name.
+func (sdk *SDK) UpdateInstancePlan(ns, name string, plan Plan, retries int) (*v1beta1.ServiceInstance, error) {
+	for j := 0; j < retries; j++ {
+		inst, err := sdk.RetrieveInstance(ns, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := setInstancePlan(inst, plan); err != nil {
+			return nil, err
+		}
+
+		updated, err := sdk.ServiceCatalog().ServiceInstances(ns).Update(inst)
+		if err == nil {
+			return updated, nil
+		}
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("could not update the plan of instance (%s)", err)
+		}
+	}
+
+	return nil, fmt.Errorf("could not update the plan of instance after %d tries", retries)
+}
+
+// setInstancePlan sets the reference to the plan of an instance, and clears
+// the resolved reference so that the controller resolves the new plan.
+func setInstancePlan(instance *v1beta1.ServiceInstance, plan Plan) error {
+	ref := &instance.Spec.PlanReference
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		if !ref.ClusterServiceClassSpecified() {
+			return fmt.Errorf("instance %s/%s does not use a cluster-scoped class", instance.Namespace, instance.Name)
+		}
+		switch {
+		case ref.ClusterServicePlanExternalID != "":
+			ref.ClusterServicePlanExternalID = p.Spec.ExternalID
+		case ref.ClusterServicePlanName != "":
+			ref.ClusterServicePlanName = p.Name
+		default:
+			ref.ClusterServicePlanExternalName = p.Spec.ExternalName
+		}
+		instance.Spec.ClusterServicePlanRef = nil
+	case *v1beta1.ServicePlan:
+		if !ref.ServiceClassSpecified() {
+			return fmt.Errorf("instance %s/%s does not use a namespaced class", instance.Namespace, instance.Name)
+		}
+		switch {
+		case ref.ServicePlanExternalID != "":
+			ref.ServicePlanExternalID = p.Spec.ExternalID
+		case ref.ServicePlanName != "":
+			ref.ServicePlanName = p.Name
+		default:
+			ref.ServicePlanExternalName = p.Spec.ExternalName
+		}
+		instance.Spec.ServicePlanRef = nil
+	default:
+		return fmt.Errorf("unsupported plan type %T", plan)
+	}
+	return nil
+}
+
 // WaitForInstanceToNotExist waits for the specified instance to no longer exist.
 func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
@@ -263,7 +362,16 @@ func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duratio
 }
 
 // WaitForInstance waits for the instance to complete the current operation (or fail).
+// An instance whose latest spec has not been observed by the controller yet is
+// still waiting for its operation to start.
 func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
+	return sdk.WaitForInstanceWithProgress(ns, name, interval, timeout, nil)
+}
+
+// WaitForInstanceWithProgress waits for the instance to complete the current
+// operation (or fail), like WaitForInstance, and calls progress, when not nil,
+// with the instance retrieved at each interval.
+func (sdk *SDK) WaitForInstanceWithProgress(ns, name string, interval time.Duration, timeout *time.Duration, progress func(*v1beta1.ServiceInstance)) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
 		notimeout := time.Duration(math.MaxInt64)
 		timeout = &notimeout
@@ -276,7 +384,11 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 				return false, err
 			}
 
-			if len(instance.Status.Conditions) == 0 {
+			if progress != nil {
+				progress(instance)
+			}
+
+			if len(instance.Status.Conditions) == 0 || !instanceSpecObserved(instance) {
 				return false, nil
 			}
 
@@ -288,6 +400,13 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 	return instance, err
 }
 
+// instanceSpecObserved returns whether the controller has observed the latest
+// spec of the instance. Instances whose status predates the observed
+// generation are considered observed.
+func instanceSpecObserved(instance *v1beta1.ServiceInstance) bool {
+	return instance.Status.ObservedGeneration == 0 || instance.Status.ObservedGeneration >= instance.Generation
+}
+
 // IsInstanceReady returns if the instance is in the Ready status.
 func (sdk *SDK) IsInstanceReady(instance *v1beta1.ServiceInstance) bool {
 	return sdk.InstanceHasStatus(instance, v1beta1.ServiceInstanceConditionReady)
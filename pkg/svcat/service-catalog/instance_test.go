@@ -24,6 +24,7 @@ import (
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -86,7 +87,7 @@ var _ = Describe("Instances", func() {
 		It("Calls the generated v1beta1 List method with the specified namespace", func() {
 			namespace := si.Namespace
 
-			instances, err := sdk.RetrieveInstances(namespace, "", "")
+			instances, err := sdk.RetrieveInstances(namespace, "", "", "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(instances.Items).Should(ConsistOf(*si, *si2))
@@ -94,6 +95,15 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
 			Expect(actions[0].(testing.ListActionImpl).Namespace).To(Equal(namespace))
 		})
+		It("Passes the label selector to the List method", func() {
+			instances, err := sdk.RetrieveInstances("", "", "", "team=payments")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances.Items).Should(BeEmpty())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("team=payments"))
+		})
 		It("Bubbles up errors", func() {
 			namespace := si.Namespace
 			badClient := fake.NewSimpleClientset()
@@ -103,7 +113,7 @@ var _ = Describe("Instances", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			_, err := sdk.RetrieveInstances(namespace, "", "")
+			_, err := sdk.RetrieveInstances(namespace, "", "", "")
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -203,7 +213,56 @@ var _ = Describe("Instances", func() {
 			requirements, selectable := actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.Requirements()
 			Expect(selectable).Should(BeTrue())
 			Expect(requirements).ShouldNot(BeEmpty())
-			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.clusterServicePlanRef.name=foobar_plan"))
+			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.clusterServicePlanRef.name=" + util.GenerateSHA("foobar_plan")))
+		})
+		It("Only returns the instances whose resolved plan reference points to the plan", func() {
+			plan := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foobar_plan",
+				},
+			}
+			planLabels := map[string]string{
+				v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServicePlanRefName: util.GenerateSHA(plan.Name),
+			}
+			referencing := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "referencing", Namespace: "ns1", Labels: planLabels},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ClusterServicePlanRef: &v1beta1.ClusterObjectReference{Name: plan.Name},
+				},
+			}
+			mislabeled := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "mislabeled", Namespace: "ns2", Labels: planLabels},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ClusterServicePlanRef: &v1beta1.ClusterObjectReference{Name: "other_plan"},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(referencing, mislabeled)
+
+			instances, err := sdk.RetrieveInstancesByPlan(plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(1))
+			Expect(instances[0].Name).To(Equal("referencing"))
+		})
+		It("Searches the instances of a namespaced plan in the namespace of the plan", func() {
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar_plan",
+					Namespace: "foobar_namespace",
+				},
+			}
+			linkedClient := fake.NewSimpleClientset()
+			sdk.ServiceCatalogClient = linkedClient
+
+			_, err := sdk.RetrieveInstancesByPlan(plan)
+			Expect(err).NotTo(HaveOccurred())
+			actions := linkedClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("foobar_namespace"))
+
+			requirements, selectable := actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.Requirements()
+			Expect(selectable).Should(BeTrue())
+			Expect(requirements).ShouldNot(BeEmpty())
+			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.servicePlanRef.name=" + util.GenerateSHA("foobar_plan")))
 		})
 		It("Bubbles up errors", func() {
 			badClient := fake.NewSimpleClientset()
@@ -229,7 +288,7 @@ var _ = Describe("Instances", func() {
 			requirements, selectable := actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.Requirements()
 			Expect(selectable).Should(BeTrue())
 			Expect(requirements).ShouldNot(BeEmpty())
-			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.clusterServicePlanRef.name=foobar_plan"))
+			Expect(requirements[0].String()).To(Equal("servicecatalog.k8s.io/spec.clusterServicePlanRef.name=" + util.GenerateSHA("foobar_plan")))
 		})
 	})
 	Describe("UpdateInstance", func() {
@@ -542,6 +601,29 @@ var _ = Describe("Instances", func() {
 			Expect(objectFromRequest.Spec.ParametersFrom).Should(ConsistOf(param, param2))
 			Expect(objectFromRequest.Spec.ExternalID).To(Equal(externalID))
 		})
+		It("Refers to the class and plan by their external IDs when they are set", func() {
+			opts := &ProvisionOptions{
+				ClassExternalID: "cherry-class-id",
+				Namespace:       "cherry_namespace",
+				PlanExternalID:  "cherry-plan-id",
+			}
+
+			service, err := sdk.Provision("cherry", "", "", true, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.PlanReference).To(Equal(v1beta1.PlanReference{
+				ClusterServiceClassExternalID: "cherry-class-id",
+				ClusterServicePlanExternalID:  "cherry-plan-id",
+			}))
+
+			service, err = sdk.Provision("cherry2", "", "", false, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.PlanReference).To(Equal(v1beta1.PlanReference{
+				ServiceClassExternalID: "cherry-class-id",
+				ServicePlanExternalID:  "cherry-plan-id",
+			}))
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error retrieving list"
 			namespace := "cherry_namespace"
@@ -595,6 +677,76 @@ var _ = Describe("Instances", func() {
 		Expect(actions[0].Matches("delete", "serviceinstances")).To(BeTrue())
 		Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(si.Name))
 	})
+	Describe("UpdateInstancePlan", func() {
+		var newPlan *v1beta1.ClusterServicePlan
+		BeforeEach(func() {
+			newPlan = &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan-kube-name"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName: "100mb",
+						ExternalID:   "plan-external-id",
+					},
+				},
+			}
+			si.Spec.PlanReference = v1beta1.PlanReference{
+				ClusterServiceClassExternalName: "mysqldb",
+				ClusterServicePlanExternalName:  "10mb",
+			}
+			si.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: "class-kube-name"}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "old-plan-kube-name"}
+			svcCatClient = fake.NewSimpleClientset(si)
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+		It("Sets the external name of the plan and clears the resolved plan", func() {
+			instance, err := sdk.UpdateInstancePlan(si.Namespace, si.Name, newPlan, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.ClusterServicePlanExternalName).To(Equal("100mb"))
+			Expect(instance.Spec.ClusterServicePlanRef).To(BeNil())
+			Expect(instance.Spec.ClusterServiceClassRef).To(Equal(si.Spec.ClusterServiceClassRef))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[1].Matches("update", "serviceinstances")).To(BeTrue())
+		})
+		It("Keeps referencing the plan by Kubernetes name", func() {
+			si.Spec.PlanReference = v1beta1.PlanReference{
+				ClusterServiceClassName: "class-kube-name",
+				ClusterServicePlanName:  "old-plan-kube-name",
+			}
+			svcCatClient = fake.NewSimpleClientset(si)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			instance, err := sdk.UpdateInstancePlan(si.Namespace, si.Name, newPlan, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.ClusterServicePlanName).To(Equal("plan-kube-name"))
+			Expect(instance.Spec.ClusterServicePlanExternalName).To(BeEmpty())
+		})
+		It("Rejects a cluster-scoped plan for an instance of a namespaced class", func() {
+			si.Spec.PlanReference = v1beta1.PlanReference{
+				ServiceClassExternalName: "mysqldb",
+				ServicePlanExternalName:  "10mb",
+			}
+			svcCatClient = fake.NewSimpleClientset(si)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			_, err := sdk.UpdateInstancePlan(si.Namespace, si.Name, newPlan, 3)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not use a cluster-scoped class"))
+			Expect(svcCatClient.Actions()).To(HaveLen(1))
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "instance not found"
+			badClient := fake.NewSimpleClientset()
+			badClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.UpdateInstancePlan(si.Namespace, si.Name, newPlan, 3)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
 	Describe("WaitForInstance", func() {
 		var (
 			counter          int
@@ -637,6 +789,22 @@ var _ = Describe("Instances", func() {
 				Expect(v.(testing.GetActionImpl).Namespace).To(Equal(si.Namespace))
 			}
 		})
+		It("Waits until the controller observed the latest spec of the instance", func() {
+			unobservedInstance := si.DeepCopy()
+			unobservedInstance.Generation = 2
+			unobservedInstance.Status.ObservedGeneration = 1
+			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				if counter > 5 {
+					return true, si, nil
+				}
+				return true, unobservedInstance, nil
+			})
+			instance, err := sdk.WaitForInstance(si.Namespace, si.Name, interval, &timeout)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(Equal(si))
+			Expect(counter).To(BeNumerically(">", 5))
+		})
 		It("Waits until the instance is Failed", func() {
 			failedInstance := &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: si.Name}}
 			failed := v1beta1.ServiceInstanceCondition{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue}
@@ -680,6 +848,32 @@ var _ = Describe("Instances", func() {
 			}
 		})
 	})
+	Describe("WaitForInstanceWithProgress", func() {
+		It("Reports the progress of the instance until it reaches a ready state", func() {
+			counter := 0
+			inProgress := si.DeepCopy()
+			inProgress.Status.AsyncOpInProgress = true
+			inProgress.Status.LastOperationDescription = "creating database, 40%"
+			waitClient := fake.NewSimpleClientset()
+			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				if counter > 2 {
+					return true, si, nil
+				}
+				return true, inProgress, nil
+			})
+			sdk.ServiceCatalogClient = waitClient
+
+			var descriptions []string
+			timeout := 1 * time.Second
+			instance, err := sdk.WaitForInstanceWithProgress(si.Namespace, si.Name, 10*time.Millisecond, &timeout, func(instance *v1beta1.ServiceInstance) {
+				descriptions = append(descriptions, instance.Status.LastOperationDescription)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(Equal(si))
+			Expect(descriptions).To(Equal([]string{"creating database, 40%", "creating database, 40%", ""}))
+		})
+	})
 	Describe("WaitForInstanceToNotExist", func() {
 		var (
 			counter    int
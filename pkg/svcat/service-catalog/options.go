@@ -41,8 +41,16 @@ type RegisterOptions struct {
 
 // ProvisionOptions allows for the passing of optional fields to the instance Provision method.
 type ProvisionOptions struct {
-	ExternalID string
-	Namespace  string
-	Params     interface{}
-	Secrets    map[string]string
+	// ClassExternalID and PlanExternalID, when set, make the instance refer
+	// to its class and plan by the IDs the broker knows them by, instead of
+	// by the Kubernetes names passed to Provision.
+	ClassExternalID string
+	PlanExternalID  string
+	ExternalID      string
+	Namespace       string
+	Params          interface{}
+	// ParametersFrom is added to the parametersFrom built from Secrets.
+	ParametersFrom      []v1beta1.ParametersFromSource
+	SecretParameterRefs []v1beta1.SecretParameterReference
+	Secrets             map[string]string
 }
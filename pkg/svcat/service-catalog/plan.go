@@ -51,6 +51,9 @@ type Plan interface {
 	// GetFree returns if the plan is free.
 	GetFree() bool
 
+	// GetCosts returns the costs of the plan.
+	GetCosts() []v1beta1.ServicePlanCost
+
 	// GetClassID returns the plan's class name.
 	GetClassID() string
 
@@ -67,9 +70,10 @@ type Plan interface {
 	GetDefaultProvisionParameters() *runtime.RawExtension
 }
 
-// RetrievePlans lists all plans defined in the cluster.
-func (sdk *SDK) RetrievePlans(classID string, opts ScopeOptions) ([]Plan, error) {
-	plans, err := sdk.retrievePlansByListOptions(opts, metav1.ListOptions{})
+// RetrievePlans lists all plans defined in the cluster that match the label
+// selector.
+func (sdk *SDK) RetrievePlans(classID string, opts ScopeOptions, labelSelector string) ([]Plan, error) {
+	plans, err := sdk.retrievePlansByListOptions(opts, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
@@ -259,3 +263,17 @@ func (sdk *SDK) RetrievePlanByID(kubeName string, opts ScopeOptions) (Plan, erro
 
 	return nil, fmt.Errorf("unable to get plan by Kubernetes name'%s'", kubeName)
 }
+
+// DeletePlan deletes a plan.
+func (sdk *SDK) DeletePlan(plan Plan) error {
+	var err error
+	if plan.GetNamespace() == "" {
+		err = sdk.ServiceCatalog().ClusterServicePlans().Delete(plan.GetName(), &metav1.DeleteOptions{})
+	} else {
+		err = sdk.ServiceCatalog().ServicePlans(plan.GetNamespace()).Delete(plan.GetName(), &metav1.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to delete plan %s (%s)", plan.GetName(), err)
+	}
+	return nil
+}
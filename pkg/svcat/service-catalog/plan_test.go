@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
@@ -103,15 +104,26 @@ var _ = Describe("Plan", func() {
 
 	Describe("RetrivePlans", func() {
 		It("Calls the generated v1beta1 List method", func() {
-			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope})
+			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(plans).Should(ConsistOf(csp, csp2, sp, sp2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceplans")).To(BeTrue())
 			Expect(svcCatClient.Actions()[1].Matches("list", "serviceplans")).To(BeTrue())
 		})
+		It("Passes the label selector to the List methods", func() {
+			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope}, "team=payments")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).Should(BeEmpty())
+			actions := svcCatClient.Actions()
+			Expect(actions).Should(HaveLen(2))
+			for _, action := range actions {
+				Expect(action.(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("team=payments"))
+			}
+		})
 		It("Filters by namespace scope", func() {
-			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: NamespaceScope, Namespace: "default"}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(plans).Should(ConsistOf(sp))
@@ -119,7 +131,7 @@ var _ = Describe("Plan", func() {
 			Expect(svcCatClient.Actions()[0].Matches("list", "serviceplans")).To(BeTrue())
 		})
 		It("Filters by cluster scope", func() {
-			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: ClusterScope})
+			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: ClusterScope}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(plans).Should(ConsistOf(csp, csp2))
@@ -127,7 +139,7 @@ var _ = Describe("Plan", func() {
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceplans")).To(BeTrue())
 		})
 		It("Filter by class", func() {
-			plans, err := sdk.RetrievePlans(csc.Name, ScopeOptions{Scope: AllScope})
+			plans, err := sdk.RetrievePlans(csc.Name, ScopeOptions{Scope: AllScope}, "")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(plans).Should(ConsistOf(csp2))
@@ -142,7 +154,7 @@ var _ = Describe("Plan", func() {
 				return true, nil, fmt.Errorf(errorMessage)
 			})
 			sdk.ServiceCatalogClient = badClient
-			_, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope})
+			_, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope}, "")
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -388,4 +400,34 @@ var _ = Describe("Plan", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(planID))
 		})
 	})
+	Describe("DeletePlan", func() {
+		It("Deletes a cluster service plan", func() {
+			err := sdk.DeletePlan(csp)
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "clusterserviceplans")).To(BeTrue())
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(csp.Name))
+		})
+		It("Deletes a service plan in its namespace", func() {
+			err := sdk.DeletePlan(sp2)
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "serviceplans")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(sp2.Namespace))
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(sp2.Name))
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "error deleting plan"
+			svcCatClient.PrependReactor("delete", "clusterserviceplans", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+
+			err := sdk.DeletePlan(csp)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
 })
@@ -23,8 +23,10 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset"
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
 	apicorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -39,7 +41,7 @@ type SvcatClient interface {
 	IsBindingFailed(*apiv1beta1.ServiceBinding) bool
 	IsBindingReady(*apiv1beta1.ServiceBinding) bool
 	RetrieveBinding(string, string) (*apiv1beta1.ServiceBinding, error)
-	RetrieveBindings(string) (*apiv1beta1.ServiceBindingList, error)
+	RetrieveBindings(string, string) (*apiv1beta1.ServiceBindingList, error)
 	RetrieveBindingsByInstance(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
 	Unbind(string, string) ([]types.NamespacedName, error)
 	WaitForBinding(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
@@ -52,15 +54,19 @@ type SvcatClient interface {
 	RetrieveBrokers(opts ScopeOptions) ([]Broker, error)
 	RetrieveBrokerByID(string, ScopeOptions) (Broker, error)
 	RetrieveBrokerByClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveBrokerMetrics(Broker, MetricsOptions) (*BrokerMetrics, error)
 	Register(string, string, *RegisterOptions, *ScopeOptions) (Broker, error)
 	Sync(string, ScopeOptions, int) error
+	ValidateBroker(string, string, *RegisterOptions) (*BrokerCatalogSummary, error)
 	WaitForBroker(string, *ScopeOptions, time.Duration, *time.Duration) (Broker, error)
 
-	RetrieveClasses(ScopeOptions) ([]Class, error)
+	RetrieveClasses(ScopeOptions, string) ([]Class, error)
 	RetrieveClassByName(string, ScopeOptions) (Class, error)
 	RetrieveClassByID(string, ScopeOptions) (Class, error)
+	RetrieveClassByExternalID(string, ScopeOptions) (Class, error)
 	RetrieveClassByPlan(Plan) (Class, error)
 	CreateClassFrom(CreateClassFromOptions) (Class, error)
+	DeleteClass(Class) error
 
 	Deprovision(string, string) error
 	InstanceParentHierarchy(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
@@ -70,21 +76,30 @@ type SvcatClient interface {
 	Provision(string, string, string, bool, *ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstance(string, string) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstanceByBinding(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, error)
-	RetrieveInstances(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
+	RetrieveInstances(string, string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	RetrieveInstancesByPlan(Plan) ([]apiv1beta1.ServiceInstance, error)
 	TouchInstance(string, string, int) error
+	UpdateInstancePlan(string, string, Plan, int) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstance(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
+	WaitForInstanceWithProgress(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstanceToNotExist(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 
-	RetrievePlans(string, ScopeOptions) ([]Plan, error)
+	RetrievePlans(string, ScopeOptions, string) ([]Plan, error)
 	RetrievePlanByName(string, ScopeOptions) (Plan, error)
 	RetrievePlanByClassAndName(string, string, ScopeOptions) (Plan, error)
 	RetrievePlanByClassIDAndName(string, string, ScopeOptions) (Plan, error)
 	RetrievePlanByID(string, ScopeOptions) (Plan, error)
+	DeletePlan(Plan) error
+
+	RetrieveRemovedCatalog(ScopeOptions) (*RemovedCatalog, error)
 
 	RetrieveSecretByBinding(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 
+	RetrieveEvents(string, string, string) (*apicorev1.EventList, error)
+	WatchEvents(string, string, string, string) (watch.Interface, error)
+
 	ServerVersion() (*version.Info, error)
+	ServerResources() (*metav1.APIResourceList, error)
 }
 
 // SDK wrapper around the generated Go client for the Kubernetes Service Catalog
@@ -24,8 +24,10 @@ import (
 	apiv1beta1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	apicorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type FakeSvcatClient struct {
@@ -128,10 +130,11 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBinding
 		result2 error
 	}
-	RetrieveBindingsStub        func(string) (*apiv1beta1.ServiceBindingList, error)
+	RetrieveBindingsStub        func(string, string) (*apiv1beta1.ServiceBindingList, error)
 	retrieveBindingsMutex       sync.RWMutex
 	retrieveBindingsArgsForCall []struct {
 		arg1 string
+		arg2 string
 	}
 	retrieveBindingsReturns struct {
 		result1 *apiv1beta1.ServiceBindingList
@@ -273,6 +276,20 @@ type FakeSvcatClient struct {
 		result1 servicecatalog.Broker
 		result2 error
 	}
+	RetrieveBrokerMetricsStub        func(servicecatalog.Broker, servicecatalog.MetricsOptions) (*servicecatalog.BrokerMetrics, error)
+	retrieveBrokerMetricsMutex       sync.RWMutex
+	retrieveBrokerMetricsArgsForCall []struct {
+		arg1 servicecatalog.Broker
+		arg2 servicecatalog.MetricsOptions
+	}
+	retrieveBrokerMetricsReturns struct {
+		result1 *servicecatalog.BrokerMetrics
+		result2 error
+	}
+	retrieveBrokerMetricsReturnsOnCall map[int]struct {
+		result1 *servicecatalog.BrokerMetrics
+		result2 error
+	}
 	RetrieveBrokerByClassStub        func(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
 	retrieveBrokerByClassMutex       sync.RWMutex
 	retrieveBrokerByClassArgsForCall []struct {
@@ -315,6 +332,21 @@ type FakeSvcatClient struct {
 	syncReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateBrokerStub        func(string, string, *servicecatalog.RegisterOptions) (*servicecatalog.BrokerCatalogSummary, error)
+	validateBrokerMutex       sync.RWMutex
+	validateBrokerArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.RegisterOptions
+	}
+	validateBrokerReturns struct {
+		result1 *servicecatalog.BrokerCatalogSummary
+		result2 error
+	}
+	validateBrokerReturnsOnCall map[int]struct {
+		result1 *servicecatalog.BrokerCatalogSummary
+		result2 error
+	}
 	WaitForBrokerStub        func(string, *servicecatalog.ScopeOptions, time.Duration, *time.Duration) (servicecatalog.Broker, error)
 	waitForBrokerMutex       sync.RWMutex
 	waitForBrokerArgsForCall []struct {
@@ -331,10 +363,11 @@ type FakeSvcatClient struct {
 		result1 servicecatalog.Broker
 		result2 error
 	}
-	RetrieveClassesStub        func(servicecatalog.ScopeOptions) ([]servicecatalog.Class, error)
+	RetrieveClassesStub        func(servicecatalog.ScopeOptions, string) ([]servicecatalog.Class, error)
 	retrieveClassesMutex       sync.RWMutex
 	retrieveClassesArgsForCall []struct {
 		arg1 servicecatalog.ScopeOptions
+		arg2 string
 	}
 	retrieveClassesReturns struct {
 		result1 []servicecatalog.Class
@@ -372,6 +405,20 @@ type FakeSvcatClient struct {
 		result1 servicecatalog.Class
 		result2 error
 	}
+	RetrieveClassByExternalIDStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Class, error)
+	retrieveClassByExternalIDMutex       sync.RWMutex
+	retrieveClassByExternalIDArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveClassByExternalIDReturns struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
+	retrieveClassByExternalIDReturnsOnCall map[int]struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
 	RetrieveClassByPlanStub        func(servicecatalog.Plan) (servicecatalog.Class, error)
 	retrieveClassByPlanMutex       sync.RWMutex
 	retrieveClassByPlanArgsForCall []struct {
@@ -398,6 +445,17 @@ type FakeSvcatClient struct {
 		result1 servicecatalog.Class
 		result2 error
 	}
+	DeleteClassStub        func(servicecatalog.Class) error
+	deleteClassMutex       sync.RWMutex
+	deleteClassArgsForCall []struct {
+		arg1 servicecatalog.Class
+	}
+	deleteClassReturns struct {
+		result1 error
+	}
+	deleteClassReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeprovisionStub        func(string, string) error
 	deprovisionMutex       sync.RWMutex
 	deprovisionArgsForCall []struct {
@@ -508,12 +566,13 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
-	RetrieveInstancesStub        func(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
+	RetrieveInstancesStub        func(string, string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	retrieveInstancesMutex       sync.RWMutex
 	retrieveInstancesArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 string
 	}
 	retrieveInstancesReturns struct {
 		result1 *apiv1beta1.ServiceInstanceList
@@ -549,6 +608,22 @@ type FakeSvcatClient struct {
 	touchInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateInstancePlanStub        func(string, string, servicecatalog.Plan, int) (*apiv1beta1.ServiceInstance, error)
+	updateInstancePlanMutex       sync.RWMutex
+	updateInstancePlanArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 servicecatalog.Plan
+		arg4 int
+	}
+	updateInstancePlanReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
+	updateInstancePlanReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
 	WaitForInstanceStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceMutex       sync.RWMutex
 	waitForInstanceArgsForCall []struct {
@@ -565,6 +640,23 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
+	WaitForInstanceWithProgressStub        func(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error)
+	waitForInstanceWithProgressMutex       sync.RWMutex
+	waitForInstanceWithProgressArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+		arg4 *time.Duration
+		arg5 func(*apiv1beta1.ServiceInstance)
+	}
+	waitForInstanceWithProgressReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
+	waitForInstanceWithProgressReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
 	WaitForInstanceToNotExistStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceToNotExistMutex       sync.RWMutex
 	waitForInstanceToNotExistArgsForCall []struct {
@@ -581,11 +673,12 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
-	RetrievePlansStub        func(string, servicecatalog.ScopeOptions) ([]servicecatalog.Plan, error)
+	RetrievePlansStub        func(string, servicecatalog.ScopeOptions, string) ([]servicecatalog.Plan, error)
 	retrievePlansMutex       sync.RWMutex
 	retrievePlansArgsForCall []struct {
 		arg1 string
 		arg2 servicecatalog.ScopeOptions
+		arg3 string
 	}
 	retrievePlansReturns struct {
 		result1 []servicecatalog.Plan
@@ -653,6 +746,30 @@ type FakeSvcatClient struct {
 		result1 servicecatalog.Plan
 		result2 error
 	}
+	DeletePlanStub        func(servicecatalog.Plan) error
+	deletePlanMutex       sync.RWMutex
+	deletePlanArgsForCall []struct {
+		arg1 servicecatalog.Plan
+	}
+	deletePlanReturns struct {
+		result1 error
+	}
+	deletePlanReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RetrieveRemovedCatalogStub        func(servicecatalog.ScopeOptions) (*servicecatalog.RemovedCatalog, error)
+	retrieveRemovedCatalogMutex       sync.RWMutex
+	retrieveRemovedCatalogArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveRemovedCatalogReturns struct {
+		result1 *servicecatalog.RemovedCatalog
+		result2 error
+	}
+	retrieveRemovedCatalogReturnsOnCall map[int]struct {
+		result1 *servicecatalog.RemovedCatalog
+		result2 error
+	}
 	RetrieveSecretByBindingStub        func(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 	retrieveSecretByBindingMutex       sync.RWMutex
 	retrieveSecretByBindingArgsForCall []struct {
@@ -666,6 +783,37 @@ type FakeSvcatClient struct {
 		result1 *apicorev1.Secret
 		result2 error
 	}
+	RetrieveEventsStub        func(string, string, string) (*apicorev1.EventList, error)
+	retrieveEventsMutex       sync.RWMutex
+	retrieveEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	retrieveEventsReturns struct {
+		result1 *apicorev1.EventList
+		result2 error
+	}
+	retrieveEventsReturnsOnCall map[int]struct {
+		result1 *apicorev1.EventList
+		result2 error
+	}
+	WatchEventsStub        func(string, string, string, string) (watch.Interface, error)
+	watchEventsMutex       sync.RWMutex
+	watchEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	watchEventsReturns struct {
+		result1 watch.Interface
+		result2 error
+	}
+	watchEventsReturnsOnCall map[int]struct {
+		result1 watch.Interface
+		result2 error
+	}
 	ServerVersionStub        func() (*version.Info, error)
 	serverVersionMutex       sync.RWMutex
 	serverVersionArgsForCall []struct{}
@@ -677,6 +825,17 @@ type FakeSvcatClient struct {
 		result1 *version.Info
 		result2 error
 	}
+	ServerResourcesStub        func() (*metav1.APIResourceList, error)
+	serverResourcesMutex       sync.RWMutex
+	serverResourcesArgsForCall []struct{}
+	serverResourcesReturns     struct {
+		result1 *metav1.APIResourceList
+		result2 error
+	}
+	serverResourcesReturnsOnCall map[int]struct {
+		result1 *metav1.APIResourceList
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1051,16 +1210,17 @@ func (fake *FakeSvcatClient) RetrieveBindingReturnsOnCall(i int, result1 *apiv1b
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrieveBindings(arg1 string) (*apiv1beta1.ServiceBindingList, error) {
+func (fake *FakeSvcatClient) RetrieveBindings(arg1 string, arg2 string) (*apiv1beta1.ServiceBindingList, error) {
 	fake.retrieveBindingsMutex.Lock()
 	ret, specificReturn := fake.retrieveBindingsReturnsOnCall[len(fake.retrieveBindingsArgsForCall)]
 	fake.retrieveBindingsArgsForCall = append(fake.retrieveBindingsArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	fake.recordInvocation("RetrieveBindings", []interface{}{arg1})
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveBindings", []interface{}{arg1, arg2})
 	fake.retrieveBindingsMutex.Unlock()
 	if fake.RetrieveBindingsStub != nil {
-		return fake.RetrieveBindingsStub(arg1)
+		return fake.RetrieveBindingsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1074,10 +1234,10 @@ func (fake *FakeSvcatClient) RetrieveBindingsCallCount() int {
 	return len(fake.retrieveBindingsArgsForCall)
 }
 
-func (fake *FakeSvcatClient) RetrieveBindingsArgsForCall(i int) string {
+func (fake *FakeSvcatClient) RetrieveBindingsArgsForCall(i int) (string, string) {
 	fake.retrieveBindingsMutex.RLock()
 	defer fake.retrieveBindingsMutex.RUnlock()
-	return fake.retrieveBindingsArgsForCall[i].arg1
+	return fake.retrieveBindingsArgsForCall[i].arg1, fake.retrieveBindingsArgsForCall[i].arg2
 }
 
 func (fake *FakeSvcatClient) RetrieveBindingsReturns(result1 *apiv1beta1.ServiceBindingList, result2 error) {
@@ -1616,6 +1776,58 @@ func (fake *FakeSvcatClient) RetrieveBrokerByIDReturnsOnCall(i int, result1 serv
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveBrokerMetrics(arg1 servicecatalog.Broker, arg2 servicecatalog.MetricsOptions) (*servicecatalog.BrokerMetrics, error) {
+	fake.retrieveBrokerMetricsMutex.Lock()
+	ret, specificReturn := fake.retrieveBrokerMetricsReturnsOnCall[len(fake.retrieveBrokerMetricsArgsForCall)]
+	fake.retrieveBrokerMetricsArgsForCall = append(fake.retrieveBrokerMetricsArgsForCall, struct {
+		arg1 servicecatalog.Broker
+		arg2 servicecatalog.MetricsOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveBrokerMetrics", []interface{}{arg1, arg2})
+	fake.retrieveBrokerMetricsMutex.Unlock()
+	if fake.RetrieveBrokerMetricsStub != nil {
+		return fake.RetrieveBrokerMetricsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveBrokerMetricsReturns.result1, fake.retrieveBrokerMetricsReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerMetricsCallCount() int {
+	fake.retrieveBrokerMetricsMutex.RLock()
+	defer fake.retrieveBrokerMetricsMutex.RUnlock()
+	return len(fake.retrieveBrokerMetricsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerMetricsArgsForCall(i int) (servicecatalog.Broker, servicecatalog.MetricsOptions) {
+	fake.retrieveBrokerMetricsMutex.RLock()
+	defer fake.retrieveBrokerMetricsMutex.RUnlock()
+	return fake.retrieveBrokerMetricsArgsForCall[i].arg1, fake.retrieveBrokerMetricsArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerMetricsReturns(result1 *servicecatalog.BrokerMetrics, result2 error) {
+	fake.RetrieveBrokerMetricsStub = nil
+	fake.retrieveBrokerMetricsReturns = struct {
+		result1 *servicecatalog.BrokerMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerMetricsReturnsOnCall(i int, result1 *servicecatalog.BrokerMetrics, result2 error) {
+	fake.RetrieveBrokerMetricsStub = nil
+	if fake.retrieveBrokerMetricsReturnsOnCall == nil {
+		fake.retrieveBrokerMetricsReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.BrokerMetrics
+			result2 error
+		})
+	}
+	fake.retrieveBrokerMetricsReturnsOnCall[i] = struct {
+		result1 *servicecatalog.BrokerMetrics
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveBrokerByClass(arg1 *apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error) {
 	fake.retrieveBrokerByClassMutex.Lock()
 	ret, specificReturn := fake.retrieveBrokerByClassReturnsOnCall[len(fake.retrieveBrokerByClassArgsForCall)]
@@ -1771,6 +1983,59 @@ func (fake *FakeSvcatClient) SyncReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) ValidateBroker(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions) (*servicecatalog.BrokerCatalogSummary, error) {
+	fake.validateBrokerMutex.Lock()
+	ret, specificReturn := fake.validateBrokerReturnsOnCall[len(fake.validateBrokerArgsForCall)]
+	fake.validateBrokerArgsForCall = append(fake.validateBrokerArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.RegisterOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ValidateBroker", []interface{}{arg1, arg2, arg3})
+	fake.validateBrokerMutex.Unlock()
+	if fake.ValidateBrokerStub != nil {
+		return fake.ValidateBrokerStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.validateBrokerReturns.result1, fake.validateBrokerReturns.result2
+}
+
+func (fake *FakeSvcatClient) ValidateBrokerCallCount() int {
+	fake.validateBrokerMutex.RLock()
+	defer fake.validateBrokerMutex.RUnlock()
+	return len(fake.validateBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ValidateBrokerArgsForCall(i int) (string, string, *servicecatalog.RegisterOptions) {
+	fake.validateBrokerMutex.RLock()
+	defer fake.validateBrokerMutex.RUnlock()
+	return fake.validateBrokerArgsForCall[i].arg1, fake.validateBrokerArgsForCall[i].arg2, fake.validateBrokerArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) ValidateBrokerReturns(result1 *servicecatalog.BrokerCatalogSummary, result2 error) {
+	fake.ValidateBrokerStub = nil
+	fake.validateBrokerReturns = struct {
+		result1 *servicecatalog.BrokerCatalogSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) ValidateBrokerReturnsOnCall(i int, result1 *servicecatalog.BrokerCatalogSummary, result2 error) {
+	fake.ValidateBrokerStub = nil
+	if fake.validateBrokerReturnsOnCall == nil {
+		fake.validateBrokerReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.BrokerCatalogSummary
+			result2 error
+		})
+	}
+	fake.validateBrokerReturnsOnCall[i] = struct {
+		result1 *servicecatalog.BrokerCatalogSummary
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForBroker(arg1 string, arg2 *servicecatalog.ScopeOptions, arg3 time.Duration, arg4 *time.Duration) (servicecatalog.Broker, error) {
 	fake.waitForBrokerMutex.Lock()
 	ret, specificReturn := fake.waitForBrokerReturnsOnCall[len(fake.waitForBrokerArgsForCall)]
@@ -1825,16 +2090,17 @@ func (fake *FakeSvcatClient) WaitForBrokerReturnsOnCall(i int, result1 serviceca
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrieveClasses(arg1 servicecatalog.ScopeOptions) ([]servicecatalog.Class, error) {
+func (fake *FakeSvcatClient) RetrieveClasses(arg1 servicecatalog.ScopeOptions, arg2 string) ([]servicecatalog.Class, error) {
 	fake.retrieveClassesMutex.Lock()
 	ret, specificReturn := fake.retrieveClassesReturnsOnCall[len(fake.retrieveClassesArgsForCall)]
 	fake.retrieveClassesArgsForCall = append(fake.retrieveClassesArgsForCall, struct {
 		arg1 servicecatalog.ScopeOptions
-	}{arg1})
-	fake.recordInvocation("RetrieveClasses", []interface{}{arg1})
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveClasses", []interface{}{arg1, arg2})
 	fake.retrieveClassesMutex.Unlock()
 	if fake.RetrieveClassesStub != nil {
-		return fake.RetrieveClassesStub(arg1)
+		return fake.RetrieveClassesStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1848,10 +2114,10 @@ func (fake *FakeSvcatClient) RetrieveClassesCallCount() int {
 	return len(fake.retrieveClassesArgsForCall)
 }
 
-func (fake *FakeSvcatClient) RetrieveClassesArgsForCall(i int) servicecatalog.ScopeOptions {
+func (fake *FakeSvcatClient) RetrieveClassesArgsForCall(i int) (servicecatalog.ScopeOptions, string) {
 	fake.retrieveClassesMutex.RLock()
 	defer fake.retrieveClassesMutex.RUnlock()
-	return fake.retrieveClassesArgsForCall[i].arg1
+	return fake.retrieveClassesArgsForCall[i].arg1, fake.retrieveClassesArgsForCall[i].arg2
 }
 
 func (fake *FakeSvcatClient) RetrieveClassesReturns(result1 []servicecatalog.Class, result2 error) {
@@ -1980,6 +2246,58 @@ func (fake *FakeSvcatClient) RetrieveClassByIDReturnsOnCall(i int, result1 servi
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveClassByExternalID(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Class, error) {
+	fake.retrieveClassByExternalIDMutex.Lock()
+	ret, specificReturn := fake.retrieveClassByExternalIDReturnsOnCall[len(fake.retrieveClassByExternalIDArgsForCall)]
+	fake.retrieveClassByExternalIDArgsForCall = append(fake.retrieveClassByExternalIDArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveClassByExternalID", []interface{}{arg1, arg2})
+	fake.retrieveClassByExternalIDMutex.Unlock()
+	if fake.RetrieveClassByExternalIDStub != nil {
+		return fake.RetrieveClassByExternalIDStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveClassByExternalIDReturns.result1, fake.retrieveClassByExternalIDReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveClassByExternalIDCallCount() int {
+	fake.retrieveClassByExternalIDMutex.RLock()
+	defer fake.retrieveClassByExternalIDMutex.RUnlock()
+	return len(fake.retrieveClassByExternalIDArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveClassByExternalIDArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrieveClassByExternalIDMutex.RLock()
+	defer fake.retrieveClassByExternalIDMutex.RUnlock()
+	return fake.retrieveClassByExternalIDArgsForCall[i].arg1, fake.retrieveClassByExternalIDArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveClassByExternalIDReturns(result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveClassByExternalIDStub = nil
+	fake.retrieveClassByExternalIDReturns = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveClassByExternalIDReturnsOnCall(i int, result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveClassByExternalIDStub = nil
+	if fake.retrieveClassByExternalIDReturnsOnCall == nil {
+		fake.retrieveClassByExternalIDReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Class
+			result2 error
+		})
+	}
+	fake.retrieveClassByExternalIDReturnsOnCall[i] = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveClassByPlan(arg1 servicecatalog.Plan) (servicecatalog.Class, error) {
 	fake.retrieveClassByPlanMutex.Lock()
 	ret, specificReturn := fake.retrieveClassByPlanReturnsOnCall[len(fake.retrieveClassByPlanArgsForCall)]
@@ -2082,6 +2400,54 @@ func (fake *FakeSvcatClient) CreateClassFromReturnsOnCall(i int, result1 service
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) DeleteClass(arg1 servicecatalog.Class) error {
+	fake.deleteClassMutex.Lock()
+	ret, specificReturn := fake.deleteClassReturnsOnCall[len(fake.deleteClassArgsForCall)]
+	fake.deleteClassArgsForCall = append(fake.deleteClassArgsForCall, struct {
+		arg1 servicecatalog.Class
+	}{arg1})
+	fake.recordInvocation("DeleteClass", []interface{}{arg1})
+	fake.deleteClassMutex.Unlock()
+	if fake.DeleteClassStub != nil {
+		return fake.DeleteClassStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteClassReturns.result1
+}
+
+func (fake *FakeSvcatClient) DeleteClassCallCount() int {
+	fake.deleteClassMutex.RLock()
+	defer fake.deleteClassMutex.RUnlock()
+	return len(fake.deleteClassArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DeleteClassArgsForCall(i int) servicecatalog.Class {
+	fake.deleteClassMutex.RLock()
+	defer fake.deleteClassMutex.RUnlock()
+	return fake.deleteClassArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) DeleteClassReturns(result1 error) {
+	fake.DeleteClassStub = nil
+	fake.deleteClassReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) DeleteClassReturnsOnCall(i int, result1 error) {
+	fake.DeleteClassStub = nil
+	if fake.deleteClassReturnsOnCall == nil {
+		fake.deleteClassReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteClassReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSvcatClient) Deprovision(arg1 string, arg2 string) error {
 	fake.deprovisionMutex.Lock()
 	ret, specificReturn := fake.deprovisionReturnsOnCall[len(fake.deprovisionArgsForCall)]
@@ -2496,18 +2862,19 @@ func (fake *FakeSvcatClient) RetrieveInstanceByBindingReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrieveInstances(arg1 string, arg2 string, arg3 string) (*apiv1beta1.ServiceInstanceList, error) {
+func (fake *FakeSvcatClient) RetrieveInstances(arg1 string, arg2 string, arg3 string, arg4 string) (*apiv1beta1.ServiceInstanceList, error) {
 	fake.retrieveInstancesMutex.Lock()
 	ret, specificReturn := fake.retrieveInstancesReturnsOnCall[len(fake.retrieveInstancesArgsForCall)]
 	fake.retrieveInstancesArgsForCall = append(fake.retrieveInstancesArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("RetrieveInstances", []interface{}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RetrieveInstances", []interface{}{arg1, arg2, arg3, arg4})
 	fake.retrieveInstancesMutex.Unlock()
 	if fake.RetrieveInstancesStub != nil {
-		return fake.RetrieveInstancesStub(arg1, arg2, arg3)
+		return fake.RetrieveInstancesStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2521,10 +2888,10 @@ func (fake *FakeSvcatClient) RetrieveInstancesCallCount() int {
 	return len(fake.retrieveInstancesArgsForCall)
 }
 
-func (fake *FakeSvcatClient) RetrieveInstancesArgsForCall(i int) (string, string, string) {
+func (fake *FakeSvcatClient) RetrieveInstancesArgsForCall(i int) (string, string, string, string) {
 	fake.retrieveInstancesMutex.RLock()
 	defer fake.retrieveInstancesMutex.RUnlock()
-	return fake.retrieveInstancesArgsForCall[i].arg1, fake.retrieveInstancesArgsForCall[i].arg2, fake.retrieveInstancesArgsForCall[i].arg3
+	return fake.retrieveInstancesArgsForCall[i].arg1, fake.retrieveInstancesArgsForCall[i].arg2, fake.retrieveInstancesArgsForCall[i].arg3, fake.retrieveInstancesArgsForCall[i].arg4
 }
 
 func (fake *FakeSvcatClient) RetrieveInstancesReturns(result1 *apiv1beta1.ServiceInstanceList, result2 error) {
@@ -2650,6 +3017,60 @@ func (fake *FakeSvcatClient) TouchInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) UpdateInstancePlan(arg1 string, arg2 string, arg3 servicecatalog.Plan, arg4 int) (*apiv1beta1.ServiceInstance, error) {
+	fake.updateInstancePlanMutex.Lock()
+	ret, specificReturn := fake.updateInstancePlanReturnsOnCall[len(fake.updateInstancePlanArgsForCall)]
+	fake.updateInstancePlanArgsForCall = append(fake.updateInstancePlanArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 servicecatalog.Plan
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("UpdateInstancePlan", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateInstancePlanMutex.Unlock()
+	if fake.UpdateInstancePlanStub != nil {
+		return fake.UpdateInstancePlanStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.updateInstancePlanReturns.result1, fake.updateInstancePlanReturns.result2
+}
+
+func (fake *FakeSvcatClient) UpdateInstancePlanCallCount() int {
+	fake.updateInstancePlanMutex.RLock()
+	defer fake.updateInstancePlanMutex.RUnlock()
+	return len(fake.updateInstancePlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) UpdateInstancePlanArgsForCall(i int) (string, string, servicecatalog.Plan, int) {
+	fake.updateInstancePlanMutex.RLock()
+	defer fake.updateInstancePlanMutex.RUnlock()
+	return fake.updateInstancePlanArgsForCall[i].arg1, fake.updateInstancePlanArgsForCall[i].arg2, fake.updateInstancePlanArgsForCall[i].arg3, fake.updateInstancePlanArgsForCall[i].arg4
+}
+
+func (fake *FakeSvcatClient) UpdateInstancePlanReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstancePlanStub = nil
+	fake.updateInstancePlanReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) UpdateInstancePlanReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstancePlanStub = nil
+	if fake.updateInstancePlanReturnsOnCall == nil {
+		fake.updateInstancePlanReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 error
+		})
+	}
+	fake.updateInstancePlanReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstance(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceReturnsOnCall[len(fake.waitForInstanceArgsForCall)]
@@ -2704,6 +3125,61 @@ func (fake *FakeSvcatClient) WaitForInstanceReturnsOnCall(i int, result1 *apiv1b
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) WaitForInstanceWithProgress(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration, arg5 func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error) {
+	fake.waitForInstanceWithProgressMutex.Lock()
+	ret, specificReturn := fake.waitForInstanceWithProgressReturnsOnCall[len(fake.waitForInstanceWithProgressArgsForCall)]
+	fake.waitForInstanceWithProgressArgsForCall = append(fake.waitForInstanceWithProgressArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+		arg4 *time.Duration
+		arg5 func(*apiv1beta1.ServiceInstance)
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("WaitForInstanceWithProgress", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.waitForInstanceWithProgressMutex.Unlock()
+	if fake.WaitForInstanceWithProgressStub != nil {
+		return fake.WaitForInstanceWithProgressStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.waitForInstanceWithProgressReturns.result1, fake.waitForInstanceWithProgressReturns.result2
+}
+
+func (fake *FakeSvcatClient) WaitForInstanceWithProgressCallCount() int {
+	fake.waitForInstanceWithProgressMutex.RLock()
+	defer fake.waitForInstanceWithProgressMutex.RUnlock()
+	return len(fake.waitForInstanceWithProgressArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WaitForInstanceWithProgressArgsForCall(i int) (string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) {
+	fake.waitForInstanceWithProgressMutex.RLock()
+	defer fake.waitForInstanceWithProgressMutex.RUnlock()
+	return fake.waitForInstanceWithProgressArgsForCall[i].arg1, fake.waitForInstanceWithProgressArgsForCall[i].arg2, fake.waitForInstanceWithProgressArgsForCall[i].arg3, fake.waitForInstanceWithProgressArgsForCall[i].arg4, fake.waitForInstanceWithProgressArgsForCall[i].arg5
+}
+
+func (fake *FakeSvcatClient) WaitForInstanceWithProgressReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.WaitForInstanceWithProgressStub = nil
+	fake.waitForInstanceWithProgressReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WaitForInstanceWithProgressReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.WaitForInstanceWithProgressStub = nil
+	if fake.waitForInstanceWithProgressReturnsOnCall == nil {
+		fake.waitForInstanceWithProgressReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 error
+		})
+	}
+	fake.waitForInstanceWithProgressReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceToNotExistMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceToNotExistReturnsOnCall[len(fake.waitForInstanceToNotExistArgsForCall)]
@@ -2758,17 +3234,18 @@ func (fake *FakeSvcatClient) WaitForInstanceToNotExistReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) RetrievePlans(arg1 string, arg2 servicecatalog.ScopeOptions) ([]servicecatalog.Plan, error) {
+func (fake *FakeSvcatClient) RetrievePlans(arg1 string, arg2 servicecatalog.ScopeOptions, arg3 string) ([]servicecatalog.Plan, error) {
 	fake.retrievePlansMutex.Lock()
 	ret, specificReturn := fake.retrievePlansReturnsOnCall[len(fake.retrievePlansArgsForCall)]
 	fake.retrievePlansArgsForCall = append(fake.retrievePlansArgsForCall, struct {
 		arg1 string
 		arg2 servicecatalog.ScopeOptions
-	}{arg1, arg2})
-	fake.recordInvocation("RetrievePlans", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RetrievePlans", []interface{}{arg1, arg2, arg3})
 	fake.retrievePlansMutex.Unlock()
 	if fake.RetrievePlansStub != nil {
-		return fake.RetrievePlansStub(arg1, arg2)
+		return fake.RetrievePlansStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2782,10 +3259,10 @@ func (fake *FakeSvcatClient) RetrievePlansCallCount() int {
 	return len(fake.retrievePlansArgsForCall)
 }
 
-func (fake *FakeSvcatClient) RetrievePlansArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+func (fake *FakeSvcatClient) RetrievePlansArgsForCall(i int) (string, servicecatalog.ScopeOptions, string) {
 	fake.retrievePlansMutex.RLock()
 	defer fake.retrievePlansMutex.RUnlock()
-	return fake.retrievePlansArgsForCall[i].arg1, fake.retrievePlansArgsForCall[i].arg2
+	return fake.retrievePlansArgsForCall[i].arg1, fake.retrievePlansArgsForCall[i].arg2, fake.retrievePlansArgsForCall[i].arg3
 }
 
 func (fake *FakeSvcatClient) RetrievePlansReturns(result1 []servicecatalog.Plan, result2 error) {
@@ -3020,6 +3497,105 @@ func (fake *FakeSvcatClient) RetrievePlanByIDReturnsOnCall(i int, result1 servic
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) DeletePlan(arg1 servicecatalog.Plan) error {
+	fake.deletePlanMutex.Lock()
+	ret, specificReturn := fake.deletePlanReturnsOnCall[len(fake.deletePlanArgsForCall)]
+	fake.deletePlanArgsForCall = append(fake.deletePlanArgsForCall, struct {
+		arg1 servicecatalog.Plan
+	}{arg1})
+	fake.recordInvocation("DeletePlan", []interface{}{arg1})
+	fake.deletePlanMutex.Unlock()
+	if fake.DeletePlanStub != nil {
+		return fake.DeletePlanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deletePlanReturns.result1
+}
+
+func (fake *FakeSvcatClient) DeletePlanCallCount() int {
+	fake.deletePlanMutex.RLock()
+	defer fake.deletePlanMutex.RUnlock()
+	return len(fake.deletePlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DeletePlanArgsForCall(i int) servicecatalog.Plan {
+	fake.deletePlanMutex.RLock()
+	defer fake.deletePlanMutex.RUnlock()
+	return fake.deletePlanArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) DeletePlanReturns(result1 error) {
+	fake.DeletePlanStub = nil
+	fake.deletePlanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) DeletePlanReturnsOnCall(i int, result1 error) {
+	fake.DeletePlanStub = nil
+	if fake.deletePlanReturnsOnCall == nil {
+		fake.deletePlanReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePlanReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) RetrieveRemovedCatalog(arg1 servicecatalog.ScopeOptions) (*servicecatalog.RemovedCatalog, error) {
+	fake.retrieveRemovedCatalogMutex.Lock()
+	ret, specificReturn := fake.retrieveRemovedCatalogReturnsOnCall[len(fake.retrieveRemovedCatalogArgsForCall)]
+	fake.retrieveRemovedCatalogArgsForCall = append(fake.retrieveRemovedCatalogArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveRemovedCatalog", []interface{}{arg1})
+	fake.retrieveRemovedCatalogMutex.Unlock()
+	if fake.RetrieveRemovedCatalogStub != nil {
+		return fake.RetrieveRemovedCatalogStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveRemovedCatalogReturns.result1, fake.retrieveRemovedCatalogReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveRemovedCatalogCallCount() int {
+	fake.retrieveRemovedCatalogMutex.RLock()
+	defer fake.retrieveRemovedCatalogMutex.RUnlock()
+	return len(fake.retrieveRemovedCatalogArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveRemovedCatalogArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveRemovedCatalogMutex.RLock()
+	defer fake.retrieveRemovedCatalogMutex.RUnlock()
+	return fake.retrieveRemovedCatalogArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveRemovedCatalogReturns(result1 *servicecatalog.RemovedCatalog, result2 error) {
+	fake.RetrieveRemovedCatalogStub = nil
+	fake.retrieveRemovedCatalogReturns = struct {
+		result1 *servicecatalog.RemovedCatalog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveRemovedCatalogReturnsOnCall(i int, result1 *servicecatalog.RemovedCatalog, result2 error) {
+	fake.RetrieveRemovedCatalogStub = nil
+	if fake.retrieveRemovedCatalogReturnsOnCall == nil {
+		fake.retrieveRemovedCatalogReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.RemovedCatalog
+			result2 error
+		})
+	}
+	fake.retrieveRemovedCatalogReturnsOnCall[i] = struct {
+		result1 *servicecatalog.RemovedCatalog
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveSecretByBinding(arg1 *apiv1beta1.ServiceBinding) (*apicorev1.Secret, error) {
 	fake.retrieveSecretByBindingMutex.Lock()
 	ret, specificReturn := fake.retrieveSecretByBindingReturnsOnCall[len(fake.retrieveSecretByBindingArgsForCall)]
@@ -3071,6 +3647,113 @@ func (fake *FakeSvcatClient) RetrieveSecretByBindingReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveEvents(arg1 string, arg2 string, arg3 string) (*apicorev1.EventList, error) {
+	fake.retrieveEventsMutex.Lock()
+	ret, specificReturn := fake.retrieveEventsReturnsOnCall[len(fake.retrieveEventsArgsForCall)]
+	fake.retrieveEventsArgsForCall = append(fake.retrieveEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RetrieveEvents", []interface{}{arg1, arg2, arg3})
+	fake.retrieveEventsMutex.Unlock()
+	if fake.RetrieveEventsStub != nil {
+		return fake.RetrieveEventsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveEventsReturns.result1, fake.retrieveEventsReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsCallCount() int {
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
+	return len(fake.retrieveEventsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsArgsForCall(i int) (string, string, string) {
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
+	return fake.retrieveEventsArgsForCall[i].arg1, fake.retrieveEventsArgsForCall[i].arg2, fake.retrieveEventsArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsReturns(result1 *apicorev1.EventList, result2 error) {
+	fake.RetrieveEventsStub = nil
+	fake.retrieveEventsReturns = struct {
+		result1 *apicorev1.EventList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsReturnsOnCall(i int, result1 *apicorev1.EventList, result2 error) {
+	fake.RetrieveEventsStub = nil
+	if fake.retrieveEventsReturnsOnCall == nil {
+		fake.retrieveEventsReturnsOnCall = make(map[int]struct {
+			result1 *apicorev1.EventList
+			result2 error
+		})
+	}
+	fake.retrieveEventsReturnsOnCall[i] = struct {
+		result1 *apicorev1.EventList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchEvents(arg1 string, arg2 string, arg3 string, arg4 string) (watch.Interface, error) {
+	fake.watchEventsMutex.Lock()
+	ret, specificReturn := fake.watchEventsReturnsOnCall[len(fake.watchEventsArgsForCall)]
+	fake.watchEventsArgsForCall = append(fake.watchEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("WatchEvents", []interface{}{arg1, arg2, arg3, arg4})
+	fake.watchEventsMutex.Unlock()
+	if fake.WatchEventsStub != nil {
+		return fake.WatchEventsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.watchEventsReturns.result1, fake.watchEventsReturns.result2
+}
+
+func (fake *FakeSvcatClient) WatchEventsCallCount() int {
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
+	return len(fake.watchEventsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WatchEventsArgsForCall(i int) (string, string, string, string) {
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
+	return fake.watchEventsArgsForCall[i].arg1, fake.watchEventsArgsForCall[i].arg2, fake.watchEventsArgsForCall[i].arg3, fake.watchEventsArgsForCall[i].arg4
+}
+
+func (fake *FakeSvcatClient) WatchEventsReturns(result1 watch.Interface, result2 error) {
+	fake.WatchEventsStub = nil
+	fake.watchEventsReturns = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchEventsReturnsOnCall(i int, result1 watch.Interface, result2 error) {
+	fake.WatchEventsStub = nil
+	if fake.watchEventsReturnsOnCall == nil {
+		fake.watchEventsReturnsOnCall = make(map[int]struct {
+			result1 watch.Interface
+			result2 error
+		})
+	}
+	fake.watchEventsReturnsOnCall[i] = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) ServerVersion() (*version.Info, error) {
 	fake.serverVersionMutex.Lock()
 	ret, specificReturn := fake.serverVersionReturnsOnCall[len(fake.serverVersionArgsForCall)]
@@ -3114,6 +3797,49 @@ func (fake *FakeSvcatClient) ServerVersionReturnsOnCall(i int, result1 *version.
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) ServerResources() (*metav1.APIResourceList, error) {
+	fake.serverResourcesMutex.Lock()
+	ret, specificReturn := fake.serverResourcesReturnsOnCall[len(fake.serverResourcesArgsForCall)]
+	fake.serverResourcesArgsForCall = append(fake.serverResourcesArgsForCall, struct{}{})
+	fake.recordInvocation("ServerResources", []interface{}{})
+	fake.serverResourcesMutex.Unlock()
+	if fake.ServerResourcesStub != nil {
+		return fake.ServerResourcesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.serverResourcesReturns.result1, fake.serverResourcesReturns.result2
+}
+
+func (fake *FakeSvcatClient) ServerResourcesCallCount() int {
+	fake.serverResourcesMutex.RLock()
+	defer fake.serverResourcesMutex.RUnlock()
+	return len(fake.serverResourcesArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ServerResourcesReturns(result1 *metav1.APIResourceList, result2 error) {
+	fake.ServerResourcesStub = nil
+	fake.serverResourcesReturns = struct {
+		result1 *metav1.APIResourceList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) ServerResourcesReturnsOnCall(i int, result1 *metav1.APIResourceList, result2 error) {
+	fake.ServerResourcesStub = nil
+	if fake.serverResourcesReturnsOnCall == nil {
+		fake.serverResourcesReturnsOnCall = make(map[int]struct {
+			result1 *metav1.APIResourceList
+			result2 error
+		})
+	}
+	fake.serverResourcesReturnsOnCall[i] = struct {
+		result1 *metav1.APIResourceList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3153,12 +3879,16 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBrokersMutex.RUnlock()
 	fake.retrieveBrokerByIDMutex.RLock()
 	defer fake.retrieveBrokerByIDMutex.RUnlock()
+	fake.retrieveBrokerMetricsMutex.RLock()
+	defer fake.retrieveBrokerMetricsMutex.RUnlock()
 	fake.retrieveBrokerByClassMutex.RLock()
 	defer fake.retrieveBrokerByClassMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
 	fake.syncMutex.RLock()
 	defer fake.syncMutex.RUnlock()
+	fake.validateBrokerMutex.RLock()
+	defer fake.validateBrokerMutex.RUnlock()
 	fake.waitForBrokerMutex.RLock()
 	defer fake.waitForBrokerMutex.RUnlock()
 	fake.retrieveClassesMutex.RLock()
@@ -3167,10 +3897,14 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveClassByNameMutex.RUnlock()
 	fake.retrieveClassByIDMutex.RLock()
 	defer fake.retrieveClassByIDMutex.RUnlock()
+	fake.retrieveClassByExternalIDMutex.RLock()
+	defer fake.retrieveClassByExternalIDMutex.RUnlock()
 	fake.retrieveClassByPlanMutex.RLock()
 	defer fake.retrieveClassByPlanMutex.RUnlock()
 	fake.createClassFromMutex.RLock()
 	defer fake.createClassFromMutex.RUnlock()
+	fake.deleteClassMutex.RLock()
+	defer fake.deleteClassMutex.RUnlock()
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
 	fake.instanceParentHierarchyMutex.RLock()
@@ -3193,8 +3927,12 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveInstancesByPlanMutex.RUnlock()
 	fake.touchInstanceMutex.RLock()
 	defer fake.touchInstanceMutex.RUnlock()
+	fake.updateInstancePlanMutex.RLock()
+	defer fake.updateInstancePlanMutex.RUnlock()
 	fake.waitForInstanceMutex.RLock()
 	defer fake.waitForInstanceMutex.RUnlock()
+	fake.waitForInstanceWithProgressMutex.RLock()
+	defer fake.waitForInstanceWithProgressMutex.RUnlock()
 	fake.waitForInstanceToNotExistMutex.RLock()
 	defer fake.waitForInstanceToNotExistMutex.RUnlock()
 	fake.retrievePlansMutex.RLock()
@@ -3207,10 +3945,20 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrievePlanByClassIDAndNameMutex.RUnlock()
 	fake.retrievePlanByIDMutex.RLock()
 	defer fake.retrievePlanByIDMutex.RUnlock()
+	fake.deletePlanMutex.RLock()
+	defer fake.deletePlanMutex.RUnlock()
+	fake.retrieveRemovedCatalogMutex.RLock()
+	defer fake.retrieveRemovedCatalogMutex.RUnlock()
 	fake.retrieveSecretByBindingMutex.RLock()
 	defer fake.retrieveSecretByBindingMutex.RUnlock()
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
 	fake.serverVersionMutex.RLock()
 	defer fake.serverVersionMutex.RUnlock()
+	fake.serverResourcesMutex.RLock()
+	defer fake.serverResourcesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -19,6 +19,8 @@ package servicecatalog
 import (
 	"fmt"
 
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 )
 
@@ -31,3 +33,14 @@ func (sdk *SDK) ServerVersion() (*version.Info, error) {
 
 	return serverVersion, nil
 }
+
+// ServerResources asks the API Server for the resources of the Service Catalog API and returns them.
+// It fails if the Service Catalog API is not reachable through the API Server.
+func (sdk *SDK) ServerResources() (*metav1.APIResourceList, error) {
+	resources, err := sdk.ServiceCatalogClient.Discovery().ServerResourcesForGroupVersion(v1beta1.SchemeGroupVersion.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the resources of %s, %v", v1beta1.SchemeGroupVersion, err)
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/klog"
+)
+
+const (
+	// scopeName is the name of the instrumentation scope of the spans.
+	scopeName = "github.com/kubernetes-sigs/service-catalog"
+
+	// maxQueueSize is the number of ended spans waiting to be exported
+	// beyond which new spans are dropped.
+	maxQueueSize = 2048
+	// maxBatchSize is the maximum number of spans of a request to the
+	// endpoint.
+	maxBatchSize = 512
+	// exportInterval is how often the ended spans are exported.
+	exportInterval = 5 * time.Second
+	// exportTimeout is the timeout of a request to the endpoint.
+	exportTimeout = 10 * time.Second
+
+	statusCodeError = 2
+)
+
+// exporter sends the ended spans to an OTLP/HTTP endpoint in batches.
+type exporter struct {
+	serviceName string
+	endpoint    string
+	httpClient  *http.Client
+
+	queue chan *Span
+}
+
+func newExporter(serviceName, endpoint string) *exporter {
+	return &exporter{
+		serviceName: serviceName,
+		endpoint:    endpoint,
+		httpClient:  &http.Client{Timeout: exportTimeout},
+		queue:       make(chan *Span, maxQueueSize),
+	}
+}
+
+// add queues an ended span, or drops it when the queue is full so that an
+// unreachable endpoint does not slow down the controller.
+func (e *exporter) add(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+		klog.V(4).Infof("Dropping span %q, the queue of the spans to export is full", s.name)
+	}
+}
+
+// run exports the queued spans every exportInterval, or as soon as a batch
+// is full, until stopCh is closed.
+func (e *exporter) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			klog.Warningf("Error exporting %d spans to %s: %v", len(batch), e.endpoint, err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) == maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-stopCh:
+			for {
+				select {
+				case s := <-e.queue:
+					batch = append(batch, s)
+					if len(batch) == maxBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends a batch of spans to the endpoint.
+func (e *exporter) export(spans []*Span) error {
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return err
+	}
+	response, err := e.httpClient.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("the endpoint returned status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// The types below are the subset of the JSON encoding of the
+// ExportTraceServiceRequest of OTLP used by the exporter.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanData `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanData struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func (e *exporter) request(spans []*Span) *exportRequest {
+	data := make([]spanData, 0, len(spans))
+	for _, s := range spans {
+		data = append(data, s.data())
+	}
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: []keyValue{toKeyValue(String("service.name", e.serviceName))},
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName},
+				Spans: data,
+			}},
+		}},
+	}
+}
+
+func (s *Span) data() spanData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := spanData{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parentSpanID != [8]byte{} {
+		d.ParentSpanID = hex.EncodeToString(s.parentSpanID[:])
+	}
+	for _, a := range s.attributes {
+		d.Attributes = append(d.Attributes, toKeyValue(a))
+	}
+	if s.err != nil {
+		d.Status = status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return d
+}
+
+// toKeyValue encodes an attribute; the integers are encoded as strings, as
+// required for 64-bit integers by the JSON encoding of OTLP.
+func toKeyValue(a Attribute) keyValue {
+	kv := keyValue{Key: a.Key}
+	switch v := a.Value.(type) {
+	case int64:
+		i := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &i
+	default:
+		str := fmt.Sprint(v)
+		kv.Value.StringValue = &str
+	}
+	return kv
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records the spans of the work of the controller and
+// exports them to an OpenTelemetry collector with the OTLP/HTTP protocol,
+// encoded as JSON.
+//
+// A nil *Tracer is a valid tracer which records nothing, and the methods of a
+// nil *Span do nothing, so that the callers do not have to check whether
+// tracing is enabled.
+package tracing
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SpanKind is the kind of a span, as defined by OpenTelemetry.
+type SpanKind int
+
+const (
+	// SpanKindInternal is the kind of the spans of an operation of the
+	// controller itself.
+	SpanKindInternal SpanKind = 1
+	// SpanKindClient is the kind of the spans of a request to another
+	// service, e.g. a broker.
+	SpanKindClient SpanKind = 3
+)
+
+// Attribute is a key and a value describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns an attribute holding a string.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an attribute holding an integer.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Tracer starts spans and exports the sampled ones to an OTLP endpoint.
+type Tracer struct {
+	// samplingThreshold is compared to the trace ID of a new trace to
+	// decide whether it is sampled.
+	samplingThreshold uint64
+	exporter          *exporter
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+}
+
+// NewTracer returns a tracer exporting the spans of serviceName to the OTLP
+// endpoint, e.g. http://otel-collector:4318. The spans are sent to the
+// /v1/traces path of the endpoint, unless the endpoint has a path already.
+// samplingRatio is the ratio of the traces that are sampled, from 0 to 1.
+// It returns a nil tracer, which records nothing, when endpoint is empty.
+func NewTracer(serviceName, endpoint string, samplingRatio float64) (*Tracer, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+	if samplingRatio < 0 || samplingRatio > 1 {
+		return nil, fmt.Errorf("invalid tracing sampling ratio %v, it must be between 0 and 1", samplingRatio)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: %v", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid tracing endpoint %q, its scheme must be http or https", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+
+	var seed int64
+	binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return &Tracer{
+		samplingThreshold: samplingThreshold(samplingRatio),
+		exporter:          newExporter(serviceName, u.String()),
+		rand:              rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// samplingThreshold returns the threshold below which the trace IDs are
+// sampled, compared to the last 63 bits of the IDs like the TraceIDRatioBased
+// sampler of OpenTelemetry.
+func samplingThreshold(ratio float64) uint64 {
+	if ratio >= 1 {
+		return 1 << 63
+	}
+	return uint64(ratio * (1 << 63))
+}
+
+// Run exports the ended spans until stopCh is closed, then exports the
+// spans that are left.
+func (t *Tracer) Run(stopCh <-chan struct{}) {
+	if t == nil {
+		return
+	}
+	t.exporter.run(stopCh)
+}
+
+// Start starts a span. The span is part of the trace of parent, and sampled
+// like parent, when parent is not nil; otherwise it starts a new trace. The
+// span must be ended with End.
+func (t *Tracer) Start(name string, parent *Span, kind SpanKind, attributes ...Attribute) *Span {
+	if t == nil {
+		return nil
+	}
+
+	s := &Span{tracer: t}
+	t.randMu.Lock()
+	if parent != nil {
+		s.traceID = parent.traceID
+		s.parentSpanID = parent.spanID
+		s.sampled = parent.sampled
+	} else {
+		t.rand.Read(s.traceID[:])
+		s.sampled = binary.BigEndian.Uint64(s.traceID[8:])>>1 < t.samplingThreshold
+	}
+	t.rand.Read(s.spanID[:])
+	t.randMu.Unlock()
+
+	if s.sampled {
+		s.name = name
+		s.kind = kind
+		s.start = time.Now()
+		s.attributes = attributes
+	}
+	return s
+}
+
+// Span is an operation of a trace. Only the sampled spans record their
+// attributes and are exported.
+type Span struct {
+	tracer       *Tracer
+	traceID      [16]byte
+	spanID       [8]byte
+	parentSpanID [8]byte
+	sampled      bool
+
+	name  string
+	kind  SpanKind
+	start time.Time
+	end   time.Time
+
+	mu         sync.Mutex
+	attributes []Attribute
+	err        error
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil || !s.sampled {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// End ends the span, which failed with err if err is not nil, and queues it
+// to be exported.
+func (s *Span) End(err error) {
+	if s == nil || !s.sampled {
+		return
+	}
+	s.mu.Lock()
+	s.end = time.Now()
+	s.err = err
+	s.mu.Unlock()
+	s.tracer.exporter.add(s)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewTracer(t *testing.T) {
+	cases := []struct {
+		name          string
+		endpoint      string
+		samplingRatio float64
+		expectedURL   string
+		err           string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:          "default path",
+			endpoint:      "http://collector:4318",
+			samplingRatio: 1,
+			expectedURL:   "http://collector:4318/v1/traces",
+		},
+		{
+			name:          "path",
+			endpoint:      "https://collector/otlp/v1/traces",
+			samplingRatio: 0.5,
+			expectedURL:   "https://collector/otlp/v1/traces",
+		},
+		{
+			name:          "invalid ratio",
+			endpoint:      "http://collector:4318",
+			samplingRatio: 2,
+			err:           "it must be between 0 and 1",
+		},
+		{
+			name:          "invalid scheme",
+			endpoint:      "collector:4318",
+			samplingRatio: 1,
+			err:           "its scheme must be http or https",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracer, err := NewTracer("test", tc.endpoint, tc.samplingRatio)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedURL == "" {
+				if tracer != nil {
+					t.Fatal("expected no tracer")
+				}
+				return
+			}
+			if e, a := tc.expectedURL, tracer.exporter.endpoint; e != a {
+				t.Fatalf("unexpected endpoint: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+// TestNilTracer tests that a nil tracer and its spans do nothing.
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+	span := tracer.Start("reconcile", nil, SpanKindInternal, String("kind", "ServiceInstance"))
+	if span != nil {
+		t.Fatal("expected no span")
+	}
+	span.SetAttributes(String("outcome", "success"))
+	span.End(nil)
+	tracer.Run(nil)
+}
+
+// TestSampling tests that the children of a span are sampled like the span.
+func TestSampling(t *testing.T) {
+	for _, ratio := range []float64{0, 1} {
+		tracer, err := NewTracer("test", "http://collector:4318", ratio)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			parent := tracer.Start("reconcile", nil, SpanKindInternal)
+			child := tracer.Start("osb", parent, SpanKindClient)
+			if e, a := ratio == 1, parent.sampled; e != a {
+				t.Fatalf("unexpected sampling of the parent with ratio %v: expected %v, got %v", ratio, e, a)
+			}
+			if child.sampled != parent.sampled || child.traceID != parent.traceID || child.parentSpanID != parent.spanID {
+				t.Fatalf("expected the child to be part of the trace of its parent")
+			}
+		}
+	}
+}
+
+// TestExport tests that the ended spans are sent to the endpoint when the
+// tracer stops.
+func TestExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		path     string
+		received exportRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("unexpected error decoding the request: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	tracer, err := NewTracer("test-service", server.URL, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent := tracer.Start("reconcile ServiceInstance", nil, SpanKindInternal, String("kind", "ServiceInstance"))
+	child := tracer.Start("osb ProvisionInstance", parent, SpanKindClient)
+	child.SetAttributes(Int("http.status_code", 500))
+	child.End(errors.New("broker error"))
+	parent.End(nil)
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	tracer.Run(stopCh)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if e, a := "/v1/traces", path; e != a {
+		t.Fatalf("unexpected path: expected %q, got %q", e, a)
+	}
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request: %+v", received)
+	}
+	if e, a := "test-service", *received.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; e != a {
+		t.Fatalf("unexpected service name: expected %q, got %q", e, a)
+	}
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	osbSpan, reconcileSpan := spans[0], spans[1]
+	if reconcileSpan.ParentSpanID != "" {
+		t.Fatalf("expected the reconcile span to have no parent, got %q", reconcileSpan.ParentSpanID)
+	}
+	if osbSpan.TraceID != reconcileSpan.TraceID || osbSpan.ParentSpanID != reconcileSpan.SpanID {
+		t.Fatalf("expected the OSB span to be a child of the reconcile span: %+v, %+v", osbSpan, reconcileSpan)
+	}
+	if e, a := SpanKindClient, osbSpan.Kind; e != a {
+		t.Fatalf("unexpected kind: expected %v, got %v", e, a)
+	}
+	if osbSpan.Status.Code != statusCodeError || osbSpan.Status.Message != "broker error" {
+		t.Fatalf("unexpected status: %+v", osbSpan.Status)
+	}
+	if a := osbSpan.Attributes; len(a) != 1 || a[0].Key != "http.status_code" || a[0].Value.IntValue == nil || *a[0].Value.IntValue != "500" {
+		t.Fatalf("unexpected attributes: %+v", a)
+	}
+	if reconcileSpan.Status.Code != 0 {
+		t.Fatalf("unexpected status: %+v", reconcileSpan.Status)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jsonschema validates JSON documents against the subset of JSON
+// Schema (draft-04 through draft-07) that brokers commonly use to describe
+// the parameters of their plans.
+//
+// Supported keywords are: type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, minLength, maxLength and pattern.
+// Any other keyword, including $ref, is ignored, so a document is never
+// rejected because of a part of the schema that can not be evaluated.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate checks the JSON document against the JSON schema, returning a
+// description of every violation found. A nil slice means the document is
+// valid. An error is returned only when the schema or document can not be
+// parsed.
+func Validate(schema, document []byte) ([]string, error) {
+	var s interface{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	var d interface{}
+	if len(document) > 0 {
+		if err := json.Unmarshal(document, &d); err != nil {
+			return nil, fmt.Errorf("invalid document: %v", err)
+		}
+	}
+	if d == nil {
+		// An absent document is treated as an empty set of parameters
+		d = map[string]interface{}{}
+	}
+
+	v := &validator{}
+	v.validate("", s, d)
+	return v.errs, nil
+}
+
+type validator struct {
+	errs []string
+}
+
+func (v *validator) errorf(path, format string, a ...interface{}) {
+	if path == "" {
+		path = "(root)"
+	}
+	v.errs = append(v.errs, path+": "+fmt.Sprintf(format, a...))
+}
+
+func (v *validator) validate(path string, rawSchema, value interface{}) {
+	schema, ok := rawSchema.(map[string]interface{})
+	if !ok {
+		// "true", "false" and malformed schemas accept everything
+		if b, isBool := rawSchema.(bool); isBool && !b {
+			v.errorf(path, "no value is allowed")
+		}
+		return
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.errorf(path, "must be of type %s", formatType(t))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.errorf(path, "must be one of %s", formatValues(enum))
+		}
+	}
+
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		v.errorf(path, "must be %s", formatValues([]interface{}{c}))
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(path, schema, val)
+	case []interface{}:
+		v.validateArray(path, schema, val)
+	case float64:
+		v.validateNumber(path, schema, val)
+	case string:
+		v.validateString(path, schema, val)
+	}
+}
+
+func (v *validator) validateObject(path string, schema map[string]interface{}, obj map[string]interface{}) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := obj[name]; !ok {
+				v.errorf(path, "missing required property %q", name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+
+	// iterate in a stable order so that errors are reported consistently
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := joinPath(path, k)
+		if propSchema, ok := properties[k]; ok {
+			v.validate(childPath, propSchema, obj[k])
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok {
+			if !allowed {
+				v.errorf(path, "additional property %q is not allowed", k)
+			}
+			continue
+		}
+		v.validate(childPath, additional, obj[k])
+	}
+}
+
+func (v *validator) validateArray(path string, schema map[string]interface{}, arr []interface{}) {
+	if min, ok := schema["minItems"].(float64); ok && float64(len(arr)) < min {
+		v.errorf(path, "must have at least %v items", min)
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(arr)) > max {
+		v.errorf(path, "must have at most %v items", max)
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range arr {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), items, item)
+		}
+	}
+}
+
+func (v *validator) validateNumber(path string, schema map[string]interface{}, n float64) {
+	if min, ok := schema["minimum"].(float64); ok {
+		// draft-04 expresses an exclusive bound as a boolean modifier
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive {
+			if n <= min {
+				v.errorf(path, "must be greater than %v", min)
+			}
+		} else if n < min {
+			v.errorf(path, "must be greater than or equal to %v", min)
+		}
+	}
+	if max, ok := schema["maximum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive {
+			if n >= max {
+				v.errorf(path, "must be less than %v", max)
+			}
+		} else if n > max {
+			v.errorf(path, "must be less than or equal to %v", max)
+		}
+	}
+	// draft-06 and later express an exclusive bound as a number
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && n <= min {
+		v.errorf(path, "must be greater than %v", min)
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && n >= max {
+		v.errorf(path, "must be less than %v", max)
+	}
+}
+
+func (v *validator) validateString(path string, schema map[string]interface{}, s string) {
+	length := float64(utf8.RuneCountInString(s))
+	if min, ok := schema["minLength"].(float64); ok && length < min {
+		v.errorf(path, "must be at least %v characters long", min)
+	}
+	if max, ok := schema["maxLength"].(float64); ok && length > max {
+		v.errorf(path, "must be at most %v characters long", max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil && !re.MatchString(s) {
+			v.errorf(path, "must match the pattern %q", pattern)
+		}
+	}
+}
+
+func matchesType(t interface{}, value interface{}) bool {
+	switch typ := t.(type) {
+	case string:
+		return matchesSingleType(typ, value)
+	case []interface{}:
+		for _, single := range typ {
+			if s, ok := single.(string); ok && matchesSingleType(s, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(t string, value interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	default:
+		return true
+	}
+}
+
+func formatType(t interface{}) string {
+	if types, ok := t.([]interface{}); ok {
+		names := make([]string, 0, len(types))
+		for _, single := range types {
+			names = append(names, fmt.Sprint(single))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func formatValues(values []interface{}) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		b, _ := json.Marshal(value)
+		formatted = append(formatted, string(b))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	schema := `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"required": ["name"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 8, "pattern": "^[a-z]+$"},
+			"size": {"type": "integer", "minimum": 1, "maximum": 10},
+			"tier": {"enum": ["basic", "premium"]},
+			"ratio": {"type": "number", "minimum": 0, "exclusiveMinimum": true},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"nested": {
+				"type": "object",
+				"additionalProperties": {"type": "boolean"}
+			}
+		}
+	}`
+
+	cases := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{
+			name:     "valid",
+			document: `{"name": "db", "size": 3, "tier": "basic", "ratio": 0.5, "tags": ["a"], "nested": {"x": true}}`,
+		},
+		{
+			name:     "missing required property",
+			document: `{"size": 3}`,
+			want:     []string{`(root): missing required property "name"`},
+		},
+		{
+			name:     "empty document is checked for required properties",
+			document: ``,
+			want:     []string{`(root): missing required property "name"`},
+		},
+		{
+			name:     "wrong type",
+			document: `{"name": 1}`,
+			want:     []string{`name: must be of type string`},
+		},
+		{
+			name:     "integer",
+			document: `{"name": "db", "size": 1.5}`,
+			want:     []string{`size: must be of type integer`},
+		},
+		{
+			name:     "bounds",
+			document: `{"name": "d", "size": 11, "ratio": 0}`,
+			want: []string{
+				`name: must be at least 2 characters long`,
+				`ratio: must be greater than 0`,
+				`size: must be less than or equal to 10`,
+			},
+		},
+		{
+			name:     "pattern",
+			document: `{"name": "DB"}`,
+			want:     []string{`name: must match the pattern "^[a-z]+$"`},
+		},
+		{
+			name:     "enum",
+			document: `{"name": "db", "tier": "gold"}`,
+			want:     []string{`tier: must be one of "basic", "premium"`},
+		},
+		{
+			name:     "additional properties",
+			document: `{"name": "db", "color": "red", "nested": {"x": "yes"}}`,
+			want: []string{
+				`(root): additional property "color" is not allowed`,
+				`nested.x: must be of type boolean`,
+			},
+		},
+		{
+			name:     "array items",
+			document: `{"name": "db", "tags": ["a", 2, "c"]}`,
+			want: []string{
+				`tags: must have at most 2 items`,
+				`tags[1]: must be of type string`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Validate([]byte(schema), []byte(tc.document))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Fatalf("unexpected violations\nwant: %q\n got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateIgnoresUnsupportedKeywords(t *testing.T) {
+	schema := `{"type": "object", "properties": {"a": {"$ref": "#/definitions/a", "format": "uuid"}}}`
+	got, err := Validate([]byte(schema), []byte(`{"a": "not-a-uuid"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected no violations, got %q", got)
+	}
+}
+
+func TestValidateInvalidInput(t *testing.T) {
+	if _, err := Validate([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an invalid schema")
+	}
+	if _, err := Validate([]byte(`{}`), []byte(`{`)); err == nil {
+		t.Fatal("expected an error for an invalid document")
+	}
+}
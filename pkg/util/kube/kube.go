@@ -26,15 +26,25 @@ import (
 // * context - Overrides the name of the kubernetes context, otherwise current-context is used
 // * kubeconfig - Overrides the config file path, defaults to ~/.kube/config
 func GetConfig(context, kubeconfig string) clientcmd.ClientConfig {
+	return GetConfigWithOverrides(context, kubeconfig, "", "")
+}
+
+// GetConfigWithOverrides returns a Kubernetes client config for a given context,
+// resolved with the same precedence as kubectl.
+// * context - Overrides the name of the kubernetes context, otherwise current-context is used
+// * kubeconfig - Overrides the config file path, otherwise $KUBECONFIG or ~/.kube/config is used
+// * server - Overrides the address of the API server of the context
+// * token - Overrides the credentials of the user of the context with a bearer token
+func GetConfigWithOverrides(context, kubeconfig, server, token string) clientcmd.ClientConfig {
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
 	rules.DefaultClientConfig = &clientcmd.DefaultClientConfig
 	rules.ExplicitPath = kubeconfig
-
 	overrides := &clientcmd.ConfigOverrides{
 		ClusterDefaults: clientcmd.ClusterDefaults,
 		CurrentContext:  context,
 	}
-
+	overrides.ClusterInfo.Server = server
+	overrides.AuthInfo.Token = token
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
 }
 
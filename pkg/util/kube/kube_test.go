@@ -0,0 +1,189 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const kubeconfigA = `apiVersion: v1
+kind: Config
+current-context: a1
+clusters:
+- name: a1
+  cluster:
+    server: https://a1.example.com
+- name: a2
+  cluster:
+    server: https://a2.example.com
+users:
+- name: a
+  user:
+    token: token-a
+contexts:
+- name: a1
+  context:
+    cluster: a1
+    user: a
+- name: a2
+  context:
+    cluster: a2
+    user: a
+`
+
+const kubeconfigB = `apiVersion: v1
+kind: Config
+current-context: b1
+clusters:
+- name: b1
+  cluster:
+    server: https://b1.example.com
+- name: b2
+  cluster:
+    server: https://b2.example.com
+users:
+- name: b
+  user:
+    token: token-b
+contexts:
+- name: b1
+  context:
+    cluster: b1
+    user: b
+- name: b2
+  context:
+    cluster: b2
+    user: b
+`
+
+func TestGetConfigWithOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pathA := filepath.Join(dir, "a")
+	pathB := filepath.Join(dir, "b")
+	if err := ioutil.WriteFile(pathA, []byte(kubeconfigA), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(pathB, []byte(kubeconfigB), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	oldKubeconfig, hadKubeconfig := os.LookupEnv("KUBECONFIG")
+	os.Setenv("KUBECONFIG", pathA)
+	defer func() {
+		if hadKubeconfig {
+			os.Setenv("KUBECONFIG", oldKubeconfig)
+		} else {
+			os.Unsetenv("KUBECONFIG")
+		}
+	}()
+
+	cases := []struct {
+		name       string
+		context    string
+		kubeconfig string
+		server     string
+		token      string
+		wantHost   string
+		wantToken  string
+		wantErr    bool
+	}{
+		{
+			name:      "current context of $KUBECONFIG",
+			wantHost:  "https://a1.example.com",
+			wantToken: "token-a",
+		},
+		{
+			name:       "--kubeconfig overrides $KUBECONFIG",
+			kubeconfig: pathB,
+			wantHost:   "https://b1.example.com",
+			wantToken:  "token-b",
+		},
+		{
+			name:      "--context overrides the current context",
+			context:   "a2",
+			wantHost:  "https://a2.example.com",
+			wantToken: "token-a",
+		},
+		{
+			name:       "--context selects a context of --kubeconfig",
+			kubeconfig: pathB,
+			context:    "b2",
+			wantHost:   "https://b2.example.com",
+			wantToken:  "token-b",
+		},
+		{
+			name:      "--server overrides the server of the context",
+			server:    "https://override.example.com",
+			wantHost:  "https://override.example.com",
+			wantToken: "token-a",
+		},
+		{
+			name:      "--token overrides the token of the context",
+			token:     "token-override",
+			wantHost:  "https://a1.example.com",
+			wantToken: "token-override",
+		},
+		{
+			name:       "all flags",
+			kubeconfig: pathB,
+			context:    "b2",
+			server:     "https://override.example.com",
+			token:      "token-override",
+			wantHost:   "https://override.example.com",
+			wantToken:  "token-override",
+		},
+		{
+			name:    "unknown --context",
+			context: "missing",
+			wantErr: true,
+		},
+		{
+			name:       "missing --kubeconfig",
+			kubeconfig: filepath.Join(dir, "missing"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := GetConfigWithOverrides(tc.context, tc.kubeconfig, tc.server, tc.token).ClientConfig()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if config.Host != tc.wantHost {
+				t.Errorf("unexpected host: expected %q, got %q", tc.wantHost, config.Host)
+			}
+			if config.BearerToken != tc.wantToken {
+				t.Errorf("unexpected token: expected %q, got %q", tc.wantToken, config.BearerToken)
+			}
+		})
+	}
+}
@@ -138,3 +138,14 @@ func FetchObject(u string, object interface{}) error {
 	}
 	return nil
 }
+
+// IsPrintableASCII returns whether s only contains printable ASCII
+// characters, so that it can be sent in an HTTP header.
+func IsPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+)
+
+func TestIsPrintableASCII(t *testing.T) {
+	for s, printable := range map[string]bool{
+		"":                         true,
+		"prod-cluster (east-1)":    true,
+		"prod\r\nX-Injected: true": false,
+		"prod\tcluster":            false,
+		"prod-clüster":             false,
+	} {
+		if e, a := printable, IsPrintableASCII(s); e != a {
+			t.Errorf("unexpected result for %q: expected %v, got %v", s, e, a)
+		}
+	}
+}
@@ -35,8 +35,10 @@ import (
 )
 
 const (
-	AllowedSecretName = "csb-secret-name"
-	DeniedSecretName  = "denied-csb-secret-name"
+	AllowedSecretName         = "csb-secret-name"
+	DeniedSecretName          = "denied-csb-secret-name"
+	AllowedServiceAccountName = "csb-sa-name"
+	DeniedServiceAccountName  = "denied-csb-sa-name"
 )
 
 // Reactors are not implemented in 'sigs.k8s.io/controller-runtime/pkg/client/fake' package
@@ -52,7 +54,11 @@ func (m *fakedClient) Create(ctx context.Context, obj runtime.Object, opts ...cl
 		return errors.New("Input object is not SubjectAccessReview type")
 	}
 
-	if obj.(*v1.SubjectAccessReview).Spec.ResourceAttributes.Name == AllowedSecretName {
+	attributes := obj.(*v1.SubjectAccessReview).Spec.ResourceAttributes
+	if attributes.Name == AllowedSecretName && attributes.Resource == "secrets" {
+		obj.(*v1.SubjectAccessReview).Status.Allowed = true
+	}
+	if attributes.Name == AllowedServiceAccountName && attributes.Resource == "serviceaccounts" && attributes.Subresource == "token" {
 		obj.(*v1.SubjectAccessReview).Status.Allowed = true
 	}
 
@@ -161,6 +167,30 @@ func TestSpecValidationHandlerAccessToBrokerAllowed(t *testing.T) {
 				}
 			}`),
 		},
+		"Request for Create ClusterServiceBroker with ServiceAccountToken AuthInfo should be allowed": {
+			admissionv1beta1.Create,
+			[]byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ClusterServiceBroker",
+  				"metadata": {
+				  "finalizers": ["kubernetes-incubator/service-catalog"],
+  				  "creationTimestamp": null,
+  				  "name": "test-broker"
+  				},
+  				"spec": {
+				  "url": "http://test-broker.local",
+				  "authInfo": {
+    			    "serviceAccountToken": {
+      				  "serviceAccountRef": {
+        			    "namespace": "test-handler",
+						"name": "` + AllowedServiceAccountName + `"
+					  },
+					  "audience": "test-broker"
+					}
+				  }
+				}
+			}`),
+		},
 	}
 
 	for desc, test := range tests {
@@ -261,6 +291,30 @@ func TestSpecValidationHandlerAccessToBrokerDenied(t *testing.T) {
   				}
 			}`),
 		},
+		"Request for Create ClusterServiceBroker with ServiceAccountToken AuthInfo should be denied": {
+			admissionv1beta1.Create,
+			[]byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ClusterServiceBroker",
+  				"metadata": {
+				  "finalizers": ["kubernetes-incubator/service-catalog"],
+  				  "creationTimestamp": null,
+  				  "name": "test-broker"
+  				},
+  				"spec": {
+				  "url": "http://test-broker.local",
+				  "authInfo": {
+    			    "serviceAccountToken": {
+      				  "serviceAccountRef": {
+        			    "namespace": "test-handler",
+						"name": "` + DeniedServiceAccountName + `"
+					  },
+					  "audience": "test-broker"
+					}
+				  }
+				}
+			}`),
+		},
 	}
 
 	for desc, test := range tests {
@@ -17,10 +17,20 @@ limitations under the License.
 package validation_test
 
 import (
+	"context"
+	"encoding/base64"
 	"testing"
 
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/clusterservicebroker/validation"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestSpecValidationHandlerDecoderErrors(t *testing.T) {
@@ -34,3 +44,71 @@ func TestSpecValidationHandlerDecoderErrors(t *testing.T) {
 		fn(t, &handler, "ClusterServiceBroker")
 	}
 }
+
+func TestSpecValidationHandlerInsecureSkipTLSVerify(t *testing.T) {
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	caBundle := base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----"))
+
+	tests := map[string]struct {
+		spec    string
+		allowed bool
+	}{
+		"Request with insecureSkipTLSVerify should be allowed": {
+			spec:    `{"url": "https://test-broker.local", "relistBehavior": "Manual", "insecureSkipTLSVerify": true}`,
+			allowed: true,
+		},
+		"Request with caBundle should be allowed": {
+			spec:    `{"url": "https://test-broker.local", "relistBehavior": "Manual", "caBundle": "` + caBundle + `"}`,
+			allowed: true,
+		},
+		"Request with insecureSkipTLSVerify and caBundle should be denied": {
+			spec:    `{"url": "https://test-broker.local", "relistBehavior": "Manual", "insecureSkipTLSVerify": true, "caBundle": "` + caBundle + `"}`,
+			allowed: false,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.StaticCreate{}}
+
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "5555-eeee",
+					Name:      "test-broker",
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ClusterServiceBroker",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "servicecatalog.k8s.io/v1beta1",
+						"kind": "ClusterServiceBroker",
+						"metadata": {"name": "test-broker"},
+						"spec": ` + test.spec + `
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.allowed, response.AdmissionResponse.Allowed)
+			if !test.allowed {
+				assert.Contains(t, string(response.AdmissionResponse.Result.Reason), "caBundle")
+			}
+		})
+	}
+}
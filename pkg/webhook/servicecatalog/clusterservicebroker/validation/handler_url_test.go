@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/clusterservicebroker/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyBrokerURL(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	policy, err := brokerurl.NewPolicy([]string{"10.0.0.0/8"}, append([]string{"10.0.0.0/8"}, brokerurl.DefaultDeniedCIDRs...), nil, []string{"*.internal"})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		operation       admissionv1beta1.Operation
+		policy          *brokerurl.Policy
+		oldURL          string
+		newURL          string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Create with an allowed address": {
+			operation:       admissionv1beta1.Create,
+			policy:          policy,
+			newURL:          "http://10.0.0.1:8080",
+			responseAllowed: true,
+			responseReason:  "ClusterServiceBroker validation successful",
+		},
+		"Create with the address of the metadata service": {
+			operation:       admissionv1beta1.Create,
+			policy:          policy,
+			newURL:          "http://169.254.169.254/latest",
+			responseAllowed: false,
+			responseReason:  "the address 169.254.169.254 of host \"169.254.169.254\" is in a denied range",
+		},
+		"Create with a loopback address": {
+			operation:       admissionv1beta1.Create,
+			policy:          policy,
+			newURL:          "http://[::1]:8080",
+			responseAllowed: false,
+			responseReason:  "is in a denied range",
+		},
+		"Create with a denied host": {
+			operation:       admissionv1beta1.Create,
+			policy:          policy,
+			newURL:          "https://broker.corp.internal",
+			responseAllowed: false,
+			responseReason:  "the host \"broker.corp.internal\" is denied",
+		},
+		"Create without a policy": {
+			operation:       admissionv1beta1.Create,
+			newURL:          "http://169.254.169.254/latest",
+			responseAllowed: true,
+			responseReason:  "ClusterServiceBroker validation successful",
+		},
+		"Update with an unchanged denied URL": {
+			operation:       admissionv1beta1.Update,
+			policy:          policy,
+			oldURL:          "http://127.0.0.1:8080",
+			newURL:          "http://127.0.0.1:8080",
+			responseAllowed: true,
+			responseReason:  "ClusterServiceBroker validation successful",
+		},
+		"Update to a denied URL": {
+			operation:       admissionv1beta1.Update,
+			policy:          policy,
+			oldURL:          "http://10.0.0.1:8080",
+			newURL:          "http://127.0.0.1:8080",
+			responseAllowed: false,
+			responseReason:  "the address 127.0.0.1 of host \"127.0.0.1\" is in a denied range",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyBrokerURL{Policy: test.policy}}
+			handler.UpdateValidators = []validation.Validator{&validation.DenyBrokerURL{Policy: test.policy}}
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-broker",
+					Operation: test.operation,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ClusterServiceBroker",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: brokerWithURL(test.newURL)},
+				},
+			}
+			if test.oldURL != "" {
+				request.OldObject = runtime.RawExtension{Raw: brokerWithURL(test.oldURL)}
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
+
+func brokerWithURL(url string) []byte {
+	return []byte(`{
+		"apiVersion": "servicecatalog.k8s.io/v1beta1",
+		"kind": "ClusterServiceBroker",
+		"metadata": {"name": "test-broker", "uid": "uid-1"},
+		"spec": {"url": "` + url + `", "relistBehavior": "Manual"}
+	}`)
+}
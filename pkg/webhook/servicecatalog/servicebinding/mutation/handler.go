@@ -19,20 +19,36 @@ package mutation
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
 
 	admissionTypes "k8s.io/api/admission/v1beta1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const (
+	// secretNameSuffixLength is the length of the random suffix of the
+	// generated Secret names.
+	secretNameSuffixLength = 5
+	// secretNameAttempts is the number of generated Secret names tried
+	// before the name of the binding is used.
+	secretNameAttempts = 5
+)
+
 // CreateUpdateHandler handles ServiceBinding
 type CreateUpdateHandler struct {
 	decoder *admission.Decoder
+	reader  client.Reader
 	UUID    webhookutil.UUIDGenerator
 }
 
@@ -57,7 +73,7 @@ func (h *CreateUpdateHandler) Handle(ctx context.Context, req admission.Request)
 	mutated := sb.DeepCopy()
 	switch req.Operation {
 	case admissionTypes.Create:
-		h.mutateOnCreate(ctx, req, mutated)
+		h.mutateOnCreate(ctx, req, mutated, traced)
 	case admissionTypes.Update:
 		oldObj := &sc.ServiceBinding{}
 		if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
@@ -88,7 +104,16 @@ func (h *CreateUpdateHandler) InjectDecoder(d *admission.Decoder) error {
 	return nil
 }
 
-func (h *CreateUpdateHandler) mutateOnCreate(ctx context.Context, req admission.Request, binding *sc.ServiceBinding) {
+var _ inject.APIReader = &CreateUpdateHandler{}
+
+// InjectAPIReader injects the reader used to check that the default Secret
+// name of a binding is free.
+func (h *CreateUpdateHandler) InjectAPIReader(r client.Reader) error {
+	h.reader = r
+	return nil
+}
+
+func (h *CreateUpdateHandler) mutateOnCreate(ctx context.Context, req admission.Request, binding *sc.ServiceBinding, traced *webhookutil.TracedLogger) {
 	// This feature was copied from Service Catalog registry: https://github.com/kubernetes-sigs/service-catalog/blob/master/pkg/registry/servicecatalog/binding/strategy.go
 	// If you want to track previous changes please check there.
 
@@ -96,8 +121,12 @@ func (h *CreateUpdateHandler) mutateOnCreate(ctx context.Context, req admission.
 		binding.Spec.ExternalID = string(h.UUID.New())
 	}
 
+	if binding.Spec.SecretNamespace == "" {
+		binding.Spec.SecretNamespace = bindingNamespace(req, binding)
+	}
+
 	if binding.Spec.SecretName == "" {
-		binding.Spec.SecretName = binding.Name
+		binding.Spec.SecretName = h.defaultSecretName(ctx, req, binding, traced)
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
@@ -114,7 +143,66 @@ func (h *CreateUpdateHandler) mutateOnUpdate(ctx context.Context, req admission.
 	// ValidateUpdate. Also, the check for whether the generation needs
 	// to be updated needs to be un-commented.
 	// If the Spec change is allowed do not forget to update UserInfo (setServiceBindingUserInfo function)
+	//
+	// The only exception is RebindRequests, which is bumped to rebind the
+	// binding. Ignore the RebindRequests field when it is the default value.
+	rebindRequests := newServiceBinding.Spec.RebindRequests
 	newServiceBinding.Spec = oldServiceBinding.Spec
+	if rebindRequests != 0 {
+		newServiceBinding.Spec.RebindRequests = rebindRequests
+	}
+	if newServiceBinding.Spec.RebindRequests != oldServiceBinding.Spec.RebindRequests &&
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
+		setServiceBindingUserInfo(req, newServiceBinding)
+	}
+}
+
+// defaultSecretName returns the name of the binding when no Secret and no
+// other binding of the Secret namespace use it, and otherwise the name of the
+// binding followed by a random suffix which is free. When no free name is
+// found, or the names can not be checked, the name of the binding is
+// returned and the validating webhook rejects it if it collides.
+func (h *CreateUpdateHandler) defaultSecretName(ctx context.Context, req admission.Request, binding *sc.ServiceBinding, traced *webhookutil.TracedLogger) string {
+	if h.reader == nil || binding.Name == "" {
+		return binding.Name
+	}
+	namespace := bindingNamespace(req, binding)
+
+	name := binding.Name
+	for attempt := 0; attempt <= secretNameAttempts; attempt++ {
+		if attempt > 0 {
+			name = generateSecretName(binding.Name)
+		}
+		inUse, err := webhookutil.BindingSecretNameInUse(ctx, h.reader, binding.Spec.SecretNamespace, name, namespace, binding.Name)
+		if err != nil {
+			traced.Infof("Could not check that Secret name %q is free, defaulting to the name of the binding: %v", name, err)
+			return binding.Name
+		}
+		if inUse == "" {
+			return name
+		}
+		traced.Infof("Not defaulting the Secret name to %q: %s", name, inUse)
+	}
+	return binding.Name
+}
+
+// bindingNamespace returns the namespace of the binding, which is only set in
+// the request when the binding does not specify it.
+func bindingNamespace(req admission.Request, binding *sc.ServiceBinding) string {
+	if binding.Namespace != "" {
+		return binding.Namespace
+	}
+	return req.Namespace
+}
+
+// generateSecretName appends a random suffix to the name of a binding,
+// truncating the name so that the result is a valid Secret name.
+func generateSecretName(bindingName string) string {
+	maxLength := validation.DNS1123SubdomainMaxLength - secretNameSuffixLength - 1
+	if len(bindingName) > maxLength {
+		bindingName = strings.TrimRight(bindingName[:maxLength], "-.")
+	}
+	return fmt.Sprintf("%s-%s", bindingName, utilrand.String(secretNameSuffixLength))
 }
 
 // setServiceBindingUserInfo injects user.Info from the request context
@@ -30,11 +30,13 @@ import (
 	"github.com/stretchr/testify/require"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -76,9 +78,14 @@ func TestCreateUpdateHandlerHandleCreateSuccess(t *testing.T) {
 					Path:      "/spec/secretName",
 					Value:     "test-binding",
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/secretNamespace",
+					Value:     "system",
+				},
 			},
 		},
-		"Should omit externalID and secretName if they are already set": {
+		"Should omit externalID, secretName and secretNamespace if they are already set": {
 			givenRawObj: []byte(`{
 				"apiVersion": "servicecatalog.k8s.io/v1beta1",
   				"kind": "ServiceBinding",
@@ -91,7 +98,8 @@ func TestCreateUpdateHandlerHandleCreateSuccess(t *testing.T) {
 					"name": "some-instance"
 				  },
 				  "externalID": "my-external-id-123",
-				  "secretName": "overridden-name"
+				  "secretName": "overridden-name",
+				  "secretNamespace": "central-secrets"
   				}
 			}`),
 			expPatches: []jsonpatch.Operation{
@@ -156,6 +164,87 @@ func TestCreateUpdateHandlerHandleCreateSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateUpdateHandlerHandleCreateGeneratesFreeSecretName(t *testing.T) {
+	const namespace = "system"
+	tests := map[string]struct {
+		givenObjects []runtime.Object
+		expGenerated bool
+	}{
+		"Should default to the binding name when it is free": {
+			givenObjects: []runtime.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: namespace}},
+			},
+			expGenerated: false,
+		},
+		"Should generate a name when a Secret with the binding name exists": {
+			givenObjects: []runtime.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-binding", Namespace: namespace}},
+			},
+			expGenerated: true,
+		},
+		"Should generate a name when another binding uses the binding name": {
+			givenObjects: []runtime.Object{
+				&sc.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: namespace},
+					Spec:       sc.ServiceBindingSpec{SecretName: "test-binding"},
+				},
+			},
+			expGenerated: true,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			sc.AddToScheme(scheme.Scheme)
+			decoder, err := admission.NewDecoder(scheme.Scheme)
+			require.NoError(t, err)
+
+			fixReq := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					Operation: admissionv1beta1.Create,
+					Name:      "test-binding",
+					Namespace: namespace,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "servicecatalog.k8s.io/v1beta1",
+						"kind": "ServiceBinding",
+						"metadata": {"name": "test-binding", "namespace": "` + namespace + `"},
+						"spec": {"instanceRef": {"name": "some-instance"}}
+					}`)},
+				},
+			}
+
+			handler := mutation.CreateUpdateHandler{
+				UUID: func() types.UID { return "mocked-uuid-123-abc" },
+			}
+			handler.InjectDecoder(decoder)
+			handler.InjectAPIReader(fake.NewFakeClientWithScheme(scheme.Scheme, tc.givenObjects...))
+
+			// when
+			resp := handler.Handle(context.Background(), fixReq)
+
+			// then
+			assert.True(t, resp.Allowed)
+			var secretName string
+			for _, patch := range resp.Patches {
+				if patch.Path == "/spec/secretName" {
+					secretName = patch.Value.(string)
+				}
+			}
+			if tc.expGenerated {
+				assert.Regexp(t, "^test-binding-[a-z0-9]{5}$", secretName)
+			} else {
+				assert.Equal(t, "test-binding", secretName)
+			}
+		})
+	}
+}
+
 func TestCreateUpdateHandlerHandleUpdateSuccess(t *testing.T) {
 	const fixUUID = "mocked-uuid-123-abc"
 	tests := map[string]struct {
@@ -200,6 +289,83 @@ func TestCreateUpdateHandlerHandleUpdateSuccess(t *testing.T) {
 				},
 			},
 		},
+		"Should keep increased rebind requests": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rebindRequests": 1
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance-1"
+				  },
+				  "rebindRequests": 2
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{
+				{
+					Operation: "replace",
+					Path:      "/spec/instanceRef/name",
+					Value:     "some-instance",
+				},
+			},
+		},
+		"Should ignore default rebind requests": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rebindRequests": 3
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  }
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{
+				{
+					Operation: "add",
+					Path:      "/spec/rebindRequests",
+					Value:     float64(3),
+				},
+			},
+		},
 	}
 
 	for tn, tc := range tests {
@@ -294,7 +460,8 @@ func TestCreateUpdateHandlerHandleSetUserInfoIfOriginatingIdentityIsEnabled(t *t
 					"name": "some-instance"
 				  },
 				  "externalID": "123-abc",
-				  "secretName": "test-binding"
+				  "secretName": "test-binding",
+				  "secretNamespace": "system"
   				}
 			}`)},
 		},
@@ -43,11 +43,16 @@ type SpecValidationHandler struct {
 var _ admission.Handler = &SpecValidationHandler{}
 var _ admission.DecoderInjector = &SpecValidationHandler{}
 var _ inject.Client = &SpecValidationHandler{}
-
-// NewSpecValidationHandler creates new SpecValidationHandler and initializes validators list
-func NewSpecValidationHandler() *SpecValidationHandler {
+var _ inject.APIReader = &SpecValidationHandler{}
+
+// NewSpecValidationHandler creates new SpecValidationHandler and initializes
+// validators list. Parameters larger than maxParametersSize bytes are
+// rejected, 0 disables the limit. parametersFromSecretPolicy is what to do
+// when a Secret referenced by the parameters does not exist. The names of new
+// bindings must match namePattern, unless it is nil.
+func NewSpecValidationHandler(maxParametersSize int, parametersFromSecretPolicy webhookutil.ParametersFromSecretPolicy, namePattern *webhookutil.NamePattern) *SpecValidationHandler {
 	return &SpecValidationHandler{
-		CreateValidators: []Validator{&ReferenceDeletion{}, &StaticCreate{}},
+		CreateValidators: []Validator{&ReferenceDeletion{}, &StaticCreate{}, &DenyNonConformingName{NamePattern: namePattern}, &DenyOversizedParameters{MaxParametersSize: maxParametersSize}, &DenyNonBindablePlan{}, &DenySecretNameCollision{}, &AccessToSecretNamespace{}, &CheckParametersFromSecrets{Policy: parametersFromSecretPolicy}, &DenyUnwatchedParametersConfigMaps{}},
 		UpdateValidators: []Validator{&StaticUpdate{}},
 	}
 }
@@ -142,3 +147,21 @@ func (h *SpecValidationHandler) InjectClient(c client.Client) error {
 
 	return nil
 }
+
+// InjectAPIReader injects the reader into the handlers
+func (h *SpecValidationHandler) InjectAPIReader(r client.Reader) error {
+	for _, v := range h.CreateValidators {
+		_, err := inject.APIReaderInto(r, v)
+		if err != nil {
+			return err
+		}
+	}
+	for _, v := range h.UpdateValidators {
+		_, err := inject.APIReaderInto(r, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyNonBindablePlan handles ServiceBinding validation
+type DenyNonBindablePlan struct {
+	client client.Client
+}
+
+var _ Validator = &DenyNonBindablePlan{}
+var _ inject.Client = &DenyNonBindablePlan{}
+
+// InjectClient injects the client
+func (h *DenyNonBindablePlan) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
+
+// Validate rejects a ServiceBinding of a ServiceInstance whose plan is not
+// bindable. The bindable field of the plan overrides the one of its class.
+// Bindings are allowed when the class or plan of the instance can not be
+// determined yet; the controller then fails them if needed.
+func (h *DenyNonBindablePlan) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyNonBindablePlan")
+
+	instance := &sc.ServiceInstance{}
+	err := h.client.Get(ctx, types.NamespacedName{Namespace: sb.Namespace, Name: sb.Spec.InstanceRef.Name}, instance)
+	if err != nil {
+		traced.Infof("Could not get ServiceInstance by name %q: %v", sb.Spec.InstanceRef.Name, err)
+		return nil
+	}
+
+	var bindable bool
+	var className, planName string
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil:
+		class := &sc.ClusterServiceClass{}
+		plan := &sc.ClusterServicePlan{}
+		if !h.get(ctx, types.NamespacedName{Name: instance.Spec.ClusterServiceClassRef.Name}, class, traced) ||
+			!h.get(ctx, types.NamespacedName{Name: instance.Spec.ClusterServicePlanRef.Name}, plan, traced) {
+			return nil
+		}
+		bindable = isBindable(class.Spec.CommonServiceClassSpec, plan.Spec.CommonServicePlanSpec)
+		className, planName = pretty.ClusterServiceClassName(class), pretty.ClusterServicePlanName(plan)
+	case instance.Spec.ServiceClassRef != nil && instance.Spec.ServicePlanRef != nil:
+		class := &sc.ServiceClass{}
+		plan := &sc.ServicePlan{}
+		if !h.get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ServiceClassRef.Name}, class, traced) ||
+			!h.get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ServicePlanRef.Name}, plan, traced) {
+			return nil
+		}
+		bindable = isBindable(class.Spec.CommonServiceClassSpec, plan.Spec.CommonServicePlanSpec)
+		className, planName = pretty.ServiceClassName(class), pretty.ServicePlanName(plan)
+	default:
+		traced.Infof("The class and plan of ServiceInstance %q are not resolved yet", instance.Name)
+		return nil
+	}
+
+	if !bindable {
+		msg := fmt.Sprintf(
+			"ServiceBinding %s/%s references the ServiceInstance %s/%s of the non-bindable %s of %s",
+			sb.Namespace, sb.Name, instance.Namespace, instance.Name, planName, className)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	traced.Info("DenyNonBindablePlan passed")
+	return nil
+}
+
+func (h *DenyNonBindablePlan) get(ctx context.Context, key types.NamespacedName, obj runtime.Object, traced *webhookutil.TracedLogger) bool {
+	if err := h.client.Get(ctx, key, obj); err != nil {
+		traced.Infof("Could not get %q: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// isBindable returns the bindable field of the plan if it is set, and the one of
+// the class otherwise.
+func isBindable(class sc.CommonServiceClassSpec, plan sc.CommonServicePlanSpec) bool {
+	if plan.Bindable != nil {
+		return *plan.Bindable
+	}
+	return class.Bindable
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebinding/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyNonBindablePlan(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	namespace := "test-handler"
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	boolPtr := func(b bool) *bool { return &b }
+	clusterInstance := &sc.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: namespace},
+		Spec: sc.ServiceInstanceSpec{
+			ClusterServiceClassRef: &sc.ClusterObjectReference{Name: "class-id"},
+			ClusterServicePlanRef:  &sc.ClusterObjectReference{Name: "plan-id"},
+		},
+	}
+	namespacedInstance := &sc.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: namespace},
+		Spec: sc.ServiceInstanceSpec{
+			ServiceClassRef: &sc.LocalObjectReference{Name: "class-id"},
+			ServicePlanRef:  &sc.LocalObjectReference{Name: "plan-id"},
+		},
+	}
+	clusterClass := func(bindable bool) *sc.ClusterServiceClass {
+		return &sc.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "class-id"},
+			Spec: sc.ClusterServiceClassSpec{CommonServiceClassSpec: sc.CommonServiceClassSpec{
+				ExternalName: "test-class",
+				Bindable:     bindable,
+			}},
+		}
+	}
+	clusterPlan := func(bindable *bool) *sc.ClusterServicePlan {
+		return &sc.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "plan-id"},
+			Spec: sc.ClusterServicePlanSpec{CommonServicePlanSpec: sc.CommonServicePlanSpec{
+				ExternalName: "test-plan",
+				Bindable:     bindable,
+			}},
+		}
+	}
+	class := func(bindable bool) *sc.ServiceClass {
+		return &sc.ServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "class-id", Namespace: namespace},
+			Spec: sc.ServiceClassSpec{CommonServiceClassSpec: sc.CommonServiceClassSpec{
+				ExternalName: "test-class",
+				Bindable:     bindable,
+			}},
+		}
+	}
+	plan := func(bindable *bool) *sc.ServicePlan {
+		return &sc.ServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "plan-id", Namespace: namespace},
+			Spec: sc.ServicePlanSpec{CommonServicePlanSpec: sc.CommonServicePlanSpec{
+				ExternalName: "test-plan",
+				Bindable:     bindable,
+			}},
+		}
+	}
+
+	tests := map[string]struct {
+		objects         []runtime.Object
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Bindable class without plan override": {
+			objects:         []runtime.Object{clusterInstance, clusterClass(true), clusterPlan(nil)},
+			responseAllowed: true,
+		},
+		"Non-bindable class without plan override": {
+			objects:         []runtime.Object{clusterInstance, clusterClass(false), clusterPlan(nil)},
+			responseAllowed: false,
+			responseReason:  `ServiceBinding test-handler/test-binding references the ServiceInstance test-handler/test-instance of the non-bindable ClusterServicePlan (K8S: "plan-id" ExternalName: "test-plan") of ClusterServiceClass (K8S: "class-id" ExternalName: "test-class")`,
+		},
+		"Bindable class with non-bindable plan": {
+			objects:         []runtime.Object{clusterInstance, clusterClass(true), clusterPlan(boolPtr(false))},
+			responseAllowed: false,
+			responseReason:  `non-bindable ClusterServicePlan (K8S: "plan-id" ExternalName: "test-plan")`,
+		},
+		"Non-bindable class with bindable plan": {
+			objects:         []runtime.Object{clusterInstance, clusterClass(false), clusterPlan(boolPtr(true))},
+			responseAllowed: true,
+		},
+		"Namespaced bindable class with non-bindable plan": {
+			objects:         []runtime.Object{namespacedInstance, class(true), plan(boolPtr(false))},
+			responseAllowed: false,
+			responseReason:  `non-bindable ServicePlan (K8S: "test-handler/plan-id" ExternalName: "test-plan")`,
+		},
+		"Namespaced non-bindable class with bindable plan": {
+			objects:         []runtime.Object{namespacedInstance, class(false), plan(boolPtr(true))},
+			responseAllowed: true,
+		},
+		"Instance not found": {
+			objects:         []runtime.Object{},
+			responseAllowed: true,
+		},
+		"Plan of the instance not resolved yet": {
+			objects: []runtime.Object{&sc.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-instance", Namespace: namespace},
+				Spec: sc.ServiceInstanceSpec{
+					PlanReference: sc.PlanReference{ClusterServiceClassExternalName: "test-class"},
+				},
+			}},
+			responseAllowed: true,
+		},
+		"Plan of the instance not found": {
+			objects:         []runtime.Object{clusterInstance, clusterClass(false)},
+			responseAllowed: true,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyNonBindablePlan{}}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, test.objects...)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectClient(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "2222-bbbb",
+					Name:      "test-binding",
+					Namespace: namespace,
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-binding", "namespace": "` + namespace + `"},
+						"spec": {"instanceRef": {"name": "test-instance"}}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			if !test.responseAllowed {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+			}
+		})
+	}
+}
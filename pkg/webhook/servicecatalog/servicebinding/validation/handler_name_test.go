@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebinding/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyNonConformingName(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		namePattern     string
+		name            string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Name matching the pattern": {
+			namePattern:     "(payments|search)-.+",
+			name:            "payments-db",
+			responseAllowed: true,
+		},
+		"Name not matching the pattern": {
+			namePattern:     "(payments|search)-.+",
+			name:            "db",
+			responseAllowed: false,
+			responseReason:  `The name "db" of the ServiceBinding does not follow the naming convention: names of ServiceBindings must match the regular expression "(payments|search)-.+"`,
+		},
+		"No pattern": {
+			name:            "db",
+			responseAllowed: true,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			namePattern, err := webhookutil.NewNamePattern(test.namePattern)
+			require.NoError(t, err)
+
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyNonConformingName{NamePattern: namePattern}}
+			err = handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "2222-name",
+					Name:      test.name,
+					Namespace: "test-handler",
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "` + test.name + `", "namespace": "test-handler"},
+						"spec": {"instanceRef": {"name": "test-instance"}}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			if !test.responseAllowed {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyUnwatchedParametersConfigMaps handles ServiceBinding validation
+type DenyUnwatchedParametersConfigMaps struct {
+	reader client.Reader
+}
+
+var _ Validator = &DenyUnwatchedParametersConfigMaps{}
+var _ inject.APIReader = &DenyUnwatchedParametersConfigMaps{}
+
+// Validate checks that the ConfigMaps referenced by the parametersFrom of a
+// binding carry the label that makes the controller watch them, so that
+// their changes are sent to the broker. A missing ConfigMap is admitted, as it
+// may be created later, with the label.
+func (h *DenyUnwatchedParametersConfigMaps) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyUnwatchedParametersConfigMaps")
+
+	namespace := sb.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	for i, source := range sb.Spec.ParametersFrom {
+		if source.ConfigMapKeyRef == nil {
+			continue
+		}
+		field := fmt.Sprintf("spec.parametersFrom[%d].configMapKeyRef", i)
+		name := source.ConfigMapKeyRef.Name
+
+		configMap := &corev1.ConfigMap{}
+		err := h.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, configMap)
+		switch {
+		case err == nil:
+			if configMap.Labels[sc.ServiceBindingParametersConfigMapLabel] != "true" {
+				msg := fmt.Sprintf("%s refers to ConfigMap %q, which does not have the label %s=true; add the label so that the changes of the ConfigMap are sent to the broker", field, name, sc.ServiceBindingParametersConfigMapLabel)
+				traced.Error(msg)
+				return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+			}
+		case apierrors.IsNotFound(err):
+			traced.Infof("Warning: %s refers to ConfigMap %q, which does not exist in namespace %q; it must be created with the label %s=true", field, name, namespace, sc.ServiceBindingParametersConfigMapLabel)
+		default:
+			traced.Infof("Could not check the labels of ConfigMap %q referenced by %s: %v", name, field, err)
+		}
+	}
+
+	traced.Info("DenyUnwatchedParametersConfigMaps passed")
+	return nil
+}
+
+// InjectAPIReader injects the reader. ConfigMaps are read from the API server
+// rather than from the cache of the client, so that the webhook does not
+// watch all the ConfigMaps of the cluster.
+func (h *DenyUnwatchedParametersConfigMaps) InjectAPIReader(r client.Reader) error {
+	h.reader = r
+	return nil
+}
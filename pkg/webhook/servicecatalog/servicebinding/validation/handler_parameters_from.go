@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// CheckParametersFromSecrets handles ServiceBinding validation
+type CheckParametersFromSecrets struct {
+	reader client.Reader
+
+	// Policy is what to do when a referenced Secret does not exist
+	Policy webhookutil.ParametersFromSecretPolicy
+}
+
+var _ Validator = &CheckParametersFromSecrets{}
+var _ inject.APIReader = &CheckParametersFromSecrets{}
+
+// Validate checks that the Secrets referenced by the parametersFrom of a
+// binding exist in its namespace.
+func (h *CheckParametersFromSecrets) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - CheckParametersFromSecrets")
+
+	if h.Policy == webhookutil.ParametersFromSecretPolicyOff {
+		return nil
+	}
+
+	var refs []webhookutil.SecretReference
+	for i, source := range sb.Spec.ParametersFrom {
+		if source.SecretKeyRef != nil {
+			refs = append(refs, webhookutil.SecretReference{
+				Field: fmt.Sprintf("spec.parametersFrom[%d].secretKeyRef", i),
+				Name:  source.SecretKeyRef.Name,
+			})
+		}
+	}
+
+	namespace := sb.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	if err := webhookutil.ValidateSecretReferencesExist(ctx, h.reader, "ServiceBinding", namespace, refs, h.Policy, traced); err != nil {
+		return err
+	}
+
+	traced.Info("CheckParametersFromSecrets passed")
+	return nil
+}
+
+// InjectAPIReader injects the reader. Secrets are read from the API server
+// rather than from the cache of the client, so that the webhook does not
+// watch all the Secrets of the cluster.
+func (h *CheckParametersFromSecrets) InjectAPIReader(r client.Reader) error {
+	h.reader = r
+	return nil
+}
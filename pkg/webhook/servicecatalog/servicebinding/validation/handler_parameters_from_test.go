@@ -0,0 +1,123 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebinding/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerCheckParametersFromSecrets(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	namespace := "test-handler"
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		policy          webhookutil.ParametersFromSecretPolicy
+		secretName      string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Existing Secret": {
+			policy:          webhookutil.ParametersFromSecretPolicyDeny,
+			secretName:      "test-secret",
+			responseAllowed: true,
+		},
+		"Missing Secret with the Deny policy": {
+			policy:          webhookutil.ParametersFromSecretPolicyDeny,
+			secretName:      "missing-secret",
+			responseAllowed: false,
+			responseReason:  `spec.parametersFrom[0].secretKeyRef refers to Secret "missing-secret", which does not exist in namespace "test-handler"`,
+		},
+		"Missing Secret with the Warn policy": {
+			policy:          webhookutil.ParametersFromSecretPolicyWarn,
+			secretName:      "missing-secret",
+			responseAllowed: true,
+		},
+		"Missing Secret with the Off policy": {
+			policy:          webhookutil.ParametersFromSecretPolicyOff,
+			secretName:      "missing-secret",
+			responseAllowed: true,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.CheckParametersFromSecrets{Policy: test.policy}}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: namespace}},
+			)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectAPIReader(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "2222-bbbb",
+					Name:      "test-binding",
+					Namespace: namespace,
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-binding", "namespace": "` + namespace + `"},
+						"spec": {
+							"instanceRef": {"name": "test-instance"},
+							"parametersFrom": [{"secretKeyRef": {"name": "` + test.secretName + `", "key": "params"}}]
+						}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			if !test.responseAllowed {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+			}
+		})
+	}
+}
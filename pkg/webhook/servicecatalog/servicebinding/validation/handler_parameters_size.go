@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyOversizedParameters handles ServiceBinding validation
+type DenyOversizedParameters struct {
+	// MaxParametersSize is the maximum size in bytes of the parameters, 0
+	// disables the check
+	MaxParametersSize int
+}
+
+var _ Validator = &DenyOversizedParameters{}
+
+// Validate checks that the parameters of a binding are not larger than
+// MaxParametersSize
+func (h *DenyOversizedParameters) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyOversizedParameters")
+
+	if err := webhookutil.ValidateParametersSize("ServiceBinding", sb.Spec.Parameters, h.MaxParametersSize); err != nil {
+		traced.Error(err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenySecretNameCollision handles ServiceBinding validation
+type DenySecretNameCollision struct {
+	reader client.Reader
+}
+
+var _ Validator = &DenySecretNameCollision{}
+var _ inject.APIReader = &DenySecretNameCollision{}
+
+// Validate checks that the Secret of a new binding does not exist yet and is
+// not the Secret of another binding, so that the controller never writes the
+// credentials into a Secret it does not own. The check is best-effort: when
+// the Secrets or the bindings can not be read, the binding is admitted and
+// the controller refuses to overwrite a foreign Secret.
+func (h *DenySecretNameCollision) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenySecretNameCollision")
+
+	namespace := sb.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	secretNamespace := sb.Spec.SecretNamespace
+	if secretNamespace == "" {
+		secretNamespace = namespace
+	}
+	inUse, err := webhookutil.BindingSecretNameInUse(ctx, h.reader, secretNamespace, sb.Spec.SecretName, namespace, sb.Name)
+	if err != nil {
+		traced.Infof("Could not check that Secret %q of the binding is free: %v", sb.Spec.SecretName, err)
+		return nil
+	}
+	if inUse != "" {
+		msg := "spec.secretName of the ServiceBinding is invalid: " + inUse
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	traced.Info("DenySecretNameCollision passed")
+	return nil
+}
+
+// InjectAPIReader injects the reader. Secrets are read from the API server
+// rather than from the cache of the client, so that the webhook does not
+// watch all the Secrets of the cluster.
+func (h *DenySecretNameCollision) InjectAPIReader(r client.Reader) error {
+	h.reader = r
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebinding/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenySecretNameCollision(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	const namespace = "test-handler"
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		secretName      string
+		secretNamespace string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Free Secret name": {
+			secretName:      "test-binding",
+			responseAllowed: true,
+		},
+		"Existing Secret": {
+			secretName:      "user-secret",
+			responseAllowed: false,
+			responseReason:  `spec.secretName of the ServiceBinding is invalid: Secret "user-secret" already exists in namespace "test-handler"`,
+		},
+		"Secret of another binding": {
+			secretName:      "shared-secret",
+			responseAllowed: false,
+			responseReason:  `spec.secretName of the ServiceBinding is invalid: Secret "shared-secret" is already used by ServiceBinding "other-binding" in namespace "test-handler"`,
+		},
+		"Secret in another namespace": {
+			secretName:      "user-secret",
+			secretNamespace: "central-secrets",
+			responseAllowed: true,
+		},
+		"Secret of a binding of another namespace": {
+			secretName:      "central-secret",
+			secretNamespace: "central-secrets",
+			responseAllowed: false,
+			responseReason:  `spec.secretName of the ServiceBinding is invalid: Secret "central-secret" in namespace "central-secrets" is already used by ServiceBinding "other-binding" in namespace "other-team"`,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenySecretNameCollision{}}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: namespace}},
+				&sc.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: namespace},
+					Spec:       sc.ServiceBindingSpec{SecretName: "shared-secret"},
+				},
+				&sc.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: "other-team"},
+					Spec:       sc.ServiceBindingSpec{SecretName: "central-secret", SecretNamespace: "central-secrets"},
+				},
+			)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectAPIReader(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "2222-bbbb",
+					Name:      "test-binding",
+					Namespace: namespace,
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-binding", "namespace": "` + namespace + `"},
+						"spec": {
+							"instanceRef": {"name": "test-instance"},
+							"secretName": "` + test.secretName + `",
+							"secretNamespace": "` + test.secretNamespace + `"
+						}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			if !test.responseAllowed {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	authenticationapi "k8s.io/api/authentication/v1"
+	authorizationapi "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AccessToSecretNamespace handles ServiceBinding validation
+type AccessToSecretNamespace struct {
+	client client.Client
+}
+
+var _ Validator = &AccessToSecretNamespace{}
+var _ inject.Client = &AccessToSecretNamespace{}
+
+// Validate checks that the user is allowed to create Secrets in the Secret
+// namespace of a binding whose Secret is in another namespace, so that the
+// controller does not write Secrets on behalf of users into namespaces they
+// have no access to.
+func (h *AccessToSecretNamespace) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBinding, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - AccessToSecretNamespace")
+
+	namespace := sb.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	if sb.Spec.SecretNamespace == "" || sb.Spec.SecretNamespace == namespace {
+		traced.Info("AccessToSecretNamespace passed, the Secret is in the namespace of the binding")
+		return nil
+	}
+
+	user := req.UserInfo
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace: sb.Spec.SecretNamespace,
+				Verb:      "create",
+				Group:     corev1.SchemeGroupVersion.Group,
+				Version:   corev1.SchemeGroupVersion.Version,
+				Resource:  corev1.ResourceSecrets.String(),
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  convertToSARExtra(user.Extra),
+			UID:    user.UID,
+		},
+	}
+
+	err := h.client.Create(ctx, sar)
+	if err != nil {
+		traced.Errorf("Could not create SubjectAccessReview for %s %q: %v", sb.Kind, sb.Name, err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusForbidden)
+	}
+
+	if !sar.Status.Allowed {
+		msg := fmt.Sprintf(
+			"binding forbidden access to create Secrets in namespace (%s): Reason: %s, EvaluationError: %s",
+			sb.Spec.SecretNamespace,
+			sar.Status.Reason,
+			sar.Status.EvaluationError)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	traced.Info("AccessToSecretNamespace passed")
+	return nil
+}
+
+func convertToSARExtra(extra map[string]authenticationapi.ExtraValue) map[string]authorizationapi.ExtraValue {
+	if extra == nil {
+		return nil
+	}
+
+	ret := map[string]authorizationapi.ExtraValue{}
+	for k, v := range extra {
+		ret[k] = authorizationapi.ExtraValue(v)
+	}
+
+	return ret
+}
+
+// InjectClient injects the client
+func (h *AccessToSecretNamespace) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
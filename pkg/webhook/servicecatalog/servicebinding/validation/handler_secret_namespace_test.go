@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebinding/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const allowedSecretNamespace = "central-secrets"
+
+// sarClient allows the creation of Secrets in allowedSecretNamespace only.
+// Reactors are not implemented in 'sigs.k8s.io/controller-runtime/pkg/client/fake'
+// package, so the Create method is overridden instead.
+type sarClient struct {
+	client.Client
+	reviews int
+}
+
+// Create overrides real client Create method for the test
+func (c *sarClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOptionFunc) error {
+	sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+	if !ok {
+		return errors.New("Input object is not SubjectAccessReview type")
+	}
+	c.reviews++
+
+	attributes := sar.Spec.ResourceAttributes
+	if attributes.Namespace == allowedSecretNamespace && attributes.Resource == "secrets" && attributes.Verb == "create" {
+		sar.Status.Allowed = true
+	}
+	return nil
+}
+
+func TestSpecValidationHandlerAccessToSecretNamespace(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	const namespace = "test-handler"
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		secretNamespace string
+		responseAllowed bool
+		responseReason  string
+		reviews         int
+	}{
+		"Default Secret namespace": {
+			secretNamespace: "",
+			responseAllowed: true,
+		},
+		"Namespace of the binding": {
+			secretNamespace: namespace,
+			responseAllowed: true,
+		},
+		"Allowed Secret namespace": {
+			secretNamespace: allowedSecretNamespace,
+			responseAllowed: true,
+			reviews:         1,
+		},
+		"Forbidden Secret namespace": {
+			secretNamespace: "other-team",
+			responseAllowed: false,
+			responseReason:  "binding forbidden access to create Secrets in namespace (other-team)",
+			reviews:         1,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.AccessToSecretNamespace{}}
+
+			fakeClient := &sarClient{Client: fake.NewFakeClientWithScheme(scheme.Scheme)}
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectClient(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "3333-cccc",
+					Name:      "test-binding",
+					Namespace: namespace,
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBinding",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-binding", "namespace": "` + namespace + `"},
+						"spec": {
+							"instanceRef": {"name": "test-instance"},
+							"secretName": "test-binding",
+							"secretNamespace": "` + test.secretNamespace + `"
+						}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			if !test.responseAllowed {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+			}
+			assert.Equal(t, test.reviews, fakeClient.reviews)
+		})
+	}
+}
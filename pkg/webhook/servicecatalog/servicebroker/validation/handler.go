@@ -19,6 +19,7 @@ package validation
 import (
 	"context"
 	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
 	admissionTypes "k8s.io/api/admission/v1beta1"
 	"net/http"
@@ -44,11 +45,13 @@ var _ admission.Handler = &SpecValidationHandler{}
 var _ admission.DecoderInjector = &SpecValidationHandler{}
 var _ inject.Client = &SpecValidationHandler{}
 
-// NewSpecValidationHandler creates new SpecValidationHandler and initializes validators list
-func NewSpecValidationHandler() *SpecValidationHandler {
+// NewSpecValidationHandler creates new SpecValidationHandler and initializes
+// validators list. Brokers whose URL is denied by brokerURLPolicy are
+// rejected, a nil policy allows every URL.
+func NewSpecValidationHandler(brokerURLPolicy *brokerurl.Policy) *SpecValidationHandler {
 	return &SpecValidationHandler{
-		CreateValidators: []Validator{&StaticCreate{}, &AccessToBroker{}},
-		UpdateValidators: []Validator{&StaticUpdate{}, &AccessToBroker{}},
+		CreateValidators: []Validator{&StaticCreate{}, &DenyBrokerURL{Policy: brokerURLPolicy}, &AccessToBroker{}},
+		UpdateValidators: []Validator{&StaticUpdate{}, &DenyBrokerURL{Policy: brokerURLPolicy}, &AccessToBroker{}},
 	}
 }
 
@@ -48,6 +48,10 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, sb
 		return nil
 	}
 
+	if sb.Spec.AuthInfo.ServiceAccountToken != nil {
+		return h.validateServiceAccountTokenAccess(ctx, req, sb, traced)
+	}
+
 	var secretRef *sc.LocalObjectReference
 	if sb.Spec.AuthInfo.Basic != nil {
 		secretRef = sb.Spec.AuthInfo.Basic.SecretRef
@@ -97,6 +101,53 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, sb
 	return nil
 }
 
+// validateServiceAccountTokenAccess checks if client is allowed to request the
+// tokens of the ServiceAccount which are sent to the broker
+func (h *AccessToBroker) validateServiceAccountTokenAccess(ctx context.Context, req admission.Request, sb *sc.ServiceBroker, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	saRef := sb.Spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+	if saRef == nil {
+		traced.Infof("%s %q has no ServiceAccountRef in ServiceAccountToken auth. Operation completed", sb.Kind, sb.Name)
+		return nil
+	}
+
+	user := req.UserInfo
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace:   sb.Namespace,
+				Verb:        "create",
+				Group:       corev1.SchemeGroupVersion.Group,
+				Version:     corev1.SchemeGroupVersion.Version,
+				Resource:    "serviceaccounts",
+				Subresource: "token",
+				Name:        saRef.Name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  convertToSARExtra(user.Extra),
+			UID:    user.UID,
+		},
+	}
+
+	err := h.client.Create(ctx, sar)
+	if err != nil {
+		traced.Errorf("Could not create SubjectAccessReview for %s %q: %v", sb.Kind, sb.Name, err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusForbidden)
+	}
+
+	if !sar.Status.Allowed {
+		msg := fmt.Sprintf(
+			"broker forbidden access to the tokens of service account (%s): Reason: %s, EvaluationError: %s",
+			saRef.Name,
+			sar.Status.Reason,
+			sar.Status.EvaluationError)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func convertToSARExtra(extra map[string]authenticationapi.ExtraValue) map[string]authorizationapi.ExtraValue {
 	if extra == nil {
 		return nil
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	admissionTypes "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyBrokerURL handles ServiceBroker validation
+type DenyBrokerURL struct {
+	decoder *admission.Decoder
+
+	// Policy decides which broker URLs are allowed, nil allows all of them
+	Policy *brokerurl.Policy
+}
+
+var _ Validator = &DenyBrokerURL{}
+var _ admission.DecoderInjector = &DenyBrokerURL{}
+
+// Validate checks that the URL of a broker is allowed by Policy. Updates which
+// leave the URL unchanged are allowed, so that tightening the policy does not
+// block unrelated changes; the controller still refuses to call the broker.
+func (h *DenyBrokerURL) Validate(ctx context.Context, req admission.Request, sb *sc.ServiceBroker, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyBrokerURL")
+
+	if req.Operation == admissionTypes.Update {
+		origBroker := &sc.ServiceBroker{}
+		if err := h.decoder.DecodeRaw(req.OldObject, origBroker); err != nil {
+			traced.Errorf("Could not decode oldObject: %v", err)
+			return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+		}
+		if sb.Spec.URL == origBroker.Spec.URL {
+			traced.Info("DenyBrokerURL passed - the URL is unchanged.")
+			return nil
+		}
+	}
+
+	if err := webhookutil.ValidateBrokerURL("ServiceBroker", sb.Spec.URL, h.Policy); err != nil {
+		traced.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// InjectDecoder injects the decoder
+func (h *DenyBrokerURL) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/util"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -105,6 +106,8 @@ func (d *DefaultServicePlan) handleDefaultClusterServicePlan(ctx context.Context
 		instance.Spec.ClusterServicePlanExternalName = p.Spec.ExternalName
 	} else if instance.Spec.ClusterServiceClassExternalID != "" {
 		instance.Spec.ClusterServicePlanExternalID = p.Spec.ExternalID
+	} else if instance.Spec.ClusterServiceClassSelector != nil {
+		instance.Spec.ClusterServicePlanExternalName = p.Spec.ExternalName
 	} else {
 		instance.Spec.ClusterServicePlanName = p.Name
 	}
@@ -161,6 +164,8 @@ func (d *DefaultServicePlan) handleDefaultServicePlan(ctx context.Context, insta
 		instance.Spec.ServicePlanExternalName = p.Spec.ExternalName
 	} else if instance.Spec.ServiceClassExternalID != "" {
 		instance.Spec.ServicePlanExternalID = p.Spec.ExternalID
+	} else if instance.Spec.ServiceClassSelector != nil {
+		instance.Spec.ServicePlanExternalName = p.Spec.ExternalName
 	} else {
 		instance.Spec.ServicePlanName = p.Name
 	}
@@ -172,6 +177,9 @@ func (d *DefaultServicePlan) getClusterServiceClassByPlanReference(ctx context.C
 	if instance.Spec.PlanReference.ClusterServiceClassName != "" {
 		return d.getClusterServiceClassByK8SName(ctx, instance, log)
 	}
+	if instance.Spec.PlanReference.ClusterServiceClassSelector != nil {
+		return d.getClusterServiceClassBySelector(ctx, instance, log)
+	}
 
 	return d.getClusterServiceClassByField(ctx, instance, log)
 }
@@ -180,6 +188,9 @@ func (d *DefaultServicePlan) getServiceClassByPlanReference(ctx context.Context,
 	if instance.Spec.PlanReference.ServiceClassName != "" {
 		return d.getServiceClassByK8SName(ctx, instance, log)
 	}
+	if instance.Spec.PlanReference.ServiceClassSelector != nil {
+		return d.getServiceClassBySelector(ctx, instance, log)
+	}
 
 	return d.getServiceClassByField(ctx, instance, log)
 }
@@ -199,6 +210,35 @@ func (d *DefaultServicePlan) getServiceClassByK8SName(ctx context.Context, insta
 	return serviceClass, err
 }
 
+func (d *DefaultServicePlan) getClusterServiceClassBySelector(ctx context.Context, instance *sc.ServiceInstance, log *webhookutil.TracedLogger) (*sc.ClusterServiceClass, error) {
+	selector, err := metav1.LabelSelectorAsSelector(instance.Spec.PlanReference.ClusterServiceClassSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(4).Infof("Fetching ClusterServiceClass selected by %q", selector)
+
+	serviceClassesList := &sc.ClusterServiceClassList{}
+	err = d.client.List(ctx, serviceClassesList, client.UseListOptions(&client.ListOptions{LabelSelector: selector}))
+	if err != nil {
+		log.V(4).Infof("Listing ClusterServiceClasses failed: %q", err)
+		return nil, err
+	}
+	var found []*sc.ClusterServiceClass
+	for i := range serviceClassesList.Items {
+		if !serviceClassesList.Items[i].Status.RemovedFromBrokerCatalog {
+			found = append(found, &serviceClassesList.Items[i])
+		}
+	}
+	if len(found) == 1 {
+		log.V(4).Infof("Found single ClusterServiceClass as %+v", *found[0])
+		return found[0], nil
+	}
+	msg := fmt.Sprintf("could not find a single ClusterServiceClass selected by %q, found %v", selector, len(found))
+	log.V(4).Info(msg)
+	return nil, errors.New(msg)
+}
+
 func (d *DefaultServicePlan) getClusterServiceClassByField(ctx context.Context, instance *sc.ServiceInstance, log *webhookutil.TracedLogger) (*sc.ClusterServiceClass, error) {
 	ref := instance.Spec.PlanReference
 
@@ -224,6 +264,35 @@ func (d *DefaultServicePlan) getClusterServiceClassByField(ctx context.Context,
 	return nil, errors.New(msg)
 }
 
+func (d *DefaultServicePlan) getServiceClassBySelector(ctx context.Context, instance *sc.ServiceInstance, log *webhookutil.TracedLogger) (*sc.ServiceClass, error) {
+	selector, err := metav1.LabelSelectorAsSelector(instance.Spec.PlanReference.ServiceClassSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(4).Infof("Fetching ServiceClass selected by %q", selector)
+
+	serviceClassesList := &sc.ServiceClassList{}
+	err = d.client.List(ctx, serviceClassesList, client.UseListOptions(&client.ListOptions{LabelSelector: selector, Namespace: instance.Namespace}))
+	if err != nil {
+		log.V(4).Infof("Listing ServiceClasses failed: %q", err)
+		return nil, err
+	}
+	var found []*sc.ServiceClass
+	for i := range serviceClassesList.Items {
+		if !serviceClassesList.Items[i].Status.RemovedFromBrokerCatalog {
+			found = append(found, &serviceClassesList.Items[i])
+		}
+	}
+	if len(found) == 1 {
+		log.V(4).Infof("Found single ServiceClass as %+v", *found[0])
+		return found[0], nil
+	}
+	msg := fmt.Sprintf("could not find a single ServiceClass selected by %q, found %v", selector, len(found))
+	log.V(4).Info(msg)
+	return nil, errors.New(msg)
+}
+
 func (d *DefaultServicePlan) getServiceClassByField(ctx context.Context, instance *sc.ServiceInstance, log *webhookutil.TracedLogger) (*sc.ServiceClass, error) {
 	ref := instance.Spec.PlanReference
 
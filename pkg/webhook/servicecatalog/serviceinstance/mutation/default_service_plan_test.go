@@ -245,6 +245,86 @@ func TestServiceClassSpecified(t *testing.T) {
 	}
 }
 
+func TestClassSelectorSpecified(t *testing.T) {
+	const namespace = "dummy"
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"database": "postgres"}}
+	labeled := func(obj metav1.Object) runtime.Object {
+		obj.GetLabels()["database"] = "postgres"
+		return obj.(runtime.Object)
+	}
+	removedClusterServiceClass := newClusterServiceClass("removed", "removed")
+	removedClusterServiceClass.Status.RemovedFromBrokerCatalog = true
+
+	for tn, tc := range map[string]struct {
+		ref          sc.PlanReference
+		objects      []runtime.Object
+		expectedPlan sc.PlanReference
+		err          *webhookutil.WebhookError
+	}{
+		"SuccessWithClusterServiceClassSelector": {
+			ref: sc.PlanReference{ClusterServiceClassSelector: selector},
+			objects: []runtime.Object{
+				labeled(newClusterServiceClass("csc", "csc")),
+				newClusterServiceClass("other", "other"),
+				newClusterServicePlans("csc", 1, false)[0],
+			},
+			expectedPlan: sc.PlanReference{ClusterServiceClassSelector: selector, ClusterServicePlanExternalName: "bar"},
+		},
+		"SuccessIgnoringRemovedClusterServiceClass": {
+			ref: sc.PlanReference{ClusterServiceClassSelector: selector},
+			objects: []runtime.Object{
+				labeled(newClusterServiceClass("csc", "csc")),
+				labeled(removedClusterServiceClass),
+				newClusterServicePlans("csc", 1, false)[0],
+			},
+			expectedPlan: sc.PlanReference{ClusterServiceClassSelector: selector, ClusterServicePlanExternalName: "bar"},
+		},
+		"ErrorWhenNoClusterServiceClassSelected": {
+			ref: sc.PlanReference{ClusterServiceClassSelector: selector},
+			objects: []runtime.Object{
+				newClusterServiceClass("csc", "csc"),
+			},
+			err: webhookutil.NewWebhookError(`could not find a single ClusterServiceClass selected by "database=postgres", found 0`, http.StatusForbidden),
+		},
+		"ErrorWhenManyClusterServiceClassesSelected": {
+			ref: sc.PlanReference{ClusterServiceClassSelector: selector},
+			objects: []runtime.Object{
+				labeled(newClusterServiceClass("csc", "csc")),
+				labeled(newClusterServiceClass("other", "other")),
+			},
+			err: webhookutil.NewWebhookError(`could not find a single ClusterServiceClass selected by "database=postgres", found 2`, http.StatusForbidden),
+		},
+		"SuccessWithServiceClassSelector": {
+			ref: sc.PlanReference{ServiceClassSelector: selector},
+			objects: []runtime.Object{
+				labeled(newServiceClass("sc", "sc", namespace)),
+				labeled(newServiceClass("sc", "sc", "other")),
+				newServicePlans("sc", namespace, 1, false)[0],
+			},
+			expectedPlan: sc.PlanReference{ServiceClassSelector: selector, ServicePlanExternalName: "bar"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(newTestScheme(t), tc.objects...)
+			traced := webhookutil.NewTracedLogger(uuid.NewUUID())
+
+			dsp := mutation.DefaultServicePlan{}
+			dsp.InjectClient(fakeClient)
+
+			instance := newServiceInstance(namespace)
+			instance.Spec.PlanReference = tc.ref
+			mutateErr := dsp.SetDefaultPlan(context.Background(), instance, traced)
+
+			if tc.err != nil {
+				assertMutateError(t, mutateErr, tc.err.Error(), tc.err.Code())
+			} else {
+				assert.Nil(t, mutateErr)
+				assert.Equal(t, tc.expectedPlan, instance.Spec.PlanReference)
+			}
+		})
+	}
+}
+
 func newTestScheme(t *testing.T) *runtime.Scheme {
 	sch, err := sc.SchemeBuilderRuntime.Build()
 	require.NoError(t, err)
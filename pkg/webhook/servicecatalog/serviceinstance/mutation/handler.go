@@ -39,12 +39,14 @@ type CreateUpdateHandler struct {
 	decoder            *admission.Decoder
 	UUID               webhookutil.UUIDGenerator
 	defaultServicePlan *DefaultServicePlan
+	instanceTemplate   *InstanceTemplate
 }
 
 // NewCreateUpdateHandler return new CreateUpdateHandler
 func NewCreateUpdateHandler() *CreateUpdateHandler {
 	return &CreateUpdateHandler{
 		defaultServicePlan: &DefaultServicePlan{},
+		instanceTemplate:   &InstanceTemplate{},
 	}
 }
 
@@ -71,6 +73,15 @@ func (h *CreateUpdateHandler) Handle(ctx context.Context, req admission.Request)
 	switch req.Operation {
 	case admissionTypes.Create:
 		h.mutateOnCreate(ctx, req, mutated)
+		// Expands the referenced ServiceInstanceTemplate before the default plan is resolved
+		if err := h.instanceTemplate.ApplyTemplate(ctx, mutated, traced); err != nil {
+			switch err.Code() {
+			case http.StatusForbidden:
+				return admission.Denied(err.Error())
+			default:
+				return admission.Errored(err.Code(), err)
+			}
+		}
 	case admissionTypes.Update:
 		oldObj := &sc.ServiceInstance{}
 		if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
@@ -191,3 +202,11 @@ func (h *CreateUpdateHandler) InjectClient(c client.Client) error {
 	}
 	return nil
 }
+
+var _ inject.APIReader = &CreateUpdateHandler{}
+
+// InjectAPIReader injects the reader
+func (h *CreateUpdateHandler) InjectAPIReader(r client.Reader) error {
+	_, err := inject.APIReaderInto(r, h.instanceTemplate)
+	return err
+}
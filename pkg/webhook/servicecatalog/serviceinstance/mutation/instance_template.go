@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/peterbourgon/mergemap"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InstanceTemplate holds logic which expands the ServiceInstanceTemplate
+// referenced by a ServiceInstance
+type InstanceTemplate struct {
+	reader client.Reader
+}
+
+// ApplyTemplate copies the class, plan and parameters of the
+// ServiceInstanceTemplate named in the template annotation of the instance
+// into the values the instance does not specify itself. It is only called
+// when the instance is created, so later changes to the template do not
+// affect the instance.
+func (t *InstanceTemplate) ApplyTemplate(ctx context.Context, instance *sc.ServiceInstance, log *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	name := instance.Annotations[sc.ServiceInstanceTemplateAnnotation]
+	if name == "" {
+		return nil
+	}
+
+	template := &sc.ServiceInstanceTemplate{}
+	err := t.reader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, template)
+	if err != nil {
+		if !apiErrors.IsNotFound(err) {
+			return webhookutil.NewWebhookError(err.Error(), http.StatusForbidden)
+		}
+		msg := fmt.Sprintf("ServiceInstanceTemplate %q does not exist in namespace %q", name, instance.Namespace)
+		log.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	log.V(4).Infof(`ServiceInstance "%s/%s": Applying ServiceInstanceTemplate %q (generation %d)`,
+		instance.Namespace, instance.Name, name, template.Generation)
+
+	applyTemplatePlanReference(&instance.Spec.PlanReference, template.Spec.PlanReference)
+
+	params, err := mergeTemplateParameters(instance.Spec.Parameters, template.Spec.Parameters)
+	if err != nil {
+		msg := fmt.Sprintf("cannot apply the parameters of ServiceInstanceTemplate %q: %v", name, err)
+		return webhookutil.NewWebhookError(msg, http.StatusBadRequest)
+	}
+	instance.Spec.Parameters = params
+
+	if len(template.Spec.ParametersFrom) > 0 {
+		parametersFrom := make([]sc.ParametersFromSource, 0, len(template.Spec.ParametersFrom)+len(instance.Spec.ParametersFrom))
+		parametersFrom = append(parametersFrom, template.Spec.ParametersFrom...)
+		instance.Spec.ParametersFrom = append(parametersFrom, instance.Spec.ParametersFrom...)
+	}
+
+	instance.Annotations[sc.ServiceInstanceTemplateGenerationAnnotation] = strconv.FormatInt(template.Generation, 10)
+	return nil
+}
+
+// applyTemplatePlanReference copies the class of the template when the
+// instance does not specify a class, and the plan of the template when the
+// instance does not specify a plan.
+func applyTemplatePlanReference(ref *sc.PlanReference, template sc.PlanReference) {
+	if !ref.ClusterServiceClassSpecified() && !ref.ServiceClassSpecified() {
+		ref.ClusterServiceClassExternalName = template.ClusterServiceClassExternalName
+		ref.ClusterServiceClassExternalID = template.ClusterServiceClassExternalID
+		ref.ClusterServiceClassName = template.ClusterServiceClassName
+		ref.ServiceClassExternalName = template.ServiceClassExternalName
+		ref.ServiceClassExternalID = template.ServiceClassExternalID
+		ref.ServiceClassName = template.ServiceClassName
+	}
+	if !ref.ClusterServicePlanSpecified() && !ref.ServicePlanSpecified() {
+		ref.ClusterServicePlanExternalName = template.ClusterServicePlanExternalName
+		ref.ClusterServicePlanExternalID = template.ClusterServicePlanExternalID
+		ref.ClusterServicePlanName = template.ClusterServicePlanName
+		ref.ServicePlanExternalName = template.ServicePlanExternalName
+		ref.ServicePlanExternalID = template.ServicePlanExternalID
+		ref.ServicePlanName = template.ServicePlanName
+	}
+}
+
+// mergeTemplateParameters merges the parameters of an instance on top of the
+// parameters of its template.
+func mergeTemplateParameters(params, templateParams *runtime.RawExtension) (*runtime.RawExtension, error) {
+	if templateParams == nil || len(templateParams.Raw) == 0 {
+		return params, nil
+	}
+	if params == nil || len(params.Raw) == 0 {
+		return templateParams.DeepCopy(), nil
+	}
+
+	paramsMap := make(map[string]interface{})
+	if err := json.Unmarshal(params.Raw, &paramsMap); err != nil {
+		return nil, fmt.Errorf("could not unmarshal instance parameters: %v", err)
+	}
+	templateParamsMap := make(map[string]interface{})
+	if err := json.Unmarshal(templateParams.Raw, &templateParamsMap); err != nil {
+		return nil, fmt.Errorf("could not unmarshal template parameters: %v", err)
+	}
+
+	result, err := json.Marshal(mergemap.Merge(templateParamsMap, paramsMap))
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: result}, nil
+}
+
+// InjectAPIReader injects the reader. Templates are read from the API server
+// rather than from the cache of the client, so that the webhook needs no
+// permission to list and watch the ServiceInstanceTemplates of the cluster.
+func (t *InstanceTemplate) InjectAPIReader(r client.Reader) error {
+	t.reader = r
+	return nil
+}
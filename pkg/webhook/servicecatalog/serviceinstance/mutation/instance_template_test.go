@@ -0,0 +1,209 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/appscode/jsonpatch"
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/mutation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	const namespace = "dummy"
+
+	template := &sc.ServiceInstanceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: namespace, Generation: 3},
+		Spec: sc.ServiceInstanceTemplateSpec{
+			PlanReference: sc.PlanReference{
+				ClusterServiceClassExternalName: "database",
+				ClusterServicePlanExternalName:  "small",
+			},
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"region":"eu","storage":{"size":10,"type":"ssd"}}`)},
+			ParametersFrom: []sc.ParametersFromSource{
+				{SecretKeyRef: &sc.SecretKeyReference{Name: "template-secret", Key: "params"}},
+			},
+		},
+	}
+
+	for tn, tc := range map[string]struct {
+		annotations map[string]string
+		spec        sc.ServiceInstanceSpec
+		expSpec     sc.ServiceInstanceSpec
+		expErr      *webhookutil.WebhookError
+	}{
+		"WithoutTemplateAnnotation": {
+			spec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{ClusterServiceClassExternalName: "queue"},
+			},
+			expSpec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{ClusterServiceClassExternalName: "queue"},
+			},
+		},
+		"CopiesClassPlanAndParameters": {
+			annotations: map[string]string{sc.ServiceInstanceTemplateAnnotation: "template"},
+			expSpec: sc.ServiceInstanceSpec{
+				PlanReference:  template.Spec.PlanReference,
+				Parameters:     template.Spec.Parameters,
+				ParametersFrom: template.Spec.ParametersFrom,
+			},
+		},
+		"AppliesInstanceDeltas": {
+			annotations: map[string]string{sc.ServiceInstanceTemplateAnnotation: "template"},
+			spec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{ClusterServicePlanExternalName: "large"},
+				Parameters:    &runtime.RawExtension{Raw: []byte(`{"storage":{"size":100}}`)},
+				ParametersFrom: []sc.ParametersFromSource{
+					{SecretKeyRef: &sc.SecretKeyReference{Name: "instance-secret", Key: "params"}},
+				},
+			},
+			expSpec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{
+					ClusterServiceClassExternalName: "database",
+					ClusterServicePlanExternalName:  "large",
+				},
+				Parameters: &runtime.RawExtension{Raw: []byte(`{"region":"eu","storage":{"size":100,"type":"ssd"}}`)},
+				ParametersFrom: []sc.ParametersFromSource{
+					{SecretKeyRef: &sc.SecretKeyReference{Name: "template-secret", Key: "params"}},
+					{SecretKeyRef: &sc.SecretKeyReference{Name: "instance-secret", Key: "params"}},
+				},
+			},
+		},
+		"KeepsClassOfInstance": {
+			annotations: map[string]string{sc.ServiceInstanceTemplateAnnotation: "template"},
+			spec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{ServiceClassName: "namespaced-class"},
+			},
+			expSpec: sc.ServiceInstanceSpec{
+				PlanReference: sc.PlanReference{
+					ServiceClassName:               "namespaced-class",
+					ClusterServicePlanExternalName: "small",
+				},
+				Parameters:     template.Spec.Parameters,
+				ParametersFrom: template.Spec.ParametersFrom,
+			},
+		},
+		"ErrorWhenTemplateDoesNotExist": {
+			annotations: map[string]string{sc.ServiceInstanceTemplateAnnotation: "missing"},
+			expErr:      webhookutil.NewWebhookError(`ServiceInstanceTemplate "missing" does not exist in namespace "dummy"`, http.StatusForbidden),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			fakeClient := fake.NewFakeClientWithScheme(newTestScheme(t), template.DeepCopy())
+			traced := webhookutil.NewTracedLogger(uuid.NewUUID())
+
+			it := mutation.InstanceTemplate{}
+			require.NoError(t, it.InjectAPIReader(fakeClient))
+
+			instance := &sc.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace, Annotations: tc.annotations},
+				Spec:       tc.spec,
+			}
+
+			// when
+			err := it.ApplyTemplate(context.Background(), instance, traced)
+
+			// then
+			if tc.expErr != nil {
+				assertMutateError(t, err, tc.expErr.Error(), tc.expErr.Code())
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.expSpec.PlanReference, instance.Spec.PlanReference)
+			assert.Equal(t, tc.expSpec.ParametersFrom, instance.Spec.ParametersFrom)
+			if tc.expSpec.Parameters == nil {
+				assert.Nil(t, instance.Spec.Parameters)
+			} else {
+				require.NotNil(t, instance.Spec.Parameters)
+				assert.JSONEq(t, string(tc.expSpec.Parameters.Raw), string(instance.Spec.Parameters.Raw))
+			}
+			if tc.annotations != nil {
+				assert.Equal(t, "3", instance.Annotations[sc.ServiceInstanceTemplateGenerationAnnotation])
+			}
+		})
+	}
+}
+
+func TestCreateUpdateHandlerReadsTemplateFromAPIReader(t *testing.T) {
+	// given
+	template := &sc.ServiceInstanceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "dummy"},
+		Spec: sc.ServiceInstanceTemplateSpec{
+			PlanReference: sc.PlanReference{
+				ClusterServiceClassExternalName: "database",
+				ClusterServicePlanExternalName:  "small",
+			},
+		},
+	}
+
+	decoder, err := admission.NewDecoder(newTestScheme(t))
+	require.NoError(t, err)
+
+	handler := mutation.NewCreateUpdateHandler()
+	require.NoError(t, handler.InjectDecoder(decoder))
+	// The cached client holds no template: it must only be read through the reader.
+	require.NoError(t, handler.InjectClient(fake.NewFakeClientWithScheme(newTestScheme(t))))
+	require.NoError(t, handler.InjectAPIReader(fake.NewFakeClientWithScheme(newTestScheme(t), template)))
+
+	req := admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			Name:      "instance",
+			Namespace: "dummy",
+			Kind: metav1.GroupVersionKind{
+				Kind:    "ServiceInstance",
+				Version: "v1beta1",
+				Group:   "servicecatalog.k8s.io",
+			},
+			Object: runtime.RawExtension{Raw: []byte(`{
+				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+				"kind": "ServiceInstance",
+				"metadata": {
+					"name": "instance",
+					"namespace": "dummy",
+					"annotations": {"servicecatalog.k8s.io/instance-template": "template"}
+				},
+				"spec": {"externalID": "instance-id"}
+			}`)},
+		},
+	}
+
+	// when
+	resp := handler.Handle(context.Background(), req)
+
+	// then
+	assert.True(t, resp.Allowed, "%v", resp.Result)
+	assert.Contains(t, resp.Patches, jsonpatch.Operation{
+		Operation: "add",
+		Path:      "/spec/clusterServiceClassExternalName",
+		Value:     "database",
+	})
+}
@@ -45,12 +45,18 @@ type SpecValidationHandler struct {
 var _ admission.Handler = &SpecValidationHandler{}
 var _ admission.DecoderInjector = &SpecValidationHandler{}
 var _ inject.Client = &SpecValidationHandler{}
-
-// NewSpecValidationHandler creates new SpecValidationHandler and initializes validators list
-func NewSpecValidationHandler() *SpecValidationHandler {
+var _ inject.APIReader = &SpecValidationHandler{}
+
+// NewSpecValidationHandler creates new SpecValidationHandler and initializes
+// validators list. Parameters larger than maxParametersSize bytes are
+// rejected, 0 disables the limit. parametersFromSecretPolicy is what to do
+// when a Secret referenced by the parameters does not exist. The names of new
+// instances must match namePattern, unless it is nil. externalIDCollisionPolicy
+// is what to do when a new instance has the external ID of an existing one.
+func NewSpecValidationHandler(maxParametersSize int, parametersFromSecretPolicy webhookutil.ParametersFromSecretPolicy, namePattern *webhookutil.NamePattern, externalIDCollisionPolicy webhookutil.ExternalIDCollisionPolicy) *SpecValidationHandler {
 	return &SpecValidationHandler{
-		UpdateValidators: []Validator{&StaticUpdate{}, &DenyPlanChangeIfNotUpdatable{}},
-		CreateValidators: []Validator{&StaticCreate{}},
+		UpdateValidators: []Validator{&StaticUpdate{}, &DenyOversizedParameters{MaxParametersSize: maxParametersSize}, &DenyInvalidParameterTemplates{}, &DenyCrossNamespaceReferences{}, &DenyPlanChangeIfNotUpdatable{}, &ValidateUpdateParameters{}, &CheckParametersFromSecrets{Policy: parametersFromSecretPolicy}},
+		CreateValidators: []Validator{&StaticCreate{}, &DenyNonConformingName{NamePattern: namePattern}, &DenyExternalIDCollisions{Policy: externalIDCollisionPolicy}, &DenyOversizedParameters{MaxParametersSize: maxParametersSize}, &DenyInvalidParameterTemplates{}, &DenyCrossNamespaceReferences{}, &CheckParametersFromSecrets{Policy: parametersFromSecretPolicy}},
 	}
 }
 
@@ -144,3 +150,21 @@ func (h *SpecValidationHandler) InjectClient(c client.Client) error {
 
 	return nil
 }
+
+// InjectAPIReader injects the reader into the handlers
+func (h *SpecValidationHandler) InjectAPIReader(r client.Reader) error {
+	for _, v := range h.CreateValidators {
+		_, err := inject.APIReaderInto(r, v)
+		if err != nil {
+			return err
+		}
+	}
+	for _, v := range h.UpdateValidators {
+		_, err := inject.APIReaderInto(r, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
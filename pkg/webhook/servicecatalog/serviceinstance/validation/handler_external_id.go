@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyExternalIDCollisions handles ServiceInstance validation
+type DenyExternalIDCollisions struct {
+	client client.Client
+
+	// Policy is what to do when the external ID of a new instance is the
+	// external ID of an existing instance
+	Policy webhookutil.ExternalIDCollisionPolicy
+}
+
+var _ Validator = &DenyExternalIDCollisions{}
+var _ inject.Client = &DenyExternalIDCollisions{}
+
+// Validate checks that the external ID of a new instance is not the external
+// ID of an existing instance of any namespace, such as an instance restored
+// from a backup next to the instance it was backed up from. The broker knows
+// an instance by its external ID only, so requests for both instances would
+// conflict.
+func (h *DenyExternalIDCollisions) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyExternalIDCollisions")
+
+	if h.Policy == webhookutil.ExternalIDCollisionPolicyOff || si.Spec.ExternalID == "" {
+		return nil
+	}
+
+	instances := &sc.ServiceInstanceList{}
+	if err := h.client.List(ctx, instances); err != nil {
+		traced.Errorf("Could not list ServiceInstances: %v", err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusInternalServerError)
+	}
+
+	namespace := si.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	for _, existing := range instances.Items {
+		if existing.Spec.ExternalID != si.Spec.ExternalID || (existing.Namespace == namespace && existing.Name == si.Name) {
+			continue
+		}
+		msg := fmt.Sprintf("spec.externalID %q is already the external ID of ServiceInstance %s/%s; remove it from the instance to have a new one generated", si.Spec.ExternalID, existing.Namespace, existing.Name)
+		if h.Policy == webhookutil.ExternalIDCollisionPolicyDeny {
+			traced.Error(msg)
+			return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+		}
+		traced.Infof("Warning: %s; the ServiceInstance is admitted, but the broker will not be able to tell both instances apart", msg)
+		break
+	}
+
+	traced.Info("DenyExternalIDCollisions passed")
+	return nil
+}
+
+// InjectClient injects the client
+func (h *DenyExternalIDCollisions) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyExternalIDCollisions(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		policy          webhookutil.ExternalIDCollisionPolicy
+		externalID      string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Unique external ID": {
+			policy:          webhookutil.ExternalIDCollisionPolicyDeny,
+			externalID:      "a1b2c3d4",
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"External ID of an instance of another namespace with Deny policy": {
+			policy:          webhookutil.ExternalIDCollisionPolicyDeny,
+			externalID:      "e5f6a7b8",
+			responseAllowed: false,
+			responseReason:  `spec.externalID "e5f6a7b8" is already the external ID of ServiceInstance other-ns/restored-instance`,
+		},
+		"External ID of an instance of another namespace with Warn policy": {
+			policy:          webhookutil.ExternalIDCollisionPolicyWarn,
+			externalID:      "e5f6a7b8",
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"External ID of an instance of another namespace with Off policy": {
+			policy:          webhookutil.ExternalIDCollisionPolicyOff,
+			externalID:      "e5f6a7b8",
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyExternalIDCollisions{Policy: test.policy}}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&sc.ServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: "restored-instance", Namespace: "other-ns"},
+					Spec:       sc.ServiceInstanceSpec{ExternalID: "e5f6a7b8"},
+				},
+			)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectClient(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+						"spec": {"externalID": "` + test.externalID + `"}
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyNonConformingName handles ServiceInstance validation
+type DenyNonConformingName struct {
+	// NamePattern is the pattern that the names of new ServiceInstances must
+	// match, nil disables the check
+	NamePattern *webhookutil.NamePattern
+}
+
+var _ Validator = &DenyNonConformingName{}
+
+// Validate checks that the name of a new instance matches NamePattern
+func (h *DenyNonConformingName) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyNonConformingName")
+
+	name := si.Name
+	if name == "" {
+		name = req.Name
+	}
+	if err := h.NamePattern.Validate("ServiceInstance", name); err != nil {
+		traced.Info(err.Error())
+		return err
+	}
+	return nil
+}
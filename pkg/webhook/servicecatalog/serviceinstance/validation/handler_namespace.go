@@ -0,0 +1,145 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	admissionTypes "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyCrossNamespaceReferences handles ServiceInstance validation
+type DenyCrossNamespaceReferences struct {
+	decoder *admission.Decoder
+	client  client.Client
+}
+
+// referencedName is the name of a class or plan set in a field of an instance
+type referencedName struct {
+	field string
+	name  string
+}
+
+var _ Validator = &DenyCrossNamespaceReferences{}
+var _ admission.DecoderInjector = &DenyCrossNamespaceReferences{}
+var _ inject.Client = &DenyCrossNamespaceReferences{}
+
+// Validate checks that the namespaced ServiceClass and ServicePlan referenced
+// by an instance are those of its own namespace. Names qualified with another
+// namespace are rejected, and class or plan refs that are being set must name
+// objects that exist in the namespace of the instance.
+func (h *DenyCrossNamespaceReferences) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyCrossNamespaceReferences")
+
+	namespace := si.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+
+	names := []referencedName{
+		{"spec.serviceClassName", si.Spec.ServiceClassName},
+		{"spec.servicePlanName", si.Spec.ServicePlanName},
+	}
+	if si.Spec.ServiceClassRef != nil {
+		names = append(names, referencedName{"spec.serviceClassRef.name", si.Spec.ServiceClassRef.Name})
+	}
+	if si.Spec.ServicePlanRef != nil {
+		names = append(names, referencedName{"spec.servicePlanRef.name", si.Spec.ServicePlanRef.Name})
+	}
+	for _, n := range names {
+		if strings.Contains(n.name, "/") {
+			msg := fmt.Sprintf("%s %q refers to another namespace; an instance can only use the classes and plans of its own namespace %q", n.field, n.name, namespace)
+			traced.Error(msg)
+			return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+		}
+	}
+
+	if req.Operation != admissionTypes.Update {
+		return nil
+	}
+
+	origInstance := &sc.ServiceInstance{}
+	if err := h.decoder.DecodeRaw(req.OldObject, origInstance); err != nil {
+		traced.Errorf("Could not decode oldObject: %v", err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+	}
+
+	// Only the refs that are being set are checked, so that instances whose
+	// class or plan has since been removed can still be updated and deleted.
+	if ref := si.Spec.ServiceClassRef; ref != nil && !localObjectReferenceEqual(ref, origInstance.Spec.ServiceClassRef) {
+		if err := h.checkExists(ctx, namespace, "ServiceClass", ref.Name, &sc.ServiceClass{}, traced); err != nil {
+			return err
+		}
+	}
+	if ref := si.Spec.ServicePlanRef; ref != nil && !localObjectReferenceEqual(ref, origInstance.Spec.ServicePlanRef) {
+		if err := h.checkExists(ctx, namespace, "ServicePlan", ref.Name, &sc.ServicePlan{}, traced); err != nil {
+			return err
+		}
+	}
+
+	traced.Info("DenyCrossNamespaceReferences passed")
+	return nil
+}
+
+func (h *DenyCrossNamespaceReferences) checkExists(ctx context.Context, namespace, kind, name string, obj runtime.Object, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := h.client.Get(ctx, key, obj)
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsNotFound(err):
+		msg := fmt.Sprintf("%s %q does not exist in namespace %q; an instance can only use the classes and plans of its own namespace", kind, name, namespace)
+		traced.Error(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	default:
+		traced.Errorf("Could not get %s %q: %v", kind, name, err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func localObjectReferenceEqual(a, b *sc.LocalObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name
+}
+
+// InjectDecoder injects the decoder
+func (h *DenyCrossNamespaceReferences) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// InjectClient injects the client
+func (h *DenyCrossNamespaceReferences) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
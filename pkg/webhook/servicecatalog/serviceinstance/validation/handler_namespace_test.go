@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyCrossNamespaceReferences(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	sch, err := sc.SchemeBuilderRuntime.Build()
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(sch)
+	require.NoError(t, err)
+
+	const oldObject = `{
+		"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+		"spec": {"serviceClassName": "class-test", "servicePlanName": "plan-test"}
+	}`
+
+	tests := map[string]struct {
+		operation       admissionv1beta1.Operation
+		object          string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Create with names of the own namespace": {
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {"serviceClassName": "class-test", "servicePlanName": "plan-test"}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Create with a class of another namespace": {
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {"serviceClassName": "other-ns/class-test", "servicePlanName": "plan-test"}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.serviceClassName "other-ns/class-test" refers to another namespace`,
+		},
+		"Create with a plan of another namespace": {
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {"serviceClassName": "class-test", "servicePlanName": "other-ns/plan-test"}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.servicePlanName "other-ns/plan-test" refers to another namespace`,
+		},
+		"Update setting refs of the own namespace": {
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"serviceClassRef": {"name": "class-test"}, "servicePlanRef": {"name": "plan-test"}
+				}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Update setting a class ref that only exists in another namespace": {
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"serviceClassRef": {"name": "class-other"}
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `ServiceClass "class-other" does not exist in namespace "ns-test"`,
+		},
+		"Update setting a plan ref that only exists in another namespace": {
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"serviceClassRef": {"name": "class-test"}, "servicePlanRef": {"name": "plan-other"}
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `ServicePlan "plan-other" does not exist in namespace "ns-test"`,
+		},
+		"Update setting a class ref qualified with another namespace": {
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"serviceClassRef": {"name": "other-ns/class-other"}
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.serviceClassRef.name "other-ns/class-other" refers to another namespace`,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyCrossNamespaceReferences{}}
+			handler.UpdateValidators = []validation.Validator{&validation.DenyCrossNamespaceReferences{}}
+
+			fakeClient := fake.NewFakeClientWithScheme(sch,
+				&sc.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "class-test", Namespace: "ns-test"}},
+				&sc.ServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "plan-test", Namespace: "ns-test"}},
+				&sc.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "class-other", Namespace: "other-ns"}},
+				&sc.ServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "plan-other", Namespace: "other-ns"}},
+			)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectClient(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: test.operation,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object:    runtime.RawExtension{Raw: []byte(test.object)},
+					OldObject: runtime.RawExtension{Raw: []byte(oldObject)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
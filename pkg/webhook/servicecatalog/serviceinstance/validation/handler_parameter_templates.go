@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+	"github.com/kubernetes-sigs/service-catalog/pkg/paramtemplate"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	admissionTypes "k8s.io/api/admission/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyInvalidParameterTemplates handles ServiceInstance validation
+type DenyInvalidParameterTemplates struct {
+	decoder *admission.Decoder
+}
+
+var _ Validator = &DenyInvalidParameterTemplates{}
+var _ admission.DecoderInjector = &DenyInvalidParameterTemplates{}
+
+// Validate checks that the templates in the parameters of an instance are
+// valid when the ParameterTemplates feature is enabled, so that an invalid
+// template is rejected instead of failing the provisioning. Updates which
+// leave the parameters unchanged are allowed.
+func (h *DenyInvalidParameterTemplates) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParameterTemplates) || si.Spec.Parameters == nil {
+		return nil
+	}
+	traced.Info("Starting validation - DenyInvalidParameterTemplates")
+
+	if req.Operation == admissionTypes.Update {
+		origInstance := &sc.ServiceInstance{}
+		if err := h.decoder.DecodeRaw(req.OldObject, origInstance); err != nil {
+			traced.Errorf("Could not decode oldObject: %v", err)
+			return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+		}
+		if apiequality.Semantic.DeepEqual(si.Spec.Parameters, origInstance.Spec.Parameters) {
+			traced.Info("DenyInvalidParameterTemplates passed - parameters are unchanged.")
+			return nil
+		}
+	}
+
+	if err := paramtemplate.Validate(si.Spec.Parameters.Raw); err != nil {
+		msg := fmt.Sprintf("The spec.parameters of the ServiceInstance hold an invalid template: %v", err)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+	return nil
+}
+
+// InjectDecoder injects the decoder
+func (h *DenyInvalidParameterTemplates) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
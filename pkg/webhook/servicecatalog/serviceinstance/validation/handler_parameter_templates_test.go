@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyInvalidParameterTemplates(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		operation       admissionv1beta1.Operation
+		enabled         bool
+		oldParameters   string
+		newParameters   string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Create with valid templates": {
+			operation:       admissionv1beta1.Create,
+			enabled:         true,
+			newParameters:   `{"project": "{{ .Namespace }}", "team": "{{ .Labels.team }}"}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Create with an unsupported template": {
+			operation:       admissionv1beta1.Create,
+			enabled:         true,
+			newParameters:   `{"project": "{{ printf \"%s\" .Namespace }}"}`,
+			responseAllowed: false,
+			responseReason:  "The spec.parameters of the ServiceInstance hold an invalid template: unsupported template",
+		},
+		"Create with an unterminated template": {
+			operation:       admissionv1beta1.Create,
+			enabled:         true,
+			newParameters:   `{"project": "{{ .Namespace"}`,
+			responseAllowed: false,
+			responseReason:  "unterminated template",
+		},
+		"Create with the feature disabled": {
+			operation:       admissionv1beta1.Create,
+			enabled:         false,
+			newParameters:   `{"project": "{{ printf \"%s\" .Namespace }}"}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Update with unchanged invalid templates": {
+			operation:       admissionv1beta1.Update,
+			enabled:         true,
+			oldParameters:   `{"uid": "{{ .UID }}"}`,
+			newParameters:   `{"uid": "{{ .UID }}"}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Update with changed invalid templates": {
+			operation:       admissionv1beta1.Update,
+			enabled:         true,
+			oldParameters:   `{"uid": "fixed"}`,
+			newParameters:   `{"uid": "{{ .UID }}"}`,
+			responseAllowed: false,
+			responseReason:  "unsupported template {{ .UID }}",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			err := utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.ParameterTemplates, test.enabled))
+			require.NoError(t, err)
+			defer utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParameterTemplates))
+
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyInvalidParameterTemplates{}}
+			handler.UpdateValidators = []validation.Validator{&validation.DenyInvalidParameterTemplates{}}
+			err = handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: test.operation,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: instanceWithParameters(test.newParameters)},
+				},
+			}
+			if test.oldParameters != "" {
+				request.OldObject = runtime.RawExtension{Raw: instanceWithParameters(test.oldParameters)}
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
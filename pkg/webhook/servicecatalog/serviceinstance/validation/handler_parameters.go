@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util"
+	"github.com/kubernetes-sigs/service-catalog/pkg/util/jsonschema"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidateUpdateParameters handles ServiceInstance validation
+type ValidateUpdateParameters struct {
+	decoder *admission.Decoder
+	client  client.Client
+}
+
+var _ admission.DecoderInjector = &ValidateUpdateParameters{}
+var _ inject.Client = &ValidateUpdateParameters{}
+
+// Validate checks the parameters of an updated instance against the update
+// schema of its plan, or against the provision schema when the plan only
+// advertises that one
+func (h *ValidateUpdateParameters) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - ValidateUpdateParameters")
+
+	origInstance := &sc.ServiceInstance{}
+	if err := h.decoder.DecodeRaw(req.OldObject, origInstance); err != nil {
+		traced.Errorf("Could not decode oldObject: %v", err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+	}
+
+	if apiequality.Semantic.DeepEqual(si.Spec.Parameters, origInstance.Spec.Parameters) &&
+		si.Spec.PlanReference == origInstance.Spec.PlanReference {
+		traced.Info("ValidateUpdateParameters passed - parameters and plan are unchanged.")
+		return nil
+	}
+
+	if len(si.Spec.ParametersFrom) > 0 || len(si.Spec.SecretParameterRefs) > 0 {
+		// The values held in Secrets are not visible here, so a partial
+		// check could reject parameters which are complete once merged
+		traced.Info("ValidateUpdateParameters skipped - parameters are partially read from Secrets.")
+		return nil
+	}
+
+	schema, err := h.getUpdateSchema(ctx, si, traced)
+	if err != nil {
+		traced.Infof("Could not determine the parameter schema of the plan: %v", err)
+		return nil // the controller reports plans that can not be resolved
+	}
+	if schema == nil || len(schema.Raw) == 0 {
+		traced.Info("ValidateUpdateParameters passed - the plan does not advertise a parameter schema.")
+		return nil
+	}
+
+	var parameters []byte
+	if si.Spec.Parameters != nil {
+		parameters = si.Spec.Parameters.Raw
+	}
+	violations, err := jsonschema.Validate(schema.Raw, parameters)
+	if err != nil {
+		traced.Infof("Could not validate parameters against the plan schema: %v", err)
+		return nil // a malformed broker schema must not block updates
+	}
+	if len(violations) > 0 {
+		msg := fmt.Sprintf("The parameters of ServiceInstance %v/%v are not valid for the plan: %s", si.Namespace, si.Name, strings.Join(violations, "; "))
+		traced.Error(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// getUpdateSchema returns the schema that update parameters must satisfy,
+// which is the update schema, falling back to the create schema
+func (h *ValidateUpdateParameters) getUpdateSchema(ctx context.Context, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) (*runtime.RawExtension, error) {
+	if si.Spec.ClusterServiceClassSpecified() {
+		plan, err := h.getClusterServicePlan(ctx, si, traced)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Spec.InstanceUpdateParameterSchema != nil {
+			return plan.Spec.InstanceUpdateParameterSchema, nil
+		}
+		return plan.Spec.InstanceCreateParameterSchema, nil
+	}
+
+	if si.Spec.ServiceClassSpecified() {
+		plan, err := h.getServicePlan(ctx, si, traced)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Spec.InstanceUpdateParameterSchema != nil {
+			return plan.Spec.InstanceUpdateParameterSchema, nil
+		}
+		return plan.Spec.InstanceCreateParameterSchema, nil
+	}
+
+	return nil, fmt.Errorf("class not specified on ServiceInstance")
+}
+
+func (h *ValidateUpdateParameters) getClusterServicePlan(ctx context.Context, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) (*sc.ClusterServicePlan, error) {
+	name := si.Spec.ClusterServicePlanName
+	if si.Spec.ClusterServicePlanRef != nil {
+		name = si.Spec.ClusterServicePlanRef.Name
+	}
+	if name != "" {
+		traced.V(4).Infof("Fetching ClusterServicePlan by k8s name %q", name)
+		csp := &sc.ClusterServicePlan{}
+		err := h.client.Get(ctx, types.NamespacedName{Name: name}, csp)
+		return csp, err
+	}
+
+	// Plan external names and IDs are only unique within their class
+	if si.Spec.ClusterServiceClassRef == nil {
+		return nil, fmt.Errorf("the ClusterServiceClass of the instance is not resolved yet")
+	}
+
+	filterLabel := si.Spec.GetClusterServicePlanFilterLabelName()
+	filterValue := si.Spec.GetSpecifiedClusterServicePlan()
+	traced.V(4).Infof("Fetching ClusterServicePlan filtered by %q = %q", filterLabel, filterValue)
+
+	plans := &sc.ClusterServicePlanList{}
+	err := h.client.List(ctx, plans, client.MatchingLabels(map[string]string{
+		filterLabel: util.GenerateSHA(filterValue),
+		sc.GroupName + "/" + sc.FilterSpecClusterServiceClassRefName: util.GenerateSHA(si.Spec.ClusterServiceClassRef.Name),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(plans.Items) != 1 {
+		return nil, fmt.Errorf("could not find a single ClusterServicePlan with %q = %q, found %v", filterLabel, filterValue, len(plans.Items))
+	}
+	return &plans.Items[0], nil
+}
+
+func (h *ValidateUpdateParameters) getServicePlan(ctx context.Context, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) (*sc.ServicePlan, error) {
+	name := si.Spec.ServicePlanName
+	if si.Spec.ServicePlanRef != nil {
+		name = si.Spec.ServicePlanRef.Name
+	}
+	if name != "" {
+		traced.V(4).Infof("Fetching ServicePlan by k8s name %q", name)
+		sp := &sc.ServicePlan{}
+		err := h.client.Get(ctx, types.NamespacedName{Namespace: si.Namespace, Name: name}, sp)
+		return sp, err
+	}
+
+	// Plan external names and IDs are only unique within their class
+	if si.Spec.ServiceClassRef == nil {
+		return nil, fmt.Errorf("the ServiceClass of the instance is not resolved yet")
+	}
+
+	filterLabel := si.Spec.GetServicePlanFilterLabelName()
+	filterValue := si.Spec.GetSpecifiedServicePlan()
+	traced.V(4).Infof("Fetching ServicePlan filtered by %q = %q", filterLabel, filterValue)
+
+	plans := &sc.ServicePlanList{}
+	err := h.client.List(ctx, plans, client.InNamespace(si.Namespace), client.MatchingLabels(map[string]string{
+		filterLabel: util.GenerateSHA(filterValue),
+		sc.GroupName + "/" + sc.FilterSpecServiceClassRefName: util.GenerateSHA(si.Spec.ServiceClassRef.Name),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(plans.Items) != 1 {
+		return nil, fmt.Errorf("could not find a single ServicePlan with %q = %q, found %v", filterLabel, filterValue, len(plans.Items))
+	}
+	return &plans.Items[0], nil
+}
+
+// InjectDecoder injects the decoder
+func (h *ValidateUpdateParameters) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// InjectClient injects the client
+func (h *ValidateUpdateParameters) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
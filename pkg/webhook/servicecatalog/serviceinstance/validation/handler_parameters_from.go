@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	admissionTypes "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// CheckParametersFromSecrets handles ServiceInstance validation
+type CheckParametersFromSecrets struct {
+	decoder *admission.Decoder
+	reader  client.Reader
+
+	// Policy is what to do when a referenced Secret does not exist
+	Policy webhookutil.ParametersFromSecretPolicy
+}
+
+var _ Validator = &CheckParametersFromSecrets{}
+var _ admission.DecoderInjector = &CheckParametersFromSecrets{}
+var _ inject.APIReader = &CheckParametersFromSecrets{}
+
+// Validate checks that the Secrets referenced by the parametersFrom and the
+// secretParameterRefs of an instance exist in its namespace. On updates only
+// the newly referenced Secrets are checked, so that an instance whose Secret
+// has since been deleted can still be updated and deleted.
+func (h *CheckParametersFromSecrets) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - CheckParametersFromSecrets")
+
+	if h.Policy == webhookutil.ParametersFromSecretPolicyOff {
+		return nil
+	}
+
+	refs := instanceSecretReferences(si)
+	if req.Operation == admissionTypes.Update {
+		origInstance := &sc.ServiceInstance{}
+		if err := h.decoder.DecodeRaw(req.OldObject, origInstance); err != nil {
+			traced.Errorf("Could not decode oldObject: %v", err)
+			return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+		}
+		refs = newSecretReferences(refs, instanceSecretReferences(origInstance))
+	}
+
+	namespace := si.Namespace
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	if err := webhookutil.ValidateSecretReferencesExist(ctx, h.reader, "ServiceInstance", namespace, refs, h.Policy, traced); err != nil {
+		return err
+	}
+
+	traced.Info("CheckParametersFromSecrets passed")
+	return nil
+}
+
+// instanceSecretReferences returns the Secrets referenced by the parameters
+// of an instance.
+func instanceSecretReferences(si *sc.ServiceInstance) []webhookutil.SecretReference {
+	var refs []webhookutil.SecretReference
+	for i, source := range si.Spec.ParametersFrom {
+		if source.SecretKeyRef != nil {
+			refs = append(refs, webhookutil.SecretReference{
+				Field: fmt.Sprintf("spec.parametersFrom[%d].secretKeyRef", i),
+				Name:  source.SecretKeyRef.Name,
+			})
+		}
+	}
+	for i, ref := range si.Spec.SecretParameterRefs {
+		refs = append(refs, webhookutil.SecretReference{
+			Field: fmt.Sprintf("spec.secretParameterRefs[%d].secretKeyRef", i),
+			Name:  ref.SecretKeyRef.Name,
+		})
+	}
+	return refs
+}
+
+// newSecretReferences returns the references to Secrets that are not
+// referenced by the old references.
+func newSecretReferences(refs, oldRefs []webhookutil.SecretReference) []webhookutil.SecretReference {
+	old := make(map[string]bool, len(oldRefs))
+	for _, ref := range oldRefs {
+		old[ref.Name] = true
+	}
+	var added []webhookutil.SecretReference
+	for _, ref := range refs {
+		if !old[ref.Name] {
+			added = append(added, ref)
+		}
+	}
+	return added
+}
+
+// InjectDecoder injects the decoder
+func (h *CheckParametersFromSecrets) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// InjectAPIReader injects the reader. Secrets are read from the API server
+// rather than from the cache of the client, so that the webhook does not
+// watch all the Secrets of the cluster.
+func (h *CheckParametersFromSecrets) InjectAPIReader(r client.Reader) error {
+	h.reader = r
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerCheckParametersFromSecrets(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	const oldObject = `{
+		"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+		"spec": {
+			"serviceClassName": "class-test", "servicePlanName": "plan-test",
+			"parametersFrom": [{"secretKeyRef": {"name": "deleted-secret", "key": "params"}}]
+		}
+	}`
+
+	tests := map[string]struct {
+		policy          webhookutil.ParametersFromSecretPolicy
+		operation       admissionv1beta1.Operation
+		object          string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Create with an existing Secret": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "test-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Create with a missing Secret and the Deny policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "missing-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.parametersFrom[0].secretKeyRef refers to Secret "missing-secret", which does not exist in namespace "ns-test"`,
+		},
+		"Create with a missing secretParameterRefs Secret and the Deny policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"secretParameterRefs": [{"name": "password", "secretKeyRef": {"name": "missing-secret", "key": "password"}}]
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.secretParameterRefs[0].secretKeyRef refers to Secret "missing-secret", which does not exist in namespace "ns-test"`,
+		},
+		"Create with a Secret of another namespace and the Deny policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "other-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.parametersFrom[0].secretKeyRef refers to Secret "other-secret", which does not exist in namespace "ns-test"`,
+		},
+		"Create with a missing Secret and the Warn policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyWarn,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "missing-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Create with a missing Secret and the Off policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyOff,
+			operation: admissionv1beta1.Create,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "missing-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Update keeping a deleted Secret and the Deny policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [{"secretKeyRef": {"name": "deleted-secret", "key": "params"}}]
+				}
+			}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Update adding a missing Secret and the Deny policy": {
+			policy:    webhookutil.ParametersFromSecretPolicyDeny,
+			operation: admissionv1beta1.Update,
+			object: `{
+				"metadata": {"name": "test-serviceinstance", "namespace": "ns-test"},
+				"spec": {
+					"serviceClassName": "class-test", "servicePlanName": "plan-test",
+					"parametersFrom": [
+						{"secretKeyRef": {"name": "deleted-secret", "key": "params"}},
+						{"secretKeyRef": {"name": "missing-secret", "key": "params"}}
+					]
+				}
+			}`,
+			responseAllowed: false,
+			responseReason:  `spec.parametersFrom[1].secretKeyRef refers to Secret "missing-secret", which does not exist in namespace "ns-test"`,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.CheckParametersFromSecrets{Policy: test.policy}}
+			handler.UpdateValidators = []validation.Validator{&validation.CheckParametersFromSecrets{Policy: test.policy}}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "ns-test"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: "other-ns"}},
+			)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectAPIReader(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: test.operation,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object:    runtime.RawExtension{Raw: []byte(test.object)},
+					OldObject: runtime.RawExtension{Raw: []byte(oldObject)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	admissionTypes "k8s.io/api/admission/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DenyOversizedParameters handles ServiceInstance validation
+type DenyOversizedParameters struct {
+	decoder *admission.Decoder
+
+	// MaxParametersSize is the maximum size in bytes of the parameters, 0
+	// disables the check
+	MaxParametersSize int
+}
+
+var _ Validator = &DenyOversizedParameters{}
+var _ admission.DecoderInjector = &DenyOversizedParameters{}
+
+// Validate checks that the parameters of an instance are not larger than
+// MaxParametersSize. Updates which leave the parameters unchanged are allowed,
+// so that lowering the limit does not block unrelated changes.
+func (h *DenyOversizedParameters) Validate(ctx context.Context, req admission.Request, si *sc.ServiceInstance, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	traced.Info("Starting validation - DenyOversizedParameters")
+
+	if req.Operation == admissionTypes.Update {
+		origInstance := &sc.ServiceInstance{}
+		if err := h.decoder.DecodeRaw(req.OldObject, origInstance); err != nil {
+			traced.Errorf("Could not decode oldObject: %v", err)
+			return webhookutil.NewWebhookError(err.Error(), http.StatusBadRequest)
+		}
+		if apiequality.Semantic.DeepEqual(si.Spec.Parameters, origInstance.Spec.Parameters) {
+			traced.Info("DenyOversizedParameters passed - parameters are unchanged.")
+			return nil
+		}
+	}
+
+	if err := webhookutil.ValidateParametersSize("ServiceInstance", si.Spec.Parameters, h.MaxParametersSize); err != nil {
+		traced.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// InjectDecoder injects the decoder
+func (h *DenyOversizedParameters) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
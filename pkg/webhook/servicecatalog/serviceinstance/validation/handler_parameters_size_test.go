@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerDenyOversizedParameters(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		operation         admissionv1beta1.Operation
+		maxParametersSize int
+		oldParameters     string
+		newParameters     string
+		responseAllowed   bool
+		responseReason    string
+	}{
+		"Create with parameters under the limit": {
+			operation:         admissionv1beta1.Create,
+			maxParametersSize: 20,
+			newParameters:     `{"size": 2}`,
+			responseAllowed:   true,
+			responseReason:    "ServiceInstance validation successful",
+		},
+		"Create with parameters over the limit": {
+			operation:         admissionv1beta1.Create,
+			maxParametersSize: 10,
+			newParameters:     `{"size": 2}`,
+			responseAllowed:   false,
+			responseReason:    "The spec.parameters of the ServiceInstance are 11 bytes, which exceeds the limit of 10 bytes",
+		},
+		"Create with the limit disabled": {
+			operation:         admissionv1beta1.Create,
+			maxParametersSize: 0,
+			newParameters:     `{"size": 2}`,
+			responseAllowed:   true,
+			responseReason:    "ServiceInstance validation successful",
+		},
+		"Update with unchanged parameters over the limit": {
+			operation:         admissionv1beta1.Update,
+			maxParametersSize: 10,
+			oldParameters:     `{"size": 2}`,
+			newParameters:     `{"size": 2}`,
+			responseAllowed:   true,
+			responseReason:    "ServiceInstance validation successful",
+		},
+		"Update with changed parameters over the limit": {
+			operation:         admissionv1beta1.Update,
+			maxParametersSize: 10,
+			oldParameters:     `{"size": 2}`,
+			newParameters:     `{"size": 20}`,
+			responseAllowed:   false,
+			responseReason:    "The spec.parameters of the ServiceInstance are 12 bytes, which exceeds the limit of 10 bytes",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.DenyOversizedParameters{MaxParametersSize: test.maxParametersSize}}
+			handler.UpdateValidators = []validation.Validator{&validation.DenyOversizedParameters{MaxParametersSize: test.maxParametersSize}}
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: test.operation,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: instanceWithParameters(test.newParameters)},
+				},
+			}
+			if test.oldParameters != "" {
+				request.OldObject = runtime.RawExtension{Raw: instanceWithParameters(test.oldParameters)}
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
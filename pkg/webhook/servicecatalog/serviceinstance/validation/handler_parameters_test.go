@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerValidateUpdateParameters(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	createSchema := `{"type": "object", "required": ["size"], "properties": {"size": {"type": "integer"}}}`
+	updateSchema := `{"type": "object", "additionalProperties": false, "properties": {"size": {"type": "integer", "maximum": 10}}}`
+
+	sch, err := sc.SchemeBuilderRuntime.Build()
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(sch)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		createSchema    string
+		updateSchema    string
+		oldParameters   string
+		newParameters   string
+		responseAllowed bool
+		responseReason  string
+	}{
+		"Parameters unchanged": {
+			updateSchema:    updateSchema,
+			oldParameters:   `{"size": 20}`,
+			newParameters:   `{"size": 20}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Parameters valid for the update schema": {
+			createSchema:    createSchema,
+			updateSchema:    updateSchema,
+			oldParameters:   `{"size": 2}`,
+			newParameters:   `{"size": 5}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"Parameters invalid for the update schema": {
+			createSchema:    createSchema,
+			updateSchema:    updateSchema,
+			oldParameters:   `{"size": 2}`,
+			newParameters:   `{"size": 20, "color": "red"}`,
+			responseAllowed: false,
+			responseReason:  `additional property "color" is not allowed; size: must be less than or equal to 10`,
+		},
+		"Update schema absent, parameters invalid for the create schema": {
+			createSchema:    createSchema,
+			oldParameters:   `{"size": 2}`,
+			newParameters:   `{"color": "red"}`,
+			responseAllowed: false,
+			responseReason:  `missing required property "size"`,
+		},
+		"Update schema absent, parameters valid for the create schema": {
+			createSchema:    createSchema,
+			oldParameters:   `{"size": 2}`,
+			newParameters:   `{"size": 3}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+		"No schemas": {
+			oldParameters:   `{"size": 2}`,
+			newParameters:   `{"anything": "goes"}`,
+			responseAllowed: true,
+			responseReason:  "ServiceInstance validation successful",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			plan := &sc.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "csp-test",
+				},
+			}
+			if test.createSchema != "" {
+				plan.Spec.InstanceCreateParameterSchema = &runtime.RawExtension{Raw: []byte(test.createSchema)}
+			}
+			if test.updateSchema != "" {
+				plan.Spec.InstanceUpdateParameterSchema = &runtime.RawExtension{Raw: []byte(test.updateSchema)}
+			}
+
+			handler := validation.SpecValidationHandler{}
+			handler.UpdateValidators = []validation.Validator{&validation.ValidateUpdateParameters{}}
+			fakeClient := fake.NewFakeClientWithScheme(sch, plan)
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+			err = handler.InjectClient(fakeClient)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: admissionv1beta1.Update,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object:    runtime.RawExtension{Raw: instanceWithParameters(test.newParameters)},
+					OldObject: runtime.RawExtension{Raw: instanceWithParameters(test.oldParameters)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			assert.Contains(t, response.AdmissionResponse.Result.Reason, test.responseReason)
+		})
+	}
+}
+
+func instanceWithParameters(parameters string) []byte {
+	return []byte(`{
+		"metadata": {
+		  "name": "test-serviceinstance",
+		  "namespace": "ns-test"
+		},
+		"spec": {
+		  "clusterServiceClassExternalName": "csc-test",
+		  "clusterServicePlanExternalName": "csp-test",
+		  "clusterServiceClassRef": {
+		    "name": "csc-test"
+		  },
+		  "clusterServicePlanRef": {
+		    "name": "csp-test"
+		  },
+		  "parameters": ` + parameters + `
+		}
+	}`)
+}
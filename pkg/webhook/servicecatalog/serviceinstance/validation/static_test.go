@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/serviceinstance/validation"
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSpecValidationHandlerStaticCreatePlanReferences(t *testing.T) {
+	tester.DiscardLoggedMsg()
+
+	// given
+	err := sc.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		spec            string
+		responseAllowed bool
+		responseReasons []string
+	}{
+		"Cluster external names": {
+			spec:            `{"clusterServiceClassExternalName": "class", "clusterServicePlanExternalName": "plan"}`,
+			responseAllowed: true,
+		},
+		"Cluster external IDs": {
+			spec:            `{"clusterServiceClassExternalID": "class-id", "clusterServicePlanExternalID": "plan-id"}`,
+			responseAllowed: true,
+		},
+		"Cluster k8s names": {
+			spec:            `{"clusterServiceClassName": "class", "clusterServicePlanName": "plan"}`,
+			responseAllowed: true,
+		},
+		"Namespaced external names": {
+			spec:            `{"serviceClassExternalName": "class", "servicePlanExternalName": "plan"}`,
+			responseAllowed: true,
+		},
+		"Namespaced external IDs": {
+			spec:            `{"serviceClassExternalID": "class-id", "servicePlanExternalID": "plan-id"}`,
+			responseAllowed: true,
+		},
+		"Namespaced k8s names": {
+			spec:            `{"serviceClassName": "class", "servicePlanName": "plan"}`,
+			responseAllowed: true,
+		},
+		"Class without plan": {
+			spec:            `{"clusterServiceClassName": "class"}`,
+			responseAllowed: true,
+		},
+		"No class": {
+			spec:            `{}`,
+			responseAllowed: false,
+			responseReasons: []string{"plan references must have a class reference set"},
+		},
+		"Class external name and k8s name": {
+			spec:            `{"clusterServiceClassExternalName": "class", "clusterServiceClassName": "class", "clusterServicePlanExternalName": "plan"}`,
+			responseAllowed: false,
+			responseReasons: []string{
+				`spec.clusterServiceClassExternalName: Invalid value: "class"`,
+				`spec.clusterServiceClassName: Invalid value: "class"`,
+				"but clusterServiceClassExternalName, clusterServiceClassName are set",
+			},
+		},
+		"Class external name and external ID": {
+			spec:            `{"serviceClassExternalName": "class", "serviceClassExternalID": "class-id"}`,
+			responseAllowed: false,
+			responseReasons: []string{"but serviceClassExternalName, serviceClassExternalID are set"},
+		},
+		"Plan external name and k8s name": {
+			spec:            `{"serviceClassExternalName": "class", "servicePlanExternalName": "plan", "servicePlanName": "plan"}`,
+			responseAllowed: false,
+			responseReasons: []string{
+				`spec.servicePlanExternalName: Invalid value: "plan"`,
+				`spec.servicePlanName: Invalid value: "plan"`,
+				"but servicePlanExternalName, servicePlanName are set",
+			},
+		},
+		"Plan external ID and k8s name": {
+			spec:            `{"clusterServiceClassName": "class", "clusterServicePlanExternalID": "plan-id", "clusterServicePlanName": "plan"}`,
+			responseAllowed: false,
+			responseReasons: []string{"but clusterServicePlanExternalID, clusterServicePlanName are set"},
+		},
+		"Class external name with plan k8s name": {
+			spec:            `{"clusterServiceClassExternalName": "class", "clusterServicePlanName": "plan"}`,
+			responseAllowed: false,
+			responseReasons: []string{"must specify clusterServicePlanExternalName with clusterServiceClassExternalName"},
+		},
+		"Cluster class with namespaced plan": {
+			spec:            `{"clusterServiceClassName": "class", "servicePlanName": "plan"}`,
+			responseAllowed: false,
+			responseReasons: []string{"but not both; clusterServiceClassName, servicePlanName are set"},
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// given
+			handler := validation.SpecValidationHandler{}
+			handler.CreateValidators = []validation.Validator{&validation.StaticCreate{}}
+
+			err := handler.InjectDecoder(decoder)
+			require.NoError(t, err)
+
+			request := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					UID:       "uuid",
+					Name:      "test-serviceinstance",
+					Namespace: "ns-test",
+					Operation: admissionv1beta1.Create,
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceInstance",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+						"metadata": {"name": "test-serviceinstance", "namespace": "ns-test", "generation": 1},
+						"spec": ` + test.spec + `
+					}`)},
+				},
+			}
+
+			// when
+			response := handler.Handle(context.Background(), request)
+
+			// then
+			assert.Equal(t, test.responseAllowed, response.AdmissionResponse.Allowed)
+			for _, reason := range test.responseReasons {
+				assert.Contains(t, response.AdmissionResponse.Result.Reason, reason)
+			}
+		})
+	}
+}
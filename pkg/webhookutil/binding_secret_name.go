@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import (
+	"context"
+	"fmt"
+
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BindingSecretNameInUse returns what already uses the Secret name in the
+// namespace: an existing Secret, or a ServiceBinding other than
+// bindingNamespace/bindingName whose Secret is namespace/name. It returns ""
+// when the name is free. The bindings of all namespaces are checked only when
+// the Secret is not in the namespace of the binding, which is when another
+// namespace is most likely to use it too.
+func BindingSecretNameInUse(ctx context.Context, reader client.Reader, namespace, name, bindingNamespace, bindingName string) (string, error) {
+	err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.Secret{})
+	switch {
+	case err == nil:
+		return fmt.Sprintf("Secret %q already exists in namespace %q", name, namespace), nil
+	case !apierrors.IsNotFound(err):
+		return "", err
+	}
+
+	bindings := &sc.ServiceBindingList{}
+	var opts []client.ListOptionFunc
+	if namespace == bindingNamespace {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := reader.List(ctx, bindings, opts...); err != nil {
+		return "", err
+	}
+	for _, binding := range bindings.Items {
+		if binding.Namespace == bindingNamespace && binding.Name == bindingName {
+			continue
+		}
+		if binding.GetSecretNamespace() == namespace && binding.Spec.SecretName == name {
+			if binding.Namespace != namespace {
+				return fmt.Sprintf("Secret %q in namespace %q is already used by ServiceBinding %q in namespace %q", name, namespace, binding.Name, binding.Namespace), nil
+			}
+			return fmt.Sprintf("Secret %q is already used by ServiceBinding %q in namespace %q", name, binding.Name, namespace), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/brokerurl"
+)
+
+// ValidateBrokerURL returns an error when the URL of the kind of broker is
+// denied by policy. A nil policy allows every URL.
+func ValidateBrokerURL(kind, url string, policy *brokerurl.Policy) *WebhookError {
+	if err := policy.Check(url); err != nil {
+		msg := fmt.Sprintf("The spec.url of the %s is invalid: %v", kind, err)
+		return NewWebhookError(msg, http.StatusForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import "fmt"
+
+// ExternalIDCollisionPolicy is what the validating webhook does when a new
+// ServiceInstance has the external ID of an existing ServiceInstance.
+type ExternalIDCollisionPolicy string
+
+const (
+	// ExternalIDCollisionPolicyOff does not look for collisions.
+	ExternalIDCollisionPolicyOff ExternalIDCollisionPolicy = "Off"
+	// ExternalIDCollisionPolicyWarn logs a warning and admits the instance.
+	ExternalIDCollisionPolicyWarn ExternalIDCollisionPolicy = "Warn"
+	// ExternalIDCollisionPolicyDeny rejects the instance.
+	ExternalIDCollisionPolicyDeny ExternalIDCollisionPolicy = "Deny"
+)
+
+// DefaultExternalIDCollisionPolicy is the default policy for the external
+// IDs of new ServiceInstances.
+const DefaultExternalIDCollisionPolicy = ExternalIDCollisionPolicyDeny
+
+// ValidateExternalIDCollisionPolicy returns an error if the policy is not
+// one of the known policies.
+func ValidateExternalIDCollisionPolicy(policy ExternalIDCollisionPolicy) error {
+	switch policy {
+	case ExternalIDCollisionPolicyOff, ExternalIDCollisionPolicyWarn, ExternalIDCollisionPolicyDeny:
+		return nil
+	default:
+		return fmt.Errorf("invalid external ID collision policy %q, allowed values are: %v, %v, %v", policy, ExternalIDCollisionPolicyOff, ExternalIDCollisionPolicyWarn, ExternalIDCollisionPolicyDeny)
+	}
+}
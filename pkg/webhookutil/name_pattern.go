@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// NamePattern is a regular expression that the whole name of a kind of
+// object must match, for example to enforce the naming conventions of an
+// organization.
+type NamePattern struct {
+	expression string
+	regexp     *regexp.Regexp
+}
+
+// NewNamePattern compiles the regular expression of a NamePattern. The
+// expression is anchored, so that it has to match the whole name. An empty
+// expression returns a nil NamePattern, which admits all the names.
+func NewNamePattern(expression string) (*NamePattern, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile("^(?:" + expression + ")$")
+	if err != nil {
+		return nil, err
+	}
+	return &NamePattern{expression: expression, regexp: re}, nil
+}
+
+// Validate returns an error when the name of the kind of object does not
+// match the pattern. A nil NamePattern admits all the names.
+func (p *NamePattern) Validate(kind, name string) *WebhookError {
+	if p == nil || p.regexp.MatchString(name) {
+		return nil
+	}
+	msg := fmt.Sprintf("The name %q of the %s does not follow the naming convention: names of %ss must match the regular expression %q", name, kind, kind, p.expression)
+	return NewWebhookError(msg, http.StatusForbidden)
+}
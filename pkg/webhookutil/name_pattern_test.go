@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil_test
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamePatternValidate(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		name       string
+		allowed    bool
+	}{
+		"Should admit all names without a pattern": {
+			name:    "anything",
+			allowed: true,
+		},
+		"Should admit a matching name": {
+			expression: "(payments|search)-[a-z0-9-]+",
+			name:       "payments-db",
+			allowed:    true,
+		},
+		"Should reject a name without the prefix": {
+			expression: "(payments|search)-[a-z0-9-]+",
+			name:       "db",
+		},
+		"Should reject a name that only contains a match": {
+			expression: "payments-[a-z]+",
+			name:       "old-payments-db",
+		},
+		"Should anchor all the alternatives": {
+			expression: "payments-.*|search-.*",
+			name:       "db-search-",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			pattern, err := webhookutil.NewNamePattern(tc.expression)
+			require.NoError(t, err)
+
+			// when
+			webhookErr := pattern.Validate("ServiceInstance", tc.name)
+
+			// then
+			if tc.allowed {
+				assert.Nil(t, webhookErr)
+				return
+			}
+			require.NotNil(t, webhookErr)
+			assert.Equal(t, `The name "`+tc.name+`" of the ServiceInstance does not follow the naming convention: names of ServiceInstances must match the regular expression "`+tc.expression+`"`, webhookErr.Error())
+		})
+	}
+}
+
+func TestNewNamePatternInvalid(t *testing.T) {
+	// when
+	_, err := webhookutil.NewNamePattern("payments-(")
+
+	// then
+	assert.Error(t, err)
+}
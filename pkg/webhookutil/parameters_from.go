@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ParametersFromSecretPolicy is what the validating webhooks do when a
+// Secret referenced by the parameters of an object does not exist.
+type ParametersFromSecretPolicy string
+
+const (
+	// ParametersFromSecretPolicyOff does not check the referenced Secrets.
+	ParametersFromSecretPolicyOff ParametersFromSecretPolicy = "Off"
+	// ParametersFromSecretPolicyWarn logs a warning and admits the object,
+	// as the Secret may be created later.
+	ParametersFromSecretPolicyWarn ParametersFromSecretPolicy = "Warn"
+	// ParametersFromSecretPolicyDeny rejects the object.
+	ParametersFromSecretPolicyDeny ParametersFromSecretPolicy = "Deny"
+)
+
+// DefaultParametersFromSecretPolicy is the default policy for the Secrets
+// referenced by the parameters of an object.
+const DefaultParametersFromSecretPolicy = ParametersFromSecretPolicyWarn
+
+// ValidateParametersFromSecretPolicy returns an error if the policy is not
+// one of the known policies.
+func ValidateParametersFromSecretPolicy(policy ParametersFromSecretPolicy) error {
+	switch policy {
+	case ParametersFromSecretPolicyOff, ParametersFromSecretPolicyWarn, ParametersFromSecretPolicyDeny:
+		return nil
+	default:
+		return fmt.Errorf("invalid parametersFrom Secret policy %q, allowed values are: %v, %v, %v", policy, ParametersFromSecretPolicyOff, ParametersFromSecretPolicyWarn, ParametersFromSecretPolicyDeny)
+	}
+}
+
+// SecretReference is a Secret referenced by a field of an object.
+type SecretReference struct {
+	// Field is the path of the field holding the reference, such as
+	// spec.parametersFrom[0].secretKeyRef.
+	Field string
+	// Name is the name of the Secret.
+	Name string
+}
+
+// ValidateSecretReferencesExist checks that the Secrets referenced by the
+// kind of object exist in its namespace, and applies the policy to those
+// that do not. The check is best-effort: a Secret that can not be read, for
+// example for lack of permissions, neither warns nor rejects the object.
+func ValidateSecretReferencesExist(ctx context.Context, reader client.Reader, kind, namespace string, refs []SecretReference, policy ParametersFromSecretPolicy, traced *TracedLogger) *WebhookError {
+	if policy == ParametersFromSecretPolicyOff {
+		return nil
+	}
+
+	for _, ref := range refs {
+		key := types.NamespacedName{
+			Namespace: namespace,
+			Name:      ref.Name,
+		}
+		err := reader.Get(ctx, key, &corev1.Secret{})
+		switch {
+		case err == nil:
+			continue
+		case apierrors.IsNotFound(err):
+			msg := fmt.Sprintf("%s refers to Secret %q, which does not exist in namespace %q", ref.Field, ref.Name, namespace)
+			if policy == ParametersFromSecretPolicyDeny {
+				traced.Error(msg)
+				return NewWebhookError(msg, http.StatusForbidden)
+			}
+			traced.Infof("Warning: %s; the %s is admitted, but can not be reconciled until the Secret is created", msg, kind)
+		default:
+			traced.Infof("Could not check that Secret %q referenced by %s exists: %v", ref.Name, ref.Field, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultMaxParametersSize is the default maximum size in bytes of the
+// parameters of a ServiceInstance or a ServiceBinding. It is well under the
+// limit of etcd on the size of a whole object.
+const DefaultMaxParametersSize = 256 * 1024
+
+// ValidateParametersSize returns an error when the parameters of the kind of
+// object are larger than maxSize bytes. A maxSize of 0 disables the check.
+func ValidateParametersSize(kind string, parameters *runtime.RawExtension, maxSize int) *WebhookError {
+	if maxSize <= 0 || parameters == nil || len(parameters.Raw) <= maxSize {
+		return nil
+	}
+	msg := fmt.Sprintf("The spec.parameters of the %s are %d bytes, which exceeds the limit of %d bytes", kind, len(parameters.Raw), maxSize)
+	return NewWebhookError(msg, http.StatusForbidden)
+}
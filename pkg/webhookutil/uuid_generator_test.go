@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookutil_test
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TestUUIDGeneratorNewIsUnique tests that the default generator, which
+// generates the external IDs of instances that do not set one, does not
+// repeat itself.
+func TestUUIDGeneratorNewIsUnique(t *testing.T) {
+	// given
+	var generator webhookutil.UUIDGenerator
+	const count = 10000
+	seen := make(map[types.UID]bool, count)
+
+	for i := 0; i < count; i++ {
+		// when
+		id := generator.New()
+
+		// then
+		assert.Len(t, id, 36)
+		assert.False(t, seen[id], "UUID %q was generated twice", id)
+		seen[id] = true
+	}
+}
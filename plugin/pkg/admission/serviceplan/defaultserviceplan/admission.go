@@ -140,6 +140,8 @@ func (d *defaultServicePlan) handleDefaultClusterServicePlan(a admission.Attribu
 		instance.Spec.ClusterServicePlanExternalName = p.Spec.ExternalName
 	} else if instance.Spec.ClusterServiceClassExternalID != "" {
 		instance.Spec.ClusterServicePlanExternalID = p.Spec.ExternalID
+	} else if instance.Spec.ClusterServiceClassSelector != nil {
+		instance.Spec.ClusterServicePlanExternalName = p.Spec.ExternalName
 	} else {
 		instance.Spec.ClusterServicePlanName = p.Name
 	}
@@ -201,6 +203,8 @@ func (d *defaultServicePlan) handleDefaultServicePlan(a admission.Attributes, in
 		instance.Spec.ServicePlanExternalName = p.Spec.ExternalName
 	} else if instance.Spec.ServiceClassExternalID != "" {
 		instance.Spec.ServicePlanExternalID = p.Spec.ExternalID
+	} else if instance.Spec.ServiceClassSelector != nil {
+		instance.Spec.ServicePlanExternalName = p.Spec.ExternalName
 	} else {
 		instance.Spec.ServicePlanName = p.Name
 	}
@@ -238,6 +242,9 @@ func (d *defaultServicePlan) getClusterServiceClassByPlanReference(a admission.A
 	if ref.ClusterServiceClassName != "" {
 		return d.getClusterServiceClassByK8SName(a, ref.ClusterServiceClassName)
 	}
+	if ref.ClusterServiceClassSelector != nil {
+		return d.getClusterServiceClassBySelector(a, ref)
+	}
 
 	return d.getClusterServiceClassByField(a, ref)
 }
@@ -246,6 +253,9 @@ func (d *defaultServicePlan) getServiceClassByPlanReference(a admission.Attribut
 	if ref.ServiceClassName != "" {
 		return d.getServiceClassByK8SName(a, ref.ServiceClassName)
 	}
+	if ref.ServiceClassSelector != nil {
+		return d.getServiceClassBySelector(a, ref)
+	}
 
 	return d.getServiceClassByField(a, ref)
 }
@@ -260,6 +270,35 @@ func (d *defaultServicePlan) getServiceClassByK8SName(a admission.Attributes, sc
 	return d.scClient.Get(scK8SName, apimachineryv1.GetOptions{})
 }
 
+func (d *defaultServicePlan) getClusterServiceClassBySelector(a admission.Attributes, ref *servicecatalog.PlanReference) (*servicecatalog.ClusterServiceClass, error) {
+	selector, err := apimachineryv1.LabelSelectorAsSelector(ref.ClusterServiceClassSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("Fetching ClusterServiceClass selected by %q", selector)
+	serviceClasses, err := d.cscClient.List(apimachineryv1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		klog.V(4).Infof("Listing ClusterServiceClasses failed: %q", err)
+		return nil, err
+	}
+	var found []*servicecatalog.ClusterServiceClass
+	for i := range serviceClasses.Items {
+		if !serviceClasses.Items[i].Status.RemovedFromBrokerCatalog {
+			found = append(found, &serviceClasses.Items[i])
+		}
+	}
+	if len(found) == 1 {
+		klog.V(4).Infof("Found single ClusterServiceClass as %+v", *found[0])
+		return found[0], nil
+	}
+	msg := fmt.Sprintf("Could not find a single ClusterServiceClass selected by %q, found %v", selector, len(found))
+	klog.V(4).Info(msg)
+	return nil, admission.NewNotFound(a)
+}
+
 func (d *defaultServicePlan) getClusterServiceClassByField(a admission.Attributes, ref *servicecatalog.PlanReference) (*servicecatalog.ClusterServiceClass, error) {
 	filterLabel := ref.GetClusterServiceClassFilterLabelName()
 	filterValue := ref.GetSpecifiedClusterServiceClass()
@@ -286,6 +325,35 @@ func (d *defaultServicePlan) getClusterServiceClassByField(a admission.Attribute
 	return nil, admission.NewNotFound(a)
 }
 
+func (d *defaultServicePlan) getServiceClassBySelector(a admission.Attributes, ref *servicecatalog.PlanReference) (*servicecatalog.ServiceClass, error) {
+	selector, err := apimachineryv1.LabelSelectorAsSelector(ref.ServiceClassSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("Fetching ServiceClass selected by %q", selector)
+	serviceClasses, err := d.scClient.List(apimachineryv1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		klog.V(4).Infof("Listing ServiceClasses failed: %q", err)
+		return nil, err
+	}
+	var found []*servicecatalog.ServiceClass
+	for i := range serviceClasses.Items {
+		if !serviceClasses.Items[i].Status.RemovedFromBrokerCatalog {
+			found = append(found, &serviceClasses.Items[i])
+		}
+	}
+	if len(found) == 1 {
+		klog.V(4).Infof("Found single ServiceClass as %+v", *found[0])
+		return found[0], nil
+	}
+	msg := fmt.Sprintf("Could not find a single ServiceClass selected by %q, found %v", selector, len(found))
+	klog.V(4).Info(msg)
+	return nil, admission.NewNotFound(a)
+}
+
 func (d *defaultServicePlan) getServiceClassByField(a admission.Attributes, ref *servicecatalog.PlanReference) (*servicecatalog.ServiceClass, error) {
 	filterLabel := ref.GetServiceClassFilterLabelName()
 	filterValue := ref.GetSpecifiedServiceClass()
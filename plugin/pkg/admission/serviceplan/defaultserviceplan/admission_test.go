@@ -413,6 +413,7 @@ func TestWithNoPlanFailsWithNoClass(t *testing.T) {
 
 // checks that the defaulting action works when a service class only provides a single plan.
 func TestWithNoPlanWorksWithSinglePlan(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"database": "postgres"}}
 	cases := []struct {
 		name          string
 		requestedPlan servicecatalog.PlanReference
@@ -435,6 +436,12 @@ func TestWithNoPlanWorksWithSinglePlan(t *testing.T) {
 			servicecatalog.PlanReference{ServiceClassExternalID: "foo-id", ServicePlanExternalID: "12345"}, true},
 		{"ns k8s", servicecatalog.PlanReference{ServiceClassName: "foo-id"},
 			servicecatalog.PlanReference{ServiceClassName: "foo-id", ServicePlanName: "bar-id"}, true},
+		{"cluster selector",
+			servicecatalog.PlanReference{ClusterServiceClassSelector: selector},
+			servicecatalog.PlanReference{ClusterServiceClassSelector: selector, ClusterServicePlanExternalName: "bar"}, false},
+		{"ns selector",
+			servicecatalog.PlanReference{ServiceClassSelector: selector},
+			servicecatalog.PlanReference{ServiceClassSelector: selector, ServicePlanExternalName: "bar"}, true},
 	}
 
 	for _, tc := range cases {
@@ -442,11 +449,13 @@ func TestWithNoPlanWorksWithSinglePlan(t *testing.T) {
 			var fakeClient *fake.Clientset
 			if tc.namespaced {
 				sc := newServiceClass("foo-id", "foo")
+				sc.Labels["database"] = "postgres"
 				sps := newServicePlans("foo-id", 1, false)
 				klog.V(4).Infof("Created Service as %+v", sc)
 				fakeClient = newFakeServiceCatalogClientForNamespacedTest(sc, sps, "" /* do not use get */)
 			} else {
 				csc := newClusterServiceClass("foo-id", "foo")
+				csc.Labels["database"] = "postgres"
 				csps := newClusterServicePlans("foo-id", 1, false)
 				klog.V(4).Infof("Created Service as %+v", csc)
 				fakeClient = newFakeServiceCatalogClientForTest(csc, csps, "" /* do not use get */)
@@ -758,7 +758,7 @@ func newControllerTestTestController(ct *controllerTest) (
 	})
 
 	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	brokerClFunc := controller.NewBrokerClientCreateFunc(fakeosb.ReturnFakeClientFunc(fakeOSBClient))
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -775,6 +775,8 @@ func newControllerTestTestController(ct *controllerTest) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
+		coreInformers.V1().Namespaces(),
 		catalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -790,9 +792,34 @@ func newControllerTestTestController(ct *controllerTest) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		20*time.Minute,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		"",
+		controller.BindingSecretRetentionPolicyDelete,
+		0,
+		0,
+		"",
+		nil,
+		0,
+		0,
+		true,
+		true,
+		0,
+		"",
+		"",
+		0,
+		controller.ClassWithoutPlansPolicyReject,
+		controller.EmptyBindingCredentialsPolicyAllow,
+		0,
+		0,
+		"",
+		0,
+		nil,
+		nil,
+		0,
+		false,
 	)
 	t.Log("controller start")
 	if err != nil {
@@ -925,7 +952,7 @@ func newTestController(t *testing.T) (
 	})
 
 	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	brokerClFunc := controller.NewBrokerClientCreateFunc(fakeosb.ReturnFakeClientFunc(fakeOSBClient))
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -942,6 +969,8 @@ func newTestController(t *testing.T) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
+		coreInformers.V1().Namespaces(),
 		catalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -957,9 +986,34 @@ func newTestController(t *testing.T) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		20*time.Minute,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		"",
+		controller.BindingSecretRetentionPolicyDelete,
+		0,
+		0,
+		"",
+		nil,
+		0,
+		0,
+		true,
+		true,
+		0,
+		"",
+		"",
+		0,
+		controller.ClassWithoutPlansPolicyReject,
+		controller.EmptyBindingCredentialsPolicyAllow,
+		0,
+		0,
+		"",
+		0,
+		nil,
+		nil,
+		0,
+		false,
 	)
 	t.Log("controller start")
 	if err != nil {